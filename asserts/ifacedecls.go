@@ -27,6 +27,7 @@ import (
 	"unicode"
 
 	"github.com/snapcore/snapd/snap/naming"
+	"github.com/snapcore/snapd/strutil"
 )
 
 // AttrMatchContext has contextual helpers for evaluating attribute constraints.
@@ -44,6 +45,8 @@ const (
 	deviceScopeConstraintsFeature = "device-scope-constraints"
 	// feature label for plug-names/slot-names constraints
 	nameConstraintsFeature = "name-constraints"
+	// feature label for $BRAND in plug-publisher-id/slot-publisher-id
+	brandPublisherIDFeature = "brand-publisher-id"
 )
 
 // AttributeConstraints implements a set of constraints on the attributes of a slot or plug.
@@ -757,6 +760,9 @@ func (c *PlugConnectionConstraints) feature(flabel string) bool {
 	if flabel == nameConstraintsFeature {
 		return c.PlugNames != nil || c.SlotNames != nil
 	}
+	if flabel == brandPublisherIDFeature {
+		return strutil.ListContains(c.SlotPublisherIDs, "$BRAND")
+	}
 	return c.PlugAttributes.feature(flabel) || c.SlotAttributes.feature(flabel)
 }
 
@@ -1090,6 +1096,9 @@ func (c *SlotConnectionConstraints) feature(flabel string) bool {
 	if flabel == nameConstraintsFeature {
 		return c.PlugNames != nil || c.SlotNames != nil
 	}
+	if flabel == brandPublisherIDFeature {
+		return strutil.ListContains(c.PlugPublisherIDs, "$BRAND")
+	}
 	return c.PlugAttributes.feature(flabel) || c.SlotAttributes.feature(flabel)
 }
 
@@ -201,6 +201,9 @@ func snapDeclarationFormatAnalyze(headers map[string]any, body []byte) (formatnu
 		if rule.feature(publisherIDConstraintsFeature) {
 			setFormatNum(6)
 		}
+		if rule.feature(brandPublisherIDFeature) {
+			setFormatNum(7)
+		}
 	})
 	if err != nil {
 		return 0, err
@@ -226,6 +229,9 @@ func snapDeclarationFormatAnalyze(headers map[string]any, body []byte) (formatnu
 		if rule.feature(publisherIDConstraintsFeature) {
 			setFormatNum(6)
 		}
+		if rule.feature(brandPublisherIDFeature) {
+			setFormatNum(7)
+		}
 	})
 	if err != nil {
 		return 0, err
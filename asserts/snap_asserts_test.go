@@ -735,6 +735,22 @@ func (sds *snapDeclSuite) TestSuggestedFormat(c *C) {
 			c.Check(fmtnum, Equals, 6)
 		}
 	}
+
+	// $BRAND in publisher id constraints => format 7
+	for _, side := range []struct{ sidePrefix, otherPrefix string }{{"plug", "slot"}, {"slot", "plug"}} {
+		headers = map[string]any{
+			side.sidePrefix + "s": map[string]any{
+				"interface7": map[string]any{
+					"allow-auto-connection": map[string]any{
+						side.otherPrefix + "-publisher-id": []any{"$BRAND"},
+					},
+				},
+			},
+		}
+		fmtnum, err = asserts.SuggestFormat(asserts.SnapDeclarationType, headers, nil)
+		c.Assert(err, IsNil)
+		c.Check(fmtnum, Equals, 7)
+	}
 }
 
 func prereqDevAccount(c *C, storeDB assertstest.SignerDB, db *asserts.Database) {
@@ -781,6 +781,17 @@ func (client *Client) DebugGet(aspect string, result any, params map[string]stri
 	return err
 }
 
+// DebugDenialStats fills result with the statistics of the AppArmor and
+// seccomp denials of the given snap, or of all snaps when snapName is empty.
+func (client *Client) DebugDenialStats(snapName string, result any) error {
+	var query url.Values
+	if snapName != "" {
+		query = url.Values{"snap": []string{snapName}}
+	}
+	_, err := client.doSync("GET", "/v2/debug/denial-stats", query, nil, nil, &result)
+	return err
+}
+
 type SystemRecoveryKeysResponse struct {
 	RecoveryKey  string `json:"recovery-key"`
 	ReinstallKey string `json:"reinstall-key,omitempty"`
@@ -646,6 +646,24 @@ func (cs *clientSuite) TestDebugGet(c *C) {
 	c.Check(cs.reqs[0].URL.Query(), DeepEquals, url.Values{"aspect": []string{"do-something"}, "foo": []string{"bar"}})
 }
 
+func (cs *clientSuite) TestDebugDenialStats(c *C) {
+	cs.rsp = `{"type": "sync", "result":[{"snap":"foo","count":1}]}`
+
+	var result []map[string]any
+	err := cs.cli.DebugDenialStats("foo", &result)
+	c.Check(err, IsNil)
+	c.Check(result, DeepEquals, []map[string]any{{"snap": "foo", "count": json.Number("1")}})
+	c.Check(cs.reqs, HasLen, 1)
+	c.Check(cs.reqs[0].Method, Equals, "GET")
+	c.Check(cs.reqs[0].URL.Path, Equals, "/v2/debug/denial-stats")
+	c.Check(cs.reqs[0].URL.Query(), DeepEquals, url.Values{"snap": []string{"foo"}})
+
+	err = cs.cli.DebugDenialStats("", &result)
+	c.Check(err, IsNil)
+	c.Check(cs.reqs, HasLen, 2)
+	c.Check(cs.reqs[1].URL.RawQuery, Equals, "")
+}
+
 func (cs *clientSuite) TestDebugMigrateHome(c *C) {
 	cs.status = 202
 	cs.rsp = `{"type": "async", "status-code": 202, "change": "123"}`
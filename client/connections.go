@@ -21,6 +21,7 @@ package client
 
 import (
 	"net/url"
+	"strconv"
 )
 
 // Connection describes a connection between a plug and a slot.
@@ -32,6 +33,9 @@ type Connection struct {
 	Manual bool `json:"manual"`
 	// Gadget is set for connections that were enabled by the gadget snap.
 	Gadget bool `json:"gadget"`
+	// Audit is set for connections whose plug side rules log the accesses
+	// and syscalls they allow.
+	Audit bool `json:"audit,omitempty"`
 	// SlotAttrs is the list of attributes of the slot side of the connection.
 	SlotAttrs map[string]any `json:"slot-attrs,omitempty"`
 	// PlugAttrs is the list of attributes of the plug side of the connection.
@@ -47,6 +51,10 @@ type Connections struct {
 	Undesired []Connection `json:"undesired"`
 	Plugs     []Plug       `json:"plugs"`
 	Slots     []Slot       `json:"slots"`
+	// Next is the ID of the last returned established connection, set
+	// when more connections can be obtained by passing it as
+	// ConnectionOptions.After.
+	Next string `json:"next,omitempty"`
 }
 
 // ConnectionOptions contains criteria for selecting matching connections, plugs
@@ -60,6 +68,18 @@ type ConnectionOptions struct {
 	// All when true, selects established and undesired connections as well
 	// as all disconnected plugs and slots.
 	All bool
+	// SecurityLevel selects connections, plugs or slots using interfaces
+	// of the given security level, one of "low", "medium" or "high".
+	SecurityLevel string
+	// Origin selects connections established automatically ("auto") or
+	// manually ("manual").
+	Origin string
+	// After selects the established connections following the connection
+	// with the given ID, as returned in Connections.Next.
+	After string
+	// Limit is the maximum number of established connections returned
+	// when non-zero.
+	Limit int
 }
 
 // Connections returns matching plugs, slots and their connections. Unless
@@ -76,6 +96,18 @@ func (client *Client) Connections(opts *ConnectionOptions) (Connections, error)
 	if opts != nil && opts.All {
 		query.Set("select", "all")
 	}
+	if opts != nil && opts.SecurityLevel != "" {
+		query.Set("security-level", opts.SecurityLevel)
+	}
+	if opts != nil && opts.Origin != "" {
+		query.Set("origin", opts.Origin)
+	}
+	if opts != nil && opts.After != "" {
+		query.Set("after", opts.After)
+	}
+	if opts != nil && opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
 	_, err := client.doSync("GET", "/v2/connections", query, nil, nil, &conns)
 	return conns, err
 }
@@ -267,4 +267,30 @@ func (cs *clientSuite) TestClientConnectionsFilter(c *check.C) {
 		"interface": []string{"test"},
 		"snap":      []string{"foo"},
 	})
+
+	_, err = cs.cli.Connections(&client.ConnectionOptions{SecurityLevel: "high", Origin: "manual", After: "a:plug b:slot", Limit: 10})
+	c.Assert(err, check.IsNil)
+	query = cs.req.URL.Query()
+	c.Check(query, check.DeepEquals, url.Values{
+		"security-level": []string{"high"},
+		"origin":         []string{"manual"},
+		"after":          []string{"a:plug b:slot"},
+		"limit":          []string{"10"},
+	})
+}
+
+func (cs *clientSuite) TestClientConnectionsNext(c *check.C) {
+	cs.rsp = `{
+		"type": "sync",
+		"result": {
+			"established": [],
+			"plugs": [],
+			"slots": [],
+			"next": "a:plug b:slot"
+		}
+	}`
+
+	conns, err := cs.cli.Connections(&client.ConnectionOptions{Limit: 1})
+	c.Assert(err, check.IsNil)
+	c.Check(conns.Next, check.Equals, "a:plug b:slot")
 }
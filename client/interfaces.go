@@ -62,21 +62,71 @@ type SlotRef struct {
 
 // Interface holds information about a given interface and its instances.
 type Interface struct {
-	Name    string `json:"name,omitempty"`
-	Summary string `json:"summary,omitempty"`
-	DocURL  string `json:"doc-url,omitempty"`
-	Plugs   []Plug `json:"plugs,omitempty"`
-	Slots   []Slot `json:"slots,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	Summary       string   `json:"summary,omitempty"`
+	DocURL        string   `json:"doc-url,omitempty"`
+	SecurityNotes []string `json:"security-notes,omitempty"`
+	// SecurityLevel is one of "low", "medium" or "high", depending on
+	// whether the interface is connected automatically, must be connected
+	// manually or is super-privileged.
+	SecurityLevel string          `json:"security-level,omitempty"`
+	Example       string          `json:"example,omitempty"`
+	PlugAttrs     []AttributeSpec `json:"plug-attrs,omitempty"`
+	SlotAttrs     []AttributeSpec `json:"slot-attrs,omitempty"`
+	Plugs         []Plug          `json:"plugs,omitempty"`
+	Slots         []Slot          `json:"slots,omitempty"`
+}
+
+// AttributeSpec documents an attribute supported by the plugs or slots of an
+// interface.
+type AttributeSpec struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required,omitempty"`
+	Default     any    `json:"default,omitempty"`
+	Allowed     []any  `json:"allowed,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 // InterfaceAction represents an action performed on the interface system.
 type InterfaceAction struct {
 	Action string `json:"action"`
 	Forget bool   `json:"forget,omitempty"`
+	Audit  bool   `json:"audit,omitempty"`
+	DryRun bool   `json:"dry-run,omitempty"`
 	Plugs  []Plug `json:"plugs,omitempty"`
 	Slots  []Slot `json:"slots,omitempty"`
 }
 
+// ConnectionPolicy is the security policy which connecting a plug to a slot
+// adds to their snaps.
+type ConnectionPolicy struct {
+	Interface string  `json:"interface"`
+	Plug      PlugRef `json:"plug"`
+	Slot      SlotRef `json:"slot"`
+	// Connected is set when the plug is already connected to the slot.
+	Connected bool            `json:"connected,omitempty"`
+	Policies  []BackendPolicy `json:"policies"`
+}
+
+// BackendPolicy is the security policy generated by a security backend,
+// broken down by the plugs, slots and connections contributing to it.
+type BackendPolicy struct {
+	Backend string       `json:"backend"`
+	Parts   []PolicyPart `json:"parts"`
+}
+
+// PolicyPart is the security policy contributed by a plug, a slot or, when
+// both are set, a connection.
+type PolicyPart struct {
+	Interface string   `json:"interface"`
+	Plug      *PlugRef `json:"plug,omitempty"`
+	Slot      *SlotRef `json:"slot,omitempty"`
+	// Snippets are keyed by the security tag of the app or hook they
+	// apply to, or by the snap name when not specific to an app or hook.
+	Snippets map[string][]string `json:"snippets"`
+}
+
 // InterfaceOptions represents opt-in elements include in responses.
 type InterfaceOptions struct {
 	Names     []string
@@ -91,6 +141,12 @@ type DisconnectOptions struct {
 	Forget bool
 }
 
+// ConnectOptions represents extra options for connect op
+type ConnectOptions struct {
+	// Audit logs the accesses and syscalls allowed by the rules of the plug
+	Audit bool
+}
+
 func (client *Client) Interfaces(opts *InterfaceOptions) ([]*Interface, error) {
 	query := url.Values{}
 	if opts != nil && len(opts.Names) > 0 {
@@ -130,14 +186,44 @@ func (client *Client) performInterfaceAction(sa *InterfaceAction) (changeID stri
 
 // Connect establishes a connection between a plug and a slot.
 // The plug and the slot must have the same interface.
-func (client *Client) Connect(plugSnapName, plugName, slotSnapName, slotName string) (changeID string, err error) {
+func (client *Client) Connect(plugSnapName, plugName, slotSnapName, slotName string, opts *ConnectOptions) (changeID string, err error) {
 	return client.performInterfaceAction(&InterfaceAction{
 		Action: "connect",
+		Audit:  opts != nil && opts.Audit,
 		Plugs:  []Plug{{Snap: plugSnapName, Name: plugName}},
 		Slots:  []Slot{{Snap: slotSnapName, Name: slotName}},
 	})
 }
 
+// ConnectDryRun returns the security policy which connecting the plug to
+// the slot would add to their snaps, without connecting them.
+func (client *Client) ConnectDryRun(plugSnapName, plugName, slotSnapName, slotName string) (*ConnectionPolicy, error) {
+	b, err := json.Marshal(&InterfaceAction{
+		Action: "connect",
+		DryRun: true,
+		Plugs:  []Plug{{Snap: plugSnapName, Name: plugName}},
+		Slots:  []Slot{{Snap: slotSnapName, Name: slotName}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var connPolicy ConnectionPolicy
+	if _, err := client.doSync("POST", "/v2/interfaces", nil, nil, bytes.NewReader(b), &connPolicy); err != nil {
+		return nil, err
+	}
+	return &connPolicy, nil
+}
+
+// UpdateConnectionAttrs sets dynamic attributes of the plug and slot of an
+// established connection.
+func (client *Client) UpdateConnectionAttrs(plugSnapName, plugName, slotSnapName, slotName string, plugAttrs, slotAttrs map[string]any) (changeID string, err error) {
+	return client.performInterfaceAction(&InterfaceAction{
+		Action: "update-attrs",
+		Plugs:  []Plug{{Snap: plugSnapName, Name: plugName, Attrs: plugAttrs}},
+		Slots:  []Slot{{Snap: slotSnapName, Name: slotName, Attrs: slotAttrs}},
+	})
+}
+
 // Disconnect breaks the connection between a plug and a slot.
 func (client *Client) Disconnect(plugSnapName, plugName, slotSnapName, slotName string, opts *DisconnectOptions) (changeID string, err error) {
 	return client.performInterfaceAction(&InterfaceAction{
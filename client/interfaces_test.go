@@ -102,6 +102,21 @@ func (cs *clientSuite) TestClientInterfacesSelectedDetails(c *check.C) {
 				"name": "iface-a",
 				"summary": "the A iface",
 				"doc-url": "http://example.org/ifaces/a",
+				"security-notes": ["a note"],
+				"security-level": "medium",
+				"example": "plugs:\n  iface-a:\n",
+				"plug-attrs": [{
+					"name": "write",
+					"type": "bool",
+					"default": false,
+					"description": "grant write access"
+				}],
+				"slot-attrs": [{
+					"name": "mode",
+					"type": "string",
+					"required": true,
+					"allowed": ["a", "b"]
+				}],
 				"plugs": [{
 					"snap": "consumer",
 					"plug": "plug",
@@ -126,11 +141,16 @@ func (cs *clientSuite) TestClientInterfacesSelectedDetails(c *check.C) {
 	c.Assert(err, check.IsNil)
 	c.Check(ifaces, check.DeepEquals, []*client.Interface{
 		{
-			Name:    "iface-a",
-			Summary: "the A iface",
-			DocURL:  "http://example.org/ifaces/a",
-			Plugs:   []client.Plug{{Snap: "consumer", Name: "plug", Interface: "iface-a"}},
-			Slots:   []client.Slot{{Snap: "producer", Name: "slot", Interface: "iface-a"}},
+			Name:          "iface-a",
+			Summary:       "the A iface",
+			DocURL:        "http://example.org/ifaces/a",
+			SecurityNotes: []string{"a note"},
+			SecurityLevel: "medium",
+			Example:       "plugs:\n  iface-a:\n",
+			PlugAttrs:     []client.AttributeSpec{{Name: "write", Type: "bool", Default: false, Description: "grant write access"}},
+			SlotAttrs:     []client.AttributeSpec{{Name: "mode", Type: "string", Required: true, Allowed: []any{"a", "b"}}},
+			Plugs:         []client.Plug{{Snap: "consumer", Name: "plug", Interface: "iface-a"}},
+			Slots:         []client.Slot{{Snap: "producer", Name: "slot", Interface: "iface-a"}},
 		},
 	})
 }
@@ -157,7 +177,7 @@ func (cs *clientSuite) TestClientInterfacesMultiple(c *check.C) {
 }
 
 func (cs *clientSuite) TestClientConnectCallsEndpoint(c *check.C) {
-	cs.cli.Connect("producer", "plug", "consumer", "slot")
+	cs.cli.Connect("producer", "plug", "consumer", "slot", nil)
 	c.Check(cs.req.Method, check.Equals, "POST")
 	c.Check(cs.req.URL.Path, check.Equals, "/v2/interfaces")
 }
@@ -170,7 +190,7 @@ func (cs *clientSuite) TestClientConnect(c *check.C) {
 		"result": { },
                 "change": "foo"
 	}`
-	id, err := cs.cli.Connect("producer", "plug", "consumer", "slot")
+	id, err := cs.cli.Connect("producer", "plug", "consumer", "slot", nil)
 	c.Assert(err, check.IsNil)
 	c.Check(id, check.Equals, "foo")
 	var body map[string]any
@@ -194,6 +214,132 @@ func (cs *clientSuite) TestClientConnect(c *check.C) {
 	})
 }
 
+func (cs *clientSuite) TestClientConnectAudit(c *check.C) {
+	cs.status = 202
+	cs.rsp = `{
+		"type": "async",
+		"status-code": 202,
+		"result": { },
+		"change": "foo"
+	}`
+	id, err := cs.cli.Connect("producer", "plug", "consumer", "slot", &client.ConnectOptions{Audit: true})
+	c.Assert(err, check.IsNil)
+	c.Check(id, check.Equals, "foo")
+	var body map[string]any
+	decoder := json.NewDecoder(cs.req.Body)
+	err = decoder.Decode(&body)
+	c.Check(err, check.IsNil)
+	c.Check(body, check.DeepEquals, map[string]any{
+		"action": "connect",
+		"audit":  true,
+		"plugs": []any{
+			map[string]any{
+				"snap": "producer",
+				"plug": "plug",
+			},
+		},
+		"slots": []any{
+			map[string]any{
+				"snap": "consumer",
+				"slot": "slot",
+			},
+		},
+	})
+}
+
+func (cs *clientSuite) TestClientConnectDryRun(c *check.C) {
+	cs.rsp = `{
+		"type": "sync",
+		"result": {
+			"interface": "test",
+			"plug": {"snap": "producer", "plug": "plug"},
+			"slot": {"snap": "consumer", "slot": "slot"},
+			"policies": [{
+				"backend": "apparmor",
+				"parts": [{
+					"interface": "test",
+					"plug": {"snap": "producer", "plug": "plug"},
+					"slot": {"snap": "consumer", "slot": "slot"},
+					"snippets": {"snap.producer.app": ["/dev/foo rw,"]}
+				}]
+			}]
+		}
+	}`
+	connPolicy, err := cs.cli.ConnectDryRun("producer", "plug", "consumer", "slot")
+	c.Assert(err, check.IsNil)
+	c.Check(cs.req.Method, check.Equals, "POST")
+	c.Check(cs.req.URL.Path, check.Equals, "/v2/interfaces")
+	c.Check(connPolicy, check.DeepEquals, &client.ConnectionPolicy{
+		Interface: "test",
+		Plug:      client.PlugRef{Snap: "producer", Name: "plug"},
+		Slot:      client.SlotRef{Snap: "consumer", Name: "slot"},
+		Policies: []client.BackendPolicy{{
+			Backend: "apparmor",
+			Parts: []client.PolicyPart{{
+				Interface: "test",
+				Plug:      &client.PlugRef{Snap: "producer", Name: "plug"},
+				Slot:      &client.SlotRef{Snap: "consumer", Name: "slot"},
+				Snippets:  map[string][]string{"snap.producer.app": {"/dev/foo rw,"}},
+			}},
+		}},
+	})
+	var body map[string]any
+	decoder := json.NewDecoder(cs.req.Body)
+	err = decoder.Decode(&body)
+	c.Check(err, check.IsNil)
+	c.Check(body, check.DeepEquals, map[string]any{
+		"action":  "connect",
+		"dry-run": true,
+		"plugs": []any{
+			map[string]any{
+				"snap": "producer",
+				"plug": "plug",
+			},
+		},
+		"slots": []any{
+			map[string]any{
+				"snap": "consumer",
+				"slot": "slot",
+			},
+		},
+	})
+}
+
+func (cs *clientSuite) TestClientUpdateConnectionAttrs(c *check.C) {
+	cs.status = 202
+	cs.rsp = `{
+		"type": "async",
+		"status-code": 202,
+		"result": { },
+		"change": "foo"
+	}`
+	id, err := cs.cli.UpdateConnectionAttrs("producer", "plug", "consumer", "slot", map[string]any{"path": "/foo"}, nil)
+	c.Assert(err, check.IsNil)
+	c.Check(id, check.Equals, "foo")
+	c.Check(cs.req.Method, check.Equals, "POST")
+	c.Check(cs.req.URL.Path, check.Equals, "/v2/interfaces")
+	var body map[string]any
+	decoder := json.NewDecoder(cs.req.Body)
+	err = decoder.Decode(&body)
+	c.Check(err, check.IsNil)
+	c.Check(body, check.DeepEquals, map[string]any{
+		"action": "update-attrs",
+		"plugs": []any{
+			map[string]any{
+				"snap":  "producer",
+				"plug":  "plug",
+				"attrs": map[string]any{"path": "/foo"},
+			},
+		},
+		"slots": []any{
+			map[string]any{
+				"snap": "consumer",
+				"slot": "slot",
+			},
+		},
+	})
+}
+
 func (cs *clientSuite) TestClientDisconnectCallsEndpoint(c *check.C) {
 	cs.cli.Disconnect("producer", "plug", "consumer", "slot", nil)
 	c.Check(cs.req.Method, check.Equals, "POST")
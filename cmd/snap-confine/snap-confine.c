@@ -718,6 +718,9 @@ static const size_t DEVICES_FILE_MAX_WAIT = 120;
 struct sc_device_cgroup_options {
     bool self_managed;
     bool non_strict;
+    /* comma separated list of devices allowed for the security tag, owned by
+     * the caller */
+    char *allowed_devices;
 };
 
 static void sc_get_device_cgroup_setup(const sc_invocation *inv, struct sc_device_cgroup_options *devsetup) {
@@ -753,8 +756,18 @@ static void sc_get_device_cgroup_setup(const sc_invocation *inv, struct sc_devic
         sc_die_on_error(err);
     }
 
+    rewind(stream);
+
+    char allowed_devices_key[PATH_MAX] = {0};
+    sc_must_snprintf(allowed_devices_key, sizeof allowed_devices_key, "allow-devices.%s", inv->security_tag);
+    char *allowed_devices_value = NULL;
+    if (sc_infofile_get_key(stream, allowed_devices_key, &allowed_devices_value, &err) < 0) {
+        sc_die_on_error(err);
+    }
+
     devsetup->self_managed = sc_streq(self_managed_value, "true");
     devsetup->non_strict = sc_streq(non_strict_value, "true");
+    devsetup->allowed_devices = allowed_devices_value;
 }
 
 static sc_device_cgroup_mode device_cgroup_mode_for_snap(sc_invocation *inv) {
@@ -821,8 +834,9 @@ static void enter_non_classic_execution_environment(sc_invocation *inv, struct s
     } else {
         // Set up a device cgroup, unless the snap has been allowed to manage the
         // device cgroup by itself.
-        struct sc_device_cgroup_options cgdevopts = {false, false};
+        struct sc_device_cgroup_options cgdevopts = {false, false, NULL};
         sc_get_device_cgroup_setup(inv, &cgdevopts);
+        char *allowed_devices SC_CLEANUP(sc_cleanup_string) = cgdevopts.allowed_devices;
 
         if (cgdevopts.self_managed) {
             debug("device cgroup is self-managed by the snap");
@@ -830,7 +844,7 @@ static void enter_non_classic_execution_environment(sc_invocation *inv, struct s
             debug("device cgroup skipped, snap in non-strict confinement");
         } else {
             sc_device_cgroup_mode mode = device_cgroup_mode_for_snap(inv);
-            sc_setup_device_cgroup(inv->security_tag, mode);
+            sc_setup_device_cgroup(inv->security_tag, mode, allowed_devices);
         }
     }
 
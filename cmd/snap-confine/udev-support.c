@@ -21,7 +21,9 @@
 #include <errno.h>
 #include <fcntl.h>
 #include <limits.h>
+#include <stdbool.h>
 #include <stdint.h>
+#include <stdio.h>
 #include <stdlib.h>
 #include <string.h>
 #include <sys/stat.h>
@@ -177,6 +179,50 @@ static void sc_udev_allow_assigned_device(sc_device_cgroup *cgroup, struct udev_
     }
 }
 
+/**
+ * Allow access to devices explicitly listed by snapd.
+ *
+ * The snapd udev security backend lists the devices which interfaces allow
+ * for a given security tag, as a comma separated list of entries in the device
+ * cgroup notation, eg. "c 10:229 rwm,b 7:* rwm". Only the "rwm" access is
+ * supported.
+ **/
+static void sc_udev_allow_listed_devices(sc_device_cgroup *cgroup, const char *allowed_devices) {
+    char *devices SC_CLEANUP(sc_cleanup_string) = sc_strdup(allowed_devices);
+    char *saveptr = NULL;
+    for (char *rule = strtok_r(devices, ",", &saveptr); rule != NULL; rule = strtok_r(NULL, ",", &saveptr)) {
+        char type = 0;
+        unsigned int major = 0;
+        unsigned int minor = 0;
+        char minor_str[11] = {0};
+        char access[4] = {0};
+        int consumed = 0;
+        if (sscanf(rule, "%c %u:%10[0-9*] %3s%n", &type, &major, minor_str, access, &consumed) != 4 ||
+            rule[consumed] != '\0') {
+            die("cannot parse allowed device entry \"%s\"", rule);
+        }
+        if (type != 'c' && type != 'b') {
+            die("invalid device type in allowed device entry \"%s\"", rule);
+        }
+        if (!sc_streq(access, "rwm")) {
+            die("unsupported access in allowed device entry \"%s\"", rule);
+        }
+        if (sc_streq(minor_str, "*")) {
+            minor = SC_DEVICE_MINOR_ANY;
+        } else {
+            char *end = NULL;
+            errno = 0;
+            unsigned long value = strtoul(minor_str, &end, 10);
+            if (errno != 0 || *end != '\0' || value >= SC_DEVICE_MINOR_ANY) {
+                die("invalid minor number in allowed device entry \"%s\"", rule);
+            }
+            minor = (unsigned int)value;
+        }
+        debug("allowing listed device %c %u:%s", type, major, minor_str);
+        sc_device_cgroup_allow(cgroup, type == 'c' ? S_IFCHR : S_IFBLK, major, minor);
+    }
+}
+
 static void sc_udev_setup_acls_common(sc_device_cgroup *cgroup) {
     /* Allow access to various devices. */
     sc_udev_allow_common(cgroup);
@@ -242,7 +288,7 @@ static void setup_current_tags_support(void) {
     /* coverity[leaked_storage] */
 }
 
-void sc_setup_device_cgroup(const char *security_tag, sc_device_cgroup_mode mode) {
+void sc_setup_device_cgroup(const char *security_tag, sc_device_cgroup_mode mode, const char *allowed_devices) {
     debug("setting up device cgroup, mode \"%s\"", mode == SC_DEVICE_CGROUP_MODE_REQUIRED ? "required" : "optional");
 
     setup_current_tags_support();
@@ -280,6 +326,11 @@ void sc_setup_device_cgroup(const char *security_tag, sc_device_cgroup_mode mode
     /* NOTE: udev_list_entry is bound to life-cycle of the used udev_enumerate */
     struct udev_list_entry *assigned;
     assigned = udev_enumerate_get_list_entry(devices);
+    bool has_listed_devices = allowed_devices != NULL && allowed_devices[0] != '\0';
+    if (has_listed_devices) {
+        /* devices explicitly allowed by snapd always need a device cgroup */
+        mode = SC_DEVICE_CGROUP_MODE_REQUIRED;
+    }
     if (assigned == NULL) {
         if (mode == SC_DEVICE_CGROUP_MODE_OPTIONAL) {
             /* NOTE: Nothing is assigned, don't create or use the device cgroup. */
@@ -303,6 +354,9 @@ void sc_setup_device_cgroup(const char *security_tag, sc_device_cgroup_mode mode
         cgroup = sc_device_cgroup_new(security_tag, 0);
         /* Setup the device group access control list */
         sc_udev_setup_acls_common(cgroup);
+        if (has_listed_devices) {
+            sc_udev_allow_listed_devices(cgroup, allowed_devices);
+        }
     }
 
     for (struct udev_list_entry *entry = assigned; entry != NULL; entry = udev_list_entry_get_next(entry)) {
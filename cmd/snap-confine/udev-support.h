@@ -26,6 +26,12 @@ typedef enum {
     SC_DEVICE_CGROUP_MODE_OPTIONAL = 0x1,
 } sc_device_cgroup_mode;
 
-void sc_setup_device_cgroup(const char *security_tag, sc_device_cgroup_mode mode);
+/**
+ * sc_setup_device_cgroup sets up and joins the device cgroup of the given
+ * security tag. The devices tagged by udev are allowed, along with the
+ * optional, comma separated, list of devices in the device cgroup notation
+ * (eg. "c 10:229 rwm,b 7:* rwm") which snapd writes for the security tag.
+ **/
+void sc_setup_device_cgroup(const char *security_tag, sc_device_cgroup_mode mode, const char *allowed_devices);
 
 #endif
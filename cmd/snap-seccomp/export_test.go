@@ -27,11 +27,13 @@ import (
 
 var (
 	Compile           = compile
+	CompileForArch    = compileForArch
 	SeccompResolver   = seccompResolver
 	VersionInfo       = versionInfo
 	GoSeccompFeatures = goSeccompFeatures
 	ExportBPF         = exportBPF
 	Dump              = dump
+	ShowSyscallName   = showSyscallName
 )
 
 func MockArchDpkgArchitecture(f func() string) (restore func()) {
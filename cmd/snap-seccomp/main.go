@@ -76,6 +76,11 @@ package main
 //#define PF_QIPCRTR AF_QIPCRTR
 //#endif				// AF_QIPCRTR
 //
+//#ifndef AF_SMC
+//#define AF_SMC 43
+//#define PF_SMC AF_SMC
+//#endif				// AF_SMC
+//
 //#ifndef AF_XDP
 //#define AF_XDP 44
 //#define PF_XDP AF_XDP
@@ -190,6 +195,598 @@ package main
 // #define TIOCLINUX 0x541C
 // #endif
 //
+// /* Define the NVMe admin passthrough ioctls, from linux/nvme_ioctl.h which
+//    is not available everywhere */
+// #ifndef NVME_IOCTL_ADMIN_CMD
+// #define NVME_IOCTL_ADMIN_CMD 0xC0484E41
+// #endif
+// #ifndef NVME_IOCTL_ADMIN64_CMD
+// #define NVME_IOCTL_ADMIN64_CMD 0xC0504E47
+// #endif
+//
+// /* Define the device-mapper ioctls, from linux/dm-ioctl.h which is not
+//    available everywhere. They are _IOWR(DM_IOCTL, nr, struct dm_ioctl),
+//    the structure being 312 bytes long */
+// #ifndef DM_VERSION
+// #define DM_VERSION 0xC138FD00
+// #define DM_REMOVE_ALL 0xC138FD01
+// #define DM_LIST_DEVICES 0xC138FD02
+// #define DM_DEV_CREATE 0xC138FD03
+// #define DM_DEV_REMOVE 0xC138FD04
+// #define DM_DEV_RENAME 0xC138FD05
+// #define DM_DEV_SUSPEND 0xC138FD06
+// #define DM_DEV_STATUS 0xC138FD07
+// #define DM_DEV_WAIT 0xC138FD08
+// #define DM_TABLE_LOAD 0xC138FD09
+// #define DM_TABLE_CLEAR 0xC138FD0A
+// #define DM_TABLE_DEPS 0xC138FD0B
+// #define DM_TABLE_STATUS 0xC138FD0C
+// #define DM_LIST_VERSIONS 0xC138FD0D
+// #define DM_TARGET_MSG 0xC138FD0E
+// #define DM_DEV_SET_GEOMETRY 0xC138FD0F
+// #define DM_DEV_ARM_POLL 0xC138FD10
+// #define DM_GET_TARGET_VERSION 0xC138FD11
+// #endif
+//
+// /* Define the amdgpu ioctls, from drm/amdgpu_drm.h which is not available
+//    everywhere */
+// #ifndef DRM_IOCTL_AMDGPU_INFO
+// #define DRM_IOCTL_AMDGPU_INFO 0x40206445
+// #endif
+// #ifndef DRM_IOCTL_AMDGPU_SCHED
+// #define DRM_IOCTL_AMDGPU_SCHED 0x40106455
+// #endif
+//
+// /* Define the i2c-dev ioctls used for SMBus transactions, from
+//    linux/i2c-dev.h which is not available everywhere */
+// #ifndef I2C_SLAVE
+// #define I2C_SLAVE 0x0703
+// #endif
+// #ifndef I2C_FUNCS
+// #define I2C_FUNCS 0x0705
+// #endif
+// #ifndef I2C_PEC
+// #define I2C_PEC 0x0708
+// #endif
+// #ifndef I2C_SMBUS
+// #define I2C_SMBUS 0x0720
+// #endif
+//
+// /* Define the ALSA hwdep ioctls, from sound/asound.h which is not available
+//    everywhere. The size of the image argument of DSP_LOAD depends on the
+//    architecture. */
+// #ifndef SNDRV_HWDEP_IOCTL_PVERSION
+// #define SNDRV_HWDEP_IOCTL_PVERSION 0x80044800
+// #endif
+// #ifndef SNDRV_HWDEP_IOCTL_INFO
+// #define SNDRV_HWDEP_IOCTL_INFO 0x80DC4801
+// #endif
+// #ifndef SNDRV_HWDEP_IOCTL_DSP_STATUS
+// #define SNDRV_HWDEP_IOCTL_DSP_STATUS 0x80404802
+// #endif
+// #ifndef SNDRV_HWDEP_IOCTL_DSP_LOAD
+// struct snap_seccomp_hwdep_dsp_image {
+//   unsigned int index;
+//   unsigned char name[64];
+//   unsigned char *image;
+//   size_t length;
+//   unsigned long driver_data;
+// };
+// #define SNDRV_HWDEP_IOCTL_DSP_LOAD _IOW('H', 0x03, struct snap_seccomp_hwdep_dsp_image)
+// #endif
+//
+// /* Define the ALSA rawmidi ioctls, from sound/asound.h as well. The sizes
+//    of the params and status arguments depend on the architecture. */
+// #ifndef SNDRV_RAWMIDI_IOCTL_PVERSION
+// #define SNDRV_RAWMIDI_IOCTL_PVERSION 0x80045700
+// #endif
+// #ifndef SNDRV_RAWMIDI_IOCTL_INFO
+// #define SNDRV_RAWMIDI_IOCTL_INFO 0x810C5701
+// #endif
+// #ifndef SNDRV_RAWMIDI_IOCTL_USER_PVERSION
+// #define SNDRV_RAWMIDI_IOCTL_USER_PVERSION 0x40045702
+// #endif
+// #ifndef SNDRV_RAWMIDI_IOCTL_PARAMS
+// struct snap_seccomp_rawmidi_params {
+//   int stream;
+//   size_t buffer_size;
+//   size_t avail_min;
+//   unsigned int no_active_sensing: 1;
+//   unsigned int mode;
+//   unsigned char reserved[12];
+// };
+// #define SNDRV_RAWMIDI_IOCTL_PARAMS _IOWR('W', 0x10, struct snap_seccomp_rawmidi_params)
+// #endif
+// #ifndef SNDRV_RAWMIDI_IOCTL_STATUS
+// struct snap_seccomp_rawmidi_status {
+//   int stream;
+//   struct timespec tstamp;
+//   size_t avail;
+//   size_t xruns;
+//   unsigned char reserved[16];
+// };
+// #define SNDRV_RAWMIDI_IOCTL_STATUS _IOWR('W', 0x20, struct snap_seccomp_rawmidi_status)
+// #endif
+// #ifndef SNDRV_RAWMIDI_IOCTL_DROP
+// #define SNDRV_RAWMIDI_IOCTL_DROP 0x40045730
+// #endif
+// #ifndef SNDRV_RAWMIDI_IOCTL_DRAIN
+// #define SNDRV_RAWMIDI_IOCTL_DRAIN 0x40045731
+// #endif
+//
+// /* Define the ALSA sequencer ioctls, from sound/asequencer.h which is not
+//    available everywhere. The size of the port information argument depends
+//    on the architecture. */
+// #ifndef SNDRV_SEQ_IOCTL_PVERSION
+// #define SNDRV_SEQ_IOCTL_PVERSION 0x80045300
+// #define SNDRV_SEQ_IOCTL_CLIENT_ID 0x80045301
+// #define SNDRV_SEQ_IOCTL_SYSTEM_INFO 0xC0305302
+// #define SNDRV_SEQ_IOCTL_RUNNING_MODE 0xC0105303
+// #define SNDRV_SEQ_IOCTL_GET_CLIENT_INFO 0xC0BC5310
+// #define SNDRV_SEQ_IOCTL_SET_CLIENT_INFO 0x40BC5311
+// #define SNDRV_SEQ_IOCTL_SUBSCRIBE_PORT 0x40505330
+// #define SNDRV_SEQ_IOCTL_UNSUBSCRIBE_PORT 0x40505331
+// #define SNDRV_SEQ_IOCTL_CREATE_QUEUE 0xC08C5332
+// #define SNDRV_SEQ_IOCTL_DELETE_QUEUE 0x408C5333
+// #define SNDRV_SEQ_IOCTL_GET_QUEUE_INFO 0xC08C5334
+// #define SNDRV_SEQ_IOCTL_SET_QUEUE_INFO 0xC08C5335
+// #define SNDRV_SEQ_IOCTL_GET_NAMED_QUEUE 0xC08C5336
+// #define SNDRV_SEQ_IOCTL_GET_QUEUE_STATUS 0xC05C5340
+// #define SNDRV_SEQ_IOCTL_GET_QUEUE_TEMPO 0xC02C5341
+// #define SNDRV_SEQ_IOCTL_SET_QUEUE_TEMPO 0x402C5342
+// #define SNDRV_SEQ_IOCTL_GET_QUEUE_TIMER 0xC0605345
+// #define SNDRV_SEQ_IOCTL_SET_QUEUE_TIMER 0x40605346
+// #define SNDRV_SEQ_IOCTL_GET_QUEUE_CLIENT 0xC04C5349
+// #define SNDRV_SEQ_IOCTL_SET_QUEUE_CLIENT 0x404C534A
+// #define SNDRV_SEQ_IOCTL_GET_CLIENT_POOL 0xC058534B
+// #define SNDRV_SEQ_IOCTL_SET_CLIENT_POOL 0x4058534C
+// #define SNDRV_SEQ_IOCTL_REMOVE_EVENTS 0x4040534E
+// #define SNDRV_SEQ_IOCTL_QUERY_SUBS 0xC058534F
+// #define SNDRV_SEQ_IOCTL_GET_SUBSCRIPTION 0xC0505350
+// #define SNDRV_SEQ_IOCTL_QUERY_NEXT_CLIENT 0xC0BC5351
+// #endif
+// #ifndef SNDRV_SEQ_IOCTL_USER_PVERSION
+// #define SNDRV_SEQ_IOCTL_USER_PVERSION 0x40045304
+// #endif
+// #ifndef SNDRV_SEQ_IOCTL_CREATE_PORT
+// struct snap_seccomp_seq_port_info {
+//   unsigned char addr[2];
+//   char name[64];
+//   unsigned int capability;
+//   unsigned int type;
+//   int midi_channels;
+//   int midi_voices;
+//   int synth_voices;
+//   int read_use;
+//   int write_use;
+//   void *kernel;
+//   unsigned int flags;
+//   unsigned char time_queue;
+//   char reserved[59];
+// };
+// #define SNDRV_SEQ_IOCTL_CREATE_PORT _IOWR('S', 0x20, struct snap_seccomp_seq_port_info)
+// #define SNDRV_SEQ_IOCTL_DELETE_PORT _IOW('S', 0x21, struct snap_seccomp_seq_port_info)
+// #define SNDRV_SEQ_IOCTL_GET_PORT_INFO _IOWR('S', 0x22, struct snap_seccomp_seq_port_info)
+// #define SNDRV_SEQ_IOCTL_SET_PORT_INFO _IOW('S', 0x23, struct snap_seccomp_seq_port_info)
+// #define SNDRV_SEQ_IOCTL_QUERY_NEXT_PORT _IOWR('S', 0x52, struct snap_seccomp_seq_port_info)
+// #endif
+//
+// /* Define the ALSA timer ioctls, from sound/asound.h as well. The sizes of
+//    the arguments holding longs or a timestamp depend on the
+//    architecture. */
+// #ifndef SNDRV_TIMER_IOCTL_PVERSION
+// #define SNDRV_TIMER_IOCTL_PVERSION 0x80045400
+// #define SNDRV_TIMER_IOCTL_NEXT_DEVICE 0xC0145401
+// #define SNDRV_TIMER_IOCTL_TREAD_OLD 0x40045402
+// #define SNDRV_TIMER_IOCTL_SELECT 0x40345410
+// #define SNDRV_TIMER_IOCTL_PARAMS 0x40505412
+// #define SNDRV_TIMER_IOCTL_START 0x54A0
+// #define SNDRV_TIMER_IOCTL_STOP 0x54A1
+// #define SNDRV_TIMER_IOCTL_CONTINUE 0x54A2
+// #define SNDRV_TIMER_IOCTL_PAUSE 0x54A3
+// #endif
+// #ifndef SNDRV_TIMER_IOCTL_TREAD64
+// #define SNDRV_TIMER_IOCTL_TREAD64 0x400454A4
+// #endif
+// #ifndef SNDRV_TIMER_IOCTL_GINFO
+// struct snap_seccomp_timer_ginfo {
+//   int tid[5];
+//   unsigned int flags;
+//   int card;
+//   unsigned char id[64];
+//   unsigned char name[80];
+//   unsigned long reserved0;
+//   unsigned long resolution;
+//   unsigned long resolution_min;
+//   unsigned long resolution_max;
+//   unsigned int clients;
+//   unsigned char reserved[32];
+// };
+// #define SNDRV_TIMER_IOCTL_GINFO _IOWR('T', 0x03, struct snap_seccomp_timer_ginfo)
+// #endif
+// #ifndef SNDRV_TIMER_IOCTL_GPARAMS
+// struct snap_seccomp_timer_gparams {
+//   int tid[5];
+//   unsigned long period_num;
+//   unsigned long period_den;
+//   unsigned char reserved[32];
+// };
+// #define SNDRV_TIMER_IOCTL_GPARAMS _IOW('T', 0x04, struct snap_seccomp_timer_gparams)
+// #endif
+// #ifndef SNDRV_TIMER_IOCTL_GSTATUS
+// struct snap_seccomp_timer_gstatus {
+//   int tid[5];
+//   unsigned long resolution;
+//   unsigned long resolution_num;
+//   unsigned long resolution_den;
+//   unsigned char reserved[32];
+// };
+// #define SNDRV_TIMER_IOCTL_GSTATUS _IOWR('T', 0x05, struct snap_seccomp_timer_gstatus)
+// #endif
+// #ifndef SNDRV_TIMER_IOCTL_INFO
+// struct snap_seccomp_timer_info {
+//   unsigned int flags;
+//   int card;
+//   unsigned char id[64];
+//   unsigned char name[80];
+//   unsigned long reserved0;
+//   unsigned long resolution;
+//   unsigned char reserved[64];
+// };
+// #define SNDRV_TIMER_IOCTL_INFO _IOR('T', 0x11, struct snap_seccomp_timer_info)
+// #endif
+// #ifndef SNDRV_TIMER_IOCTL_STATUS
+// struct snap_seccomp_timer_status {
+//   struct timespec tstamp;
+//   unsigned int resolution;
+//   unsigned int lost;
+//   unsigned int overrun;
+//   unsigned int queue;
+//   unsigned char reserved[64];
+// };
+// #define SNDRV_TIMER_IOCTL_STATUS _IOR('T', 0x14, struct snap_seccomp_timer_status)
+// #endif
+//
+// /* Define the bpf commands, from linux/bpf.h where they are values of
+//    enum bpf_cmd, which is not included. */
+// #ifndef BPF_MAP_CREATE
+// #define BPF_MAP_CREATE 0
+// #endif
+// #ifndef BPF_MAP_LOOKUP_ELEM
+// #define BPF_MAP_LOOKUP_ELEM 1
+// #endif
+// #ifndef BPF_MAP_UPDATE_ELEM
+// #define BPF_MAP_UPDATE_ELEM 2
+// #endif
+// #ifndef BPF_MAP_DELETE_ELEM
+// #define BPF_MAP_DELETE_ELEM 3
+// #endif
+// #ifndef BPF_MAP_GET_NEXT_KEY
+// #define BPF_MAP_GET_NEXT_KEY 4
+// #endif
+// #ifndef BPF_OBJ_PIN
+// #define BPF_OBJ_PIN 6
+// #endif
+// #ifndef BPF_OBJ_GET
+// #define BPF_OBJ_GET 7
+// #endif
+// #ifndef BPF_OBJ_GET_INFO_BY_FD
+// #define BPF_OBJ_GET_INFO_BY_FD 15
+// #endif
+// #ifndef BPF_MAP_LOOKUP_AND_DELETE_ELEM
+// #define BPF_MAP_LOOKUP_AND_DELETE_ELEM 21
+// #endif
+// #ifndef BPF_MAP_FREEZE
+// #define BPF_MAP_FREEZE 22
+// #endif
+// #ifndef BPF_MAP_LOOKUP_BATCH
+// #define BPF_MAP_LOOKUP_BATCH 24
+// #endif
+// #ifndef BPF_MAP_LOOKUP_AND_DELETE_BATCH
+// #define BPF_MAP_LOOKUP_AND_DELETE_BATCH 25
+// #endif
+// #ifndef BPF_MAP_UPDATE_BATCH
+// #define BPF_MAP_UPDATE_BATCH 26
+// #endif
+// #ifndef BPF_MAP_DELETE_BATCH
+// #define BPF_MAP_DELETE_BATCH 27
+// #endif
+//
+// /* Define the ALSA compressed audio offload ioctls, from
+//    sound/compress_offload.h. The arguments are packed structures of fixed
+//    size on all architectures. */
+// #ifndef SNDRV_COMPRESS_IOCTL_VERSION
+// #define SNDRV_COMPRESS_IOCTL_VERSION 0x80044300
+// #endif
+// #ifndef SNDRV_COMPRESS_GET_CAPS
+// #define SNDRV_COMPRESS_GET_CAPS 0xC0C44310
+// #endif
+// #ifndef SNDRV_COMPRESS_GET_CODEC_CAPS
+// #define SNDRV_COMPRESS_GET_CODEC_CAPS 0xEB884311
+// #endif
+// #ifndef SNDRV_COMPRESS_SET_PARAMS
+// #define SNDRV_COMPRESS_SET_PARAMS 0x40844312
+// #endif
+// #ifndef SNDRV_COMPRESS_GET_PARAMS
+// #define SNDRV_COMPRESS_GET_PARAMS 0x80784313
+// #endif
+// #ifndef SNDRV_COMPRESS_SET_METADATA
+// #define SNDRV_COMPRESS_SET_METADATA 0x40244314
+// #endif
+// #ifndef SNDRV_COMPRESS_GET_METADATA
+// #define SNDRV_COMPRESS_GET_METADATA 0xC0244315
+// #endif
+// #ifndef SNDRV_COMPRESS_TSTAMP
+// #define SNDRV_COMPRESS_TSTAMP 0x80144320
+// #endif
+// #ifndef SNDRV_COMPRESS_AVAIL
+// #define SNDRV_COMPRESS_AVAIL 0x801C4321
+// #endif
+// #ifndef SNDRV_COMPRESS_PAUSE
+// #define SNDRV_COMPRESS_PAUSE 0x4330
+// #endif
+// #ifndef SNDRV_COMPRESS_RESUME
+// #define SNDRV_COMPRESS_RESUME 0x4331
+// #endif
+// #ifndef SNDRV_COMPRESS_START
+// #define SNDRV_COMPRESS_START 0x4332
+// #endif
+// #ifndef SNDRV_COMPRESS_STOP
+// #define SNDRV_COMPRESS_STOP 0x4333
+// #endif
+// #ifndef SNDRV_COMPRESS_DRAIN
+// #define SNDRV_COMPRESS_DRAIN 0x4334
+// #endif
+// #ifndef SNDRV_COMPRESS_NEXT_TRACK
+// #define SNDRV_COMPRESS_NEXT_TRACK 0x4335
+// #endif
+// #ifndef SNDRV_COMPRESS_PARTIAL_DRAIN
+// #define SNDRV_COMPRESS_PARTIAL_DRAIN 0x4336
+// #endif
+//
+// /* Define the COMEDI ioctls, from linux/comedi.h which is only shipped by
+//    recent kernel headers. The sizes of the arguments holding pointers
+//    depend on the architecture. */
+// #ifndef COMEDI_DEVINFO
+// #define COMEDI_DEVINFO 0x80B06401
+// #endif
+// #ifndef COMEDI_SUBDINFO
+// #define COMEDI_SUBDINFO 0x80486402
+// #endif
+// #ifndef COMEDI_CHANINFO
+// struct snap_seccomp_comedi_chaninfo {
+//   unsigned int subdev;
+//   unsigned int *maxdata_list;
+//   unsigned int *flaglist;
+//   unsigned int *rangelist;
+//   unsigned int unused[4];
+// };
+// #define COMEDI_CHANINFO _IOR('d', 3, struct snap_seccomp_comedi_chaninfo)
+// #endif
+// #ifndef COMEDI_LOCK
+// #define COMEDI_LOCK 0x6405
+// #endif
+// #ifndef COMEDI_UNLOCK
+// #define COMEDI_UNLOCK 0x6406
+// #endif
+// #ifndef COMEDI_CANCEL
+// #define COMEDI_CANCEL 0x6407
+// #endif
+// #ifndef COMEDI_RANGEINFO
+// struct snap_seccomp_comedi_rangeinfo {
+//   unsigned int range_type;
+//   void *range_ptr;
+// };
+// #define COMEDI_RANGEINFO _IOR('d', 8, struct snap_seccomp_comedi_rangeinfo)
+// #endif
+// #if !defined(COMEDI_CMD) || !defined(COMEDI_CMDTEST)
+// struct snap_seccomp_comedi_cmd {
+//   unsigned int subdev;
+//   unsigned int flags;
+//   unsigned int start_src;
+//   unsigned int start_arg;
+//   unsigned int scan_begin_src;
+//   unsigned int scan_begin_arg;
+//   unsigned int convert_src;
+//   unsigned int convert_arg;
+//   unsigned int scan_end_src;
+//   unsigned int scan_end_arg;
+//   unsigned int stop_src;
+//   unsigned int stop_arg;
+//   unsigned int *chanlist;
+//   unsigned int chanlist_len;
+//   short *data;
+//   unsigned int data_len;
+// };
+// #endif
+// #ifndef COMEDI_CMD
+// #define COMEDI_CMD _IOR('d', 9, struct snap_seccomp_comedi_cmd)
+// #endif
+// #ifndef COMEDI_CMDTEST
+// #define COMEDI_CMDTEST _IOR('d', 10, struct snap_seccomp_comedi_cmd)
+// #endif
+// #ifndef COMEDI_INSNLIST
+// struct snap_seccomp_comedi_insnlist {
+//   unsigned int n_insns;
+//   void *insns;
+// };
+// #define COMEDI_INSNLIST _IOR('d', 11, struct snap_seccomp_comedi_insnlist)
+// #endif
+// #ifndef COMEDI_INSN
+// struct snap_seccomp_comedi_insn {
+//   unsigned int insn;
+//   unsigned int n;
+//   unsigned int *data;
+//   unsigned int subdev;
+//   unsigned int chanspec;
+//   unsigned int unused[3];
+// };
+// #define COMEDI_INSN _IOR('d', 12, struct snap_seccomp_comedi_insn)
+// #endif
+// #ifndef COMEDI_BUFCONFIG
+// #define COMEDI_BUFCONFIG 0x8020640D
+// #endif
+// #ifndef COMEDI_BUFINFO
+// #define COMEDI_BUFINFO 0xC02C640E
+// #endif
+// #ifndef COMEDI_POLL
+// #define COMEDI_POLL 0x640F
+// #endif
+// #ifndef COMEDI_SETRSUBD
+// #define COMEDI_SETRSUBD 0x6410
+// #endif
+// #ifndef COMEDI_SETWSUBD
+// #define COMEDI_SETWSUBD 0x6411
+// #endif
+//
+// /* Define the GPIO chip and line ioctls, from linux/gpio.h which only
+//    ships the v2 uAPI with recent kernel headers. */
+// #ifndef GPIO_GET_CHIPINFO_IOCTL
+// #define GPIO_GET_CHIPINFO_IOCTL 0x8044B401
+// #endif
+// #ifndef GPIO_GET_LINEINFO_UNWATCH_IOCTL
+// #define GPIO_GET_LINEINFO_UNWATCH_IOCTL 0xC004B40C
+// #endif
+// #ifndef GPIO_V2_GET_LINEINFO_IOCTL
+// #define GPIO_V2_GET_LINEINFO_IOCTL 0xC100B405
+// #define GPIO_V2_GET_LINEINFO_WATCH_IOCTL 0xC100B406
+// #define GPIO_V2_GET_LINE_IOCTL 0xC250B407
+// #define GPIO_V2_LINE_GET_VALUES_IOCTL 0xC010B40E
+// #define GPIO_V2_LINE_SET_VALUES_IOCTL 0xC010B40F
+// #endif
+// #ifndef GPIO_V2_LINE_SET_CONFIG_IOCTL
+// struct snap_seccomp_gpio_v2_line_config {
+//   unsigned char data[272];
+// };
+// #define GPIO_V2_LINE_SET_CONFIG_IOCTL _IOWR(0xB4, 0x0D, struct snap_seccomp_gpio_v2_line_config)
+// #endif
+//
+// /* Define the MEI ioctls, from linux/mei.h which only ships the client
+//    notifications and vtag support with recent kernel headers. */
+// #ifndef IOCTL_MEI_CONNECT_CLIENT
+// struct snap_seccomp_mei_connect_client_data {
+//   unsigned char data[16];
+// };
+// #define IOCTL_MEI_CONNECT_CLIENT _IOWR('H', 0x01, struct snap_seccomp_mei_connect_client_data)
+// #endif
+// #ifndef IOCTL_MEI_NOTIFY_SET
+// #define IOCTL_MEI_NOTIFY_SET _IOW('H', 0x02, __u32)
+// #endif
+// #ifndef IOCTL_MEI_NOTIFY_GET
+// #define IOCTL_MEI_NOTIFY_GET _IOR('H', 0x03, __u32)
+// #endif
+// #ifndef IOCTL_MEI_CONNECT_CLIENT_VTAG
+// struct snap_seccomp_mei_connect_client_data_vtag {
+//   unsigned char data[20];
+// };
+// #define IOCTL_MEI_CONNECT_CLIENT_VTAG _IOWR('H', 0x04, struct snap_seccomp_mei_connect_client_data_vtag)
+// #endif
+//
+// /* Define the TEE ioctls, from linux/tee.h which is not available
+//    everywhere. */
+// #ifndef TEE_IOC_VERSION
+// #define TEE_IOC_VERSION 0x800CA400
+// #define TEE_IOC_SHM_ALLOC 0xC010A401
+// #define TEE_IOC_OPEN_SESSION 0x8010A402
+// #define TEE_IOC_INVOKE 0x8010A403
+// #define TEE_IOC_CANCEL 0x8008A404
+// #define TEE_IOC_CLOSE_SESSION 0x8004A405
+// #define TEE_IOC_SUPPL_RECV 0x8010A406
+// #define TEE_IOC_SUPPL_SEND 0x8010A407
+// #define TEE_IOC_SHM_REGISTER 0xC018A409
+// #endif
+//
+// /* Define the AppArmor notification ioctls, from linux/apparmor.h which is
+//    only shipped by the kernel headers of some distributions. */
+// #ifndef APPARMOR_NOTIF_SET_FILTER
+// #define APPARMOR_NOTIF_SET_FILTER 0x4008F800
+// #define APPARMOR_NOTIF_GET_FILTER 0x8008F801
+// #define APPARMOR_NOTIF_IS_ID_VALID 0x8008F803
+// #define APPARMOR_NOTIF_RECV 0xC008F804
+// #define APPARMOR_NOTIF_SEND 0xC008F805
+// #endif
+// #ifndef APPARMOR_NOTIF_REGISTER
+// #define APPARMOR_NOTIF_REGISTER 0xC008F806
+// #define APPARMOR_NOTIF_RESEND 0xC008F807
+// #endif
+//
+// /* Define the MMC ioctls, from linux/mmc/ioctl.h which is not available
+//    everywhere. */
+// #ifndef MMC_IOC_CMD
+// #define MMC_IOC_CMD 0xC048B300
+// #endif
+// #ifndef MMC_IOC_MULTI_CMD
+// #define MMC_IOC_MULTI_CMD 0xC008B301
+// #endif
+//
+// /* Define the DVB frontend, demux, ca and net ioctls, from
+//    linux/dvb/{frontend,dmx,ca,net}.h which are not available everywhere.
+//    Only the property requests carry a pointer in their argument. */
+// #ifndef FE_GET_INFO
+// #define FE_GET_INFO 0x80A86F3D
+// #define FE_DISEQC_RESET_OVERLOAD 0x6F3E
+// #define FE_DISEQC_SEND_MASTER_CMD 0x40076F3F
+// #define FE_DISEQC_RECV_SLAVE_REPLY 0x800C6F40
+// #define FE_DISEQC_SEND_BURST 0x6F41
+// #define FE_SET_TONE 0x6F42
+// #define FE_SET_VOLTAGE 0x6F43
+// #define FE_ENABLE_HIGH_LNB_VOLTAGE 0x6F44
+// #define FE_READ_STATUS 0x80046F45
+// #define FE_READ_BER 0x80046F46
+// #define FE_READ_SIGNAL_STRENGTH 0x80026F47
+// #define FE_READ_SNR 0x80026F48
+// #define FE_READ_UNCORRECTED_BLOCKS 0x80046F49
+// #define FE_SET_FRONTEND 0x40246F4C
+// #define FE_GET_FRONTEND 0x80246F4D
+// #define FE_GET_EVENT 0x80286F4E
+// #define FE_DISHNETWORK_SEND_LEGACY_CMD 0x6F50
+// #define FE_SET_FRONTEND_TUNE_MODE 0x6F51
+// #endif
+// #if !defined(FE_SET_PROPERTY) || !defined(FE_GET_PROPERTY)
+// struct snap_seccomp_dtv_properties {
+//   unsigned int num;
+//   void *props;
+// };
+// #endif
+// #ifndef FE_SET_PROPERTY
+// #define FE_SET_PROPERTY _IOW('o', 82, struct snap_seccomp_dtv_properties)
+// #endif
+// #ifndef FE_GET_PROPERTY
+// #define FE_GET_PROPERTY _IOR('o', 83, struct snap_seccomp_dtv_properties)
+// #endif
+// #ifndef DMX_START
+// #define DMX_START 0x6F29
+// #define DMX_STOP 0x6F2A
+// #define DMX_SET_FILTER 0x403C6F2B
+// #define DMX_SET_PES_FILTER 0x40146F2C
+// #define DMX_SET_BUFFER_SIZE 0x6F2D
+// #define DMX_GET_PES_PIDS 0x800A6F2F
+// #define DMX_GET_STC 0xC0106F32
+// #define DMX_ADD_PID 0x40026F33
+// #define DMX_REMOVE_PID 0x40026F34
+// #endif
+// #ifndef DMX_REQBUFS
+// #define DMX_REQBUFS 0xC0086F3C
+// #define DMX_QUERYBUF 0xC0186F3D
+// #define DMX_EXPBUF 0xC00C6F3E
+// #define DMX_QBUF 0xC0186F3F
+// #define DMX_DQBUF 0xC0186F40
+// #endif
+// #ifndef CA_RESET
+// #define CA_RESET 0x6F80
+// #define CA_GET_CAP 0x80106F81
+// #define CA_GET_SLOT_INFO 0x800C6F82
+// #define CA_GET_DESCR_INFO 0x80086F83
+// #define CA_GET_MSG 0x810C6F84
+// #define CA_SEND_MSG 0x410C6F85
+// #define CA_SET_DESCR 0x40106F86
+// #endif
+// #ifndef NET_ADD_IF
+// #define NET_ADD_IF 0xC0066F34
+// #define NET_REMOVE_IF 0x6F35
+// #define NET_GET_IF 0xC0066F36
+// #endif
+//
 //#include <linux/version.h>
 //#if LINUX_VERSION_CODE >= KERNEL_VERSION(3,19,0)
 // #include <linux/kcmp.h>
@@ -316,6 +913,8 @@ var seccompResolver = map[string]uint64{
 	"PF_CONN":    C.PF_CONN,
 	"AF_QIPCRTR": C.AF_QIPCRTR, // 42
 	"PF_QIPCRTR": C.PF_QIPCRTR,
+	"AF_SMC":     C.AF_SMC, // 43
+	"PF_SMC":     C.PF_SMC,
 	"AF_XDP":     C.AF_XDP, // 44
 	"PF_XDP":     C.PF_XDP,
 
@@ -403,12 +1002,253 @@ var seccompResolver = map[string]uint64{
 	"CLONE_NEWUSER": syscall.CLONE_NEWUSER,
 	"CLONE_NEWUTS":  syscall.CLONE_NEWUTS,
 
+	// man 2 bpf
+	"BPF_MAP_CREATE":                  C.BPF_MAP_CREATE,
+	"BPF_MAP_LOOKUP_ELEM":             C.BPF_MAP_LOOKUP_ELEM,
+	"BPF_MAP_UPDATE_ELEM":             C.BPF_MAP_UPDATE_ELEM,
+	"BPF_MAP_DELETE_ELEM":             C.BPF_MAP_DELETE_ELEM,
+	"BPF_MAP_GET_NEXT_KEY":            C.BPF_MAP_GET_NEXT_KEY,
+	"BPF_OBJ_PIN":                     C.BPF_OBJ_PIN,
+	"BPF_OBJ_GET":                     C.BPF_OBJ_GET,
+	"BPF_OBJ_GET_INFO_BY_FD":          C.BPF_OBJ_GET_INFO_BY_FD,
+	"BPF_MAP_LOOKUP_AND_DELETE_ELEM":  C.BPF_MAP_LOOKUP_AND_DELETE_ELEM,
+	"BPF_MAP_FREEZE":                  C.BPF_MAP_FREEZE,
+	"BPF_MAP_LOOKUP_BATCH":            C.BPF_MAP_LOOKUP_BATCH,
+	"BPF_MAP_LOOKUP_AND_DELETE_BATCH": C.BPF_MAP_LOOKUP_AND_DELETE_BATCH,
+	"BPF_MAP_UPDATE_BATCH":            C.BPF_MAP_UPDATE_BATCH,
+	"BPF_MAP_DELETE_BATCH":            C.BPF_MAP_DELETE_BATCH,
+
 	// man 4 tty_ioctl
 	"TIOCSTI": syscall.TIOCSTI,
 
 	// man 2 ioctl_console
 	"TIOCLINUX": C.TIOCLINUX,
 
+	// uapi/linux/nvme_ioctl.h
+	"NVME_IOCTL_ADMIN_CMD":   C.NVME_IOCTL_ADMIN_CMD,
+	"NVME_IOCTL_ADMIN64_CMD": C.NVME_IOCTL_ADMIN64_CMD,
+
+	// uapi/linux/dm-ioctl.h
+	"DM_VERSION":            C.DM_VERSION,
+	"DM_REMOVE_ALL":         C.DM_REMOVE_ALL,
+	"DM_LIST_DEVICES":       C.DM_LIST_DEVICES,
+	"DM_DEV_CREATE":         C.DM_DEV_CREATE,
+	"DM_DEV_REMOVE":         C.DM_DEV_REMOVE,
+	"DM_DEV_RENAME":         C.DM_DEV_RENAME,
+	"DM_DEV_SUSPEND":        C.DM_DEV_SUSPEND,
+	"DM_DEV_STATUS":         C.DM_DEV_STATUS,
+	"DM_DEV_WAIT":           C.DM_DEV_WAIT,
+	"DM_TABLE_LOAD":         C.DM_TABLE_LOAD,
+	"DM_TABLE_CLEAR":        C.DM_TABLE_CLEAR,
+	"DM_TABLE_DEPS":         C.DM_TABLE_DEPS,
+	"DM_TABLE_STATUS":       C.DM_TABLE_STATUS,
+	"DM_LIST_VERSIONS":      C.DM_LIST_VERSIONS,
+	"DM_TARGET_MSG":         C.DM_TARGET_MSG,
+	"DM_DEV_SET_GEOMETRY":   C.DM_DEV_SET_GEOMETRY,
+	"DM_DEV_ARM_POLL":       C.DM_DEV_ARM_POLL,
+	"DM_GET_TARGET_VERSION": C.DM_GET_TARGET_VERSION,
+
+	// uapi/drm/amdgpu_drm.h
+	"DRM_IOCTL_AMDGPU_INFO":  C.DRM_IOCTL_AMDGPU_INFO,
+	"DRM_IOCTL_AMDGPU_SCHED": C.DRM_IOCTL_AMDGPU_SCHED,
+
+	// uapi/linux/i2c-dev.h
+	"I2C_SLAVE": C.I2C_SLAVE,
+	"I2C_FUNCS": C.I2C_FUNCS,
+	"I2C_PEC":   C.I2C_PEC,
+	"I2C_SMBUS": C.I2C_SMBUS,
+
+	// uapi/sound/asound.h
+	"SNDRV_HWDEP_IOCTL_PVERSION":   C.SNDRV_HWDEP_IOCTL_PVERSION,
+	"SNDRV_HWDEP_IOCTL_INFO":       C.SNDRV_HWDEP_IOCTL_INFO,
+	"SNDRV_HWDEP_IOCTL_DSP_STATUS": C.SNDRV_HWDEP_IOCTL_DSP_STATUS,
+	"SNDRV_HWDEP_IOCTL_DSP_LOAD":   C.SNDRV_HWDEP_IOCTL_DSP_LOAD,
+
+	"SNDRV_RAWMIDI_IOCTL_PVERSION":      C.SNDRV_RAWMIDI_IOCTL_PVERSION,
+	"SNDRV_RAWMIDI_IOCTL_INFO":          C.SNDRV_RAWMIDI_IOCTL_INFO,
+	"SNDRV_RAWMIDI_IOCTL_USER_PVERSION": C.SNDRV_RAWMIDI_IOCTL_USER_PVERSION,
+	"SNDRV_RAWMIDI_IOCTL_PARAMS":        C.SNDRV_RAWMIDI_IOCTL_PARAMS,
+	"SNDRV_RAWMIDI_IOCTL_STATUS":        C.SNDRV_RAWMIDI_IOCTL_STATUS,
+	"SNDRV_RAWMIDI_IOCTL_DROP":          C.SNDRV_RAWMIDI_IOCTL_DROP,
+	"SNDRV_RAWMIDI_IOCTL_DRAIN":         C.SNDRV_RAWMIDI_IOCTL_DRAIN,
+
+	"SNDRV_SEQ_IOCTL_PVERSION":          C.SNDRV_SEQ_IOCTL_PVERSION,
+	"SNDRV_SEQ_IOCTL_CLIENT_ID":         C.SNDRV_SEQ_IOCTL_CLIENT_ID,
+	"SNDRV_SEQ_IOCTL_SYSTEM_INFO":       C.SNDRV_SEQ_IOCTL_SYSTEM_INFO,
+	"SNDRV_SEQ_IOCTL_RUNNING_MODE":      C.SNDRV_SEQ_IOCTL_RUNNING_MODE,
+	"SNDRV_SEQ_IOCTL_USER_PVERSION":     C.SNDRV_SEQ_IOCTL_USER_PVERSION,
+	"SNDRV_SEQ_IOCTL_GET_CLIENT_INFO":   C.SNDRV_SEQ_IOCTL_GET_CLIENT_INFO,
+	"SNDRV_SEQ_IOCTL_SET_CLIENT_INFO":   C.SNDRV_SEQ_IOCTL_SET_CLIENT_INFO,
+	"SNDRV_SEQ_IOCTL_CREATE_PORT":       C.SNDRV_SEQ_IOCTL_CREATE_PORT,
+	"SNDRV_SEQ_IOCTL_DELETE_PORT":       C.SNDRV_SEQ_IOCTL_DELETE_PORT,
+	"SNDRV_SEQ_IOCTL_GET_PORT_INFO":     C.SNDRV_SEQ_IOCTL_GET_PORT_INFO,
+	"SNDRV_SEQ_IOCTL_SET_PORT_INFO":     C.SNDRV_SEQ_IOCTL_SET_PORT_INFO,
+	"SNDRV_SEQ_IOCTL_SUBSCRIBE_PORT":    C.SNDRV_SEQ_IOCTL_SUBSCRIBE_PORT,
+	"SNDRV_SEQ_IOCTL_UNSUBSCRIBE_PORT":  C.SNDRV_SEQ_IOCTL_UNSUBSCRIBE_PORT,
+	"SNDRV_SEQ_IOCTL_CREATE_QUEUE":      C.SNDRV_SEQ_IOCTL_CREATE_QUEUE,
+	"SNDRV_SEQ_IOCTL_DELETE_QUEUE":      C.SNDRV_SEQ_IOCTL_DELETE_QUEUE,
+	"SNDRV_SEQ_IOCTL_GET_QUEUE_INFO":    C.SNDRV_SEQ_IOCTL_GET_QUEUE_INFO,
+	"SNDRV_SEQ_IOCTL_SET_QUEUE_INFO":    C.SNDRV_SEQ_IOCTL_SET_QUEUE_INFO,
+	"SNDRV_SEQ_IOCTL_GET_NAMED_QUEUE":   C.SNDRV_SEQ_IOCTL_GET_NAMED_QUEUE,
+	"SNDRV_SEQ_IOCTL_GET_QUEUE_STATUS":  C.SNDRV_SEQ_IOCTL_GET_QUEUE_STATUS,
+	"SNDRV_SEQ_IOCTL_GET_QUEUE_TEMPO":   C.SNDRV_SEQ_IOCTL_GET_QUEUE_TEMPO,
+	"SNDRV_SEQ_IOCTL_SET_QUEUE_TEMPO":   C.SNDRV_SEQ_IOCTL_SET_QUEUE_TEMPO,
+	"SNDRV_SEQ_IOCTL_GET_QUEUE_TIMER":   C.SNDRV_SEQ_IOCTL_GET_QUEUE_TIMER,
+	"SNDRV_SEQ_IOCTL_SET_QUEUE_TIMER":   C.SNDRV_SEQ_IOCTL_SET_QUEUE_TIMER,
+	"SNDRV_SEQ_IOCTL_GET_QUEUE_CLIENT":  C.SNDRV_SEQ_IOCTL_GET_QUEUE_CLIENT,
+	"SNDRV_SEQ_IOCTL_SET_QUEUE_CLIENT":  C.SNDRV_SEQ_IOCTL_SET_QUEUE_CLIENT,
+	"SNDRV_SEQ_IOCTL_GET_CLIENT_POOL":   C.SNDRV_SEQ_IOCTL_GET_CLIENT_POOL,
+	"SNDRV_SEQ_IOCTL_SET_CLIENT_POOL":   C.SNDRV_SEQ_IOCTL_SET_CLIENT_POOL,
+	"SNDRV_SEQ_IOCTL_REMOVE_EVENTS":     C.SNDRV_SEQ_IOCTL_REMOVE_EVENTS,
+	"SNDRV_SEQ_IOCTL_QUERY_SUBS":        C.SNDRV_SEQ_IOCTL_QUERY_SUBS,
+	"SNDRV_SEQ_IOCTL_GET_SUBSCRIPTION":  C.SNDRV_SEQ_IOCTL_GET_SUBSCRIPTION,
+	"SNDRV_SEQ_IOCTL_QUERY_NEXT_CLIENT": C.SNDRV_SEQ_IOCTL_QUERY_NEXT_CLIENT,
+	"SNDRV_SEQ_IOCTL_QUERY_NEXT_PORT":   C.SNDRV_SEQ_IOCTL_QUERY_NEXT_PORT,
+
+	"SNDRV_TIMER_IOCTL_PVERSION":    C.SNDRV_TIMER_IOCTL_PVERSION,
+	"SNDRV_TIMER_IOCTL_NEXT_DEVICE": C.SNDRV_TIMER_IOCTL_NEXT_DEVICE,
+	"SNDRV_TIMER_IOCTL_TREAD_OLD":   C.SNDRV_TIMER_IOCTL_TREAD_OLD,
+	"SNDRV_TIMER_IOCTL_GINFO":       C.SNDRV_TIMER_IOCTL_GINFO,
+	"SNDRV_TIMER_IOCTL_GPARAMS":     C.SNDRV_TIMER_IOCTL_GPARAMS,
+	"SNDRV_TIMER_IOCTL_GSTATUS":     C.SNDRV_TIMER_IOCTL_GSTATUS,
+	"SNDRV_TIMER_IOCTL_SELECT":      C.SNDRV_TIMER_IOCTL_SELECT,
+	"SNDRV_TIMER_IOCTL_INFO":        C.SNDRV_TIMER_IOCTL_INFO,
+	"SNDRV_TIMER_IOCTL_PARAMS":      C.SNDRV_TIMER_IOCTL_PARAMS,
+	"SNDRV_TIMER_IOCTL_STATUS":      C.SNDRV_TIMER_IOCTL_STATUS,
+	"SNDRV_TIMER_IOCTL_START":       C.SNDRV_TIMER_IOCTL_START,
+	"SNDRV_TIMER_IOCTL_STOP":        C.SNDRV_TIMER_IOCTL_STOP,
+	"SNDRV_TIMER_IOCTL_CONTINUE":    C.SNDRV_TIMER_IOCTL_CONTINUE,
+	"SNDRV_TIMER_IOCTL_PAUSE":       C.SNDRV_TIMER_IOCTL_PAUSE,
+	"SNDRV_TIMER_IOCTL_TREAD64":     C.SNDRV_TIMER_IOCTL_TREAD64,
+
+	// uapi/sound/compress_offload.h
+	"SNDRV_COMPRESS_IOCTL_VERSION":  C.SNDRV_COMPRESS_IOCTL_VERSION,
+	"SNDRV_COMPRESS_GET_CAPS":       C.SNDRV_COMPRESS_GET_CAPS,
+	"SNDRV_COMPRESS_GET_CODEC_CAPS": C.SNDRV_COMPRESS_GET_CODEC_CAPS,
+	"SNDRV_COMPRESS_SET_PARAMS":     C.SNDRV_COMPRESS_SET_PARAMS,
+	"SNDRV_COMPRESS_GET_PARAMS":     C.SNDRV_COMPRESS_GET_PARAMS,
+	"SNDRV_COMPRESS_SET_METADATA":   C.SNDRV_COMPRESS_SET_METADATA,
+	"SNDRV_COMPRESS_GET_METADATA":   C.SNDRV_COMPRESS_GET_METADATA,
+	"SNDRV_COMPRESS_TSTAMP":         C.SNDRV_COMPRESS_TSTAMP,
+	"SNDRV_COMPRESS_AVAIL":          C.SNDRV_COMPRESS_AVAIL,
+	"SNDRV_COMPRESS_PAUSE":          C.SNDRV_COMPRESS_PAUSE,
+	"SNDRV_COMPRESS_RESUME":         C.SNDRV_COMPRESS_RESUME,
+	"SNDRV_COMPRESS_START":          C.SNDRV_COMPRESS_START,
+	"SNDRV_COMPRESS_STOP":           C.SNDRV_COMPRESS_STOP,
+	"SNDRV_COMPRESS_DRAIN":          C.SNDRV_COMPRESS_DRAIN,
+	"SNDRV_COMPRESS_NEXT_TRACK":     C.SNDRV_COMPRESS_NEXT_TRACK,
+	"SNDRV_COMPRESS_PARTIAL_DRAIN":  C.SNDRV_COMPRESS_PARTIAL_DRAIN,
+
+	// uapi/linux/comedi.h
+	"COMEDI_DEVINFO":   C.COMEDI_DEVINFO,
+	"COMEDI_SUBDINFO":  C.COMEDI_SUBDINFO,
+	"COMEDI_CHANINFO":  C.COMEDI_CHANINFO,
+	"COMEDI_LOCK":      C.COMEDI_LOCK,
+	"COMEDI_UNLOCK":    C.COMEDI_UNLOCK,
+	"COMEDI_CANCEL":    C.COMEDI_CANCEL,
+	"COMEDI_RANGEINFO": C.COMEDI_RANGEINFO,
+	"COMEDI_CMD":       C.COMEDI_CMD,
+	"COMEDI_CMDTEST":   C.COMEDI_CMDTEST,
+	"COMEDI_INSNLIST":  C.COMEDI_INSNLIST,
+	"COMEDI_INSN":      C.COMEDI_INSN,
+	"COMEDI_BUFCONFIG": C.COMEDI_BUFCONFIG,
+	"COMEDI_BUFINFO":   C.COMEDI_BUFINFO,
+	"COMEDI_POLL":      C.COMEDI_POLL,
+	"COMEDI_SETRSUBD":  C.COMEDI_SETRSUBD,
+	"COMEDI_SETWSUBD":  C.COMEDI_SETWSUBD,
+
+	// uapi/linux/gpio.h
+	"GPIO_GET_CHIPINFO_IOCTL":          C.GPIO_GET_CHIPINFO_IOCTL,
+	"GPIO_GET_LINEINFO_UNWATCH_IOCTL":  C.GPIO_GET_LINEINFO_UNWATCH_IOCTL,
+	"GPIO_V2_GET_LINEINFO_IOCTL":       C.GPIO_V2_GET_LINEINFO_IOCTL,
+	"GPIO_V2_GET_LINEINFO_WATCH_IOCTL": C.GPIO_V2_GET_LINEINFO_WATCH_IOCTL,
+	"GPIO_V2_GET_LINE_IOCTL":           C.GPIO_V2_GET_LINE_IOCTL,
+	"GPIO_V2_LINE_SET_CONFIG_IOCTL":    C.GPIO_V2_LINE_SET_CONFIG_IOCTL,
+	"GPIO_V2_LINE_GET_VALUES_IOCTL":    C.GPIO_V2_LINE_GET_VALUES_IOCTL,
+	"GPIO_V2_LINE_SET_VALUES_IOCTL":    C.GPIO_V2_LINE_SET_VALUES_IOCTL,
+
+	// uapi/linux/mei.h
+	"IOCTL_MEI_CONNECT_CLIENT":      C.IOCTL_MEI_CONNECT_CLIENT,
+	"IOCTL_MEI_NOTIFY_SET":          C.IOCTL_MEI_NOTIFY_SET,
+	"IOCTL_MEI_NOTIFY_GET":          C.IOCTL_MEI_NOTIFY_GET,
+	"IOCTL_MEI_CONNECT_CLIENT_VTAG": C.IOCTL_MEI_CONNECT_CLIENT_VTAG,
+
+	// uapi/linux/tee.h
+	"TEE_IOC_VERSION":       C.TEE_IOC_VERSION,
+	"TEE_IOC_SHM_ALLOC":     C.TEE_IOC_SHM_ALLOC,
+	"TEE_IOC_OPEN_SESSION":  C.TEE_IOC_OPEN_SESSION,
+	"TEE_IOC_INVOKE":        C.TEE_IOC_INVOKE,
+	"TEE_IOC_CANCEL":        C.TEE_IOC_CANCEL,
+	"TEE_IOC_CLOSE_SESSION": C.TEE_IOC_CLOSE_SESSION,
+	"TEE_IOC_SUPPL_RECV":    C.TEE_IOC_SUPPL_RECV,
+	"TEE_IOC_SUPPL_SEND":    C.TEE_IOC_SUPPL_SEND,
+	"TEE_IOC_SHM_REGISTER":  C.TEE_IOC_SHM_REGISTER,
+
+	// uapi/linux/apparmor.h
+	"APPARMOR_NOTIF_SET_FILTER":  C.APPARMOR_NOTIF_SET_FILTER,
+	"APPARMOR_NOTIF_GET_FILTER":  C.APPARMOR_NOTIF_GET_FILTER,
+	"APPARMOR_NOTIF_IS_ID_VALID": C.APPARMOR_NOTIF_IS_ID_VALID,
+	"APPARMOR_NOTIF_RECV":        C.APPARMOR_NOTIF_RECV,
+	"APPARMOR_NOTIF_SEND":        C.APPARMOR_NOTIF_SEND,
+	"APPARMOR_NOTIF_REGISTER":    C.APPARMOR_NOTIF_REGISTER,
+	"APPARMOR_NOTIF_RESEND":      C.APPARMOR_NOTIF_RESEND,
+
+	// uapi/linux/mmc/ioctl.h
+	"MMC_IOC_CMD":       C.MMC_IOC_CMD,
+	"MMC_IOC_MULTI_CMD": C.MMC_IOC_MULTI_CMD,
+
+	// uapi/linux/dvb/frontend.h
+	"FE_GET_INFO":                    C.FE_GET_INFO,
+	"FE_DISEQC_RESET_OVERLOAD":       C.FE_DISEQC_RESET_OVERLOAD,
+	"FE_DISEQC_SEND_MASTER_CMD":      C.FE_DISEQC_SEND_MASTER_CMD,
+	"FE_DISEQC_RECV_SLAVE_REPLY":     C.FE_DISEQC_RECV_SLAVE_REPLY,
+	"FE_DISEQC_SEND_BURST":           C.FE_DISEQC_SEND_BURST,
+	"FE_SET_TONE":                    C.FE_SET_TONE,
+	"FE_SET_VOLTAGE":                 C.FE_SET_VOLTAGE,
+	"FE_ENABLE_HIGH_LNB_VOLTAGE":     C.FE_ENABLE_HIGH_LNB_VOLTAGE,
+	"FE_READ_STATUS":                 C.FE_READ_STATUS,
+	"FE_READ_BER":                    C.FE_READ_BER,
+	"FE_READ_SIGNAL_STRENGTH":        C.FE_READ_SIGNAL_STRENGTH,
+	"FE_READ_SNR":                    C.FE_READ_SNR,
+	"FE_READ_UNCORRECTED_BLOCKS":     C.FE_READ_UNCORRECTED_BLOCKS,
+	"FE_SET_FRONTEND":                C.FE_SET_FRONTEND,
+	"FE_GET_FRONTEND":                C.FE_GET_FRONTEND,
+	"FE_GET_EVENT":                   C.FE_GET_EVENT,
+	"FE_DISHNETWORK_SEND_LEGACY_CMD": C.FE_DISHNETWORK_SEND_LEGACY_CMD,
+	"FE_SET_FRONTEND_TUNE_MODE":      C.FE_SET_FRONTEND_TUNE_MODE,
+	"FE_SET_PROPERTY":                C.FE_SET_PROPERTY,
+	"FE_GET_PROPERTY":                C.FE_GET_PROPERTY,
+
+	// uapi/linux/dvb/dmx.h
+	"DMX_START":           C.DMX_START,
+	"DMX_STOP":            C.DMX_STOP,
+	"DMX_SET_FILTER":      C.DMX_SET_FILTER,
+	"DMX_SET_PES_FILTER":  C.DMX_SET_PES_FILTER,
+	"DMX_SET_BUFFER_SIZE": C.DMX_SET_BUFFER_SIZE,
+	"DMX_GET_PES_PIDS":    C.DMX_GET_PES_PIDS,
+	"DMX_GET_STC":         C.DMX_GET_STC,
+	"DMX_ADD_PID":         C.DMX_ADD_PID,
+	"DMX_REMOVE_PID":      C.DMX_REMOVE_PID,
+	"DMX_REQBUFS":         C.DMX_REQBUFS,
+	"DMX_QUERYBUF":        C.DMX_QUERYBUF,
+	"DMX_EXPBUF":          C.DMX_EXPBUF,
+	"DMX_QBUF":            C.DMX_QBUF,
+	"DMX_DQBUF":           C.DMX_DQBUF,
+
+	// uapi/linux/dvb/ca.h
+	"CA_RESET":          C.CA_RESET,
+	"CA_GET_CAP":        C.CA_GET_CAP,
+	"CA_GET_SLOT_INFO":  C.CA_GET_SLOT_INFO,
+	"CA_GET_DESCR_INFO": C.CA_GET_DESCR_INFO,
+	"CA_GET_MSG":        C.CA_GET_MSG,
+	"CA_SEND_MSG":       C.CA_SEND_MSG,
+	"CA_SET_DESCR":      C.CA_SET_DESCR,
+
+	// uapi/linux/dvb/net.h
+	"NET_ADD_IF":    C.NET_ADD_IF,
+	"NET_REMOVE_IF": C.NET_REMOVE_IF,
+	"NET_GET_IF":    C.NET_GET_IF,
+
 	// man 2 quotactl (with what Linux supports)
 	"Q_SYNC":      C.Q_SYNC,
 	"Q_QUOTAON":   C.Q_QUOTAON,
@@ -432,6 +1272,12 @@ var seccompResolver = map[string]uint64{
 	"S_IFIFO":  syscall.S_IFIFO,
 	"S_IFSOCK": syscall.S_IFSOCK,
 
+	// man 2 mount
+	"MS_RDONLY": syscall.MS_RDONLY,
+	"MS_NOSUID": syscall.MS_NOSUID,
+	"MS_NODEV":  syscall.MS_NODEV,
+	"MS_NOEXEC": syscall.MS_NOEXEC,
+
 	// man 7 netlink (uapi/linux/netlink.h)
 	"NETLINK_ROUTE":          syscall.NETLINK_ROUTE,
 	"NETLINK_USERSOCK":       syscall.NETLINK_USERSOCK,
@@ -587,6 +1433,20 @@ func readNumber(token string, syscallName string) (uint64, error) {
 	return uint64(uint32(value)), nil
 }
 
+// readFlags reads a number made of flags or-ed together, e.g.
+// MS_NOSUID|MS_NODEV.
+func readFlags(token string, syscallName string) (uint64, error) {
+	var flags uint64
+	for _, flag := range strings.Split(token, "|") {
+		value, err := readNumber(flag, syscallName)
+		if err != nil {
+			return 0, err
+		}
+		flags |= value
+	}
+	return flags, nil
+}
+
 func readMaskedEqual(token string, syscallName string) (uint64, uint64, error) {
 	l := strings.Split(token, "|")
 	if len(l) != 2 {
@@ -627,10 +1487,18 @@ func parseLine(line string, secFilterAllow, secFilterDeny *seccomp.ScmpFilter) e
 
 	// fish out syscall
 	syscallName := tokens[0]
+	logged := false
 	if strings.HasPrefix(syscallName, "~") {
 		action = seccomp.ActErrno.SetReturnCode(errnoOnExplicitDenial)
 		syscallName = syscallName[1:]
 		secFilter = secFilterDeny
+	} else if strings.HasPrefix(syscallName, "?") {
+		// allow the listed syscall but log it, this is used to
+		// find out whether syscalls granted by privileged
+		// interfaces are actually used
+		action = complainAction()
+		syscallName = syscallName[1:]
+		logged = true
 	}
 
 	secSyscall, err := seccomp.GetSyscallFromName(syscallName)
@@ -668,8 +1536,9 @@ func parseLine(line string, secFilterAllow, secFilterDeny *seccomp.ScmpFilter) e
 			cmpOp = seccomp.CompareGreater
 			value, err = readNumber(arg[1:], syscallName)
 		} else if strings.HasPrefix(arg, "|") {
+			// all the given flags must be set
 			cmpOp = seccomp.CompareMaskedEqual
-			value, err = readNumber(arg[1:], syscallName)
+			value, err = readFlags(arg[1:], syscallName)
 			value2 = value
 		} else if strings.Contains(arg, "|") {
 			cmpOp = seccomp.CompareMaskedEqual
@@ -722,6 +1591,14 @@ func parseLine(line string, secFilterAllow, secFilterDeny *seccomp.ScmpFilter) e
 	if err = secFilter.AddRuleConditionalExact(secSyscall, action, conds); err != nil {
 		err = secFilter.AddRuleConditional(secSyscall, action, conds)
 	}
+	if err != nil && logged {
+		// the same syscall may be allowed by another rule already, in
+		// which case it cannot be logged, or the log action may not be
+		// supported, in both cases simply allow it
+		if err = secFilter.AddRuleConditionalExact(secSyscall, seccomp.ActAllow, conds); err != nil {
+			err = secFilter.AddRuleConditional(secSyscall, seccomp.ActAllow, conds)
+		}
+	}
 	if err != nil {
 		return fmt.Errorf("cannot add rule for line %q: %v", line, err)
 	}
@@ -745,6 +1622,15 @@ var (
 // the kernel arch to support the kernel's arch (eg, 64bit kernels with
 // 32bit userspace).
 func addSecondaryArches(secFilter *seccomp.ScmpFilter) error {
+	if compatArch := secondaryArch(); compatArch != seccomp.ArchInvalid {
+		return secFilter.AddArch(compatArch)
+	}
+	return nil
+}
+
+// secondaryArch returns the architecture added to the filters next to the
+// native one, see addSecondaryArches, or seccomp.ArchInvalid.
+func secondaryArch() seccomp.ScmpArch {
 	// note that all architecture strings are in the dpkg
 	// architecture notation
 	var compatArch seccomp.ScmpArch
@@ -775,13 +1661,45 @@ func addSecondaryArches(secFilter *seccomp.ScmpFilter) error {
 		compatArch = DpkgArchToScmpArch(archDpkgKernelArchitecture())
 	}
 
-	if compatArch != seccomp.ArchInvalid {
-		return secFilter.AddArch(compatArch)
-	}
+	return compatArch
+}
 
+// restrictToArch removes all the architectures but the given one from the
+// filter. The architecture must be either the native one or the secondary
+// one, the syscall names and arguments are resolved by the host.
+func restrictToArch(secFilter *seccomp.ScmpFilter, target seccomp.ScmpArch) error {
+	native, err := seccomp.GetNativeArch()
+	if err != nil {
+		return fmt.Errorf("cannot obtain the native architecture: %v", err)
+	}
+	for _, arch := range []seccomp.ScmpArch{native, secondaryArch()} {
+		if arch == seccomp.ArchInvalid || arch == target {
+			continue
+		}
+		if err := secFilter.RemoveArch(arch); err != nil {
+			return fmt.Errorf("cannot remove architecture %s from the filter: %v", arch, err)
+		}
+	}
 	return nil
 }
 
+// targetArch returns the architecture matching the given dpkg architecture,
+// which must be one the filters are compiled for on this host.
+func targetArch(dpkgArch string) (seccomp.ScmpArch, error) {
+	target := DpkgArchToScmpArch(dpkgArch)
+	if target == seccomp.ArchInvalid {
+		return seccomp.ArchInvalid, fmt.Errorf("unknown architecture %q", dpkgArch)
+	}
+	native, err := seccomp.GetNativeArch()
+	if err != nil {
+		return seccomp.ArchInvalid, fmt.Errorf("cannot obtain the native architecture: %v", err)
+	}
+	if target != native && target != secondaryArch() {
+		return seccomp.ArchInvalid, fmt.Errorf("cannot compile for architecture %q on this host", dpkgArch)
+	}
+	return target, nil
+}
+
 func preprocess(content []byte) (unrestricted, complain bool) {
 	scanner := bufio.NewScanner(bytes.NewBuffer(content))
 	for scanner.Scan() {
@@ -922,9 +1840,23 @@ func writeSeccompFilter(outFile string, filterAllow, filterDeny *seccomp.ScmpFil
 }
 
 func compile(content []byte, out string) error {
+	return compileForArch(content, out, "")
+}
+
+// compileForArch compiles the filters for the given dpkg architecture only,
+// or all the architectures supported by the host when empty.
+func compileForArch(content []byte, out, dpkgArch string) error {
 	var err error
 	var secFilterAllow, secFilterDeny *seccomp.ScmpFilter
 
+	target := seccomp.ArchInvalid
+	if dpkgArch != "" {
+		target, err = targetArch(dpkgArch)
+		if err != nil {
+			return err
+		}
+	}
+
 	unrestricted, complain := preprocess(content)
 	switch {
 	case unrestricted:
@@ -984,17 +1916,38 @@ func compile(content []byte, out string) error {
 	if err := addSecondaryArches(secFilterDeny); err != nil {
 		return err
 	}
+	if target != seccomp.ArchInvalid {
+		if err := restrictToArch(secFilterAllow, target); err != nil {
+			return err
+		}
+		if err := restrictToArch(secFilterDeny, target); err != nil {
+			return err
+		}
+	}
 
 	if !unrestricted {
+		// logged rules are added last, such that a syscall both logged
+		// and allowed by different rules ends up allowed
+		var loggedLines []string
 		scanner := bufio.NewScanner(bytes.NewBuffer(content))
 		for scanner.Scan() {
-			if err := parseLine(scanner.Text(), secFilterAllow, secFilterDeny); err != nil {
+			line := scanner.Text()
+			if strings.HasPrefix(line, "?") {
+				loggedLines = append(loggedLines, line)
+				continue
+			}
+			if err := parseLine(line, secFilterAllow, secFilterDeny); err != nil {
 				return fmt.Errorf("cannot parse line: %s", err)
 			}
 		}
 		if scanner.Err(); err != nil {
 			return err
 		}
+		for _, line := range loggedLines {
+			if err := parseLine(line, secFilterAllow, secFilterDeny); err != nil {
+				return fmt.Errorf("cannot parse line: %s", err)
+			}
+		}
 	}
 
 	if osutil.GetenvBool("SNAP_SECCOMP_DEBUG") {
@@ -1048,6 +2001,22 @@ func showSeccompLibraryVersion() error {
 	return nil
 }
 
+// showSyscallName prints the name of the syscall with the given number on the
+// native architecture, such that the syscalls of the seccomp messages of the
+// kernel log can be resolved.
+func showSyscallName(number string) error {
+	nr, err := strconv.ParseInt(number, 10, 32)
+	if err != nil {
+		return fmt.Errorf("cannot parse syscall number %q: %v", number, err)
+	}
+	name, err := seccomp.ScmpSyscall(nr).GetName()
+	if err != nil {
+		return fmt.Errorf("cannot resolve syscall number %d: %v", nr, err)
+	}
+	fmt.Fprintln(os.Stdout, name)
+	return nil
+}
+
 func dump(what, prefix string) error {
 	f, err := os.Open(what)
 	if err != nil {
@@ -1101,18 +2070,28 @@ func main() {
 	switch cmd {
 	case "compile":
 		if len(os.Args) < 4 {
-			fmt.Println("compile needs an input and output file")
+			fmt.Println("compile needs an input and output file, and optionally an architecture")
 			os.Exit(1)
 		}
 		content, err = os.ReadFile(os.Args[2])
 		if err != nil {
 			break
 		}
-		err = compile(content, os.Args[3])
+		var dpkgArch string
+		if len(os.Args) > 4 {
+			dpkgArch = os.Args[4]
+		}
+		err = compileForArch(content, os.Args[3], dpkgArch)
 	case "library-version":
 		err = showSeccompLibraryVersion()
 	case "version-info":
 		err = showVersionInfo()
+	case "syscall-name":
+		if len(os.Args) < 3 {
+			fmt.Println("syscall-name needs a syscall number")
+			os.Exit(1)
+		}
+		err = showSyscallName(os.Args[2])
 	case "dump":
 		if len(os.Args) < 4 {
 			fmt.Println("dump needs <file> and <prefix>")
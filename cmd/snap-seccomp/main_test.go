@@ -443,6 +443,10 @@ func (s *snapSeccompSuite) TestCompile(c *C) {
 
 		// trivial allow
 		{"read", "read", Allow},
+		// logged syscalls are allowed
+		{"?read", "read", Allow},
+		{"?read\nread", "read", Allow},
+		{"read\n?read", "read", Allow},
 		{"read\nwrite\nexecve\n", "write", Allow},
 
 		// trivial denial (uses write in allow-list to ensure any
@@ -509,6 +513,77 @@ func (s *snapSeccompSuite) TestCompile(c *C) {
 		{"ioctl\n~ioctl - TIOCSTI\n~ioctl - TIOCLINUX\nioctl - !TIOCSTI", "ioctl;native;-,TIOCLINUX", DenyExplicit},
 		{"ioctl\n~ioctl - TIOCSTI\n~ioctl - TIOCLINUX\nioctl - !TIOCSTI", "ioctl;native;-,TIOCGWINSZ", Allow},
 
+		// nvme admin passthrough only
+		{"ioctl - NVME_IOCTL_ADMIN_CMD", "ioctl;native;-,NVME_IOCTL_ADMIN_CMD", Allow},
+		{"ioctl - NVME_IOCTL_ADMIN64_CMD", "ioctl;native;-,NVME_IOCTL_ADMIN64_CMD", Allow},
+		{"ioctl - NVME_IOCTL_ADMIN_CMD", "ioctl;native;-,NVME_IOCTL_ADMIN64_CMD", Deny},
+
+		// device-mapper, a subset only
+		{"ioctl - DM_DEV_CREATE\nioctl - DM_TABLE_LOAD", "ioctl;native;-,DM_DEV_CREATE", Allow},
+		{"ioctl - DM_DEV_CREATE\nioctl - DM_TABLE_LOAD", "ioctl;native;-,DM_TABLE_LOAD", Allow},
+		{"ioctl - DM_DEV_CREATE\nioctl - DM_TABLE_LOAD", "ioctl;native;-,DM_REMOVE_ALL", Deny},
+
+		// amdgpu
+		{"ioctl - DRM_IOCTL_AMDGPU_INFO", "ioctl;native;-,DRM_IOCTL_AMDGPU_INFO", Allow},
+		{"ioctl - DRM_IOCTL_AMDGPU_INFO", "ioctl;native;-,DRM_IOCTL_AMDGPU_SCHED", Deny},
+
+		// smbus
+		{"ioctl - I2C_SLAVE\nioctl - I2C_SMBUS", "ioctl;native;-,I2C_SMBUS", Allow},
+		{"ioctl - I2C_SLAVE\nioctl - I2C_SMBUS", "ioctl;native;-,I2C_PEC", Deny},
+
+		// alsa hwdep
+		{"ioctl - SNDRV_HWDEP_IOCTL_INFO\nioctl - SNDRV_HWDEP_IOCTL_DSP_LOAD", "ioctl;native;-,SNDRV_HWDEP_IOCTL_DSP_LOAD", Allow},
+		{"ioctl - SNDRV_HWDEP_IOCTL_INFO\nioctl - SNDRV_HWDEP_IOCTL_DSP_LOAD", "ioctl;native;-,SNDRV_HWDEP_IOCTL_DSP_STATUS", Deny},
+
+		// alsa rawmidi
+		{"ioctl - SNDRV_RAWMIDI_IOCTL_PARAMS\nioctl - SNDRV_RAWMIDI_IOCTL_DRAIN", "ioctl;native;-,SNDRV_RAWMIDI_IOCTL_PARAMS", Allow},
+		{"ioctl - SNDRV_RAWMIDI_IOCTL_PARAMS\nioctl - SNDRV_RAWMIDI_IOCTL_DRAIN", "ioctl;native;-,SNDRV_RAWMIDI_IOCTL_STATUS", Deny},
+
+		// alsa sequencer
+		{"ioctl - SNDRV_SEQ_IOCTL_CREATE_PORT\nioctl - SNDRV_TIMER_IOCTL_STATUS", "ioctl;native;-,SNDRV_SEQ_IOCTL_CREATE_PORT", Allow},
+		{"ioctl - SNDRV_SEQ_IOCTL_CREATE_PORT\nioctl - SNDRV_TIMER_IOCTL_STATUS", "ioctl;native;-,SNDRV_TIMER_IOCTL_STATUS", Allow},
+		{"ioctl - SNDRV_SEQ_IOCTL_CREATE_PORT\nioctl - SNDRV_TIMER_IOCTL_STATUS", "ioctl;native;-,SNDRV_SEQ_IOCTL_DELETE_PORT", Deny},
+
+		// bpf
+		{"bpf BPF_MAP_CREATE\nbpf BPF_OBJ_GET", "bpf;native;BPF_MAP_CREATE", Allow},
+		{"bpf BPF_MAP_CREATE\nbpf BPF_OBJ_GET", "bpf;native;BPF_OBJ_GET", Allow},
+		{"bpf BPF_MAP_CREATE\nbpf BPF_OBJ_GET", "bpf;native;5", Deny},
+
+		// compress offload
+		{"ioctl - SNDRV_COMPRESS_SET_PARAMS\nioctl - SNDRV_COMPRESS_TSTAMP", "ioctl;native;-,SNDRV_COMPRESS_TSTAMP", Allow},
+		{"ioctl - SNDRV_COMPRESS_SET_PARAMS\nioctl - SNDRV_COMPRESS_TSTAMP", "ioctl;native;-,SNDRV_COMPRESS_START", Deny},
+
+		// comedi
+		{"ioctl - COMEDI_CMD\nioctl - COMEDI_INSNLIST", "ioctl;native;-,COMEDI_INSNLIST", Allow},
+		{"ioctl - COMEDI_CMD\nioctl - COMEDI_INSNLIST", "ioctl;native;-,COMEDI_BUFCONFIG", Deny},
+
+		// gpio
+		{"ioctl - GPIO_V2_GET_LINE_IOCTL\nioctl - GPIO_V2_LINE_SET_VALUES_IOCTL", "ioctl;native;-,GPIO_V2_LINE_SET_VALUES_IOCTL", Allow},
+		{"ioctl - GPIO_V2_GET_LINE_IOCTL\nioctl - GPIO_V2_LINE_SET_VALUES_IOCTL", "ioctl;native;-,GPIO_V2_LINE_SET_CONFIG_IOCTL", Deny},
+		{"ioctl\n~ioctl - GPIO_V2_LINE_SET_CONFIG_IOCTL", "ioctl;native;-,GPIO_V2_LINE_SET_CONFIG_IOCTL", DenyExplicit},
+		{"ioctl\n~ioctl - 4294967295|GPIO_V2_LINE_SET_CONFIG_IOCTL", "ioctl;native;-,GPIO_V2_LINE_SET_CONFIG_IOCTL", DenyExplicit},
+
+		// mei
+		{"ioctl - IOCTL_MEI_CONNECT_CLIENT\nioctl - IOCTL_MEI_NOTIFY_GET", "ioctl;native;-,IOCTL_MEI_CONNECT_CLIENT", Allow},
+		{"ioctl - IOCTL_MEI_CONNECT_CLIENT\nioctl - IOCTL_MEI_NOTIFY_GET", "ioctl;native;-,IOCTL_MEI_NOTIFY_SET", Deny},
+
+		// tee
+		{"ioctl - TEE_IOC_OPEN_SESSION\nioctl - TEE_IOC_INVOKE", "ioctl;native;-,TEE_IOC_OPEN_SESSION", Allow},
+		{"ioctl - TEE_IOC_OPEN_SESSION\nioctl - TEE_IOC_INVOKE", "ioctl;native;-,TEE_IOC_SUPPL_RECV", Deny},
+
+		// apparmor notify
+		{"ioctl - APPARMOR_NOTIF_RECV\nioctl - APPARMOR_NOTIF_SEND", "ioctl;native;-,APPARMOR_NOTIF_SEND", Allow},
+		{"ioctl - APPARMOR_NOTIF_RECV\nioctl - APPARMOR_NOTIF_SEND", "ioctl;native;-,APPARMOR_NOTIF_SET_FILTER", Deny},
+
+		// mmc
+		{"ioctl - MMC_IOC_CMD", "ioctl;native;-,MMC_IOC_CMD", Allow},
+		{"ioctl - MMC_IOC_CMD", "ioctl;native;-,MMC_IOC_MULTI_CMD", Deny},
+
+		// dvb
+		{"ioctl - FE_SET_PROPERTY\nioctl - DMX_SET_PES_FILTER", "ioctl;native;-,FE_SET_PROPERTY", Allow},
+		{"ioctl - FE_SET_PROPERTY\nioctl - DMX_SET_PES_FILTER", "ioctl;native;-,DMX_SET_PES_FILTER", Allow},
+		{"ioctl - FE_SET_PROPERTY\nioctl - DMX_SET_PES_FILTER", "ioctl;native;-,FE_GET_PROPERTY", Deny},
+
 		// see CVE-2019-7303
 		{"ioctl\n~ioctl - 4294967295|TIOCSTI", "ioctl;native;-,TIOCSTI", DenyExplicit},
 		{"ioctl\n~ioctl - 4294967295|TIOCLINUX", "ioctl;native;-,TIOCLINUX", DenyExplicit},
@@ -521,6 +596,12 @@ func (s *snapSeccompSuite) TestCompile(c *C) {
 		{"mknod - |S_IFIFO", "mknod;native;-,S_IFIFO", Allow},
 		{"mknod - |S_IFIFO", "mknod;native;-,99", Deny},
 
+		// all the flags must be set
+		{"mount - - - |MS_NOSUID|MS_NODEV", "mount;native;-,-,-,6", Allow},
+		{"mount - - - |MS_NOSUID|MS_NODEV", "mount;native;-,-,-,7", Allow},
+		{"mount - - - |MS_NOSUID|MS_NODEV", "mount;native;-,-,-,2", Deny},
+		{"mount - - - |MS_NOSUID|MS_NODEV", "mount;native;-,-,-,0", Deny},
+
 		// test_bad_seccomp_filter_args_prctl
 		{"prctl PR_CAP_AMBIENT_RAISE", "prctl;native;PR_CAP_AMBIENT_RAISE", Allow},
 		{"prctl PR_CAP_AMBIENT_RAISE", "prctl;native;99", Deny},
@@ -585,6 +666,8 @@ func (s *snapSeccompSuite) TestCompileSocket(c *C) {
 		{"socket - SOCK_STREAM", "socket;native;-,99", Deny},
 		{"socket AF_CONN", "socket;native;AF_CONN", Allow},
 		{"socket AF_CONN", "socket;native;99", Deny},
+		{"socket AF_SMC", "socket;native;AF_SMC", Allow},
+		{"socket AF_SMC", "socket;native;99", Deny},
 	} {
 		s.runBpf(c, t.seccompAllowlist, t.bpfInput, t.expected)
 	}
@@ -606,6 +689,9 @@ func (s *snapSeccompSuite) TestCompileBadInput(c *C) {
 		{"mknod - |S_IFIFOO", `cannot parse line: cannot parse token "S_IFIFOO" \(line "mknod - |S_IFIFOO"\)`},
 		{"mknod - |S_!FIFO", `cannot parse line: cannot parse token "S_IFIFO" \(line "mknod - |S_!FIFO"\)`},
 
+		// a typo in one of the flags
+		{"mount - - - |MS_NOSUID|MS_NODEVV", `cannot parse line: cannot parse token "\|MS_NOSUID\|MS_NODEVV" \(line "mount - - - \|MS_NOSUID\|MS_NODEVV"\)`},
+
 		// test_bad_seccomp_filter_args_null
 		{"socket S\x00CK_STREAM", `cannot parse line: cannot parse token .*`},
 		{"socket SOCK_STREAM\x00bad stuff", `cannot parse line: cannot parse token .*`},
@@ -934,6 +1020,40 @@ func (s *snapSeccompSuite) TestCompatArchWorks(c *C) {
 	}
 }
 
+func (s *snapSeccompSuite) TestCompileForArch(c *C) {
+	dir := c.MkDir()
+	allPath := filepath.Join(dir, "all")
+	c.Assert(main.Compile([]byte("read\n"), allPath), IsNil)
+	all, err := os.ReadFile(allPath)
+	c.Assert(err, IsNil)
+
+	nativePath := filepath.Join(dir, "native")
+	c.Assert(main.CompileForArch([]byte("read\n"), nativePath, arch.DpkgArchitecture()), IsNil)
+	native, err := os.ReadFile(nativePath)
+	c.Assert(err, IsNil)
+	c.Check(len(native) > 0, Equals, true)
+	if arch.DpkgArchitecture() == "amd64" {
+		// the compat i386 architecture is left out
+		c.Check(len(native) < len(all), Equals, true)
+
+		i386Path := filepath.Join(dir, "i386")
+		c.Assert(main.CompileForArch([]byte("read\n"), i386Path, "i386"), IsNil)
+		i386, err := os.ReadFile(i386Path)
+		c.Assert(err, IsNil)
+		c.Check(i386, Not(DeepEquals), native)
+	}
+
+	err = main.CompileForArch([]byte("read\n"), filepath.Join(dir, "bad"), "no-such-arch")
+	c.Check(err, ErrorMatches, `unknown architecture "no-such-arch"`)
+
+	foreign := "s390x"
+	if arch.DpkgArchitecture() == foreign || arch.DpkgKernelArchitecture() == foreign {
+		foreign = "amd64"
+	}
+	err = main.CompileForArch([]byte("read\n"), filepath.Join(dir, "foreign"), foreign)
+	c.Check(err, ErrorMatches, fmt.Sprintf(`cannot compile for architecture %q on this host`, foreign))
+}
+
 func (s *snapSeccompSuite) TestExportBpfErrors(c *C) {
 	fout, err := os.Create(filepath.Join(c.MkDir(), "filter"))
 	c.Assert(err, IsNil)
@@ -966,3 +1086,11 @@ uname
 	c.Assert(err, IsNil)
 	c.Check(fi.Size() > 10, Equals, true)
 }
+
+func (s *snapSeccompSuite) TestShowSyscallNameErrors(c *C) {
+	err := main.ShowSyscallName("mount")
+	c.Check(err, ErrorMatches, `cannot parse syscall number "mount": .*`)
+
+	err = main.ShowSyscallName("99999")
+	c.Check(err, ErrorMatches, `cannot resolve syscall number 99999: .*`)
+}
@@ -20,13 +20,21 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/jessevdk/go-flags"
 
+	"github.com/snapcore/snapd/client"
 	"github.com/snapcore/snapd/i18n"
 )
 
 type cmdConnect struct {
 	waitMixin
+	Audit       bool `long:"audit"`
+	DryRun      bool `long:"dry-run"`
 	Positionals struct {
 		PlugSpec connectPlugSpec `required:"yes"`
 		SlotSpec connectSlotSpec
@@ -52,12 +60,27 @@ $ snap connect <snap>:<plug>
 
 Connects the provided plug to the slot in the core snap with a name matching
 the plug name.
+
+With --audit, the AppArmor rules and the seccomp rules which the connection
+adds to the apps and hooks bound to the plug log the accesses and system calls
+they allow, for as long as the connection is established. The rules are still
+enforced. The logged messages can be listed with 'snap debug denials <snap>'.
+
+With --dry-run, nothing is connected: the security policy which the connection
+would add to the snaps of the plug and of the slot is printed instead, such as
+AppArmor rules, allowed system calls, tagged devices and mount entries. A
+connection which the policy would refuse is reported as an error.
 `)
 
 func init() {
 	addCommand("connect", shortConnectHelp, longConnectHelp, func() flags.Commander {
 		return &cmdConnect{}
-	}, waitDescs, []argDesc{
+	}, waitDescs.also(map[string]string{
+		// TRANSLATORS: This should not start with a lowercase letter.
+		"audit": i18n.G("Log the accesses and system calls allowed by the rules of the plug"),
+		// TRANSLATORS: This should not start with a lowercase letter.
+		"dry-run": i18n.G("Print the security policy the connection would add without connecting"),
+	}), []argDesc{
 		// TRANSLATORS: This needs to begin with < and end with >
 		{name: i18n.G("<snap>:<plug>")},
 		// TRANSLATORS: This needs to begin with < and end with >
@@ -77,7 +100,20 @@ func (x *cmdConnect) Execute(args []string) error {
 		x.Positionals.PlugSpec.Snap = ""
 	}
 
-	id, err := x.client.Connect(x.Positionals.PlugSpec.Snap, x.Positionals.PlugSpec.Name, x.Positionals.SlotSpec.Snap, x.Positionals.SlotSpec.Name)
+	if x.DryRun {
+		if x.Audit {
+			return errors.New(i18n.G("cannot use --audit with --dry-run"))
+		}
+		connPolicy, err := x.client.ConnectDryRun(x.Positionals.PlugSpec.Snap, x.Positionals.PlugSpec.Name, x.Positionals.SlotSpec.Snap, x.Positionals.SlotSpec.Name)
+		if err != nil {
+			return err
+		}
+		printConnectionPolicy(connPolicy)
+		return nil
+	}
+
+	opts := &client.ConnectOptions{Audit: x.Audit}
+	id, err := x.client.Connect(x.Positionals.PlugSpec.Snap, x.Positionals.PlugSpec.Name, x.Positionals.SlotSpec.Snap, x.Positionals.SlotSpec.Name, opts)
 	if err != nil {
 		return err
 	}
@@ -91,3 +127,51 @@ func (x *cmdConnect) Execute(args []string) error {
 
 	return nil
 }
+
+var connectionPolicyHeaders = map[string]string{
+	"apparmor": i18n.G("AppArmor rules"),
+	"seccomp":  i18n.G("Allowed system calls"),
+	"udev":     i18n.G("Tagged devices"),
+	"mount":    i18n.G("Mount entries"),
+	"dbus":     i18n.G("D-Bus policy"),
+}
+
+func printConnectionPolicy(connPolicy *client.ConnectionPolicy) {
+	plug := fmt.Sprintf("%s:%s", connPolicy.Plug.Snap, connPolicy.Plug.Name)
+	slot := fmt.Sprintf("%s:%s", connPolicy.Slot.Snap, connPolicy.Slot.Name)
+	if connPolicy.Connected {
+		fmt.Fprintf(Stdout, i18n.G("Plug %s is already connected to %s (%s), the connection grants:\n"), plug, slot, connPolicy.Interface)
+	} else {
+		fmt.Fprintf(Stdout, i18n.G("Connecting %s to %s (%s) would add:\n"), plug, slot, connPolicy.Interface)
+	}
+	if len(connPolicy.Policies) == 0 {
+		fmt.Fprintf(Stdout, "  %s\n", i18n.G("no security policy"))
+		return
+	}
+	for _, policy := range connPolicy.Policies {
+		header := connectionPolicyHeaders[policy.Backend]
+		if header == "" {
+			header = policy.Backend
+		}
+		fmt.Fprintf(Stdout, "\n%s:\n", header)
+		snippets := make(map[string][]string)
+		for _, part := range policy.Parts {
+			for tag, values := range part.Snippets {
+				snippets[tag] = append(snippets[tag], values...)
+			}
+		}
+		tags := make([]string, 0, len(snippets))
+		for tag := range snippets {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		for _, tag := range tags {
+			fmt.Fprintf(Stdout, "  %s:\n", tag)
+			for _, snippet := range snippets[tag] {
+				for _, line := range strings.Split(strings.Trim(snippet, "\n"), "\n") {
+					fmt.Fprintf(Stdout, "    %s\n", line)
+				}
+			}
+		}
+	}
+}
@@ -53,9 +53,23 @@ $ snap connect <snap>:<plug>
 Connects the provided plug to the slot in the core snap with a name matching
 the plug name.
 
+With --audit, the AppArmor rules and the seccomp rules which the connection
+adds to the apps and hooks bound to the plug log the accesses and system calls
+they allow, for as long as the connection is established. The rules are still
+enforced. The logged messages can be listed with 'snap debug denials <snap>'.
+
+With --dry-run, nothing is connected: the security policy which the connection
+would add to the snaps of the plug and of the slot is printed instead, such as
+AppArmor rules, allowed system calls, tagged devices and mount entries. A
+connection which the policy would refuse is reported as an error.
+
 [connect command options]
       --no-wait          Do not wait for the operation to finish but just print
                          the change id.
+      --audit            Log the accesses and system calls allowed by the rules
+                         of the plug
+      --dry-run          Print the security policy the connection would add
+                         without connecting
 `
 	s.testSubCommandHelp(c, "connect", msg)
 }
@@ -94,6 +108,145 @@ func (s *SnapSuite) TestConnectExplicitEverything(c *C) {
 	c.Assert(rest, DeepEquals, []string{})
 }
 
+func (s *SnapSuite) TestConnectAudit(c *C) {
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/interfaces":
+			c.Check(r.Method, Equals, "POST")
+			c.Check(DecodedRequestBody(c, r), DeepEquals, map[string]any{
+				"action": "connect",
+				"audit":  true,
+				"plugs": []any{
+					map[string]any{
+						"snap": "producer",
+						"plug": "plug",
+					},
+				},
+				"slots": []any{
+					map[string]any{
+						"snap": "consumer",
+						"slot": "slot",
+					},
+				},
+			})
+			w.WriteHeader(202)
+			fmt.Fprintln(w, `{"type":"async", "status-code": 202, "change": "zzz"}`)
+		case "/v2/changes/zzz":
+			c.Check(r.Method, Equals, "GET")
+			fmt.Fprintln(w, `{"type":"sync", "result":{"ready": true, "status": "Done"}}`)
+		default:
+			c.Fatalf("unexpected path %q", r.URL.Path)
+		}
+	})
+	rest, err := Parser(Client()).ParseArgs([]string{"connect", "--audit", "producer:plug", "consumer:slot"})
+	c.Assert(err, IsNil)
+	c.Assert(rest, DeepEquals, []string{})
+}
+
+func (s *SnapSuite) TestConnectDryRun(c *C) {
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/interfaces":
+			c.Check(r.Method, Equals, "POST")
+			c.Check(DecodedRequestBody(c, r), DeepEquals, map[string]any{
+				"action":  "connect",
+				"dry-run": true,
+				"plugs": []any{
+					map[string]any{
+						"snap": "consumer",
+						"plug": "plug",
+					},
+				},
+				"slots": []any{
+					map[string]any{
+						"snap": "",
+						"slot": "",
+					},
+				},
+			})
+			fmt.Fprintln(w, `{"type": "sync", "result": {
+				"interface": "test",
+				"plug": {"snap": "consumer", "plug": "plug"},
+				"slot": {"snap": "core", "slot": "test"},
+				"policies": [{
+					"backend": "apparmor",
+					"parts": [{
+						"interface": "test",
+						"plug": {"snap": "consumer", "plug": "plug"},
+						"slot": {"snap": "core", "slot": "test"},
+						"snippets": {
+							"snap.consumer.app": ["# Description: test\n/dev/foo rw,\n"],
+							"snap.consumer.hook.configure": ["# Description: test\n/dev/foo rw,\n"]
+						}
+					}]
+				}, {
+					"backend": "seccomp",
+					"parts": [{
+						"interface": "test",
+						"plug": {"snap": "consumer", "plug": "plug"},
+						"slot": {"snap": "core", "slot": "test"},
+						"snippets": {"snap.consumer.app": ["ioctl"]}
+					}]
+				}, {
+					"backend": "udev",
+					"parts": [{
+						"interface": "test",
+						"plug": {"snap": "consumer", "plug": "plug"},
+						"slot": {"snap": "core", "slot": "test"},
+						"snippets": {"consumer": ["# test\nKERNEL==\"foo\", TAG+=\"snap_consumer_app\""]}
+					}]
+				}]
+			}}`)
+		default:
+			c.Fatalf("unexpected path %q", r.URL.Path)
+		}
+	})
+	rest, err := Parser(Client()).ParseArgs([]string{"connect", "--dry-run", "consumer:plug"})
+	c.Assert(err, IsNil)
+	c.Assert(rest, DeepEquals, []string{})
+	c.Check(s.Stdout(), Equals, `Connecting consumer:plug to core:test (test) would add:
+
+AppArmor rules:
+  snap.consumer.app:
+    # Description: test
+    /dev/foo rw,
+  snap.consumer.hook.configure:
+    # Description: test
+    /dev/foo rw,
+
+Allowed system calls:
+  snap.consumer.app:
+    ioctl
+
+Tagged devices:
+  consumer:
+    # test
+    KERNEL=="foo", TAG+="snap_consumer_app"
+`)
+	c.Check(s.Stderr(), Equals, "")
+}
+
+func (s *SnapSuite) TestConnectDryRunAlreadyConnected(c *C) {
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Path, Equals, "/v2/interfaces")
+		fmt.Fprintln(w, `{"type": "sync", "result": {
+			"interface": "test",
+			"plug": {"snap": "consumer", "plug": "plug"},
+			"slot": {"snap": "producer", "slot": "slot"},
+			"connected": true,
+			"policies": []
+		}}`)
+	})
+	_, err := Parser(Client()).ParseArgs([]string{"connect", "--dry-run", "consumer:plug", "producer:slot"})
+	c.Assert(err, IsNil)
+	c.Check(s.Stdout(), Equals, `Plug consumer:plug is already connected to producer:slot (test), the connection grants:
+  no security policy
+`)
+
+	_, err = Parser(Client()).ParseArgs([]string{"connect", "--dry-run", "--audit", "consumer:plug"})
+	c.Assert(err, ErrorMatches, "cannot use --audit with --dry-run")
+}
+
 func (s *SnapSuite) TestConnectExplicitPlugImplicitSlot(c *C) {
 	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
@@ -34,6 +34,7 @@ import (
 type cmdConnections struct {
 	clientMixin
 	All         bool `long:"all"`
+	Dangerous   bool `long:"dangerous"`
 	Positionals struct {
 		Snap installedSnapName
 	} `positional-args:"true"`
@@ -52,13 +53,18 @@ $ snap connections <snap>
 
 Lists connected and unconnected plugs and slots for the specified
 snap.
+
+Pass --dangerous to only list plugs, slots and connections of
+super-privileged interfaces, which snaps can only use when allowed
+by a snap declaration.
 `)
 
 func init() {
 	addCommand("connections", shortConnectionsHelp, longConnectionsHelp, func() flags.Commander {
 		return &cmdConnections{}
 	}, map[string]string{
-		"all": i18n.G("Show connected and unconnected plugs and slots"),
+		"all":       i18n.G("Show connected and unconnected plugs and slots"),
+		"dangerous": i18n.G("Only show super-privileged interfaces"),
 	}, []argDesc{{
 		// TRANSLATORS: This needs to be wrapped in <>s.
 		name: "<snap>",
@@ -146,6 +152,9 @@ func (x *cmdConnections) Execute(args []string) error {
 	opts := client.ConnectionOptions{
 		All: x.All,
 	}
+	if x.Dangerous {
+		opts.SecurityLevel = "high"
+	}
 	wanted := string(x.Positionals.Snap)
 	if wanted != "" {
 		if x.All {
@@ -464,6 +464,62 @@ func (s *SnapSuite) TestConnectionsFiltering(c *C) {
 	c.Assert(rest, DeepEquals, []string{"--all"})
 }
 
+func (s *SnapSuite) TestConnectionsDangerous(c *C) {
+	result := client.Connections{
+		Established: []client.Connection{
+			{
+				Slot:      client.SlotRef{Snap: "core", Name: "kernel-module-control"},
+				Plug:      client.PlugRef{Snap: "keyboard-lights", Name: "kernel-module-control"},
+				Interface: "kernel-module-control",
+				Manual:    true,
+			},
+		},
+		Plugs: []client.Plug{
+			{
+				Snap:      "keyboard-lights",
+				Name:      "kernel-module-control",
+				Interface: "kernel-module-control",
+				Connections: []client.SlotRef{
+					{Snap: "core", Name: "kernel-module-control"},
+				},
+			},
+		},
+	}
+	query := url.Values{
+		"security-level": []string{"high"},
+	}
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, Equals, "GET")
+		c.Check(r.URL.Path, Equals, "/v2/connections")
+		c.Check(r.URL.Query(), DeepEquals, query)
+		EncodeResponseBody(c, w, map[string]any{
+			"type":   "sync",
+			"result": result,
+		})
+	})
+
+	rest, err := Parser(Client()).ParseArgs([]string{"connections", "--dangerous"})
+	c.Assert(err, IsNil)
+	c.Assert(rest, DeepEquals, []string{})
+	expectedStdout := "" +
+		"Interface              Plug                                   Slot                    Notes\n" +
+		"kernel-module-control  keyboard-lights:kernel-module-control  :kernel-module-control  manual\n"
+	c.Assert(s.Stdout(), Equals, expectedStdout)
+	c.Assert(s.Stderr(), Equals, "")
+
+	s.ResetStdStreams()
+
+	query = url.Values{
+		"security-level": []string{"high"},
+		"select":         []string{"all"},
+		"snap":           []string{"keyboard-lights"},
+	}
+	rest, err = Parser(Client()).ParseArgs([]string{"connections", "--dangerous", "keyboard-lights"})
+	c.Assert(err, IsNil)
+	c.Assert(rest, DeepEquals, []string{})
+	c.Assert(s.Stdout(), Equals, expectedStdout)
+}
+
 func (s *SnapSuite) TestConnectionsSorting(c *C) {
 	result := client.Connections{
 		Established: []client.Connection{
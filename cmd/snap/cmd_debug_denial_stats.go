@@ -0,0 +1,103 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/snapcore/snapd/i18n"
+)
+
+type cmdDebugDenialStats struct {
+	clientMixin
+
+	Positionals struct {
+		Snap installedSnapName `positional-arg-name:"<snap>"`
+	} `positional-args:"true"`
+}
+
+const longDebugDenialStatsHelp = `
+The denial-stats command prints how many times the AppArmor and seccomp
+profiles of the apps and hooks of snaps denied each kind of operation, along
+with the interfaces connected to their plugs. It requires root access.
+
+Denials are only collected once enabled with:
+    snap set system experimental.denial-stats=true
+`
+
+func init() {
+	cmd := addDebugCommand("denial-stats",
+		"(internal) print statistics of the denials of snaps",
+		longDebugDenialStatsHelp,
+		func() flags.Commander {
+			return &cmdDebugDenialStats{}
+		}, nil, []argDesc{{
+			// TRANSLATORS: This needs to begin with < and end with >
+			name: i18n.G("<snap>"),
+			// TRANSLATORS: This should not start with a lowercase letter.
+			desc: i18n.G("Only print the denials of the given snap"),
+		}})
+	cmd.hidden = true
+}
+
+// debugDenialStat mirrors ifacestate.DenialStat as returned by the API.
+type debugDenialStat struct {
+	Snap       string   `json:"snap"`
+	App        string   `json:"app"`
+	Hook       string   `json:"hook"`
+	Backend    string   `json:"backend"`
+	Operation  string   `json:"operation"`
+	Count      int      `json:"count"`
+	Interfaces []string `json:"interfaces"`
+}
+
+func (x *cmdDebugDenialStats) Execute(args []string) error {
+	if len(args) > 0 {
+		return ErrExtraArgs
+	}
+
+	var stats []*debugDenialStat
+	if err := x.client.DebugDenialStats(string(x.Positionals.Snap), &stats); err != nil {
+		return err
+	}
+	if len(stats) == 0 {
+		fmt.Fprintln(Stderr, i18n.G("No denials recorded."))
+		return nil
+	}
+
+	w := tabWriter()
+	defer w.Flush()
+	fmt.Fprintln(w, i18n.G("Snap\tApp\tBackend\tOperation\tCount\tInterfaces"))
+	for _, stat := range stats {
+		app := stat.App
+		if stat.Hook != "" {
+			app = "hook:" + stat.Hook
+		}
+		ifaces := "-"
+		if len(stat.Interfaces) > 0 {
+			ifaces = strings.Join(stat.Interfaces, ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", stat.Snap, app, stat.Backend, stat.Operation, stat.Count, ifaces)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package main_test
+
+import (
+	"net/http"
+	"net/url"
+
+	. "gopkg.in/check.v1"
+
+	snap "github.com/snapcore/snapd/cmd/snap"
+)
+
+func (s *SnapSuite) TestDebugDenialStats(c *C) {
+	n := 0
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		n++
+		c.Check(r.Method, Equals, "GET")
+		c.Check(r.URL.Path, Equals, "/v2/debug/denial-stats")
+		c.Check(r.URL.Query(), DeepEquals, url.Values{"snap": {"foo"}})
+		EncodeResponseBody(c, w, map[string]any{
+			"type": "sync",
+			"result": []map[string]any{{
+				"snap":       "foo",
+				"app":        "app",
+				"backend":    "apparmor",
+				"operation":  "open",
+				"count":      3,
+				"interfaces": []string{"home", "network"},
+			}, {
+				"snap":      "foo",
+				"hook":      "configure",
+				"backend":   "seccomp",
+				"operation": "syscall:165",
+				"count":     1,
+			}},
+		})
+	})
+	rest, err := snap.Parser(snap.Client()).ParseArgs([]string{"debug", "denial-stats", "foo"})
+	c.Assert(err, IsNil)
+	c.Assert(rest, DeepEquals, []string{})
+	c.Check(s.Stdout(), Equals, `
+Snap  App             Backend   Operation    Count  Interfaces
+foo   app             apparmor  open         3      home,network
+foo   hook:configure  seccomp   syscall:165  1      -
+`[1:])
+	c.Check(s.Stderr(), Equals, "")
+	c.Check(n, Equals, 1)
+}
+
+func (s *SnapSuite) TestDebugDenialStatsNone(c *C) {
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Path, Equals, "/v2/debug/denial-stats")
+		c.Check(r.URL.RawQuery, Equals, "")
+		EncodeResponseBody(c, w, map[string]any{
+			"type":   "sync",
+			"result": []map[string]any{},
+		})
+	})
+	rest, err := snap.Parser(snap.Client()).ParseArgs([]string{"debug", "denial-stats"})
+	c.Assert(err, IsNil)
+	c.Assert(rest, DeepEquals, []string{})
+	c.Check(s.Stdout(), Equals, "")
+	c.Check(s.Stderr(), Equals, "No denials recorded.\n")
+}
@@ -0,0 +1,269 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/snapcore/snapd/arch"
+	"github.com/snapcore/snapd/client"
+	"github.com/snapcore/snapd/i18n"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/osutil"
+	"github.com/snapcore/snapd/snapdtool"
+	"github.com/snapcore/snapd/strutil"
+)
+
+type cmdDebugDenials struct {
+	clientMixin
+	Positional struct {
+		Snap installedSnapName `positional-arg-name:"<snap>" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+var shortDebugDenialsHelp = i18n.G("Show the messages logged for connections in audit mode")
+var longDebugDenialsHelp = i18n.G(`
+The denials command collects from the kernel log the AppArmor and seccomp
+messages of the given snap, and attributes them to the connections of the snap
+which were established with 'snap connect --audit'.
+
+AppArmor messages are attributed to a connection when they come from an app or
+hook bound to its plug. Seccomp messages are attributed to a connection when
+the interface of the connection allows the logged system call. System calls
+are only resolved for the native architecture of the system.
+`)
+
+func init() {
+	addDebugCommand("denials", shortDebugDenialsHelp, longDebugDenialsHelp, func() flags.Commander {
+		return &cmdDebugDenials{}
+	}, nil, []argDesc{{
+		// TRANSLATORS: This needs to begin with < and end with >
+		name: i18n.G("<snap>"),
+		// TRANSLATORS: This should not start with a lowercase letter.
+		desc: i18n.G("Snap whose audited connections to show the messages of"),
+	}})
+}
+
+// kernelLog returns the messages of the kernel log of the current boot.
+func kernelLog() ([]byte, error) {
+	stdout, stderr, err := osutil.RunSplitOutput("journalctl", "-k", "-b", "-o", "cat", "--no-pager")
+	if err != nil {
+		return nil, osutil.OutputErr(stderr, err)
+	}
+	return stdout, nil
+}
+
+// auditArchs maps the dpkg architectures to the architecture identifiers
+// found in the seccomp messages of the kernel log, see AUDIT_ARCH_* in
+// linux/audit.h.
+var auditArchs = map[string]string{
+	"amd64":   "c000003e",
+	"arm64":   "c00000b7",
+	"armhf":   "40000028",
+	"i386":    "40000003",
+	"ppc64el": "c0000015",
+	"riscv64": "c00000f3",
+	"s390x":   "80000016",
+}
+
+// seccompSyscallName returns the name of the syscall with the given number
+// on the native architecture, as resolved by snap-seccomp.
+var seccompSyscallName = func(number string) (string, error) {
+	snapSeccomp, err := snapdtool.InternalToolPath("snap-seccomp")
+	if err != nil {
+		return "", err
+	}
+	stdout, stderr, err := osutil.RunSplitOutput(snapSeccomp, "syscall-name", number)
+	if err != nil {
+		return "", osutil.OutputErr(stderr, err)
+	}
+	return strings.TrimSpace(string(stdout)), nil
+}
+
+// appArmorLogProfile returns the profile of an AppArmor log message about an
+// access, without the name of the child profile, if any.
+func appArmorLogProfile(line string) (profile string, ok bool) {
+	switch {
+	case strings.Contains(line, `apparmor="DENIED"`):
+	case strings.Contains(line, `apparmor="ALLOWED"`):
+	case strings.Contains(line, `apparmor="AUDIT"`):
+	default:
+		return "", false
+	}
+	_, rest, ok := strings.Cut(line, ` profile="`)
+	if !ok {
+		return "", false
+	}
+	profile, _, ok = strings.Cut(rest, `"`)
+	if !ok {
+		return "", false
+	}
+	profile, _, _ = strings.Cut(profile, "//")
+	return profile, true
+}
+
+// isSeccompLogOf returns whether the log line is a seccomp message about a
+// process running an executable of the given snap.
+func isSeccompLogOf(line, snapName string) bool {
+	if !strings.Contains(line, "type=1326") {
+		return false
+	}
+	return strings.Contains(line, fmt.Sprintf(`exe="/snap/%s/`, snapName))
+}
+
+// logField returns the value of the given unquoted field of a kernel log
+// message, such as "syscall".
+func logField(line, key string) string {
+	_, rest, ok := strings.Cut(line, " "+key+"=")
+	if !ok {
+		return ""
+	}
+	value, _, _ := strings.Cut(rest, " ")
+	return value
+}
+
+// syscallResolver resolves the syscalls of the seccomp messages of the kernel
+// log and the interfaces allowing them, caching the results.
+type syscallResolver struct {
+	names      map[string]string
+	interfaces map[string][]string
+}
+
+// interfacesAllowing returns the interfaces which allow the syscall of the
+// given seccomp message. Nothing is returned for messages about another
+// architecture than the native one, or when the syscall cannot be resolved.
+func (r *syscallResolver) interfacesAllowing(line string) []string {
+	if logField(line, "arch") != auditArchs[arch.DpkgArchitecture()] {
+		return nil
+	}
+	number := logField(line, "syscall")
+	if number == "" {
+		return nil
+	}
+	name, ok := r.names[number]
+	if !ok {
+		var err error
+		name, err = seccompSyscallName(number)
+		if err != nil {
+			name = ""
+		}
+		r.names[number] = name
+	}
+	if name == "" {
+		return nil
+	}
+	ifaces, ok := r.interfaces[name]
+	if !ok {
+		ifaces = builtin.InterfacesGrantingSyscall(name)
+		r.interfaces[name] = ifaces
+	}
+	return ifaces
+}
+
+func (x *cmdDebugDenials) Execute(args []string) error {
+	if len(args) > 0 {
+		return ErrExtraArgs
+	}
+
+	snapName := string(x.Positional.Snap)
+	conns, err := x.client.Connections(&client.ConnectionOptions{Snap: snapName})
+	if err != nil {
+		return err
+	}
+
+	plugApps := make(map[string][]string, len(conns.Plugs))
+	for _, plug := range conns.Plugs {
+		plugApps[plug.Snap+":"+plug.Name] = plug.Apps
+	}
+
+	var audited []client.Connection
+	for _, conn := range conns.Established {
+		if conn.Audit && conn.Plug.Snap == snapName {
+			audited = append(audited, conn)
+		}
+	}
+	if len(audited) == 0 {
+		return fmt.Errorf(i18n.G("snap %q has no connections in audit mode"), snapName)
+	}
+
+	output, err := kernelLog()
+	if err != nil {
+		return fmt.Errorf(i18n.G("cannot read the kernel log: %v"), err)
+	}
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf(i18n.G("cannot read the kernel log: %v"), err)
+	}
+
+	resolver := &syscallResolver{
+		names:      make(map[string]string),
+		interfaces: make(map[string][]string),
+	}
+	for i, conn := range audited {
+		if i > 0 {
+			fmt.Fprintln(Stdout)
+		}
+		fmt.Fprintf(Stdout, "%s:%s %s:%s (%s):\n", conn.Plug.Snap, conn.Plug.Name, conn.Slot.Snap, conn.Slot.Name, conn.Interface)
+
+		apps := plugApps[conn.Plug.Snap+":"+conn.Plug.Name]
+		attributable := func(line string) bool {
+			if isSeccompLogOf(line, snapName) {
+				// the seccomp messages carry the syscall of the
+				// rule, not the connection adding it
+				return strutil.ListContains(resolver.interfacesAllowing(line), conn.Interface)
+			}
+			profile, ok := appArmorLogProfile(line)
+			if !ok {
+				return false
+			}
+			// plugs are bound to all the hooks of the snap
+			if strings.HasPrefix(profile, fmt.Sprintf("snap.%s.hook.", snapName)) {
+				return true
+			}
+			for _, app := range apps {
+				if profile == fmt.Sprintf("snap.%s.%s", snapName, app) {
+					return true
+				}
+			}
+			return false
+		}
+
+		found := false
+		for _, line := range lines {
+			if attributable(line) {
+				fmt.Fprintf(Stdout, "  %s\n", line)
+				found = true
+			}
+		}
+		if !found {
+			fmt.Fprintln(Stdout, i18n.G("  no messages found"))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package main_test
+
+import (
+	"fmt"
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/arch/archtest"
+	snap "github.com/snapcore/snapd/cmd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+const debugDenialsConnectionsJSON = `{
+	"type": "sync",
+	"result": {
+		"established": [
+			{
+				"slot": {"snap": "core", "slot": "network"},
+				"plug": {"snap": "foo", "plug": "network"},
+				"interface": "network",
+				"audit": true
+			},
+			{
+				"slot": {"snap": "core", "slot": "home"},
+				"plug": {"snap": "foo", "plug": "home"},
+				"interface": "home"
+			},
+			{
+				"slot": {"snap": "core", "slot": "fuse-support"},
+				"plug": {"snap": "foo", "plug": "fuse-support"},
+				"interface": "fuse-support",
+				"audit": true
+			}
+		],
+		"plugs": [
+			{"snap": "foo", "plug": "network", "interface": "network", "apps": ["app"]},
+			{"snap": "foo", "plug": "home", "interface": "home", "apps": ["app", "other"]},
+			{"snap": "foo", "plug": "fuse-support", "interface": "fuse-support", "apps": ["other"]}
+		]
+	}
+}`
+
+const debugDenialsKernelLog = `audit: type=1400 audit(1.1:1): apparmor="ALLOWED" operation="create" class="net" profile="snap.foo.app" pid=1 comm="app" family="inet" sock_type="stream"
+audit: type=1400 audit(1.1:2): apparmor="DENIED" operation="open" class="file" profile="snap.foo.other" name="/etc/shadow" pid=2 comm="other"
+audit: type=1400 audit(1.1:3): apparmor="ALLOWED" operation="open" class="file" profile="snap.foo.hook.configure" name="/etc/hosts" pid=3 comm="configure"
+audit: type=1400 audit(1.1:4): apparmor="ALLOWED" operation="exec" class="file" profile="snap.foo.app//fusermount" name="/bin/true" pid=4 comm="app"
+audit: type=1400 audit(1.1:5): apparmor="DENIED" operation="open" class="file" profile="snap.bar.app" name="/etc/hosts" pid=5 comm="app"
+audit: type=1326 audit(1.1:6): auid=1000 uid=1000 gid=1000 ses=1 pid=6 comm="app" exe="/snap/foo/x1/bin/app" sig=0 arch=c000003e syscall=165 compat=0 ip=0x1 code=0x7ffc0000
+audit: type=1326 audit(1.1:7): auid=1000 uid=1000 gid=1000 ses=1 pid=7 comm="app" exe="/snap/bar/x1/bin/app" sig=0 arch=c000003e syscall=165 compat=0 ip=0x1 code=0x7ffc0000
+audit: type=1326 audit(1.1:8): auid=1000 uid=1000 gid=1000 ses=1 pid=8 comm="app" exe="/snap/foo/x1/bin/app" sig=0 arch=c000003e syscall=41 compat=0 ip=0x1 code=0x7ffc0000
+audit: type=1326 audit(1.1:9): auid=1000 uid=1000 gid=1000 ses=1 pid=9 comm="app" exe="/snap/foo/x1/bin/app" sig=0 arch=40000003 syscall=21 compat=1 ip=0x1 code=0x7ffc0000
+audit: type=1400 audit(1.1:10): apparmor="AUDIT" operation="mount" class="mount" profile="snap.foo.other" name="/mnt/" pid=10 comm="other" fstype="fuse.sshfs"
+some unrelated kernel message
+`
+
+func (s *SnapSuite) mockDebugDenialsSyscalls(c *C) {
+	s.AddCleanup(archtest.MockArchitecture("amd64"))
+	s.AddCleanup(snap.MockSeccompSyscallName(func(number string) (string, error) {
+		switch number {
+		case "41":
+			return "socket", nil
+		case "165":
+			return "mount", nil
+		}
+		return "", fmt.Errorf("unknown syscall %s", number)
+	}))
+}
+
+func (s *SnapSuite) TestDebugDenials(c *C) {
+	s.mockDebugDenialsSyscalls(c)
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, Equals, "GET")
+		c.Check(r.URL.Path, Equals, "/v2/connections")
+		c.Check(r.URL.Query().Get("snap"), Equals, "foo")
+		fmt.Fprintln(w, debugDenialsConnectionsJSON)
+	})
+	journalctl := testutil.MockCommand(c, "journalctl", fmt.Sprintf("cat <<'EOF'\n%sEOF", debugDenialsKernelLog))
+	defer journalctl.Restore()
+
+	rest, err := snap.Parser(snap.Client()).ParseArgs([]string{"debug", "denials", "foo"})
+	c.Assert(err, IsNil)
+	c.Assert(rest, DeepEquals, []string{})
+	c.Check(s.Stdout(), Equals, `foo:network core:network (network):
+  audit: type=1400 audit(1.1:1): apparmor="ALLOWED" operation="create" class="net" profile="snap.foo.app" pid=1 comm="app" family="inet" sock_type="stream"
+  audit: type=1400 audit(1.1:3): apparmor="ALLOWED" operation="open" class="file" profile="snap.foo.hook.configure" name="/etc/hosts" pid=3 comm="configure"
+  audit: type=1400 audit(1.1:4): apparmor="ALLOWED" operation="exec" class="file" profile="snap.foo.app//fusermount" name="/bin/true" pid=4 comm="app"
+  audit: type=1326 audit(1.1:8): auid=1000 uid=1000 gid=1000 ses=1 pid=8 comm="app" exe="/snap/foo/x1/bin/app" sig=0 arch=c000003e syscall=41 compat=0 ip=0x1 code=0x7ffc0000
+
+foo:fuse-support core:fuse-support (fuse-support):
+  audit: type=1400 audit(1.1:2): apparmor="DENIED" operation="open" class="file" profile="snap.foo.other" name="/etc/shadow" pid=2 comm="other"
+  audit: type=1400 audit(1.1:3): apparmor="ALLOWED" operation="open" class="file" profile="snap.foo.hook.configure" name="/etc/hosts" pid=3 comm="configure"
+  audit: type=1326 audit(1.1:6): auid=1000 uid=1000 gid=1000 ses=1 pid=6 comm="app" exe="/snap/foo/x1/bin/app" sig=0 arch=c000003e syscall=165 compat=0 ip=0x1 code=0x7ffc0000
+  audit: type=1400 audit(1.1:10): apparmor="AUDIT" operation="mount" class="mount" profile="snap.foo.other" name="/mnt/" pid=10 comm="other" fstype="fuse.sshfs"
+`)
+	c.Check(s.Stderr(), Equals, "")
+	c.Check(journalctl.Calls(), DeepEquals, [][]string{
+		{"journalctl", "-k", "-b", "-o", "cat", "--no-pager"},
+	})
+}
+
+func (s *SnapSuite) TestDebugDenialsNothingFound(c *C) {
+	s.mockDebugDenialsSyscalls(c)
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, debugDenialsConnectionsJSON)
+	})
+	journalctl := testutil.MockCommand(c, "journalctl", "echo some unrelated kernel message")
+	defer journalctl.Restore()
+
+	_, err := snap.Parser(snap.Client()).ParseArgs([]string{"debug", "denials", "foo"})
+	c.Assert(err, IsNil)
+	c.Check(s.Stdout(), Equals, "foo:network core:network (network):\n  no messages found\n\nfoo:fuse-support core:fuse-support (fuse-support):\n  no messages found\n")
+}
+
+func (s *SnapSuite) TestDebugDenialsNoAuditedConnections(c *C) {
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"type": "sync", "result": {"established": [{"slot": {"snap": "core", "slot": "home"}, "plug": {"snap": "foo", "plug": "home"}, "interface": "home"}]}}`)
+	})
+	journalctl := testutil.MockCommand(c, "journalctl", "")
+	defer journalctl.Restore()
+
+	_, err := snap.Parser(snap.Client()).ParseArgs([]string{"debug", "denials", "foo"})
+	c.Assert(err, ErrorMatches, `snap "foo" has no connections in audit mode`)
+	c.Check(journalctl.Calls(), HasLen, 0)
+}
+
+func (s *SnapSuite) TestDebugDenialsJournalError(c *C) {
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, debugDenialsConnectionsJSON)
+	})
+	journalctl := testutil.MockCommand(c, "journalctl", "echo boom >&2; exit 1")
+	defer journalctl.Restore()
+
+	_, err := snap.Parser(snap.Client()).ParseArgs([]string{"debug", "denials", "foo"})
+	c.Assert(err, ErrorMatches, `cannot read the kernel log: boom`)
+}
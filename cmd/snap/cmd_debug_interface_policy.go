@@ -0,0 +1,122 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/snapcore/snapd/i18n"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/release"
+	"github.com/snapcore/snapd/snap"
+)
+
+type cmdDebugInterfacePolicy struct {
+	OSID        string `long:"os-id"`
+	OSVersionID string `long:"os-version-id"`
+	System      string `long:"system" choice:"classic" choice:"core"`
+
+	Positionals struct {
+		Interfaces []string `positional-arg-name:"<interface>"`
+	} `positional-args:"true"`
+}
+
+const longDebugInterfacePolicyHelp = `
+The interface-policy command prints, as JSON, the AppArmor, seccomp and udev
+snippets which the built-in interfaces contribute to the security profiles of
+the applications of both sides of a connection without attributes.
+
+The policy depends on the system the command runs on, as described by
+/etc/os-release. It can be pinned with the --os-id, --os-version-id and
+--system options, so that the output does not depend on the host.
+`
+
+func init() {
+	cmd := addDebugCommand("interface-policy",
+		"(internal) print the security policy of the built-in interfaces",
+		longDebugInterfacePolicyHelp,
+		func() flags.Commander {
+			return &cmdDebugInterfacePolicy{}
+		}, map[string]string{
+			// TRANSLATORS: This should not start with a lowercase letter.
+			"os-id": i18n.G("Compute the policy as if running on the system with the given os-release ID"),
+			// TRANSLATORS: This should not start with a lowercase letter.
+			"os-version-id": i18n.G("Compute the policy as if running on the system with the given os-release VERSION_ID"),
+			// TRANSLATORS: This should not start with a lowercase letter.
+			"system": i18n.G("Compute the policy as if running on a classic or a core system"),
+		}, []argDesc{{
+			// TRANSLATORS: This needs to begin with < and end with >
+			name: i18n.G("<interface>"),
+			// TRANSLATORS: This should not start with a lowercase letter.
+			desc: i18n.G("Only print the policy of the given interfaces"),
+		}})
+	cmd.hidden = true
+}
+
+func (x *cmdDebugInterfacePolicy) Execute(args []string) error {
+	if len(args) > 0 {
+		return ErrExtraArgs
+	}
+	// plug/slot sanitization is disabled (no-op) by default at the package
+	// level for "snap" command, the policy depends on the attributes it
+	// sets however
+	snap.SanitizePlugsSlots = builtin.SanitizePlugsSlots
+
+	// the policy is computed from the release information seen by this
+	// process, pin it when asked to
+	if x.OSID != "" {
+		release.ReleaseInfo.ID = x.OSID
+	}
+	if x.OSVersionID != "" {
+		release.ReleaseInfo.VersionID = x.OSVersionID
+	}
+	if x.System != "" {
+		release.OnClassic = x.System == "classic"
+	}
+
+	var policies map[string]*builtin.InterfacePolicy
+	if len(x.Positionals.Interfaces) == 0 {
+		policies = builtin.ConnectedPolicies()
+	} else {
+		ifaces := make(map[string]interfaces.Interface)
+		for _, iface := range builtin.Interfaces() {
+			ifaces[iface.Name()] = iface
+		}
+		policies = make(map[string]*builtin.InterfacePolicy, len(x.Positionals.Interfaces))
+		for _, name := range x.Positionals.Interfaces {
+			iface, ok := ifaces[name]
+			if !ok {
+				return fmt.Errorf(i18n.G("unknown interface %q"), name)
+			}
+			policies[name] = builtin.ConnectedPolicy(iface)
+		}
+	}
+
+	data, err := json.MarshalIndent(policies, "", "\t")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(Stdout, "%s\n", data)
+	return nil
+}
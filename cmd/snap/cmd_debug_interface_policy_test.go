@@ -0,0 +1,60 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package main_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	snap "github.com/snapcore/snapd/cmd/snap"
+	"github.com/snapcore/snapd/release"
+	snaplib "github.com/snapcore/snapd/snap"
+)
+
+func (s *SnapSuite) TestDebugInterfacePolicy(c *C) {
+	defer snaplib.MockSanitizePlugsSlots(func(*snaplib.Info) {})()
+	defer release.MockOnClassic(true)()
+	defer release.MockReleaseInfo(&release.OS{ID: "fedora", VersionID: "42"})()
+
+	rest, err := snap.Parser(snap.Client()).ParseArgs([]string{"debug", "interface-policy", "--system=core", "--os-id=ubuntu-core", "--os-version-id=24", "pidfd-control"})
+	c.Assert(err, IsNil)
+	c.Assert(rest, DeepEquals, []string{})
+	c.Check(s.Stdout(), Matches, `(?s)\{
+	"pidfd-control": \{
+		"connected-plug": \{
+			"seccomp": \[
+				".*\\npidfd_open\\npidfd_send_signal\\npidfd_getfd\\n\\n"
+			\]
+		\},
+		"connected-slot": \{\}
+	\}
+\}
+`)
+	c.Check(s.Stderr(), Equals, "")
+	c.Check(release.OnClassic, Equals, false)
+	c.Check(release.ReleaseInfo, DeepEquals, release.OS{ID: "ubuntu-core", VersionID: "24"})
+}
+
+func (s *SnapSuite) TestDebugInterfacePolicyUnknownInterface(c *C) {
+	defer snaplib.MockSanitizePlugsSlots(func(*snaplib.Info) {})()
+
+	_, err := snap.Parser(snap.Client()).ParseArgs([]string{"debug", "interface-policy", "pidfd-control", "no-such-interface"})
+	c.Assert(err, ErrorMatches, `unknown interface "no-such-interface"`)
+	c.Check(s.Stdout(), Equals, "")
+}
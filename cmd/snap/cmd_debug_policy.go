@@ -0,0 +1,220 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/snapcore/snapd/i18n"
+	"github.com/snapcore/snapd/interfaces"
+)
+
+type cmdDebugPolicy struct {
+	clientMixin
+
+	Backend string `long:"backend"`
+	Diff    bool   `long:"diff"`
+
+	Positionals struct {
+		Snap installedSnapName `positional-arg-name:"<snap>" required:"1"`
+	} `positional-args:"true"`
+}
+
+const longDebugPolicyHelp = `
+The policy command prints the security policy which each security backend
+generates for the given snap, broken down by the plug, slot or connection
+contributing it.
+
+With --diff the security profiles which the backends would write for the snap
+are instead compared with the ones installed on disk, showing any drift.
+`
+
+func init() {
+	cmd := addDebugCommand("policy",
+		"(internal) print the security policy of a snap",
+		longDebugPolicyHelp,
+		func() flags.Commander {
+			return &cmdDebugPolicy{}
+		}, map[string]string{
+			// TRANSLATORS: This should not start with a lowercase letter.
+			"backend": i18n.G("Only print the policy of the given security backends (comma-separated)"),
+			// TRANSLATORS: This should not start with a lowercase letter.
+			"diff": i18n.G("Compare the expected security profiles with the ones on disk"),
+		}, []argDesc{{
+			// TRANSLATORS: This needs to begin with < and end with >
+			name: i18n.G("<snap>"),
+			// TRANSLATORS: This should not start with a lowercase letter.
+			desc: i18n.G("Snap to print the policy of"),
+		}})
+	cmd.hidden = true
+}
+
+// debugPolicy mirrors ifacestate.SnapPolicy as returned by the API.
+type debugPolicy struct {
+	Backend string `json:"backend"`
+	Parts   []struct {
+		Interface string              `json:"interface"`
+		Plug      *interfaces.PlugRef `json:"plug"`
+		Slot      *interfaces.SlotRef `json:"slot"`
+		Snippets  map[string][]string `json:"snippets"`
+	} `json:"parts"`
+	Profiles []struct {
+		Path    string `json:"path"`
+		Status  string `json:"status"`
+		Content string `json:"content"`
+		OnDisk  string `json:"on-disk"`
+	} `json:"profiles"`
+}
+
+func (x *cmdDebugPolicy) Execute(args []string) error {
+	if len(args) > 0 {
+		return ErrExtraArgs
+	}
+
+	params := map[string]string{"snap": string(x.Positionals.Snap)}
+	if x.Backend != "" {
+		params["backend"] = x.Backend
+	}
+	if x.Diff {
+		params["profiles"] = "true"
+	}
+	var policies []*debugPolicy
+	if err := x.client.DebugGet("policy", &policies, params); err != nil {
+		return err
+	}
+
+	for i, policy := range policies {
+		if i > 0 {
+			fmt.Fprintln(Stdout)
+		}
+		fmt.Fprintf(Stdout, "%s:\n", policy.Backend)
+		if x.Diff {
+			printPolicyProfiles(Stdout, policy)
+		} else {
+			printPolicyParts(Stdout, policy)
+		}
+	}
+	return nil
+}
+
+func printPolicyParts(w io.Writer, policy *debugPolicy) {
+	if len(policy.Parts) == 0 {
+		fmt.Fprintf(w, "  %s\n", i18n.G("no policy"))
+		return
+	}
+	for _, part := range policy.Parts {
+		switch {
+		case part.Plug != nil && part.Slot != nil:
+			fmt.Fprintf(w, "  connection %s %s (%s):\n", part.Plug, part.Slot, part.Interface)
+		case part.Plug != nil:
+			fmt.Fprintf(w, "  plug %s (%s):\n", part.Plug, part.Interface)
+		case part.Slot != nil:
+			fmt.Fprintf(w, "  slot %s (%s):\n", part.Slot, part.Interface)
+		}
+		tags := make([]string, 0, len(part.Snippets))
+		for tag := range part.Snippets {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		for _, tag := range tags {
+			fmt.Fprintf(w, "    %s:\n", tag)
+			for _, snippet := range part.Snippets[tag] {
+				for _, line := range strings.Split(strings.Trim(snippet, "\n"), "\n") {
+					fmt.Fprintf(w, "      %s\n", line)
+				}
+			}
+		}
+	}
+}
+
+func printPolicyProfiles(w io.Writer, policy *debugPolicy) {
+	if len(policy.Profiles) == 0 {
+		fmt.Fprintf(w, "  %s\n", i18n.G("no profiles"))
+		return
+	}
+	for _, profile := range policy.Profiles {
+		switch profile.Status {
+		case "same":
+			fmt.Fprintf(w, "  %s: %s\n", profile.Path, i18n.G("up to date"))
+		case "missing":
+			fmt.Fprintf(w, "  %s: %s\n", profile.Path, i18n.G("missing"))
+		default:
+			fmt.Fprintf(w, "  %s: %s\n", profile.Path, i18n.G("differs"))
+			fmt.Fprintf(w, "    --- %s\n", i18n.G("on disk"))
+			fmt.Fprintf(w, "    +++ %s\n", i18n.G("expected"))
+			for _, line := range diffLines(splitLines(profile.OnDisk), splitLines(profile.Content)) {
+				fmt.Fprintf(w, "    %s\n", line)
+			}
+		}
+	}
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines returns the lines which differ between a and b, prefixed with
+// "-" when only present in a and with "+" when only present in b, based on
+// their longest common subsequence. Profiles are small enough for the
+// quadratic cost not to matter.
+func diffLines(a, b []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			out = append(out, "-"+a[i])
+			i++
+		default:
+			out = append(out, "+"+b[j])
+			j++
+		}
+	}
+	return out
+}
@@ -0,0 +1,140 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package main_test
+
+import (
+	"net/http"
+	"net/url"
+
+	. "gopkg.in/check.v1"
+
+	snap "github.com/snapcore/snapd/cmd/snap"
+)
+
+func (s *SnapSuite) TestDebugPolicy(c *C) {
+	n := 0
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		n++
+		c.Check(r.Method, Equals, "GET")
+		c.Check(r.URL.Path, Equals, "/v2/debug")
+		c.Check(r.URL.Query(), DeepEquals, url.Values{
+			"aspect":  {"policy"},
+			"snap":    {"foo"},
+			"backend": {"apparmor,udev"},
+		})
+		EncodeResponseBody(c, w, map[string]any{
+			"type": "sync",
+			"result": []map[string]any{{
+				"backend": "apparmor",
+				"parts": []map[string]any{{
+					"interface": "network",
+					"plug":      map[string]any{"snap": "foo", "plug": "network"},
+					"snippets": map[string]any{
+						"snap.foo.app":  []string{"network inet,\nnetwork inet6,\n"},
+						"snap.foo.app2": []string{"network inet,"},
+					},
+				}, {
+					"interface": "home",
+					"plug":      map[string]any{"snap": "foo", "plug": "home"},
+					"slot":      map[string]any{"snap": "core", "slot": "home"},
+					"snippets": map[string]any{
+						"snap.foo.app": []string{"owner @{HOME}/ r,"},
+					},
+				}},
+			}, {
+				"backend": "udev",
+				"parts":   []map[string]any{},
+			}},
+		})
+	})
+	rest, err := snap.Parser(snap.Client()).ParseArgs([]string{"debug", "policy", "--backend=apparmor,udev", "foo"})
+	c.Assert(err, IsNil)
+	c.Assert(rest, DeepEquals, []string{})
+	c.Check(s.Stdout(), Equals, `
+apparmor:
+  plug foo:network (network):
+    snap.foo.app:
+      network inet,
+      network inet6,
+    snap.foo.app2:
+      network inet,
+  connection foo:home core:home (home):
+    snap.foo.app:
+      owner @{HOME}/ r,
+
+udev:
+  no policy
+`[1:])
+	c.Check(s.Stderr(), Equals, "")
+	c.Check(n, Equals, 1)
+}
+
+func (s *SnapSuite) TestDebugPolicyDiff(c *C) {
+	n := 0
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		n++
+		c.Check(r.URL.Query(), DeepEquals, url.Values{
+			"aspect":   {"policy"},
+			"snap":     {"foo"},
+			"profiles": {"true"},
+		})
+		EncodeResponseBody(c, w, map[string]any{
+			"type": "sync",
+			"result": []map[string]any{{
+				"backend": "seccomp",
+				"profiles": []map[string]any{{
+					"path":    "/var/lib/snapd/seccomp/bpf/snap.foo.app.src",
+					"status":  "same",
+					"content": "read\n",
+					"on-disk": "read\n",
+				}, {
+					"path":    "/var/lib/snapd/seccomp/bpf/snap.foo.app2.src",
+					"status":  "missing",
+					"content": "read\n",
+				}, {
+					"path":    "/var/lib/snapd/seccomp/bpf/snap.foo.hook.configure.src",
+					"status":  "differs",
+					"content": "read\nwrite\nclose\n",
+					"on-disk": "read\nopen\nclose\n",
+				}},
+			}},
+		})
+	})
+	rest, err := snap.Parser(snap.Client()).ParseArgs([]string{"debug", "policy", "--diff", "foo"})
+	c.Assert(err, IsNil)
+	c.Assert(rest, DeepEquals, []string{})
+	c.Check(s.Stdout(), Equals, `
+seccomp:
+  /var/lib/snapd/seccomp/bpf/snap.foo.app.src: up to date
+  /var/lib/snapd/seccomp/bpf/snap.foo.app2.src: missing
+  /var/lib/snapd/seccomp/bpf/snap.foo.hook.configure.src: differs
+    --- on disk
+    +++ expected
+    -open
+    +write
+`[1:])
+	c.Check(s.Stderr(), Equals, "")
+	c.Check(n, Equals, 1)
+}
+
+func (s *SnapSuite) TestDebugPolicyMissingSnap(c *C) {
+	_, err := snap.Parser(snap.Client()).ParseArgs([]string{"debug", "policy"})
+	c.Assert(err, ErrorMatches, "the required argument `<snap>` was not provided")
+}
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/jessevdk/go-flags"
@@ -117,6 +118,19 @@ func (x *cmdInterface) showOneInterface(iface *client.Interface) {
 	if iface.DocURL != "" {
 		fmt.Fprintf(w, "documentation:\t%s\n", iface.DocURL)
 	}
+	if iface.SecurityLevel != "" {
+		fmt.Fprintf(w, "security-level:\t%s\n", iface.SecurityLevel)
+	}
+	if x.ShowAttrs {
+		x.showAttrSpecs(w, "plug-attributes", iface.PlugAttrs)
+		x.showAttrSpecs(w, "slot-attributes", iface.SlotAttrs)
+		if example := strings.TrimRight(iface.Example, "\n"); example != "" {
+			fmt.Fprintf(w, "example: |\n")
+			for _, line := range strings.Split(example, "\n") {
+				fmt.Fprintf(w, "  %s\n", line)
+			}
+		}
+	}
 	if len(iface.Plugs) > 0 {
 		fmt.Fprintf(w, "plugs:\n")
 		for _, plug := range iface.Plugs {
@@ -163,6 +177,35 @@ func (x *cmdInterface) showOneInterface(iface *client.Interface) {
 	}
 }
 
+// showAttrSpecs shows the documentation of the attributes supported by the
+// plugs or slots of an interface.
+func (x *cmdInterface) showAttrSpecs(w io.Writer, title string, specs []client.AttributeSpec) {
+	if len(specs) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%s:\n", title)
+	for _, spec := range specs {
+		fmt.Fprintf(w, "  %s:\n", spec.Name)
+		fmt.Fprintf(w, "    type:\t%s\n", spec.Type)
+		if spec.Required {
+			fmt.Fprintf(w, "    required:\ttrue\n")
+		}
+		if spec.Default != nil {
+			fmt.Fprintf(w, "    default:\t%v\n", spec.Default)
+		}
+		if len(spec.Allowed) > 0 {
+			allowed := make([]string, 0, len(spec.Allowed))
+			for _, value := range spec.Allowed {
+				allowed = append(allowed, fmt.Sprintf("%v", value))
+			}
+			fmt.Fprintf(w, "    allowed:\t[%s]\n", strings.Join(allowed, ", "))
+		}
+		if spec.Description != "" {
+			fmt.Fprintf(w, "    description:\t%s\n", spec.Description)
+		}
+	}
+}
+
 func (x *cmdInterface) showManyInterfaces(infos []*client.Interface) {
 	w := tabWriter()
 	defer w.Flush()
@@ -240,6 +240,69 @@ func (s *SnapSuite) TestInterfaceDetailsAndAttrs(c *C) {
 	c.Assert(s.Stderr(), Equals, "")
 }
 
+func (s *SnapSuite) TestInterfaceDetailsAndAttributesDoc(c *C) {
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, Equals, "GET")
+		c.Check(r.URL.Path, Equals, "/v2/interfaces")
+		c.Check(r.URL.RawQuery, Equals, "doc=true&names=fuse-support&plugs=true&select=all&slots=true")
+		EncodeResponseBody(c, w, map[string]any{
+			"type": "sync",
+			"result": []*client.Interface{{
+				Name:          "fuse-support",
+				Summary:       "allows access to the FUSE file system",
+				SecurityLevel: "medium",
+				Example:       "plugs:\n  fuse-support:\n    hooks-only: true\n",
+				PlugAttrs: []client.AttributeSpec{
+					{Name: "hooks-only", Type: "bool", Description: "limit the plug to the hooks of the snap"},
+					{Name: "mode", Type: "string", Allowed: []any{"privileged", "unprivileged"}},
+				},
+				SlotAttrs: []client.AttributeSpec{
+					{Name: "path", Type: "string", Required: true, Default: "/dev/fuse"},
+				},
+				Plugs: []client.Plug{{Snap: "sshfs", Name: "fuse-support"}},
+			}},
+		})
+	})
+	rest, err := Parser(Client()).ParseArgs([]string{"interface", "--attrs", "fuse-support"})
+	c.Assert(err, IsNil)
+	c.Assert(rest, DeepEquals, []string{})
+	expectedStdout := "" +
+		"name:           fuse-support\n" +
+		"summary:        allows access to the FUSE file system\n" +
+		"security-level: medium\n" +
+		"plug-attributes:\n" +
+		"  hooks-only:\n" +
+		"    type:        bool\n" +
+		"    description: limit the plug to the hooks of the snap\n" +
+		"  mode:\n" +
+		"    type:    string\n" +
+		"    allowed: [privileged, unprivileged]\n" +
+		"slot-attributes:\n" +
+		"  path:\n" +
+		"    type:     string\n" +
+		"    required: true\n" +
+		"    default:  /dev/fuse\n" +
+		"example: |\n" +
+		"  plugs:\n" +
+		"    fuse-support:\n" +
+		"      hooks-only: true\n" +
+		"plugs:\n" +
+		"  - sshfs\n"
+	c.Assert(s.Stdout(), Equals, expectedStdout)
+	c.Assert(s.Stderr(), Equals, "")
+
+	// without --attrs only the security level is shown
+	s.ResetStdStreams()
+	_, err = Parser(Client()).ParseArgs([]string{"interface", "fuse-support"})
+	c.Assert(err, IsNil)
+	c.Assert(s.Stdout(), Equals, ""+
+		"name:           fuse-support\n"+
+		"summary:        allows access to the FUSE file system\n"+
+		"security-level: medium\n"+
+		"plugs:\n"+
+		"  - sshfs\n")
+}
+
 func (s *SnapSuite) TestInterfaceCompletion(c *C) {
 	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
 		c.Assert(r.Method, Equals, "GET")
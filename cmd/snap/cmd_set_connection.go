@@ -0,0 +1,130 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/snapcore/snapd/client/clientutil"
+	"github.com/snapcore/snapd/i18n"
+)
+
+type cmdSetConnection struct {
+	waitMixin
+	Positionals struct {
+		PlugSpec disconnectSlotOrPlugSpec `required:"yes"`
+		SlotSpec disconnectSlotSpec       `required:"yes"`
+		Values   []string                 `required:"1"`
+	} `positional-args:"true" required:"yes"`
+
+	String bool `short:"s"`
+}
+
+var shortSetConnectionHelp = i18n.G("Change attributes of a connection")
+var longSetConnectionHelp = i18n.G(`
+The set-connection command changes attributes of an established connection
+without disconnecting it. Attributes of the plug side are given as
+plug.<key>=<value>, attributes of the slot side as slot.<key>=<value>:
+
+    $ snap set-connection foo:content bar:content slot.target=/data
+
+The security profiles of both snaps are regenerated and reloaded in place, and
+their apps and services keep running. Only the attributes which are not set in
+the snap details can be changed.
+`)
+
+func init() {
+	addCommand("set-connection", shortSetConnectionHelp, longSetConnectionHelp, func() flags.Commander {
+		return &cmdSetConnection{}
+	}, waitDescs.also(map[string]string{
+		// TRANSLATORS: This should not start with a lowercase letter.
+		"s": i18n.G("Parse the value as a string"),
+	}), []argDesc{
+		// TRANSLATORS: This needs to begin with < and end with >
+		{name: i18n.G("<snap>:<plug>")},
+		// TRANSLATORS: This needs to begin with < and end with >
+		{name: i18n.G("<snap>:<slot>")},
+		{
+			// TRANSLATORS: This needs to begin with < and end with >
+			name: i18n.G("<attr value>"),
+			// TRANSLATORS: This should not start with a lowercase letter.
+			desc: i18n.G("Set a plug (plug.key=value) or slot (slot.key=value) attribute"),
+		},
+	})
+}
+
+func (x *cmdSetConnection) Execute(args []string) error {
+	if len(args) > 0 {
+		return ErrExtraArgs
+	}
+
+	values, _, err := clientutil.ParseConfigValues(x.Positionals.Values, &clientutil.ParseConfigOptions{String: x.String})
+	if err != nil {
+		return err
+	}
+	var plugAttrs, slotAttrs map[string]any
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := values[key]
+		if value == nil {
+			return fmt.Errorf(i18n.G("cannot unset connection attribute %q"), key)
+		}
+		side, name, ok := strings.Cut(key, ".")
+		if !ok || name == "" || (side != "plug" && side != "slot") {
+			return fmt.Errorf(i18n.G("invalid connection attribute %q (want plug.<key> or slot.<key>)"), key)
+		}
+		switch side {
+		case "plug":
+			if plugAttrs == nil {
+				plugAttrs = make(map[string]any)
+			}
+			plugAttrs[name] = value
+		case "slot":
+			if slotAttrs == nil {
+				slotAttrs = make(map[string]any)
+			}
+			slotAttrs[name] = value
+		}
+	}
+
+	plug := x.Positionals.PlugSpec
+	slot := x.Positionals.SlotSpec
+	id, err := x.client.UpdateConnectionAttrs(plug.Snap, plug.Name, slot.Snap, slot.Name, plugAttrs, slotAttrs)
+	if err != nil {
+		return err
+	}
+
+	if _, err := x.wait(id); err != nil {
+		if err == noWait {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package main_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	. "gopkg.in/check.v1"
+
+	. "github.com/snapcore/snapd/cmd/snap"
+)
+
+func (s *SnapSuite) TestSetConnection(c *C) {
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/interfaces":
+			c.Check(r.Method, Equals, "POST")
+			c.Check(DecodedRequestBody(c, r), DeepEquals, map[string]any{
+				"action": "update-attrs",
+				"plugs": []any{
+					map[string]any{
+						"snap":  "consumer",
+						"plug":  "plug",
+						"attrs": map[string]any{"number": json.Number("42")},
+					},
+				},
+				"slots": []any{
+					map[string]any{
+						"snap":  "producer",
+						"slot":  "slot",
+						"attrs": map[string]any{"path": "/foo", "list": []any{"a", "b"}},
+					},
+				},
+			})
+			w.WriteHeader(202)
+			fmt.Fprintln(w, `{"type":"async", "status-code": 202, "change": "zzz"}`)
+		case "/v2/changes/zzz":
+			c.Check(r.Method, Equals, "GET")
+			fmt.Fprintln(w, `{"type":"sync", "result":{"ready": true, "status": "Done"}}`)
+		default:
+			c.Fatalf("unexpected path %q", r.URL.Path)
+		}
+	})
+	rest, err := Parser(Client()).ParseArgs([]string{"set-connection", "consumer:plug", "producer:slot", "plug.number=42", "slot.path=/foo", `slot.list=["a","b"]`})
+	c.Assert(err, IsNil)
+	c.Assert(rest, DeepEquals, []string{})
+}
+
+func (s *SnapSuite) TestSetConnectionString(c *C) {
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/interfaces":
+			c.Check(DecodedRequestBody(c, r), DeepEquals, map[string]any{
+				"action": "update-attrs",
+				"plugs": []any{
+					map[string]any{
+						"snap":  "consumer",
+						"plug":  "plug",
+						"attrs": map[string]any{"number": "42"},
+					},
+				},
+				"slots": []any{
+					map[string]any{
+						"snap": "producer",
+						"slot": "slot",
+					},
+				},
+			})
+			w.WriteHeader(202)
+			fmt.Fprintln(w, `{"type":"async", "status-code": 202, "change": "zzz"}`)
+		case "/v2/changes/zzz":
+			fmt.Fprintln(w, `{"type":"sync", "result":{"ready": true, "status": "Done"}}`)
+		default:
+			c.Fatalf("unexpected path %q", r.URL.Path)
+		}
+	})
+	_, err := Parser(Client()).ParseArgs([]string{"set-connection", "-s", "consumer:plug", "producer:slot", "plug.number=42"})
+	c.Assert(err, IsNil)
+}
+
+func (s *SnapSuite) TestSetConnectionErrors(c *C) {
+	s.RedirectClientToTestServer(func(w http.ResponseWriter, r *http.Request) {
+		c.Fatalf("unexpected request to %q", r.URL.Path)
+	})
+	for _, tc := range []struct {
+		value string
+		err   string
+	}{
+		{"path=/foo", `invalid connection attribute "path" \(want plug.<key> or slot.<key>\)`},
+		{"other.path=/foo", `invalid connection attribute "other.path" \(want plug.<key> or slot.<key>\)`},
+		{"plug.=/foo", `invalid connection attribute "plug." \(want plug.<key> or slot.<key>\)`},
+		{"plug.path!", `cannot unset connection attribute "plug.path"`},
+		{"plug.path", `invalid configuration: "plug.path" \(want key=value\)`},
+	} {
+		_, err := Parser(Client()).ParseArgs([]string{"set-connection", "consumer:plug", "producer:slot", tc.value})
+		c.Check(err, ErrorMatches, tc.err, Commentf("%s", tc.value))
+	}
+}
@@ -495,3 +495,7 @@ func MockTimeAfter(f func(d time.Duration) <-chan time.Time) (restore func()) {
 func MockSnapdtoolIsReexecd(f func() (bool, error)) (restore func()) {
 	return testutil.Mock(&snapdtoolIsReexecd, f)
 }
+
+func MockSeccompSyscallName(f func(number string) (string, error)) (restore func()) {
+	return testutil.Mock(&seccompSyscallName, f)
+}
@@ -67,6 +67,7 @@ var api = []*Command{
 	logsCmd,
 	warningsCmd,
 	debugPprofCmd,
+	debugDenialStatsCmd,
 	debugCmd,
 	snapshotCmd,
 	snapshotExportCmd,
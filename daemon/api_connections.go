@@ -23,6 +23,7 @@ import (
 	"errors"
 	"net/http"
 	"sort"
+	"strconv"
 
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/overlord/auth"
@@ -41,6 +42,12 @@ type collectFilter struct {
 	snapName  string
 	ifaceName string
 	connected bool
+	// securityLevel selects plugs, slots and connections of interfaces
+	// with the given security level
+	securityLevel interfaces.SecurityLevel
+	// origin selects connections established automatically ("auto") or
+	// manually ("manual")
+	origin string
 }
 
 func (c *collectFilter) plugOrConnectedSlotMatches(plug *interfaces.PlugRef, connectedSlots []interfaces.SlotRef) bool {
@@ -67,10 +74,23 @@ func (c *collectFilter) slotOrConnectedPlugMatches(slot *interfaces.SlotRef, con
 	return true
 }
 
-func (c *collectFilter) ifaceMatches(ifaceName string) bool {
+func (c *collectFilter) ifaceMatches(repo *interfaces.Repository, ifaceName string) bool {
 	if c.ifaceName != "" && c.ifaceName != ifaceName {
 		return false
 	}
+	if c.securityLevel != "" && repo.InterfaceSecurityLevel(ifaceName) != c.securityLevel {
+		return false
+	}
+	return true
+}
+
+func (c *collectFilter) originMatches(manual bool) bool {
+	switch c.origin {
+	case "auto":
+		return !manual
+	case "manual":
+		return manual
+	}
 	return true
 }
 
@@ -150,7 +170,11 @@ func collectConnections(ifaceMgr *ifacestate.InterfaceManager, filter collectFil
 		if !filter.plugOrConnectedSlotMatches(&cref.PlugRef, nil) && !filter.slotOrConnectedPlugMatches(&cref.SlotRef, nil) {
 			continue
 		}
-		if !filter.ifaceMatches(cstate.Interface) {
+		if !filter.ifaceMatches(repo, cstate.Interface) {
+			continue
+		}
+		// explicitly disconnected are always manual
+		if !filter.originMatches(!cstate.Auto || cstate.Undesired) {
 			continue
 		}
 		plugRef := interfaces.PlugRef{Snap: cref.PlugRef.Snap, Name: cref.PlugRef.Name}
@@ -163,6 +187,7 @@ func collectConnections(ifaceMgr *ifacestate.InterfaceManager, filter collectFil
 			Plug:      plugRef,
 			Manual:    !cstate.Auto,
 			Gadget:    cstate.ByGadget,
+			Audit:     cstate.Audit,
 			Interface: cstate.Interface,
 			PlugAttrs: mergeAttrs(cstate.StaticPlugAttrs, cstate.DynamicPlugAttrs),
 			SlotAttrs: mergeAttrs(cstate.StaticSlotAttrs, cstate.DynamicSlotAttrs),
@@ -185,7 +210,7 @@ func collectConnections(ifaceMgr *ifacestate.InterfaceManager, filter collectFil
 		if !connected && filter.connected {
 			continue
 		}
-		if !filter.ifaceMatches(plug.Interface) || !filter.plugOrConnectedSlotMatches(&plugRef, connectedSlots) {
+		if !filter.ifaceMatches(repo, plug.Interface) || !filter.plugOrConnectedSlotMatches(&plugRef, connectedSlots) {
 			continue
 		}
 		var apps []string
@@ -210,7 +235,7 @@ func collectConnections(ifaceMgr *ifacestate.InterfaceManager, filter collectFil
 		if !connected && filter.connected {
 			continue
 		}
-		if !filter.ifaceMatches(slot.Interface) || !filter.slotOrConnectedPlugMatches(&slotRef, connectedPlugs) {
+		if !filter.ifaceMatches(repo, slot.Interface) || !filter.slotOrConnectedPlugMatches(&slotRef, connectedPlugs) {
 			continue
 		}
 		var apps []string
@@ -245,6 +270,54 @@ func (b byCrefConnJSON) Less(i, j int) bool {
 	return sortsBefore
 }
 
+// paginateConnections trims the established connections, which must be sorted,
+// to at most limit connections following the connection with the given ID, and
+// only keeps the plugs and slots on either side of the remaining connections.
+// It returns the ID of the last returned connection when more connections
+// follow, to be used as the starting point of the next page.
+func paginateConnections(connsjson *connectionsJSON, after string, limit int) (next string, err error) {
+	established := connsjson.Established
+	if after != "" {
+		afterRef, err := interfaces.ParseConnRef(after)
+		if err != nil {
+			return "", err
+		}
+		idx := sort.Search(len(established), func(i int) bool {
+			cref := interfaces.ConnRef{PlugRef: established[i].Plug, SlotRef: established[i].Slot}
+			return afterRef.SortsBefore(&cref)
+		})
+		established = established[idx:]
+	}
+	if limit > 0 && len(established) > limit {
+		established = established[:limit]
+		last := established[limit-1]
+		next = (&interfaces.ConnRef{PlugRef: last.Plug, SlotRef: last.Slot}).ID()
+	}
+	connsjson.Established = established
+
+	plugs := make(map[interfaces.PlugRef]bool, len(established))
+	slots := make(map[interfaces.SlotRef]bool, len(established))
+	for _, cj := range established {
+		plugs[cj.Plug] = true
+		slots[cj.Slot] = true
+	}
+	pagePlugs := make([]*plugJSON, 0, len(plugs))
+	for _, pj := range connsjson.Plugs {
+		if plugs[interfaces.PlugRef{Snap: pj.Snap, Name: pj.Name}] {
+			pagePlugs = append(pagePlugs, pj)
+		}
+	}
+	pageSlots := make([]*slotJSON, 0, len(slots))
+	for _, sj := range connsjson.Slots {
+		if slots[interfaces.SlotRef{Snap: sj.Snap, Name: sj.Name}] {
+			pageSlots = append(pageSlots, sj)
+		}
+	}
+	connsjson.Plugs = pagePlugs
+	connsjson.Slots = pageSlots
+	return next, nil
+}
+
 func checkSnapInstalled(st *state.State, name string) error {
 	st.Lock()
 	defer st.Unlock()
@@ -263,6 +336,32 @@ func getConnections(c *Command, r *http.Request, user *auth.UserState) Response
 	}
 	onlyConnected := qselect == ""
 
+	securityLevel := interfaces.SecurityLevel(query.Get("security-level"))
+	switch securityLevel {
+	case "", interfaces.SecurityLevelLow, interfaces.SecurityLevelMedium, interfaces.SecurityLevelHigh:
+	default:
+		return BadRequest("unsupported security level %q", securityLevel)
+	}
+	origin := query.Get("origin")
+	if origin != "" && origin != "auto" && origin != "manual" {
+		return BadRequest("unsupported origin %q", origin)
+	}
+
+	// pagination is only supported over the established connections
+	after := query.Get("after")
+	limit := 0
+	if qlimit := query.Get("limit"); qlimit != "" {
+		var err error
+		limit, err = strconv.Atoi(qlimit)
+		if err != nil || limit <= 0 {
+			return BadRequest("invalid limit %q", qlimit)
+		}
+	}
+	paginate := after != "" || limit > 0
+	if !onlyConnected && (origin != "" || paginate) {
+		return BadRequest("cannot use origin, after or limit with select=all")
+	}
+
 	snapName = ifacestate.RemapSnapFromRequest(snapName)
 	if snapName != "" {
 		if err := checkSnapInstalled(c.d.overlord.State(), snapName); err != nil {
@@ -277,6 +376,9 @@ func getConnections(c *Command, r *http.Request, user *auth.UserState) Response
 		snapName:  snapName,
 		ifaceName: ifaceName,
 		connected: onlyConnected,
+
+		securityLevel: securityLevel,
+		origin:        origin,
 	})
 	if err != nil {
 		return InternalError("collecting connection information failed: %v", err)
@@ -284,5 +386,12 @@ func getConnections(c *Command, r *http.Request, user *auth.UserState) Response
 	sort.Sort(byCrefConnJSON(connsjson.Established))
 	sort.Sort(byCrefConnJSON(connsjson.Undesired))
 
+	if paginate {
+		connsjson.Next, err = paginateConnections(connsjson, after, limit)
+		if err != nil {
+			return BadRequest("invalid after: %v", err)
+		}
+	}
+
 	return SyncResponse(connsjson)
 }
@@ -780,6 +780,64 @@ func (s *interfacesSuite) TestConnectionsDefaultGadget(c *check.C) {
 	})
 }
 
+func (s *interfacesSuite) TestConnectionsAudit(c *check.C) {
+	restore := builtin.MockInterface(&ifacetest.TestInterface{InterfaceName: "test"})
+	defer restore()
+
+	d := s.daemon(c)
+
+	s.mockSnap(c, consumerYaml)
+	s.mockSnap(c, producerYaml)
+
+	s.testConnectionsConnected(c, d, "/v2/connections", map[string]any{
+		"consumer:plug producer:slot": map[string]any{
+			"interface": "test",
+			"audit":     true,
+		},
+	}, nil, map[string]any{
+		"result": map[string]any{
+			"plugs": []any{
+				map[string]any{
+					"snap":      "consumer",
+					"plug":      "plug",
+					"interface": "test",
+					"attrs":     map[string]any{"key": "value"},
+					"apps":      []any{"app"},
+					"label":     "label",
+					"connections": []any{
+						map[string]any{"snap": "producer", "slot": "slot"},
+					},
+				},
+			},
+			"slots": []any{
+				map[string]any{
+					"snap":      "producer",
+					"slot":      "slot",
+					"interface": "test",
+					"attrs":     map[string]any{"key": "value"},
+					"apps":      []any{"app"},
+					"label":     "label",
+					"connections": []any{
+						map[string]any{"snap": "consumer", "plug": "plug"},
+					},
+				},
+			},
+			"established": []any{
+				map[string]any{
+					"plug":      map[string]any{"snap": "consumer", "plug": "plug"},
+					"slot":      map[string]any{"snap": "producer", "slot": "slot"},
+					"manual":    true,
+					"audit":     true,
+					"interface": "test",
+				},
+			},
+		},
+		"status":      "OK",
+		"status-code": 200.0,
+		"type":        "sync",
+	})
+}
+
 func (s *interfacesSuite) TestConnectionsAll(c *check.C) {
 	restore := builtin.MockInterface(&ifacetest.TestInterface{InterfaceName: "test"})
 	defer restore()
@@ -1042,3 +1100,263 @@ slots:
 		"type":        "sync",
 	})
 }
+
+func (s *interfacesSuite) TestConnectionsFiltersUnhappy(c *check.C) {
+	s.daemon(c)
+	for _, tc := range []struct {
+		query   string
+		message string
+	}{
+		{"/v2/connections?security-level=extreme", `unsupported security level "extreme"`},
+		{"/v2/connections?origin=store", `unsupported origin "store"`},
+		{"/v2/connections?limit=none", `invalid limit "none"`},
+		{"/v2/connections?limit=0", `invalid limit "0"`},
+		{"/v2/connections?select=all&origin=auto", "cannot use origin, after or limit with select=all"},
+		{"/v2/connections?select=all&limit=1", "cannot use origin, after or limit with select=all"},
+		{"/v2/connections?after=foo", `invalid after: malformed connection identifier: "foo"`},
+	} {
+		req, err := http.NewRequest("GET", tc.query, nil)
+		c.Assert(err, check.IsNil)
+		rec := httptest.NewRecorder()
+		s.req(c, req, nil, actionIsExpected).ServeHTTP(rec, req)
+		c.Check(rec.Code, check.Equals, 400, check.Commentf("query %q", tc.query))
+		var body map[string]any
+		err = json.Unmarshal(rec.Body.Bytes(), &body)
+		c.Check(err, check.IsNil)
+		c.Check(body["result"], check.DeepEquals, map[string]any{"message": tc.message}, check.Commentf("query %q", tc.query))
+	}
+}
+
+func (s *interfacesSuite) TestConnectionsBySecurityLevel(c *check.C) {
+	restore := builtin.MockInterface(&ifacetest.TestInterface{
+		InterfaceName: "test",
+		InterfaceStaticInfo: interfaces.StaticInfo{
+			BaseDeclarationPlugs: `
+  test:
+    allow-installation: false
+`,
+		},
+	})
+	defer restore()
+
+	d := s.daemon(c)
+
+	s.mockSnap(c, consumerYaml)
+	s.mockSnap(c, producerYaml)
+
+	connsState := map[string]any{
+		"consumer:plug producer:slot": map[string]any{
+			"interface": "test",
+		},
+	}
+	s.testConnectionsConnected(c, d, "/v2/connections?security-level=high", connsState, nil, map[string]any{
+		"result": map[string]any{
+			"plugs": []any{
+				map[string]any{
+					"snap":      "consumer",
+					"plug":      "plug",
+					"interface": "test",
+					"attrs":     map[string]any{"key": "value"},
+					"apps":      []any{"app"},
+					"label":     "label",
+					"connections": []any{
+						map[string]any{"snap": "producer", "slot": "slot"},
+					},
+				},
+			},
+			"slots": []any{
+				map[string]any{
+					"snap":      "producer",
+					"slot":      "slot",
+					"interface": "test",
+					"attrs":     map[string]any{"key": "value"},
+					"apps":      []any{"app"},
+					"label":     "label",
+					"connections": []any{
+						map[string]any{"snap": "consumer", "plug": "plug"},
+					},
+				},
+			},
+			"established": []any{
+				map[string]any{
+					"plug":      map[string]any{"snap": "consumer", "plug": "plug"},
+					"slot":      map[string]any{"snap": "producer", "slot": "slot"},
+					"manual":    true,
+					"interface": "test",
+				},
+			},
+		},
+		"status":      "OK",
+		"status-code": 200.0,
+		"type":        "sync",
+	})
+
+	// the interface is not of the requested level
+	s.testConnections(c, "/v2/connections?security-level=low", map[string]any{
+		"result": map[string]any{
+			"established": []any{},
+			"plugs":       []any{},
+			"slots":       []any{},
+		},
+		"status":      "OK",
+		"status-code": 200.0,
+		"type":        "sync",
+	})
+}
+
+func (s *interfacesSuite) TestConnectionsByOrigin(c *check.C) {
+	restore := builtin.MockInterface(&ifacetest.TestInterface{InterfaceName: "test"})
+	defer restore()
+
+	d := s.daemon(c)
+
+	s.mockSnap(c, consumerYaml)
+	s.mockSnap(c, producerYaml)
+
+	connsState := map[string]any{
+		"consumer:plug producer:slot": map[string]any{
+			"interface": "test",
+			"auto":      true,
+		},
+	}
+	s.testConnectionsConnected(c, d, "/v2/connections?origin=manual", connsState, nil, map[string]any{
+		"result": map[string]any{
+			"established": []any{},
+			"plugs":       []any{},
+			"slots":       []any{},
+		},
+		"status":      "OK",
+		"status-code": 200.0,
+		"type":        "sync",
+	})
+
+	s.testConnections(c, "/v2/connections?origin=auto", map[string]any{
+		"result": map[string]any{
+			"plugs": []any{
+				map[string]any{
+					"snap":      "consumer",
+					"plug":      "plug",
+					"interface": "test",
+					"attrs":     map[string]any{"key": "value"},
+					"apps":      []any{"app"},
+					"label":     "label",
+					"connections": []any{
+						map[string]any{"snap": "producer", "slot": "slot"},
+					},
+				},
+			},
+			"slots": []any{
+				map[string]any{
+					"snap":      "producer",
+					"slot":      "slot",
+					"interface": "test",
+					"attrs":     map[string]any{"key": "value"},
+					"apps":      []any{"app"},
+					"label":     "label",
+					"connections": []any{
+						map[string]any{"snap": "consumer", "plug": "plug"},
+					},
+				},
+			},
+			"established": []any{
+				map[string]any{
+					"plug":      map[string]any{"snap": "consumer", "plug": "plug"},
+					"slot":      map[string]any{"snap": "producer", "slot": "slot"},
+					"interface": "test",
+				},
+			},
+		},
+		"status":      "OK",
+		"status-code": 200.0,
+		"type":        "sync",
+	})
+}
+
+func (s *interfacesSuite) TestConnectionsPaginated(c *check.C) {
+	restore := builtin.MockInterface(&ifacetest.TestInterface{InterfaceName: "test"})
+	defer restore()
+
+	d := s.daemon(c)
+
+	var anotherConsumerYaml = `
+name: another-consumer-%s
+version: 1
+plugs:
+ plug:
+  interface: test
+`
+	s.mockSnap(c, fmt.Sprintf(anotherConsumerYaml, "abc"))
+	s.mockSnap(c, fmt.Sprintf(anotherConsumerYaml, "def"))
+	s.mockSnap(c, fmt.Sprintf(anotherConsumerYaml, "ghi"))
+	s.mockSnap(c, producerYaml)
+
+	connsState := map[string]any{
+		"another-consumer-abc:plug producer:slot": map[string]any{"interface": "test"},
+		"another-consumer-def:plug producer:slot": map[string]any{"interface": "test"},
+		"another-consumer-ghi:plug producer:slot": map[string]any{"interface": "test"},
+	}
+	producerSlot := map[string]any{
+		"snap":      "producer",
+		"slot":      "slot",
+		"interface": "test",
+		"attrs":     map[string]any{"key": "value"},
+		"apps":      []any{"app"},
+		"label":     "label",
+		"connections": []any{
+			map[string]any{"snap": "another-consumer-abc", "plug": "plug"},
+			map[string]any{"snap": "another-consumer-def", "plug": "plug"},
+			map[string]any{"snap": "another-consumer-ghi", "plug": "plug"},
+		},
+	}
+	consumerPlug := func(snapName string) map[string]any {
+		return map[string]any{
+			"snap":      snapName,
+			"plug":      "plug",
+			"interface": "test",
+			"connections": []any{
+				map[string]any{"snap": "producer", "slot": "slot"},
+			},
+		}
+	}
+	connection := func(snapName string) map[string]any {
+		return map[string]any{
+			"plug":      map[string]any{"snap": snapName, "plug": "plug"},
+			"slot":      map[string]any{"snap": "producer", "slot": "slot"},
+			"manual":    true,
+			"interface": "test",
+		}
+	}
+
+	s.testConnectionsConnected(c, d, "/v2/connections?limit=2", connsState, nil, map[string]any{
+		"result": map[string]any{
+			"plugs": []any{
+				consumerPlug("another-consumer-abc"),
+				consumerPlug("another-consumer-def"),
+			},
+			"slots": []any{producerSlot},
+			"established": []any{
+				connection("another-consumer-abc"),
+				connection("another-consumer-def"),
+			},
+			"next": "another-consumer-def:plug producer:slot",
+		},
+		"status":      "OK",
+		"status-code": 200.0,
+		"type":        "sync",
+	})
+
+	s.testConnections(c, "/v2/connections?limit=2&after=another-consumer-def:plug+producer:slot", map[string]any{
+		"result": map[string]any{
+			"plugs": []any{
+				consumerPlug("another-consumer-ghi"),
+			},
+			"slots": []any{producerSlot},
+			"established": []any{
+				connection("another-consumer-ghi"),
+			},
+		},
+		"status":      "OK",
+		"status-code": 200.0,
+		"type":        "sync",
+	})
+}
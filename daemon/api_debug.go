@@ -418,6 +418,8 @@ func getDebug(c *Command, r *http.Request, user *auth.UserState) Response {
 		return getRAAInfo(st)
 	case "features":
 		return getFeatures(c)
+	case "policy":
+		return getSnapPolicy(c, query.Get("snap"), query.Get("backend"), query.Get("profiles") == "true")
 	default:
 		return BadRequest("unknown debug aspect %q", aspect)
 	}
@@ -0,0 +1,49 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package daemon
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/snapcore/snapd/overlord/auth"
+	"github.com/snapcore/snapd/overlord/ifacestate"
+)
+
+var debugDenialStatsCmd = &Command{
+	Path:       "/v2/debug/denial-stats",
+	GET:        getDenialStats,
+	ReadAccess: rootAccess{},
+}
+
+var ifacemgrDenialStats = (*ifacestate.InterfaceManager).DenialStats
+
+func getDenialStats(c *Command, r *http.Request, user *auth.UserState) Response {
+	instanceName := r.URL.Query().Get("snap")
+
+	stats, err := ifacemgrDenialStats(c.d.overlord.InterfaceManager(), instanceName)
+	if errors.Is(err, ifacestate.ErrDenialStatsDisabled) {
+		return BadRequest("cannot get denial statistics: collection is disabled, enable it with: snap set system experimental.denial-stats=true")
+	}
+	if err != nil {
+		return InternalError("cannot get denial statistics: %v", err)
+	}
+	return SyncResponse(stats)
+}
@@ -0,0 +1,84 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package daemon_test
+
+import (
+	"errors"
+	"net/http"
+
+	"gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/daemon"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/overlord/ifacestate"
+)
+
+func (s *postDebugSuite) TestGetDenialStats(c *check.C) {
+	s.daemonWithOverlordMock()
+	// the denial records of all the snaps are only available to root
+	s.expectReadAccess(daemon.RootAccess{})
+
+	stats := []*ifacestate.DenialStat{{
+		Snap:       "foo",
+		App:        "app",
+		Backend:    interfaces.SecurityAppArmor,
+		Operation:  "open",
+		Count:      3,
+		Interfaces: []string{"home"},
+	}}
+	var called int
+	restore := daemon.MockIfacemgrDenialStats(func(m *ifacestate.InterfaceManager, instanceName string) ([]*ifacestate.DenialStat, error) {
+		called++
+		c.Check(instanceName, check.Equals, "foo")
+		return stats, nil
+	})
+	defer restore()
+
+	req, err := http.NewRequest("GET", "/v2/debug/denial-stats?snap=foo", nil)
+	c.Assert(err, check.IsNil)
+
+	rsp := s.syncReq(c, req, nil, actionIsExpected)
+	c.Check(rsp.Result, check.DeepEquals, stats)
+	c.Check(called, check.Equals, 1)
+}
+
+func (s *postDebugSuite) TestGetDenialStatsErrors(c *check.C) {
+	s.daemonWithOverlordMock()
+	// the denial records of all the snaps are only available to root
+	s.expectReadAccess(daemon.RootAccess{})
+
+	statsErr := ifacestate.ErrDenialStatsDisabled
+	restore := daemon.MockIfacemgrDenialStats(func(m *ifacestate.InterfaceManager, instanceName string) ([]*ifacestate.DenialStat, error) {
+		c.Check(instanceName, check.Equals, "")
+		return nil, statsErr
+	})
+	defer restore()
+
+	req, err := http.NewRequest("GET", "/v2/debug/denial-stats", nil)
+	c.Assert(err, check.IsNil)
+	rsp := s.errorReq(c, req, nil, actionIsExpected)
+	c.Check(rsp.Status, check.Equals, 400)
+	c.Check(rsp.Message, check.Equals, "cannot get denial statistics: collection is disabled, enable it with: snap set system experimental.denial-stats=true")
+
+	statsErr = errors.New("boom")
+	rsp = s.errorReq(c, req, nil, actionIsExpected)
+	c.Check(rsp.Status, check.Equals, 500)
+	c.Check(rsp.Message, check.Equals, "cannot get denial statistics: boom")
+}
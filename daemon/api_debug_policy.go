@@ -0,0 +1,49 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package daemon
+
+import (
+	"errors"
+
+	"github.com/snapcore/snapd/overlord/ifacestate"
+	"github.com/snapcore/snapd/overlord/state"
+	"github.com/snapcore/snapd/strutil"
+)
+
+var ifacemgrSnapPolicy = (*ifacestate.InterfaceManager).SnapPolicy
+
+func getSnapPolicy(c *Command, instanceName, backends string, withProfiles bool) Response {
+	if instanceName == "" {
+		return BadRequest("cannot get security policy: missing snap name")
+	}
+	var backendNames []string
+	if backends != "" {
+		backendNames = strutil.CommaSeparatedList(backends)
+	}
+
+	policies, err := ifacemgrSnapPolicy(c.d.overlord.InterfaceManager(), instanceName, backendNames, withProfiles)
+	if errors.Is(err, state.ErrNoState) {
+		return SnapNotFound(instanceName, err)
+	}
+	if err != nil {
+		return InternalError("cannot get security policy of snap %q: %v", instanceName, err)
+	}
+	return SyncResponse(policies)
+}
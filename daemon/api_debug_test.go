@@ -31,6 +31,8 @@ import (
 
 	"github.com/snapcore/snapd/client"
 	"github.com/snapcore/snapd/daemon"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/overlord/ifacestate"
 	"github.com/snapcore/snapd/overlord/state"
 	"github.com/snapcore/snapd/snap"
 	"github.com/snapcore/snapd/testutil"
@@ -409,3 +411,61 @@ func (s *postDebugSuite) TestRefreshAppAwarenessUnhappy(c *check.C) {
 	c.Check(rsp.Status, check.Equals, 500)
 	c.Check(rsp.Message, check.Equals, "boom!")
 }
+
+func (s *postDebugSuite) TestGetSnapPolicy(c *check.C) {
+	s.daemonWithOverlordMock()
+
+	policies := []*ifacestate.SnapPolicy{{
+		Backend: "apparmor",
+		Parts: []*ifacestate.PolicyPart{{
+			Interface: "network",
+			Plug:      &interfaces.PlugRef{Snap: "foo", Name: "network"},
+			Snippets:  map[string][]string{"snap.foo.app": {"network,"}},
+		}},
+	}}
+	var called int
+	restore := daemon.MockIfacemgrSnapPolicy(func(m *ifacestate.InterfaceManager, instanceName string, backendNames []string, withProfiles bool) ([]*ifacestate.SnapPolicy, error) {
+		called++
+		c.Check(instanceName, check.Equals, "foo")
+		c.Check(backendNames, check.DeepEquals, []string{"apparmor", "seccomp"})
+		c.Check(withProfiles, check.Equals, true)
+		return policies, nil
+	})
+	defer restore()
+
+	req, err := http.NewRequest("GET", "/v2/debug?aspect=policy&snap=foo&backend=apparmor,seccomp&profiles=true", nil)
+	c.Assert(err, check.IsNil)
+
+	rsp := s.syncReq(c, req, nil, actionIsExpected)
+	c.Check(rsp.Result, check.DeepEquals, policies)
+	c.Check(called, check.Equals, 1)
+}
+
+func (s *postDebugSuite) TestGetSnapPolicyErrors(c *check.C) {
+	s.daemonWithOverlordMock()
+
+	req, err := http.NewRequest("GET", "/v2/debug?aspect=policy", nil)
+	c.Assert(err, check.IsNil)
+	rsp := s.errorReq(c, req, nil, actionIsExpected)
+	c.Check(rsp.Status, check.Equals, 400)
+	c.Check(rsp.Message, check.Equals, "cannot get security policy: missing snap name")
+
+	policyErr := state.ErrNoState
+	restore := daemon.MockIfacemgrSnapPolicy(func(m *ifacestate.InterfaceManager, instanceName string, backendNames []string, withProfiles bool) ([]*ifacestate.SnapPolicy, error) {
+		c.Check(backendNames, check.IsNil)
+		c.Check(withProfiles, check.Equals, false)
+		return nil, policyErr
+	})
+	defer restore()
+
+	req, err = http.NewRequest("GET", "/v2/debug?aspect=policy&snap=foo", nil)
+	c.Assert(err, check.IsNil)
+	rsp = s.errorReq(c, req, nil, actionIsExpected)
+	c.Check(rsp.Status, check.Equals, 404)
+	c.Check(rsp.Kind, check.Equals, client.ErrorKindSnapNotFound)
+
+	policyErr = errors.New(`unknown security backend "foo"`)
+	rsp = s.errorReq(c, req, nil, actionIsExpected)
+	c.Check(rsp.Status, check.Equals, 500)
+	c.Check(rsp.Message, check.Equals, `cannot get security policy of snap "foo": unknown security backend "foo"`)
+}
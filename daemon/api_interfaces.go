@@ -40,15 +40,18 @@ var (
 		Path:        "/v2/interfaces",
 		GET:         interfacesConnectionsMultiplexer,
 		POST:        changeInterfaces,
-		Actions:     []string{"connect", "disconnect"},
+		Actions:     []string{"connect", "disconnect", "update-attrs"},
 		ReadAccess:  openAccess{},
 		WriteAccess: authenticatedAccess{Polkit: polkitActionManageInterfaces},
 	}
 )
 
+var ifacemgrConnectionPolicy = (*ifacestate.InterfaceManager).ConnectionPolicy
+
 var (
 	connectSnapChangeKind    = swfeats.RegisterChangeKind("connect-snap")
 	disconnectSnapChangeKind = swfeats.RegisterChangeKind("disconnect-snap")
+	updateConnChangeKind     = swfeats.RegisterChangeKind("update-connection-attrs")
 )
 
 // interfacesConnectionsMultiplexer multiplexes to either legacy (connection) or modern behavior (interfaces).
@@ -106,11 +109,16 @@ func getInterfaces(c *Command, r *http.Request, user *auth.UserState) Response {
 			})
 		}
 		infoJSONs = append(infoJSONs, &interfaceJSON{
-			Name:    info.Name,
-			Summary: info.Summary,
-			DocURL:  info.DocURL,
-			Plugs:   plugs,
-			Slots:   slots,
+			Name:          info.Name,
+			Summary:       info.Summary,
+			DocURL:        info.DocURL,
+			SecurityNotes: info.SecurityNotes,
+			SecurityLevel: string(info.SecurityLevel),
+			Example:       info.Example,
+			PlugAttrs:     attrSpecsJSON(info.PlugAttrs),
+			SlotAttrs:     attrSpecsJSON(info.SlotAttrs),
+			Plugs:         plugs,
+			Slots:         slots,
 		})
 	}
 	return SyncResponse(infoJSONs)
@@ -142,12 +150,15 @@ func changeInterfaces(c *Command, r *http.Request, user *auth.UserState) Respons
 	if len(a.Plugs) > 1 || len(a.Slots) > 1 {
 		return NotImplemented("many-to-many operations are not implemented")
 	}
-	if a.Action != "connect" && a.Action != "disconnect" {
+	if a.Action != "connect" && a.Action != "disconnect" && a.Action != "update-attrs" {
 		return BadRequest("unsupported interface action: %q", a.Action)
 	}
 	if len(a.Plugs) == 0 || len(a.Slots) == 0 {
 		return BadRequest("at least one plug and slot is required")
 	}
+	if a.DryRun && a.Action != "connect" {
+		return BadRequest("dry-run is only supported by the connect action")
+	}
 
 	var summary string
 	var err error
@@ -194,12 +205,21 @@ func changeInterfaces(c *Command, r *http.Request, user *auth.UserState) Respons
 		var connRef *interfaces.ConnRef
 		repo := c.d.overlord.InterfaceManager().Repository()
 		connRef, err = repo.ResolveConnect(a.Plugs[0].Snap, a.Plugs[0].Name, a.Slots[0].Snap, a.Slots[0].Name)
+		if err == nil && a.DryRun {
+			// report the policy the connection would add instead
+			connPolicy, err := ifacemgrConnectionPolicy(c.d.overlord.InterfaceManager(), connRef)
+			if err != nil {
+				return errToResponse(err, nil, BadRequest, "%v")
+			}
+			return SyncResponse(connPolicy)
+		}
 		if err == nil {
 			var ts *state.TaskSet
 			affected = snapNamesFromConns([]*interfaces.ConnRef{connRef})
 			summary = fmt.Sprintf("Connect %s:%s to %s:%s", connRef.PlugRef.Snap, connRef.PlugRef.Name, connRef.SlotRef.Snap, connRef.SlotRef.Name)
-			ts, err = ifacestate.Connect(st, connRef.PlugRef.Snap, connRef.PlugRef.Name, connRef.SlotRef.Snap, connRef.SlotRef.Name)
-			if _, ok := err.(*ifacestate.ErrAlreadyConnected); ok {
+			opts := &ifacestate.ConnectOptions{Audit: a.Audit}
+			ts, err = ifacestate.ConnectWithOptions(st, connRef.PlugRef.Snap, connRef.PlugRef.Name, connRef.SlotRef.Snap, connRef.SlotRef.Name, opts)
+			if _, ok := err.(*ifacestate.ErrAlreadyConnected); ok && !a.Audit {
 				change := newChange(st, connectSnapChangeKind, summary, nil, affected)
 				change.SetStatus(state.DoneStatus)
 				return AsyncResponse(nil, change.ID())
@@ -240,6 +260,31 @@ func changeInterfaces(c *Command, r *http.Request, user *auth.UserState) Respons
 			affected = snapNamesFromConns(conns)
 		}
 		changeKind = disconnectSnapChangeKind
+	case "update-attrs":
+		if a.Plugs[0].Name == "" || a.Slots[0].Name == "" {
+			return BadRequest("both the plug and the slot of the connection to update are required")
+		}
+		connRef := &interfaces.ConnRef{
+			PlugRef: interfaces.PlugRef{Snap: a.Plugs[0].Snap, Name: a.Plugs[0].Name},
+			SlotRef: interfaces.SlotRef{Snap: a.Slots[0].Snap, Name: a.Slots[0].Name},
+		}
+		// the snap name can be omitted to implicitly refer to the core snap
+		if connRef.PlugRef.Snap == "" {
+			connRef.PlugRef.Snap = ifacestate.SystemSnapName()
+		}
+		if connRef.SlotRef.Snap == "" {
+			connRef.SlotRef.Snap = ifacestate.SystemSnapName()
+		}
+		summary = fmt.Sprintf("Update attributes of connection %s:%s to %s:%s", connRef.PlugRef.Snap, connRef.PlugRef.Name, connRef.SlotRef.Snap, connRef.SlotRef.Name)
+		var conn *interfaces.Connection
+		conn, err = c.d.overlord.InterfaceManager().Repository().Connection(connRef)
+		if err == nil {
+			var ts *state.TaskSet
+			ts, err = ifacestate.UpdateConnectionAttrs(st, conn, a.Plugs[0].Attrs, a.Slots[0].Attrs)
+			tasksets = append(tasksets, ts)
+			affected = snapNamesFromConns([]*interfaces.ConnRef{connRef})
+		}
+		changeKind = updateConnChangeKind
 	}
 	if err != nil {
 		return errToResponse(err, nil, BadRequest, "%v")
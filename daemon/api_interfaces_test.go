@@ -22,6 +22,7 @@ package daemon_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -177,6 +178,54 @@ func (s *interfacesSuite) TestConnectPlugSuccess(c *check.C) {
 	}})
 }
 
+func (s *interfacesSuite) TestConnectPlugAudit(c *check.C) {
+	restore := builtin.MockInterface(&ifacetest.TestInterface{InterfaceName: "test"})
+	defer restore()
+
+	d := s.daemon(c)
+
+	s.mockSnap(c, consumerYaml)
+	s.mockSnap(c, producerYaml)
+
+	d.Overlord().Loop()
+	defer d.Overlord().Stop()
+
+	action := &client.InterfaceAction{
+		Action: "connect",
+		Audit:  true,
+		Plugs:  []client.Plug{{Snap: "consumer", Name: "plug"}},
+		Slots:  []client.Slot{{Snap: "producer", Name: "slot"}},
+	}
+	text, err := json.Marshal(action)
+	c.Assert(err, check.IsNil)
+	buf := bytes.NewBuffer(text)
+	req, err := http.NewRequest("POST", "/v2/interfaces", buf)
+	c.Assert(err, check.IsNil)
+	rec := httptest.NewRecorder()
+	s.req(c, req, nil, actionIsExpected).ServeHTTP(rec, req)
+	c.Check(rec.Code, check.Equals, 202)
+	var body map[string]any
+	err = json.Unmarshal(rec.Body.Bytes(), &body)
+	c.Check(err, check.IsNil)
+	id := body["change"].(string)
+
+	st := d.Overlord().State()
+	st.Lock()
+	chg := st.Change(id)
+	st.Unlock()
+	c.Assert(chg, check.NotNil)
+
+	<-chg.Ready()
+
+	st.Lock()
+	defer st.Unlock()
+	c.Assert(chg.Err(), check.IsNil)
+
+	connStates, err := ifacestate.ConnectionStates(st)
+	c.Assert(err, check.IsNil)
+	c.Check(connStates["consumer:plug producer:slot"].Audit, check.Equals, true)
+}
+
 func (s *interfacesSuite) TestConnectPlugFailureInterfaceMismatch(c *check.C) {
 	d := s.daemon(c)
 
@@ -307,6 +356,233 @@ func (s *interfacesSuite) TestConnectAlreadyConnected(c *check.C) {
 	st.Unlock()
 }
 
+func (s *interfacesSuite) TestConnectAuditAlreadyConnected(c *check.C) {
+	d := s.daemon(c)
+
+	mockIface(c, d, &ifacetest.TestInterface{InterfaceName: "test"})
+	s.mockSnap(c, producerYaml)
+	s.mockSnap(c, consumerYaml)
+
+	repo := d.Overlord().InterfaceManager().Repository()
+	connRef := &interfaces.ConnRef{
+		PlugRef: interfaces.PlugRef{Snap: "consumer", Name: "plug"},
+		SlotRef: interfaces.SlotRef{Snap: "producer", Name: "slot"},
+	}
+
+	_, err := repo.Connect(connRef, nil, nil, nil, nil, nil)
+	c.Assert(err, check.IsNil)
+	st := d.Overlord().State()
+	st.Lock()
+	st.Set("conns", map[string]any{
+		"consumer:plug producer:slot": map[string]any{
+			"auto": false,
+		},
+	})
+	st.Unlock()
+
+	// an established connection cannot be switched to audit mode
+	action := &client.InterfaceAction{
+		Action: "connect",
+		Audit:  true,
+		Plugs:  []client.Plug{{Snap: "consumer", Name: "plug"}},
+		Slots:  []client.Slot{{Snap: "producer", Name: "slot"}},
+	}
+	text, err := json.Marshal(action)
+	c.Assert(err, check.IsNil)
+	buf := bytes.NewBuffer(text)
+	req, err := http.NewRequest("POST", "/v2/interfaces", buf)
+	c.Assert(err, check.IsNil)
+	rec := httptest.NewRecorder()
+	s.req(c, req, nil, actionIsExpected).ServeHTTP(rec, req)
+	c.Check(rec.Code, check.Equals, 400)
+	var body map[string]any
+	err = json.Unmarshal(rec.Body.Bytes(), &body)
+	c.Check(err, check.IsNil)
+	c.Check(body["result"], check.DeepEquals, map[string]any{
+		"message": `already connected: "consumer:plug producer:slot"`,
+	})
+}
+
+func (s *interfacesSuite) TestConnectDryRun(c *check.C) {
+	d := s.daemon(c)
+
+	mockIface(c, d, &ifacetest.TestInterface{InterfaceName: "test"})
+	s.mockSnap(c, producerYaml)
+	s.mockSnap(c, consumerYaml)
+
+	var called int
+	restore := daemon.MockIfacemgrConnectionPolicy(func(m *ifacestate.InterfaceManager, connRef *interfaces.ConnRef) (*ifacestate.ConnectionPolicy, error) {
+		called++
+		c.Check(connRef, check.DeepEquals, &interfaces.ConnRef{
+			PlugRef: interfaces.PlugRef{Snap: "consumer", Name: "plug"},
+			SlotRef: interfaces.SlotRef{Snap: "producer", Name: "slot"},
+		})
+		return &ifacestate.ConnectionPolicy{
+			Interface: "test",
+			Plug:      connRef.PlugRef,
+			Slot:      connRef.SlotRef,
+			Policies:  []*ifacestate.SnapPolicy{},
+		}, nil
+	})
+	defer restore()
+
+	// the slot is resolved
+	action := &client.InterfaceAction{
+		Action: "connect",
+		DryRun: true,
+		Plugs:  []client.Plug{{Snap: "consumer", Name: "plug"}},
+		Slots:  []client.Slot{{Snap: "producer"}},
+	}
+	text, err := json.Marshal(action)
+	c.Assert(err, check.IsNil)
+	req, err := http.NewRequest("POST", "/v2/interfaces", bytes.NewBuffer(text))
+	c.Assert(err, check.IsNil)
+	rsp := s.syncReq(c, req, nil, actionIsExpected)
+	c.Check(called, check.Equals, 1)
+	c.Check(rsp.Result, check.DeepEquals, &ifacestate.ConnectionPolicy{
+		Interface: "test",
+		Plug:      interfaces.PlugRef{Snap: "consumer", Name: "plug"},
+		Slot:      interfaces.SlotRef{Snap: "producer", Name: "slot"},
+		Policies:  []*ifacestate.SnapPolicy{},
+	})
+
+	// nothing was connected
+	st := d.Overlord().State()
+	st.Lock()
+	defer st.Unlock()
+	c.Check(st.Changes(), check.HasLen, 0)
+	c.Check(d.Overlord().InterfaceManager().Repository().Interfaces().Connections, check.HasLen, 0)
+}
+
+func (s *interfacesSuite) TestConnectDryRunError(c *check.C) {
+	d := s.daemon(c)
+
+	mockIface(c, d, &ifacetest.TestInterface{InterfaceName: "test"})
+	s.mockSnap(c, producerYaml)
+	s.mockSnap(c, consumerYaml)
+
+	restore := daemon.MockIfacemgrConnectionPolicy(func(m *ifacestate.InterfaceManager, connRef *interfaces.ConnRef) (*ifacestate.ConnectionPolicy, error) {
+		return nil, errors.New("cannot connect plug")
+	})
+	defer restore()
+
+	action := &client.InterfaceAction{
+		Action: "connect",
+		DryRun: true,
+		Plugs:  []client.Plug{{Snap: "consumer", Name: "plug"}},
+		Slots:  []client.Slot{{Snap: "producer", Name: "slot"}},
+	}
+	text, err := json.Marshal(action)
+	c.Assert(err, check.IsNil)
+	req, err := http.NewRequest("POST", "/v2/interfaces", bytes.NewBuffer(text))
+	c.Assert(err, check.IsNil)
+	rspe := s.errorReq(c, req, nil, actionIsExpected)
+	c.Check(rspe.Status, check.Equals, 400)
+	c.Check(rspe.Message, check.Equals, "cannot connect plug")
+
+	// dry-run is only supported when connecting
+	action.Action = "disconnect"
+	text, err = json.Marshal(action)
+	c.Assert(err, check.IsNil)
+	req, err = http.NewRequest("POST", "/v2/interfaces", bytes.NewBuffer(text))
+	c.Assert(err, check.IsNil)
+	rspe = s.errorReq(c, req, nil, actionIsExpected)
+	c.Check(rspe.Status, check.Equals, 400)
+	c.Check(rspe.Message, check.Equals, "dry-run is only supported by the connect action")
+}
+
+func (s *interfacesSuite) TestUpdateConnectionAttrs(c *check.C) {
+	restore := builtin.MockInterface(&ifacetest.TestInterface{InterfaceName: "test"})
+	defer restore()
+
+	d := s.daemon(c)
+
+	s.mockSnap(c, consumerYaml)
+	s.mockSnap(c, producerYaml)
+
+	repo := d.Overlord().InterfaceManager().Repository()
+	connRef := &interfaces.ConnRef{
+		PlugRef: interfaces.PlugRef{Snap: "consumer", Name: "plug"},
+		SlotRef: interfaces.SlotRef{Snap: "producer", Name: "slot"},
+	}
+	_, err := repo.Connect(connRef, nil, nil, nil, nil, nil)
+	c.Assert(err, check.IsNil)
+	st := d.Overlord().State()
+	st.Lock()
+	st.Set("conns", map[string]any{
+		"consumer:plug producer:slot": map[string]any{
+			"interface": "test",
+		},
+	})
+	st.Unlock()
+
+	d.Overlord().Loop()
+	defer d.Overlord().Stop()
+
+	action := &client.InterfaceAction{
+		Action: "update-attrs",
+		Plugs:  []client.Plug{{Snap: "consumer", Name: "plug", Attrs: map[string]any{"path": "/foo"}}},
+		Slots:  []client.Slot{{Snap: "producer", Name: "slot"}},
+	}
+	text, err := json.Marshal(action)
+	c.Assert(err, check.IsNil)
+	buf := bytes.NewBuffer(text)
+	req, err := http.NewRequest("POST", "/v2/interfaces", buf)
+	c.Assert(err, check.IsNil)
+	rec := httptest.NewRecorder()
+	s.req(c, req, nil, actionIsExpected).ServeHTTP(rec, req)
+	c.Check(rec.Code, check.Equals, 202)
+	var body map[string]any
+	err = json.Unmarshal(rec.Body.Bytes(), &body)
+	c.Check(err, check.IsNil)
+	id := body["change"].(string)
+
+	st.Lock()
+	chg := st.Change(id)
+	st.Unlock()
+	c.Assert(chg, check.NotNil)
+
+	<-chg.Ready()
+
+	st.Lock()
+	defer st.Unlock()
+	c.Assert(chg.Err(), check.IsNil)
+	c.Check(chg.Kind(), check.Equals, "update-connection-attrs")
+	c.Check(chg.Summary(), check.Equals, "Update attributes of connection consumer:plug to producer:slot")
+
+	connStates, err := ifacestate.ConnectionStates(st)
+	c.Assert(err, check.IsNil)
+	c.Check(connStates["consumer:plug producer:slot"].DynamicPlugAttrs, check.DeepEquals, map[string]any{"path": "/foo"})
+}
+
+func (s *interfacesSuite) TestUpdateConnectionAttrsNotConnected(c *check.C) {
+	d := s.daemon(c)
+
+	mockIface(c, d, &ifacetest.TestInterface{InterfaceName: "test"})
+	s.mockSnap(c, consumerYaml)
+	s.mockSnap(c, producerYaml)
+
+	action := &client.InterfaceAction{
+		Action: "update-attrs",
+		Plugs:  []client.Plug{{Snap: "consumer", Name: "plug", Attrs: map[string]any{"path": "/foo"}}},
+		Slots:  []client.Slot{{Snap: "producer", Name: "slot"}},
+	}
+	text, err := json.Marshal(action)
+	c.Assert(err, check.IsNil)
+	buf := bytes.NewBuffer(text)
+	req, err := http.NewRequest("POST", "/v2/interfaces", buf)
+	c.Assert(err, check.IsNil)
+	rec := httptest.NewRecorder()
+	s.req(c, req, nil, actionIsExpected).ServeHTTP(rec, req)
+	c.Check(rec.Code, check.Equals, 400)
+	var body map[string]any
+	err = json.Unmarshal(rec.Body.Bytes(), &body)
+	c.Check(err, check.IsNil)
+	c.Check(body["result"], check.DeepEquals, map[string]any{
+		"message": "no connection from consumer:plug to producer:slot",
+	})
+}
+
 func (s *interfacesSuite) TestConnectPlugFailureNoSuchSlot(c *check.C) {
 	d := s.daemon(c)
 
@@ -1158,8 +1434,9 @@ func (s *interfacesSuite) TestInterfacesModern(c *check.C) {
 	c.Check(body, check.DeepEquals, map[string]any{
 		"result": []any{
 			map[string]any{
-				"name":    "test",
-				"doc-url": "https://snapcraft.io/docs/test-interface",
+				"name":           "test",
+				"doc-url":        "https://snapcraft.io/docs/test-interface",
+				"security-level": "low",
 				"plugs": []any{
 					map[string]any{
 						"snap":  "consumer",
@@ -1187,6 +1464,56 @@ func (s *interfacesSuite) TestInterfacesModern(c *check.C) {
 	})
 }
 
+func (s *interfacesSuite) TestInterfacesSecurityNotes(c *check.C) {
+	_ = s.daemon(c)
+
+	req, err := http.NewRequest("GET", "/v2/interfaces?select=all&doc=true&names=fuse-support", nil)
+	c.Assert(err, check.IsNil)
+	rec := httptest.NewRecorder()
+	s.req(c, req, nil, actionIsExpected).ServeHTTP(rec, req)
+	c.Check(rec.Code, check.Equals, 200)
+	var body struct {
+		Result []struct {
+			Name          string   `json:"name"`
+			SecurityNotes []string `json:"security-notes"`
+		} `json:"result"`
+	}
+	c.Assert(json.Unmarshal(rec.Body.Bytes(), &body), check.IsNil)
+	c.Assert(body.Result, check.HasLen, 1)
+	c.Check(body.Result[0].Name, check.Equals, "fuse-support")
+	iface, err := interfaces.ByName("fuse-support")
+	c.Assert(err, check.IsNil)
+	c.Check(body.Result[0].SecurityNotes, check.DeepEquals, interfaces.StaticInfoOf(iface).SecurityNotes)
+	c.Check(body.Result[0].SecurityNotes, check.Not(check.HasLen), 0)
+}
+
+func (s *interfacesSuite) TestInterfacesAttributesDoc(c *check.C) {
+	_ = s.daemon(c)
+
+	req, err := http.NewRequest("GET", "/v2/interfaces?select=all&doc=true&names=optical-drive", nil)
+	c.Assert(err, check.IsNil)
+	rec := httptest.NewRecorder()
+	s.req(c, req, nil, actionIsExpected).ServeHTTP(rec, req)
+	c.Check(rec.Code, check.Equals, 200)
+	var body map[string]any
+	c.Assert(json.Unmarshal(rec.Body.Bytes(), &body), check.IsNil)
+	result := body["result"].([]any)
+	c.Assert(result, check.HasLen, 1)
+	info := result[0].(map[string]any)
+	c.Check(info["name"], check.Equals, "optical-drive")
+	c.Check(info["security-level"], check.Equals, "medium")
+	c.Check(strings.Contains(info["example"].(string), "optical-drive:"), check.Equals, true)
+	c.Check(info["plug-attrs"], check.DeepEquals, []any{
+		map[string]any{
+			"name":        "write",
+			"type":        "bool",
+			"default":     false,
+			"description": "grant write access to the optical drives, for burning discs",
+		},
+	})
+	c.Check(info["slot-attrs"], check.IsNil)
+}
+
 func (s *interfacesSuite) TestInterfacesAllDefaultDocURL(c *check.C) {
 	_ = s.daemon(c)
 
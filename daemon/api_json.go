@@ -20,6 +20,8 @@
 package daemon
 
 import (
+	"sort"
+
 	"github.com/snapcore/snapd/interfaces"
 )
 
@@ -49,17 +51,61 @@ type slotJSON struct {
 
 // interfaceJSON aids in marshaling interfaces.Info into JSON.
 type interfaceJSON struct {
-	Name    string      `json:"name,omitempty"`
-	Summary string      `json:"summary,omitempty"`
-	DocURL  string      `json:"doc-url,omitempty"`
-	Plugs   []*plugJSON `json:"plugs,omitempty"`
-	Slots   []*slotJSON `json:"slots,omitempty"`
+	Name          string          `json:"name,omitempty"`
+	Summary       string          `json:"summary,omitempty"`
+	DocURL        string          `json:"doc-url,omitempty"`
+	SecurityNotes []string        `json:"security-notes,omitempty"`
+	SecurityLevel string          `json:"security-level,omitempty"`
+	Example       string          `json:"example,omitempty"`
+	PlugAttrs     []*attrSpecJSON `json:"plug-attrs,omitempty"`
+	SlotAttrs     []*attrSpecJSON `json:"slot-attrs,omitempty"`
+	Plugs         []*plugJSON     `json:"plugs,omitempty"`
+	Slots         []*slotJSON     `json:"slots,omitempty"`
+}
+
+// attrSpecJSON aids in marshaling the documentation of an interface
+// attribute, as described by interfaces.AttrSpec, into JSON.
+type attrSpecJSON struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required,omitempty"`
+	Default     any    `json:"default,omitempty"`
+	Allowed     []any  `json:"allowed,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// attrSpecsJSON returns the documentation of the attributes of a schema,
+// sorted by name.
+func attrSpecsJSON(schema map[string]interfaces.AttrSpec) []*attrSpecJSON {
+	if len(schema) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	specs := make([]*attrSpecJSON, 0, len(names))
+	for _, name := range names {
+		spec := schema[name]
+		specs = append(specs, &attrSpecJSON{
+			Name:        name,
+			Type:        string(spec.Type),
+			Required:    spec.Required,
+			Default:     spec.Default,
+			Allowed:     spec.Allowed,
+			Description: spec.Description,
+		})
+	}
+	return specs
 }
 
 // interfaceAction is an action performed on the interface system.
 type interfaceAction struct {
 	Action string     `json:"action"`
 	Forget bool       `json:"forget,omitempty"`
+	Audit  bool       `json:"audit,omitempty"`
+	DryRun bool       `json:"dry-run,omitempty"`
 	Plugs  []plugJSON `json:"plugs,omitempty"`
 	Slots  []slotJSON `json:"slots,omitempty"`
 }
@@ -72,6 +118,7 @@ type connectionJSON struct {
 	Interface string             `json:"interface"`
 	Manual    bool               `json:"manual,omitempty"`
 	Gadget    bool               `json:"gadget,omitempty"`
+	Audit     bool               `json:"audit,omitempty"`
 	SlotAttrs map[string]any     `json:"slot-attrs,omitempty"`
 	PlugAttrs map[string]any     `json:"plug-attrs,omitempty"`
 }
@@ -88,4 +135,7 @@ type connectionsJSON struct {
 	Undesired   []connectionJSON `json:"undesired,omitempty"`
 	Plugs       []*plugJSON      `json:"plugs"`
 	Slots       []*slotJSON      `json:"slots"`
+	// Next is the ID of the last established connection returned when
+	// more connections follow in the next page.
+	Next string `json:"next,omitempty"`
 }
@@ -19,7 +19,10 @@
 
 package daemon
 
-import "github.com/snapcore/snapd/testutil"
+import (
+	"github.com/snapcore/snapd/overlord/ifacestate"
+	"github.com/snapcore/snapd/testutil"
+)
 
 type (
 	ConnectivityStatus = connectivityStatus
@@ -38,3 +41,11 @@ var (
 func MockCgroupPidsOfSnap(f func(instanceName string) (map[string][]int, error)) (restore func()) {
 	return testutil.Mock(&cgroupPidsOfSnap, f)
 }
+
+func MockIfacemgrSnapPolicy(f func(m *ifacestate.InterfaceManager, instanceName string, backendNames []string, withProfiles bool) ([]*ifacestate.SnapPolicy, error)) (restore func()) {
+	return testutil.Mock(&ifacemgrSnapPolicy, f)
+}
+
+func MockIfacemgrDenialStats(f func(m *ifacestate.InterfaceManager, instanceName string) ([]*ifacestate.DenialStat, error)) (restore func()) {
+	return testutil.Mock(&ifacemgrDenialStats, f)
+}
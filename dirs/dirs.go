@@ -52,6 +52,7 @@ var (
 	SnapLdconfigDir      string
 	SnapSeccompBase      string
 	SnapSeccompDir       string
+	SnapSeccompCacheDir  string
 	SnapMountPolicyDir   string
 	SnapCgroupPolicyDir  string
 	SnapUdevRulesDir     string
@@ -575,6 +576,7 @@ func SetRootDir(rootdir string) {
 	SnapDownloadCacheDir = filepath.Join(rootdir, snappyDir, "cache")
 	SnapSeccompBase = filepath.Join(rootdir, snappyDir, "seccomp")
 	SnapSeccompDir = filepath.Join(SnapSeccompBase, "bpf")
+	SnapSeccompCacheDir = filepath.Join(SnapSeccompBase, "cache")
 	SnapMountPolicyDir = filepath.Join(rootdir, snappyDir, "mount")
 	SnapCgroupPolicyDir = filepath.Join(rootdir, snappyDir, "cgroup")
 	SnapdMaintenanceFile = filepath.Join(rootdir, snappyDir, "maintenance.json")
@@ -79,6 +79,8 @@ const (
 	ContentCompatLabel
 	// Clustering enables experimental clustering support.
 	Clustering
+	// DenialStats enables the collection of anonymized statistics of the AppArmor and seccomp denials of snaps.
+	DenialStats
 	// lastFeature is the final known feature, it is only used for testing.
 	lastFeature
 )
@@ -129,6 +131,7 @@ var featureNames = map[SnapdFeature]string{
 	AppArmorPrompting:  "apparmor-prompting",
 	ContentCompatLabel: "content-compatibility-label",
 	Clustering:         "clustering",
+	DenialStats:        "denial-stats",
 }
 
 // featuresEnabledWhenUnset contains a set of features that are enabled when not explicitly configured.
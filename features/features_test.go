@@ -66,6 +66,7 @@ func (*featureSuite) TestName(c *C) {
 	check(features.AppArmorPrompting, "apparmor-prompting")
 	check(features.ContentCompatLabel, "content-compatibility-label")
 	check(features.Clustering, "clustering")
+	check(features.DenialStats, "denial-stats")
 
 	c.Check(tested, Equals, features.NumberOfFeatures())
 	c.Check(func() { _ = features.SnapdFeature(1000).String() }, PanicMatches, "unknown feature flag code 1000")
@@ -108,6 +109,7 @@ func (*featureSuite) TestIsExported(c *C) {
 	check(features.AppArmorPrompting, true)
 	check(features.ContentCompatLabel, false)
 	check(features.Clustering, false)
+	check(features.DenialStats, false)
 
 	c.Check(tested, Equals, features.NumberOfFeatures())
 }
@@ -235,6 +237,7 @@ func (*featureSuite) TestIsEnabledWhenUnset(c *C) {
 	check(features.ConfdbControl, false)
 	check(features.ContentCompatLabel, false)
 	check(features.Clustering, false)
+	check(features.DenialStats, false)
 
 	c.Check(tested, Equals, features.NumberOfFeatures())
 }
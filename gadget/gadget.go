@@ -949,13 +949,20 @@ func AllDiskVolumeDeviceTraits(allVols map[string]*Volume, optsPerVolume map[str
 //
 //	plug: (<plug-snap-id>|system):plug
 //	[slot: (<slot-snap-id>|system):slot]
+//	[plug-attributes: {<name>: <value>, ...}]
+//	[slot-attributes: {<name>: <value>, ...}]
 //
 // "system" indicates a system plug or slot.
 // Fully omitting the slot part indicates a system slot with the same name
 // as the plug.
+// The attributes are set on the plug and slot of the connection in addition
+// to the ones declared by the snaps.
 type Connection struct {
 	Plug ConnectionPlug `yaml:"plug"`
 	Slot ConnectionSlot `yaml:"slot"`
+
+	PlugAttrs map[string]any `yaml:"plug-attributes,omitempty"`
+	SlotAttrs map[string]any `yaml:"slot-attributes,omitempty"`
 }
 
 type ConnectionPlug struct {
@@ -1004,6 +1011,27 @@ func (gcslot *ConnectionSlot) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
+// normalizeConnectionAttrs normalizes the values of the attributes of a
+// gadget connection like the attributes of plugs and slots of snaps.
+func normalizeConnectionAttrs(attrs map[string]any) (map[string]any, error) {
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+	normalized := make(map[string]any, len(attrs))
+	for name, value := range attrs {
+		switch name {
+		case "", "interface", "label":
+			return nil, fmt.Errorf("invalid attribute name %q", name)
+		}
+		v, err := metautil.NormalizeValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %v", name, err)
+		}
+		normalized[name] = v
+	}
+	return normalized, nil
+}
+
 func parseSnapIDColonName(s string) (snapID, name string, err error) {
 	parts := strings.Split(s, ":")
 	if len(parts) == 2 {
@@ -1196,6 +1224,16 @@ func InfoFromGadgetYaml(gadgetYaml []byte, model Model) (*Info, error) {
 			gi.Connections[i].Slot.SnapID = "system"
 			gi.Connections[i].Slot.Slot = gconn.Plug.Plug
 		}
+		plugAttrs, err := normalizeConnectionAttrs(gconn.PlugAttrs)
+		if err != nil {
+			return nil, fmt.Errorf("gadget connection plug %s:%s: %v", gconn.Plug.SnapID, gconn.Plug.Plug, err)
+		}
+		slotAttrs, err := normalizeConnectionAttrs(gconn.SlotAttrs)
+		if err != nil {
+			return nil, fmt.Errorf("gadget connection slot %s:%s: %v", gi.Connections[i].Slot.SnapID, gi.Connections[i].Slot.Slot, err)
+		}
+		gi.Connections[i].PlugAttrs = plugAttrs
+		gi.Connections[i].SlotAttrs = slotAttrs
 	}
 
 	if len(gi.Volumes) == 0 && classicOrUndetermined(model) {
@@ -992,6 +992,69 @@ defaults:
 	c.Assert(err, ErrorMatches, `default stanza not keyed by "system" or snap-id: foo`)
 }
 
+func (s *gadgetYamlTestSuite) TestReadGadgetYamlConnectionAttributes(c *C) {
+	mockGadgetYaml := []byte(`
+connections:
+  - plug: snapid1:serial
+    slot: snapid2:serial
+    plug-attributes:
+      baud-rate: 115200
+      flags: [raw, nonblock]
+    slot-attributes:
+      path: /dev/ttyS1
+      usb:
+        vendor-id: 0x1234
+  - plug: snapid3:process-control
+`)
+	err := os.WriteFile(s.gadgetYamlPath, mockGadgetYaml, 0644)
+	c.Assert(err, IsNil)
+
+	ginfo, err := gadget.ReadInfo(s.dir, nil)
+	c.Assert(err, IsNil)
+	c.Check(ginfo.Connections, DeepEquals, []gadget.Connection{
+		{
+			Plug: gadget.ConnectionPlug{SnapID: "snapid1", Plug: "serial"},
+			Slot: gadget.ConnectionSlot{SnapID: "snapid2", Slot: "serial"},
+			PlugAttrs: map[string]any{
+				"baud-rate": int64(115200),
+				"flags":     []any{"raw", "nonblock"},
+			},
+			SlotAttrs: map[string]any{
+				"path": "/dev/ttyS1",
+				"usb":  map[string]any{"vendor-id": int64(0x1234)},
+			},
+		},
+		{Plug: gadget.ConnectionPlug{SnapID: "snapid3", Plug: "process-control"}, Slot: gadget.ConnectionSlot{SnapID: "system", Slot: "process-control"}},
+	})
+}
+
+func (s *gadgetYamlTestSuite) TestReadGadgetYamlInvalidConnectionAttributes(c *C) {
+	mockGadgetYamlBroken := `
+connections:
+ - plug: snapid1:serial
+   @INVALID@
+`
+	tests := []struct {
+		invalidAttrs string
+		expectedErr  string
+	}{
+		{`plug-attributes: {interface: serial-port}`, `gadget connection plug snapid1:serial: invalid attribute name "interface"`},
+		{`slot-attributes: {label: foo}`, `gadget connection slot system:serial: invalid attribute name "label"`},
+		{`plug-attributes: {foo: {1: bar}}`, `gadget connection plug snapid1:serial: attribute "foo": .*`},
+		{`slot-attributes: [foo]`, `(?s).*unmarshal errors:.*`},
+	}
+
+	for _, t := range tests {
+		mockGadgetYamlBroken := strings.Replace(mockGadgetYamlBroken, "@INVALID@", t.invalidAttrs, 1)
+
+		err := os.WriteFile(s.gadgetYamlPath, []byte(mockGadgetYamlBroken), 0644)
+		c.Assert(err, IsNil)
+
+		_, err = gadget.ReadInfo(s.dir, nil)
+		c.Check(err, ErrorMatches, t.expectedErr, Commentf(t.invalidAttrs))
+	}
+}
+
 func (s *gadgetYamlTestSuite) TestReadGadgetYamlInvalidConnection(c *C) {
 	mockGadgetYamlBroken := `
 connections:
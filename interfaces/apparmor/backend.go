@@ -46,6 +46,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/snapcore/snapd/dirs"
 	"github.com/snapcore/snapd/interfaces"
@@ -79,6 +80,12 @@ type Backend struct {
 
 	coreSnap  *snap.Info
 	snapdSnap *snap.Info
+
+	// degradedFeatures are indexed by snap instance name and record the
+	// apparmor kernel features which the interfaces of the snap could not
+	// rely on, see Specification.DegradedFeatures.
+	degradedMu       sync.Mutex
+	degradedFeatures map[string]map[string][]string
 }
 
 // Name returns the name of the backend.
@@ -386,15 +393,8 @@ func (b *Backend) prepareProfiles(appSet *interfaces.SnapAppSet, opts interfaces
 	}
 
 	snapInfo := appSet.Info()
-
-	// Add snippets for parallel snap installation mapping
-	spec.(*Specification).AddOvername(snapInfo)
-
-	// Add snippets derived from the layout definition.
-	spec.(*Specification).AddLayout(appSet)
-
-	// Add additional mount layouts rules for the snap.
-	spec.(*Specification).AddExtraLayouts(snapInfo, opts.ExtraLayouts)
+	addSnapSpecificSnippets(spec.(*Specification), appSet, opts)
+	b.recordDegradedFeatures(snapName, spec.(*Specification).DegradedFeatures())
 
 	// core on classic is special
 	if snapName == "core" && release.OnClassic && apparmor_sandbox.ProbedLevel() != apparmor_sandbox.Unsupported {
@@ -470,6 +470,75 @@ func (b *Backend) prepareProfiles(appSet *interfaces.SnapAppSet, opts interfaces
 	return &profilePathsResults{changed: changedPaths, removed: removedPaths, unchanged: unchangedPaths}, nil
 }
 
+// recordDegradedFeatures remembers the apparmor kernel features which the
+// interfaces of the given snap could not rely on, so that they are reported
+// along with the sandbox features.
+func (b *Backend) recordDegradedFeatures(snapName string, degraded map[string][]string) {
+	b.degradedMu.Lock()
+	defer b.degradedMu.Unlock()
+	if len(degraded) == 0 {
+		delete(b.degradedFeatures, snapName)
+		return
+	}
+	if b.degradedFeatures == nil {
+		b.degradedFeatures = make(map[string]map[string][]string)
+	}
+	b.degradedFeatures[snapName] = degraded
+}
+
+// degradedFeatureTags returns the sorted "degraded:<feature>:<interface>" tags
+// of the apparmor kernel features which interfaces of any snap could not rely
+// on.
+func (b *Backend) degradedFeatureTags() []string {
+	b.degradedMu.Lock()
+	defer b.degradedMu.Unlock()
+	var tags []string
+	for _, degraded := range b.degradedFeatures {
+		for feature, ifaces := range degraded {
+			for _, iface := range ifaces {
+				tag := fmt.Sprintf("degraded:%s:%s", feature, iface)
+				if !strutil.ListContains(tags, tag) {
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// addSnapSpecificSnippets adds the snippets which are not contributed by
+// interfaces but derived from the snap itself.
+func addSnapSpecificSnippets(spec *Specification, appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions) {
+	snapInfo := appSet.Info()
+
+	// Add snippets for parallel snap installation mapping
+	spec.AddOvername(snapInfo)
+
+	// Add snippets derived from the layout definition.
+	spec.AddLayout(appSet)
+
+	// Add additional mount layouts rules for the snap.
+	spec.AddExtraLayouts(snapInfo, opts.ExtraLayouts)
+}
+
+// Profiles returns the apparmor profiles of the apps and hooks of a given
+// snap, as Setup would write them.
+func (b *Backend) Profiles(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, repo *interfaces.Repository) (map[string][]byte, error) {
+	spec, err := repo.SnapSpecification(b.Name(), appSet, opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot obtain apparmor specification for snap %q: %s", appSet.InstanceName(), err)
+	}
+	addSnapSpecificSnippets(spec.(*Specification), appSet, opts)
+
+	content := b.deriveContent(spec.(*Specification), appSet, opts)
+	profiles := make(map[string][]byte, len(content))
+	for name, state := range content {
+		profiles[filepath.Join(dirs.SnapAppArmorDir, name)] = state.(*osutil.MemoryFileState).Content
+	}
+	return profiles, nil
+}
+
 // Setup creates and loads apparmor profiles specific to a given snap.
 // The snap can be in developer mode to make security violations non-fatal to
 // the offending application process.
@@ -612,6 +681,8 @@ func RemoveAllSnapAppArmorProfiles() error {
 
 // Remove removes the apparmor profiles of a given snap from disk and the cache.
 func (b *Backend) Remove(snapName string) error {
+	b.recordDegradedFeatures(snapName, nil)
+
 	dir := dirs.SnapAppArmorDir
 	globs := profileGlobs(snapName)
 	cache := apparmor_sandbox.CacheDir
@@ -1028,6 +1099,13 @@ func (b *Backend) addContent(securityTag string, snapInfo *snap.Info, cmdName st
 		return ""
 	})
 
+	// Describe the interfaces which contributed to the profile, so that
+	// the generated file is easier to inspect. This has no effect on the
+	// policy itself.
+	if names := spec.InterfacesForTag(securityTag); len(names) > 0 && !ignoreSnippets {
+		policy = fmt.Sprintf("# interfaces: %s\n", strings.Join(names, ", ")) + policy
+	}
+
 	content[securityTag] = &osutil.MemoryFileState{
 		Content: []byte(policy),
 		Mode:    0644,
@@ -1039,6 +1117,8 @@ func (b *Backend) NewSpecification(appSet *interfaces.SnapAppSet, opts interface
 	return &Specification{
 		appSet:          appSet,
 		usePromptPrefix: opts.AppArmorPrompting,
+		confinement:     opts.Confinement(),
+		auditedPlugs:    opts.AuditedPlugs,
 	}
 }
 
@@ -1071,5 +1151,9 @@ func (b *Backend) SandboxFeatures() []string {
 	tags = append(tags, fmt.Sprintf("support-level:%s", level))
 	tags = append(tags, fmt.Sprintf("policy:%s", policy))
 
+	// Interfaces which dropped or replaced rules because the kernel does
+	// not mediate a feature are listed as "degraded:<feature>:<interface>".
+	tags = append(tags, b.degradedFeatureTags()...)
+
 	return tags
 }
@@ -25,6 +25,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	. "gopkg.in/check.v1"
@@ -1190,12 +1191,17 @@ const commonPrefix = `
 @{SNAP_NAME}="samba"
 # This is a snap name with instance key
 @{SNAP_INSTANCE_NAME}="samba"
+# This is the instance key, empty unless the snap is a parallel instance
+@{SNAP_INSTANCE_KEY}=""
 @{SNAP_INSTANCE_DESKTOP}="samba"
 @{SNAP_COMMAND_NAME}="smbd"
 @{SNAP_REVISION}="1"
 @{PROFILE_DBUS}="snap_2esamba_2esmbd"
 @{INSTALL_DIR}="/{,var/lib/snapd/}snap"`
 
+// interfacesHeader lists the interfaces contributing snippets to the profile
+const interfacesHeader = "# interfaces: iface\n"
+
 var combineSnippetsScenarios = []combineSnippetsScenario{{
 	// By default apparmor is enforcing mode.
 	opts:    interfaces.ConfinementOptions{},
@@ -1204,17 +1210,17 @@ var combineSnippetsScenarios = []combineSnippetsScenario{{
 	// Snippets are injected in the space between "{" and "}"
 	opts:    interfaces.ConfinementOptions{},
 	snippet: "snippet",
-	content: commonPrefix + "\nprofile \"snap.samba.smbd\" flags=(attach_disconnected,mediate_deleted) {\nsnippet\n}\n",
+	content: interfacesHeader + commonPrefix + "\nprofile \"snap.samba.smbd\" flags=(attach_disconnected,mediate_deleted) {\nsnippet\n}\n",
 }, {
 	// DevMode switches apparmor to non-enforcing (complain) mode.
 	opts:    interfaces.ConfinementOptions{DevMode: true},
 	snippet: "snippet",
-	content: commonPrefix + "\nprofile \"snap.samba.smbd\" flags=(attach_disconnected,mediate_deleted,complain) {\nsnippet\n}\n",
+	content: interfacesHeader + commonPrefix + "\nprofile \"snap.samba.smbd\" flags=(attach_disconnected,mediate_deleted,complain) {\nsnippet\n}\n",
 }, {
 	// JailMode switches apparmor to enforcing mode even in the presence of DevMode.
 	opts:    interfaces.ConfinementOptions{DevMode: true},
 	snippet: "snippet",
-	content: commonPrefix + "\nprofile \"snap.samba.smbd\" flags=(attach_disconnected,mediate_deleted,complain) {\nsnippet\n}\n",
+	content: interfacesHeader + commonPrefix + "\nprofile \"snap.samba.smbd\" flags=(attach_disconnected,mediate_deleted,complain) {\nsnippet\n}\n",
 }, {
 	// Classic confinement (without jailmode) uses apparmor in complain mode by default and ignores all snippets.
 	opts:    interfaces.ConfinementOptions{Classic: true},
@@ -1224,7 +1230,7 @@ var combineSnippetsScenarios = []combineSnippetsScenario{{
 	// Classic confinement in JailMode uses enforcing apparmor.
 	opts:    interfaces.ConfinementOptions{Classic: true, JailMode: true},
 	snippet: "snippet",
-	content: commonPrefix + `
+	content: interfacesHeader + commonPrefix + `
 profile "snap.samba.smbd" flags=(attach_disconnected,mediate_deleted) {
 
   # Read-only access to the core snap.
@@ -1244,6 +1250,41 @@ snippet
 `,
 }}
 
+const auditedPlugYaml = `
+name: samba
+version: 1
+developer: acme
+apps:
+    smbd:
+        plugs: [plug]
+    nmbd:
+plugs:
+    plug:
+        interface: iface
+`
+
+func (s *backendSuite) TestAuditedPlugsEnforce(c *C) {
+	restore := apparmor_sandbox.MockLevel(apparmor_sandbox.Full)
+	defer restore()
+	restore = osutil.MockIsHomeUsingRemoteFS(func() (bool, error) { return false, nil })
+	defer restore()
+	restore = osutil.MockIsRootWritableOverlay(func() (string, error) { return "", nil })
+	defer restore()
+	restoreTemplate := apparmor.MockTemplate("\n" +
+		"###PROFILEATTACH### ###FLAGS### {\n" +
+		"}\n")
+	defer restoreTemplate()
+
+	// the profiles of the apps bound to an audited plug are still
+	// enforcing, only the rules of the plug are audited
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{AuditedPlugs: []string{"plug"}}, "", auditedPlugYaml, 1)
+	c.Check(filepath.Join(dirs.SnapAppArmorDir, "snap.samba.smbd"), testutil.FileContains,
+		"profile \"snap.samba.smbd\" flags=(attach_disconnected,mediate_deleted) {\n")
+	c.Check(filepath.Join(dirs.SnapAppArmorDir, "snap.samba.nmbd"), testutil.FileContains,
+		"profile \"snap.samba.nmbd\" flags=(attach_disconnected,mediate_deleted) {\n")
+	s.RemoveSnap(c, snapInfo)
+}
+
 func (s *backendSuite) TestCombineSnippets(c *C) {
 	restore := apparmor_sandbox.MockLevel(apparmor_sandbox.Full)
 	defer restore()
@@ -1482,6 +1523,8 @@ func (s *backendSuite) TestParallelInstallCombineSnippets(c *C) {
 @{SNAP_NAME}="samba"
 # This is a snap name with instance key
 @{SNAP_INSTANCE_NAME}="samba_foo"
+# This is the instance key, empty unless the snap is a parallel instance
+@{SNAP_INSTANCE_KEY}="foo"
 @{SNAP_INSTANCE_DESKTOP}="samba+foo"
 @{SNAP_COMMAND_NAME}="smbd"
 @{SNAP_REVISION}="1"
@@ -1521,6 +1564,8 @@ func (s *backendSuite) TestTemplateVarsWithHook(c *C) {
 @{SNAP_NAME}="foo"
 # This is a snap name with instance key
 @{SNAP_INSTANCE_NAME}="foo"
+# This is the instance key, empty unless the snap is a parallel instance
+@{SNAP_INSTANCE_KEY}=""
 @{SNAP_INSTANCE_DESKTOP}="foo"
 @{SNAP_COMMAND_NAME}="hook.configure"
 @{SNAP_REVISION}="1"
@@ -1560,6 +1605,8 @@ func (s *backendSuite) TestTemplateVarsWithComponentHook(c *C) {
 @{SNAP_NAME}="snap"
 # This is a snap name with instance key
 @{SNAP_INSTANCE_NAME}="snap"
+# This is the instance key, empty unless the snap is a parallel instance
+@{SNAP_INSTANCE_KEY}=""
 @{SNAP_INSTANCE_DESKTOP}="snap"
 @{SNAP_COMMAND_NAME}="snap+comp.hook.install"
 @{SNAP_REVISION}="1"
@@ -2520,6 +2567,41 @@ func (s *backendSuite) TestSandboxFeaturesPartial(c *C) {
 	c.Assert(s.Backend.SandboxFeatures(), DeepEquals, []string{"kernel:foo", "kernel:bar", "parser:baz", "parser:norf", "support-level:partial", "policy:default"})
 }
 
+func (s *backendSuite) TestSandboxFeaturesDegraded(c *C) {
+	restore := apparmor_sandbox.MockLevel(apparmor_sandbox.Partial)
+	defer restore()
+	restore = apparmor.MockKernelFeatures(func() ([]string, error) { return []string{"file"}, nil })
+	defer restore()
+	restore = apparmor.MockParserFeatures(func() ([]string, error) { return nil, nil })
+	defer restore()
+	restore = apparmor.MockKernelFeatureSupported(func(feature string) bool { return feature == "file" })
+	defer restore()
+	s.Iface.AppArmorPermanentSlotCallback = func(spec *apparmor.Specification, slot *snap.SlotInfo) error {
+		if !spec.AddSnippetIfFeature("mount", "mount -> /foo/,") {
+			spec.AddSnippet("# mounts are not mediated")
+		}
+		spec.AddSnippetIfFeature("dbus", "dbus send,")
+		return nil
+	}
+
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", ifacetest.SambaYamlV1, 1)
+	profile := filepath.Join(dirs.SnapAppArmorDir, "snap.samba.smbd")
+	c.Check(profile, testutil.FileContains, "# mounts are not mediated")
+	c.Check(profile, Not(testutil.FileContains), "mount -> /foo/,")
+	c.Check(profile, Not(testutil.FileContains), "dbus send,")
+
+	c.Check(s.Backend.SandboxFeatures(), DeepEquals, []string{
+		"kernel:file", "support-level:partial", "policy:default",
+		"degraded:dbus:iface", "degraded:mount:iface",
+	})
+
+	// the degraded features are forgotten along with the snap
+	s.RemoveSnap(c, snapInfo)
+	c.Check(s.Backend.SandboxFeatures(), DeepEquals, []string{
+		"kernel:file", "support-level:partial", "policy:default",
+	})
+}
+
 func (s *backendSuite) TestParallelInstanceSetupSnapUpdateNS(c *C) {
 	dirs.SetRootDir(s.RootDir)
 
@@ -3062,3 +3144,22 @@ func (s *backendSuite) TestKernelModulesAndFwRule(c *C) {
 		s.RemoveSnap(c, snapInfo)
 	}
 }
+
+func (s *backendSuite) TestProfilesMatchSetup(c *C) {
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", ifacetest.SambaYamlV1, 1)
+	appSet, err := interfaces.NewSnapAppSet(snapInfo, nil)
+	c.Assert(err, IsNil)
+
+	profiles, err := s.Backend.(interfaces.SecurityBackendProfiles).Profiles(appSet, interfaces.ConfinementOptions{}, s.Repo)
+	c.Assert(err, IsNil)
+	paths := make([]string, 0, len(profiles))
+	for path, content := range profiles {
+		paths = append(paths, path)
+		c.Check(path, testutil.FileEquals, string(content))
+	}
+	sort.Strings(paths)
+	c.Check(paths, DeepEquals, []string{
+		filepath.Join(dirs.SnapAppArmorDir, "snap-update-ns.samba"),
+		filepath.Join(dirs.SnapAppArmorDir, "snap.samba.smbd"),
+	})
+}
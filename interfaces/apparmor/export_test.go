@@ -99,6 +99,10 @@ func MockMetadataTagsSupported(f func() bool) (restore func()) {
 	return testutil.Mock(&metadataTagsSupported, f)
 }
 
+func MockKernelFeatureSupported(f func(feature string) bool) (restore func()) {
+	return testutil.Mock(&kernelFeatureSupported, f)
+}
+
 func (b *Backend) SetupSnapConfineReexec(info *snap.Info) error {
 	return b.setupSnapConfineReexec(info)
 }
@@ -0,0 +1,394 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+package apparmor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/snapcore/snapd/osutil"
+)
+
+var mountConditionals = map[string]bool{
+	"flags":   true,
+	"fstype":  true,
+	"options": true,
+	"vfstype": true,
+}
+
+// parseMountRule extracts the filesystem type, the source and the target of
+// mount, remount and umount rules, fields start with the rule keyword.
+func parseMountRule(rule Rule, fields []string) (Rule, error) {
+	keyword := fields[0]
+	var positional []string
+	arrow := false
+	for i := 1; i < len(fields); i++ {
+		f := fields[i]
+		name, value, hasValue := strings.Cut(f, "=")
+		if !mountConditionals[name] {
+			if f == "->" {
+				arrow = true
+				positional = append(positional, f)
+				continue
+			}
+			positional = append(positional, strings.Trim(f, `"`))
+			continue
+		}
+		// the value may be separated from the name, as in
+		// "options in (ro, bind)", or span multiple fields
+		if !hasValue || value == "" {
+			if !hasValue && i+1 < len(fields) && (fields[i+1] == "in" || fields[i+1] == "=") {
+				i++
+			}
+			i++
+			if i >= len(fields) {
+				return rule, fmt.Errorf("cannot parse apparmor mount rule %q: missing %s value", rule.Text, name)
+			}
+			value = fields[i]
+		}
+		for strings.HasPrefix(value, "(") && !strings.Contains(value, ")") {
+			i++
+			if i >= len(fields) {
+				return rule, fmt.Errorf("cannot parse apparmor mount rule %q: unterminated %s list", rule.Text, name)
+			}
+			value += " " + fields[i]
+		}
+		if name == "fstype" || name == "vfstype" {
+			rule.FSType = strings.Trim(value, `"`)
+		}
+	}
+	if arrow {
+		if len(positional) > 3 || positional[len(positional)-1] == "->" {
+			return rule, fmt.Errorf("cannot parse apparmor mount rule %q: invalid mount point", rule.Text)
+		}
+	} else if len(positional) > 1 {
+		return rule, fmt.Errorf("cannot parse apparmor mount rule %q: too many paths", rule.Text)
+	}
+	switch {
+	case arrow:
+		if positional[0] != "->" {
+			rule.Source = positional[0]
+		}
+		rule.Target = positional[len(positional)-1]
+	case len(positional) == 1 && keyword == "mount":
+		rule.Source = positional[0]
+	case len(positional) == 1:
+		// umount and remount only take the mount point
+		rule.Target = positional[0]
+	}
+	return rule, nil
+}
+
+// MountRulesConflict returns whether two mount rules allow mounting
+// filesystems of different types over overlapping mount points. Mounting a
+// filesystem on top of, or below, one of another type is how the guarantees
+// of the latter can be sidestepped, for example to shadow a read-only mount.
+// Only mount rules are considered, umount and remount rules never conflict.
+func MountRulesConflict(a, b Rule) bool {
+	if !isMountRule(a) || !isMountRule(b) {
+		return false
+	}
+	if a.FSType == b.FSType {
+		return false
+	}
+	return PatternsOverlap(a.Target, b.Target)
+}
+
+func isMountRule(rule Rule) bool {
+	return rule.Kind == MountRule && strings.HasPrefix(rule.Text, "mount")
+}
+
+// mountEntryVariables maps the snap variables which may be used by mount
+// entries to the apparmor variables with the same meaning. Both the $VAR and
+// the ${VAR} forms are accepted, the longest names come first so that
+// $SNAP_INSTANCE_NAME is not taken for $SNAP_INSTANCE followed by _NAME.
+var mountEntryVariables = strings.NewReplacer(
+	"${SNAP_INSTANCE_NAME}", "@{SNAP_INSTANCE_NAME}",
+	"$SNAP_INSTANCE_NAME", "@{SNAP_INSTANCE_NAME}",
+	"${SNAP_REVISION}", "@{SNAP_REVISION}",
+	"$SNAP_REVISION", "@{SNAP_REVISION}",
+	"${SNAP_NAME}", "@{SNAP_NAME}",
+	"$SNAP_NAME", "@{SNAP_NAME}",
+)
+
+// mountRulePath returns the given mount entry path for use in a mount rule,
+// with snap variables turned into apparmor variables. Paths with blanks are
+// quoted, apparmor still expands the variables of quoted paths.
+func mountRulePath(path string) string {
+	path = mountEntryVariables.Replace(path)
+	if strings.ContainsAny(path, " \t") {
+		return fmt.Sprintf("%q", path)
+	}
+	return path
+}
+
+// MountRuleFromEntry returns the apparmor rule allowing the mount described
+// by the given entry, so that interfaces can derive the rule from the same
+// entry as the one used by the mount backend. The filesystem type is omitted
+// when the entry does not set one, or sets it to "none".
+func MountRuleFromEntry(e osutil.MountEntry) string {
+	var buf strings.Builder
+	buf.WriteString("mount")
+	if e.Type != "" && e.Type != "none" {
+		fmt.Fprintf(&buf, " fstype=%s", e.Type)
+	}
+	if len(e.Options) > 0 {
+		fmt.Fprintf(&buf, " options=(%s)", strings.Join(e.Options, ","))
+	}
+	fmt.Fprintf(&buf, " %s -> %s,", mountRulePath(e.Name), mountRulePath(e.Dir))
+	return buf.String()
+}
+
+// snapWritableMountPrefixes are the directories of the snap which mount
+// rules of interfaces may target, the writable data directories of the snap
+// and of its users.
+var snapWritableMountPrefixes = []string{
+	"/home/*/snap/@{SNAP_INSTANCE_NAME}/",
+	"/var/snap/@{SNAP_NAME}/",
+	"/var/snap/@{SNAP_INSTANCE_NAME}/",
+}
+
+// ValidateMountTargetSafe returns an error if the given rule is a mount,
+// remount or umount rule allowing a mount point outside of the writable
+// directories of the snap. Rules of other kinds and deny rules are always
+// accepted. It is meant to be used by tests to catch interfaces targeting
+// system directories by mistake.
+//
+// The target must be restricted by the rule and each of its alternatives must
+// start with one of the snap writable directories, verbatim.
+func ValidateMountTargetSafe(rule Rule) error {
+	if rule.Kind != MountRule || rule.HasQualifier("deny") {
+		return nil
+	}
+	if rule.Target == "" {
+		return fmt.Errorf("mount rule %q does not restrict the mount point", rule.Text)
+	}
+	// variables use braces too, hide them while expanding alternations
+	masked := variableRegexp.ReplaceAllStringFunc(rule.Target, func(v string) string {
+		return "\x00" + v[2:len(v)-1] + "\x00"
+	})
+	for _, target := range expandAlternations(masked) {
+		target = maskedVariableRegexp.ReplaceAllString(target, "@{$1}")
+		if strings.Contains("/"+target+"/", "/../") {
+			return fmt.Errorf("mount rule %q targets %q which is not a snap writable directory", rule.Text, target)
+		}
+		safe := false
+		for _, prefix := range snapWritableMountPrefixes {
+			if strings.HasPrefix(target, prefix) {
+				safe = true
+				break
+			}
+		}
+		if !safe {
+			return fmt.Errorf("mount rule %q targets %q which is not a snap writable directory", rule.Text, target)
+		}
+	}
+	return nil
+}
+
+var variableRegexp = regexp.MustCompile(`@\{[A-Za-z0-9_]+\}`)
+
+var maskedVariableRegexp = regexp.MustCompile("\x00([A-Za-z0-9_]+)\x00")
+
+// PatternsOverlap returns whether there is a path which matches both of the
+// given apparmor path patterns. An empty pattern matches any path.
+//
+// The analysis is conservative: variables are assumed to expand into any
+// single path component and character classes into any character but '/',
+// so patterns may be reported as overlapping when they are not.
+func PatternsOverlap(a, b string) bool {
+	if a == "" || b == "" {
+		return true
+	}
+	for _, x := range expandAlternations(variableRegexp.ReplaceAllString(a, "*")) {
+		for _, y := range expandAlternations(variableRegexp.ReplaceAllString(b, "*")) {
+			if globsOverlap(tokenizeGlob(x), tokenizeGlob(y)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// expandAlternations expands the {a,b} alternations of a pattern, which may
+// be nested, into the list of patterns without alternations.
+func expandAlternations(pattern string) []string {
+	start := strings.IndexByte(pattern, '{')
+	if start < 0 {
+		return []string{pattern}
+	}
+	depth := 0
+	var choices []string
+	last := start + 1
+	for i := start; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			depth++
+		case ',':
+			if depth == 1 {
+				choices = append(choices, pattern[last:i])
+				last = i + 1
+			}
+		case '}':
+			depth--
+			if depth == 0 {
+				choices = append(choices, pattern[last:i])
+				var expanded []string
+				for _, choice := range choices {
+					expanded = append(expanded, expandAlternations(pattern[:start]+choice+pattern[i+1:])...)
+				}
+				return expanded
+			}
+		}
+	}
+	// unbalanced, take the brace literally
+	return []string{pattern}
+}
+
+type globTokenKind int
+
+const (
+	globLiteral globTokenKind = iota
+	// globOne matches any single character but '/'
+	globOne
+	// globStar matches any sequence of characters without '/'
+	globStar
+	// globDoubleStar matches any sequence of characters
+	globDoubleStar
+)
+
+type globToken struct {
+	kind globTokenKind
+	ch   byte
+}
+
+func tokenizeGlob(pattern string) []globToken {
+	var tokens []globToken
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; ch {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				tokens = append(tokens, globToken{kind: globDoubleStar})
+				i++
+			} else {
+				tokens = append(tokens, globToken{kind: globStar})
+			}
+		case '?':
+			tokens = append(tokens, globToken{kind: globOne})
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				tokens = append(tokens, globToken{kind: globLiteral, ch: ch})
+				continue
+			}
+			tokens = append(tokens, globToken{kind: globOne})
+			i += end + 1
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+			tokens = append(tokens, globToken{kind: globLiteral, ch: pattern[i]})
+		default:
+			tokens = append(tokens, globToken{kind: globLiteral, ch: ch})
+		}
+	}
+	return tokens
+}
+
+// globClosure adds to states the positions reachable by matching stars
+// against the empty string.
+func globClosure(tokens []globToken, pos int, states map[int]bool) {
+	for !states[pos] {
+		states[pos] = true
+		if pos == len(tokens) || (tokens[pos].kind != globStar && tokens[pos].kind != globDoubleStar) {
+			return
+		}
+		pos++
+	}
+}
+
+// globStep returns the positions reached from pos after matching ch.
+func globStep(tokens []globToken, pos int, ch byte) []int {
+	if pos == len(tokens) {
+		return nil
+	}
+	switch t := tokens[pos]; t.kind {
+	case globLiteral:
+		if t.ch == ch {
+			return []int{pos + 1}
+		}
+	case globOne:
+		if ch != '/' {
+			return []int{pos + 1}
+		}
+	case globStar:
+		if ch != '/' {
+			return []int{pos}
+		}
+	case globDoubleStar:
+		return []int{pos}
+	}
+	return nil
+}
+
+// globsOverlap explores the product of the automatons of both globs, looking
+// for a string accepted by both.
+func globsOverlap(a, b []globToken) bool {
+	// besides '/' and the literal characters of the globs, a character
+	// appearing in neither stands for all the others
+	alphabet := map[byte]bool{'/': true, 0: true}
+	for _, t := range append(append([]globToken(nil), a...), b...) {
+		if t.kind == globLiteral {
+			alphabet[t.ch] = true
+		}
+	}
+	type state struct{ i, j int }
+	seen := make(map[state]bool)
+	var queue []state
+	push := func(i, j int) {
+		is, js := make(map[int]bool), make(map[int]bool)
+		globClosure(a, i, is)
+		globClosure(b, j, js)
+		for i := range is {
+			for j := range js {
+				if s := (state{i, j}); !seen[s] {
+					seen[s] = true
+					queue = append(queue, s)
+				}
+			}
+		}
+	}
+	push(0, 0)
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		if s.i == len(a) && s.j == len(b) {
+			return true
+		}
+		for ch := range alphabet {
+			for _, i := range globStep(a, s.i, ch) {
+				for _, j := range globStep(b, s.j, ch) {
+					push(i, j)
+				}
+			}
+		}
+	}
+	return false
+}
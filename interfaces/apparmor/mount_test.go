@@ -0,0 +1,184 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+package apparmor_test
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/osutil"
+)
+
+type mountSuite struct{}
+
+var _ = Suite(&mountSuite{})
+
+func (s *mountSuite) TestParseMountRules(c *C) {
+	rules, err := apparmor.ParseRules(`
+mount fstype="tmpfs" options=(rw, nosuid, strictatime) tmpfs -> /var/snap/foo/rootfs/dev/,
+mount options in (ro, bind) /a -> /b,
+mount options = (rw, rshared) -> /run/netns/,
+mount /dev/hugepages,
+mount fstype=(ext4, xfs),
+mount,
+remount /a,
+umount fstype=nfs{,4} /var/snap/foo/common/{,**},
+`)
+	c.Assert(err, IsNil)
+	type mount struct{ fstype, source, target string }
+	var mounts []mount
+	for _, rule := range rules {
+		c.Check(rule.Kind, Equals, apparmor.MountRule)
+		mounts = append(mounts, mount{rule.FSType, rule.Source, rule.Target})
+	}
+	c.Check(mounts, DeepEquals, []mount{
+		{"tmpfs", "tmpfs", "/var/snap/foo/rootfs/dev/"},
+		{"", "/a", "/b"},
+		{"", "", "/run/netns/"},
+		{"", "/dev/hugepages", ""},
+		{"(ext4, xfs)", "", ""},
+		{"", "", ""},
+		{"", "", "/a"},
+		{"nfs{,4}", "", "/var/snap/foo/common/{,**}"},
+	})
+}
+
+func (s *mountSuite) TestPatternsOverlap(c *C) {
+	for _, t := range []struct {
+		a, b    string
+		overlap bool
+	}{
+		{"/a", "/a", true},
+		{"/a", "/b", false},
+		{"", "/b", true},
+		{"/a/*", "/a/b", true},
+		{"/a/*", "/a/b/c", false},
+		{"/a/**", "/a/b/c", true},
+		{"/a/{,**}", "/a/", true},
+		{"/a/{b,c}/d", "/a/c/*", true},
+		{"/a/{b,{c,d}}", "/a/d", true},
+		{"/a/{b,c}", "/a/d", false},
+		{"/a/*/c", "/a/b/*", true},
+		{"/a/*/c", "/a/**/d", false},
+		{"/dev/sd[a-z]", "/dev/sdb", true},
+		{"/dev/sd[a-z]", "/dev/sd/", false},
+		{"/dev/sd?", "/dev/sdb", true},
+		{"/var/snap/@{SNAP_INSTANCE_NAME}/common/**", "/var/snap/foo/common/bar", true},
+		{"/var/snap/@{SNAP_INSTANCE_NAME}/common/**", "/var/snap/foo/bar/common/baz", false},
+		{"/home/*/snap/**", "/var/snap/**", false},
+		{`/a\*`, "/ab", false},
+	} {
+		comment := Commentf("%q and %q", t.a, t.b)
+		c.Check(apparmor.PatternsOverlap(t.a, t.b), Equals, t.overlap, comment)
+		c.Check(apparmor.PatternsOverlap(t.b, t.a), Equals, t.overlap, comment)
+	}
+}
+
+func (s *mountSuite) TestMountRulesConflict(c *C) {
+	rules, err := apparmor.ParseRules(`
+mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /var/snap/foo/common/{,**/},
+mount fstype=nfs{,4} *:** -> /var/snap/foo/common/{,**},
+mount fstype=nfs{,4} *:** -> /var/snap/foo/1/{,**},
+mount options=(rw, bind) /var/snap/foo/common/ -> /var/snap/foo/common/,
+umount /var/snap/foo/common/{,**},
+mount fstype=sysfs,
+`)
+	c.Assert(err, IsNil)
+	fuse, nfsCommon, nfsRevision, bind, umount, sysfs := rules[0], rules[1], rules[2], rules[3], rules[4], rules[5]
+
+	c.Check(apparmor.MountRulesConflict(fuse, nfsCommon), Equals, true)
+	c.Check(apparmor.MountRulesConflict(fuse, bind), Equals, true)
+	// without a mount point any mount point is allowed
+	c.Check(apparmor.MountRulesConflict(sysfs, nfsRevision), Equals, true)
+	// same filesystem type
+	c.Check(apparmor.MountRulesConflict(nfsCommon, nfsRevision), Equals, false)
+	// disjoint mount points
+	c.Check(apparmor.MountRulesConflict(fuse, nfsRevision), Equals, false)
+	// umount rules never conflict
+	c.Check(apparmor.MountRulesConflict(fuse, umount), Equals, false)
+	c.Check(apparmor.MountRulesConflict(umount, sysfs), Equals, false)
+}
+
+func (s *mountSuite) TestValidateMountTargetSafe(c *C) {
+	for _, text := range []string{
+		"mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/common/{,**/},",
+		"mount fstype=nfs{,4} *:** -> /home/*/snap/@{SNAP_INSTANCE_NAME}/@{SNAP_REVISION}/{,**},",
+		"umount /var/snap/@{SNAP_NAME}/{@{SNAP_REVISION},common}/{,**/},",
+		"remount /var/snap/@{SNAP_INSTANCE_NAME}/common/,",
+		// deny rules and rules of other kinds are not mount targets
+		"deny mount,",
+		"/etc/** rw,",
+	} {
+		rules, err := apparmor.ParseRules(text)
+		c.Assert(err, IsNil)
+		c.Assert(rules, HasLen, 1)
+		c.Check(apparmor.ValidateMountTargetSafe(rules[0]), IsNil, Commentf(text))
+	}
+
+	for _, t := range []struct {
+		text string
+		err  string
+	}{
+		{"mount,", `mount rule "mount" does not restrict the mount point`},
+		{"mount fstype=sysfs,", `mount rule "mount fstype=sysfs" does not restrict the mount point`},
+		{"mount options=(rw, bind) /run/ -> /run/,", `.* targets "/run/" which is not a snap writable directory`},
+		{"umount /,", `.* targets "/" which is not a snap writable directory`},
+		{"mount ** -> /{var/snap/@{SNAP_NAME}/common,etc}/,", `.* targets "/etc/" which is not a snap writable directory`},
+		{"mount ** -> /var/snap/*/common/,", `.* targets "/var/snap/\*/common/" which is not a snap writable directory`},
+		{"mount ** -> /var/snap/@{SNAP_NAME}/../../etc/,", `.* targets "/var/snap/@{SNAP_NAME}/../../etc/" which is not a snap writable directory`},
+		{"mount ** -> /var/snap/@{SNAP_NAME},", `.* targets "/var/snap/@{SNAP_NAME}" which is not a snap writable directory`},
+	} {
+		rules, err := apparmor.ParseRules(t.text)
+		c.Assert(err, IsNil)
+		c.Assert(rules, HasLen, 1)
+		c.Check(apparmor.ValidateMountTargetSafe(rules[0]), ErrorMatches, t.err, Commentf(t.text))
+	}
+}
+
+func (s *mountSuite) TestMountRuleFromEntry(c *C) {
+	for _, t := range []struct {
+		entry osutil.MountEntry
+		rule  string
+	}{{
+		entry: osutil.MountEntry{Name: "**", Dir: "/var/snap/@{SNAP_NAME}/@{SNAP_REVISION}/{,**/}", Type: "fuse.*", Options: []string{"rw", "nosuid", "nodev"}},
+		rule:  "mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /var/snap/@{SNAP_NAME}/@{SNAP_REVISION}/{,**/},",
+	}, {
+		entry: osutil.MountEntry{Name: "/dev/loop[0-9]*", Dir: "/var/snap/$SNAP_NAME/$SNAP_REVISION/mnt/", Type: "ext4", Options: []string{"ro"}},
+		rule:  "mount fstype=ext4 options=(ro) /dev/loop[0-9]* -> /var/snap/@{SNAP_NAME}/@{SNAP_REVISION}/mnt/,",
+	}, {
+		entry: osutil.MountEntry{Name: "/var/lib/snapd/hostfs/srv", Dir: "/home/*/snap/${SNAP_INSTANCE_NAME}/common/srv", Type: "none", Options: []string{"bind"}},
+		rule:  "mount options=(bind) /var/lib/snapd/hostfs/srv -> /home/*/snap/@{SNAP_INSTANCE_NAME}/common/srv,",
+	}, {
+		entry: osutil.MountEntry{Name: "tmpfs", Dir: "/var/snap/$SNAP_INSTANCE_NAME/common/my dir", Type: "tmpfs"},
+		rule:  `mount fstype=tmpfs tmpfs -> "/var/snap/@{SNAP_INSTANCE_NAME}/common/my dir",`,
+	}} {
+		rule := apparmor.MountRuleFromEntry(t.entry)
+		c.Check(rule, Equals, t.rule)
+		if strings.Contains(rule, `"`) {
+			continue
+		}
+		// the generated rule is understood by the rule parser
+		rules, err := apparmor.ParseRules(rule)
+		c.Assert(err, IsNil)
+		c.Assert(rules, HasLen, 1)
+		c.Check(rules[0].Kind, Equals, apparmor.MountRule)
+	}
+}
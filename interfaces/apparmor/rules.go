@@ -0,0 +1,199 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package apparmor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuleKind is the kind of an apparmor rule.
+type RuleKind string
+
+const (
+	FileRule       RuleKind = "file"
+	CapabilityRule RuleKind = "capability"
+	MountRule      RuleKind = "mount"
+	NetworkRule    RuleKind = "network"
+	// OtherRule is used for all rules the parser does not know about,
+	// such as dbus, signal or ptrace rules.
+	OtherRule RuleKind = "other"
+)
+
+// Rule is a single parsed apparmor rule.
+//
+// The parser is minimal: it understands enough of the rule syntax to tell the
+// kinds of rules apart and to extract the most useful fields, the text of the
+// rule is always available in Text.
+type Rule struct {
+	Kind RuleKind
+	// Qualifiers are the rule qualifiers in use, like "deny" or "owner".
+	Qualifiers []string
+	// Text is the rule without qualifiers and the terminating comma.
+	Text string
+
+	// Path and Permissions are set for file rules.
+	Path        string
+	Permissions string
+	// Capabilities are set for capability rules.
+	Capabilities []string
+	// FSType, Source and Target are set for mount rules when the rule
+	// restricts them, an empty value matches anything.
+	FSType string
+	Source string
+	Target string
+}
+
+// HasQualifier returns whether the rule uses the given qualifier.
+func (r Rule) HasQualifier(qualifier string) bool {
+	for _, q := range r.Qualifiers {
+		if q == qualifier {
+			return true
+		}
+	}
+	return false
+}
+
+var ruleQualifiers = map[string]bool{
+	"allow":  true,
+	"audit":  true,
+	"deny":   true,
+	"owner":  true,
+	"prompt": true,
+}
+
+var ruleKeywords = map[string]RuleKind{
+	"capability": CapabilityRule,
+	"file":       FileRule,
+	"mount":      MountRule,
+	"network":    NetworkRule,
+	"remount":    MountRule,
+	"umount":     MountRule,
+}
+
+// stripComment removes a trailing comment from a line. A '#' only starts a
+// comment at the beginning of the line or after whitespace, outside of
+// quotes.
+func stripComment(line string) string {
+	inQuotes := false
+	for i, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == '#' && !inQuotes && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// ParseRules parses the rules of an apparmor policy snippet. Comments,
+// #include directives and block delimiters such as child profiles are
+// skipped, the rules within blocks are returned as any other rules. Rules
+// may span multiple lines and are terminated by a comma.
+func ParseRules(snippet string) ([]Rule, error) {
+	var rules []Rule
+	var pending []string
+	for _, line := range strings.Split(snippet, "\n") {
+		line = strings.TrimSpace(stripComment(line))
+		if line == "" {
+			continue
+		}
+		if len(pending) == 0 && (strings.HasSuffix(line, "{") || line == "}") {
+			continue
+		}
+		pending = append(pending, line)
+		if !strings.HasSuffix(line, ",") {
+			continue
+		}
+		text := strings.TrimSuffix(strings.Join(pending, " "), ",")
+		pending = nil
+		rule, err := parseRule(text)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	if len(pending) != 0 {
+		return nil, fmt.Errorf("cannot parse apparmor rule %q: missing terminating comma", strings.Join(pending, " "))
+	}
+	return rules, nil
+}
+
+func parseRule(text string) (Rule, error) {
+	var rule Rule
+	fields := strings.Fields(text)
+	for len(fields) > 0 && ruleQualifiers[fields[0]] {
+		rule.Qualifiers = append(rule.Qualifiers, fields[0])
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return rule, fmt.Errorf("cannot parse apparmor rule %q: empty rule", text)
+	}
+	rule.Text = strings.Join(fields, " ")
+
+	if kind, ok := ruleKeywords[fields[0]]; ok {
+		rule.Kind = kind
+		switch kind {
+		case CapabilityRule:
+			rule.Capabilities = fields[1:]
+		case MountRule:
+			return parseMountRule(rule, fields)
+		case FileRule:
+			// "file," allows access to all files, otherwise the
+			// rule continues as an ordinary file rule
+			if len(fields) > 1 {
+				return parseFileRule(rule, fields[1:])
+			}
+		}
+		return rule, nil
+	}
+	// file rules start with the path, which may be quoted or use a
+	// variable
+	if strings.HasPrefix(fields[0], "/") || strings.HasPrefix(fields[0], "@{") || strings.HasPrefix(fields[0], `"`) {
+		return parseFileRule(rule, fields)
+	}
+	rule.Kind = OtherRule
+	return rule, nil
+}
+
+func parseFileRule(rule Rule, fields []string) (Rule, error) {
+	rule.Kind = FileRule
+	// quoted paths may contain spaces
+	pathFields := 1
+	if strings.HasPrefix(fields[0], `"`) {
+		pathFields = 0
+		for i, f := range fields {
+			if (i > 0 || len(f) > 1) && strings.HasSuffix(f, `"`) {
+				pathFields = i + 1
+				break
+			}
+		}
+		if pathFields == 0 {
+			return rule, fmt.Errorf("cannot parse apparmor file rule %q: unterminated quote", rule.Text)
+		}
+	}
+	if len(fields) <= pathFields {
+		return rule, fmt.Errorf("cannot parse apparmor file rule %q: missing permissions", rule.Text)
+	}
+	rule.Path = strings.Trim(strings.Join(fields[:pathFields], " "), `"`)
+	rule.Permissions = fields[pathFields]
+	return rule, nil
+}
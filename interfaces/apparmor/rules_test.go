@@ -0,0 +1,105 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package apparmor_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces/apparmor"
+)
+
+type rulesSuite struct{}
+
+var _ = Suite(&rulesSuite{})
+
+func (s *rulesSuite) TestParseRules(c *C) {
+	rules, err := apparmor.ParseRules(`
+# Description: a comment
+#include <abstractions/base>
+/dev/fuse rw,
+deny /etc/fuse.conf r,
+owner @{HOME}/.config/foo/** rwk,  # trailing comment
+"/path/with space/#file" r,
+file,
+audit deny file /etc/shadow r,
+capability sys_admin,
+capability net_admin net_raw,
+network netlink raw,
+network,
+mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> /var/snap/foo/{,**/},
+umount /var/snap/foo/,
+profile child {
+  /usr/bin/child ixr,
+}
+tags=(foo) {
+dbus (send)
+    bus=system
+    path=/org/freedesktop/foo
+    member={Foo,Bar}
+    peer=(label=unconfined),
+}
+signal (receive) peer=unconfined,
+`)
+	c.Assert(err, IsNil)
+	c.Assert(rules, DeepEquals, []apparmor.Rule{
+		{Kind: apparmor.FileRule, Text: "/dev/fuse rw", Path: "/dev/fuse", Permissions: "rw"},
+		{Kind: apparmor.FileRule, Qualifiers: []string{"deny"}, Text: "/etc/fuse.conf r", Path: "/etc/fuse.conf", Permissions: "r"},
+		{Kind: apparmor.FileRule, Qualifiers: []string{"owner"}, Text: "@{HOME}/.config/foo/** rwk", Path: "@{HOME}/.config/foo/**", Permissions: "rwk"},
+		{Kind: apparmor.FileRule, Text: `"/path/with space/#file" r`, Path: "/path/with space/#file", Permissions: "r"},
+		{Kind: apparmor.FileRule, Text: "file"},
+		{Kind: apparmor.FileRule, Qualifiers: []string{"audit", "deny"}, Text: "file /etc/shadow r", Path: "/etc/shadow", Permissions: "r"},
+		{Kind: apparmor.CapabilityRule, Text: "capability sys_admin", Capabilities: []string{"sys_admin"}},
+		{Kind: apparmor.CapabilityRule, Text: "capability net_admin net_raw", Capabilities: []string{"net_admin", "net_raw"}},
+		{Kind: apparmor.NetworkRule, Text: "network netlink raw"},
+		{Kind: apparmor.NetworkRule, Text: "network"},
+		{Kind: apparmor.MountRule, Text: "mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> /var/snap/foo/{,**/}", FSType: "fuse.*", Source: "**", Target: "/var/snap/foo/{,**/}"},
+		{Kind: apparmor.MountRule, Text: "umount /var/snap/foo/", Target: "/var/snap/foo/"},
+		{Kind: apparmor.FileRule, Text: "/usr/bin/child ixr", Path: "/usr/bin/child", Permissions: "ixr"},
+		{Kind: apparmor.OtherRule, Text: "dbus (send) bus=system path=/org/freedesktop/foo member={Foo,Bar} peer=(label=unconfined)"},
+		{Kind: apparmor.OtherRule, Text: "signal (receive) peer=unconfined"},
+	})
+	c.Check(rules[1].HasQualifier("deny"), Equals, true)
+	c.Check(rules[1].HasQualifier("owner"), Equals, false)
+}
+
+func (s *rulesSuite) TestParseRulesEmpty(c *C) {
+	rules, err := apparmor.ParseRules("\n# only a comment\n")
+	c.Assert(err, IsNil)
+	c.Check(rules, HasLen, 0)
+}
+
+func (s *rulesSuite) TestParseRulesErrors(c *C) {
+	for _, t := range []struct {
+		snippet string
+		err     string
+	}{
+		{"/dev/fuse rw", `cannot parse apparmor rule "/dev/fuse rw": missing terminating comma`},
+		{"deny ,", `cannot parse apparmor rule "deny ": empty rule`},
+		{"/dev/fuse,", `cannot parse apparmor file rule "/dev/fuse": missing permissions`},
+		{`"/dev/fu se r,`, `cannot parse apparmor file rule "\\"/dev/fu se r": unterminated quote`},
+		{"mount options=(rw, bind /a -> /b,", `cannot parse apparmor mount rule "mount options=\(rw, bind /a -> /b": unterminated options list`},
+		{"mount fstype=,", `cannot parse apparmor mount rule "mount fstype=": missing fstype value`},
+		{"mount /a /b,", `cannot parse apparmor mount rule "mount /a /b": too many paths`},
+		{"mount /a ->,", `cannot parse apparmor mount rule "mount /a ->": invalid mount point`},
+	} {
+		_, err := apparmor.ParseRules(t.snippet)
+		c.Check(err, ErrorMatches, t.err, Commentf("snippet %q", t.snippet))
+	}
+}
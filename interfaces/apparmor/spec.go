@@ -157,6 +157,8 @@ type Specification struct {
 
 	// scope for various Add{...}Snippet functions
 	securityTags []string
+	// name of the interface adding snippets in the current scope
+	scopeInterface string
 
 	// snippets are indexed by security tag and describe parts of apparmor policy
 	// for snap application and hook processes. The security tag encodes the identity
@@ -227,9 +229,36 @@ type Specification struct {
 	// Include prompt prefix for relevant rules when generating security profiles.
 	usePromptPrefix bool
 
+	// auditedPlugs are the plugs of the snap with connections in audit
+	// mode, the rules added for them are qualified with "audit" such that
+	// the accesses they allow are logged. auditing is set while the rules
+	// of such a plug are added.
+	auditedPlugs []string
+	auditing     bool
+
+	// confinement is the type of confinement the profiles are generated
+	// for, see Confinement
+	confinement snap.ConfinementType
+
 	// Unconfined profile mode allows a profile to be applied without any
 	// real confinement
 	unconfined UnconfinedMode
+
+	// interfaceNames are indexed by security tag and record the names of
+	// the interfaces which contributed to the policy of the tag. They are
+	// only used to describe the generated profile.
+	interfaceNames map[string]map[string]bool
+
+	// interfaceSnippets are indexed by interface name and collect the
+	// snippets each interface contributed, in the order they were first
+	// added, so that the rules of a single interface can be compared
+	// between regenerations of the profiles.
+	interfaceSnippets map[string]*strutil.OrderedSet
+
+	// degradedFeatures are indexed by apparmor kernel feature and record
+	// the names of the interfaces which dropped or replaced rules because
+	// the kernel does not mediate the feature, see SupportsFeature.
+	degradedFeatures map[string]map[string]bool
 }
 
 func NewSpecification(appSet *interfaces.SnapAppSet) *Specification {
@@ -242,6 +271,70 @@ func (spec *Specification) SnapAppSet() *interfaces.SnapAppSet {
 	return spec.appSet
 }
 
+// setInterfaceScope sets the scope of subsequent AddSnippet family functions
+// to the given security tags, on behalf of the interface with the given name.
+// The returned function resets the scope to an empty scope.
+func (spec *Specification) setInterfaceScope(ifaceName string, securityTags []string) (restore func()) {
+	restoreTags := spec.setScope(securityTags)
+	spec.scopeInterface = ifaceName
+	return func() {
+		restoreTags()
+		spec.scopeInterface = ""
+	}
+}
+
+// recordInterface records that the interface in scope contributes the given
+// snippet to the policy of the security tags in scope.
+func (spec *Specification) recordInterface(snippet string) {
+	if spec.scopeInterface == "" {
+		return
+	}
+	if spec.interfaceSnippets == nil {
+		spec.interfaceSnippets = make(map[string]*strutil.OrderedSet)
+	}
+	group := spec.interfaceSnippets[spec.scopeInterface]
+	if group == nil {
+		group = &strutil.OrderedSet{}
+		spec.interfaceSnippets[spec.scopeInterface] = group
+	}
+	group.Put(snippet)
+	if spec.interfaceNames == nil {
+		spec.interfaceNames = make(map[string]map[string]bool)
+	}
+	for _, tag := range spec.securityTags {
+		names := spec.interfaceNames[tag]
+		if names == nil {
+			names = make(map[string]bool)
+			spec.interfaceNames[tag] = names
+		}
+		names[spec.scopeInterface] = true
+	}
+}
+
+// InterfacesForTag returns the sorted names of the interfaces which
+// contributed to the policy of the given security tag.
+func (spec *Specification) InterfacesForTag(tag string) []string {
+	names := make([]string, 0, len(spec.interfaceNames[tag]))
+	for name := range spec.interfaceNames[tag] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SnippetsForInterface returns the snippets contributed by the interface with
+// the given name, regardless of the security tags they apply to, in the order
+// they were first added. Prioritized snippets are returned even if they were
+// later superseded, parametric snippets are returned expanded with their
+// single value. Rules for snap-update-ns are not included.
+func (spec *Specification) SnippetsForInterface(name string) []string {
+	group := spec.interfaceSnippets[name]
+	if group == nil {
+		return nil
+	}
+	return group.Items()
+}
+
 // setScope sets the scope of subsequent AddSnippet family functions.
 // The returned function resets the scope to an empty scope.
 func (spec *Specification) setScope(securityTags []string) (restore func()) {
@@ -287,16 +380,169 @@ func MetadataTagSnippet(snippet string, tags []MetadataTag) string {
 	return b.String()
 }
 
+// kernelFeatureSupported returns whether the apparmor kernel mediates the
+// given feature, e.g. "mount" or "dbus". Only partial apparmor support lacks
+// features that snapd relies on, when the features cannot be probed they are
+// assumed to be supported.
+var kernelFeatureSupported = func(feature string) bool {
+	if apparmor_sandbox.ProbedLevel() != apparmor_sandbox.Partial {
+		return true
+	}
+	features, err := apparmor_sandbox.KernelFeatures()
+	if err != nil {
+		return true
+	}
+	return strutil.ListContains(features, feature)
+}
+
+// SupportsFeature returns whether the apparmor kernel of the system mediates
+// the given feature. If it does not, the feature is recorded as degraded for
+// the interface in scope so that the interface can add a fallback instead of
+// rules which would fail to load or silently have no effect.
+func (spec *Specification) SupportsFeature(feature string) bool {
+	if kernelFeatureSupported(feature) {
+		return true
+	}
+	if spec.scopeInterface != "" {
+		if spec.degradedFeatures == nil {
+			spec.degradedFeatures = make(map[string]map[string]bool)
+		}
+		names := spec.degradedFeatures[feature]
+		if names == nil {
+			names = make(map[string]bool)
+			spec.degradedFeatures[feature] = names
+		}
+		names[spec.scopeInterface] = true
+	}
+	return false
+}
+
+// AddSnippetIfFeature adds the given snippet like AddSnippet, but only if the
+// apparmor kernel of the system mediates the given feature. It returns whether
+// the snippet was added, see SupportsFeature.
+func (spec *Specification) AddSnippetIfFeature(feature, snippet string) bool {
+	if !spec.SupportsFeature(feature) {
+		return false
+	}
+	spec.AddSnippet(snippet)
+	return true
+}
+
+// DegradedFeatures returns the apparmor kernel features which interfaces could
+// not rely on, mapped to the sorted names of the affected interfaces.
+func (spec *Specification) DegradedFeatures() map[string][]string {
+	if len(spec.degradedFeatures) == 0 {
+		return nil
+	}
+	degraded := make(map[string][]string, len(spec.degradedFeatures))
+	for feature, names := range spec.degradedFeatures {
+		for name := range names {
+			degraded[feature] = append(degraded[feature], name)
+		}
+		sort.Strings(degraded[feature])
+	}
+	return degraded
+}
+
+// expandSnippet returns the given snippet with the snap variables it refers
+// to expanded for the snap of the specification. The profiles of the snap
+// define apparmor variables for the names and the revision of the snap, the
+// snap variables expand to those.
+func (spec *Specification) expandSnippet(snippet string) string {
+	if spec.appSet == nil {
+		return snippet
+	}
+	vars := &interfaces.SnippetVariables{
+		SnapName:         "@{SNAP_NAME}",
+		InstanceName:     "@{SNAP_INSTANCE_NAME}",
+		Revision:         "@{SNAP_REVISION}",
+		ParallelInstance: spec.appSet.Info().InstanceKey != "",
+	}
+	return vars.Expand(snippet)
+}
+
+// auditSnippet returns the given snippet with its rules qualified with
+// "audit" when the rules of a plug connected in audit mode are being added,
+// see auditedSnippet, and as is otherwise.
+func (spec *Specification) auditSnippet(snippet string) string {
+	if !spec.auditing {
+		return snippet
+	}
+	return auditedSnippet(snippet)
+}
+
+// auditedSnippet returns the given snippet with each of its rules qualified
+// with "audit", such that the accesses the rules allow are logged while they
+// are still enforced. Rules may span several lines, only their first line is
+// qualified. Comments, includes, variable assignments and the lines opening
+// or closing blocks, such as child profiles, are left as is.
+func auditedSnippet(snippet string) string {
+	lines := strings.Split(snippet, "\n")
+	ruleStart := true
+	for i, line := range lines {
+		code := apparmorRuleCode(line)
+		if code == "" {
+			continue
+		}
+		switch {
+		case strings.HasSuffix(code, "{") || strings.HasPrefix(code, "}"):
+			ruleStart = true
+			continue
+		case strings.HasPrefix(code, "@{") || strings.HasPrefix(code, "include ") || strings.HasPrefix(code, "set "):
+			ruleStart = true
+			continue
+		}
+		if ruleStart && !strings.HasPrefix(code, "audit ") {
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			lines[i] = indent + "audit " + strings.TrimLeft(line, " \t")
+		}
+		ruleStart = strings.HasSuffix(code, ",")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// apparmorRuleCode returns the given line of an apparmor snippet without its
+// comment and surrounding whitespace. Placeholders such as ###PROMPT### are
+// not comments.
+func apparmorRuleCode(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] != '#' {
+			continue
+		}
+		if strings.HasPrefix(line[i:], "###") {
+			// skip the placeholder
+			if end := strings.Index(line[i+3:], "###"); end >= 0 {
+				i += 3 + end + 2
+				continue
+			}
+		}
+		if i == 0 || line[i-1] == ' ' || line[i-1] == '\t' {
+			line = line[:i]
+			break
+		}
+	}
+	return strings.TrimSpace(line)
+}
+
 // AddSnippet adds a new apparmor snippet to all applications and hooks using the interface.
+// Identical snippets are only added once. The snap variables which the snippet
+// refers to are expanded, see interfaces.SnippetVariables.
 func (spec *Specification) AddSnippet(snippet string) {
 	if len(spec.securityTags) == 0 {
 		return
 	}
+	snippet = spec.auditSnippet(spec.expandSnippet(snippet))
+	spec.recordInterface(snippet)
 	if spec.snippets == nil {
 		spec.snippets = make(map[string][]string)
 	}
 	for _, tag := range spec.securityTags {
-		spec.snippets[tag] = append(spec.snippets[tag], snippet)
+		// the snippets are kept sorted, identical ones are only kept once
+		snippets := spec.snippets[tag]
+		if i := sort.SearchStrings(snippets, snippet); i < len(snippets) && snippets[i] == snippet {
+			continue
+		}
+		spec.snippets[tag] = append(snippets, snippet)
 		sort.Strings(spec.snippets[tag])
 	}
 }
@@ -313,6 +559,8 @@ func (spec *Specification) AddPrioritizedSnippet(snippet string, key SnippetKey,
 	if len(spec.securityTags) == 0 {
 		return
 	}
+	snippet = spec.auditSnippet(spec.expandSnippet(snippet))
+	spec.recordInterface(snippet)
 	if spec.prioritizedSnippets == nil {
 		spec.prioritizedSnippets = make(map[string]map[SnippetKey]prioritizedSnippets)
 	}
@@ -359,6 +607,8 @@ func (spec *Specification) AddDeduplicatedSnippet(snippet string) {
 	if len(spec.securityTags) == 0 {
 		return
 	}
+	snippet = spec.auditSnippet(spec.expandSnippet(snippet))
+	spec.recordInterface(snippet)
 	if spec.dedupSnippets == nil {
 		spec.dedupSnippets = make(map[string]*strutil.OrderedSet)
 	}
@@ -372,6 +622,53 @@ func (spec *Specification) AddDeduplicatedSnippet(snippet string) {
 	}
 }
 
+// ExecMode is the transition mode of an exec rule.
+type ExecMode string
+
+const (
+	// ExecInherit runs the executable under the profile of the caller.
+	ExecInherit ExecMode = "ix"
+	// ExecProfile runs the executable under its own profile, with a
+	// scrubbed environment.
+	ExecProfile ExecMode = "px"
+	// ExecChild runs the executable under a child profile of the profile of
+	// the caller, with a scrubbed environment.
+	ExecChild ExecMode = "cx"
+)
+
+// AddExec allows all applications and hooks using the interface to execute
+// the given path with the given transition mode. For the px and cx modes, the
+// optional target names the profile or child profile to transition to,
+// otherwise the profile attached to the path is used. The ix mode does not
+// take a target. The rules are de-duplicated, so several interfaces may allow
+// the same executable.
+func (spec *Specification) AddExec(path string, mode ExecMode, target string) error {
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("cannot allow executing relative path %q", path)
+	}
+	var rule string
+	switch mode {
+	case ExecInherit:
+		if target != "" {
+			return fmt.Errorf("cannot use target %q with exec mode %q", target, mode)
+		}
+		rule = fmt.Sprintf("%s ixr,", path)
+	case ExecProfile, ExecChild:
+		// the scrubbing variants, the environment of the caller may
+		// otherwise alter the behaviour of the executable
+		perms := strings.ToUpper(string(mode[:1])) + string(mode[1:]) + "r"
+		if target != "" {
+			rule = fmt.Sprintf("%s %s -> %s,", path, perms, target)
+		} else {
+			rule = fmt.Sprintf("%s %s,", path, perms)
+		}
+	default:
+		return fmt.Errorf("cannot use unknown exec mode %q", mode)
+	}
+	spec.AddDeduplicatedSnippet(rule)
+	return nil
+}
+
 // AddParametricSnippet adds a new apparmor snippet both de-duplicated and optimized for the parser.
 //
 // Conceptually the function takes a parametric template and a single value to
@@ -429,6 +726,8 @@ func (spec *Specification) AddParametricSnippet(templateFragment []string, value
 	default:
 		template = strings.Join(templateFragment, "###PARAM###")
 	}
+	template = spec.auditSnippet(spec.expandSnippet(template))
+	spec.recordInterface(strings.Replace(template, "###PARAM###", value, -1))
 
 	// Expand the spec's parametric snippets, initializing each
 	// part of the map as needed
@@ -453,7 +752,13 @@ func (spec *Specification) AddParametricSnippet(templateFragment []string, value
 }
 
 // AddUpdateNS adds a new apparmor snippet for the snap-update-ns program.
+// The snap variables which the snippet refers to are expanded to the values
+// of the snap, the profile of snap-update-ns does not define the apparmor
+// variables of the profiles of the snap.
 func (spec *Specification) AddUpdateNS(snippet string) {
+	if spec.appSet != nil {
+		snippet = interfaces.SnapSnippetVariables(spec.appSet.Info()).Expand(snippet)
+	}
 	spec.updateNS.Put(snippet)
 }
 
@@ -562,6 +867,36 @@ func (spec *Specification) AddLayout(appSet *interfaces.SnapAppSet) {
 	}
 }
 
+// LayoutPaths returns the sorted paths at which the layouts of the snap
+// present the given directory, or parts of it, through bind mounts. The
+// directory may use the variables of layouts, such as $SNAP_COMMON, the
+// returned paths are expanded. Interfaces granting access to the directories of the snap can use
+// it to grant the same access to the paths where layouts present them.
+func (spec *Specification) LayoutPaths(dir string) []string {
+	if spec.appSet == nil {
+		return nil
+	}
+	snapInfo := spec.appSet.Info()
+	dir = filepath.Clean(snapInfo.ExpandSnapVariables(dir))
+	var paths []string
+	for _, layout := range snapInfo.Layout {
+		if layout.Bind == "" {
+			continue
+		}
+		source := filepath.Clean(snapInfo.ExpandSnapVariables(layout.Bind))
+		path := snapInfo.ExpandSnapVariables(layout.Path)
+		switch {
+		case source == dir || strings.HasPrefix(source, dir+"/"):
+			paths = append(paths, path)
+		case strings.HasPrefix(dir, source+"/"):
+			// the directory is presented below the layout path
+			paths = append(paths, path+strings.TrimPrefix(dir, source))
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
 // AddExtraLayouts adds additional apparmor snippets based on the provided layouts.
 // The function is in part identical to AddLayout, except that it considers only the
 // layouts passed as parameters instead of those declared in the snap.Info structure.
@@ -865,6 +1200,7 @@ func (spec *Specification) AddEnsureDirMounts(ifaceName string, ensureDirSpecs [
 // AddConnectedPlug records apparmor-specific side-effects of having a connected plug.
 func (spec *Specification) AddConnectedPlug(iface interfaces.Interface, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
 	type definer interface {
+		interfaces.Interface
 		AppArmorConnectedPlug(spec *Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error
 	}
 	if iface, ok := iface.(definer); ok {
@@ -873,8 +1209,12 @@ func (spec *Specification) AddConnectedPlug(iface interfaces.Interface, plug *in
 			return err
 		}
 
-		restore := spec.setScope(tags)
+		restore := spec.setInterfaceScope(iface.Name(), tags)
 		defer restore()
+		if strutil.ListContains(spec.auditedPlugs, plug.Name()) {
+			spec.auditing = true
+			defer func() { spec.auditing = false }()
+		}
 		return iface.AppArmorConnectedPlug(spec, plug, slot)
 	}
 	return nil
@@ -883,6 +1223,7 @@ func (spec *Specification) AddConnectedPlug(iface interfaces.Interface, plug *in
 // AddConnectedSlot records apparmor-specific side-effects of having a connected slot.
 func (spec *Specification) AddConnectedSlot(iface interfaces.Interface, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
 	type definer interface {
+		interfaces.Interface
 		AppArmorConnectedSlot(spec *Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error
 	}
 	if iface, ok := iface.(definer); ok {
@@ -891,7 +1232,7 @@ func (spec *Specification) AddConnectedSlot(iface interfaces.Interface, plug *in
 			return err
 		}
 
-		restore := spec.setScope(tags)
+		restore := spec.setInterfaceScope(iface.Name(), tags)
 		defer restore()
 		return iface.AppArmorConnectedSlot(spec, plug, slot)
 	}
@@ -905,6 +1246,7 @@ func (spec *Specification) AddPermanentPlug(iface interfaces.Interface, plug *sn
 		spec.setUnconfinedSupported()
 	}
 	type definer interface {
+		interfaces.Interface
 		AppArmorPermanentPlug(spec *Specification, plug *snap.PlugInfo) error
 	}
 	if iface, ok := iface.(definer); ok {
@@ -913,7 +1255,7 @@ func (spec *Specification) AddPermanentPlug(iface interfaces.Interface, plug *sn
 			return err
 		}
 
-		restore := spec.setScope(tags)
+		restore := spec.setInterfaceScope(iface.Name(), tags)
 		defer restore()
 		return iface.AppArmorPermanentPlug(spec, plug)
 	}
@@ -927,6 +1269,7 @@ func (spec *Specification) AddPermanentSlot(iface interfaces.Interface, slot *sn
 		spec.setUnconfinedSupported()
 	}
 	type definer interface {
+		interfaces.Interface
 		AppArmorPermanentSlot(spec *Specification, slot *snap.SlotInfo) error
 	}
 	if iface, ok := iface.(definer); ok {
@@ -935,7 +1278,7 @@ func (spec *Specification) AddPermanentSlot(iface interfaces.Interface, slot *sn
 			return err
 		}
 
-		restore := spec.setScope(tags)
+		restore := spec.setInterfaceScope(iface.Name(), tags)
 		defer restore()
 		return iface.AppArmorPermanentSlot(spec, slot)
 	}
@@ -970,6 +1313,19 @@ func (spec *Specification) UsePromptPrefix() bool {
 	return spec.usePromptPrefix
 }
 
+// Confinement returns the type of confinement the security profiles are
+// generated for. Unless set by the backend, this is the confinement requested
+// by the snap.
+func (spec *Specification) Confinement() snap.ConfinementType {
+	if spec.confinement != "" {
+		return spec.confinement
+	}
+	if spec.appSet != nil && spec.appSet.Info().Confinement != "" {
+		return spec.appSet.Info().Confinement
+	}
+	return snap.StrictConfinement
+}
+
 // SetUsesSysModuleCapability records that some interface has granted the
 // sys_module capability
 func (spec *Specification) SetUsesSysModuleCapability() {
@@ -118,6 +118,108 @@ func (s *specSuite) TestSpecificationIface(c *C) {
 	})
 }
 
+// The spec.Specification records the interfaces which contributed snippets.
+func (s *specSuite) TestInterfacesForTag(c *C) {
+	other := &ifacetest.TestInterface{
+		InterfaceName: "other",
+		AppArmorConnectedPlugCallback: func(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddDeduplicatedSnippet("other")
+			return nil
+		},
+	}
+	silent := &ifacetest.TestInterface{
+		InterfaceName: "silent",
+		AppArmorConnectedPlugCallback: func(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			return nil
+		},
+	}
+	c.Assert(s.spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(s.spec.AddPermanentPlug(s.iface, s.plugInfo), IsNil)
+	c.Assert(s.spec.AddConnectedPlug(silent, s.plug, s.slot), IsNil)
+	c.Assert(s.spec.AddConnectedPlug(other, s.plug, s.slot), IsNil)
+
+	// the names are sorted and interfaces not adding any snippets are not
+	// listed
+	c.Check(s.spec.InterfacesForTag("snap.snap1.app1"), DeepEquals, []string{"other", "test"})
+	c.Check(s.spec.InterfacesForTag("snap.snap1.other"), HasLen, 0)
+
+	// snippets added outside of an interface are not attributed
+	restore := apparmor.SetSpecScope(s.spec, []string{"snap.snap1.app1", "snap.snap1.app2"})
+	defer restore()
+	s.spec.AddSnippet("unattributed")
+	c.Check(s.spec.InterfacesForTag("snap.snap1.app2"), HasLen, 0)
+}
+
+// The spec.Specification groups the snippets by contributing interface.
+func (s *specSuite) TestSnippetsForInterface(c *C) {
+	other := &ifacetest.TestInterface{
+		InterfaceName: "other",
+		AppArmorConnectedPlugCallback: func(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddDeduplicatedSnippet("dedup")
+			spec.AddDeduplicatedSnippet("dedup")
+			spec.AddParametricSnippet([]string{"/dev/", " rw,"}, "sda1")
+			spec.AddParametricSnippet([]string{"/dev/", " rw,"}, "sdb2")
+			spec.AddUpdateNS("update-ns")
+			return nil
+		},
+	}
+	c.Assert(s.spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(s.spec.AddPermanentPlug(s.iface, s.plugInfo), IsNil)
+	c.Assert(s.spec.AddConnectedPlug(other, s.plug, s.slot), IsNil)
+
+	c.Check(s.spec.SnippetsForInterface("test"), DeepEquals, []string{"connected-plug", "permanent-plug"})
+	// snippets are recorded once, parametric snippets are expanded and
+	// snap-update-ns rules are not part of the group
+	c.Check(s.spec.SnippetsForInterface("other"), DeepEquals, []string{
+		"dedup",
+		"/dev/sda1 rw,",
+		"/dev/sdb2 rw,",
+	})
+	c.Check(s.spec.SnippetsForInterface("unknown"), IsNil)
+}
+
+func (s *specSuite) TestAddSnippetIfFeature(c *C) {
+	restore := apparmor.MockKernelFeatureSupported(func(feature string) bool {
+		return feature != "mount"
+	})
+	defer restore()
+
+	var added []bool
+	other := &ifacetest.TestInterface{
+		InterfaceName: "other",
+		AppArmorConnectedPlugCallback: func(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			added = append(added, spec.AddSnippetIfFeature("mount", "mount -> /foo/,"))
+			added = append(added, spec.AddSnippetIfFeature("dbus", "dbus send,"))
+			return nil
+		},
+	}
+	c.Assert(s.spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(s.spec.AddConnectedPlug(other, s.plug, s.slot), IsNil)
+
+	c.Check(added, DeepEquals, []bool{false, true})
+	c.Check(s.spec.SnippetForTag("snap.snap1.app1"), Equals, "connected-plug\ndbus send,")
+	c.Check(s.spec.DegradedFeatures(), DeepEquals, map[string][]string{
+		"mount": {"other"},
+	})
+
+	// unsupported features are not attributed outside of an interface
+	restoreScope := apparmor.SetSpecScope(s.spec, []string{"snap.snap1.app1"})
+	defer restoreScope()
+	c.Check(s.spec.AddSnippetIfFeature("ptrace", "ptrace,"), Equals, true)
+	c.Check(s.spec.SupportsFeature("mount"), Equals, false)
+	c.Check(s.spec.DegradedFeatures(), DeepEquals, map[string][]string{
+		"mount": {"other"},
+	})
+}
+
+func (s *specSuite) TestDegradedFeaturesNone(c *C) {
+	restore := apparmor.MockKernelFeatureSupported(func(feature string) bool { return true })
+	defer restore()
+
+	c.Assert(s.spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(s.spec.DegradedFeatures(), IsNil)
+}
+
 // MetadataTagSnippet wraps a snippet in the given metadata tags.
 func (s *specSuite) TestMetadataTagSnippet(c *C) {
 	tagFoo := apparmor.RegisterMetadataTagWithInterface("foo", "an-interface")
@@ -270,6 +372,42 @@ func (s *specSuite) TestAddDeduplicatedSnippet(c *C) {
 	c.Assert(s.spec.SecurityTags(), DeepEquals, []string{"snap.demo.command", "snap.demo.service"})
 }
 
+// AddExec adds a de-duplicated exec rule for each security tag.
+func (s *specSuite) TestAddExec(c *C) {
+	restore := apparmor.SetSpecScope(s.spec, []string{"snap.demo.command"})
+	defer restore()
+
+	c.Assert(s.spec.AddExec("/usr/bin/helper", apparmor.ExecInherit, ""), IsNil)
+	c.Assert(s.spec.AddExec("/usr/bin/helper", apparmor.ExecInherit, ""), IsNil)
+	c.Assert(s.spec.AddExec("/usr/bin/tool", apparmor.ExecProfile, ""), IsNil)
+	c.Assert(s.spec.AddExec("/usr/bin/tool", apparmor.ExecProfile, "other-profile"), IsNil)
+	c.Assert(s.spec.AddExec("/{,usr/}bin/fusermount{,3}", apparmor.ExecChild, ""), IsNil)
+	c.Assert(s.spec.AddExec("/{,usr/}bin/fusermount{,3}", apparmor.ExecChild, "fusermount"), IsNil)
+
+	c.Assert(s.spec.Snippets(), DeepEquals, map[string][]string{
+		"snap.demo.command": {
+			"/usr/bin/helper ixr,",
+			"/usr/bin/tool Pxr,",
+			"/usr/bin/tool Pxr -> other-profile,",
+			"/{,usr/}bin/fusermount{,3} Cxr,",
+			"/{,usr/}bin/fusermount{,3} Cxr -> fusermount,",
+		},
+	})
+}
+
+func (s *specSuite) TestAddExecErrors(c *C) {
+	restore := apparmor.SetSpecScope(s.spec, []string{"snap.demo.command"})
+	defer restore()
+
+	c.Check(s.spec.AddExec("usr/bin/helper", apparmor.ExecInherit, ""), ErrorMatches,
+		`cannot allow executing relative path "usr/bin/helper"`)
+	c.Check(s.spec.AddExec("/usr/bin/helper", apparmor.ExecInherit, "other-profile"), ErrorMatches,
+		`cannot use target "other-profile" with exec mode "ix"`)
+	c.Check(s.spec.AddExec("/usr/bin/helper", apparmor.ExecMode("ux"), ""), ErrorMatches,
+		`cannot use unknown exec mode "ux"`)
+	c.Check(s.spec.Snippets(), HasLen, 0)
+}
+
 func (s *specSuite) TestAddParametricSnippet(c *C) {
 	restore := apparmor.SetSpecScope(s.spec, []string{"snap.demo.command", "snap.demo.service"})
 	defer restore()
@@ -291,6 +429,23 @@ func (s *specSuite) TestAddParametricSnippet(c *C) {
 }
 
 // All of AddSnippet, AddDeduplicatedSnippet, AddParameticSnippet work correctly together.
+func (s *specSuite) TestAddSnippetDeduplicates(c *C) {
+	restore := apparmor.SetSpecScope(s.spec, []string{"snap.demo.command", "snap.demo.service"})
+	defer restore()
+
+	for i := 0; i < 3; i++ {
+		s.spec.AddSnippet("snippet 2")
+		s.spec.AddSnippet("deny /etc/fuse.conf r,")
+		s.spec.AddSnippet("snippet 1")
+	}
+
+	// identical snippets are kept once, all of them are still sorted
+	c.Assert(s.spec.Snippets(), DeepEquals, map[string][]string{
+		"snap.demo.command": {"deny /etc/fuse.conf r,", "snippet 1", "snippet 2"},
+		"snap.demo.service": {"deny /etc/fuse.conf r,", "snippet 1", "snippet 2"},
+	})
+}
+
 func (s *specSuite) TestAddSnippetAndAddDeduplicatedAndParamSnippet(c *C) {
 	restore := apparmor.SetSpecScope(s.spec, []string{"snap.demo.command", "snap.demo.service"})
 	defer restore()
@@ -391,6 +546,44 @@ layout:
     bind-file: $SNAP/foo.conf
 `
 
+func (s *specSuite) TestLayoutPaths(c *C) {
+	const snapWithDataLayout = `
+name: vanguard
+version: 0
+apps:
+  vanguard:
+    command: vanguard
+layout:
+  /etc/vanguard:
+    bind: $SNAP_COMMON/etc
+  /var/lib/vanguard:
+    bind: $SNAP_DATA
+  /usr/foo:
+    bind: $SNAP/usr/foo
+  /var/cache/mylink:
+    symlink: $SNAP_COMMON/link/target
+  /etc/foo.conf:
+    bind-file: $SNAP_COMMON/foo.conf
+`
+	snapInfo := snaptest.MockInfo(c, snapWithDataLayout, &snap.SideInfo{Revision: snap.R(42)})
+	appSet, err := interfaces.NewSnapAppSet(snapInfo, nil)
+	c.Assert(err, IsNil)
+	spec := apparmor.NewSpecification(appSet)
+
+	// only directories bind mounted from, or containing, the given directory
+	// are returned
+	c.Check(spec.LayoutPaths("$SNAP_COMMON"), DeepEquals, []string{"/etc/vanguard"})
+	c.Check(spec.LayoutPaths("/var/snap/vanguard/common/"), DeepEquals, []string{"/etc/vanguard"})
+	c.Check(spec.LayoutPaths("$SNAP_COMMON/etc/sub"), DeepEquals, []string{"/etc/vanguard/sub"})
+	c.Check(spec.LayoutPaths("$SNAP_COMMON/etcetera"), HasLen, 0)
+	c.Check(spec.LayoutPaths("$SNAP_DATA"), DeepEquals, []string{"/var/lib/vanguard"})
+	c.Check(spec.LayoutPaths("/var/snap/vanguard"), DeepEquals, []string{"/etc/vanguard", "/var/lib/vanguard"})
+	c.Check(spec.LayoutPaths("/var/snap/vanguard-other"), HasLen, 0)
+
+	// no layouts
+	c.Check(s.spec.LayoutPaths("$SNAP_COMMON"), HasLen, 0)
+}
+
 func (s *specSuite) TestApparmorSnippetsFromLayout(c *C) {
 	snapInfo := snaptest.MockInfo(c, snapWithLayout, &snap.SideInfo{Revision: snap.R(42)})
 	restore := apparmor.SetSpecScope(s.spec, []string{"snap.vanguard.vanguard"})
@@ -830,3 +1023,109 @@ func (s *specSuite) TestRegisterMetadataTagInvalid(c *C) {
 		c.Check(func() { apparmor.RegisterMetadataTagWithInterface(badTag, "something") }, PanicMatches, `cannot register invalid metadata tag: .*`)
 	}
 }
+
+func (s *specSuite) TestConfinement(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Check(spec.Confinement(), Equals, snap.StrictConfinement)
+
+	const classicYaml = `name: snap1
+version: 1
+confinement: classic
+apps:
+ app1:
+  plugs: [name]
+`
+	plug, _ := ifacetest.MockConnectedPlug(c, classicYaml, nil, "name")
+	spec = apparmor.NewSpecification(plug.AppSet())
+	c.Check(spec.Confinement(), Equals, snap.ClassicConfinement)
+
+	// the backend prepares specifications for the effective confinement
+	backend := &apparmor.Backend{}
+	spec = backend.NewSpecification(plug.AppSet(), interfaces.ConfinementOptions{Classic: true, JailMode: true}).(*apparmor.Specification)
+	c.Check(spec.Confinement(), Equals, snap.StrictConfinement)
+	spec = backend.NewSpecification(s.plug.AppSet(), interfaces.ConfinementOptions{DevMode: true}).(*apparmor.Specification)
+	c.Check(spec.Confinement(), Equals, snap.DevModeConfinement)
+}
+
+func (s *specSuite) TestAuditedPlugRules(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "test",
+		AppArmorConnectedPlugCallback: func(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddSnippet(`# Description: comment
+#include <abstractions/base>
+@{FOO}="/foo"
+/dev/fuse rw,
+dbus (send)
+    bus=system
+    member=Get,
+###PROMPT### owner @{HOME}/ r, # trailing comment
+audit /run/already r,
+profile child (attach_disconnected) {
+  capability sys_admin,
+}`)
+			spec.AddDeduplicatedSnippet("mount fstype=fuse -> /mnt/,")
+			return nil
+		},
+	}
+	backend := &apparmor.Backend{}
+
+	// the rules of an audited plug are logged while still being enforced
+	spec := backend.NewSpecification(s.plug.AppSet(), interfaces.ConfinementOptions{AuditedPlugs: []string{"name"}}).(*apparmor.Specification)
+	c.Assert(spec.AddConnectedPlug(iface, s.plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.snap1.app1"), Equals, `# Description: comment
+#include <abstractions/base>
+@{FOO}="/foo"
+audit /dev/fuse rw,
+audit dbus (send)
+    bus=system
+    member=Get,
+audit ###PROMPT### owner @{HOME}/ r, # trailing comment
+audit /run/already r,
+profile child (attach_disconnected) {
+  audit capability sys_admin,
+}
+audit mount fstype=fuse -> /mnt/,`)
+	// the permanent snippets are not affected
+	c.Assert(spec.AddPermanentPlug(s.iface, s.plugInfo), IsNil)
+	c.Check(spec.SnippetsForTag("snap.snap1.app1"), testutil.Contains, "permanent-plug")
+
+	// nor are the rules of other plugs
+	spec = backend.NewSpecification(s.plug.AppSet(), interfaces.ConfinementOptions{AuditedPlugs: []string{"other"}}).(*apparmor.Specification)
+	c.Assert(spec.AddConnectedPlug(iface, s.plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.snap1.app1"), Not(testutil.Contains), "audit /dev/fuse")
+	c.Check(spec.SnippetForTag("snap.snap1.app1"), testutil.Contains, "\n/dev/fuse rw,\n")
+}
+
+func (s *specSuite) TestSnippetVariables(c *C) {
+	snippet := "###SNAP_COMMON###/** rw,\n/run/###SNAP_INSTANCE_NAME###/ r,\n###PARAM### r,"
+	for _, t := range []struct {
+		instanceKey string
+		snippet     string
+		updateNS    string
+	}{{
+		snippet:  "/var/snap/@{SNAP_NAME}/common/** rw,\n/run/@{SNAP_INSTANCE_NAME}/ r,\n###PARAM### r,",
+		updateNS: "/var/snap/some-snap/common/** rw,\n/run/some-snap/ r,\n###PARAM### r,",
+	}, {
+		instanceKey: "instance",
+		snippet:     "/var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/common/** rw,\n/run/@{SNAP_INSTANCE_NAME}/ r,\n###PARAM### r,",
+		updateNS:    "/var/snap/{some-snap,some-snap_instance}/common/** rw,\n/run/some-snap_instance/ r,\n###PARAM### r,",
+	}} {
+		snapInfo := snaptest.MockInfo(c, snapTrivial, &snap.SideInfo{Revision: snap.R(42)})
+		snapInfo.InstanceKey = t.instanceKey
+		appSet, err := interfaces.NewSnapAppSet(snapInfo, nil)
+		c.Assert(err, IsNil)
+		spec := apparmor.NewSpecification(appSet)
+		tag := "snap." + snapInfo.InstanceName() + ".app"
+		restore := apparmor.SetSpecScope(spec, []string{tag})
+
+		spec.AddSnippet(snippet)
+		spec.AddDeduplicatedSnippet(snippet)
+		spec.AddUpdateNS(snippet)
+		restore()
+
+		c.Check(spec.Snippets(), DeepEquals, map[string][]string{
+			tag: {t.snippet, t.snippet},
+		})
+		c.Check(spec.UpdateNS(), DeepEquals, []string{t.updateNS})
+	}
+}
@@ -73,7 +73,8 @@ var templateCommon = `
 # may all be installed on the system. To support this, SNAP_NAME is set to the
 # name (eg, 'foo') while SNAP_INSTANCE_NAME is set to the instance name (eg
 # 'foo_bar'). The profile name and most rules therefore reference
-# SNAP_INSTANCE_NAME. In some cases, snapd will adjust the snap's runtime
+# SNAP_INSTANCE_NAME while SNAP_INSTANCE_KEY is set to the instance key alone
+# (eg 'bar', empty for 'foo'). In some cases, snapd will adjust the snap's runtime
 # environment so the snap doesn't have to be aware of the distinction (eg,
 # SNAP, SNAP_DATA and SNAP_COMMON are all bind mounted onto a directory with
 # SNAP_NAME so the security policy will allow writing to both locations (since
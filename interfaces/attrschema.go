@@ -0,0 +1,197 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package interfaces
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/snapcore/snapd/snap"
+)
+
+// AttrType is the type of the value of an interface attribute.
+type AttrType string
+
+const (
+	AttrBool   AttrType = "bool"
+	AttrString AttrType = "string"
+	AttrInt    AttrType = "int"
+	AttrList   AttrType = "list"
+	AttrMap    AttrType = "map"
+)
+
+// AttrSpec describes the acceptable values of an interface attribute.
+type AttrSpec struct {
+	// Type is the type the value of the attribute must have.
+	Type AttrType
+	// Allowed optionally restricts the attribute to the given values.
+	Allowed []any
+	// Required is set when the attribute must be present.
+	Required bool
+	// Default is the value of the attribute when it is not present, see
+	// AttrValue. It must have the type of the attribute.
+	Default any
+	// Description documents the attribute for developers.
+	Description string
+}
+
+// PlugAttributeSchema can be implemented by Interfaces that describe the
+// attributes of their plugs declaratively. The attributes are then validated
+// by BeforePreparePlug, before any PlugSanitizer is invoked. Attributes not
+// mentioned in the schema are left for the interface to sanitize.
+type PlugAttributeSchema interface {
+	AttributeSchema() map[string]AttrSpec
+}
+
+// SlotAttributeSchema can be implemented by Interfaces that describe the
+// attributes of their slots declaratively, as PlugAttributeSchema does for
+// plugs. The attributes are validated by BeforePrepareSlot, before any
+// SlotSanitizer is invoked.
+type SlotAttributeSchema interface {
+	SlotAttributeSchema() map[string]AttrSpec
+}
+
+// AttrValue returns the value of the given attribute, or its default value
+// from the schema when the attribute is not present.
+func AttrValue(attrs Attrer, name string, schema map[string]AttrSpec) (value any, ok bool) {
+	if value, ok := attrs.Lookup(name); ok {
+		return value, true
+	}
+	if spec, ok := schema[name]; ok && spec.Default != nil {
+		return spec.Default, true
+	}
+	return nil, false
+}
+
+func (t AttrType) description() string {
+	switch t {
+	case AttrBool:
+		return "a boolean"
+	case AttrString:
+		return "a string"
+	case AttrInt:
+		return "an integer"
+	case AttrList:
+		return "a list"
+	case AttrMap:
+		return "a map"
+	}
+	return string(t)
+}
+
+func (t AttrType) matches(value any) (bool, error) {
+	switch t {
+	case AttrBool:
+		_, ok := value.(bool)
+		return ok, nil
+	case AttrString:
+		_, ok := value.(string)
+		return ok, nil
+	case AttrInt:
+		_, ok := value.(int64)
+		return ok, nil
+	case AttrList:
+		_, ok := value.([]any)
+		return ok, nil
+	case AttrMap:
+		_, ok := value.(map[string]any)
+		return ok, nil
+	}
+	return false, fmt.Errorf("unknown attribute type %q", t)
+}
+
+func formatAllowed(allowed []any) string {
+	quoted := make([]string, len(allowed))
+	for i, v := range allowed {
+		if s, ok := v.(string); ok {
+			quoted[i] = fmt.Sprintf("%q", s)
+		} else {
+			quoted[i] = fmt.Sprintf("%v", v)
+		}
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// ValidateAttrs checks the given attributes of an interface against the
+// schema. Attributes are expected to be normalized, as done when loading snap
+// metadata, such that integers are represented as int64.
+func ValidateAttrs(ifaceName string, attrs map[string]any, schema map[string]AttrSpec) error {
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	// report problems in a stable order
+	sort.Strings(names)
+
+	for _, name := range names {
+		spec := schema[name]
+		value, ok := attrs[name]
+		if !ok {
+			if spec.Required {
+				return fmt.Errorf("%s must contain the %q attribute", ifaceName, name)
+			}
+			if spec.Default != nil {
+				if ok, err := spec.Type.matches(spec.Default); err != nil || !ok {
+					return fmt.Errorf("internal error: %s %q attribute default %v is not %s", ifaceName, name, spec.Default, spec.Type.description())
+				}
+			}
+			continue
+		}
+		ok, err := spec.Type.matches(value)
+		if err != nil {
+			return fmt.Errorf("internal error: cannot validate %s %q attribute: %v", ifaceName, name, err)
+		}
+		if !ok {
+			return fmt.Errorf("%s %q attribute must be %s", ifaceName, name, spec.Type.description())
+		}
+		if len(spec.Allowed) == 0 {
+			continue
+		}
+		allowed := false
+		for _, candidate := range spec.Allowed {
+			if reflect.DeepEqual(value, candidate) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("%s %q attribute must be one of %s", ifaceName, name, formatAllowed(spec.Allowed))
+		}
+	}
+	return nil
+}
+
+func validatePlugAttrs(iface Interface, plugInfo *snap.PlugInfo) error {
+	withSchema, ok := iface.(PlugAttributeSchema)
+	if !ok {
+		return nil
+	}
+	return ValidateAttrs(iface.Name(), plugInfo.Attrs, withSchema.AttributeSchema())
+}
+
+func validateSlotAttrs(iface Interface, slotInfo *snap.SlotInfo) error {
+	withSchema, ok := iface.(SlotAttributeSchema)
+	if !ok {
+		return nil
+	}
+	return ValidateAttrs(iface.Name(), slotInfo.Attrs, withSchema.SlotAttributeSchema())
+}
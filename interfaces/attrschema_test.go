@@ -0,0 +1,235 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package interfaces_test
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/ifacetest"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/snap/snaptest"
+)
+
+type attrSchemaSuite struct{}
+
+var _ = Suite(&attrSchemaSuite{})
+
+type schemaIface struct {
+	ifacetest.TestInterface
+
+	schema map[string]interfaces.AttrSpec
+}
+
+func (iface *schemaIface) AttributeSchema() map[string]interfaces.AttrSpec {
+	return iface.schema
+}
+
+var testSchema = map[string]interfaces.AttrSpec{
+	"flag":  {Type: interfaces.AttrBool},
+	"mode":  {Type: interfaces.AttrString, Allowed: []any{"ro", "rw"}},
+	"path":  {Type: interfaces.AttrString, Required: true},
+	"count": {Type: interfaces.AttrInt, Allowed: []any{int64(1), int64(2)}},
+	"items": {Type: interfaces.AttrList},
+	"extra": {Type: interfaces.AttrMap},
+}
+
+func (s *attrSchemaSuite) TestValidateAttrsHappy(c *C) {
+	c.Check(interfaces.ValidateAttrs("iface", map[string]any{
+		"path": "/dev/foo",
+	}, testSchema), IsNil)
+	c.Check(interfaces.ValidateAttrs("iface", map[string]any{
+		"flag":    true,
+		"mode":    "rw",
+		"path":    "/dev/foo",
+		"count":   int64(2),
+		"items":   []any{"a", "b"},
+		"extra":   map[string]any{"a": "b"},
+		"unknown": "attributes not in the schema are ignored",
+	}, testSchema), IsNil)
+	c.Check(interfaces.ValidateAttrs("iface", nil, nil), IsNil)
+}
+
+func (s *attrSchemaSuite) TestValidateAttrsTypeMismatch(c *C) {
+	for _, t := range []struct {
+		name  string
+		value any
+		err   string
+	}{
+		{"flag", "yes", `iface "flag" attribute must be a boolean`},
+		{"mode", true, `iface "mode" attribute must be a string`},
+		{"count", "1", `iface "count" attribute must be an integer`},
+		{"items", "a", `iface "items" attribute must be a list`},
+		{"extra", []any{"a"}, `iface "extra" attribute must be a map`},
+	} {
+		attrs := map[string]any{"path": "/dev/foo", t.name: t.value}
+		c.Check(interfaces.ValidateAttrs("iface", attrs, testSchema), ErrorMatches, t.err, Commentf("%s", t.name))
+	}
+}
+
+func (s *attrSchemaSuite) TestValidateAttrsDisallowedValue(c *C) {
+	c.Check(interfaces.ValidateAttrs("iface", map[string]any{
+		"path": "/dev/foo",
+		"mode": "wo",
+	}, testSchema), ErrorMatches, `iface "mode" attribute must be one of "ro", "rw"`)
+	c.Check(interfaces.ValidateAttrs("iface", map[string]any{
+		"path":  "/dev/foo",
+		"count": int64(3),
+	}, testSchema), ErrorMatches, `iface "count" attribute must be one of 1, 2`)
+}
+
+func (s *attrSchemaSuite) TestValidateAttrsMissingRequired(c *C) {
+	c.Check(interfaces.ValidateAttrs("iface", map[string]any{
+		"flag": true,
+	}, testSchema), ErrorMatches, `iface must contain the "path" attribute`)
+}
+
+func (s *attrSchemaSuite) TestValidateAttrsUnknownType(c *C) {
+	c.Check(interfaces.ValidateAttrs("iface", map[string]any{
+		"foo": 1.5,
+	}, map[string]interfaces.AttrSpec{
+		"foo": {Type: "float"},
+	}), ErrorMatches, `internal error: cannot validate iface "foo" attribute: unknown attribute type "float"`)
+}
+
+func (s *attrSchemaSuite) TestBeforePreparePlugValidatesSchema(c *C) {
+	info := snaptest.MockInfo(c, `
+name: snap
+version: 0
+plugs:
+  plug:
+    interface: iface
+    flag: maybe
+`, nil)
+	plug := info.Plugs["plug"]
+
+	called := false
+	iface := &schemaIface{
+		TestInterface: ifacetest.TestInterface{
+			InterfaceName: "iface",
+			BeforePreparePlugCallback: func(plug *snap.PlugInfo) error {
+				called = true
+				return nil
+			},
+		},
+		schema: map[string]interfaces.AttrSpec{
+			"flag": {Type: interfaces.AttrBool},
+		},
+	}
+	c.Assert(interfaces.BeforePreparePlug(iface, plug), ErrorMatches, `iface "flag" attribute must be a boolean`)
+	// the sanitizer of the interface is not reached
+	c.Check(called, Equals, false)
+
+	plug.Attrs["flag"] = true
+	c.Assert(interfaces.BeforePreparePlug(iface, plug), IsNil)
+	c.Check(called, Equals, true)
+
+	iface.BeforePreparePlugCallback = func(plug *snap.PlugInfo) error { return fmt.Errorf("broken") }
+	c.Assert(interfaces.BeforePreparePlug(iface, plug), ErrorMatches, "broken")
+}
+
+type slotSchemaIface struct {
+	ifacetest.TestInterface
+
+	schema map[string]interfaces.AttrSpec
+}
+
+func (iface *slotSchemaIface) SlotAttributeSchema() map[string]interfaces.AttrSpec {
+	return iface.schema
+}
+
+func (s *attrSchemaSuite) TestBeforePrepareSlotValidatesSchema(c *C) {
+	info := snaptest.MockInfo(c, `
+name: snap
+version: 0
+slots:
+  slot:
+    interface: iface
+    count: 3
+`, nil)
+	slot := info.Slots["slot"]
+
+	called := false
+	iface := &slotSchemaIface{
+		TestInterface: ifacetest.TestInterface{
+			InterfaceName: "iface",
+			BeforePrepareSlotCallback: func(slot *snap.SlotInfo) error {
+				called = true
+				return nil
+			},
+		},
+		schema: map[string]interfaces.AttrSpec{
+			"count": {Type: interfaces.AttrInt, Allowed: []any{int64(1), int64(2)}},
+		},
+	}
+	c.Assert(interfaces.BeforePrepareSlot(iface, slot), ErrorMatches, `iface "count" attribute must be one of 1, 2`)
+	// the sanitizer of the interface is not reached
+	c.Check(called, Equals, false)
+
+	slot.Attrs["count"] = int64(2)
+	c.Assert(interfaces.BeforePrepareSlot(iface, slot), IsNil)
+	c.Check(called, Equals, true)
+}
+
+func (s *attrSchemaSuite) TestValidateAttrsBadDefault(c *C) {
+	c.Check(interfaces.ValidateAttrs("iface", nil, map[string]interfaces.AttrSpec{
+		"flag": {Type: interfaces.AttrBool, Default: "yes"},
+	}), ErrorMatches, `internal error: iface "flag" attribute default yes is not a boolean`)
+	c.Check(interfaces.ValidateAttrs("iface", nil, map[string]interfaces.AttrSpec{
+		"flag": {Type: interfaces.AttrBool, Default: false},
+	}), IsNil)
+}
+
+func (s *attrSchemaSuite) TestAttrValue(c *C) {
+	info := snaptest.MockInfo(c, `
+name: snap
+version: 0
+plugs:
+  plug:
+    interface: iface
+    mode: rw
+`, nil)
+	appSet, err := interfaces.NewSnapAppSet(info, nil)
+	c.Assert(err, IsNil)
+	plug := interfaces.NewConnectedPlug(info.Plugs["plug"], appSet, nil, nil)
+	schema := map[string]interfaces.AttrSpec{
+		"mode": {Type: interfaces.AttrString, Default: "ro"},
+		"flag": {Type: interfaces.AttrBool, Default: true},
+		"path": {Type: interfaces.AttrString},
+	}
+
+	// the value of the plug wins over the default
+	value, ok := interfaces.AttrValue(plug, "mode", schema)
+	c.Check(ok, Equals, true)
+	c.Check(value, Equals, "rw")
+
+	// the default is used when the attribute is not set
+	value, ok = interfaces.AttrValue(plug, "flag", schema)
+	c.Check(ok, Equals, true)
+	c.Check(value, Equals, true)
+
+	// unless there is none
+	_, ok = interfaces.AttrValue(plug, "path", schema)
+	c.Check(ok, Equals, false)
+	_, ok = interfaces.AttrValue(plug, "unknown", schema)
+	c.Check(ok, Equals, false)
+}
@@ -75,6 +75,29 @@ type ConfinementOptions struct {
 	// KernelSnap is the name of the kernel snap in the system
 	// (empty for classic systems).
 	KernelSnap string
+	// AuditedPlugs are the plugs of the snap with connections in audit
+	// mode. The rules the interfaces add for those plugs stay enforced but
+	// the accesses and syscalls they allow are logged.
+	AuditedPlugs []string
+	// LogPrivilegedSyscalls switches the syscalls granted by privileged
+	// interfaces to the log action, see the
+	// debug.seccomp.log-privileged-syscalls system option.
+	LogPrivilegedSyscalls bool
+}
+
+// Confinement returns the type of confinement the options apply to the snap.
+// The JailMode flag enforces strict confinement, even for snaps using another
+// type of confinement.
+func (opts *ConfinementOptions) Confinement() snap.ConfinementType {
+	switch {
+	case opts.JailMode:
+		return snap.StrictConfinement
+	case opts.Classic:
+		return snap.ClassicConfinement
+	case opts.DevMode:
+		return snap.DevModeConfinement
+	}
+	return snap.StrictConfinement
 }
 
 // SecurityBackendOptions carries extra flags that affect initialization of the
@@ -137,6 +160,24 @@ type SecurityBackendSetupMany interface {
 	SetupMany(appSets []*SnapAppSet, confinement func(snapName string) ConfinementOptions, repo *Repository, tm timings.Measurer) []error
 }
 
+// SecurityBackendConcurrentSetup interface may be implemented by backends
+// whose Setup only affects the security artefacts of the given snap, such that
+// it can be called for multiple snaps at the same time.
+type SecurityBackendConcurrentSetup interface {
+	// CanSetupConcurrently returns true if Setup can be called concurrently
+	// for distinct snaps.
+	CanSetupConcurrently() bool
+}
+
+// SecurityBackendProfiles interface may be implemented by backends that can
+// render the security files they would write for a snap without writing
+// them, so that those can be compared with the files present on disk.
+type SecurityBackendProfiles interface {
+	// Profiles returns the content of the security files of the given snap,
+	// keyed by their path.
+	Profiles(appSet *SnapAppSet, opts ConfinementOptions, repo *Repository) (map[string][]byte, error)
+}
+
 // SecurityBackendDiscardingLate interface may be implemented by backends that
 // support removal snap profiles late during the very last step of the snap
 // remove process, typically long after the SecuityBackend.Remove() has been
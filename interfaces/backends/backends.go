@@ -22,6 +22,7 @@ package backends
 import (
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/cgroup"
 	"github.com/snapcore/snapd/interfaces/configfiles"
 	"github.com/snapcore/snapd/interfaces/dbus"
 	"github.com/snapcore/snapd/interfaces/kmod"
@@ -57,6 +58,7 @@ func All() []interfaces.SecurityBackend {
 		&ldconfig.Backend{},
 		&configfiles.Backend{},
 		&symlinks.Backend{},
+		&cgroup.Backend{},
 	}
 
 	// TODO use something like:
@@ -0,0 +1,132 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package interfaces
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// AllInterfaces returns all the known interfaces sorted by name. Note that in
+// order for this to work properly, the package "interfaces/builtin" must also
+// eventually be imported to populate the full list of interfaces.
+var AllInterfaces = func() []Interface {
+	panic("AllInterfaces is unset, import interfaces/builtin to initialize this")
+}
+
+// appendBaseDeclarationRules appends the plug or slot rules of the base
+// declaration of the given interface to buf. The rules use the format of
+// assertion headers, indented under the top-level plugs or slots entry, and
+// may only describe the interface itself.
+func appendBaseDeclarationRules(buf *bytes.Buffer, iface Interface, kind, rules string) error {
+	rules = strings.Trim(rules, "\n")
+	if rules == "" {
+		return nil
+	}
+	for _, line := range strings.Split(rules, "\n") {
+		if !strings.HasPrefix(line, "  ") {
+			return fmt.Errorf("invalid base declaration %s of interface %q: unexpected line %q", kind, iface.Name(), line)
+		}
+		if strings.HasPrefix(line, "   ") {
+			continue
+		}
+		if name := strings.TrimSpace(line); name != iface.Name()+":" {
+			return fmt.Errorf("invalid base declaration %s of interface %q: unexpected entry %q", kind, iface.Name(), strings.TrimSuffix(name, ":"))
+		}
+	}
+	buf.WriteString(rules)
+	buf.WriteByte('\n')
+	return nil
+}
+
+// AggregateBaseDeclaration returns the plug and slot rules of the base
+// declarations of all the known interfaces as a single document, with the
+// interfaces sorted by name. Unlike the builtin base-declaration assertion,
+// the document carries no assertion headers and is meant for review tooling.
+func AggregateBaseDeclaration() (string, error) {
+	ifaces := AllInterfaces()
+	var buf bytes.Buffer
+	buf.WriteString("plugs:\n")
+	for _, iface := range ifaces {
+		if err := appendBaseDeclarationRules(&buf, iface, "plugs", StaticInfoOf(iface).BaseDeclarationPlugs); err != nil {
+			return "", err
+		}
+	}
+	buf.WriteString("slots:\n")
+	for _, iface := range ifaces {
+		if err := appendBaseDeclarationRules(&buf, iface, "slots", StaticInfoOf(iface).BaseDeclarationSlots); err != nil {
+			return "", err
+		}
+	}
+	return buf.String(), nil
+}
+
+// SecurityLevel summarizes the security implications of granting an
+// interface to a snap.
+type SecurityLevel string
+
+const (
+	// SecurityLevelLow is the level of interfaces which are connected
+	// automatically.
+	SecurityLevelLow SecurityLevel = "low"
+	// SecurityLevelMedium is the level of interfaces which, at least in some
+	// cases, must be connected manually or through a snap declaration.
+	SecurityLevelMedium SecurityLevel = "medium"
+	// SecurityLevelHigh is the level of super-privileged interfaces, which
+	// snaps can only plug when allowed by a snap declaration.
+	SecurityLevelHigh SecurityLevel = "high"
+)
+
+// baseDeclarationRules returns the rules of the base declaration of the given
+// interface, as found in the plug or slot rules of its static information.
+func baseDeclarationRules(iface Interface, rules string) map[string]any {
+	var decl map[string]map[string]any
+	if err := yaml.Unmarshal([]byte(rules), &decl); err != nil {
+		return nil
+	}
+	return decl[iface.Name()]
+}
+
+// SecurityLevelOf returns the security level of the given interface, as
+// declared by the interface or otherwise derived from its base declaration.
+func SecurityLevelOf(iface Interface) SecurityLevel {
+	si := StaticInfoOf(iface)
+	if si.SecurityLevel != "" {
+		return si.SecurityLevel
+	}
+	plugRules := baseDeclarationRules(iface, si.BaseDeclarationPlugs)
+	if plugRules["allow-installation"] == false || plugRules["deny-installation"] == true {
+		return SecurityLevelHigh
+	}
+	for _, rules := range []map[string]any{plugRules, baseDeclarationRules(iface, si.BaseDeclarationSlots)} {
+		// denial of the auto-connection, possibly under some
+		// constraints
+		if deny, ok := rules["deny-auto-connection"]; ok && deny != false {
+			return SecurityLevelMedium
+		}
+		if rules["allow-auto-connection"] == false {
+			return SecurityLevelMedium
+		}
+	}
+	return SecurityLevelLow
+}
@@ -0,0 +1,160 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package interfaces_test
+
+import (
+	"sort"
+	"strings"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/ifacetest"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type baseDeclarationSuite struct {
+	testutil.BaseTest
+}
+
+var _ = Suite(&baseDeclarationSuite{})
+
+func (s *baseDeclarationSuite) TestAggregateBaseDeclaration(c *C) {
+	decl, err := interfaces.AggregateBaseDeclaration()
+	c.Assert(err, IsNil)
+
+	c.Check(decl, testutil.Contains, `
+  fuse-support:
+    allow-installation:
+      slot-snap-type:
+        - core
+        - gadget
+    deny-auto-connection: true
+`)
+
+	// the slot rules of the interfaces are sorted by name
+	plugs, slots, found := strings.Cut(decl, "\nslots:\n")
+	c.Assert(found, Equals, true)
+	c.Check(strings.HasPrefix(plugs, "plugs:\n"), Equals, true)
+	var names []string
+	for _, line := range strings.Split(slots, "\n") {
+		if strings.HasPrefix(line, "  ") && !strings.HasPrefix(line, "   ") {
+			names = append(names, strings.TrimSuffix(strings.TrimSpace(line), ":"))
+		}
+	}
+	c.Check(sort.StringsAreSorted(names), Equals, true)
+	c.Check(names, testutil.Contains, "fuse-support")
+}
+
+func (s *baseDeclarationSuite) TestAggregateBaseDeclarationMocked(c *C) {
+	s.AddCleanup(testutil.Backup(&interfaces.AllInterfaces))
+	interfaces.AllInterfaces = func() []interfaces.Interface {
+		return []interfaces.Interface{
+			&ifacetest.TestInterface{
+				InterfaceName: "one",
+				InterfaceStaticInfo: interfaces.StaticInfo{
+					BaseDeclarationSlots: `
+  one:
+    allow-installation: false
+`,
+				},
+			},
+			&ifacetest.TestInterface{
+				InterfaceName: "two",
+				InterfaceStaticInfo: interfaces.StaticInfo{
+					BaseDeclarationPlugs: `
+  two:
+    allow-installation: false
+`,
+					BaseDeclarationSlots: `
+  two:
+    deny-auto-connection: true
+`,
+				},
+			},
+		}
+	}
+	decl, err := interfaces.AggregateBaseDeclaration()
+	c.Assert(err, IsNil)
+	c.Check(decl, Equals, `plugs:
+  two:
+    allow-installation: false
+slots:
+  one:
+    allow-installation: false
+  two:
+    deny-auto-connection: true
+`)
+}
+
+func (s *baseDeclarationSuite) TestAggregateBaseDeclarationErrors(c *C) {
+	s.AddCleanup(testutil.Backup(&interfaces.AllInterfaces))
+	for _, t := range []struct {
+		info interfaces.StaticInfo
+		err  string
+	}{
+		{interfaces.StaticInfo{BaseDeclarationPlugs: "\niface:\n  allow-installation: false\n"}, `invalid base declaration plugs of interface "iface": unexpected line "iface:"`},
+		{interfaces.StaticInfo{BaseDeclarationSlots: "\n  other:\n    deny-auto-connection: true\n"}, `invalid base declaration slots of interface "iface": unexpected entry "other"`},
+	} {
+		interfaces.AllInterfaces = func() []interfaces.Interface {
+			return []interfaces.Interface{&ifacetest.TestInterface{
+				InterfaceName:       "iface",
+				InterfaceStaticInfo: t.info,
+			}}
+		}
+		_, err := interfaces.AggregateBaseDeclaration()
+		c.Check(err, ErrorMatches, t.err)
+	}
+}
+
+func (s *baseDeclarationSuite) TestSecurityLevelOf(c *C) {
+	for _, t := range []struct {
+		plugs, slots string
+		level        interfaces.SecurityLevel
+	}{
+		{"", "", interfaces.SecurityLevelLow},
+		{"", "\n  iface:\n    allow-installation:\n      slot-snap-type:\n        - core\n", interfaces.SecurityLevelLow},
+		{"", "\n  iface:\n    deny-auto-connection: false\n", interfaces.SecurityLevelLow},
+		{"", "\n  iface:\n    deny-auto-connection: true\n", interfaces.SecurityLevelMedium},
+		{"", "\n  iface:\n    deny-auto-connection:\n      slot-snap-type:\n        - core\n", interfaces.SecurityLevelMedium},
+		{"\n  iface:\n    allow-auto-connection: false\n", "", interfaces.SecurityLevelMedium},
+		{"\n  iface:\n    allow-installation: false\n    deny-auto-connection: true\n", "", interfaces.SecurityLevelHigh},
+		{"\n  iface:\n    deny-installation: true\n", "", interfaces.SecurityLevelHigh},
+	} {
+		iface := &ifacetest.TestInterface{
+			InterfaceName: "iface",
+			InterfaceStaticInfo: interfaces.StaticInfo{
+				BaseDeclarationPlugs: t.plugs,
+				BaseDeclarationSlots: t.slots,
+			},
+		}
+		c.Check(interfaces.SecurityLevelOf(iface), Equals, t.level, Commentf("plugs %q slots %q", t.plugs, t.slots))
+	}
+
+	// the level can be set explicitly
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "iface",
+		InterfaceStaticInfo: interfaces.StaticInfo{
+			SecurityLevel:        interfaces.SecurityLevelHigh,
+			BaseDeclarationSlots: "\n  iface:\n    deny-auto-connection: true\n",
+		},
+	}
+	c.Check(interfaces.SecurityLevelOf(iface), Equals, interfaces.SecurityLevelHigh)
+}
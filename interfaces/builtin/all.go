@@ -24,6 +24,7 @@ import (
 	"sort"
 
 	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/logger"
 	"github.com/snapcore/snapd/snap"
 )
 
@@ -32,16 +33,23 @@ func init() {
 
 	// setup the ByName function using allInterfaces
 	interfaces.ByName = func(name string) (interfaces.Interface, error) {
+		if newName, ok := interfaceAliases[name]; ok {
+			name = newName
+		}
 		iface, ok := allInterfaces[name]
 		if !ok {
 			return nil, fmt.Errorf("interface %q not found", name)
 		}
 		return iface, nil
 	}
+	interfaces.AllInterfaces = Interfaces
 }
 
 var (
 	allInterfaces map[string]interfaces.Interface
+	// interfaceAliases maps the former names of renamed interfaces to
+	// their current names.
+	interfaceAliases map[string]string
 )
 
 // Interfaces returns all of the built-in interfaces.
@@ -56,7 +64,7 @@ func Interfaces() []interfaces.Interface {
 
 // registerIface appends the given interface into the list of all known interfaces.
 func registerIface(iface interfaces.Interface) {
-	if allInterfaces[iface.Name()] != nil {
+	if allInterfaces[iface.Name()] != nil || interfaceAliases[iface.Name()] != "" {
 		panic(fmt.Errorf("cannot register duplicate interface %q", iface.Name()))
 	}
 	if allInterfaces == nil {
@@ -65,11 +73,39 @@ func registerIface(iface interfaces.Interface) {
 	allInterfaces[iface.Name()] = iface
 }
 
+// registerIfaceAlias registers oldName as an alias of the given interface,
+// which must have been registered already. Plugs and slots declared with the
+// old name keep working and are moved to the current name of the interface.
+func registerIfaceAlias(oldName string, iface interfaces.Interface) {
+	if allInterfaces[iface.Name()] != iface {
+		panic(fmt.Errorf("cannot register alias %q of unregistered interface %q", oldName, iface.Name()))
+	}
+	if allInterfaces[oldName] != nil || interfaceAliases[oldName] != "" {
+		panic(fmt.Errorf("cannot register duplicate interface %q", oldName))
+	}
+	if interfaceAliases == nil {
+		interfaceAliases = make(map[string]string)
+	}
+	interfaceAliases[oldName] = iface.Name()
+}
+
+// resolveIfaceAlias replaces the former name of a renamed interface used by a
+// plug or slot with the current one, with a deprecation warning.
+func resolveIfaceAlias(snapName, kind, name string, ifaceName *string) {
+	newName, ok := interfaceAliases[*ifaceName]
+	if !ok {
+		return
+	}
+	logger.Noticef("WARNING: %s %q of snap %q uses deprecated interface name %q, use %q instead", kind, name, snapName, *ifaceName, newName)
+	*ifaceName = newName
+}
+
 func SanitizePlugsSlots(snapInfo *snap.Info) {
 	var badPlugs []string
 	var badSlots []string
 
 	for plugName, plugInfo := range snapInfo.Plugs {
+		resolveIfaceAlias(snapInfo.InstanceName(), "plug", plugName, &plugInfo.Interface)
 		iface, ok := allInterfaces[plugInfo.Interface]
 		if !ok {
 			snapInfo.BadInterfaces[plugName] = fmt.Sprintf("unknown interface %q", plugInfo.Interface)
@@ -90,6 +126,7 @@ func SanitizePlugsSlots(snapInfo *snap.Info) {
 	}
 
 	for slotName, slotInfo := range snapInfo.Slots {
+		resolveIfaceAlias(snapInfo.InstanceName(), "slot", slotName, &slotInfo.Interface)
 		iface, ok := allInterfaces[slotInfo.Interface]
 		if !ok {
 			snapInfo.BadInterfaces[slotName] = fmt.Sprintf("unknown interface %q", slotInfo.Interface)
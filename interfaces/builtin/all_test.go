@@ -37,6 +37,7 @@ import (
 	"github.com/snapcore/snapd/interfaces/seccomp"
 	"github.com/snapcore/snapd/interfaces/systemd"
 	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/logger"
 	"github.com/snapcore/snapd/snap"
 	"github.com/snapcore/snapd/snap/snaptest"
 	"github.com/snapcore/snapd/testutil"
@@ -269,6 +270,69 @@ func (s *AllSuite) TestRegisterIface(c *C) {
 	c.Assert(func() { builtin.RegisterIface(iface) }, PanicMatches, `cannot register duplicate interface "foo"`)
 }
 
+func (s *AllSuite) TestRegisterIfaceAlias(c *C) {
+	restore := builtin.MockInterfaces(nil)
+	defer restore()
+	restore = builtin.MockInterfaceAliases(nil)
+	defer restore()
+
+	iface := &ifacetest.TestInterface{InterfaceName: "foo"}
+	c.Assert(func() { builtin.RegisterIfaceAlias("old-foo", iface) }, PanicMatches, `cannot register alias "old-foo" of unregistered interface "foo"`)
+
+	builtin.RegisterIface(iface)
+	builtin.RegisterIfaceAlias("old-foo", iface)
+	found, err := interfaces.ByName("old-foo")
+	c.Assert(err, IsNil)
+	c.Check(found, Equals, interfaces.Interface(iface))
+
+	// Aliases can neither shadow interfaces nor other aliases.
+	builtin.RegisterIface(&ifacetest.TestInterface{InterfaceName: "bar"})
+	c.Assert(func() { builtin.RegisterIfaceAlias("bar", iface) }, PanicMatches, `cannot register duplicate interface "bar"`)
+	c.Assert(func() { builtin.RegisterIfaceAlias("old-foo", iface) }, PanicMatches, `cannot register duplicate interface "old-foo"`)
+	c.Assert(func() { builtin.RegisterIface(&ifacetest.TestInterface{InterfaceName: "old-foo"}) }, PanicMatches, `cannot register duplicate interface "old-foo"`)
+}
+
+const testConsumerAliasedInterfaceYaml = `
+name: consumer
+version: 0
+plugs:
+ old-plug:
+  interface: old-iface
+ new-plug:
+  interface: iface
+slots:
+ old-slot:
+  interface: old-iface
+apps:
+    app:
+        plugs: [old-plug, new-plug]
+        slots: [old-slot]
+`
+
+func (s *AllSuite) TestSanitizeResolvesInterfaceAlias(c *C) {
+	logbuf, restore := logger.MockLogger()
+	defer restore()
+	restore = builtin.MockInterfaces(map[string]interfaces.Interface{
+		"iface": &ifacetest.TestInterface{InterfaceName: "iface"},
+	})
+	defer restore()
+	restore = builtin.MockInterfaceAliases(map[string]string{"old-iface": "iface"})
+	defer restore()
+
+	snapInfo := snaptest.MockInfo(c, testConsumerAliasedInterfaceYaml, nil)
+	snap.SanitizePlugsSlots(snapInfo)
+	c.Assert(snapInfo.BadInterfaces, HasLen, 0)
+	c.Check(snapInfo.Plugs["old-plug"].Interface, Equals, "iface")
+	c.Check(snapInfo.Plugs["new-plug"].Interface, Equals, "iface")
+	c.Check(snapInfo.Slots["old-slot"].Interface, Equals, "iface")
+	c.Check(snapInfo.Apps["app"].Plugs, HasLen, 2)
+	c.Check(snapInfo.Apps["app"].Slots, HasLen, 1)
+
+	c.Check(logbuf.String(), testutil.Contains, `WARNING: plug "old-plug" of snap "consumer" uses deprecated interface name "old-iface", use "iface" instead`)
+	c.Check(logbuf.String(), testutil.Contains, `WARNING: slot "old-slot" of snap "consumer" uses deprecated interface name "old-iface", use "iface" instead`)
+	c.Check(logbuf.String(), Not(testutil.Contains), `"new-plug"`)
+}
+
 const testConsumerInvalidSlotNameYaml = `
 name: consumer
 version: 0
@@ -467,6 +531,27 @@ func (s *AllSuite) TestAppArmorUnconfinedSlots(c *C) {
 	}
 }
 
+func (s *AllSuite) TestExamplesAreValid(c *C) {
+	for _, iface := range builtin.Interfaces() {
+		example := interfaces.StaticInfoOf(iface).Example
+		if example == "" {
+			continue
+		}
+		// the example is a snapcraft.yaml stanza, whose plugs are
+		// valid for the interface
+		snapInfo := snaptest.MockInfo(c, "name: example\nversion: 1\n"+example, nil)
+		found := false
+		for _, plug := range snapInfo.Plugs {
+			if plug.Interface != iface.Name() {
+				continue
+			}
+			found = true
+			c.Check(interfaces.BeforePreparePlug(iface, plug), IsNil, Commentf("interface %s", iface.Name()))
+		}
+		c.Check(found, Equals, true, Commentf("interface %s", iface.Name()))
+	}
+}
+
 func (s *AllSuite) TestPrioritizedSnippets(c *C) {
 	keys := apparmor.RegisteredSnippetKeys()
 	c.Assert(len(keys), Equals, 1)
@@ -0,0 +1,74 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const amdgpuControlSummary = `allows managing the power and clocks of AMD GPUs`
+
+const amdgpuControlBaseDeclarationSlots = `
+  amdgpu-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const amdgpuControlConnectedPlugAppArmor = `
+# Description: Can manage the power play features of AMD GPUs driven by
+# amdgpu, such as the power profile, the performance level and the clock
+# and voltage tables. This allows overclocking and undervolting the GPU,
+# which may damage the hardware.
+# See https://docs.kernel.org/gpu/amdgpu/thermal.html
+
+# The sysfs attributes are reached through /sys/class/drm/card*/device, which
+# links to the directory of the PCI device.
+/sys/class/drm/ r,
+/sys/devices/pci[0-9a-f]*/**/pp_* rw,
+/sys/devices/pci[0-9a-f]*/**/power_dpm_force_performance_level rw,
+/sys/devices/pci[0-9a-f]*/**/power_dpm_state r,
+
+# For the amdgpu ioctls, see the seccomp policy
+/dev/dri/ r,
+/dev/dri/card[0-9]* rw,
+`
+
+const amdgpuControlConnectedPlugSecComp = `
+# Description: Can query the state of AMD GPUs and override the scheduling
+# priority of processes using them.
+
+ioctl - DRM_IOCTL_AMDGPU_INFO
+ioctl - DRM_IOCTL_AMDGPU_SCHED
+`
+
+var amdgpuControlConnectedPlugUDev = []string{
+	`SUBSYSTEM=="drm", KERNEL=="card[0-9]*"`,
+}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "amdgpu-control",
+		summary:               amdgpuControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  amdgpuControlBaseDeclarationSlots,
+		connectedPlugAppArmor: amdgpuControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  amdgpuControlConnectedPlugSecComp,
+		connectedPlugUDev:     amdgpuControlConnectedPlugUDev,
+	})
+}
@@ -0,0 +1,130 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type amdgpuControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&amdgpuControlInterfaceSuite{
+	iface: builtin.MustInterface("amdgpu-control"),
+})
+
+const amdgpuControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [amdgpu-control]
+`
+
+const amdgpuControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  amdgpu-control:
+`
+
+func (s *amdgpuControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, amdgpuControlConsumerYaml, nil, "amdgpu-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, amdgpuControlCoreYaml, nil, "amdgpu-control")
+}
+
+func (s *amdgpuControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "amdgpu-control")
+}
+
+func (s *amdgpuControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *amdgpuControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *amdgpuControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/dev/dri/card[0-9]* rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/class/drm/ r,\n")
+}
+
+func (s *amdgpuControlInterfaceSuite) TestAppArmorSpecPowerPlay(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/pci[0-9a-f]*/**/pp_* rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/pci[0-9a-f]*/**/power_dpm_force_performance_level rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/pci[0-9a-f]*/**/power_dpm_state r,\n")
+}
+
+func (s *amdgpuControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\nioctl - DRM_IOCTL_AMDGPU_INFO\n")
+	c.Check(snippet, testutil.Contains, "\nioctl - DRM_IOCTL_AMDGPU_SCHED\n")
+}
+
+func (s *amdgpuControlInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 2)
+	c.Assert(spec.Snippets(), testutil.Contains, `# amdgpu-control
+SUBSYSTEM=="drm", KERNEL=="card[0-9]*", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+}
+
+func (s *amdgpuControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows managing the power and clocks of AMD GPUs`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "amdgpu-control")
+}
+
+func (s *amdgpuControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *amdgpuControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -0,0 +1,78 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const apparmorNotifyControlSummary = `allows receiving and replying to AppArmor permission prompts`
+
+// Replying to AppArmor notifications decides which accesses of other confined
+// snaps are allowed, a snap with this interface connected can therefore lift
+// the confinement of any other snap using prompting. This is only meant for
+// the trusted prompting agent, hence it must never be auto-connected.
+const apparmorNotifyControlBaseDeclarationSlots = `
+  apparmor-notify-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const apparmorNotifyControlConnectedPlugAppArmor = `
+# Description: Can register as a listener for AppArmor notifications and
+# reply to them, granting or denying the accesses of other confined snaps
+# which are subject to prompting.
+
+# The notification interface is driven with ioctls issued on the open file
+# descriptor, registering a listener is restricted to policy administrators
+capability mac_admin,
+/sys/kernel/security/apparmor/.notify rw,
+
+# Allow discovering whether the kernel supports notifications and with which
+# protocol version
+/sys/kernel/security/apparmor/ r,
+/sys/kernel/security/apparmor/features/policy/permstable32 r,
+/sys/kernel/security/apparmor/features/policy/notify/{,**} r,
+`
+
+const apparmorNotifyControlConnectedPlugSecComp = `
+# Description: Can register as a listener for AppArmor notifications and
+# reply to them.
+
+# The notification protocol is driven with ioctls on the .notify file
+# descriptor
+ioctl - APPARMOR_NOTIF_SET_FILTER
+ioctl - APPARMOR_NOTIF_GET_FILTER
+ioctl - APPARMOR_NOTIF_IS_ID_VALID
+ioctl - APPARMOR_NOTIF_RECV
+ioctl - APPARMOR_NOTIF_SEND
+ioctl - APPARMOR_NOTIF_REGISTER
+ioctl - APPARMOR_NOTIF_RESEND
+`
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "apparmor-notify-control",
+		summary:               apparmorNotifyControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  apparmorNotifyControlBaseDeclarationSlots,
+		connectedPlugAppArmor: apparmorNotifyControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  apparmorNotifyControlConnectedPlugSecComp,
+	})
+}
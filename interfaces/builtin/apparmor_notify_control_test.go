@@ -0,0 +1,115 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type apparmorNotifyControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&apparmorNotifyControlInterfaceSuite{
+	iface: builtin.MustInterface("apparmor-notify-control"),
+})
+
+const apparmorNotifyControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [apparmor-notify-control]
+`
+
+const apparmorNotifyControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  apparmor-notify-control:
+`
+
+func (s *apparmorNotifyControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, apparmorNotifyControlConsumerYaml, nil, "apparmor-notify-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, apparmorNotifyControlCoreYaml, nil, "apparmor-notify-control")
+}
+
+func (s *apparmorNotifyControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "apparmor-notify-control")
+}
+
+func (s *apparmorNotifyControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *apparmorNotifyControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *apparmorNotifyControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "/sys/kernel/security/apparmor/.notify rw,\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "capability mac_admin,\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "/sys/kernel/security/apparmor/features/policy/notify/{,**} r,\n")
+
+	// no access is granted to the slot side
+	spec = apparmor.NewSpecification(s.slot.AppSet())
+	c.Assert(spec.AddConnectedSlot(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), HasLen, 0)
+}
+
+func (s *apparmorNotifyControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	for _, ioctl := range []string{"APPARMOR_NOTIF_SET_FILTER", "APPARMOR_NOTIF_IS_ID_VALID", "APPARMOR_NOTIF_RECV", "APPARMOR_NOTIF_SEND", "APPARMOR_NOTIF_REGISTER", "APPARMOR_NOTIF_RESEND"} {
+		c.Check(snippet, testutil.Contains, "\nioctl - "+ioctl+"\n")
+	}
+	c.Check(snippet, Not(testutil.Contains), "\nioctl\n")
+}
+
+func (s *apparmorNotifyControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows receiving and replying to AppArmor permission prompts`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "apparmor-notify-control")
+}
+
+func (s *apparmorNotifyControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *apparmorNotifyControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
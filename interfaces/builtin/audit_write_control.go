@@ -0,0 +1,64 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const auditWriteControlSummary = `allows emitting audit events`
+
+// Audit events are trusted by the tools processing the audit log, a snap
+// emitting them can forge records attributed to other programs, so
+// connections need to be explicitly granted.
+const auditWriteControlBaseDeclarationSlots = `
+  audit-write-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const auditWriteControlConnectedPlugAppArmor = `
+# Description: Can emit user space audit events through the audit netlink
+# socket. Unlike netlink-audit, the audit log cannot be read nor the audit
+# rules changed.
+
+# CAP_AUDIT_WRITE required to write records to the kernel auditing log per
+# 'man 7 capabilities'
+capability audit_write,
+
+# libaudit uses a raw netlink socket, audit_open(3)
+network netlink raw,
+`
+
+const auditWriteControlConnectedPlugSecComp = `
+# Description: Can emit user space audit events through the audit netlink
+# socket.
+socket AF_NETLINK - NETLINK_AUDIT
+`
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "audit-write-control",
+		summary:               auditWriteControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  auditWriteControlBaseDeclarationSlots,
+		connectedPlugAppArmor: auditWriteControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  auditWriteControlConnectedPlugSecComp,
+	})
+}
@@ -0,0 +1,113 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type auditWriteControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&auditWriteControlInterfaceSuite{
+	iface: builtin.MustInterface("audit-write-control"),
+})
+
+const auditWriteControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [audit-write-control]
+`
+
+const auditWriteControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  audit-write-control:
+`
+
+func (s *auditWriteControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, auditWriteControlConsumerYaml, nil, "audit-write-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, auditWriteControlCoreYaml, nil, "audit-write-control")
+}
+
+func (s *auditWriteControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "audit-write-control")
+}
+
+func (s *auditWriteControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *auditWriteControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+func (s *auditWriteControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\ncapability audit_write,\n")
+	c.Check(snippet, testutil.Contains, "\nnetwork netlink raw,\n")
+	// reading the audit log and changing the audit rules are left to
+	// netlink-audit
+	c.Check(snippet, Not(testutil.Contains), "audit_read")
+	c.Check(snippet, Not(testutil.Contains), "audit_control")
+	c.Check(snippet, Not(testutil.Contains), "net_admin")
+}
+
+func (s *auditWriteControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\nsocket AF_NETLINK - NETLINK_AUDIT\n")
+	// no need to join the multicast group of the audit log
+	c.Check(snippet, Not(testutil.Contains), "\nbind\n")
+}
+
+func (s *auditWriteControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows emitting audit events`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "audit-write-control")
+}
+
+func (s *auditWriteControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *auditWriteControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
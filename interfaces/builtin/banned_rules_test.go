@@ -0,0 +1,150 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+	"regexp"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/ifacetest"
+	"github.com/snapcore/snapd/strutil"
+)
+
+type bannedRulesSuite struct{}
+
+var _ = Suite(&bannedRulesSuite{})
+
+// bannedAppArmorRule describes apparmor rules which interfaces must not grant
+// by accident. The interfaces which are meant to grant such rules are listed
+// explicitly.
+type bannedAppArmorRule struct {
+	pattern *regexp.Regexp
+	reason  string
+	allowed []string
+}
+
+var bannedAppArmorRules = []bannedAppArmorRule{{
+	pattern: regexp.MustCompile(`^/\*\*\s+\S*[wakx]`),
+	reason:  "writes or executes anything on the system",
+}, {
+	pattern: regexp.MustCompile(`^(capability|file|mount|unix|network|signal|dbus|ptrace)$`),
+	reason:  "grants a whole class of rules",
+	allowed: []string{"docker-support"},
+}, {
+	pattern: regexp.MustCompile(`^change_profile\s*(unsafe\s*)?(->\s*)?\*?$`),
+	reason:  "transitions to any profile",
+}, {
+	pattern: regexp.MustCompile(`^capability\s.*\bsys_module\b`),
+	reason:  "loads kernel modules",
+	allowed: []string{"kernel-module-control"},
+}, {
+	pattern: regexp.MustCompile(`^capability\s.*\bmac_admin\b`),
+	reason:  "changes the apparmor policy",
+	allowed: []string{"apparmor-notify-control", "microstack-support", "multipass-support"},
+}, {
+	pattern: regexp.MustCompile(`^/dev/k?mem\s+\S*w`),
+	reason:  "writes to the physical memory",
+	allowed: []string{"physical-memory-control"},
+}}
+
+// findBannedAppArmorRules returns a description of the rules granted by the
+// plug side of the given interface which match the banned rules, with no
+// attributes set. Deny rules are ignored.
+func findBannedAppArmorRules(iface interfaces.Interface, banned []bannedAppArmorRule) ([]string, error) {
+	rules, err := builtin.ParseAppArmorRules(iface)
+	if err != nil {
+		return nil, err
+	}
+	var found []string
+	for _, rule := range rules {
+		if rule.HasQualifier("deny") {
+			continue
+		}
+		for _, b := range banned {
+			if b.pattern.MatchString(rule.Text) && !strutil.ListContains(b.allowed, iface.Name()) {
+				found = append(found, fmt.Sprintf("%s: %q %s", iface.Name(), rule.Text, b.reason))
+			}
+		}
+	}
+	return found, nil
+}
+
+func (s *bannedRulesSuite) TestNoBannedAppArmorRules(c *C) {
+	scanned := 0
+	for _, iface := range interfaces.AllInterfaces() {
+		found, err := findBannedAppArmorRules(iface, bannedAppArmorRules)
+		if err != nil {
+			// the interface cannot be connected without attributes
+			continue
+		}
+		scanned++
+		c.Check(found, HasLen, 0, Commentf("%v", found))
+	}
+	// most interfaces can be scanned
+	c.Check(scanned > len(interfaces.AllInterfaces())/2, Equals, true)
+}
+
+func (s *bannedRulesSuite) TestFuseSupportHasNoBannedAppArmorRules(c *C) {
+	found, err := findBannedAppArmorRules(builtin.MustInterface("fuse-support"), bannedAppArmorRules)
+	c.Assert(err, IsNil)
+	c.Check(found, HasLen, 0)
+}
+
+func (s *bannedRulesSuite) TestBannedAppArmorRulesFound(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "bad",
+		AppArmorConnectedPlugCallback: func(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddSnippet(`
+/dev/foo rw,
+/** rwmix,
+capability sys_module,
+capability,
+deny /dev/mem w,
+/dev/mem rw,
+`)
+			return nil
+		},
+	}
+	restore := builtin.MockInterface(iface)
+	defer restore()
+
+	found, err := findBannedAppArmorRules(iface, bannedAppArmorRules)
+	c.Assert(err, IsNil)
+	c.Check(found, DeepEquals, []string{
+		`bad: "/** rwmix" writes or executes anything on the system`,
+		`bad: "capability sys_module" loads kernel modules`,
+		`bad: "capability" grants a whole class of rules`,
+		`bad: "/dev/mem rw" writes to the physical memory`,
+	})
+
+	// the rules can be granted by allowed interfaces
+	allowed := []bannedAppArmorRule{{
+		pattern: regexp.MustCompile(`^capability\s.*\bsys_module\b`),
+		allowed: []string{"other", "bad"},
+	}}
+	found, err = findBannedAppArmorRules(iface, allowed)
+	c.Assert(err, IsNil)
+	c.Check(found, HasLen, 0)
+}
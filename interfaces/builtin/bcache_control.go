@@ -0,0 +1,68 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+package builtin
+
+const bcacheControlSummary = `allows managing bcache block device caches`
+
+const bcacheControlBaseDeclarationSlots = `
+  bcache-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const bcacheControlConnectedPlugAppArmor = `
+# Description: Can register backing and caching devices with bcache, attach
+# and detach them and tune the caches, which is all done through sysfs. This
+# also allows using the resulting bcache devices.
+# See https://docs.kernel.org/admin-guide/bcache.html
+
+# Registering devices and the cache sets
+/sys/fs/bcache/ r,
+/sys/fs/bcache/** rw,
+
+# Attributes of the bcache devices and of their backing and caching devices
+/sys/devices/**/block/*/bcache/{,**} rw,
+/sys/devices/**/block/*/*/bcache/{,**} rw,
+
+/dev/bcache[0-9]* rwk,
+/dev/bcache/{,**} r,
+`
+
+var bcacheControlConnectedPlugUDev = []string{
+	`SUBSYSTEM=="block", KERNEL=="bcache[0-9]*"`,
+}
+
+var bcacheControlConnectedPlugKmod = []string{
+	"bcache",
+}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                     "bcache-control",
+		summary:                  bcacheControlSummary,
+		implicitOnCore:           true,
+		implicitOnClassic:        true,
+		baseDeclarationSlots:     bcacheControlBaseDeclarationSlots,
+		connectedPlugAppArmor:    bcacheControlConnectedPlugAppArmor,
+		connectedPlugKModModules: bcacheControlConnectedPlugKmod,
+		connectedPlugUDev:        bcacheControlConnectedPlugUDev,
+	})
+}
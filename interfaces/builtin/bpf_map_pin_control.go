@@ -0,0 +1,116 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	apparmor_sandbox "github.com/snapcore/snapd/sandbox/apparmor"
+	"github.com/snapcore/snapd/strutil"
+)
+
+const bpfMapPinControlSummary = `allows creating and using pinned eBPF maps`
+
+// eBPF maps pinned by other snaps or by the system may be read and modified,
+// and mounting the bpf filesystem requires CAP_SYS_ADMIN, so connections need
+// to be explicitly granted.
+const bpfMapPinControlBaseDeclarationSlots = `
+  bpf-map-pin-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const bpfMapPinControlConnectedPlugAppArmor = `
+# Description: Can create eBPF maps, pin them in the bpf filesystem and use the
+# maps pinned there. Loading eBPF programs is not allowed. See
+# https://docs.kernel.org/bpf/maps.html
+
+# Mounting the bpf filesystem, and creating maps on kernels or with apparmor
+# parsers which predate CAP_BPF, require CAP_SYS_ADMIN
+capability sys_admin,
+mount fstype=bpf bpf -> /sys/fs/bpf/,
+umount /sys/fs/bpf/,
+
+# The pinned maps, and the directories organizing them
+/sys/fs/bpf/ r,
+/sys/fs/bpf/** rw,
+`
+
+const bpfMapPinControlConnectedPlugSecComp = `
+# Description: Can create eBPF maps, pin them in the bpf filesystem and use the
+# maps pinned there. Loading eBPF programs is not allowed.
+
+bpf BPF_MAP_CREATE
+bpf BPF_MAP_LOOKUP_ELEM
+bpf BPF_MAP_UPDATE_ELEM
+bpf BPF_MAP_DELETE_ELEM
+bpf BPF_MAP_GET_NEXT_KEY
+bpf BPF_MAP_LOOKUP_AND_DELETE_ELEM
+bpf BPF_MAP_FREEZE
+bpf BPF_MAP_LOOKUP_BATCH
+bpf BPF_MAP_LOOKUP_AND_DELETE_BATCH
+bpf BPF_MAP_UPDATE_BATCH
+bpf BPF_MAP_DELETE_BATCH
+bpf BPF_OBJ_PIN
+bpf BPF_OBJ_GET
+bpf BPF_OBJ_GET_INFO_BY_FD
+
+mount
+umount
+umount2
+`
+
+type bpfMapPinControlInterface struct {
+	commonInterface
+}
+
+func (iface *bpfMapPinControlInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	if err := iface.commonInterface.AppArmorConnectedPlug(spec, plug, slot); err != nil {
+		return err
+	}
+
+	if apparmor_sandbox.ProbedLevel() == apparmor_sandbox.Unsupported {
+		// no apparmor means we don't have to deal with parser features
+		return nil
+	}
+	features, err := apparmor_sandbox.ParserFeatures()
+	if err != nil {
+		return err
+	}
+	if strutil.ListContains(features, "cap-bpf") {
+		spec.AddSnippet("capability bpf,\n")
+	}
+
+	return nil
+}
+
+func init() {
+	registerIface(&bpfMapPinControlInterface{commonInterface{
+		name:                  "bpf-map-pin-control",
+		summary:               bpfMapPinControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  bpfMapPinControlBaseDeclarationSlots,
+		connectedPlugAppArmor: bpfMapPinControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  bpfMapPinControlConnectedPlugSecComp,
+	}})
+}
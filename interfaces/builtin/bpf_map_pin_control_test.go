@@ -0,0 +1,148 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	apparmor_sandbox "github.com/snapcore/snapd/sandbox/apparmor"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type bpfMapPinControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&bpfMapPinControlInterfaceSuite{
+	iface: builtin.MustInterface("bpf-map-pin-control"),
+})
+
+const bpfMapPinControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [bpf-map-pin-control]
+`
+
+const bpfMapPinControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  bpf-map-pin-control:
+`
+
+func (s *bpfMapPinControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, bpfMapPinControlConsumerYaml, nil, "bpf-map-pin-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, bpfMapPinControlCoreYaml, nil, "bpf-map-pin-control")
+}
+
+func (s *bpfMapPinControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "bpf-map-pin-control")
+}
+
+func (s *bpfMapPinControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *bpfMapPinControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *bpfMapPinControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	r := apparmor_sandbox.MockFeatures(nil, nil, nil, nil)
+	defer r()
+
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\ncapability sys_admin,\n")
+	c.Check(snippet, testutil.Contains, "\nmount fstype=bpf bpf -> /sys/fs/bpf/,\n")
+	c.Check(snippet, testutil.Contains, "\numount /sys/fs/bpf/,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/fs/bpf/ r,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/fs/bpf/** rw,\n")
+	// the parser does not support CAP_BPF
+	c.Check(snippet, Not(testutil.Contains), "capability bpf,")
+}
+
+func (s *bpfMapPinControlInterfaceSuite) TestAppArmorSpecWithCapBPFFeature(c *C) {
+	r := apparmor_sandbox.MockLevel(apparmor_sandbox.Full)
+	defer r()
+	r = apparmor_sandbox.MockFeatures(nil, nil, []string{"feat1", "cap-bpf"}, nil)
+	defer r()
+
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\ncapability bpf,\n")
+}
+
+func (s *bpfMapPinControlInterfaceSuite) TestAppArmorSpecWithNoAppArmor(c *C) {
+	r := apparmor_sandbox.MockLevel(apparmor_sandbox.Unsupported)
+	defer r()
+
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\n/sys/fs/bpf/** rw,\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "capability bpf,")
+}
+
+func (s *bpfMapPinControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	for _, cmd := range []string{
+		"MAP_CREATE", "MAP_LOOKUP_ELEM", "MAP_UPDATE_ELEM", "MAP_DELETE_ELEM", "MAP_GET_NEXT_KEY",
+		"MAP_LOOKUP_AND_DELETE_ELEM", "MAP_FREEZE", "MAP_LOOKUP_BATCH", "MAP_LOOKUP_AND_DELETE_BATCH",
+		"MAP_UPDATE_BATCH", "MAP_DELETE_BATCH", "OBJ_PIN", "OBJ_GET", "OBJ_GET_INFO_BY_FD",
+	} {
+		c.Check(snippet, testutil.Contains, "\nbpf BPF_"+cmd+"\n")
+	}
+	// bpf is only allowed for the map commands
+	c.Check(snippet, Not(testutil.Contains), "\nbpf\n")
+	c.Check(snippet, Not(testutil.Contains), "BPF_PROG_LOAD")
+	c.Check(snippet, testutil.Contains, "\nmount\n")
+	c.Check(snippet, testutil.Contains, "\numount2\n")
+}
+
+func (s *bpfMapPinControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows creating and using pinned eBPF maps`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "bpf-map-pin-control")
+}
+
+func (s *bpfMapPinControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *bpfMapPinControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -0,0 +1,69 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const cachefilesControlSummary = `allows managing the cache of network filesystems through cachefiles`
+
+// The cache is shared by all the network filesystems of the system and the
+// kernel accesses it on behalf of the snap, connections need to be explicitly
+// granted.
+const cachefilesControlBaseDeclarationSlots = `
+  cachefiles-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const cachefilesControlConnectedPlugAppArmor = `
+# Description: Can run a cachefiles daemon, such as cachefilesd, which manages
+# the local cache of network filesystems, such as NFS, through the cachefiles
+# control device.
+# https://www.kernel.org/doc/html/latest/filesystems/caching/cachefiles.html
+/dev/cachefiles rw,
+
+# Binding the cache with the control device requires CAP_SYS_ADMIN
+capability sys_admin,
+
+# The default cache directory of cachefilesd, the kernel creates and culls
+# the cache files with the credentials of the daemon
+/var/cache/fscache/ rw,
+/var/cache/fscache/** rwlk,
+
+# The state of the cache and of the caching backends
+@{PROC}/fs/fscache/{,*} r,
+/sys/module/cachefiles/{,**} r,
+`
+
+var cachefilesControlConnectedPlugUDev = []string{`KERNEL=="cachefiles"`}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "cachefiles-control",
+		summary:               cachefilesControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  cachefilesControlBaseDeclarationSlots,
+		connectedPlugAppArmor: cachefilesControlConnectedPlugAppArmor,
+		connectedPlugUDev:     cachefilesControlConnectedPlugUDev,
+		// the control device only exists once the module is loaded
+		connectedPlugKModModules: []string{"cachefiles"},
+	})
+}
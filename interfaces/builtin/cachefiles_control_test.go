@@ -0,0 +1,113 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/kmod"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type cachefilesControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&cachefilesControlInterfaceSuite{
+	iface: builtin.MustInterface("cachefiles-control"),
+})
+
+const cachefilesControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [cachefiles-control]
+`
+
+const cachefilesControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  cachefiles-control:
+`
+
+func (s *cachefilesControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, cachefilesControlConsumerYaml, nil, "cachefiles-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, cachefilesControlCoreYaml, nil, "cachefiles-control")
+}
+
+func (s *cachefilesControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "cachefiles-control")
+}
+
+func (s *cachefilesControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *cachefilesControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+func (s *cachefilesControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/dev/cachefiles rw,\n")
+	c.Check(snippet, testutil.Contains, "\ncapability sys_admin,\n")
+	c.Check(snippet, testutil.Contains, "\n/var/cache/fscache/** rwlk,\n")
+}
+
+func (s *cachefilesControlInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 2)
+	c.Check(spec.Snippets(), testutil.Contains, "# cachefiles-control\nKERNEL==\"cachefiles\", TAG+=\"snap_consumer_app\"")
+}
+
+func (s *cachefilesControlInterfaceSuite) TestKModSpec(c *C) {
+	spec := &kmod.Specification{}
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.Modules(), DeepEquals, map[string]bool{"cachefiles": true})
+}
+
+func (s *cachefilesControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows managing the cache of network filesystems through cachefiles`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "cachefiles-control")
+}
+
+func (s *cachefilesControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *cachefilesControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
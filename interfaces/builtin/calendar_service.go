@@ -19,10 +19,6 @@
 
 package builtin
 
-import (
-	"github.com/snapcore/snapd/release"
-)
-
 const calendarServiceSummary = `allows communication with Evolution Data Service Calendar`
 
 const calendarServiceBaseDeclarationSlots = `
@@ -132,10 +128,11 @@ dbus (send)
 
 func init() {
 	registerIface(&commonInterface{
-		name:                  "calendar-service",
-		summary:               calendarServiceSummary,
-		implicitOnClassic:     !(release.ReleaseInfo.ID == "ubuntu" && release.ReleaseInfo.VersionID == "14.04"),
-		baseDeclarationSlots:  calendarServiceBaseDeclarationSlots,
-		connectedPlugAppArmor: calendarServiceConnectedPlugAppArmor,
+		name:                         "calendar-service",
+		summary:                      calendarServiceSummary,
+		implicitOnClassic:            true,
+		implicitOnClassicMinVersions: map[string]string{"ubuntu": "16.04"},
+		baseDeclarationSlots:         calendarServiceBaseDeclarationSlots,
+		connectedPlugAppArmor:        calendarServiceConnectedPlugAppArmor,
 	})
 }
@@ -43,17 +43,17 @@ const cifsMountConnectedPlugAppArmor = `
 capability sys_admin,
 
 # Allow mounts to our snap-specific writable directories
-# parallel-installs: SNAP_{DATA,COMMON} are remapped, need to use SNAP_NAME, for
-# completeness allow SNAP_INSTANCE_NAME too
-mount fstype=cifs //** -> /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/@{SNAP_REVISION}/{,**},
-mount fstype=cifs //** -> /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/common/{,**},
+# parallel-installs: SNAP_{DATA,COMMON} are remapped and use SNAP_NAME, the
+# directories of SNAP_INSTANCE_NAME are allowed too for parallel instances
+mount fstype=cifs //** -> ###SNAP_DATA###/{,**},
+mount fstype=cifs //** -> ###SNAP_COMMON###/{,**},
 
 # NOTE: due to LP: #1613403, fstype is not mediated and as such, these rules
 # allow, for example, unmounting bind mounts from the content interface
-# parallel-installs: SNAP_{DATA,COMMON} are remapped, need to use SNAP_NAME, for
-# completeness allow SNAP_INSTANCE_NAME too
-umount /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/@{SNAP_REVISION}/{,**},
-umount /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/common/{,**},
+# parallel-installs: SNAP_{DATA,COMMON} are remapped and use SNAP_NAME, the
+# directories of SNAP_INSTANCE_NAME are allowed too for parallel instances
+umount ###SNAP_DATA###/{,**},
+umount ###SNAP_COMMON###/{,**},
 
 # Due to an unsolved issue with namespace awareness of libmount the unmount tries to access
 # /run/mount/utab but fails. The resulting apparmor warning can be ignored. The log warning
@@ -0,0 +1,84 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const comediControlSummary = `allows access to COMEDI data acquisition devices`
+
+const comediControlBaseDeclarationSlots = `
+  comedi-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const comediControlConnectedPlugAppArmor = `
+# Description: Can acquire and generate data through the COMEDI data
+# acquisition devices, including their subdevice files. See
+# https://www.comedi.org/doc/
+
+/dev/comedi[0-9]* rw,
+
+# Enumerating the devices and their subdevices, and reading the sizes of their
+# buffers
+/sys/class/comedi/ r,
+/sys/devices/**/comedi/comedi[0-9]*/** r,
+`
+
+// Attaching and detaching the drivers of the devices with COMEDI_DEVCONFIG
+// is left out.
+const comediControlConnectedPlugSecComp = `
+# Description: Can query the devices, run commands and instructions on their
+# subdevices, and configure and poll their buffers.
+
+ioctl - COMEDI_DEVINFO
+ioctl - COMEDI_SUBDINFO
+ioctl - COMEDI_CHANINFO
+ioctl - COMEDI_LOCK
+ioctl - COMEDI_UNLOCK
+ioctl - COMEDI_CANCEL
+ioctl - COMEDI_RANGEINFO
+ioctl - COMEDI_CMD
+ioctl - COMEDI_CMDTEST
+ioctl - COMEDI_INSNLIST
+ioctl - COMEDI_INSN
+ioctl - COMEDI_BUFCONFIG
+ioctl - COMEDI_BUFINFO
+ioctl - COMEDI_POLL
+ioctl - COMEDI_SETRSUBD
+ioctl - COMEDI_SETWSUBD
+`
+
+var comediControlConnectedPlugUDev = []string{
+	`SUBSYSTEM=="comedi", KERNEL=="comedi[0-9]*"`,
+}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "comedi-control",
+		summary:               comediControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  comediControlBaseDeclarationSlots,
+		connectedPlugAppArmor: comediControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  comediControlConnectedPlugSecComp,
+		connectedPlugUDev:     comediControlConnectedPlugUDev,
+	})
+}
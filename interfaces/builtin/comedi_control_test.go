@@ -0,0 +1,128 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type comediControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&comediControlInterfaceSuite{
+	iface: builtin.MustInterface("comedi-control"),
+})
+
+const comediControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [comedi-control]
+`
+
+const comediControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  comedi-control:
+`
+
+func (s *comediControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, comediControlConsumerYaml, nil, "comedi-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, comediControlCoreYaml, nil, "comedi-control")
+}
+
+func (s *comediControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "comedi-control")
+}
+
+func (s *comediControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *comediControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *comediControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/dev/comedi[0-9]* rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/class/comedi/ r,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/**/comedi/comedi[0-9]*/** r,\n")
+}
+
+func (s *comediControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	for _, ioctl := range []string{
+		"DEVINFO", "SUBDINFO", "CHANINFO", "LOCK", "UNLOCK", "CANCEL", "RANGEINFO", "CMD",
+		"CMDTEST", "INSNLIST", "INSN", "BUFCONFIG", "BUFINFO", "POLL", "SETRSUBD", "SETWSUBD",
+	} {
+		c.Check(snippet, testutil.Contains, "\nioctl - COMEDI_"+ioctl+"\n")
+	}
+	// drivers cannot be attached nor detached
+	c.Check(snippet, Not(testutil.Contains), "COMEDI_DEVCONFIG")
+}
+
+func (s *comediControlInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 2)
+	c.Assert(spec.Snippets(), testutil.Contains, `# comedi-control
+SUBSYSTEM=="comedi", KERNEL=="comedi[0-9]*", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+}
+
+func (s *comediControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows access to COMEDI data acquisition devices`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "comedi-control")
+}
+
+func (s *comediControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *comediControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
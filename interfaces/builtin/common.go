@@ -22,6 +22,8 @@ package builtin
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/interfaces/apparmor"
@@ -29,6 +31,7 @@ import (
 	"github.com/snapcore/snapd/interfaces/mount"
 	"github.com/snapcore/snapd/interfaces/seccomp"
 	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/logger"
 	"github.com/snapcore/snapd/osutil"
 	"github.com/snapcore/snapd/snap"
 )
@@ -41,13 +44,29 @@ var evalSymlinks = filepath.EvalSymlinks
 // testing.
 var readDir = os.ReadDir
 
+// attrSnippet is a policy snippet added for the connected plugs whose attr
+// attribute, or its default value from the plug attribute schema, is value.
+type attrSnippet struct {
+	attr    string
+	value   any
+	snippet string
+}
+
 type commonInterface struct {
-	name    string
-	summary string
-	docURL  string
+	name          string
+	summary       string
+	docURL        string
+	securityNotes []string
+	// example is a snapcraft.yaml stanza showing how the interface is
+	// used, see interfaces.StaticInfo.Example
+	example string
 
 	implicitOnCore    bool
 	implicitOnClassic bool
+	// implicitOnClassicMinVersions restricts implicitOnClassic to the
+	// releases of the given distributions which are at least the given
+	// version, see interfaces.StaticInfo.ImplicitOnClassicMinVersions
+	implicitOnClassicMinVersions map[string]string
 
 	implicitPlugOnCore    bool
 	implicitPlugOnClassic bool
@@ -57,6 +76,12 @@ type commonInterface struct {
 	appArmorUnconfinedPlugs bool
 	appArmorUnconfinedSlots bool
 
+	seedOnly bool
+
+	providerOnly bool
+
+	defaultProvider string
+
 	// baseDeclarationPlugs defines optional plug-side rules in the
 	// base-declaration assertion relevant for this interface. See
 	// interfaces/builtin/README.md, especially "Base declaration policy
@@ -73,6 +98,22 @@ type commonInterface struct {
 	connectedPlugUDev      []string
 	rejectAutoConnectPairs bool
 
+	// plugAttrSchema and slotAttrSchema describe the attributes of the
+	// plugs and slots, which are validated before any sanitizer runs.
+	plugAttrSchema map[string]interfaces.AttrSpec
+	slotAttrSchema map[string]interfaces.AttrSpec
+
+	// connectedPlugAttrAppArmor and connectedPlugAttrSecComp are added
+	// after connectedPlugAppArmor and connectedPlugSecComp depending on
+	// the attributes of the plug.
+	connectedPlugAttrAppArmor []attrSnippet
+	connectedPlugAttrSecComp  []attrSnippet
+
+	// connectedPlugUDevDevices are the patterns of the device nodes
+	// matched by the connectedPlugUDev rules. When set, the devices are only
+	// tagged if one of the device nodes is present.
+	connectedPlugUDevDevices []string
+
 	connectedPlugUpdateNSAppArmor string
 	connectedPlugMount            []osutil.MountEntry
 
@@ -93,9 +134,15 @@ type commonInterface struct {
 	serviceSnippets []interfaces.PlugServicesSnippet
 
 	conflictingConnectedInterfaces []string
+
+	disconnectAfter []string
 }
 
 var _ = interfaces.ConflictingConnectedInterfacesDefiner(&commonInterface{})
+var _ = interfaces.DisconnectOrderDefiner(&commonInterface{})
+var _ = interfaces.DevicePresenceUser(&commonInterface{})
+var _ = interfaces.PlugAttributeSchema(&commonInterface{})
+var _ = interfaces.SlotAttributeSchema(&commonInterface{})
 
 // Name returns the interface name.
 func (iface *commonInterface) Name() string {
@@ -105,21 +152,48 @@ func (iface *commonInterface) Name() string {
 // StaticInfo returns various meta-data about this interface.
 func (iface *commonInterface) StaticInfo() interfaces.StaticInfo {
 	return interfaces.StaticInfo{
-		Summary:               iface.summary,
-		DocURL:                iface.docURL,
-		ImplicitOnCore:        iface.implicitOnCore,
-		ImplicitOnClassic:     iface.implicitOnClassic,
-		ImplicitPlugOnCore:    iface.implicitPlugOnCore,
-		ImplicitPlugOnClassic: iface.implicitPlugOnClassic,
-		BaseDeclarationPlugs:  iface.baseDeclarationPlugs,
-		BaseDeclarationSlots:  iface.baseDeclarationSlots,
+		Summary:                      iface.summary,
+		DocURL:                       iface.docURL,
+		SecurityNotes:                iface.securityNotes,
+		Example:                      iface.example,
+		ImplicitOnCore:               iface.implicitOnCore,
+		ImplicitOnClassic:            iface.implicitOnClassic,
+		ImplicitOnClassicMinVersions: iface.implicitOnClassicMinVersions,
+		ImplicitPlugOnCore:           iface.implicitPlugOnCore,
+		ImplicitPlugOnClassic:        iface.implicitPlugOnClassic,
+		BaseDeclarationPlugs:         iface.baseDeclarationPlugs,
+		BaseDeclarationSlots:         iface.baseDeclarationSlots,
 		// affects the plug snap because of mount backend
 		AffectsPlugOnRefresh:    iface.affectsPlugOnRefresh,
 		AppArmorUnconfinedPlugs: iface.appArmorUnconfinedPlugs,
 		AppArmorUnconfinedSlots: iface.appArmorUnconfinedSlots,
+		SeedOnly:                iface.seedOnly,
+		ProviderOnly:            iface.providerOnly,
+		DefaultProvider:         iface.defaultProvider,
 	}
 }
 
+// AttributeSchema returns the schema of the plug attributes.
+func (iface *commonInterface) AttributeSchema() map[string]interfaces.AttrSpec {
+	return iface.plugAttrSchema
+}
+
+// SlotAttributeSchema returns the schema of the slot attributes.
+func (iface *commonInterface) SlotAttributeSchema() map[string]interfaces.AttrSpec {
+	return iface.slotAttrSchema
+}
+
+// plugAttrSnippets returns the snippets matching the attributes of the plug.
+func (iface *commonInterface) plugAttrSnippets(plug *interfaces.ConnectedPlug, snippets []attrSnippet) []string {
+	var matching []string
+	for _, s := range snippets {
+		if value, ok := interfaces.AttrValue(plug, s.attr, iface.plugAttrSchema); ok && reflect.DeepEqual(value, s.value) {
+			matching = append(matching, s.snippet)
+		}
+	}
+	return matching
+}
+
 func (iface *commonInterface) ServicePermanentPlug(plug *snap.PlugInfo) []interfaces.PlugServicesSnippet {
 	return iface.serviceSnippets
 }
@@ -144,6 +218,9 @@ func (iface *commonInterface) AppArmorConnectedPlug(spec *apparmor.Specification
 	if snippet := iface.connectedPlugAppArmor; snippet != "" {
 		spec.AddSnippet(snippet)
 	}
+	for _, snippet := range iface.plugAttrSnippets(plug, iface.connectedPlugAttrAppArmor) {
+		spec.AddSnippet(snippet)
+	}
 	if snippet := iface.connectedPlugUpdateNSAppArmor; snippet != "" {
 		spec.AddUpdateNS(snippet)
 	}
@@ -208,6 +285,9 @@ func (iface *commonInterface) SecCompConnectedPlug(spec *seccomp.Specification,
 	if iface.connectedPlugSecComp != "" {
 		spec.AddSnippet(iface.connectedPlugSecComp)
 	}
+	for _, snippet := range iface.plugAttrSnippets(plug, iface.connectedPlugAttrSecComp) {
+		spec.AddSnippet(snippet)
+	}
 	return nil
 }
 
@@ -217,6 +297,10 @@ func (iface *commonInterface) UDevConnectedPlug(spec *udev.Specification, plug *
 		spec.SetControlsDeviceCgroup()
 	} else {
 		for _, rule := range iface.connectedPlugUDev {
+			if len(iface.connectedPlugUDevDevices) > 0 {
+				spec.TagDeviceIfPresent(rule, iface.connectedPlugUDevDevices...)
+				continue
+			}
 			spec.TagDevice(rule)
 		}
 	}
@@ -224,6 +308,28 @@ func (iface *commonInterface) UDevConnectedPlug(spec *udev.Specification, plug *
 	return nil
 }
 
+// BeforeConnectPlug warns when none of the devices of an interface tagging
+// present devices only exists, the connection is not refused as the plug is
+// still granted its other permissions.
+func (iface *commonInterface) BeforeConnectPlug(plug *interfaces.ConnectedPlug) error {
+	devices := iface.connectedPlugUDevDevices
+	if len(devices) > 0 && !udev.DevicePresent(devices...) {
+		logger.Noticef("%s: no device matching %s is present, snap %q will not be given access to any device",
+			iface.name, strings.Join(devices, " or "), plug.Snap().InstanceName())
+	}
+	return nil
+}
+
+// PlugDevicePatterns returns the patterns of the device nodes tagged only when
+// present, see TagDeviceIfPresent.
+func (iface *commonInterface) PlugDevicePatterns() []string {
+	return iface.connectedPlugUDevDevices
+}
+
 func (iface *commonInterface) ConflictsWithOtherConnectedInterfaces() []string {
 	return iface.conflictingConnectedInterfaces
 }
+
+func (iface *commonInterface) DisconnectAfter() []string {
+	return iface.disconnectAfter
+}
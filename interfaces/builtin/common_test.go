@@ -28,6 +28,7 @@ import (
 	"github.com/snapcore/snapd/dirs"
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/seccomp"
 	"github.com/snapcore/snapd/interfaces/udev"
 	"github.com/snapcore/snapd/testutil"
 )
@@ -278,3 +279,100 @@ slots:
 	c.Assert(spec.AddConnectedPlug(iface, plug, slot), IsNil)
 	c.Assert(spec.ControlsDeviceCgroup(), Equals, true)
 }
+
+func (s *commonIfaceSuite) TestAttrSnippets(c *C) {
+	const consumerYaml = `
+name: consumer
+version: 0
+apps:
+  app:
+    plugs: [default, write, read-only]
+plugs:
+  default:
+    interface: common
+  write:
+    interface: common
+    mode: rw
+  read-only:
+    interface: common
+    mode: ro
+`
+	slot, _ := MockConnectedSlot(c, `
+name: producer
+version: 0
+slots:
+  common:
+`, nil, "common")
+
+	iface := &commonInterface{
+		name:                  "common",
+		connectedPlugAppArmor: "common-apparmor",
+		connectedPlugSecComp:  "common-seccomp",
+		plugAttrSchema: map[string]interfaces.AttrSpec{
+			"mode": {Type: interfaces.AttrString, Allowed: []any{"ro", "rw"}, Default: "ro"},
+		},
+		connectedPlugAttrAppArmor: []attrSnippet{
+			{attr: "mode", value: "ro", snippet: "ro-apparmor"},
+			{attr: "mode", value: "rw", snippet: "rw-apparmor"},
+		},
+		connectedPlugAttrSecComp: []attrSnippet{
+			{attr: "mode", value: "rw", snippet: "rw-seccomp"},
+		},
+	}
+
+	for _, t := range []struct {
+		plug     string
+		apparmor []string
+		seccomp  string
+	}{
+		// the default value of the attribute selects the snippets
+		{"default", []string{"common-apparmor", "ro-apparmor"}, "common-seccomp\n"},
+		{"read-only", []string{"common-apparmor", "ro-apparmor"}, "common-seccomp\n"},
+		{"write", []string{"common-apparmor", "rw-apparmor"}, "common-seccomp\nrw-seccomp\n"},
+	} {
+		plug, plugInfo := MockConnectedPlug(c, consumerYaml, nil, t.plug)
+		c.Assert(interfaces.BeforePreparePlug(iface, plugInfo), IsNil)
+
+		apparmorSpec := apparmor.NewSpecification(plug.AppSet())
+		c.Assert(apparmorSpec.AddConnectedPlug(iface, plug, slot), IsNil)
+		c.Check(apparmorSpec.Snippets()["snap.consumer.app"], DeepEquals, t.apparmor, Commentf("%s", t.plug))
+
+		seccompSpec := seccomp.NewSpecification(plug.AppSet())
+		c.Assert(seccompSpec.AddConnectedPlug(iface, plug, slot), IsNil)
+		c.Check(seccompSpec.SnippetForTag("snap.consumer.app"), Equals, t.seccomp, Commentf("%s", t.plug))
+	}
+}
+
+func (s *commonIfaceSuite) TestAttrSchemas(c *C) {
+	_, plugInfo := MockConnectedPlug(c, `
+name: consumer
+version: 0
+plugs:
+  common:
+    mode: wo
+`, nil, "common")
+	_, slotInfo := MockConnectedSlot(c, `
+name: producer
+version: 0
+slots:
+  common:
+    count: many
+`, nil, "common")
+
+	iface := &commonInterface{
+		name: "common",
+		plugAttrSchema: map[string]interfaces.AttrSpec{
+			"mode": {Type: interfaces.AttrString, Allowed: []any{"ro", "rw"}},
+		},
+		slotAttrSchema: map[string]interfaces.AttrSpec{
+			"count": {Type: interfaces.AttrInt},
+		},
+	}
+	c.Check(interfaces.BeforePreparePlug(iface, plugInfo), ErrorMatches, `common "mode" attribute must be one of "ro", "rw"`)
+	c.Check(interfaces.BeforePrepareSlot(iface, slotInfo), ErrorMatches, `common "count" attribute must be an integer`)
+
+	// without any schema all attributes are accepted
+	iface = &commonInterface{name: "common"}
+	c.Check(interfaces.BeforePreparePlug(iface, plugInfo), IsNil)
+	c.Check(interfaces.BeforePrepareSlot(iface, slotInfo), IsNil)
+}
@@ -19,10 +19,6 @@
 
 package builtin
 
-import (
-	"github.com/snapcore/snapd/release"
-)
-
 const contactsServiceSummary = `allows communication with Evolution Data Service Address Book`
 
 const contactsServiceBaseDeclarationSlots = `
@@ -151,10 +147,11 @@ owner @{HOME}/.cache/evolution/addressbook/[0-9a-f]*/*.jpeg r,
 
 func init() {
 	registerIface(&commonInterface{
-		name:                  "contacts-service",
-		summary:               contactsServiceSummary,
-		implicitOnClassic:     !(release.ReleaseInfo.ID == "ubuntu" && release.ReleaseInfo.VersionID == "14.04"),
-		baseDeclarationSlots:  contactsServiceBaseDeclarationSlots,
-		connectedPlugAppArmor: contactsServiceConnectedPlugAppArmor,
+		name:                         "contacts-service",
+		summary:                      contactsServiceSummary,
+		implicitOnClassic:            true,
+		implicitOnClassicMinVersions: map[string]string{"ubuntu": "16.04"},
+		baseDeclarationSlots:         contactsServiceBaseDeclarationSlots,
+		connectedPlugAppArmor:        contactsServiceConnectedPlugAppArmor,
 	})
 }
@@ -0,0 +1,144 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+)
+
+const containerRuntimeSupportSummary = `allows operating as a container runtime using FUSE, overlayfs, loop and device-mapper devices`
+
+const containerRuntimeSupportBaseDeclarationPlugs = `
+  container-runtime-support:
+    allow-installation: false
+    deny-auto-connection: true
+`
+
+const containerRuntimeSupportBaseDeclarationSlots = `
+  container-runtime-support:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const containerRuntimeSupportConnectedPlugAppArmor = `
+# Description: Can operate as a container runtime assembling the filesystems
+# of its containers from FUSE and overlay filesystems, loop devices and
+# device-mapper devices, like fuse-support, loop devices of block-devices and
+# dm-control would together. The filesystems can only be mounted to the
+# writable directories of the snap.
+
+# Required for mounts and for the device-mapper ioctls
+capability sys_admin,
+
+# FUSE filesystems, the safe defaults of fuse are used rather than the
+# options of /etc/fuse.conf, see fuse-support
+/dev/fuse rw,
+deny /etc/fuse.conf r,
+/sys/fs/fuse/ r,
+/sys/fs/fuse/** r,
+
+# Loop devices backing container images
+/dev/loop-control rw,
+/dev/loop[0-9]* rwk,
+/sys/devices/virtual/block/loop[0-9]*/{,**} r,
+
+# Device-mapper devices, eg. for thin provisioned container storage
+/dev/mapper/ r,
+/dev/mapper/control rw,
+/dev/dm-[0-9]* rwk,
+/sys/devices/virtual/block/dm-[0-9]*/{,**} r,
+
+# Mounting FUSE and overlay filesystems to the writable directories of the snap
+`
+
+const containerRuntimeSupportConnectedPlugSecComp = `
+# Description: Can mount FUSE and overlay filesystems to the writable
+# directories of the snap.
+
+%s
+%s
+%s
+`
+
+// containerRuntimeSupportMountTypes are the filesystem types and sources the
+// runtime can mount to the writable directories of the snap.
+var containerRuntimeSupportMountTypes = []struct{ fstype, source string }{
+	{"fuse.*", "**"},
+	{"overlay", "overlay"},
+}
+
+var containerRuntimeSupportConnectedPlugUDev = []string{
+	`KERNEL=="fuse"`,
+	`KERNEL=="loop-control"`,
+	`SUBSYSTEM=="block", KERNEL=="loop[0-9]*"`,
+	`KERNEL=="device-mapper"`,
+	`SUBSYSTEM=="block", KERNEL=="dm-[0-9]*"`,
+}
+
+var containerRuntimeSupportConnectedPlugKmod = []string{
+	"dm_mod",
+	"fuse",
+	"loop",
+	"overlay",
+}
+
+type containerRuntimeSupportInterface struct {
+	commonInterface
+}
+
+func (iface *containerRuntimeSupportInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	var buf bytes.Buffer
+	buf.WriteString(containerRuntimeSupportConnectedPlugAppArmor)
+	for _, mount := range containerRuntimeSupportMountTypes {
+		buf.WriteString(snapWritableMountRules(mount.fstype, mount.source))
+	}
+	for _, target := range snapWritableMountTargets {
+		fmt.Fprintf(&buf, "umount %s,\n", target)
+	}
+	spec.AddSnippet(buf.String())
+	return nil
+}
+
+func (iface *containerRuntimeSupportInterface) SecCompConnectedPlug(spec *seccomp.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	spec.AddSnippet(fmt.Sprintf(containerRuntimeSupportConnectedPlugSecComp, spec.PrivilegedRule("mount"), spec.PrivilegedRule("umount"), spec.PrivilegedRule("umount2")))
+	// the device-mapper ioctls are the ones of dm-control
+	spec.AddSnippet(dmControlConnectedPlugSecComp)
+	return nil
+}
+
+func init() {
+	registerIface(&containerRuntimeSupportInterface{commonInterface{
+		name:                     "container-runtime-support",
+		summary:                  containerRuntimeSupportSummary,
+		implicitOnCore:           true,
+		implicitOnClassic:        true,
+		baseDeclarationPlugs:     containerRuntimeSupportBaseDeclarationPlugs,
+		baseDeclarationSlots:     containerRuntimeSupportBaseDeclarationSlots,
+		connectedPlugKModModules: containerRuntimeSupportConnectedPlugKmod,
+		connectedPlugUDev:        containerRuntimeSupportConnectedPlugUDev,
+	}})
+}
@@ -0,0 +1,146 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/kmod"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type containerRuntimeSupportInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&containerRuntimeSupportInterfaceSuite{
+	iface: builtin.MustInterface("container-runtime-support"),
+})
+
+const containerRuntimeSupportConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [container-runtime-support]
+`
+
+const containerRuntimeSupportCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  container-runtime-support:
+`
+
+func (s *containerRuntimeSupportInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, containerRuntimeSupportConsumerYaml, nil, "container-runtime-support")
+	s.slot, s.slotInfo = MockConnectedSlot(c, containerRuntimeSupportCoreYaml, nil, "container-runtime-support")
+}
+
+func (s *containerRuntimeSupportInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "container-runtime-support")
+}
+
+func (s *containerRuntimeSupportInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *containerRuntimeSupportInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *containerRuntimeSupportInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "capability sys_admin,\n")
+	c.Check(snippet, testutil.Contains, "\n/dev/fuse rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/dev/loop-control rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/dev/mapper/control rw,\n")
+	c.Check(snippet, testutil.Contains, "\nmount fstype=overlay options=(rw,nosuid,nodev) overlay -> /var/snap/@{SNAP_NAME}/common/{,**/},\n")
+	c.Check(snippet, testutil.Contains, "\nmount fstype=fuse.* options=(ro,nosuid,nodev) ** -> /home/*/snap/@{SNAP_INSTANCE_NAME}/common/{,**/},\n")
+	c.Check(snippet, testutil.Contains, "\numount /var/snap/@{SNAP_NAME}/common/{,**/},\n")
+}
+
+func (s *containerRuntimeSupportInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\nmount\numount\numount2\n")
+	c.Check(snippet, testutil.Contains, "\nioctl - DM_TABLE_LOAD\n")
+	c.Check(snippet, Not(testutil.Contains), "DM_REMOVE_ALL\n")
+}
+
+func (s *containerRuntimeSupportInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 6)
+	for _, rule := range []string{
+		`KERNEL=="fuse"`,
+		`KERNEL=="loop-control"`,
+		`SUBSYSTEM=="block", KERNEL=="loop[0-9]*"`,
+		`KERNEL=="device-mapper"`,
+		`SUBSYSTEM=="block", KERNEL=="dm-[0-9]*"`,
+	} {
+		c.Check(spec.Snippets(), testutil.Contains, "# container-runtime-support\n"+rule+`, TAG+="snap_consumer_app"`)
+	}
+	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+}
+
+func (s *containerRuntimeSupportInterfaceSuite) TestKModSpec(c *C) {
+	spec := &kmod.Specification{}
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Modules(), DeepEquals, map[string]bool{
+		"dm_mod":  true,
+		"fuse":    true,
+		"loop":    true,
+		"overlay": true,
+	})
+}
+
+func (s *containerRuntimeSupportInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows operating as a container runtime using FUSE, overlayfs, loop and device-mapper devices`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "container-runtime-support")
+}
+
+func (s *containerRuntimeSupportInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *containerRuntimeSupportInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -0,0 +1,59 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const cpuidleControlSummary = `allows enabling and disabling CPU idle states`
+
+const cpuidleControlBaseDeclarationSlots = `
+  cpuidle-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const cpuidleControlConnectedPlugAppArmor = `
+# Description: Can read the CPU idle states and their statistics, and disable
+# or re-enable individual idle states of each CPU. See
+# https://www.kernel.org/doc/html/latest/admin-guide/pm/cpuidle.html
+
+# Enumerating the CPUs
+/sys/devices/system/cpu/ r,
+/sys/devices/system/cpu/{possible,present,online} r,
+
+# The cpuidle driver and governor in use
+/sys/devices/system/cpu/cpuidle/{,*} r,
+
+# The idle states of each CPU, disabling a state prevents the governor from
+# selecting it
+/sys/devices/system/cpu/cpu[0-9]*/cpuidle/{,**} r,
+/sys/devices/system/cpu/cpu[0-9]*/cpuidle/state[0-9]*/disable rw,
+`
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "cpuidle-control",
+		summary:               cpuidleControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  cpuidleControlBaseDeclarationSlots,
+		connectedPlugAppArmor: cpuidleControlConnectedPlugAppArmor,
+	})
+}
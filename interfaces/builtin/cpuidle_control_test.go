@@ -0,0 +1,113 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type cpuidleControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&cpuidleControlInterfaceSuite{
+	iface: builtin.MustInterface("cpuidle-control"),
+})
+
+const cpuidleControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [cpuidle-control]
+`
+
+const cpuidleControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  cpuidle-control:
+`
+
+func (s *cpuidleControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, cpuidleControlConsumerYaml, nil, "cpuidle-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, cpuidleControlCoreYaml, nil, "cpuidle-control")
+}
+
+func (s *cpuidleControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "cpuidle-control")
+}
+
+func (s *cpuidleControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *cpuidleControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+func (s *cpuidleControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/system/cpu/cpuidle/{,*} r,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/system/cpu/cpu[0-9]*/cpuidle/{,**} r,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/system/cpu/cpu[0-9]*/cpuidle/state[0-9]*/disable rw,\n")
+	// the other idle state files and the governor cannot be changed
+	c.Check(snippet, Not(testutil.Contains), "/cpuidle/{,**} rw,")
+	c.Check(snippet, Not(testutil.Contains), "current_governor w")
+}
+
+func (s *cpuidleControlInterfaceSuite) TestNoSecCompOrUDev(c *C) {
+	seccompSpec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(seccompSpec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(seccompSpec.Snippets(), HasLen, 0)
+
+	udevSpec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(udevSpec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(udevSpec.Snippets(), HasLen, 0)
+}
+
+func (s *cpuidleControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows enabling and disabling CPU idle states`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "cpuidle-control")
+}
+
+func (s *cpuidleControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *cpuidleControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -53,7 +53,9 @@ type daemoNotifyInterface struct {
 
 var osGetenv = os.Getenv
 
-func (iface *daemoNotifyInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+// notifySocketRule returns the apparmor rule allowing to send messages to
+// the notify socket of the service manager.
+func notifySocketRule() (string, error) {
 	// If the system has defined it, use NOTIFY_SOCKET from the environment. Note
 	// this is safe because it is examined on snapd start and snaps cannot manipulate
 	// the environment of snapd.
@@ -63,30 +65,34 @@ func (iface *daemoNotifyInterface) AppArmorConnectedPlug(spec *apparmor.Specific
 	}
 	if !strings.HasPrefix(notifySocket, "/") && !strings.HasPrefix(notifySocket, "@") {
 		// must be an absolute path or an abstract socket path
-		return fmt.Errorf("cannot use %q as notify socket path: not absolute", notifySocket)
+		return "", fmt.Errorf("cannot use %q as notify socket path: not absolute", notifySocket)
 	}
 	if err := apparmor_sandbox.ValidateNoAppArmorRegexp(notifySocket); err != nil {
-		return fmt.Errorf("cannot use %q as notify socket path: %s", notifySocket, err)
+		return "", fmt.Errorf("cannot use %q as notify socket path: %s", notifySocket, err)
 	}
 
-	var rule string
-
 	switch {
 	case strings.HasPrefix(notifySocket, "/"):
-		rule = fmt.Sprintf(`"%s" w`, notifySocket)
+		return fmt.Sprintf(`"%s" w`, notifySocket), nil
 	case strings.HasPrefix(notifySocket, "@/org/freedesktop/systemd1/notify/"):
 		// special case for Ubuntu 14.04 where the manpage states that
 		// /run/systemd/notify is used, but in fact the services get an
 		// abstract socket path such as
 		// @/org/freedesktop/systemd1/notify/13334051644891137417, the
 		// last part changing with each reboot
-		rule = `unix (connect, send) type=dgram peer=(label=unconfined,addr="@/org/freedesktop/systemd1/notify/[0-9]*")`
+		return `unix (connect, send) type=dgram peer=(label=unconfined,addr="@/org/freedesktop/systemd1/notify/[0-9]*")`, nil
 	case strings.HasPrefix(notifySocket, "@"):
-		rule = fmt.Sprintf(`unix (connect, send) type=dgram peer=(label=unconfined,addr="%s")`, notifySocket)
+		return fmt.Sprintf(`unix (connect, send) type=dgram peer=(label=unconfined,addr="%s")`, notifySocket), nil
 	default:
-		return fmt.Errorf("cannot use %q as notify socket path", notifySocket)
+		return "", fmt.Errorf("cannot use %q as notify socket path", notifySocket)
 	}
+}
 
+func (iface *daemoNotifyInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	rule, err := notifySocketRule()
+	if err != nil {
+		return err
+	}
 	snippet := strings.Replace(daemonNotifyConnectedPlugAppArmorTemplate,
 		"{{notify-socket-rule}}", rule, 1)
 	spec.AddSnippet(snippet)
@@ -21,7 +21,9 @@ package builtin
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 
@@ -218,6 +220,35 @@ dbus (receive, send)
     peer=(label=###SLOT_SECURITY_TAGS###),
 `
 
+const dbusConnectedSlotAppArmorScoped = `
+# allow snaps to introspect us. This allows clients to introspect all
+# DBus interfaces of this service (but not use them).
+dbus (receive)
+    bus=###DBUS_BUS###
+    interface=org.freedesktop.DBus.Introspectable
+    member=Introspect
+    peer=(label=###PLUG_SECURITY_TAGS###),
+
+# allow connected snaps to the declared object paths and interfaces only
+###DBUS_SCOPED_RULES###
+`
+
+const dbusConnectedPlugAppArmorScoped = `
+#include <abstractions/###DBUS_ABSTRACTION###>
+
+# allow snaps to introspect the slot service. This allows us to introspect
+# all DBus interfaces of the service (but not use them).
+dbus (send)
+    bus=###DBUS_BUS###
+    interface=org.freedesktop.DBus.Introspectable
+    member=Introspect
+    peer=(label=###SLOT_SECURITY_TAGS###),
+
+# allow connected snaps to the declared object paths and interfaces of
+# ###DBUS_NAME### only
+###DBUS_SCOPED_RULES###
+`
+
 type dbusInterface struct{}
 
 func (iface *dbusInterface) Name() string {
@@ -267,6 +298,86 @@ func (iface *dbusInterface) getAttribs(attribs interfaces.Attrer) (string, strin
 	return bus, name, nil
 }
 
+// DBus object paths, optionally ending with a /* or /** wildcard
+var validDBusScopePath = regexp.MustCompile(`^(/[A-Za-z0-9_]+)+(/\*|/\*\*)?$`).MatchString
+
+// DBus interface names, optionally ending with a .* wildcard
+var validDBusScopeInterface = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*(\.\*)?$`).MatchString
+
+// dbusScope describes the object paths and interfaces a connection to a
+// well-known name is restricted to. Empty lists mean that everything under
+// the well-known name is allowed.
+type dbusScope struct {
+	paths      []string
+	interfaces []string
+}
+
+func (scope *dbusScope) isEmpty() bool {
+	return len(scope.paths) == 0 && len(scope.interfaces) == 0
+}
+
+// Obtain yaml-specified object paths and interfaces which further restrict
+// the use of the well-known name. Wildcards cannot widen the scope beyond
+// the declared name.
+func (iface *dbusInterface) getScope(attribs interfaces.Attrer, name string) (*dbusScope, error) {
+	var scope dbusScope
+
+	if err := attribs.Attr("paths", &scope.paths); err != nil && !errors.Is(err, snap.AttributeNotFoundError{}) {
+		return nil, fmt.Errorf("cannot use attribute 'paths': %v", err)
+	} else if err == nil && len(scope.paths) == 0 {
+		return nil, fmt.Errorf("attribute 'paths' must not be empty")
+	}
+	namePath := "/" + strings.Replace(name, ".", "/", -1)
+	for _, path := range scope.paths {
+		if !validDBusScopePath(path) {
+			return nil, fmt.Errorf("invalid DBus object path %q", path)
+		}
+		if path != namePath && !strings.HasPrefix(path, namePath+"/") {
+			return nil, fmt.Errorf("DBus object path %q is not within %q", path, namePath)
+		}
+	}
+
+	if err := attribs.Attr("interfaces", &scope.interfaces); err != nil && !errors.Is(err, snap.AttributeNotFoundError{}) {
+		return nil, fmt.Errorf("cannot use attribute 'interfaces': %v", err)
+	} else if err == nil && len(scope.interfaces) == 0 {
+		return nil, fmt.Errorf("attribute 'interfaces' must not be empty")
+	}
+	for _, dbusIface := range scope.interfaces {
+		if !validDBusScopeInterface(dbusIface) {
+			return nil, fmt.Errorf("invalid DBus interface name %q", dbusIface)
+		}
+		if dbusIface != name && !strings.HasPrefix(dbusIface, name+".") {
+			return nil, fmt.Errorf("DBus interface %q is not within %q", dbusIface, name)
+		}
+	}
+
+	return &scope, nil
+}
+
+// Calculate the rules allowing access to the object paths and interfaces of
+// the scope, falling back to everything under the well-known name for the
+// list which was not specified.
+func getAppArmorScopedRules(scope *dbusScope, bus, name, peer string) string {
+	paths := scope.paths
+	if len(paths) == 0 {
+		paths = []string{"/" + strings.Replace(name, ".", "/", -1) + "{,/**}"}
+	}
+	ifaces := `"` + name + `{,.*}"`
+	if len(scope.interfaces) == 1 {
+		ifaces = `"` + scope.interfaces[0] + `"`
+	} else if len(scope.interfaces) > 1 {
+		ifaces = `"{` + strings.Join(scope.interfaces, ",") + `}"`
+	}
+
+	var buf bytes.Buffer
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "dbus (receive, send)\n    bus=%s\n    path=\"%s\"\n    interface=%s\n    peer=(label=%s),\n", bus, path, ifaces, peer)
+		// the standard interfaces are needed to use the objects at all
+		fmt.Fprintf(&buf, "dbus (receive, send)\n    bus=%s\n    path=\"%s\"\n    interface=org.freedesktop.DBus.{Properties,Peer}\n    peer=(label=%s),\n", bus, path, peer)
+	}
+	return buf.String()
+}
+
 // Determine AppArmor dbus abstraction to use based on bus
 func getAppArmorAbstraction(bus string) (string, error) {
 	var abstraction string
@@ -329,8 +440,27 @@ func (iface *dbusInterface) AppArmorConnectedPlug(spec *apparmor.Specification,
 		return nil
 	}
 
+	scope, err := iface.getScope(plug, name)
+	if err != nil {
+		return err
+	}
+	scopeSlot, err := iface.getScope(slot, nameSlot)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(scope, scopeSlot) {
+		return nil
+	}
+
 	// well-known DBus name-specific connected plug policy
-	snippet := getAppArmorSnippet(dbusConnectedPlugAppArmor, bus, name)
+	var snippet string
+	if scope.isEmpty() {
+		snippet = getAppArmorSnippet(dbusConnectedPlugAppArmor, bus, name)
+	} else {
+		snippet = getAppArmorSnippet(dbusConnectedPlugAppArmorScoped, bus, name)
+		rules := getAppArmorScopedRules(scope, bus, name, "###SLOT_SECURITY_TAGS###")
+		snippet = strings.Replace(snippet, "###DBUS_SCOPED_RULES###", rules, -1)
+	}
 
 	// abstraction policy
 	abstraction, err := getAppArmorAbstraction(bus)
@@ -419,8 +549,27 @@ func (iface *dbusInterface) AppArmorConnectedSlot(spec *apparmor.Specification,
 		return nil
 	}
 
+	scope, err := iface.getScope(slot, name)
+	if err != nil {
+		return err
+	}
+	scopePlug, err := iface.getScope(plug, namePlug)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(scope, scopePlug) {
+		return nil
+	}
+
 	// well-known DBus name-specific connected slot policy
-	snippet := getAppArmorSnippet(dbusConnectedSlotAppArmor, bus, name)
+	var snippet string
+	if scope.isEmpty() {
+		snippet = getAppArmorSnippet(dbusConnectedSlotAppArmor, bus, name)
+	} else {
+		snippet = getAppArmorSnippet(dbusConnectedSlotAppArmorScoped, bus, name)
+		rules := getAppArmorScopedRules(scope, bus, name, "###PLUG_SECURITY_TAGS###")
+		snippet = strings.Replace(snippet, "###DBUS_SCOPED_RULES###", rules, -1)
+	}
 
 	old := "###PLUG_SECURITY_TAGS###"
 	new := plug.LabelExpression()
@@ -430,12 +579,20 @@ func (iface *dbusInterface) AppArmorConnectedSlot(spec *apparmor.Specification,
 }
 
 func (iface *dbusInterface) BeforePreparePlug(plug *snap.PlugInfo) error {
-	_, _, err := iface.getAttribs(plug)
+	_, name, err := iface.getAttribs(plug)
+	if err != nil {
+		return err
+	}
+	_, err = iface.getScope(plug, name)
 	return err
 }
 
 func (iface *dbusInterface) BeforePrepareSlot(slot *snap.SlotInfo) error {
-	_, _, err := iface.getAttribs(slot)
+	_, name, err := iface.getAttribs(slot)
+	if err != nil {
+		return err
+	}
+	_, err = iface.getScope(slot, name)
 	return err
 }
 
@@ -20,6 +20,8 @@
 package builtin_test
 
 import (
+	"fmt"
+
 	. "gopkg.in/check.v1"
 
 	"github.com/snapcore/snapd/interfaces"
@@ -692,6 +694,199 @@ slots:
 	c.Assert(apparmorSpec.SecurityTags(), HasLen, 0)
 }
 
+func (s *DbusInterfaceSuite) TestSanitizeScope(c *C) {
+	const yamlTemplate = `name: slotter
+version: 1.0
+slots:
+ this:
+  interface: dbus
+  bus: system
+  name: org.example.foo
+  %s
+`
+	for _, t := range []struct {
+		attrs string
+		err   string
+	}{
+		{attrs: "paths: [/org/example/foo]"},
+		{attrs: "paths: [/org/example/foo/bar, /org/example/foo/baz/**]"},
+		{attrs: "paths: [/org/example/foo/*]"},
+		{attrs: "interfaces: [org.example.foo.Bar, org.example.foo]"},
+		{attrs: "interfaces: [org.example.foo.*]"},
+		{attrs: "paths: []", err: `attribute 'paths' must not be empty`},
+		{attrs: "paths: /org/example/foo", err: `cannot use attribute 'paths': .*`},
+		{attrs: "paths: [/org/example/**]", err: `DBus object path "/org/example/\*\*" is not within "/org/example/foo"`},
+		{attrs: "paths: [/org/example/foobar]", err: `DBus object path "/org/example/foobar" is not within "/org/example/foo"`},
+		{attrs: "paths: [/org/example/foo*]", err: `invalid DBus object path "/org/example/foo\*"`},
+		{attrs: "paths: [/org/example/foo/]", err: `invalid DBus object path "/org/example/foo/"`},
+		{attrs: "paths: [/org/*/foo]", err: `invalid DBus object path "/org/\*/foo"`},
+		{attrs: "interfaces: []", err: `attribute 'interfaces' must not be empty`},
+		{attrs: "interfaces: [org.example.*]", err: `DBus interface "org.example.\*" is not within "org.example.foo"`},
+		{attrs: "interfaces: [org.example.foobar]", err: `DBus interface "org.example.foobar" is not within "org.example.foo"`},
+		{attrs: "interfaces: [org.example.foo*]", err: `invalid DBus interface name "org.example.foo\*"`},
+		{attrs: "interfaces: [org.freedesktop.DBus.Properties]", err: `DBus interface "org.freedesktop.DBus.Properties" is not within "org.example.foo"`},
+	} {
+		info := snaptest.MockInfo(c, fmt.Sprintf(yamlTemplate, t.attrs), nil)
+		err := interfaces.BeforePrepareSlot(s.iface, info.Slots["this"])
+		if t.err == "" {
+			c.Check(err, IsNil, Commentf(t.attrs))
+		} else {
+			c.Check(err, ErrorMatches, t.err, Commentf(t.attrs))
+		}
+	}
+}
+
+func (s *DbusInterfaceSuite) TestConnectionScoped(c *C) {
+	const plugYaml = `name: plugger
+version: 1.0
+plugs:
+ this:
+  interface: dbus
+  bus: system
+  name: org.example.foo
+  paths: [/org/example/foo/Manager, /org/example/foo/devices/**]
+  interfaces: [org.example.foo.Manager]
+apps:
+ app:
+  plugs: [this]
+`
+	const slotYaml = `name: slotter
+version: 1.0
+slots:
+ this:
+  interface: dbus
+  bus: system
+  name: org.example.foo
+  paths: [/org/example/foo/Manager, /org/example/foo/devices/**]
+  interfaces: [org.example.foo.Manager]
+apps:
+ app:
+  slots: [this]
+`
+
+	slot, _ := MockConnectedSlot(c, slotYaml, nil, "this")
+	plug, _ := MockConnectedPlug(c, plugYaml, nil, "this")
+
+	apparmorSpec := apparmor.NewSpecification(plug.AppSet())
+	err := apparmorSpec.AddConnectedPlug(s.iface, plug, slot)
+	c.Assert(err, IsNil)
+	c.Assert(apparmorSpec.SecurityTags(), DeepEquals, []string{"snap.plugger.app"})
+	snippet := apparmorSpec.SnippetForTag("snap.plugger.app")
+	c.Check(snippet, testutil.Contains, `dbus (receive, send)
+    bus=system
+    path="/org/example/foo/Manager"
+    interface="org.example.foo.Manager"
+    peer=(label="snap.slotter.app"),
+dbus (receive, send)
+    bus=system
+    path="/org/example/foo/Manager"
+    interface=org.freedesktop.DBus.{Properties,Peer}
+    peer=(label="snap.slotter.app"),
+dbus (receive, send)
+    bus=system
+    path="/org/example/foo/devices/**"
+    interface="org.example.foo.Manager"
+    peer=(label="snap.slotter.app"),
+`)
+	// the unrestricted rules are not present
+	c.Check(snippet, Not(testutil.Contains), "peer=(name=org.example.foo")
+	c.Check(snippet, Not(testutil.Contains), `path="/org/example/foo{,/**}"`)
+	c.Check(snippet, Not(testutil.Contains), `interface="org.example.foo{,.*}"`)
+
+	apparmorSpec = apparmor.NewSpecification(slot.AppSet())
+	err = apparmorSpec.AddConnectedSlot(s.iface, plug, slot)
+	c.Assert(err, IsNil)
+	c.Assert(apparmorSpec.SecurityTags(), DeepEquals, []string{"snap.slotter.app"})
+	snippet = apparmorSpec.SnippetForTag("snap.slotter.app")
+	c.Check(snippet, testutil.Contains, `dbus (receive, send)
+    bus=system
+    path="/org/example/foo/devices/**"
+    interface="org.example.foo.Manager"
+    peer=(label="snap.plugger.app"),
+`)
+	c.Check(snippet, Not(testutil.Contains), `path="/org/example/foo{,/**}"`)
+}
+
+func (s *DbusInterfaceSuite) TestConnectionScopedDefaults(c *C) {
+	const plugYaml = `name: plugger
+version: 1.0
+plugs:
+ this:
+  interface: dbus
+  bus: session
+  name: org.example.foo
+  interfaces: [org.example.foo.Bar, org.example.foo.Baz]
+apps:
+ app:
+  plugs: [this]
+`
+	const slotYaml = `name: slotter
+version: 1.0
+slots:
+ this:
+  interface: dbus
+  bus: session
+  name: org.example.foo
+  interfaces: [org.example.foo.Bar, org.example.foo.Baz]
+apps:
+ app:
+  slots: [this]
+`
+
+	slot, _ := MockConnectedSlot(c, slotYaml, nil, "this")
+	plug, _ := MockConnectedPlug(c, plugYaml, nil, "this")
+
+	apparmorSpec := apparmor.NewSpecification(plug.AppSet())
+	err := apparmorSpec.AddConnectedPlug(s.iface, plug, slot)
+	c.Assert(err, IsNil)
+	c.Check(apparmorSpec.SnippetForTag("snap.plugger.app"), testutil.Contains, `dbus (receive, send)
+    bus=session
+    path="/org/example/foo{,/**}"
+    interface="{org.example.foo.Bar,org.example.foo.Baz}"
+    peer=(label="snap.slotter.app"),
+`)
+}
+
+func (s *DbusInterfaceSuite) TestConnectionMismatchScope(c *C) {
+	const plugYaml = `name: plugger
+version: 1.0
+plugs:
+ this:
+  interface: dbus
+  bus: session
+  name: org.example.foo
+  paths: [/org/example/foo/**]
+apps:
+ app:
+  plugs: [this]
+`
+	const slotYaml = `name: slotter
+version: 1.0
+slots:
+ this:
+  interface: dbus
+  bus: session
+  name: org.example.foo
+  paths: [/org/example/foo/Bar]
+apps:
+ app:
+  slots: [this]
+`
+
+	slot, _ := MockConnectedSlot(c, slotYaml, nil, "this")
+	plug, _ := MockConnectedPlug(c, plugYaml, nil, "this")
+
+	apparmorSpec := apparmor.NewSpecification(plug.AppSet())
+	err := apparmorSpec.AddConnectedPlug(s.iface, plug, slot)
+	c.Assert(err, IsNil)
+	c.Assert(apparmorSpec.SecurityTags(), HasLen, 0)
+
+	apparmorSpec = apparmor.NewSpecification(slot.AppSet())
+	err = apparmorSpec.AddConnectedSlot(s.iface, plug, slot)
+	c.Assert(err, IsNil)
+	c.Assert(apparmorSpec.SecurityTags(), HasLen, 0)
+}
+
 func (s *DbusInterfaceSuite) TestInterfaces(c *C) {
 	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
 }
@@ -0,0 +1,93 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+package builtin
+
+const dmControlSummary = `allows creating and managing device-mapper devices`
+
+const dmControlBaseDeclarationSlots = `
+  dm-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const dmControlConnectedPlugAppArmor = `
+# Description: Can create, load tables into, suspend, resume and remove
+# device-mapper devices through the control node, as done by LVM and
+# cryptsetup, and access the resulting devices.
+# See https://docs.kernel.org/admin-guide/device-mapper/index.html
+
+# the device-mapper ioctls require CAP_SYS_ADMIN
+capability sys_admin,
+
+/dev/mapper/ r,
+/dev/mapper/control rw,
+/dev/dm-[0-9]* rwk,
+
+# Device names, tables and dependencies are also exported in sysfs
+/sys/devices/virtual/block/dm-[0-9]*/{,**} r,
+`
+
+const dmControlConnectedPlugSecComp = `
+# Description: Can issue the device-mapper ioctls on the control node. Removing
+# all the device-mapper devices of the system at once with DM_REMOVE_ALL is not
+# allowed.
+
+ioctl - DM_VERSION
+ioctl - DM_LIST_DEVICES
+ioctl - DM_DEV_CREATE
+ioctl - DM_DEV_REMOVE
+ioctl - DM_DEV_RENAME
+ioctl - DM_DEV_SUSPEND
+ioctl - DM_DEV_STATUS
+ioctl - DM_DEV_WAIT
+ioctl - DM_TABLE_LOAD
+ioctl - DM_TABLE_CLEAR
+ioctl - DM_TABLE_DEPS
+ioctl - DM_TABLE_STATUS
+ioctl - DM_LIST_VERSIONS
+ioctl - DM_TARGET_MSG
+ioctl - DM_DEV_SET_GEOMETRY
+ioctl - DM_DEV_ARM_POLL
+ioctl - DM_GET_TARGET_VERSION
+`
+
+var dmControlConnectedPlugUDev = []string{
+	`KERNEL=="device-mapper"`,
+	`SUBSYSTEM=="block", KERNEL=="dm-[0-9]*"`,
+}
+
+var dmControlConnectedPlugKmod = []string{
+	"dm_mod",
+}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                     "dm-control",
+		summary:                  dmControlSummary,
+		implicitOnCore:           true,
+		implicitOnClassic:        true,
+		baseDeclarationSlots:     dmControlBaseDeclarationSlots,
+		connectedPlugAppArmor:    dmControlConnectedPlugAppArmor,
+		connectedPlugSecComp:     dmControlConnectedPlugSecComp,
+		connectedPlugKModModules: dmControlConnectedPlugKmod,
+		connectedPlugUDev:        dmControlConnectedPlugUDev,
+	})
+}
@@ -0,0 +1,118 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const dvbControlSummary = `allows control of DVB (Digital Video Broadcasting) tuners`
+
+const dvbControlBaseDeclarationSlots = `
+  dvb-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const dvbControlConnectedPlugAppArmor = `
+# Description: Can tune and control DVB (Digital Video Broadcasting)
+# adapters, including their frontend, demux, dvr, net and ca devices.
+# See https://www.kernel.org/doc/html/latest/userspace-api/media/dvb/
+
+/dev/dvb/ r,
+/dev/dvb/adapter[0-9]*/ r,
+/dev/dvb/adapter[0-9]*/{frontend,demux,dvr,net,ca}[0-9]* rw,
+
+# Enumerating adapters and their properties
+/sys/class/dvb/ r,
+/sys/devices/**/dvb/dvb[0-9]*.*/{,**} r,
+# From https://www.kernel.org/doc/Documentation/admin-guide/devices.txt
+/run/udev/data/c212:[0-9]* r,
+`
+
+const dvbControlConnectedPlugSecComp = `
+# Description: Can tune and control DVB (Digital Video Broadcasting)
+# adapters.
+
+# Tuning the frontend
+ioctl - FE_GET_INFO
+ioctl - FE_DISEQC_RESET_OVERLOAD
+ioctl - FE_DISEQC_SEND_MASTER_CMD
+ioctl - FE_DISEQC_RECV_SLAVE_REPLY
+ioctl - FE_DISEQC_SEND_BURST
+ioctl - FE_SET_TONE
+ioctl - FE_SET_VOLTAGE
+ioctl - FE_ENABLE_HIGH_LNB_VOLTAGE
+ioctl - FE_READ_STATUS
+ioctl - FE_READ_BER
+ioctl - FE_READ_SIGNAL_STRENGTH
+ioctl - FE_READ_SNR
+ioctl - FE_READ_UNCORRECTED_BLOCKS
+ioctl - FE_SET_FRONTEND
+ioctl - FE_GET_FRONTEND
+ioctl - FE_GET_EVENT
+ioctl - FE_DISHNETWORK_SEND_LEGACY_CMD
+ioctl - FE_SET_FRONTEND_TUNE_MODE
+ioctl - FE_SET_PROPERTY
+ioctl - FE_GET_PROPERTY
+
+# Filtering the transport stream with the demux, also used on the dvr device
+ioctl - DMX_START
+ioctl - DMX_STOP
+ioctl - DMX_SET_FILTER
+ioctl - DMX_SET_PES_FILTER
+ioctl - DMX_SET_BUFFER_SIZE
+ioctl - DMX_GET_PES_PIDS
+ioctl - DMX_GET_STC
+ioctl - DMX_ADD_PID
+ioctl - DMX_REMOVE_PID
+ioctl - DMX_REQBUFS
+ioctl - DMX_QUERYBUF
+ioctl - DMX_EXPBUF
+ioctl - DMX_QBUF
+ioctl - DMX_DQBUF
+
+# Conditional access modules
+ioctl - CA_RESET
+ioctl - CA_GET_CAP
+ioctl - CA_GET_SLOT_INFO
+ioctl - CA_GET_DESCR_INFO
+ioctl - CA_GET_MSG
+ioctl - CA_SEND_MSG
+ioctl - CA_SET_DESCR
+
+# Network interfaces for the data carried in the transport stream
+ioctl - NET_ADD_IF
+ioctl - NET_REMOVE_IF
+ioctl - NET_GET_IF
+`
+
+var dvbControlConnectedPlugUDev = []string{`SUBSYSTEM=="dvb"`}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "dvb-control",
+		summary:               dvbControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  dvbControlBaseDeclarationSlots,
+		connectedPlugAppArmor: dvbControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  dvbControlConnectedPlugSecComp,
+		connectedPlugUDev:     dvbControlConnectedPlugUDev,
+	})
+}
@@ -0,0 +1,102 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+)
+
+const ecryptfsMountControlSummary = `allows mounting ecryptfs filesystems to the writable directories of the snap`
+
+const ecryptfsMountControlBaseDeclarationSlots = `
+  ecryptfs-mount-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const ecryptfsMountControlConnectedPlugAppArmor = `
+# Description: Can stack ecryptfs filesystems on top of directories of the
+# snap, mounted to the writable directories of the snap. The mount key is
+# passed to the kernel through the keyring of the user.
+
+# Required for mounts
+capability sys_admin,
+
+# Mounting ecryptfs to the writable directories of the snap
+`
+
+// ecryptfsMountControlLowerDirs are the directories which the encrypted
+// files, presented decrypted at the mount point, may be stored in.
+const ecryptfsMountControlLowerDirs = "/{var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}},home/*/snap/@{SNAP_INSTANCE_NAME}}/**"
+
+const ecryptfsMountControlConnectedPlugSecComp = `
+# Description: Can mount ecryptfs filesystems to the writable directories of
+# the snap and manage the key of the mount in the kernel keyring.
+
+%s
+%s
+%s
+
+add_key
+keyctl
+request_key
+`
+
+var ecryptfsMountControlConnectedPlugKmod = []string{
+	"ecryptfs",
+}
+
+type ecryptfsMountControlInterface struct {
+	commonInterface
+}
+
+func (iface *ecryptfsMountControlInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	var buf bytes.Buffer
+	buf.WriteString(ecryptfsMountControlConnectedPlugAppArmor)
+	buf.WriteString(snapWritableMountRules("ecryptfs", ecryptfsMountControlLowerDirs))
+	for _, target := range snapWritableMountTargets {
+		fmt.Fprintf(&buf, "umount %s,\n", target)
+	}
+	spec.AddSnippet(buf.String())
+	return nil
+}
+
+func (iface *ecryptfsMountControlInterface) SecCompConnectedPlug(spec *seccomp.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	spec.AddSnippet(fmt.Sprintf(ecryptfsMountControlConnectedPlugSecComp, spec.PrivilegedRule("mount"), spec.PrivilegedRule("umount"), spec.PrivilegedRule("umount2")))
+	return nil
+}
+
+func init() {
+	registerIface(&ecryptfsMountControlInterface{commonInterface{
+		name:                     "ecryptfs-mount-control",
+		summary:                  ecryptfsMountControlSummary,
+		implicitOnCore:           true,
+		implicitOnClassic:        true,
+		baseDeclarationSlots:     ecryptfsMountControlBaseDeclarationSlots,
+		connectedPlugKModModules: ecryptfsMountControlConnectedPlugKmod,
+	}})
+}
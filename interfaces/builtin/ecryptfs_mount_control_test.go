@@ -0,0 +1,155 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/kmod"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type ecryptfsMountControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&ecryptfsMountControlInterfaceSuite{
+	iface: builtin.MustInterface("ecryptfs-mount-control"),
+})
+
+const ecryptfsMountControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [ecryptfs-mount-control]
+`
+
+const ecryptfsMountControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  ecryptfs-mount-control:
+`
+
+func (s *ecryptfsMountControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, ecryptfsMountControlConsumerYaml, nil, "ecryptfs-mount-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, ecryptfsMountControlCoreYaml, nil, "ecryptfs-mount-control")
+}
+
+func (s *ecryptfsMountControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "ecryptfs-mount-control")
+}
+
+func (s *ecryptfsMountControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *ecryptfsMountControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *ecryptfsMountControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "capability sys_admin,\n")
+	c.Check(snippet, testutil.Contains, "\nmount fstype=ecryptfs options=(rw,nosuid,nodev) /{var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}},home/*/snap/@{SNAP_INSTANCE_NAME}}/** -> /var/snap/@{SNAP_NAME}/common/{,**/},\n")
+	c.Check(snippet, testutil.Contains, "\nmount fstype=ecryptfs options=(ro,nosuid,nodev) /{var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}},home/*/snap/@{SNAP_INSTANCE_NAME}}/** -> /home/*/snap/@{SNAP_INSTANCE_NAME}/@{SNAP_REVISION}/{,**/},\n")
+	c.Check(snippet, testutil.Contains, "\numount /var/snap/@{SNAP_NAME}/common/{,**/},\n")
+}
+
+func (s *ecryptfsMountControlInterfaceSuite) TestAppArmorSpecMountRules(c *C) {
+	rules, err := builtin.ParseAppArmorRules(s.iface)
+	c.Assert(err, IsNil)
+	mounts := 0
+	for _, rule := range rules {
+		if rule.Kind != apparmor.MountRule {
+			continue
+		}
+		c.Check(apparmor.ValidateMountTargetSafe(rule), IsNil)
+		if !strings.HasPrefix(rule.Text, "mount ") {
+			continue
+		}
+		mounts++
+		// only ecryptfs stacked on the directories of the snap, with
+		// safe options
+		c.Check(rule.FSType, Equals, "ecryptfs")
+		c.Check(rule.Source, Equals, "/{var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}},home/*/snap/@{SNAP_INSTANCE_NAME}}/**")
+		c.Check(rule.Text, Matches, `.* options=\((ro|rw),nosuid,nodev\) .*`)
+	}
+	c.Check(mounts, Equals, 8)
+}
+
+func (s *ecryptfsMountControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\nmount\n")
+	c.Check(snippet, testutil.Contains, "\numount\n")
+	c.Check(snippet, testutil.Contains, "\numount2\n")
+	// the mount key is kept in the kernel keyring
+	c.Check(snippet, testutil.Contains, "\nadd_key\n")
+	c.Check(snippet, testutil.Contains, "\nkeyctl\n")
+	c.Check(snippet, testutil.Contains, "\nrequest_key\n")
+}
+
+func (s *ecryptfsMountControlInterfaceSuite) TestNoUDev(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.Snippets(), HasLen, 0)
+}
+
+func (s *ecryptfsMountControlInterfaceSuite) TestKModSpec(c *C) {
+	spec := &kmod.Specification{}
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Modules(), DeepEquals, map[string]bool{
+		"ecryptfs": true,
+	})
+}
+
+func (s *ecryptfsMountControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows mounting ecryptfs filesystems to the writable directories of the snap`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "ecryptfs-mount-control")
+}
+
+func (s *ecryptfsMountControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *ecryptfsMountControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
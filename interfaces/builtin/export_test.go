@@ -32,13 +32,30 @@ import (
 )
 
 var (
-	RegisterIface               = registerIface
-	ResolveSpecialVariable      = resolveSpecialVariable
-	ImplicitSystemPermanentSlot = implicitSystemPermanentSlot
-	ImplicitSystemConnectedSlot = implicitSystemConnectedSlot
-	StringListAttribute         = stringListAttribute
+	RegisterIface                = registerIface
+	RegisterIfaceAlias           = registerIfaceAlias
+	ResolveSpecialVariable       = resolveSpecialVariable
+	ImplicitSystemPermanentSlot  = implicitSystemPermanentSlot
+	ImplicitSystemConnectedSlot  = implicitSystemConnectedSlot
+	StringListAttribute          = stringListAttribute
+	SeccompSyscalls              = seccompSyscalls
+	PlugSecCompSnippets          = plugSecCompSnippets
+	IntrospectConnectionWithSlot = introspectConnectionWithSlot
+	IntrospectAppSlotSnapYaml    = introspectAppSlotSnapYaml
 )
 
+// SnapWritableMountRules returns the rules of snapWritableMountRules as
+// expanded by the apparmor specification of a snap which is not a parallel
+// instance.
+func SnapWritableMountRules(fstype, source string) string {
+	vars := &interfaces.SnippetVariables{
+		SnapName:     "@{SNAP_NAME}",
+		InstanceName: "@{SNAP_INSTANCE_NAME}",
+		Revision:     "@{SNAP_REVISION}",
+	}
+	return vars.Expand(snapWritableMountRules(fstype, source))
+}
+
 type GbmDriverLibsInterface gbmDriverLibsInterface
 
 func SymlinksUserIfaceFromGbmIface(iface interfaces.Interface) interfaces.SymlinksUser {
@@ -72,6 +89,12 @@ func Interface(name string) interfaces.Interface {
 	return allInterfaces[name]
 }
 
+func MockInterfaceAliases(aliases map[string]string) (restore func()) {
+	old := interfaceAliases
+	interfaceAliases = aliases
+	return func() { interfaceAliases = old }
+}
+
 // MustInterface returns the interface with the given name or panics.
 func MustInterface(name string) interfaces.Interface {
 	if iface, ok := allInterfaces[name]; ok {
@@ -163,6 +186,10 @@ func MockPolkitDaemonPaths(path1, path2 string) (restore func()) {
 	}
 }
 
+func MockSecCompCompilerLookup(lookup func(name string) (string, error)) (restore func()) {
+	return testutil.Mock(&secCompCompilerLookup, lookup)
+}
+
 func MockApparmorGenerateAAREExclusionPatterns(fn func(excludePatterns []string, opts *apparmor.AAREExclusionPatternsOptions) (string, error)) (restore func()) {
 	return testutil.Mock(&apparmorGenerateAAREExclusionPatterns, fn)
 }
@@ -0,0 +1,103 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+package builtin
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+)
+
+const ext4MountControlSummary = `allows mounting ext4 filesystem images to the writable directories of the snap`
+
+const ext4MountControlBaseDeclarationSlots = `
+  ext4-mount-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const ext4MountControlConnectedPlugAppArmor = `
+# Description: Can attach ext4 filesystem images to loop devices and mount
+# them to the writable directories of the snap. The images are provided by
+# the snap, mounting other block devices is not allowed.
+
+# Required for mounts
+capability sys_admin,
+
+# Setting up loop devices for the images
+/dev/loop-control rw,
+/dev/loop[0-9]* rw,
+
+# Mounting the images to the writable directories of the snap
+`
+
+const ext4MountControlConnectedPlugSecComp = `
+# Description: Can mount ext4 filesystem images to the writable directories of
+# the snap.
+
+%s
+%s
+%s
+`
+
+var ext4MountControlConnectedPlugUDev = []string{
+	`KERNEL=="loop-control"`,
+	`SUBSYSTEM=="block", KERNEL=="loop[0-9]*"`,
+}
+
+var ext4MountControlConnectedPlugKmod = []string{
+	"loop",
+}
+
+type ext4MountControlInterface struct {
+	commonInterface
+}
+
+func (iface *ext4MountControlInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	var buf bytes.Buffer
+	buf.WriteString(ext4MountControlConnectedPlugAppArmor)
+	buf.WriteString(snapWritableMountRules("ext4", "/dev/loop[0-9]*"))
+	for _, target := range snapWritableMountTargets {
+		fmt.Fprintf(&buf, "umount %s,\n", target)
+	}
+	spec.AddSnippet(buf.String())
+	return nil
+}
+
+func (iface *ext4MountControlInterface) SecCompConnectedPlug(spec *seccomp.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	spec.AddSnippet(fmt.Sprintf(ext4MountControlConnectedPlugSecComp, spec.PrivilegedRule("mount"), spec.PrivilegedRule("umount"), spec.PrivilegedRule("umount2")))
+	return nil
+}
+
+func init() {
+	registerIface(&ext4MountControlInterface{commonInterface{
+		name:                     "ext4-mount-control",
+		summary:                  ext4MountControlSummary,
+		implicitOnCore:           true,
+		implicitOnClassic:        true,
+		baseDeclarationSlots:     ext4MountControlBaseDeclarationSlots,
+		connectedPlugKModModules: ext4MountControlConnectedPlugKmod,
+		connectedPlugUDev:        ext4MountControlConnectedPlugUDev,
+	}})
+}
@@ -0,0 +1,155 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/kmod"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type ext4MountControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&ext4MountControlInterfaceSuite{
+	iface: builtin.MustInterface("ext4-mount-control"),
+})
+
+const ext4MountControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [ext4-mount-control]
+`
+
+const ext4MountControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  ext4-mount-control:
+`
+
+func (s *ext4MountControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, ext4MountControlConsumerYaml, nil, "ext4-mount-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, ext4MountControlCoreYaml, nil, "ext4-mount-control")
+}
+
+func (s *ext4MountControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "ext4-mount-control")
+}
+
+func (s *ext4MountControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *ext4MountControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *ext4MountControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "capability sys_admin,\n")
+	c.Check(snippet, testutil.Contains, "/dev/loop-control rw,\n")
+	c.Check(snippet, testutil.Contains, "\nmount fstype=ext4 options=(rw,nosuid,nodev) /dev/loop[0-9]* -> /var/snap/@{SNAP_NAME}/common/{,**/},\n")
+	c.Check(snippet, testutil.Contains, "\nmount fstype=ext4 options=(ro,nosuid,nodev) /dev/loop[0-9]* -> /home/*/snap/@{SNAP_INSTANCE_NAME}/@{SNAP_REVISION}/{,**/},\n")
+	c.Check(snippet, testutil.Contains, "\numount /var/snap/@{SNAP_NAME}/common/{,**/},\n")
+}
+
+func (s *ext4MountControlInterfaceSuite) TestAppArmorSpecMountRules(c *C) {
+	rules, err := builtin.ParseAppArmorRules(s.iface)
+	c.Assert(err, IsNil)
+	mounts := 0
+	for _, rule := range rules {
+		if rule.Kind != apparmor.MountRule || !strings.HasPrefix(rule.Text, "mount ") {
+			continue
+		}
+		mounts++
+		// only ext4 from loop devices, with safe options
+		c.Check(rule.FSType, Equals, "ext4")
+		c.Check(rule.Source, Equals, "/dev/loop[0-9]*")
+		c.Check(rule.Text, Matches, `.* options=\((ro|rw),nosuid,nodev\) .*`)
+		c.Check(rule.Target, Matches, `/(home/\*/snap/@\{SNAP_INSTANCE_NAME\}|var/snap/@\{SNAP_NAME\})/.*`)
+	}
+	c.Check(mounts, Equals, 8)
+}
+
+func (s *ext4MountControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\nmount\n")
+	c.Check(snippet, testutil.Contains, "\numount\n")
+	c.Check(snippet, testutil.Contains, "\numount2\n")
+}
+
+func (s *ext4MountControlInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 3)
+	c.Assert(spec.Snippets(), testutil.Contains, `# ext4-mount-control
+KERNEL=="loop-control", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, `# ext4-mount-control
+SUBSYSTEM=="block", KERNEL=="loop[0-9]*", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+}
+
+func (s *ext4MountControlInterfaceSuite) TestKModSpec(c *C) {
+	spec := &kmod.Specification{}
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Modules(), DeepEquals, map[string]bool{
+		"loop": true,
+	})
+}
+
+func (s *ext4MountControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows mounting ext4 filesystem images to the writable directories of the snap`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "ext4-mount-control")
+}
+
+func (s *ext4MountControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *ext4MountControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -20,38 +20,165 @@
 package builtin
 
 import (
-	"github.com/snapcore/snapd/release"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/mount"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/osutil"
+	apparmor_sandbox "github.com/snapcore/snapd/sandbox/apparmor"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/strutil"
 )
 
 const fuseSupportSummary = `allows access to the FUSE file system`
 
+// fuseSupportSecurityNotes are displayed by the store along with the
+// interface.
+var fuseSupportSecurityNotes = []string{
+	"Grants the CAP_SYS_ADMIN capability to mount FUSE filesystems, unless the plug uses the fusermount helper. The capability is also required by many other privileged operations.",
+	"With the unprivileged mode, the snap uses the fusermount helper and may create user namespaces to mount FUSE filesystems there, without the CAP_SYS_ADMIN capability.",
+	"Allows mounting FUSE filesystems over the writable directories of the snap and the directories it shares with other snaps, the content of the files there is then provided by the snap.",
+}
+
+// Gadget slots can present a host path to the plugs, see BeforePrepareSlot.
 const fuseSupportBaseDeclarationSlots = `
   fuse-support:
     allow-installation:
       slot-snap-type:
         - core
+        - gadget
     deny-auto-connection: true
 `
 
 const fuseSupportConnectedPlugSecComp = `
-# Description: Can run a FUSE filesystem. Unprivileged fuse mounts are
-# not supported at this time.
+# Description: Can run a FUSE filesystem. Unprivileged fuse mounts use the
+# fusermount helper when the plug sets the fusermount attribute.
 
-mount
+%s
 `
 
+// fuseSupportFusermountConnectedPlugSecComp is used when the plug sets the
+// "fusermount" attribute. The seccomp filter is inherited by the setuid helper
+// so it cannot drop the mount syscalls, the helper needs umount2 as well to
+// unmount with 'fusermount -u'.
+const fuseSupportFusermountConnectedPlugSecComp = `
+# Description: Can run a FUSE filesystem mounted through the fusermount
+# helper.
+
+%s
+%s
+`
+
+// fuseSupportUserNSConnectedPlugSecComp is added in the unprivileged mode when
+// AppArmor mediates the creation of user namespaces.
+const fuseSupportUserNSConnectedPlugSecComp = `
+# Description: Can create user namespaces to mount FUSE filesystems there.
+unshare
+`
+
+// fuseSupportMountSecCompRule only allows mounts with the MS_NOSUID and
+// MS_NODEV flags, the flags are the fourth argument of mount.
+const fuseSupportMountSecCompRule = "mount - - - |MS_NOSUID|MS_NODEV"
+
 const fuseSupportConnectedPlugAppArmor = `
-# Description: Can run a FUSE filesystem. Unprivileged fuse mounts are
-# not supported at this time.
+# Description: Can run a FUSE filesystem. Unprivileged fuse mounts use the
+# fusermount helper when the plug sets the fusermount attribute.
 
 # Allow communicating with fuse kernel driver
 # https://www.kernel.org/doc/Documentation/filesystems/fuse.txt
 /dev/fuse rw,
 
-# Required for mounts
+%s
+# Explicitly deny reads to /etc/fuse.conf. We do this to ensure that
+# the safe defaults of fuse are used (which are enforced by our mount
+# rules) and not system-specific options from /etc/fuse.conf that
+# may conflict with our mount rules.
+deny /etc/fuse.conf r,
+
+# Allow read access to the fuse filesystem
+/sys/fs/fuse/ r,
+/sys/fs/fuse/** r,
+
+# Allow the FUSE daemon to listen on a control socket, the directory of the
+# socket includes the instance key so that parallel instances of the snap get
+# sockets of their own. The key is empty when the snap is not a parallel
+# instance, the resulting double slash is collapsed by apparmor_parser.
+/run/fuse-support/{,@{SNAP_NAME}/,@{SNAP_NAME}/@{SNAP_INSTANCE_KEY}/} rw,
+/run/fuse-support/@{SNAP_NAME}/@{SNAP_INSTANCE_KEY}/control.sock rw,
+`
+
+// fuseSupportPrivilegedConnectedPlugAppArmor is used unless the plug sets the
+// "fusermount" attribute, the snap then mounts fuse filesystems by itself.
+const fuseSupportPrivilegedConnectedPlugAppArmor = `# Required for mounts
 capability sys_admin,
 
-# Allow mounts to our snap-specific writable directories
+%s`
+
+// fuseSupportFusermountExecConnectedPlugAppArmor is used instead when the plug
+// sets the "fusermount" attribute, mounts are left to the setuid helper which
+// runs under the fusermount child profile, see fuseSupportFusermountPath.
+const fuseSupportFusermountExecConnectedPlugAppArmor = `# Mounts are done by the fusermount helper, in its own child profile
+`
+
+// fuseSupportFusermountPath is the setuid helper shipped by the base.
+const fuseSupportFusermountPath = "/{,usr/}bin/fusermount{,3}"
+
+// fuseSupportUserNSConnectedPlugAppArmor is added in the unprivileged mode
+// when AppArmor mediates the creation of user namespaces, it is followed by
+// the mount rules of fuseSupportMountRules. The profile does not grant
+// CAP_SYS_ADMIN, the snap may only mount from the user namespaces it creates,
+// where the kernel checks the capability against the namespace.
+const fuseSupportUserNSConnectedPlugAppArmor = `
+# Description: Can create user namespaces to mount FUSE filesystems there.
+userns,
+
+%s`
+
+// fuseSupportUserNSMounts returns whether AppArmor mediates the creation of
+// user namespaces. Both the kernel and the parser features are part of the
+// system key, so that the profiles are regenerated when they change.
+func fuseSupportUserNSMounts() (bool, error) {
+	if apparmor_sandbox.ProbedLevel() == apparmor_sandbox.Unsupported {
+		return false, nil
+	}
+	kernelFeatures, err := apparmor_sandbox.KernelFeatures()
+	if err != nil {
+		return false, err
+	}
+	parserFeatures, err := apparmor_sandbox.ParserFeatures()
+	if err != nil {
+		return false, err
+	}
+	return strutil.ListContains(kernelFeatures, "namespaces") && strutil.ListContains(parserFeatures, "userns"), nil
+}
+
+// fuseSupportUnprivileged returns whether the plug uses the unprivileged
+// mode, set with "mode: unprivileged".
+func fuseSupportUnprivileged(plug *interfaces.ConnectedPlug) bool {
+	var mode string
+	_ = plug.Attr("mode", &mode)
+	return mode == "unprivileged"
+}
+
+// fuseSupportUsesFusermount returns whether the plug mounts through the
+// fusermount helper, either because it sets the "fusermount" attribute or
+// because it uses the unprivileged mode.
+func fuseSupportUsesFusermount(plug *interfaces.ConnectedPlug) bool {
+	var fusermount bool
+	_ = plug.Attr("fusermount", &fusermount)
+	return fusermount || fuseSupportUnprivileged(plug)
+}
+
+// fuseSupportMountConnectedPlugAppArmor is followed by the mount rules of
+// fuseSupportMountRules.
+const fuseSupportMountConnectedPlugAppArmor = `# Allow mounts to our snap-specific writable directories
 # Note 1: fstype is 'fuse.<command>', eg 'fuse.sshfs'
 # Note 2: due to LP: #1612393 - @{HOME} can't be used in mountpoint
 # Note 3: local fuse mounts of filesystem directories are mediated by
@@ -64,44 +191,516 @@ capability sys_admin,
 #         be very strict and only support the default (rw,nosuid,nodev) and
 #         read-only.
 #
-# parallel-installs: SNAP_USER_{DATA,COMMON} are not remapped, need to use SNAP_INSTANCE_NAME
-mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> /home/*/snap/@{SNAP_INSTANCE_NAME}/@{SNAP_REVISION}/{,**/},
-mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /home/*/snap/@{SNAP_INSTANCE_NAME}/@{SNAP_REVISION}/{,**/},
-mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> /home/*/snap/@{SNAP_INSTANCE_NAME}/common/{,**/},
-mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /home/*/snap/@{SNAP_INSTANCE_NAME}/common/{,**/},
-# parallel-installs: SNAP_{DATA,COMMON} are remapped, use SNAP_NAME instead, for
-# completeness allow SNAP_INSTANCE_NAME too
-mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/@{SNAP_REVISION}/{,**/},
-mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/@{SNAP_REVISION}/{,**/},
-mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/common/{,**/},
-mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/common/{,**/},
+# parallel-installs: SNAP_USER_{DATA,COMMON} are not remapped and use
+# SNAP_INSTANCE_NAME, SNAP_{DATA,COMMON} are remapped and use SNAP_NAME, the
+# directories of SNAP_INSTANCE_NAME are allowed too for parallel instances
+`
 
-# Explicitly deny reads to /etc/fuse.conf. We do this to ensure that
-# the safe defaults of fuse are used (which are enforced by our mount
-# rules) and not system-specific options from /etc/fuse.conf that
-# may conflict with our mount rules.
-deny /etc/fuse.conf r,
+// fuseSupportMountRules returns the mount rules of the plug, they are only
+// used under strict confinement, the profile of classic snaps does not
+// mediate mounts.
+func fuseSupportMountRules() string {
+	return fuseSupportMountConnectedPlugAppArmor + snapWritableMountRules("fuse.*", "**")
+}
 
-# Allow read access to the fuse filesystem
-/sys/fs/fuse/ r,
-/sys/fs/fuse/** r,
+// fuseSupportFusermountProfile is the child profile used when the plug sets
+// the "fusermount" attribute. Running the helper under its own profile keeps
+// the privileges needed by fusermount out of the main profile of the snap.
+const fuseSupportFusermountProfile = "fusermount"
+
+const fuseSupportFusermountConnectedPlugAppArmor = `
+# Description: Can transition to a child profile dedicated to fusermount.
+profile fusermount {
+  #include <abstractions/base>
+
+  /{,usr/}bin/fusermount{,3} mr,
+  /dev/fuse rw,
+  capability sys_admin,
+  deny /etc/fuse.conf r,
+
+  mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> ###SNAP_USER_DATA###/{,**/},
+  mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> ###SNAP_USER_DATA###/{,**/},
+  mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> ###SNAP_USER_COMMON###/{,**/},
+  mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> ###SNAP_USER_COMMON###/{,**/},
+  mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> ###SNAP_DATA###/{,**/},
+  mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> ###SNAP_DATA###/{,**/},
+  mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> ###SNAP_COMMON###/{,**/},
+  mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> ###SNAP_COMMON###/{,**/},
+  umount ###SNAP_USER_DATA###/{,**/},
+  umount ###SNAP_USER_COMMON###/{,**/},
+  umount ###SNAP_DATA###/{,**/},
+  umount ###SNAP_COMMON###/{,**/},
+}
+`
+
+// snapWritableMountTargets are the writable directories of a snap which
+// interfaces allow to mount filesystems to, starting with fuse-support.
+// The snap variables are expanded by the apparmor specification, taking
+// parallel instances into account.
+var snapWritableMountTargets = []string{
+	"###SNAP_USER_DATA###/{,**/}",
+	"###SNAP_USER_COMMON###/{,**/}",
+	"###SNAP_DATA###/{,**/}",
+	"###SNAP_COMMON###/{,**/}",
+}
+
+// snapWritableMountEntries returns the entries describing the mounts of the
+// given source with the given filesystem type to the writable directories of
+// the snap, read-only or read-write, both with nosuid and nodev.
+func snapWritableMountEntries(fstype, source string) []osutil.MountEntry {
+	var entries []osutil.MountEntry
+	for _, target := range snapWritableMountTargets {
+		for _, options := range [][]string{{"ro", "nosuid", "nodev"}, {"rw", "nosuid", "nodev"}} {
+			entries = append(entries, osutil.MountEntry{
+				Name:    source,
+				Dir:     target,
+				Type:    fstype,
+				Options: options,
+			})
+		}
+	}
+	return entries
+}
 
-# Unprivileged fuser mounts must use the setuid helper in the core snap
-# (not currently available, so don't include in policy at this time).
-#/{,usr/}bin/fusermount ixr,
+// snapWritableMountRules returns the apparmor rules allowing the mounts of
+// snapWritableMountEntries.
+func snapWritableMountRules(fstype, source string) string {
+	var buf bytes.Buffer
+	for _, entry := range snapWritableMountEntries(fstype, source) {
+		buf.WriteString(apparmor.MountRuleFromEntry(entry))
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// fuseSupportLayoutMountRules returns the rules allowing mounts to the paths
+// where the layouts of the snap present its writable directories in /var/snap,
+// as the mount points are mediated by the path seen in the mount namespace.
+// The fusermount child profile is left with the default mount points.
+func fuseSupportLayoutMountRules(spec *apparmor.Specification) string {
+	var paths []string
+	for _, dir := range []string{"$SNAP_DATA", "$SNAP_COMMON"} {
+		paths = append(paths, spec.LayoutPaths(dir)...)
+	}
+	if len(paths) == 0 {
+		return ""
+	}
+	sort.Strings(paths)
+	var buf bytes.Buffer
+	buf.WriteString("# Allow mounts to the layouts of our snap-specific writable directories\n")
+	for i, path := range paths {
+		if i > 0 && path == paths[i-1] {
+			continue
+		}
+		for _, options := range []string{"ro,nosuid,nodev", "rw,nosuid,nodev"} {
+			fmt.Fprintf(&buf, "mount fstype=fuse.* options=(%s) ** -> \"%s/{,**/}\",\n", options, path)
+		}
+	}
+	return buf.String()
+}
+
+// fuseSupportMountDirsRules returns the mount rules for the additional mount
+// targets listed by the "mount-dirs" plug attribute, if any.
+func fuseSupportMountDirsRules(plug *interfaces.ConnectedPlug) string {
+	var mountDirs []string
+	if err := plug.Attr("mount-dirs", &mountDirs); err != nil || len(mountDirs) == 0 {
+		return ""
+	}
+	return fuseSupportMountTargetRules("# Allow mounts to the additional directories of the plug\n", mountDirs)
+}
+
+// fuseSupportMountTargetRules returns the given comment followed by the rules
+// allowing mounts to the given directories and anywhere below them.
+func fuseSupportMountTargetRules(comment string, dirs []string) string {
+	var buf bytes.Buffer
+	buf.WriteString(comment)
+	for _, dir := range dirs {
+		for _, options := range [][]string{{"ro", "nosuid", "nodev"}, {"rw", "nosuid", "nodev"}} {
+			buf.WriteString(apparmor.MountRuleFromEntry(osutil.MountEntry{
+				Name:    "**",
+				Dir:     dir + "/{,**/}",
+				Type:    "fuse.*",
+				Options: options,
+			}))
+			buf.WriteString("\n")
+		}
+	}
+	return buf.String()
+}
+
+// fuseSupportAllowedMountDirs are the directories below which the plug may
+// list mount targets, the system directories, the ones of snapd and the ones
+// of the snaps are thus left out. The directories themselves cannot be mount
+// targets, such that other mounts there are not hidden.
+var fuseSupportAllowedMountDirs = []string{"/media", "/mnt", "/srv", "/run/user"}
+
+// validateFuseSupportMountDir checks an entry of the "mount-dirs" plug
+// attribute.
+func validateFuseSupportMountDir(dir string) error {
+	if !filepath.IsAbs(dir) || filepath.Clean(dir) != dir || dir == "/" {
+		return fmt.Errorf("fuse-support mount-dirs entry %q must be a clean, absolute path other than /", dir)
+	}
+	if strings.Contains(dir, "..") {
+		return fmt.Errorf("fuse-support mount-dirs entry %q cannot contain ..", dir)
+	}
+	if err := apparmor_sandbox.ValidateNoAppArmorRegexp(dir); err != nil {
+		return fmt.Errorf("fuse-support mount-dirs entry is invalid: %v", err)
+	}
+	if strings.ContainsAny(dir, " \t\n@") {
+		return fmt.Errorf("fuse-support mount-dirs entry %q cannot contain whitespace or @", dir)
+	}
+	for _, allowed := range fuseSupportAllowedMountDirs {
+		if strings.HasPrefix(dir, allowed+"/") {
+			return nil
+		}
+	}
+	return fmt.Errorf("fuse-support mount-dirs entry %q must be below one of %s", dir, strings.Join(fuseSupportAllowedMountDirs, ", "))
+}
+
+// BeforePreparePlug checks the entries of the optional "mount-dirs" plug
+// attribute, its type is checked by the attribute schema already.
+func (iface *fuseSupportInterface) BeforePreparePlug(plug *snap.PlugInfo) error {
+	mountDirs, ok := plug.Attrs["mount-dirs"].([]any)
+	if !ok {
+		return nil
+	}
+	if fusermount, _ := plug.Attrs["fusermount"].(bool); fusermount {
+		return fmt.Errorf("fuse-support mount-dirs attribute cannot be used along with fusermount")
+	}
+	if mode, _ := plug.Attrs["mode"].(string); mode == "unprivileged" {
+		return fmt.Errorf("fuse-support mount-dirs attribute cannot be used along with the unprivileged mode")
+	}
+	for _, entry := range mountDirs {
+		dir, ok := entry.(string)
+		if !ok {
+			return fmt.Errorf("fuse-support mount-dirs attribute must be a list of strings")
+		}
+		if err := validateFuseSupportMountDir(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const fuseSupportHostPathConnectedPlugAppArmor = `
+# Description: Can read the host path %[1]s, presented read-only in the
+# mount namespace of the snap, eg. as the source of a FUSE filesystem.
+%[1]s/{,**} r,
 `
 
 var fuseSupportConnectedPlugUDev = []string{`KERNEL=="fuse"`}
 
+// fuseSupportDeviceMajor and fuseSupportDeviceMinor are the device number of
+// /dev/fuse, the misc device minor is reserved to fuse by the kernel.
+const (
+	fuseSupportDeviceMajor = 10
+	fuseSupportDeviceMinor = 229
+)
+
+type fuseSupportInterface struct {
+	commonInterface
+}
+
+// AutoConnect only pairs the plug with a slot of the same snap, of the system
+// or of the gadget. The base declaration still denies the auto-connection, so
+// it has to be granted by a snap declaration, the plug of a snap with such a
+// grant then never auto-connects to the fuse-support slots of other snaps.
+func (iface *fuseSupportInterface) AutoConnect(plug *snap.PlugInfo, slot *snap.SlotInfo) bool {
+	if implicitSystemPermanentSlot(slot) || slot.Snap.Type() == snap.TypeGadget {
+		return true
+	}
+	return plug.Snap.InstanceName() == slot.Snap.InstanceName()
+}
+
+// AttributeSchema describes the optional plug attributes. The "hooks-only"
+// attribute limits the plug to the hooks of the snap, such that for instance
+// an install hook can mount a FUSE filesystem while the apps cannot. The
+// "mount-dirs" attribute lists additional mount targets, outside of the
+// writable directories of the snap. The "mode" attribute selects the
+// unprivileged mode, where mounts use the fusermount helper or a user
+// namespace created by the snap.
+func (iface *fuseSupportInterface) AttributeSchema() map[string]interfaces.AttrSpec {
+	return map[string]interfaces.AttrSpec{
+		"fusermount": {Type: interfaces.AttrBool, Description: "allow mounting through the fusermount helper"},
+		"hooks-only": {Type: interfaces.AttrBool, Description: "limit the plug to the hooks of the snap"},
+		"mode":       {Type: interfaces.AttrString, Allowed: []any{"privileged", "unprivileged"}, Description: "mount with privileges or as the user, through fusermount or a user namespace"},
+		"mount-dirs": {Type: interfaces.AttrList, Description: "additional mount targets below /media, /mnt, /srv or /run/user"},
+	}
+}
+
+// fuseSupportContentShareSlot returns the content slot of the given snap which
+// shares the given content, or nil. The content label defaults to the name of
+// the slot, the content interface may not have set it yet.
+func fuseSupportContentShareSlot(info *snap.Info, content string) *snap.SlotInfo {
+	names := make([]string, 0, len(info.Slots))
+	for name := range info.Slots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		slot := info.Slots[name]
+		if slot.Interface != "content" {
+			continue
+		}
+		label, ok := slot.Attrs["content"].(string)
+		if !ok || label == "" {
+			label = slot.Name
+		}
+		if label == content {
+			return slot
+		}
+	}
+	return nil
+}
+
+// fuseSupportContentShareWritePaths returns the writable paths shared by the
+// given content slot, they must be below $SNAP_DATA or $SNAP_COMMON.
+func fuseSupportContentShareWritePaths(slot *snap.SlotInfo) []string {
+	return (&contentInterface{}).path(slot, "write")
+}
+
+// validateFuseSupportContentShare checks the optional "content-share" slot
+// attribute, naming a content slot of the same snap the FUSE filesystems of
+// the plugs can be mounted to.
+func validateFuseSupportContentShare(slot *snap.SlotInfo, contentShare any) error {
+	content, ok := contentShare.(string)
+	if !ok || content == "" {
+		return fmt.Errorf("fuse-support content-share attribute must be a non-empty string")
+	}
+	if _, ok := slot.Attrs["host-path"]; ok {
+		return fmt.Errorf("fuse-support content-share attribute cannot be used along with host-path")
+	}
+	contentSlot := fuseSupportContentShareSlot(slot.Snap, content)
+	if contentSlot == nil {
+		return fmt.Errorf("fuse-support content-share attribute %q does not match any content slot of snap %q", content, slot.Snap.InstanceName())
+	}
+	paths := fuseSupportContentShareWritePaths(contentSlot)
+	if len(paths) == 0 {
+		return fmt.Errorf("fuse-support content-share attribute %q must match a content slot with write paths", content)
+	}
+	for _, path := range paths {
+		if !strings.HasPrefix(path, "$SNAP_DATA/") && !strings.HasPrefix(path, "$SNAP_COMMON/") {
+			return fmt.Errorf("fuse-support content-share write path %q must be in $SNAP_DATA or $SNAP_COMMON", path)
+		}
+		if err := validatePath(path); err != nil {
+			return err
+		}
+		if strings.ContainsAny(path, " \t\n@") {
+			return fmt.Errorf("fuse-support content-share write path %q cannot contain whitespace or @", path)
+		}
+	}
+	return nil
+}
+
+// fuseSupportAllowedHostPathDirs are the directories below which the slot may
+// present host paths. The host path is mounted over the same location of the
+// base, so the directories of the base, of snapd and of the snaps are left
+// out.
+var fuseSupportAllowedHostPathDirs = []string{"/media", "/mnt", "/srv"}
+
+// BeforePrepareSlot checks the optional "host-path" slot attribute, the host
+// directory presented read-only at the same location in the mount namespace
+// of the plug snaps. Only the system and the gadget can present host paths,
+// and only below fuseSupportAllowedHostPathDirs.
+// The optional "content-share" attribute is checked as well, see
+// validateFuseSupportContentShare.
+func (iface *fuseSupportInterface) BeforePrepareSlot(slot *snap.SlotInfo) error {
+	if contentShare, ok := slot.Attrs["content-share"]; ok {
+		if err := validateFuseSupportContentShare(slot, contentShare); err != nil {
+			return err
+		}
+	}
+	hostPath, ok := slot.Attrs["host-path"]
+	if !ok {
+		return nil
+	}
+	if t := slot.Snap.Type(); t != snap.TypeOS && t != snap.TypeSnapd && t != snap.TypeGadget {
+		return fmt.Errorf("fuse-support host-path attribute can only be used by core or gadget slots")
+	}
+	path, ok := hostPath.(string)
+	if !ok || !filepath.IsAbs(path) {
+		return fmt.Errorf("fuse-support host-path attribute must be an absolute path")
+	}
+	if filepath.Clean(path) != path || path == "/" {
+		return fmt.Errorf("fuse-support host-path attribute %q must be a clean path other than /", path)
+	}
+	if err := apparmor_sandbox.ValidateNoAppArmorRegexp(path); err != nil {
+		return fmt.Errorf("fuse-support host-path attribute is invalid: %v", err)
+	}
+	if strings.ContainsAny(path, " \t\n") {
+		return fmt.Errorf("fuse-support host-path attribute %q cannot contain whitespace", path)
+	}
+	for _, allowed := range fuseSupportAllowedHostPathDirs {
+		if strings.HasPrefix(path, allowed+"/") {
+			return nil
+		}
+	}
+	return fmt.Errorf("fuse-support host-path attribute %q must be below one of %s", path, strings.Join(fuseSupportAllowedHostPathDirs, ", "))
+}
+
+// fuseSupportContentShareDirs returns the directories shared by the content
+// slot named by the "content-share" slot attribute, as seen by the plug, if
+// any.
+func fuseSupportContentShareDirs(slot *interfaces.ConnectedSlot) []string {
+	var content string
+	if err := slot.Attr("content-share", &content); err != nil || content == "" {
+		return nil
+	}
+	contentSlot := fuseSupportContentShareSlot(slot.Snap(), content)
+	if contentSlot == nil {
+		return nil
+	}
+	var dirs []string
+	for _, path := range fuseSupportContentShareWritePaths(contentSlot) {
+		dirs = append(dirs, resolveSpecialVariable(path, slot.Snap()))
+	}
+	return dirs
+}
+
+// fuseSupportHostPath returns the host path presented by the slot, if any.
+func fuseSupportHostPath(slot *interfaces.ConnectedSlot) string {
+	var hostPath string
+	_ = slot.Attr("host-path", &hostPath)
+	return hostPath
+}
+
+// fuseSupportPlugMountRules returns the mount rules of fuseSupportMountRules,
+// or a note on why mounts are not mediated by the profile.
+func fuseSupportPlugMountRules(spec *apparmor.Specification) string {
+	if spec.Confinement() == snap.ClassicConfinement {
+		return "# Mounts are not mediated under classic confinement\n"
+	}
+	if !spec.SupportsFeature("mount") {
+		return "# Mounts are not mediated by the apparmor kernel of the system\n"
+	}
+	return fuseSupportMountRules()
+}
+
+func (iface *fuseSupportInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	fusermount := fuseSupportUsesFusermount(plug)
+
+	// A snap may have several fuse-support plugs with different attributes
+	// connected at the same time. The snippets are deduplicated so that the
+	// profile carries the union of the rules of all the plugs, each only once.
+	privileged := fmt.Sprintf(fuseSupportPrivilegedConnectedPlugAppArmor, fuseSupportPlugMountRules(spec))
+	if fusermount {
+		privileged = fuseSupportFusermountExecConnectedPlugAppArmor
+	}
+	spec.AddDeduplicatedSnippet(fmt.Sprintf(fuseSupportConnectedPlugAppArmor, privileged))
+	if !fusermount && spec.Confinement() != snap.ClassicConfinement {
+		// the rules are pointless when the kernel does not mediate
+		// mounts, the main snippet then carries a note instead
+		if layoutMounts := fuseSupportLayoutMountRules(spec); layoutMounts != "" {
+			spec.AddSnippetIfFeature("mount", layoutMounts)
+		}
+		if dirsMounts := fuseSupportMountDirsRules(plug); dirsMounts != "" {
+			spec.AddSnippetIfFeature("mount", dirsMounts)
+		}
+		// the fusermount child profile is static, content shares
+		// are only available to privileged mounts
+		if dirs := fuseSupportContentShareDirs(slot); len(dirs) > 0 {
+			spec.AddSnippetIfFeature("mount", fuseSupportMountTargetRules(
+				fmt.Sprintf("# Allow mounts to the directories shared by %s\n", slot.Ref()), dirs))
+		}
+	}
+
+	// 'mode: unprivileged' allows mounts from user namespaces too
+	if fuseSupportUnprivileged(plug) {
+		userns, err := fuseSupportUserNSMounts()
+		if err != nil {
+			return err
+		}
+		if userns {
+			spec.AddDeduplicatedSnippet(fmt.Sprintf(fuseSupportUserNSConnectedPlugAppArmor, fuseSupportPlugMountRules(spec)))
+		}
+	}
+
+	// 'fusermount: true' allows running the helper under a child profile
+	if fusermount {
+		if err := spec.AddExec(fuseSupportFusermountPath, apparmor.ExecChild, fuseSupportFusermountProfile); err != nil {
+			return err
+		}
+		spec.AddDeduplicatedSnippet(fuseSupportFusermountConnectedPlugAppArmor)
+	}
+
+	if hostPath := fuseSupportHostPath(slot); hostPath != "" {
+		spec.AddSnippet(fmt.Sprintf(fuseSupportHostPathConnectedPlugAppArmor, hostPath))
+		emit := spec.AddUpdateNSf
+		emit("  # Mount the host path %s read-only\n", hostPath)
+		emit("  mount options=(bind) /var/lib/snapd/hostfs%[1]s/ -> %[1]s/,\n", hostPath)
+		emit("  remount options=(bind, ro) %s/,\n", hostPath)
+		emit("  umount %s/,\n", hostPath)
+		// The mount target does not necessarily exist in the base, in
+		// which case a writable mimic is needed.
+		apparmor.GenWritableProfile(emit, hostPath, 1)
+	}
+	return nil
+}
+
+func (iface *fuseSupportInterface) MountConnectedPlug(spec *mount.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	if hostPath := fuseSupportHostPath(slot); hostPath != "" {
+		spec.AddMountEntry(osutil.MountEntry{
+			Name:    "/var/lib/snapd/hostfs" + hostPath,
+			Dir:     hostPath,
+			Options: []string{"bind", "ro"},
+		})
+	}
+	return nil
+}
+
+// UDevConnectedPlug tags the fuse device by its kernel name and allows it
+// explicitly by its device number too.
+func (iface *fuseSupportInterface) UDevConnectedPlug(spec *udev.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	if err := iface.commonInterface.UDevConnectedPlug(spec, plug, slot); err != nil {
+		return err
+	}
+	return spec.AllowDevice(udev.CharDevice, fuseSupportDeviceMajor, fuseSupportDeviceMinor)
+}
+
+func (iface *fuseSupportInterface) SecCompConnectedPlug(spec *seccomp.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	fusermount := fuseSupportUsesFusermount(plug)
+
+	// mount is logged in the diagnostic mode to find out whether it is used,
+	// like the AppArmor mount rules it requires the nosuid and nodev flags,
+	// which fusermount always sets for unprivileged users
+	mount := spec.PrivilegedRule(spec.ArgumentsRule(fuseSupportMountSecCompRule))
+	if fusermount {
+		spec.AddSnippet(fmt.Sprintf(fuseSupportFusermountConnectedPlugSecComp, mount, spec.PrivilegedRule("umount2")))
+		// errors are reported by the apparmor backend
+		if userns, _ := fuseSupportUserNSMounts(); userns && fuseSupportUnprivileged(plug) {
+			spec.AddSnippet(fuseSupportUserNSConnectedPlugSecComp)
+		}
+		return nil
+	}
+	spec.AddSnippet(fmt.Sprintf(fuseSupportConnectedPlugSecComp, mount))
+	return nil
+}
+
+const fuseSupportExample = `plugs:
+  fuse-support:
+    mount-dirs: [/srv/data]
+apps:
+  mounter:
+    plugs: [fuse-support]
+`
+
 func init() {
-	registerIface(&commonInterface{
-		name:                  "fuse-support",
-		summary:               fuseSupportSummary,
-		implicitOnCore:        true,
-		implicitOnClassic:     !(release.ReleaseInfo.ID == "ubuntu" && release.ReleaseInfo.VersionID == "14.04"),
-		baseDeclarationSlots:  fuseSupportBaseDeclarationSlots,
-		connectedPlugAppArmor: fuseSupportConnectedPlugAppArmor,
-		connectedPlugSecComp:  fuseSupportConnectedPlugSecComp,
-		connectedPlugUDev:     fuseSupportConnectedPlugUDev,
-	})
+	registerIface(&fuseSupportInterface{commonInterface{
+		name:                         "fuse-support",
+		summary:                      fuseSupportSummary,
+		securityNotes:                fuseSupportSecurityNotes,
+		example:                      fuseSupportExample,
+		implicitOnCore:               true,
+		implicitOnClassic:            true,
+		implicitOnClassicMinVersions: map[string]string{"ubuntu": "16.04"},
+		baseDeclarationSlots:         fuseSupportBaseDeclarationSlots,
+		connectedPlugUDev:            fuseSupportConnectedPlugUDev,
+		// the fuse module is not necessarily loaded at boot on minimal
+		// images, mounting a FUSE filesystem would then fail with ENODEV
+		connectedPlugKModModules: []string{"fuse"},
+		// FUSE filesystems such as ntfs-3g or exfat may be backed by
+		// block devices or files on removable media, the mounts need
+		// to go away before their source
+		disconnectAfter: []string{"block-devices", "removable-media"},
+	}})
 }
@@ -20,7 +20,10 @@
 package builtin_test
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	. "gopkg.in/check.v1"
 
@@ -28,14 +31,23 @@ import (
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/interfaces/apparmor"
 	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/ifacetest"
+	"github.com/snapcore/snapd/interfaces/kmod"
+	"github.com/snapcore/snapd/interfaces/mount"
 	"github.com/snapcore/snapd/interfaces/seccomp"
 	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/osutil"
 	"github.com/snapcore/snapd/release"
+	apparmor_sandbox "github.com/snapcore/snapd/sandbox/apparmor"
+	seccomp_sandbox "github.com/snapcore/snapd/sandbox/seccomp"
 	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/snap/snaptest"
 	"github.com/snapcore/snapd/testutil"
 )
 
 type FuseSupportInterfaceSuite struct {
+	testutil.BaseTest
+
 	iface    interfaces.Interface
 	slotInfo *snap.SlotInfo
 	slot     *interfaces.ConnectedSlot
@@ -57,54 +69,555 @@ slots:
   fuse-support:
 `
 
+const fuseSupportFusermountConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [fuse-support]
+plugs:
+ fuse-support:
+  fusermount: true
+`
+
 var _ = Suite(&FuseSupportInterfaceSuite{
 	iface: builtin.MustInterface("fuse-support"),
 })
 
 func (s *FuseSupportInterfaceSuite) SetUpTest(c *C) {
+	s.BaseTest.SetUpTest(c)
 	s.plug, s.plugInfo = MockConnectedPlug(c, fuseSupportConsumerYaml, nil, "fuse-support")
 	s.slot, s.slotInfo = MockConnectedSlot(c, fuseSupportCoreYaml, nil, "fuse-support")
 }
 
-func (s *FuseSupportInterfaceSuite) TestName(c *C) {
-	c.Assert(s.iface.Name(), Equals, "fuse-support")
+func (s *FuseSupportInterfaceSuite) TearDownTest(c *C) {
+	s.BaseTest.TearDownTest(c)
+}
+
+func (s *FuseSupportInterfaceSuite) TestStandardInterfaceTests(c *C) {
+	ifacetest.StandardInterfaceTests(c, ifacetest.Config{
+		Iface:            s.iface,
+		ExpectedAppArmor: []string{"\n/dev/fuse rw,\n"},
+		ExpectedSeccomp:  []string{"\nmount - - - |MS_NOSUID|MS_NODEV\n"},
+		ExpectedUDev: []string{`# fuse-support
+KERNEL=="fuse", TAG+="snap_consumer_app"`, `# fuse-support
+SUBSYSTEM!="block", ENV{MAJOR}=="10", ENV{MINOR}=="229", TAG+="snap_consumer_app"`},
+		AutoConnect: true,
+	})
+}
+
+func (s *FuseSupportInterfaceSuite) TestSanitizePlugWithFusermount(c *C) {
+	_, plugInfo := MockConnectedPlug(c, fuseSupportFusermountConsumerYaml, nil, "fuse-support")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), IsNil)
+}
+
+func (s *FuseSupportInterfaceSuite) TestSanitizePlugWithInvalidFusermount(c *C) {
+	const badFusermount = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [fuse-support]
+plugs:
+ fuse-support:
+  fusermount: yes-please
+`
+	_, plugInfo := MockConnectedPlug(c, badFusermount, nil, "fuse-support")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), ErrorMatches,
+		`fuse-support "fusermount" attribute must be a boolean`)
+}
+
+const fuseSupportMountDirsConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [fuse-support]
+plugs:
+ fuse-support:
+  mount-dirs: [%s]
+`
+
+func (s *FuseSupportInterfaceSuite) TestSanitizePlugWithMountDirs(c *C) {
+	_, plugInfo := MockConnectedPlug(c, fmt.Sprintf(fuseSupportMountDirsConsumerYaml, "/srv/data, /mnt/media"), nil, "fuse-support")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), IsNil)
+}
+
+func (s *FuseSupportInterfaceSuite) TestSanitizePlugWithInvalidMountDirs(c *C) {
+	for _, t := range []struct {
+		dirs string
+		err  string
+	}{
+		{"srv/data", `fuse-support mount-dirs entry "srv/data" must be a clean, absolute path other than /`},
+		{"/srv/data/", `fuse-support mount-dirs entry "/srv/data/" must be a clean, absolute path other than /`},
+		{"/srv/../etc", `fuse-support mount-dirs entry "/srv/../etc" must be a clean, absolute path other than /`},
+		{"/", `fuse-support mount-dirs entry "/" must be a clean, absolute path other than /`},
+		{"/srv/a..b", `fuse-support mount-dirs entry "/srv/a..b" cannot contain ..`},
+		{"/srv/*", `fuse-support mount-dirs entry is invalid: "/srv/\*" contains a reserved apparmor char from .*`},
+		{"'/srv/{a,b}'", `fuse-support mount-dirs entry is invalid: "/srv/{a,b}" contains a reserved apparmor char from .*`},
+		{"'/srv/my data'", `fuse-support mount-dirs entry "/srv/my data" cannot contain whitespace or @`},
+		{"'/srv/@{HOME}x'", `fuse-support mount-dirs entry is invalid: .*`},
+		{"/srv/@home", `fuse-support mount-dirs entry "/srv/@home" cannot contain whitespace or @`},
+		{"42", `fuse-support mount-dirs attribute must be a list of strings`},
+	} {
+		_, plugInfo := MockConnectedPlug(c, fmt.Sprintf(fuseSupportMountDirsConsumerYaml, t.dirs), nil, "fuse-support")
+		c.Check(interfaces.BeforePreparePlug(s.iface, plugInfo), ErrorMatches, t.err, Commentf("%s", t.dirs))
+	}
+
+	// only directories below the allowed ones can be mount targets
+	for _, dir := range []string{
+		"/etc", "/etc/fuse", "/proc/1", "/sys/fs/fuse", "/usr", "/usr/lib",
+		"/var/lib/snapd", "/var/lib/snapd/snap/core", "/var/snap/other", "/snap/core/current",
+		"/run", "/run/snapd", "/run/users/1000", "/boot", "/boot/efi", "/home/user",
+		"/media", "/mnt", "/srv", "/run/user", "/mediax/data", "/srvdata",
+	} {
+		_, plugInfo := MockConnectedPlug(c, fmt.Sprintf(fuseSupportMountDirsConsumerYaml, dir), nil, "fuse-support")
+		c.Check(interfaces.BeforePreparePlug(s.iface, plugInfo), ErrorMatches,
+			fmt.Sprintf(`fuse-support mount-dirs entry %q must be below one of /media, /mnt, /srv, /run/user`, dir), Commentf("%s", dir))
+	}
+
+	_, plugInfo := MockConnectedPlug(c, fmt.Sprintf(fuseSupportMountDirsConsumerYaml, "/media/data, /mnt/x, /srv/data/sub, /run/user/1000/fuse"), nil, "fuse-support")
+	c.Check(interfaces.BeforePreparePlug(s.iface, plugInfo), IsNil)
+}
+
+func (s *FuseSupportInterfaceSuite) TestSanitizePlugWithMountDirsNotAList(c *C) {
+	const badMountDirs = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [fuse-support]
+plugs:
+ fuse-support:
+  mount-dirs: /srv/data
+`
+	_, plugInfo := MockConnectedPlug(c, badMountDirs, nil, "fuse-support")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), ErrorMatches,
+		`fuse-support "mount-dirs" attribute must be a list`)
+}
+
+func (s *FuseSupportInterfaceSuite) TestSanitizePlugWithMountDirsAndFusermount(c *C) {
+	const mountDirsFusermount = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [fuse-support]
+plugs:
+ fuse-support:
+  fusermount: true
+  mount-dirs: [/srv/data]
+`
+	_, plugInfo := MockConnectedPlug(c, mountDirsFusermount, nil, "fuse-support")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), ErrorMatches,
+		`fuse-support mount-dirs attribute cannot be used along with fusermount`)
 }
 
-func (s *FuseSupportInterfaceSuite) TestSanitizeSlot(c *C) {
-	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecWithMountDirs(c *C) {
+	plug, _ := MockConnectedPlug(c, fmt.Sprintf(fuseSupportMountDirsConsumerYaml, "/srv/data, /mnt/media"), nil, "fuse-support")
+	spec := apparmor.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, `# Allow mounts to the additional directories of the plug
+mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> /srv/data/{,**/},
+mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /srv/data/{,**/},
+mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> /mnt/media/{,**/},
+mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /mnt/media/{,**/},
+`)
+
+	// the default rules are still there
+	c.Check(snippet, testutil.Contains, "\ncapability sys_admin,\n")
+	c.Check(snippet, testutil.Contains, builtin.SnapWritableMountRules("fuse.*", "**"))
+
+	// mounts are not mediated under classic confinement
+	const classicMountDirsConsumerYaml = `name: consumer
+version: 0
+confinement: classic
+apps:
+ app:
+  plugs: [fuse-support]
+plugs:
+ fuse-support:
+  mount-dirs: [/srv/data]
+`
+	plug, _ = MockConnectedPlug(c, classicMountDirsConsumerYaml, nil, "fuse-support")
+	spec = apparmor.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "/srv/data")
 }
 
-func (s *FuseSupportInterfaceSuite) TestSanitizePlug(c *C) {
-	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecWithoutMountMediation(c *C) {
+	// no mount mediation makes the apparmor support partial
+	restore := apparmor_sandbox.MockFeatures([]string{"caps", "file"}, nil, []string{"unsafe"}, nil)
+	defer restore()
+	c.Assert(apparmor_sandbox.ProbedLevel(), Equals, apparmor_sandbox.Partial)
+
+	plug, _ := MockConnectedPlug(c, fmt.Sprintf(fuseSupportMountDirsConsumerYaml, "/srv/data"), nil, "fuse-support")
+	spec := apparmor.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\ncapability sys_admin,\n\n# Mounts are not mediated by the apparmor kernel of the system\n")
+	c.Check(snippet, Not(testutil.Contains), "\nmount ")
+	c.Check(snippet, Not(testutil.Contains), "/srv/data")
+	c.Check(snippet, testutil.Contains, "\n/dev/fuse rw,\n")
+	c.Check(spec.DegradedFeatures(), DeepEquals, map[string][]string{
+		"mount": {"fuse-support"},
+	})
 }
 
-func (s *FuseSupportInterfaceSuite) TestAppArmorSpec(c *C) {
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecNoChangeProfileByDefault(c *C) {
 	appSet, err := interfaces.NewSnapAppSet(s.plug.Snap(), nil)
 	c.Assert(err, IsNil)
 	spec := apparmor.NewSpecification(appSet)
 	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), `change_profile`)
+	c.Assert(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), `profile fusermount {`)
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecWithFusermount(c *C) {
+	plug, _ := MockConnectedPlug(c, fuseSupportFusermountConsumerYaml, nil, "fuse-support")
+	appSet, err := interfaces.NewSnapAppSet(plug.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec := apparmor.NewSpecification(appSet)
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
 	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
 	c.Assert(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, `/dev/fuse`)
+	c.Assert(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "profile fusermount {\n")
+	c.Assert(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\n/{,usr/}bin/fusermount{,3} Cxr -> fusermount,\n")
+
+	// the main profile leaves the mounts to the helper
+	mainProfile := strings.Split(spec.SnippetForTag("snap.consumer.app"), "profile fusermount {")[0]
+	c.Check(mainProfile, Not(testutil.Contains), "capability sys_admin,")
+	c.Check(mainProfile, Not(testutil.Contains), "\nmount ")
+	// the exec transition is the only way into the child profile, the
+	// process cannot change to it on its own
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "change_profile")
 }
 
-func (s *FuseSupportInterfaceSuite) TestSecCompSpec(c *C) {
-	appSet, err := interfaces.NewSnapAppSet(s.plug.Snap(), nil)
-	c.Assert(err, IsNil)
-	spec := seccomp.NewSpecification(appSet)
-	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+func (s *FuseSupportInterfaceSuite) TestSecCompSpecWithFusermount(c *C) {
+	plug, _ := MockConnectedPlug(c, fuseSupportFusermountConsumerYaml, nil, "fuse-support")
+	spec := seccomp.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
 	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
-	c.Assert(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "mount\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\nmount - - - |MS_NOSUID|MS_NODEV\numount2\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "fusermount")
 }
 
-func (s *FuseSupportInterfaceSuite) TestUDevSpec(c *C) {
-	appSet, err := interfaces.NewSnapAppSet(s.plug.Snap(), nil)
+const fuseSupportUnprivilegedConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [fuse-support]
+plugs:
+ fuse-support:
+  mode: unprivileged
+`
+
+func (s *FuseSupportInterfaceSuite) TestSanitizePlugWithMode(c *C) {
+	_, plugInfo := MockConnectedPlug(c, fuseSupportUnprivilegedConsumerYaml, nil, "fuse-support")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), IsNil)
+
+	const badMode = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [fuse-support]
+plugs:
+ fuse-support:
+  mode: rootless
+`
+	_, plugInfo = MockConnectedPlug(c, badMode, nil, "fuse-support")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), ErrorMatches,
+		`fuse-support "mode" attribute must be one of "privileged", "unprivileged"`)
+
+	const withMountDirs = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [fuse-support]
+plugs:
+ fuse-support:
+  mode: unprivileged
+  mount-dirs: [/media/data]
+`
+	_, plugInfo = MockConnectedPlug(c, withMountDirs, nil, "fuse-support")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), ErrorMatches,
+		`fuse-support mount-dirs attribute cannot be used along with the unprivileged mode`)
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecUnprivileged(c *C) {
+	restore := apparmor_sandbox.MockLevel(apparmor_sandbox.Full)
+	defer restore()
+	restore = apparmor_sandbox.MockFeatures([]string{"namespaces"}, nil, []string{"userns"}, nil)
+	defer restore()
+
+	plug, _ := MockConnectedPlug(c, fuseSupportUnprivilegedConsumerYaml, nil, "fuse-support")
+	spec := apparmor.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	// mounts can go through the fusermount helper
+	c.Check(snippet, testutil.Contains, "profile fusermount {\n")
+	c.Check(snippet, testutil.Contains, "\n/{,usr/}bin/fusermount{,3} Cxr -> fusermount,\n")
+	// or be done from a user namespace, without the capability
+	mainProfile := strings.Split(snippet, "profile fusermount {")[0]
+	c.Check(mainProfile, testutil.Contains, "# Description: Can create user namespaces to mount FUSE filesystems there.\nuserns,\n")
+	c.Check(mainProfile, testutil.Contains, "\nmount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /var/snap/@{SNAP_NAME}/common/{,**/},\n")
+	c.Check(mainProfile, Not(testutil.Contains), "capability sys_admin,")
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecUnprivilegedNoUserNSMediation(c *C) {
+	restore := apparmor_sandbox.MockLevel(apparmor_sandbox.Full)
+	defer restore()
+
+	for _, t := range []struct {
+		kernel, parser []string
+	}{
+		{kernel: []string{"namespaces"}},
+		{parser: []string{"userns"}},
+		{},
+	} {
+		restore = apparmor_sandbox.MockFeatures(t.kernel, nil, t.parser, nil)
+		defer restore()
+
+		plug, _ := MockConnectedPlug(c, fuseSupportUnprivilegedConsumerYaml, nil, "fuse-support")
+		spec := apparmor.NewSpecification(plug.AppSet())
+		c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+		snippet := spec.SnippetForTag("snap.consumer.app")
+		// only the fusermount helper can mount
+		c.Check(snippet, testutil.Contains, "profile fusermount {\n")
+		mainProfile := strings.Split(snippet, "profile fusermount {")[0]
+		c.Check(mainProfile, Not(testutil.Contains), "capability sys_admin,")
+		c.Check(mainProfile, Not(testutil.Contains), "\nmount ")
+		c.Check(mainProfile, Not(testutil.Contains), "userns,")
+	}
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecUnprivilegedFeaturesError(c *C) {
+	restore := apparmor_sandbox.MockLevel(apparmor_sandbox.Full)
+	defer restore()
+	restore = apparmor_sandbox.MockFeatures(nil, nil, nil, errors.New("cannot probe parser"))
+	defer restore()
+
+	plug, _ := MockConnectedPlug(c, fuseSupportUnprivilegedConsumerYaml, nil, "fuse-support")
+	spec := apparmor.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), ErrorMatches, "cannot probe parser")
+}
+
+func (s *FuseSupportInterfaceSuite) TestSecCompSpecUnprivileged(c *C) {
+	restore := apparmor_sandbox.MockLevel(apparmor_sandbox.Full)
+	defer restore()
+	restore = apparmor_sandbox.MockFeatures([]string{"namespaces"}, nil, []string{"userns"}, nil)
+	defer restore()
+
+	plug, _ := MockConnectedPlug(c, fuseSupportUnprivilegedConsumerYaml, nil, "fuse-support")
+	spec := seccomp.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\nmount - - - |MS_NOSUID|MS_NODEV\numount2\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\nunshare\n")
+
+	restore = apparmor_sandbox.MockFeatures([]string{"namespaces"}, nil, nil, nil)
+	defer restore()
+	spec = seccomp.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\nmount - - - |MS_NOSUID|MS_NODEV\numount2\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "unshare")
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecMultiplePlugs(c *C) {
+	const multiPlugConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [fuse-plain, fuse-fusermount, fuse-fusermount-again]
+plugs:
+ fuse-plain:
+  interface: fuse-support
+ fuse-fusermount:
+  interface: fuse-support
+  fusermount: true
+ fuse-fusermount-again:
+  interface: fuse-support
+  fusermount: true
+`
+	plain, _ := MockConnectedPlug(c, multiPlugConsumerYaml, nil, "fuse-plain")
+	withFusermount, _ := MockConnectedPlug(c, multiPlugConsumerYaml, nil, "fuse-fusermount")
+	withFusermountAgain, _ := MockConnectedPlug(c, multiPlugConsumerYaml, nil, "fuse-fusermount-again")
+	appSet, err := interfaces.NewSnapAppSet(plain.Snap(), nil)
 	c.Assert(err, IsNil)
-	spec := udev.NewSpecification(appSet)
+	spec := apparmor.NewSpecification(appSet)
+	for _, plug := range []*interfaces.ConnectedPlug{plain, withFusermount, withFusermountAgain} {
+		c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	}
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+
+	// the profile carries the rules of all the plugs, the plugs with the
+	// same attributes contribute them only once
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(strings.Count(snippet, "\n/dev/fuse rw,\n"), Equals, 2)
+	c.Check(strings.Count(snippet, "\ncapability sys_admin,\n"), Equals, 1)
+	c.Check(strings.Count(snippet, "\n/{,usr/}bin/fusermount{,3} Cxr -> fusermount,\n"), Equals, 1)
+	c.Check(strings.Count(snippet, "profile fusermount {\n"), Equals, 1)
+}
+
+func (s *FuseSupportInterfaceSuite) TestUDevSpecHooks(c *C) {
+	const hooksConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [fuse-support]
+ other:
+hooks:
+ install:
+  plugs: [fuse-support]
+plugs:
+ fuse-support:
+`
+	plug, _ := MockConnectedPlug(c, hooksConsumerYaml, nil, "fuse-support")
+	spec := udev.NewSpecification(plug.AppSet())
+	// the same connection seen twice does not duplicate the rules
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 6)
+	for _, tag := range []string{"snap_consumer_app", "snap_consumer_hook_install"} {
+		c.Check(spec.Snippets(), testutil.Contains, fmt.Sprintf("# fuse-support\nKERNEL==\"fuse\", TAG+=\"%s\"", tag))
+		c.Check(spec.Snippets(), testutil.Contains, fmt.Sprintf("# fuse-support\nSUBSYSTEM!=\"block\", ENV{MAJOR}==\"10\", ENV{MINOR}==\"229\", TAG+=\"%s\"", tag))
+		c.Check(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="%[1]s", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%[2]s/snap-device-helper $env{ACTION} %[1]s $devpath $major:$minor"`, tag, dirs.DistroLibExecDir))
+	}
+}
+
+func (s *FuseSupportInterfaceSuite) TestUDevSpecHookOnly(c *C) {
+	const hookOnlyConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+hooks:
+ configure:
+  plugs: [fuse-support]
+`
+	plug, _ := MockConnectedPlug(c, hookOnlyConsumerYaml, nil, "fuse-support")
+	spec := udev.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 3)
+	c.Check(spec.Snippets(), testutil.Contains, "# fuse-support\nKERNEL==\"fuse\", TAG+=\"snap_consumer_hook_configure\"")
+}
+
+func (s *FuseSupportInterfaceSuite) TestUDevSpecDeviceNumbers(c *C) {
+	// the device number is fixed, there is no need for /dev/fuse to exist
+	dirs.SetRootDir(c.MkDir())
+	defer dirs.SetRootDir("")
+
+	spec := udev.NewSpecification(s.plug.AppSet())
 	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
-	c.Assert(spec.Snippets(), HasLen, 2)
-	c.Assert(spec.Snippets(), testutil.Contains, `# fuse-support
+	c.Assert(spec.Snippets(), HasLen, 3)
+	// the device is still matched by its kernel name
+	c.Check(spec.Snippets(), testutil.Contains, "# fuse-support\nKERNEL==\"fuse\", TAG+=\"snap_consumer_app\"")
+	c.Check(spec.Snippets(), testutil.Contains, "# fuse-support\nSUBSYSTEM!=\"block\", ENV{MAJOR}==\"10\", ENV{MINOR}==\"229\", TAG+=\"snap_consumer_app\"")
+	c.Check(spec.DeviceRules(), DeepEquals, map[string][]udev.DeviceRule{
+		"snap.consumer.app": {{Type: udev.CharDevice, Major: 10, Minor: 229}},
+	})
+}
+
+func (s *FuseSupportInterfaceSuite) TestKModSpec(c *C) {
+	spec := &kmod.Specification{}
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.Modules(), DeepEquals, map[string]bool{"fuse": true})
+}
+
+func (s *FuseSupportInterfaceSuite) TestKModSpecMultiplePlugs(c *C) {
+	const multiPlugConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [fuse-one, fuse-two]
+plugs:
+ fuse-one:
+  interface: fuse-support
+ fuse-two:
+  interface: fuse-support
+`
+	one, _ := MockConnectedPlug(c, multiPlugConsumerYaml, nil, "fuse-one")
+	two, _ := MockConnectedPlug(c, multiPlugConsumerYaml, nil, "fuse-two")
+	spec := &kmod.Specification{}
+	c.Assert(spec.AddConnectedPlug(s.iface, one, s.slot), IsNil)
+	c.Assert(spec.AddConnectedPlug(s.iface, two, s.slot), IsNil)
+	// the module is loaded once for the snap
+	c.Check(spec.Modules(), DeepEquals, map[string]bool{"fuse": true})
+}
+
+func (s *FuseSupportInterfaceSuite) TestHooksOnly(c *C) {
+	const hooksOnlyConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [fuse-support]
+hooks:
+ install:
+  plugs: [fuse-support]
+plugs:
+ fuse-support:
+  hooks-only: true
+`
+	plug, plugInfo := MockConnectedPlug(c, hooksOnlyConsumerYaml, nil, "fuse-support")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), IsNil)
+	appSet, err := interfaces.NewSnapAppSet(plug.Snap(), nil)
+	c.Assert(err, IsNil)
+
+	apparmorSpec := apparmor.NewSpecification(appSet)
+	c.Assert(apparmorSpec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Assert(apparmorSpec.SecurityTags(), DeepEquals, []string{"snap.consumer.hook.install"})
+	c.Assert(apparmorSpec.SnippetForTag("snap.consumer.hook.install"), testutil.Contains, `/dev/fuse`)
+
+	seccompSpec := seccomp.NewSpecification(appSet)
+	c.Assert(seccompSpec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Assert(seccompSpec.SecurityTags(), DeepEquals, []string{"snap.consumer.hook.install"})
+
+	udevSpec := udev.NewSpecification(appSet)
+	c.Assert(udevSpec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Assert(udevSpec.Snippets(), testutil.Contains, `# fuse-support
+KERNEL=="fuse", TAG+="snap_consumer_hook_install"`)
+	c.Assert(udevSpec.Snippets(), Not(testutil.Contains), `# fuse-support
 KERNEL=="fuse", TAG+="snap_consumer_app"`)
-	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+}
+
+func (s *FuseSupportInterfaceSuite) TestSanitizePlugWithInvalidHooksOnly(c *C) {
+	const badHooksOnly = `name: consumer
+version: 0
+hooks:
+ install:
+  plugs: [fuse-support]
+plugs:
+ fuse-support:
+  hooks-only: "yes"
+`
+	_, plugInfo := MockConnectedPlug(c, badHooksOnly, nil, "fuse-support")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), ErrorMatches,
+		`fuse-support "hooks-only" attribute must be a boolean`)
+}
+
+func (s *FuseSupportInterfaceSuite) TestSecCompSpecNoArgumentFiltering(c *C) {
+	// the backend cannot tell that snap-seccomp filters arguments reliably
+	// without its version information, mount is then allowed unconstrained
+	backend := &seccomp.Backend{}
+	fusermountPlug, _ := MockConnectedPlug(c, fuseSupportFusermountConsumerYaml, nil, "fuse-support")
+	for _, plug := range []*interfaces.ConnectedPlug{s.plug, fusermountPlug} {
+		spec := backend.NewSpecification(plug.AppSet(), interfaces.ConfinementOptions{}).(*seccomp.Specification)
+		c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+		snippet := spec.SnippetForTag("snap.consumer.app")
+		c.Check(snippet, testutil.Contains, "\nmount\n")
+		c.Check(snippet, Not(testutil.Contains), "MS_NOSUID")
+	}
+}
+
+func (s *FuseSupportInterfaceSuite) TestSecCompSpecLogPrivilegedSyscalls(c *C) {
+	restore := seccomp_sandbox.MockActions([]string{"allow", "errno", "log"})
+	defer restore()
+
+	// the backend is not initialized, arguments are not filtered
+	backend := &seccomp.Backend{}
+	spec := backend.NewSpecification(s.plug.AppSet(), interfaces.ConfinementOptions{LogPrivilegedSyscalls: true}).(*seccomp.Specification)
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\n?mount\n")
+
+	// the mount syscall is allowed again once the mode is disabled
+	spec = backend.NewSpecification(s.plug.AppSet(), interfaces.ConfinementOptions{}).(*seccomp.Specification)
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\nmount\n")
+	c.Assert(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "?mount")
 }
 
 func (s *FuseSupportInterfaceSuite) TestStaticInfo(c *C) {
@@ -115,8 +628,465 @@ func (s *FuseSupportInterfaceSuite) TestStaticInfo(c *C) {
 	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "fuse-support")
 }
 
-func (s *FuseSupportInterfaceSuite) TestAutoConnect(c *C) {
-	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+func (s *FuseSupportInterfaceSuite) TestStaticInfoSecurityNotes(c *C) {
+	notes := interfaces.StaticInfoOf(s.iface).SecurityNotes
+	c.Assert(notes, HasLen, 3)
+	c.Check(notes[0], testutil.Contains, "CAP_SYS_ADMIN")
+	c.Check(notes[1], testutil.Contains, "user namespaces")
+	c.Check(notes[2], testutil.Contains, "mounting FUSE filesystems")
+
+	// the notes come along with the documentation of the interface
+	repo := interfaces.NewRepository()
+	c.Assert(repo.AddInterface(s.iface), IsNil)
+	infos := repo.Info(&interfaces.InfoOptions{Names: []string{"fuse-support"}, Doc: true})
+	c.Assert(infos, HasLen, 1)
+	c.Check(infos[0].SecurityNotes, DeepEquals, notes)
+	infos = repo.Info(&interfaces.InfoOptions{Names: []string{"fuse-support"}})
+	c.Assert(infos, HasLen, 1)
+	c.Check(infos[0].SecurityNotes, HasLen, 0)
+}
+
+func (s *FuseSupportInterfaceSuite) TestStaticInfoImplicitOnClassicRelease(c *C) {
+	restore := release.MockReleaseInfo(&release.OS{ID: "ubuntu", VersionID: "14.04"})
+	defer restore()
+	c.Check(interfaces.StaticInfoOf(s.iface).ImplicitOnClassic, Equals, false)
+
+	release.MockReleaseInfo(&release.OS{ID: "ubuntu", VersionID: "16.04"})
+	c.Check(interfaces.StaticInfoOf(s.iface).ImplicitOnClassic, Equals, true)
+
+	release.MockReleaseInfo(&release.OS{ID: "fedora", VersionID: "14.04"})
+	c.Check(interfaces.StaticInfoOf(s.iface).ImplicitOnClassic, Equals, true)
+
+	// rolling releases have no VERSION_ID
+	release.MockReleaseInfo(&release.OS{ID: "arch"})
+	c.Check(interfaces.StaticInfoOf(s.iface).ImplicitOnClassic, Equals, true)
+
+	// the running system does not matter when asking about another release
+	c.Check(interfaces.StaticInfoForRelease(s.iface, release.OS{ID: "ubuntu", VersionID: "14.04"}).ImplicitOnClassic, Equals, false)
+	c.Check(interfaces.StaticInfoForRelease(s.iface, release.OS{ID: "ubuntu", VersionID: "16.04"}).ImplicitOnClassic, Equals, true)
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecSnapWritableMountRules(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	// the mount rules target the same directories as the ones of the
+	// other interfaces mounting to the writable directories of the snap
+	rules := builtin.SnapWritableMountRules("fuse.*", "**")
+	c.Assert(strings.Count(rules, "\n"), Equals, 8)
+	for _, rule := range strings.Split(strings.TrimSpace(rules), "\n") {
+		c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\n"+rule+"\n")
+	}
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecMountTargetsSafe(c *C) {
+	// only the fusermount child profile has mount rules
+	plug, _ := MockConnectedPlug(c, fuseSupportFusermountConsumerYaml, nil, "fuse-support")
+	spec := apparmor.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	rules, err := apparmor.ParseRules(spec.SnippetForTag("snap.consumer.app"))
+	c.Assert(err, IsNil)
+	mountRules := 0
+	for _, rule := range rules {
+		if rule.Kind != apparmor.MountRule {
+			continue
+		}
+		c.Check(apparmor.ValidateMountTargetSafe(rule), IsNil)
+		mountRules++
+	}
+	c.Check(mountRules, Equals, 12)
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecSnippetsForInterface(c *C) {
+	other := &ifacetest.TestInterface{
+		InterfaceName: "other",
+		AppArmorConnectedPlugCallback: func(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddSnippet("/dev/other rw,")
+			return nil
+		},
+	}
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.AddConnectedPlug(other, s.plug, s.slot), IsNil)
+
+	// the rules of fuse-support form a group of their own, which is the
+	// same as what the interface adds to the profile
+	snippets := spec.SnippetsForInterface("fuse-support")
+	c.Assert(snippets, HasLen, 1)
+	c.Check(snippets[0], testutil.Contains, "\n/dev/fuse rw,\n")
+	c.Check(snippets[0], Not(testutil.Contains), "/dev/other")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, snippets[0])
+	c.Check(spec.SnippetsForInterface("other"), DeepEquals, []string{"/dev/other rw,"})
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecSnippetsForInterfaceFusermount(c *C) {
+	plug, _ := MockConnectedPlug(c, fuseSupportFusermountConsumerYaml, nil, "fuse-support")
+	spec := apparmor.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	snippets := spec.SnippetsForInterface("fuse-support")
+	c.Assert(snippets, HasLen, 3)
+	c.Check(snippets[1], Equals, "/{,usr/}bin/fusermount{,3} Cxr -> fusermount,")
+	c.Check(snippets[2], testutil.Contains, "profile fusermount {\n")
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecLayouts(c *C) {
+	const layoutConsumerYaml = `name: consumer
+version: 0
+apps:
+  app:
+    plugs: [fuse-support]
+layout:
+  /mnt/remote:
+    bind: $SNAP_COMMON/remote
+  /var/lib/consumer:
+    bind: $SNAP_DATA
+  /usr/share/consumer:
+    bind: $SNAP/usr/share/consumer
+`
+	plug, _ := MockConnectedPlug(c, layoutConsumerYaml, &snap.SideInfo{Revision: snap.R(42)}, "fuse-support")
+	spec := apparmor.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	// the writable directories are mounted to at their layout paths too
+	c.Check(snippet, testutil.Contains, "\nmount fstype=fuse.* options=(ro,nosuid,nodev) ** -> \"/mnt/remote/{,**/}\",\n")
+	c.Check(snippet, testutil.Contains, "\nmount fstype=fuse.* options=(rw,nosuid,nodev) ** -> \"/mnt/remote/{,**/}\",\n")
+	c.Check(snippet, testutil.Contains, "\nmount fstype=fuse.* options=(rw,nosuid,nodev) ** -> \"/var/lib/consumer/{,**/}\",\n")
+	c.Check(snippet, Not(testutil.Contains), "/usr/share/consumer")
+	c.Check(snippet, testutil.Contains, "\nmount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /var/snap/@{SNAP_NAME}/common/{,**/},\n")
+
+	// the mount points are still the ones of the snap
+	rules, err := apparmor.ParseRules(snippet)
+	c.Assert(err, IsNil)
+	layoutRules := 0
+	for _, rule := range rules {
+		if rule.Kind == apparmor.MountRule && !strings.HasPrefix(rule.Target, "/var/snap/") && !strings.HasPrefix(rule.Target, "/home/") {
+			c.Check(rule.Target, Matches, `/(mnt/remote|var/lib/consumer)/\{,\*\*/\}`)
+			layoutRules++
+		}
+	}
+	c.Check(layoutRules, Equals, 4)
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecNoLayoutsUnchanged(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "layouts")
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecStrictConfinement(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\nmount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /var/snap/@{SNAP_NAME}/common/{,**/},\n")
+	c.Check(snippet, Not(testutil.Contains), "Mounts are not mediated")
+	c.Check(snippet, testutil.Contains, "\ndeny /etc/fuse.conf r,\n")
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecParallelInstance(c *C) {
+	plug, _ := MockConnectedPlug(c, fuseSupportFusermountConsumerYaml, nil, "fuse-support")
+	plug.Snap().InstanceKey = "instance"
+	spec := apparmor.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	snippet := spec.SnippetForTag("snap.consumer_instance.app")
+	// the system directories are remapped in the mount namespace of the
+	// instance, the ones of both names are allowed
+	c.Check(snippet, testutil.Contains, "\n  mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/common/{,**/},\n")
+	c.Check(snippet, testutil.Contains, "\n  umount /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/@{SNAP_REVISION}/{,**/},\n")
+	c.Check(snippet, testutil.Contains, "\n  mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /home/*/snap/@{SNAP_INSTANCE_NAME}/common/{,**/},\n")
+	c.Check(snippet, Not(testutil.Contains), "###SNAP_")
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecClassicConfinement(c *C) {
+	const classicConsumerYaml = `name: consumer
+version: 0
+confinement: classic
+apps:
+ app:
+  plugs: [fuse-support]
+`
+	plug, _ := MockConnectedPlug(c, classicConsumerYaml, nil, "fuse-support")
+	spec := apparmor.NewSpecification(plug.AppSet())
+	c.Assert(spec.Confinement(), Equals, snap.ClassicConfinement)
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, Not(testutil.Contains), "\nmount ")
+	c.Check(snippet, testutil.Contains, "\n# Mounts are not mediated under classic confinement\n")
+	// the rest of the policy is unchanged
+	c.Check(snippet, testutil.Contains, "\n/dev/fuse rw,\n")
+	c.Check(snippet, testutil.Contains, "\ndeny /etc/fuse.conf r,\n")
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecClassicConfinementJailMode(c *C) {
+	const classicConsumerYaml = `name: consumer
+version: 0
+confinement: classic
+apps:
+ app:
+  plugs: [fuse-support]
+`
+	plug, _ := MockConnectedPlug(c, classicConsumerYaml, nil, "fuse-support")
+	backend := &apparmor.Backend{}
+	spec := backend.NewSpecification(plug.AppSet(), interfaces.ConfinementOptions{Classic: true, JailMode: true}).(*apparmor.Specification)
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\nmount fstype=fuse.* options=(ro,nosuid,nodev) ** -> /home/*/snap/@{SNAP_INSTANCE_NAME}/common/{,**/},\n")
+}
+
+func (s *FuseSupportInterfaceSuite) TestDisconnectAfter(c *C) {
+	orderer, ok := s.iface.(interfaces.DisconnectOrderDefiner)
+	c.Assert(ok, Equals, true)
+	c.Check(orderer.DisconnectAfter(), DeepEquals, []string{"block-devices", "removable-media"})
+
+	repo := interfaces.NewRepository()
+	for _, iface := range builtin.Interfaces() {
+		c.Assert(repo.AddInterface(iface), IsNil)
+	}
+	c.Check(repo.DisconnectsBefore("fuse-support", "block-devices"), Equals, true)
+	c.Check(repo.DisconnectsBefore("fuse-support", "removable-media"), Equals, true)
+	c.Check(repo.DisconnectsBefore("removable-media", "fuse-support"), Equals, false)
+}
+
+func (s *FuseSupportInterfaceSuite) TestAutoConnectSameSnap(c *C) {
+	const sameSnapYaml = `name: consumer
+version: 0
+plugs:
+ fuse-support:
+slots:
+ fuse-helper:
+  interface: fuse-support
+`
+	_, plugInfo := MockConnectedPlug(c, sameSnapYaml, nil, "fuse-support")
+	_, slotInfo := MockConnectedSlot(c, sameSnapYaml, nil, "fuse-helper")
+	c.Check(s.iface.AutoConnect(plugInfo, slotInfo), Equals, true)
+}
+
+func (s *FuseSupportInterfaceSuite) TestAutoConnectSystemAndGadget(c *C) {
+	c.Check(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+
+	_, slotInfo := MockConnectedSlot(c, fuseSupportHostPathGadgetYaml, nil, "fuse-host-data")
+	c.Check(s.iface.AutoConnect(s.plugInfo, slotInfo), Equals, true)
+}
+
+func (s *FuseSupportInterfaceSuite) TestAutoConnectOtherSnap(c *C) {
+	const otherSnapYaml = `name: provider
+version: 0
+slots:
+ fuse-helper:
+  interface: fuse-support
+`
+	_, slotInfo := MockConnectedSlot(c, otherSnapYaml, nil, "fuse-helper")
+	c.Check(s.iface.AutoConnect(s.plugInfo, slotInfo), Equals, false)
+
+	// nor to another instance of the same snap
+	instanceSlotInfo := MockSlot(c, `name: consumer
+version: 0
+slots:
+ fuse-helper:
+  interface: fuse-support
+`, nil, "fuse-helper")
+	instanceSlotInfo.Snap.InstanceKey = "other"
+	c.Check(s.iface.AutoConnect(s.plugInfo, instanceSlotInfo), Equals, false)
+}
+
+const fuseSupportHostPathGadgetYaml = `name: gadget
+version: 0
+type: gadget
+slots:
+  fuse-host-data:
+    interface: fuse-support
+    host-path: /srv/data
+`
+
+func (s *FuseSupportInterfaceSuite) TestSanitizeSlotHostPath(c *C) {
+	_, slotInfo := MockConnectedSlot(c, fuseSupportHostPathGadgetYaml, nil, "fuse-host-data")
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, slotInfo), IsNil)
+}
+
+func (s *FuseSupportInterfaceSuite) TestSanitizeSlotHostPathErrors(c *C) {
+	for _, t := range []struct {
+		snapType, hostPath, err string
+	}{
+		{"app", "/srv/data", `fuse-support host-path attribute can only be used by core or gadget slots`},
+		{"gadget", "srv/data", `fuse-support host-path attribute must be an absolute path`},
+		{"gadget", "[1]", `fuse-support host-path attribute must be an absolute path`},
+		{"gadget", "/srv/../etc", `fuse-support host-path attribute "/srv/../etc" must be a clean path other than /`},
+		{"gadget", "/srv/data/", `fuse-support host-path attribute "/srv/data/" must be a clean path other than /`},
+		{"gadget", "/", `fuse-support host-path attribute "/" must be a clean path other than /`},
+		{"gadget", "/srv/*", `fuse-support host-path attribute is invalid: .* contains a reserved apparmor char .*`},
+		{"gadget", `"/srv/my data"`, `fuse-support host-path attribute "/srv/my data" cannot contain whitespace`},
+		{"gadget", "/srv", `fuse-support host-path attribute "/srv" must be below one of /media, /mnt, /srv`},
+		{"gadget", "/usr", `fuse-support host-path attribute "/usr" must be below one of /media, /mnt, /srv`},
+		{"gadget", "/usr/share", `fuse-support host-path attribute "/usr/share" must be below one of /media, /mnt, /srv`},
+		{"gadget", "/etc", `fuse-support host-path attribute "/etc" must be below one of /media, /mnt, /srv`},
+		{"gadget", "/var/lib/snapd", `fuse-support host-path attribute "/var/lib/snapd" must be below one of /media, /mnt, /srv`},
+		{"gadget", "/snap", `fuse-support host-path attribute "/snap" must be below one of /media, /mnt, /srv`},
+		{"gadget", "/srvdata", `fuse-support host-path attribute "/srvdata" must be below one of /media, /mnt, /srv`},
+	} {
+		yaml := fmt.Sprintf(`name: provider
+version: 0
+type: %s
+slots:
+  fuse-host-data:
+    interface: fuse-support
+    host-path: %s
+`, t.snapType, t.hostPath)
+		slotInfo := snaptest.MockInfo(c, yaml, nil).Slots["fuse-host-data"]
+		c.Check(interfaces.BeforePrepareSlot(s.iface, slotInfo), ErrorMatches, t.err, Commentf("%s", t.hostPath))
+	}
+}
+
+func (s *FuseSupportInterfaceSuite) TestMountSpecHostPath(c *C) {
+	slot, _ := MockConnectedSlot(c, fuseSupportHostPathGadgetYaml, nil, "fuse-host-data")
+	spec := &mount.Specification{}
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, slot), IsNil)
+	c.Check(spec.MountEntries(), DeepEquals, []osutil.MountEntry{{
+		Name:    "/var/lib/snapd/hostfs/srv/data",
+		Dir:     "/srv/data",
+		Options: []string{"bind", "ro"},
+	}})
+	c.Check(spec.UserMountEntries(), HasLen, 0)
+
+	// nothing is presented without the attribute
+	spec = &mount.Specification{}
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.MountEntries(), HasLen, 0)
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecHostPath(c *C) {
+	slot, _ := MockConnectedSlot(c, fuseSupportHostPathGadgetYaml, nil, "fuse-host-data")
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\n/srv/data/{,**} r,\n")
+	updateNS := strings.Join(spec.UpdateNS(), "")
+	c.Check(updateNS, testutil.Contains, "  mount options=(bind) /var/lib/snapd/hostfs/srv/data/ -> /srv/data/,\n")
+	c.Check(updateNS, testutil.Contains, "  remount options=(bind, ro) /srv/data/,\n")
+	c.Check(updateNS, testutil.Contains, "  umount /srv/data/,\n")
+	// the mount point may need a writable mimic over /srv
+	c.Check(updateNS, testutil.Contains, `mount options=(rbind, rw) "/srv/" -> "/tmp/.snap/srv/",`)
+
+	// no update-ns rules without the attribute
+	spec = apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.UpdateNS(), HasLen, 0)
+}
+
+const fuseSupportContentShareProviderYaml = `name: provider
+version: 0
+slots:
+  fuse-media:
+    interface: fuse-support
+    content-share: media
+  media:
+    interface: content
+    write:
+      - $SNAP_COMMON/media
+      - $SNAP_DATA/cache
+`
+
+func (s *FuseSupportInterfaceSuite) TestSanitizeSlotContentShare(c *C) {
+	_, slotInfo := MockConnectedSlot(c, fuseSupportContentShareProviderYaml, nil, "fuse-media")
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, slotInfo), IsNil)
+
+	// the content label is used rather than the name of the slot
+	const labelYaml = `name: provider
+version: 0
+slots:
+  fuse-media:
+    interface: fuse-support
+    content-share: media
+  shared:
+    interface: content
+    content: media
+    write: [$SNAP_COMMON/media]
+`
+	_, slotInfo = MockConnectedSlot(c, labelYaml, nil, "fuse-media")
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, slotInfo), IsNil)
+}
+
+func (s *FuseSupportInterfaceSuite) TestSanitizeSlotContentShareErrors(c *C) {
+	for _, t := range []struct {
+		attrs, contentSlot, err string
+	}{
+		{"content-share: ''", "write: [$SNAP_COMMON/media]", `fuse-support content-share attribute must be a non-empty string`},
+		{"content-share: [media]", "write: [$SNAP_COMMON/media]", `fuse-support content-share attribute must be a non-empty string`},
+		{"content-share: other", "write: [$SNAP_COMMON/media]", `fuse-support content-share attribute "other" does not match any content slot of snap "provider"`},
+		{"content-share: media", "read: [$SNAP/media]", `fuse-support content-share attribute "media" must match a content slot with write paths`},
+		{"content-share: media", "write: [media]", `fuse-support content-share write path "media" must be in \$SNAP_DATA or \$SNAP_COMMON`},
+		{"content-share: media", "write: [$SNAP/media]", `fuse-support content-share write path "\$SNAP/media" must be in \$SNAP_DATA or \$SNAP_COMMON`},
+		{"content-share: media", "write: [$SNAP_COMMON/../media]", `content interface path is not clean: .*`},
+		{"content-share: media", "write: [$SNAP_COMMON/*]", `content interface path is invalid: .* contains a reserved apparmor char .*`},
+		{"content-share: media", `write: ["$SNAP_COMMON/my media"]`, `fuse-support content-share write path "\$SNAP_COMMON/my media" cannot contain whitespace or @`},
+		{"content-share: media\n    host-path: /srv/data", "write: [$SNAP_COMMON/media]", `fuse-support content-share attribute cannot be used along with host-path`},
+	} {
+		yaml := fmt.Sprintf(`name: provider
+version: 0
+slots:
+  fuse-media:
+    interface: fuse-support
+    %s
+  media:
+    interface: content
+    %s
+`, t.attrs, t.contentSlot)
+		slotInfo := snaptest.MockInfo(c, yaml, nil).Slots["fuse-media"]
+		c.Check(interfaces.BeforePrepareSlot(s.iface, slotInfo), ErrorMatches, t.err, Commentf("%s %s", t.attrs, t.contentSlot))
+	}
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecContentShare(c *C) {
+	slot, _ := MockConnectedSlot(c, fuseSupportContentShareProviderYaml, &snap.SideInfo{Revision: snap.R(5)}, "fuse-media")
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, slot), IsNil)
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, `# Allow mounts to the directories shared by provider:fuse-media
+mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> /var/snap/provider/common/media/{,**/},
+mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /var/snap/provider/common/media/{,**/},
+mount fstype=fuse.* options=(ro,nosuid,nodev) ** -> /var/snap/provider/5/cache/{,**/},
+mount fstype=fuse.* options=(rw,nosuid,nodev) ** -> /var/snap/provider/5/cache/{,**/},
+`)
+	// the default rules are still there
+	c.Check(snippet, testutil.Contains, builtin.SnapWritableMountRules("fuse.*", "**"))
+
+	// the fusermount child profile does not mount to content shares
+	plug, _ := MockConnectedPlug(c, fuseSupportFusermountConsumerYaml, nil, "fuse-support")
+	spec = apparmor.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "/var/snap/provider/")
+
+	// nor are there any rules without the attribute
+	spec = apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "shared by")
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorSpecInstanceControlSocket(c *C) {
+	// expandVars resolves the variables of the socket rules the way the
+	// template variables of the profile of the given snap do
+	expandVars := func(info *snap.Info, rule string) string {
+		rule = strings.NewReplacer(
+			"@{SNAP_NAME}", info.SnapName(),
+			"@{SNAP_INSTANCE_KEY}", info.InstanceKey,
+		).Replace(rule)
+		return filepath.Clean(strings.TrimSuffix(rule, " rw,"))
+	}
+
+	const socketRule = "/run/fuse-support/@{SNAP_NAME}/@{SNAP_INSTANCE_KEY}/control.sock rw,"
+	var sockets []string
+	for _, instanceKey := range []string{"", "other"} {
+		plug, _ := MockConnectedPlug(c, fuseSupportConsumerYaml, nil, "fuse-support")
+		plug.AppSet().Info().InstanceKey = instanceKey
+		spec := apparmor.NewSpecification(plug.AppSet())
+		c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+		tag := plug.AppSet().Info().Apps["app"].SecurityTag()
+		c.Check(spec.SnippetForTag(tag), testutil.Contains, "\n"+socketRule+"\n")
+		sockets = append(sockets, expandVars(plug.AppSet().Info(), socketRule))
+	}
+	c.Check(sockets, DeepEquals, []string{
+		"/run/fuse-support/consumer/control.sock",
+		"/run/fuse-support/consumer/other/control.sock",
+	})
+}
+
+func (s *FuseSupportInterfaceSuite) TestAppArmorRuleBudget(c *C) {
+	ifacetest.AssertMaxRuleCount(c, s.iface, 15)
 }
 
 func (s *FuseSupportInterfaceSuite) TestInterfaces(c *C) {
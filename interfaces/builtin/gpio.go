@@ -76,10 +76,20 @@ func (iface *gpioInterface) BeforePrepareSlot(slot *snap.SlotInfo) error {
 		return fmt.Errorf("gpio slot number attribute must be an int")
 	}
 
+	// Optional physical pin the GPIO is routed to
+	if err := validateSlotPin(slot); err != nil {
+		return err
+	}
+
 	// Slot is good
 	return nil
 }
 
+// SlotPin returns the physical pin used by the slot, if specified.
+func (iface *gpioInterface) SlotPin(slot *snap.SlotInfo) (int64, bool) {
+	return slotPin(slot)
+}
+
 func (iface *gpioInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
 	var number int64
 	if err := slot.Attr("number", &number); err != nil {
@@ -126,6 +136,24 @@ func (iface *gpioInterface) AutoConnect(*snap.PlugInfo, *snap.SlotInfo) bool {
 	return true
 }
 
+// validateSlotPin checks the optional "pin" attribute of a slot, used to
+// detect slots of different interfaces sharing the same physical pin.
+func validateSlotPin(slot *snap.SlotInfo) error {
+	pin, ok := slot.Attrs["pin"]
+	if !ok {
+		return nil
+	}
+	if n, ok := pin.(int64); !ok || n < 0 {
+		return fmt.Errorf("%s slot pin attribute must be a non-negative int", slot.Interface)
+	}
+	return nil
+}
+
+func slotPin(slot *snap.SlotInfo) (int64, bool) {
+	pin, ok := slot.Attrs["pin"].(int64)
+	return pin, ok
+}
+
 func init() {
 	registerIface(&gpioInterface{})
 }
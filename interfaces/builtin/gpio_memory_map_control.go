@@ -0,0 +1,155 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/snap"
+)
+
+const gpioMemoryMapControlSummary = `allows mapping the GPIO registers of the SoC through /dev/mem`
+
+const gpioMemoryMapControlBaseDeclarationSlots = `
+  gpio-memory-map-control:
+    allow-installation:
+      slot-snap-type:
+        - gadget
+        - core
+    deny-auto-connection: true
+`
+
+// AppArmor mediates the access to /dev/mem but not the offsets passed to mmap,
+// the range of the slot is only recorded in the profile. The connect hooks of
+// the snap can read it from the slot attributes.
+const gpioMemoryMapControlConnectedPlugAppArmor = `
+# Description: Can map the GPIO registers of the SoC exposed in the physical
+# memory range %#x-%#x through /dev/mem. With STRICT_DEVMEM=y the kernel
+# restricts the accessible ranges to the ones not claimed as system RAM.
+capability sys_rawio,
+/dev/mem rw,
+`
+
+var gpioMemoryMapControlConnectedPlugUDev = []string{`KERNEL=="mem"`}
+
+// gpioMemoryMapControlPageSize is the granularity of the mappings, the range
+// of the slot must be aligned to it.
+const gpioMemoryMapControlPageSize = 4096
+
+type gpioMemoryMapControlInterface struct {
+	commonInterface
+}
+
+// gpioMemoryMapControlAttr returns the value of the given slot attribute,
+// either an integer or a string with an integer in decimal or hexadecimal
+// notation.
+func gpioMemoryMapControlAttr(attrs interfaces.Attrer, name string) (uint64, error) {
+	value, ok := attrs.Lookup(name)
+	if !ok {
+		return 0, fmt.Errorf("gpio-memory-map-control slot must have a %s attribute", name)
+	}
+	switch v := value.(type) {
+	case int64:
+		if v >= 0 {
+			return uint64(v), nil
+		}
+	case string:
+		if n, err := strconv.ParseUint(v, 0, 64); err == nil {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("gpio-memory-map-control slot %s attribute must be a non-negative integer, got %v", name, value)
+}
+
+// gpioMemoryMapControlRange returns the physical memory range of the slot
+// as its first and last address.
+func gpioMemoryMapControlRange(attrs interfaces.Attrer) (first, last uint64, err error) {
+	base, err := gpioMemoryMapControlAttr(attrs, "base")
+	if err != nil {
+		return 0, 0, err
+	}
+	size, err := gpioMemoryMapControlAttr(attrs, "size")
+	if err != nil {
+		return 0, 0, err
+	}
+	if size == 0 {
+		return 0, 0, fmt.Errorf("gpio-memory-map-control slot size attribute cannot be zero")
+	}
+	if base%gpioMemoryMapControlPageSize != 0 || size%gpioMemoryMapControlPageSize != 0 {
+		return 0, 0, fmt.Errorf("gpio-memory-map-control slot base and size attributes must be aligned to %d bytes", gpioMemoryMapControlPageSize)
+	}
+	last = base + size - 1
+	if last < base {
+		return 0, 0, fmt.Errorf("gpio-memory-map-control slot range %#x+%#x overflows", base, size)
+	}
+	return base, last, nil
+}
+
+// gpioMemoryMapControlCheckRole checks that the optional role attribute,
+// which tells apart the banks of GPIOs of the gadget, is a string.
+func gpioMemoryMapControlCheckRole(attrs interfaces.Attrer, side string) error {
+	role, ok := attrs.Lookup("role")
+	if !ok {
+		return nil
+	}
+	if s, isString := role.(string); !isString || s == "" {
+		return fmt.Errorf("gpio-memory-map-control %s role attribute must be a non-empty string, got %v", side, role)
+	}
+	return nil
+}
+
+func (iface *gpioMemoryMapControlInterface) BeforePrepareSlot(slot *snap.SlotInfo) error {
+	if _, _, err := gpioMemoryMapControlRange(slot); err != nil {
+		return err
+	}
+	return gpioMemoryMapControlCheckRole(slot, "slot")
+}
+
+func (iface *gpioMemoryMapControlInterface) BeforePreparePlug(plug *snap.PlugInfo) error {
+	return gpioMemoryMapControlCheckRole(plug, "plug")
+}
+
+// BeforeConnect requires the plug to declare the role of the slot, when the
+// gadget sets one, so that a snap is only connected to the GPIOs it was
+// written for.
+func (iface *gpioMemoryMapControlInterface) BeforeConnect(plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	return interfaces.MatchAttr(plug, slot, "role")
+}
+
+func (iface *gpioMemoryMapControlInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	first, last, err := gpioMemoryMapControlRange(slot)
+	if err != nil {
+		return err
+	}
+	spec.AddSnippet(fmt.Sprintf(gpioMemoryMapControlConnectedPlugAppArmor, first, last))
+	return nil
+}
+
+func init() {
+	registerIface(&gpioMemoryMapControlInterface{commonInterface{
+		name:                 "gpio-memory-map-control",
+		summary:              gpioMemoryMapControlSummary,
+		baseDeclarationSlots: gpioMemoryMapControlBaseDeclarationSlots,
+		connectedPlugUDev:    gpioMemoryMapControlConnectedPlugUDev,
+	}})
+}
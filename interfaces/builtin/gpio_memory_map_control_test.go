@@ -0,0 +1,195 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/snap/snaptest"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type gpioMemoryMapControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&gpioMemoryMapControlInterfaceSuite{
+	iface: builtin.MustInterface("gpio-memory-map-control"),
+})
+
+const gpioMemoryMapControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [gpio-memory-map-control]
+`
+
+const gpioMemoryMapControlGadgetYaml = `name: gadget
+version: 0
+type: gadget
+slots:
+  gpio-regs:
+    interface: gpio-memory-map-control
+    base: 0x3f200000
+    size: 4096
+`
+
+func (s *gpioMemoryMapControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, gpioMemoryMapControlConsumerYaml, nil, "gpio-memory-map-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, gpioMemoryMapControlGadgetYaml, nil, "gpio-regs")
+}
+
+func (s *gpioMemoryMapControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "gpio-memory-map-control")
+}
+
+func (s *gpioMemoryMapControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *gpioMemoryMapControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *gpioMemoryMapControlInterfaceSuite) TestSanitizeSlotHexString(c *C) {
+	const hexYaml = `name: gadget
+version: 0
+type: gadget
+slots:
+  gpio-regs:
+    interface: gpio-memory-map-control
+    base: "0x3f200000"
+    size: "0x1000"
+`
+	_, slotInfo := MockConnectedSlot(c, hexYaml, nil, "gpio-regs")
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, slotInfo), IsNil)
+}
+
+func (s *gpioMemoryMapControlInterfaceSuite) TestSanitizeSlotBadAttrs(c *C) {
+	for _, t := range []struct {
+		attrs string
+		err   string
+	}{
+		{"size: 4096", `gpio-memory-map-control slot must have a base attribute`},
+		{"base: 4096", `gpio-memory-map-control slot must have a size attribute`},
+		{"base: -4096\n    size: 4096", `gpio-memory-map-control slot base attribute must be a non-negative integer, got -4096`},
+		{"base: 0xg000\n    size: 4096", `gpio-memory-map-control slot base attribute must be a non-negative integer, got 0xg000`},
+		{"base: 4096\n    size: [1]", `gpio-memory-map-control slot size attribute must be a non-negative integer, got \[1\]`},
+		{"base: 4096\n    size: 1.5", `gpio-memory-map-control slot size attribute must be a non-negative integer, got 1.5`},
+		{"base: 4096\n    size: 0", `gpio-memory-map-control slot size attribute cannot be zero`},
+		{"base: 0x3f200100\n    size: 4096", `gpio-memory-map-control slot base and size attributes must be aligned to 4096 bytes`},
+		{"base: 0x3f200000\n    size: 100", `gpio-memory-map-control slot base and size attributes must be aligned to 4096 bytes`},
+		{"base: \"0xfffffffffffff000\"\n    size: 8192", `gpio-memory-map-control slot range 0xfffffffffffff000\+0x2000 overflows`},
+	} {
+		yaml := fmt.Sprintf(`name: gadget
+version: 0
+type: gadget
+slots:
+  gpio-regs:
+    interface: gpio-memory-map-control
+    %s
+`, t.attrs)
+		slotInfo := snaptest.MockInfo(c, yaml, nil).Slots["gpio-regs"]
+		c.Check(interfaces.BeforePrepareSlot(s.iface, slotInfo), ErrorMatches, t.err, Commentf("%q", t.attrs))
+	}
+}
+
+func (s *gpioMemoryMapControlInterfaceSuite) TestSanitizeBadRole(c *C) {
+	slotInfo := snaptest.MockInfo(c, gpioMemoryMapControlGadgetYaml+"    role: [a]\n", nil).Slots["gpio-regs"]
+	c.Check(interfaces.BeforePrepareSlot(s.iface, slotInfo), ErrorMatches, `gpio-memory-map-control slot role attribute must be a non-empty string, got \[a\]`)
+
+	plugInfo := snaptest.MockInfo(c, gpioMemoryMapControlRoleConsumerYaml(`""`), nil).Plugs["gpio"]
+	c.Check(interfaces.BeforePreparePlug(s.iface, plugInfo), ErrorMatches, `gpio-memory-map-control plug role attribute must be a non-empty string, got `)
+}
+
+func gpioMemoryMapControlRoleConsumerYaml(role string) string {
+	return fmt.Sprintf(`name: consumer
+version: 0
+plugs:
+  gpio:
+    interface: gpio-memory-map-control
+    role: %s
+apps:
+ app:
+  plugs: [gpio]
+`, role)
+}
+
+func (s *gpioMemoryMapControlInterfaceSuite) TestBeforeConnectRole(c *C) {
+	roleSlot, _ := MockConnectedSlot(c, gpioMemoryMapControlGadgetYaml+"    role: leds\n", nil, "gpio-regs")
+	ledsPlug, _ := MockConnectedPlug(c, gpioMemoryMapControlRoleConsumerYaml("leds"), nil, "gpio")
+	motorsPlug, _ := MockConnectedPlug(c, gpioMemoryMapControlRoleConsumerYaml("motors"), nil, "gpio")
+
+	// without roles anything can be connected
+	c.Check(interfaces.BeforeConnect(s.iface, s.plug, s.slot), IsNil)
+	// matching roles
+	c.Check(interfaces.BeforeConnect(s.iface, ledsPlug, roleSlot), IsNil)
+	// mismatched roles
+	c.Check(interfaces.BeforeConnect(s.iface, motorsPlug, roleSlot), ErrorMatches, `plug attribute "role" \(motors\) does not match the one of the slot \(leds\)`)
+	c.Check(interfaces.BeforeConnect(s.iface, s.plug, roleSlot), ErrorMatches, `plug must have attribute "role" matching the one of the slot \(leds\)`)
+	c.Check(interfaces.BeforeConnect(s.iface, ledsPlug, s.slot), ErrorMatches, `plug attribute "role" \(leds\) is not set by the slot`)
+}
+
+func (s *gpioMemoryMapControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/dev/mem rw,\n")
+	c.Check(snippet, testutil.Contains, "\ncapability sys_rawio,\n")
+	c.Check(snippet, testutil.Contains, "memory range 0x3f200000-0x3f200fff through /dev/mem")
+}
+
+func (s *gpioMemoryMapControlInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 2)
+	c.Assert(spec.Snippets(), testutil.Contains, `# gpio-memory-map-control
+KERNEL=="mem", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+}
+
+func (s *gpioMemoryMapControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, false)
+	c.Assert(si.ImplicitOnClassic, Equals, false)
+	c.Assert(si.Summary, Equals, `allows mapping the GPIO registers of the SoC through /dev/mem`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "gpio-memory-map-control")
+}
+
+func (s *gpioMemoryMapControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *gpioMemoryMapControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -135,6 +135,125 @@ func (s *GpioInterfaceSuite) TestSanitizeSlotOsSnap(c *C) {
 	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.osGpioSlotInfo), IsNil)
 }
 
+func (s *GpioInterfaceSuite) TestSanitizeSlotPin(c *C) {
+	info := snaptest.MockInfo(c, `
+name: my-device
+version: 0
+type: gadget
+slots:
+    with-pin:
+        interface: gpio
+        number: 100
+        pin: 12
+    bad-pin:
+        interface: gpio
+        number: 101
+        pin: twelve
+    negative-pin:
+        interface: gpio
+        number: 102
+        pin: -1
+`, nil)
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, info.Slots["with-pin"]), IsNil)
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, info.Slots["bad-pin"]), ErrorMatches,
+		"gpio slot pin attribute must be a non-negative int")
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, info.Slots["negative-pin"]), ErrorMatches,
+		"gpio slot pin attribute must be a non-negative int")
+}
+
+func (s *GpioInterfaceSuite) TestSlotPin(c *C) {
+	info := snaptest.MockInfo(c, `
+name: my-device
+version: 0
+type: gadget
+slots:
+    with-pin:
+        interface: gpio
+        number: 100
+        pin: 12
+`, nil)
+	pinUser, ok := s.iface.(interfaces.PinUser)
+	c.Assert(ok, Equals, true)
+
+	pin, ok := pinUser.SlotPin(info.Slots["with-pin"])
+	c.Check(ok, Equals, true)
+	c.Check(pin, Equals, int64(12))
+
+	_, ok = pinUser.SlotPin(s.gadgetGpioSlotInfo)
+	c.Check(ok, Equals, false)
+}
+
+func (s *GpioInterfaceSuite) TestConnectGpioAndPwmPinConflicts(c *C) {
+	gadgetInfo := snaptest.MockInfo(c, `
+name: my-device
+version: 0
+type: gadget
+slots:
+    gpio-12:
+        interface: gpio
+        number: 100
+        pin: 12
+    gpio-13:
+        interface: gpio
+        number: 101
+        pin: 13
+    pwm-12:
+        interface: pwm
+        channel: 0
+        chip-number: 0
+        pin: 12
+    pwm-14:
+        interface: pwm
+        channel: 1
+        chip-number: 0
+        pin: 14
+`, nil)
+	consumerInfo := snaptest.MockInfo(c, `
+name: consumer
+version: 0
+plugs:
+    gpio-a:
+        interface: gpio
+    gpio-b:
+        interface: gpio
+    pwm:
+        interface: pwm
+apps:
+    app:
+        command: foo
+`, nil)
+
+	repo := interfaces.NewRepository()
+	c.Assert(repo.AddInterface(s.iface), IsNil)
+	c.Assert(repo.AddInterface(builtin.MustInterface("pwm")), IsNil)
+	for _, info := range []*snap.Info{gadgetInfo, consumerInfo} {
+		appSet, err := interfaces.NewSnapAppSet(info, nil)
+		c.Assert(err, IsNil)
+		c.Assert(repo.AddAppSet(appSet), IsNil)
+	}
+	connect := func(plug, slot string) error {
+		ref := interfaces.NewConnRef(consumerInfo.Plugs[plug], gadgetInfo.Slots[slot])
+		_, err := repo.Connect(ref, nil, nil, nil, nil, nil)
+		return err
+	}
+
+	c.Assert(connect("gpio-a", "gpio-12"), IsNil)
+	// a different pin can be used through pwm
+	c.Assert(connect("pwm", "pwm-14"), IsNil)
+	c.Assert(repo.Disconnect("consumer", "pwm", "my-device", "pwm-14"), IsNil)
+	// but pin 12 is already used as GPIO
+	c.Assert(connect("pwm", "pwm-12"), ErrorMatches,
+		`cannot connect plug "pwm" of snap "consumer" to slot "pwm-12" of snap "my-device": pin 12 is already used by connected slot "gpio-12" \(interface "gpio"\)`)
+	// another GPIO on a different pin is fine
+	c.Assert(connect("gpio-b", "gpio-13"), IsNil)
+
+	// once the GPIO is disconnected the pin can be used for PWM
+	c.Assert(repo.Disconnect("consumer", "gpio-a", "my-device", "gpio-12"), IsNil)
+	c.Assert(connect("pwm", "pwm-12"), IsNil)
+	c.Assert(connect("gpio-a", "gpio-12"), ErrorMatches,
+		`cannot connect plug "gpio-a" of snap "consumer" to slot "gpio-12" of snap "my-device": pin 12 is already used by connected slot "pwm-12" \(interface "pwm"\)`)
+}
+
 func (s *GpioInterfaceSuite) TestSanitizePlug(c *C) {
 	c.Assert(interfaces.BeforePreparePlug(s.iface, s.gadgetPlugInfo), IsNil)
 }
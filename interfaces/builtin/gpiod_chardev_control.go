@@ -0,0 +1,183 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+)
+
+// https://www.kernel.org/doc/html/latest/userspace-api/gpio/chardev.html
+const gpiodChardevControlSummary = `allows control of GPIO lines through the gpiochip character devices`
+
+// Requesting lines of a gpiochip allows driving arbitrary hardware wired to
+// it, so connections need to be explicitly granted. Gadget snaps can provide
+// slots pinned to a single chip.
+const gpiodChardevControlBaseDeclarationSlots = `
+  gpiod-chardev-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+        - gadget
+    deny-auto-connection: true
+`
+
+const gpiodChardevControlConnectedPlugAppArmor = `
+# Description: Can request and control GPIO lines with the v2 uAPI of the
+# gpiochip character devices. This allows privileged access to the hardware
+# wired to the GPIO lines.
+
+# Allow reading the chip and line information exposed in sysfs
+/sys/bus/gpio/devices/ r,
+/sys/devices/**/gpiochip[0-9]*/{,**} r,
+`
+
+const gpiodChardevControlConnectedPlugSecComp = `
+# Description: Can request and control GPIO lines with the v2 uAPI of the
+# gpiochip character devices.
+
+# Querying the chip and requesting lines
+ioctl - GPIO_GET_CHIPINFO_IOCTL
+ioctl - GPIO_V2_GET_LINEINFO_IOCTL
+ioctl - GPIO_V2_GET_LINEINFO_WATCH_IOCTL
+ioctl - GPIO_GET_LINEINFO_UNWATCH_IOCTL
+ioctl - GPIO_V2_GET_LINE_IOCTL
+
+# Reading and driving the requested lines, on the returned line file
+# descriptor
+ioctl - GPIO_V2_LINE_GET_VALUES_IOCTL
+ioctl - GPIO_V2_LINE_SET_VALUES_IOCTL
+`
+
+// gpiodChardevControlAllowConfigSecComp is used when a plug sets the
+// "allow-config" attribute.
+const gpiodChardevControlAllowConfigSecComp = `
+# Description: Can reconfigure the direction and the edge detection of the
+# requested GPIO lines.
+ioctl - GPIO_V2_LINE_SET_CONFIG_IOCTL
+`
+
+// gpiodChardevControlDenyConfigSecComp is used unless a plug sets the
+// "allow-config" attribute. The direction and edge detection of the lines are
+// then fixed by the line request, they cannot be reconfigured afterwards.
+const gpiodChardevControlDenyConfigSecComp = `
+# Description: Cannot reconfigure the direction nor the edge detection of the
+# requested GPIO lines.
+~ioctl - GPIO_V2_LINE_SET_CONFIG_IOCTL
+# see CVE-2019-7303
+~ioctl - 4294967295|GPIO_V2_LINE_SET_CONFIG_IOCTL
+`
+
+type gpiodChardevControlInterface struct {
+	commonInterface
+}
+
+func (iface *gpiodChardevControlInterface) BeforePrepareSlot(slot *snap.SlotInfo) error {
+	rawChip, ok := slot.Attrs["chip"]
+	if !ok {
+		return nil
+	}
+	if chip, ok := rawChip.(int64); !ok || chip < 0 {
+		return fmt.Errorf(`%s "chip" attribute must be a non-negative integer`, iface.Name())
+	}
+	return nil
+}
+
+// gpiodChardevControlDevice returns the device node pattern of the chips the
+// slot grants access to, either the chip pinned by the "chip" attribute or
+// all of them.
+func gpiodChardevControlDevice(slot *interfaces.ConnectedSlot) (string, error) {
+	var chip int64
+	err := slot.Attr("chip", &chip)
+	if errors.Is(err, snap.AttributeNotFoundError{}) {
+		return "gpiochip[0-9]*", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("gpiochip%d", chip), nil
+}
+
+func (iface *gpiodChardevControlInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	device, err := gpiodChardevControlDevice(slot)
+	if err != nil {
+		return err
+	}
+	spec.AddSnippet(gpiodChardevControlConnectedPlugAppArmor)
+	spec.AddSnippet(fmt.Sprintf("/dev/%s rw,", device))
+	return nil
+}
+
+// gpiodChardevControlAllowsConfig returns whether any plug of the snap of the
+// given plug sets the "allow-config" attribute. Seccomp cannot tell the line
+// file descriptors of the plugs apart, and explicit denials take precedence,
+// so the attribute of one plug applies to the whole snap.
+func gpiodChardevControlAllowsConfig(plug *interfaces.ConnectedPlug) bool {
+	for _, plugInfo := range plug.Snap().Plugs {
+		if plugInfo.Interface != plug.Interface() {
+			continue
+		}
+		if allowConfig, _ := plugInfo.Attrs["allow-config"].(bool); allowConfig {
+			return true
+		}
+	}
+	return false
+}
+
+func (iface *gpiodChardevControlInterface) SecCompConnectedPlug(spec *seccomp.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	spec.AddSnippet(gpiodChardevControlConnectedPlugSecComp)
+	if gpiodChardevControlAllowsConfig(plug) {
+		spec.AddSnippet(gpiodChardevControlAllowConfigSecComp)
+	} else {
+		spec.AddSnippet(gpiodChardevControlDenyConfigSecComp)
+	}
+	return nil
+}
+
+func (iface *gpiodChardevControlInterface) UDevConnectedPlug(spec *udev.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	device, err := gpiodChardevControlDevice(slot)
+	if err != nil {
+		return err
+	}
+	spec.TagDevice(fmt.Sprintf(`SUBSYSTEM=="gpio", KERNEL=="%s"`, device))
+	return nil
+}
+
+func init() {
+	registerIface(&gpiodChardevControlInterface{commonInterface{
+		name:                 "gpiod-chardev-control",
+		summary:              gpiodChardevControlSummary,
+		implicitOnCore:       true,
+		implicitOnClassic:    true,
+		baseDeclarationSlots: gpiodChardevControlBaseDeclarationSlots,
+		// the optional "allow-config" plug attribute allows
+		// reconfiguring the requested lines, see
+		// gpiodChardevControlDenyConfigSecComp
+		plugAttrSchema: map[string]interfaces.AttrSpec{
+			"allow-config": {Type: interfaces.AttrBool, Description: "allow reconfiguring the requested lines"},
+		},
+	}})
+}
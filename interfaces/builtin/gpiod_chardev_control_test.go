@@ -0,0 +1,212 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type gpiodChardevControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&gpiodChardevControlInterfaceSuite{
+	iface: builtin.MustInterface("gpiod-chardev-control"),
+})
+
+const gpiodChardevControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [gpiod-chardev-control]
+`
+
+const gpiodChardevControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  gpiod-chardev-control:
+`
+
+func (s *gpiodChardevControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, gpiodChardevControlConsumerYaml, nil, "gpiod-chardev-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, gpiodChardevControlCoreYaml, nil, "gpiod-chardev-control")
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "gpiod-chardev-control")
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+const gpiodChardevControlPinnedGadgetYaml = `name: gadget
+version: 0
+type: gadget
+slots:
+  gpiod-chardev-control:
+    chip: 2
+`
+
+func (s *gpiodChardevControlInterfaceSuite) TestSanitizeSlotPinned(c *C) {
+	slotInfo := MockSlot(c, gpiodChardevControlPinnedGadgetYaml, nil, "gpiod-chardev-control")
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, slotInfo), IsNil)
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestSanitizeSlotInvalidChip(c *C) {
+	for _, chip := range []string{`-1`, `"0"`, `1.5`, `[1]`} {
+		slotInfo := MockSlot(c, fmt.Sprintf(`name: gadget
+version: 0
+type: gadget
+slots:
+  gpiod-chardev-control:
+    chip: %s
+`, chip), nil, "gpiod-chardev-control")
+		c.Check(interfaces.BeforePrepareSlot(s.iface, slotInfo), ErrorMatches,
+			`gpiod-chardev-control "chip" attribute must be a non-negative integer`, Commentf("chip %s", chip))
+	}
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "/dev/gpiochip[0-9]* rw,")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "/sys/devices/**/gpiochip[0-9]*/{,**} r,\n")
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestAppArmorSpecPinned(c *C) {
+	slot, _ := MockConnectedSlot(c, gpiodChardevControlPinnedGadgetYaml, nil, "gpiod-chardev-control")
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "/dev/gpiochip2 rw,")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "/dev/gpiochip[0-9]* rw,")
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\nioctl - GPIO_V2_GET_LINE_IOCTL\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\nioctl - GPIO_V2_LINE_SET_VALUES_IOCTL\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "\nioctl\n")
+	// the lines cannot be reconfigured by default
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "\nioctl - GPIO_V2_LINE_SET_CONFIG_IOCTL\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\n~ioctl - GPIO_V2_LINE_SET_CONFIG_IOCTL\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\n~ioctl - 4294967295|GPIO_V2_LINE_SET_CONFIG_IOCTL\n")
+}
+
+const gpiodChardevControlAllowConfigConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [gpiod-chardev-control]
+ other:
+  plugs: [gpio-lines]
+plugs:
+ gpiod-chardev-control:
+  allow-config: %s
+ gpio-lines:
+  interface: gpiod-chardev-control
+`
+
+func (s *gpiodChardevControlInterfaceSuite) TestSanitizePlugAllowConfig(c *C) {
+	_, plugInfo := MockConnectedPlug(c, fmt.Sprintf(gpiodChardevControlAllowConfigConsumerYaml, "true"), nil, "gpiod-chardev-control")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), IsNil)
+
+	_, plugInfo = MockConnectedPlug(c, fmt.Sprintf(gpiodChardevControlAllowConfigConsumerYaml, "yes-please"), nil, "gpiod-chardev-control")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), ErrorMatches, `gpiod-chardev-control "allow-config" attribute must be a boolean`)
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestSecCompSpecAllowConfig(c *C) {
+	plug, _ := MockConnectedPlug(c, fmt.Sprintf(gpiodChardevControlAllowConfigConsumerYaml, "true"), nil, "gpiod-chardev-control")
+	spec := seccomp.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\nioctl - GPIO_V2_LINE_SET_CONFIG_IOCTL\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "~ioctl - GPIO_V2_LINE_SET_CONFIG_IOCTL\n")
+
+	// seccomp cannot tell the lines of the plugs apart, the other plug of
+	// the snap may reconfigure its lines too
+	plug, _ = MockConnectedPlug(c, fmt.Sprintf(gpiodChardevControlAllowConfigConsumerYaml, "true"), nil, "gpio-lines")
+	spec = seccomp.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.other"), testutil.Contains, "\nioctl - GPIO_V2_LINE_SET_CONFIG_IOCTL\n")
+	c.Check(spec.SnippetForTag("snap.consumer.other"), Not(testutil.Contains), "~ioctl - GPIO_V2_LINE_SET_CONFIG_IOCTL\n")
+
+	// an explicit false keeps the default
+	plug, _ = MockConnectedPlug(c, fmt.Sprintf(gpiodChardevControlAllowConfigConsumerYaml, "false"), nil, "gpiod-chardev-control")
+	spec = seccomp.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "\n~ioctl - GPIO_V2_LINE_SET_CONFIG_IOCTL\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "\nioctl - GPIO_V2_LINE_SET_CONFIG_IOCTL\n")
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 2)
+	c.Assert(spec.Snippets(), testutil.Contains, `# gpiod-chardev-control
+SUBSYSTEM=="gpio", KERNEL=="gpiochip[0-9]*", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestUDevSpecPinned(c *C) {
+	slot, _ := MockConnectedSlot(c, gpiodChardevControlPinnedGadgetYaml, nil, "gpiod-chardev-control")
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, slot), IsNil)
+	c.Assert(spec.Snippets(), testutil.Contains, `# gpiod-chardev-control
+SUBSYSTEM=="gpio", KERNEL=="gpiochip2", TAG+="snap_consumer_app"`)
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows control of GPIO lines through the gpiochip character devices`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "gpiod-chardev-control")
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *gpiodChardevControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
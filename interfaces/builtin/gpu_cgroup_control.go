@@ -0,0 +1,86 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/cgroup"
+)
+
+const gpuCgroupControlSummary = `allows controlling the GPU resource limits of the cgroups of the snap`
+
+// The device cgroup of the snap is part of its confinement, being able to
+// allow devices in it lets the snap access any device node, so connections
+// need to be explicitly granted.
+const gpuCgroupControlBaseDeclarationSlots = `
+  gpu-cgroup-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const gpuCgroupControlConnectedPlugAppArmor = `
+# Description: Can set the GPU resource limits of the cgroups of the snap and
+# of the cgroups it creates below them, for instance to share GPUs between
+# tenants. This allows changing the devices which the snap can access.
+
+# cgroup v1: the device cgroups of the apps and hooks of the snap, set up by
+# snap-confine
+/sys/fs/cgroup/devices/snap.@{SNAP_INSTANCE_NAME}.*/ r,
+/sys/fs/cgroup/devices/snap.@{SNAP_INSTANCE_NAME}.*/**/ rw,
+/sys/fs/cgroup/devices/snap.@{SNAP_INSTANCE_NAME}.*/{,**/}devices.{allow,deny} w,
+/sys/fs/cgroup/devices/snap.@{SNAP_INSTANCE_NAME}.*/{,**/}devices.list r,
+/sys/fs/cgroup/devices/snap.@{SNAP_INSTANCE_NAME}.*/{,**/}cgroup.procs rw,
+
+# cgroup v2: the device memory (dmem) controller of the scopes of the apps and
+# of the services of the snap, the devices controller is implemented with BPF
+/sys/fs/cgroup/{,**/}snap.@{SNAP_INSTANCE_NAME}.*.{scope,service}/ r,
+/sys/fs/cgroup/{,**/}snap.@{SNAP_INSTANCE_NAME}.*.{scope,service}/**/ rw,
+/sys/fs/cgroup/{,**/}snap.@{SNAP_INSTANCE_NAME}.*.{scope,service}/{,**/}dmem.{min,low,max} rw,
+/sys/fs/cgroup/{,**/}snap.@{SNAP_INSTANCE_NAME}.*.{scope,service}/{,**/}dmem.current r,
+/sys/fs/cgroup/{,**/}snap.@{SNAP_INSTANCE_NAME}.*.{scope,service}/{,**/}cgroup.{procs,subtree_control} rw,
+/sys/fs/cgroup/{,**/}snap.@{SNAP_INSTANCE_NAME}.*.{scope,service}/{,**/}cgroup.controllers r,
+/sys/fs/cgroup/dmem.capacity r,
+`
+
+// systemd only supports creating cgroups below a service and enabling
+// controllers for them when the service has them delegated, so that tenants
+// created by the snap can also be given the usual resource limits.
+var gpuCgroupControlDelegatedControllers = []string{"cpu", "memory", "pids"}
+
+type gpuCgroupControlInterface struct {
+	commonInterface
+}
+
+func (iface *gpuCgroupControlInterface) CgroupConnectedPlug(spec *cgroup.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	return spec.DelegateControllers(gpuCgroupControlDelegatedControllers...)
+}
+
+func init() {
+	registerIface(&gpuCgroupControlInterface{commonInterface{
+		name:                  "gpu-cgroup-control",
+		summary:               gpuCgroupControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  gpuCgroupControlBaseDeclarationSlots,
+		connectedPlugAppArmor: gpuCgroupControlConnectedPlugAppArmor,
+	}})
+}
@@ -0,0 +1,137 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/cgroup"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type gpuCgroupControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&gpuCgroupControlInterfaceSuite{
+	iface: builtin.MustInterface("gpu-cgroup-control"),
+})
+
+const gpuCgroupControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [gpu-cgroup-control]
+`
+
+const gpuCgroupControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  gpu-cgroup-control:
+`
+
+func (s *gpuCgroupControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, gpuCgroupControlConsumerYaml, nil, "gpu-cgroup-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, gpuCgroupControlCoreYaml, nil, "gpu-cgroup-control")
+}
+
+func (s *gpuCgroupControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "gpu-cgroup-control")
+}
+
+func (s *gpuCgroupControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *gpuCgroupControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+func (s *gpuCgroupControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	// cgroup v1
+	c.Check(snippet, testutil.Contains, "\n/sys/fs/cgroup/devices/snap.@{SNAP_INSTANCE_NAME}.*/{,**/}devices.{allow,deny} w,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/fs/cgroup/devices/snap.@{SNAP_INSTANCE_NAME}.*/{,**/}devices.list r,\n")
+	// cgroup v2
+	c.Check(snippet, testutil.Contains, "\n/sys/fs/cgroup/{,**/}snap.@{SNAP_INSTANCE_NAME}.*.{scope,service}/{,**/}dmem.{min,low,max} rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/fs/cgroup/{,**/}snap.@{SNAP_INSTANCE_NAME}.*.{scope,service}/{,**/}cgroup.{procs,subtree_control} rw,\n")
+
+	// everything but the read-only capacity of the root cgroup is scoped
+	// to the cgroups of the snap
+	for _, line := range strings.Split(snippet, "\n") {
+		if !strings.HasPrefix(line, "/sys/fs/cgroup/") || line == "/sys/fs/cgroup/dmem.capacity r," {
+			continue
+		}
+		c.Check(line, Matches, `/sys/fs/cgroup/(devices/|\{,\*\*/\})snap\.@\{SNAP_INSTANCE_NAME\}\.\*[./].*`)
+	}
+}
+
+func (s *gpuCgroupControlInterfaceSuite) TestNoSecCompOrUDev(c *C) {
+	seccompSpec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(seccompSpec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(seccompSpec.Snippets(), HasLen, 0)
+
+	udevSpec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(udevSpec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(udevSpec.Snippets(), HasLen, 0)
+}
+
+func (s *gpuCgroupControlInterfaceSuite) TestCgroupSpec(c *C) {
+	spec := cgroup.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	c.Assert(spec.Controllers("snap.consumer.app"), DeepEquals, []string{"cpu", "memory", "pids"})
+
+	// nothing is delegated to the slot side
+	spec = cgroup.NewSpecification(s.slot.AppSet())
+	c.Assert(spec.AddConnectedSlot(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), HasLen, 0)
+}
+
+func (s *gpuCgroupControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows controlling the GPU resource limits of the cgroups of the snap`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "gpu-cgroup-control")
+}
+
+func (s *gpuCgroupControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *gpuCgroupControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -0,0 +1,69 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const gpuFirmwareControlSummary = `allows loading firmware to GPUs through sysfs`
+
+// The firmware runs on the devices with access to the memory of the system,
+// connections need to be explicitly granted.
+const gpuFirmwareControlBaseDeclarationSlots = `
+  gpu-firmware-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const gpuFirmwareControlConnectedPlugAppArmor = `
+# Description: Can load firmware to GPUs and other devices through the sysfs
+# interfaces of the kernel firmware loader, and read the firmware files of the
+# system.
+# https://www.kernel.org/doc/html/latest/driver-api/firmware/fallback-mechanisms.html
+# https://www.kernel.org/doc/html/latest/driver-api/firmware/fw_upload.html
+
+# the firmware search path, including the custom path of firmware_class
+/{,usr/}lib/firmware/{,**} r,
+/sys/module/firmware_class/parameters/path r,
+
+# the sysfs fallback mechanism and the firmware upload API, which both
+# expose a loading and a data file for each request, the class directory
+# entries are symlinks to the devices
+/sys/class/firmware/ r,
+/sys/class/firmware/timeout rw,
+/sys/devices/**/firmware/*/ r,
+/sys/devices/**/firmware/*/{loading,data,cancel} rw,
+/sys/devices/**/firmware/*/{status,error,remaining_size} r,
+
+# the devfreq state of the GPUs, to follow them while the firmware is
+# reloaded
+/sys/class/devfreq/ r,
+/sys/devices/**/devfreq/*/{,**} r,
+`
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "gpu-firmware-control",
+		summary:               gpuFirmwareControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  gpuFirmwareControlBaseDeclarationSlots,
+		connectedPlugAppArmor: gpuFirmwareControlConnectedPlugAppArmor,
+	})
+}
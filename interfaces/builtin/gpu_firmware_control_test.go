@@ -0,0 +1,122 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type gpuFirmwareControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&gpuFirmwareControlInterfaceSuite{
+	iface: builtin.MustInterface("gpu-firmware-control"),
+})
+
+const gpuFirmwareControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [gpu-firmware-control]
+`
+
+const gpuFirmwareControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  gpu-firmware-control:
+`
+
+func (s *gpuFirmwareControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, gpuFirmwareControlConsumerYaml, nil, "gpu-firmware-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, gpuFirmwareControlCoreYaml, nil, "gpu-firmware-control")
+}
+
+func (s *gpuFirmwareControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "gpu-firmware-control")
+}
+
+func (s *gpuFirmwareControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *gpuFirmwareControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+func (s *gpuFirmwareControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	// the firmware search path is read-only
+	c.Check(snippet, testutil.Contains, "\n/{,usr/}lib/firmware/{,**} r,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/module/firmware_class/parameters/path r,\n")
+	// firmware is loaded through sysfs
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/**/firmware/*/{loading,data,cancel} rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/**/devfreq/*/{,**} r,\n")
+
+	// nothing outside of sysfs is writable
+	for _, line := range strings.Split(snippet, "\n") {
+		if strings.HasSuffix(line, " rw,") {
+			c.Check(line, Matches, `/sys/.* rw,`)
+		}
+	}
+}
+
+func (s *gpuFirmwareControlInterfaceSuite) TestNoSecCompOrUDev(c *C) {
+	seccompSpec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(seccompSpec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(seccompSpec.Snippets(), HasLen, 0)
+
+	udevSpec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(udevSpec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(udevSpec.Snippets(), HasLen, 0)
+}
+
+func (s *gpuFirmwareControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows loading firmware to GPUs through sysfs`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "gpu-firmware-control")
+}
+
+func (s *gpuFirmwareControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *gpuFirmwareControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
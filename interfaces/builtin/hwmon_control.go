@@ -0,0 +1,58 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const hwmonControlSummary = `allows controlling the fans of the system through hwmon`
+
+// Stopping the fans can overheat and damage the hardware, connections need to
+// be explicitly granted.
+const hwmonControlBaseDeclarationSlots = `
+  hwmon-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const hwmonControlConnectedPlugAppArmor = `
+# Description: Can read the hardware monitoring sensors and set the speed of
+# the fans of the system.
+# https://www.kernel.org/doc/html/latest/hwmon/sysfs-interface.html
+
+# /sys/class/hwmon/hwmon* are symlinks to the hwmon devices
+/sys/class/hwmon/ r,
+/sys/devices/**/hwmon/hwmon[0-9]*/ r,
+/sys/devices/**/hwmon/hwmon[0-9]*/** r,
+
+# the PWM duty cycle of the fans along with their control method
+# (pwm*_enable), mode and automatic fan speed control settings
+/sys/devices/**/hwmon/hwmon[0-9]*/pwm[0-9]* rw,
+`
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "hwmon-control",
+		summary:               hwmonControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  hwmonControlBaseDeclarationSlots,
+		connectedPlugAppArmor: hwmonControlConnectedPlugAppArmor,
+	})
+}
@@ -0,0 +1,118 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type hwmonControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&hwmonControlInterfaceSuite{
+	iface: builtin.MustInterface("hwmon-control"),
+})
+
+const hwmonControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [hwmon-control]
+`
+
+const hwmonControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  hwmon-control:
+`
+
+func (s *hwmonControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, hwmonControlConsumerYaml, nil, "hwmon-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, hwmonControlCoreYaml, nil, "hwmon-control")
+}
+
+func (s *hwmonControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "hwmon-control")
+}
+
+func (s *hwmonControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *hwmonControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+func (s *hwmonControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/**/hwmon/hwmon[0-9]*/pwm[0-9]* rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/**/hwmon/hwmon[0-9]*/** r,\n")
+
+	// only the pwm attributes are writable
+	for _, line := range strings.Split(snippet, "\n") {
+		if strings.HasSuffix(line, " rw,") || strings.HasSuffix(line, " w,") {
+			c.Check(line, Equals, "/sys/devices/**/hwmon/hwmon[0-9]*/pwm[0-9]* rw,")
+		}
+	}
+}
+
+func (s *hwmonControlInterfaceSuite) TestNoSecCompOrUDev(c *C) {
+	seccompSpec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(seccompSpec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(seccompSpec.Snippets(), HasLen, 0)
+
+	udevSpec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(udevSpec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(udevSpec.Snippets(), HasLen, 0)
+}
+
+func (s *hwmonControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows controlling the fans of the system through hwmon`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "hwmon-control")
+}
+
+func (s *hwmonControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *hwmonControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -0,0 +1,438 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	seccomp_sandbox "github.com/snapcore/snapd/sandbox/seccomp"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/snapdtool"
+	"github.com/snapcore/snapd/strutil"
+)
+
+const introspectPlugSnapYaml = `name: consumer
+version: 0
+apps:
+  app:
+    plugs: [plug]
+plugs:
+  plug:
+    interface: %s
+`
+
+const introspectSlotSnapYaml = `name: core
+version: 0
+type: os
+slots:
+  slot:
+    interface: %s
+`
+
+const introspectAppSlotSnapYaml = `name: provider
+version: 0
+apps:
+  app:
+    slots: [slot]
+slots:
+  slot:
+    interface: %s
+`
+
+// introspectConnection returns a synthetic connection of the given interface
+// between an application snap plug and a core snap slot, with no attributes
+// set. An error is returned for interfaces which cannot be sanitized without
+// extra attributes.
+func introspectConnection(iface interfaces.Interface) (*interfaces.ConnectedPlug, *interfaces.ConnectedSlot, error) {
+	return introspectConnectionWithSlot(iface, introspectSlotSnapYaml)
+}
+
+// introspectConnectionWithSlot is like introspectConnection but the slot is
+// provided by the snap described by the given snap.yaml format.
+func introspectConnectionWithSlot(iface interfaces.Interface, slotSnapYaml string) (*interfaces.ConnectedPlug, *interfaces.ConnectedSlot, error) {
+	plugSnap, err := snap.InfoFromSnapYaml([]byte(fmt.Sprintf(introspectPlugSnapYaml, iface.Name())))
+	if err != nil {
+		return nil, nil, err
+	}
+	slotSnap, err := snap.InfoFromSnapYaml([]byte(fmt.Sprintf(slotSnapYaml, iface.Name())))
+	if err != nil {
+		return nil, nil, err
+	}
+	// plugs and slots which fail sanitization are dropped while
+	// loading the snap, the reason is recorded as a bad interface
+	plugInfo, ok := plugSnap.Plugs["plug"]
+	if !ok {
+		return nil, nil, fmt.Errorf("cannot prepare plug: %s", plugSnap.BadInterfaces["plug"])
+	}
+	slotInfo, ok := slotSnap.Slots["slot"]
+	if !ok {
+		return nil, nil, fmt.Errorf("cannot prepare slot: %s", slotSnap.BadInterfaces["slot"])
+	}
+	plugAppSet, err := interfaces.NewSnapAppSet(plugSnap, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	slotAppSet, err := interfaces.NewSnapAppSet(slotSnap, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	plug := interfaces.NewConnectedPlug(plugInfo, plugAppSet, nil, nil)
+	slot := interfaces.NewConnectedSlot(slotInfo, slotAppSet, nil, nil)
+	return plug, slot, nil
+}
+
+// seccompSyscalls returns the syscall names allowed by the given seccomp
+// snippet, including the ones which are logged. Comments, rules denying a
+// syscall and special directives such as @unrestricted are ignored.
+func seccompSyscalls(snippet string) []string {
+	var syscalls []string
+	for _, line := range strings.Split(snippet, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		name := fields[0]
+		if strings.HasPrefix(name, "#") || strings.HasPrefix(name, "~") || strings.HasPrefix(name, "@") {
+			continue
+		}
+		syscalls = append(syscalls, strings.TrimPrefix(name, "?"))
+	}
+	return syscalls
+}
+
+// plugSecCompSnippets returns the seccomp snippets which the plug side of the
+// given interface contributes to a consuming application.
+func plugSecCompSnippets(iface interfaces.Interface) ([]string, error) {
+	plug, slot, err := introspectConnection(iface)
+	if err != nil {
+		return nil, err
+	}
+	spec := seccomp.NewSpecification(plug.AppSet())
+	if err := spec.AddPermanentPlug(iface, plug.Snap().Plugs[plug.Name()]); err != nil {
+		return nil, err
+	}
+	if err := spec.AddConnectedPlug(iface, plug, slot); err != nil {
+		return nil, err
+	}
+	var snippets []string
+	// all the snippets are added for the single app of the consumer snap
+	for _, tag := range spec.SecurityTags() {
+		snippets = append(snippets, spec.SnippetForTag(tag))
+	}
+	return snippets, nil
+}
+
+// plugSyscalls returns the syscalls which the plug side of the given
+// interface allows to a consuming application.
+func plugSyscalls(iface interfaces.Interface) ([]string, error) {
+	snippets, err := plugSecCompSnippets(iface)
+	if err != nil {
+		return nil, err
+	}
+	var syscalls []string
+	for _, snippet := range snippets {
+		syscalls = append(syscalls, seccompSyscalls(snippet)...)
+	}
+	return syscalls, nil
+}
+
+var secCompCompilerLookup = snapdtool.InternalToolPath
+
+// CompileSecCompBPF compiles the seccomp policy which the plug side of the
+// given interface contributes to a consuming application, with the
+// snap-seccomp compiler of snapd, for the given dpkg architecture. Only the
+// rules of the interface are compiled, not the default template, such that the
+// resulting filter can be inspected on its own. The architecture must be
+// either the native one or its compat one, see snap-seccomp.
+func CompileSecCompBPF(iface interfaces.Interface, arch string) ([]byte, error) {
+	snippets, err := plugSecCompSnippets(iface)
+	if err != nil {
+		return nil, err
+	}
+	if len(snippets) == 0 {
+		return nil, fmt.Errorf("interface %q has no seccomp policy", iface.Name())
+	}
+	compiler, err := seccomp_sandbox.NewCompiler(secCompCompilerLookup)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "snap-seccomp-bpf-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	in := filepath.Join(dir, iface.Name()+".src")
+	out := filepath.Join(dir, iface.Name()+".bin2")
+	if err := os.WriteFile(in, []byte(strings.Join(snippets, "\n")), 0644); err != nil {
+		return nil, err
+	}
+	if err := compiler.CompileForArch(in, out, arch); err != nil {
+		return nil, fmt.Errorf("cannot compile the seccomp policy of interface %q: %v", iface.Name(), err)
+	}
+	return os.ReadFile(out)
+}
+
+// InterfacesGrantingSyscall returns the sorted names of the built-in
+// interfaces whose plug side seccomp policy allows the given syscall. This is
+// meant to help figuring out which interface to connect to when a confined
+// snap gets a seccomp denial.
+func InterfacesGrantingSyscall(syscall string) []string {
+	var names []string
+	for _, iface := range Interfaces() {
+		syscalls, err := plugSyscalls(iface)
+		if err != nil {
+			continue
+		}
+		if strutil.ListContains(syscalls, syscall) {
+			names = append(names, iface.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseAppArmorRules returns the AppArmor rules which the plug side of the
+// given interface contributes to the profile of a consuming application, as
+// parsed rule objects rather than opaque snippets.
+func ParseAppArmorRules(iface interfaces.Interface) ([]apparmor.Rule, error) {
+	plug, slot, err := introspectConnection(iface)
+	if err != nil {
+		return nil, err
+	}
+	spec := apparmor.NewSpecification(plug.AppSet())
+	if err := spec.AddPermanentPlug(iface, plug.Snap().Plugs[plug.Name()]); err != nil {
+		return nil, err
+	}
+	if err := spec.AddConnectedPlug(iface, plug, slot); err != nil {
+		return nil, err
+	}
+	var rules []apparmor.Rule
+	// all the rules are added for the single app of the consumer snap
+	for _, tag := range spec.SecurityTags() {
+		tagRules, err := apparmor.ParseRules(spec.SnippetForTag(tag))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, tagRules...)
+	}
+	return rules, nil
+}
+
+type regoFile struct {
+	Path        string `json:"path"`
+	Permissions string `json:"permissions"`
+	Owner       bool   `json:"owner,omitempty"`
+}
+
+type regoMount struct {
+	FSType string `json:"fstype,omitempty"`
+	Source string `json:"source,omitempty"`
+	Target string `json:"target,omitempty"`
+}
+
+// regoPackage returns the name of the Rego package of the given interface.
+func regoPackage(iface interfaces.Interface) string {
+	return "snapd.interfaces." + strings.ReplaceAll(iface.Name(), "-", "_")
+}
+
+// ExportRego returns a Rego module describing what the plug side of the given
+// interface grants to a consuming application: the allowed and denied file
+// paths, the capabilities, the mounts and the syscalls. The module only
+// carries data, meant to be evaluated by external policy engines, for
+// instance as data.snapd.interfaces.fuse_support.syscalls. Rules which are
+// neither file, capability nor mount rules are not exported.
+func ExportRego(iface interfaces.Interface) (string, error) {
+	rules, err := ParseAppArmorRules(iface)
+	if err != nil {
+		return "", err
+	}
+	syscalls, err := plugSyscalls(iface)
+	if err != nil {
+		return "", err
+	}
+	files := []regoFile{}
+	deniedFiles := []regoFile{}
+	capabilities := []string{}
+	mounts := []regoMount{}
+	for _, rule := range rules {
+		switch {
+		case rule.Kind == apparmor.FileRule:
+			file := regoFile{Path: rule.Path, Permissions: rule.Permissions, Owner: rule.HasQualifier("owner")}
+			if rule.HasQualifier("deny") {
+				deniedFiles = append(deniedFiles, file)
+			} else {
+				files = append(files, file)
+			}
+		case rule.HasQualifier("deny"):
+			// only denied files are of interest, other deny rules
+			// take away from the rules of the other interfaces
+		case rule.Kind == apparmor.CapabilityRule:
+			for _, capability := range rule.Capabilities {
+				if !strutil.ListContains(capabilities, capability) {
+					capabilities = append(capabilities, capability)
+				}
+			}
+		case isMountRule(rule):
+			mounts = append(mounts, regoMount{FSType: rule.FSType, Source: rule.Source, Target: rule.Target})
+		}
+	}
+	sort.Strings(capabilities)
+	syscalls = strutil.Deduplicate(syscalls)
+	sort.Strings(syscalls)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\n", regoPackage(iface))
+	for _, doc := range []struct {
+		name  string
+		value any
+	}{
+		{"files", files},
+		{"denied_files", deniedFiles},
+		{"capabilities", capabilities},
+		{"mounts", mounts},
+		{"syscalls", syscalls},
+	} {
+		value, err := json.MarshalIndent(doc.value, "", "\t")
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&buf, "\n%s := %s\n", doc.name, value)
+	}
+	return buf.String(), nil
+}
+
+// isMountRule returns whether the given rule is a mount rule, as opposed to
+// a remount or umount rule.
+func isMountRule(rule apparmor.Rule) bool {
+	return rule.Kind == apparmor.MountRule && strings.HasPrefix(rule.Text, "mount")
+}
+
+// PolicySnippets holds the snippets which one side of a connection
+// contributes to each of the security backends.
+type PolicySnippets struct {
+	AppArmor []string `json:"apparmor,omitempty"`
+	SecComp  []string `json:"seccomp,omitempty"`
+	UDev     []string `json:"udev,omitempty"`
+}
+
+// InterfacePolicy holds the security policy of a connection of an interface,
+// as seen from the consuming application of the plug side and from the
+// providing application of the slot side. Error is set when no synthetic
+// connection could be made for one of the sides, whose policy is then left
+// unset.
+type InterfacePolicy struct {
+	ConnectedPlug *PolicySnippets `json:"connected-plug,omitempty"`
+	ConnectedSlot *PolicySnippets `json:"connected-slot,omitempty"`
+	Error         string          `json:"error,omitempty"`
+}
+
+// connectedPlugSnippets returns the snippets which ConnectedPlug methods of
+// the given interface contribute to the application of the plug.
+func connectedPlugSnippets(iface interfaces.Interface, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) (*PolicySnippets, error) {
+	appArmorSpec := apparmor.NewSpecification(plug.AppSet())
+	if err := appArmorSpec.AddConnectedPlug(iface, plug, slot); err != nil {
+		return nil, err
+	}
+	secCompSpec := seccomp.NewSpecification(plug.AppSet())
+	if err := secCompSpec.AddConnectedPlug(iface, plug, slot); err != nil {
+		return nil, err
+	}
+	udevSpec := udev.NewSpecification(plug.AppSet())
+	if err := udevSpec.AddConnectedPlug(iface, plug, slot); err != nil {
+		return nil, err
+	}
+	return policySnippets(appArmorSpec, secCompSpec, udevSpec), nil
+}
+
+// connectedSlotSnippets returns the snippets which ConnectedSlot methods of
+// the given interface contribute to the application of the slot.
+func connectedSlotSnippets(iface interfaces.Interface, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) (*PolicySnippets, error) {
+	appArmorSpec := apparmor.NewSpecification(slot.AppSet())
+	if err := appArmorSpec.AddConnectedSlot(iface, plug, slot); err != nil {
+		return nil, err
+	}
+	secCompSpec := seccomp.NewSpecification(slot.AppSet())
+	if err := secCompSpec.AddConnectedSlot(iface, plug, slot); err != nil {
+		return nil, err
+	}
+	udevSpec := udev.NewSpecification(slot.AppSet())
+	if err := udevSpec.AddConnectedSlot(iface, plug, slot); err != nil {
+		return nil, err
+	}
+	return policySnippets(appArmorSpec, secCompSpec, udevSpec), nil
+}
+
+func policySnippets(appArmorSpec *apparmor.Specification, secCompSpec *seccomp.Specification, udevSpec *udev.Specification) *PolicySnippets {
+	snippets := &PolicySnippets{UDev: udevSpec.Snippets()}
+	// the synthetic snaps have a single app, the snippets of all the tags
+	// are the ones of that app
+	for _, tag := range appArmorSpec.SecurityTags() {
+		snippets.AppArmor = append(snippets.AppArmor, appArmorSpec.SnippetForTag(tag))
+	}
+	for _, tag := range secCompSpec.SecurityTags() {
+		snippets.SecComp = append(snippets.SecComp, secCompSpec.SnippetForTag(tag))
+	}
+	return snippets
+}
+
+// ConnectedPolicy returns the security policy which a connection of the given
+// interface with no attributes set results in. The plug side is connected to
+// a slot of the core snap, while the slot side is provided by an application
+// snap, the policy of both is computed using the release information the
+// process currently sees, as in release.ReleaseInfo and release.OnClassic.
+func ConnectedPolicy(iface interfaces.Interface) *InterfacePolicy {
+	policy := &InterfacePolicy{}
+	plug, slot, err := introspectConnection(iface)
+	if err == nil {
+		policy.ConnectedPlug, err = connectedPlugSnippets(iface, plug, slot)
+	}
+	if err != nil {
+		policy.Error = err.Error()
+		return policy
+	}
+	plug, slot, err = introspectConnectionWithSlot(iface, introspectAppSlotSnapYaml)
+	if err == nil {
+		policy.ConnectedSlot, err = connectedSlotSnippets(iface, plug, slot)
+	}
+	if err != nil {
+		policy.Error = err.Error()
+	}
+	return policy
+}
+
+// ConnectedPolicies returns the security policy of every built-in interface,
+// keyed by interface name. See ConnectedPolicy for details.
+func ConnectedPolicies() map[string]*InterfacePolicy {
+	policies := make(map[string]*InterfacePolicy)
+	for _, iface := range Interfaces() {
+		policies[iface.Name()] = ConnectedPolicy(iface)
+	}
+	return policies
+}
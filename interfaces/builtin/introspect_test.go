@@ -0,0 +1,398 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/ifacetest"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/release"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type introspectSuite struct{}
+
+var _ = Suite(&introspectSuite{})
+
+func (s *introspectSuite) TestInterfacesGrantingSyscallMount(c *C) {
+	names := builtin.InterfacesGrantingSyscall("mount")
+	c.Check(names, testutil.Contains, "fuse-support")
+	c.Check(names, Not(testutil.Contains), "network")
+	c.Check(sort.StringsAreSorted(names), Equals, true)
+}
+
+func (s *introspectSuite) TestInterfacesGrantingSyscallUnknown(c *C) {
+	c.Check(builtin.InterfacesGrantingSyscall("not-a-syscall"), HasLen, 0)
+}
+
+func (s *introspectSuite) TestInterfacesGrantingSyscallLogged(c *C) {
+	restore := builtin.MockInterfaces(map[string]interfaces.Interface{
+		"logged": &ifacetest.TestInterface{
+			InterfaceName: "logged",
+			SecCompConnectedPlugCallback: func(spec *seccomp.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+				spec.AddSnippet("?getrandom\n?mount - - - |MS_NOSUID\n~ptrace\n")
+				return nil
+			},
+		},
+	})
+	defer restore()
+
+	// the rules which log the syscalls they allow are found too
+	c.Check(builtin.InterfacesGrantingSyscall("getrandom"), DeepEquals, []string{"logged"})
+	c.Check(builtin.InterfacesGrantingSyscall("mount"), DeepEquals, []string{"logged"})
+	c.Check(builtin.InterfacesGrantingSyscall("?getrandom"), HasLen, 0)
+	c.Check(builtin.InterfacesGrantingSyscall("ptrace"), HasLen, 0)
+}
+
+func (s *introspectSuite) TestSeccompSyscalls(c *C) {
+	snippet := `
+# Description: comment
+mount
+umount2 - MNT_DETACH
+~ptrace
+@unrestricted
+
+bind
+?getrandom
+`
+	c.Check(builtin.SeccompSyscalls(snippet), DeepEquals, []string{"mount", "umount2", "bind", "getrandom"})
+}
+
+func (s *introspectSuite) TestParseAppArmorRulesFuseSupport(c *C) {
+	rules, err := builtin.ParseAppArmorRules(builtin.MustInterface("fuse-support"))
+	c.Assert(err, IsNil)
+
+	byKind := make(map[apparmor.RuleKind][]apparmor.Rule)
+	for _, rule := range rules {
+		byKind[rule.Kind] = append(byKind[rule.Kind], rule)
+	}
+	c.Check(byKind[apparmor.OtherRule], HasLen, 0)
+	c.Check(byKind[apparmor.NetworkRule], HasLen, 0)
+
+	c.Assert(byKind[apparmor.CapabilityRule], HasLen, 1)
+	c.Check(byKind[apparmor.CapabilityRule][0].Capabilities, DeepEquals, []string{"sys_admin"})
+
+	c.Check(byKind[apparmor.MountRule], HasLen, 8)
+	for _, rule := range byKind[apparmor.MountRule] {
+		c.Check(rule.Text, Matches, `mount fstype=fuse\.\* options=\((ro|rw),nosuid,nodev\) \*\* -> .*`)
+	}
+
+	files := make(map[string]apparmor.Rule)
+	for _, rule := range byKind[apparmor.FileRule] {
+		files[rule.Path] = rule
+	}
+	c.Check(files, HasLen, 6)
+	c.Check(files["/dev/fuse"].Permissions, Equals, "rw")
+	c.Check(files["/run/fuse-support/@{SNAP_NAME}/@{SNAP_INSTANCE_KEY}/control.sock"].Permissions, Equals, "rw")
+	c.Check(files["/etc/fuse.conf"].Permissions, Equals, "r")
+	c.Check(files["/etc/fuse.conf"].HasQualifier("deny"), Equals, true)
+	c.Check(files["/sys/fs/fuse/"].Permissions, Equals, "r")
+	c.Check(files["/sys/fs/fuse/**"].Permissions, Equals, "r")
+}
+
+func (s *introspectSuite) TestParseAppArmorRulesNoAppArmor(c *C) {
+	rules, err := builtin.ParseAppArmorRules(builtin.MustInterface("pidfd-control"))
+	c.Assert(err, IsNil)
+	c.Check(rules, HasLen, 0)
+}
+
+func (s *introspectSuite) TestExportRegoFuseSupport(c *C) {
+	rego, err := builtin.ExportRego(builtin.MustInterface("fuse-support"))
+	c.Assert(err, IsNil)
+	c.Check(strings.HasPrefix(rego, "package snapd.interfaces.fuse_support\n"), Equals, true)
+
+	// the module only defines data documents, in JSON syntax
+	docs := make(map[string]json.RawMessage)
+	for _, part := range strings.Split(rego, "\n\n")[1:] {
+		name, value, ok := strings.Cut(part, " := ")
+		c.Assert(ok, Equals, true, Commentf("%s", part))
+		docs[name] = json.RawMessage(value)
+	}
+	c.Assert(docs, HasLen, 5)
+
+	var files, deniedFiles []map[string]any
+	c.Assert(json.Unmarshal(docs["files"], &files), IsNil)
+	c.Assert(json.Unmarshal(docs["denied_files"], &deniedFiles), IsNil)
+	c.Check(files, testutil.DeepContains, map[string]any{"path": "/dev/fuse", "permissions": "rw"})
+	c.Check(files, testutil.DeepContains, map[string]any{"path": "/sys/fs/fuse/**", "permissions": "r"})
+	c.Check(deniedFiles, DeepEquals, []map[string]any{{"path": "/etc/fuse.conf", "permissions": "r"}})
+
+	var capabilities, syscalls []string
+	c.Assert(json.Unmarshal(docs["capabilities"], &capabilities), IsNil)
+	c.Assert(json.Unmarshal(docs["syscalls"], &syscalls), IsNil)
+	c.Check(capabilities, DeepEquals, []string{"sys_admin"})
+	c.Check(syscalls, DeepEquals, []string{"mount"})
+
+	var mounts []map[string]any
+	c.Assert(json.Unmarshal(docs["mounts"], &mounts), IsNil)
+	c.Check(mounts, HasLen, 8)
+	c.Check(mounts, testutil.DeepContains, map[string]any{
+		"fstype": "fuse.*",
+		"source": "**",
+		"target": "/var/snap/@{SNAP_NAME}/common/{,**/}",
+	})
+}
+
+func (s *introspectSuite) TestExportRegoNoAppArmor(c *C) {
+	rego, err := builtin.ExportRego(builtin.MustInterface("pidfd-control"))
+	c.Assert(err, IsNil)
+	c.Check(rego, Equals, `package snapd.interfaces.pidfd_control
+
+files := []
+
+denied_files := []
+
+capabilities := []
+
+mounts := []
+
+syscalls := [
+	"pidfd_getfd",
+	"pidfd_open",
+	"pidfd_send_signal"
+]
+`)
+}
+
+func (s *introspectSuite) TestConnectedPolicySecCompOnly(c *C) {
+	policy := builtin.ConnectedPolicy(builtin.MustInterface("pidfd-control"))
+	c.Check(policy.Error, Equals, "")
+	c.Assert(policy.ConnectedPlug, NotNil)
+	c.Check(policy.ConnectedPlug.AppArmor, HasLen, 0)
+	c.Check(policy.ConnectedPlug.UDev, HasLen, 0)
+	c.Assert(policy.ConnectedPlug.SecComp, HasLen, 1)
+	c.Check(policy.ConnectedPlug.SecComp[0], testutil.Contains, "\npidfd_open\n")
+	c.Check(policy.ConnectedSlot, DeepEquals, &builtin.PolicySnippets{UDev: []string{}})
+
+	data, err := json.Marshal(policy)
+	c.Assert(err, IsNil)
+	c.Check(string(data), Matches, `\{"connected-plug":\{"seccomp":\[".*pidfd_getfd\\n\\n"\]\},"connected-slot":\{\}\}`)
+}
+
+func (s *introspectSuite) TestConnectedPolicySlotSide(c *C) {
+	policy := builtin.ConnectedPolicy(builtin.MustInterface("network-manager"))
+	c.Check(policy.Error, Equals, "")
+	c.Assert(policy.ConnectedSlot, NotNil)
+	c.Assert(policy.ConnectedSlot.AppArmor, HasLen, 1)
+	c.Check(policy.ConnectedSlot.AppArmor[0], testutil.Contains, `peer=(label="snap.consumer.app")`)
+}
+
+func (s *introspectSuite) TestConnectedPolicyError(c *C) {
+	policy := builtin.ConnectedPolicy(builtin.MustInterface("content"))
+	c.Check(policy, DeepEquals, &builtin.InterfacePolicy{
+		Error: "cannot prepare plug: content plug must contain target path",
+	})
+}
+
+func (s *introspectSuite) TestConnectedPoliciesPinnedRelease(c *C) {
+	policies := func(onClassic bool) map[string]*builtin.InterfacePolicy {
+		defer release.MockOnClassic(onClassic)()
+		defer release.MockReleaseInfo(&release.OS{ID: "ubuntu", VersionID: "24.04"})()
+		return builtin.ConnectedPolicies()
+	}
+	classic := policies(true)
+	c.Check(classic, HasLen, len(builtin.Interfaces()))
+	c.Check(policies(true), DeepEquals, classic)
+	core := policies(false)
+	c.Check(core, HasLen, len(classic))
+	c.Check(core["audio-playback"], Not(DeepEquals), classic["audio-playback"])
+}
+
+// mountAnywhereInterfaces are the interfaces whose default mount rules do
+// not restrict the mount point, they are super-privileged already and are
+// left out of the mount conflicts analysis.
+var mountAnywhereInterfaces = map[string]bool{
+	"docker-support":    true,
+	"hugepages-control": true,
+	"network-control":   true,
+}
+
+// snapOwnedMountPointRegexp matches the mount points below the data
+// directories of the snap, mounting filesystems of different types there
+// cannot affect anything but the snap itself.
+var snapOwnedMountPointRegexp = regexp.MustCompile(`^(/var/snap/(\{@\{SNAP_NAME\},@\{SNAP_INSTANCE_NAME\}\}|@\{SNAP_NAME\}|@\{SNAP_INSTANCE_NAME\})|/home/\*/snap/@\{SNAP_INSTANCE_NAME\})/`)
+
+func (s *introspectSuite) TestNoConflictingMountRules(c *C) {
+	type ifaceRule struct {
+		iface string
+		rule  apparmor.Rule
+	}
+	var mountRules []ifaceRule
+	for _, iface := range builtin.Interfaces() {
+		if mountAnywhereInterfaces[iface.Name()] {
+			continue
+		}
+		// interfaces which require attributes have no default rules
+		rules, err := builtin.ParseAppArmorRules(iface)
+		if err != nil {
+			continue
+		}
+		for _, rule := range rules {
+			if rule.Kind != apparmor.MountRule || rule.HasQualifier("deny") {
+				continue
+			}
+			c.Check(rule.Target, Not(Equals), "", Commentf("%s allows mounting anywhere: %s", iface.Name(), rule.Text))
+			if snapOwnedMountPointRegexp.MatchString(rule.Target) {
+				continue
+			}
+			mountRules = append(mountRules, ifaceRule{iface.Name(), rule})
+		}
+	}
+	for i, a := range mountRules {
+		for _, b := range mountRules[i+1:] {
+			if a.iface == b.iface {
+				continue
+			}
+			c.Check(apparmor.MountRulesConflict(a.rule, b.rule), Equals, false,
+				Commentf("%s rule %q conflicts with %s rule %q", a.iface, a.rule.Text, b.iface, b.rule.Text))
+		}
+	}
+}
+
+// systemMountInterfaces are the interfaces whose default mount rules target
+// system directories, as they are meant to.
+var systemMountInterfaces = map[string]bool{
+	"bpf-map-pin-control": true,
+	"classic-support":     true,
+	"dm-crypt":            true,
+	"docker-support":      true,
+	"greengrass-support":  true,
+	"hugepages-control":   true,
+	"kubernetes-support":  true,
+	"network-control":     true,
+}
+
+func (s *introspectSuite) TestMountTargetsSafe(c *C) {
+	checked := 0
+	for _, iface := range builtin.Interfaces() {
+		if systemMountInterfaces[iface.Name()] {
+			continue
+		}
+		// interfaces which require attributes have no default rules
+		rules, err := builtin.ParseAppArmorRules(iface)
+		if err != nil {
+			continue
+		}
+		for _, rule := range rules {
+			if rule.Kind != apparmor.MountRule {
+				continue
+			}
+			c.Check(apparmor.ValidateMountTargetSafe(rule), IsNil, Commentf(iface.Name()))
+			checked++
+		}
+	}
+	// fuse-support, ext4-mount-control, nfs-mount and cifs-mount all have
+	// mount rules
+	c.Check(checked >= 8+12+4+4, Equals, true)
+}
+
+func (s *introspectSuite) TestSnapOwnedMountPointRegexp(c *C) {
+	for _, target := range []string{
+		"/var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/common/{,**}",
+		"/var/snap/@{SNAP_INSTANCE_NAME}/common/**",
+		"/var/snap/@{SNAP_NAME}/@{SNAP_REVISION}/{,**/}",
+		"/home/*/snap/@{SNAP_INSTANCE_NAME}/@{SNAP_REVISION}/{,**/}",
+	} {
+		c.Check(snapOwnedMountPointRegexp.MatchString(target), Equals, true, Commentf(target))
+	}
+	for _, target := range []string{
+		"/var/snap/",
+		"/var/snap/**",
+		"/var/snap/@{SNAP_INSTANCE_NAME}.other/",
+		"/{,run/}media/**",
+		"/",
+	} {
+		c.Check(snapOwnedMountPointRegexp.MatchString(target), Equals, false, Commentf(target))
+	}
+}
+
+func (s *introspectSuite) mockSnapSeccomp(c *C) (cmd *testutil.MockCmd, restore func()) {
+	// the mocked compiler prefixes the profile with the architecture
+	cmd = testutil.MockCommand(c, "snap-seccomp", `
+if [ "$4" = "s390x" ]; then
+	echo "error: cannot compile for architecture \"$4\" on this host"
+	exit 1
+fi
+printf 'bpf for %s\n' "$4" > "$3"
+cat "$2" >> "$3"
+`)
+	restoreLookup := builtin.MockSecCompCompilerLookup(func(name string) (string, error) {
+		return cmd.Exe(), nil
+	})
+	return cmd, func() {
+		restoreLookup()
+		cmd.Restore()
+	}
+}
+
+func (s *introspectSuite) TestCompileSecCompBPFFuseSupport(c *C) {
+	cmd, restore := s.mockSnapSeccomp(c)
+	defer restore()
+
+	bpf, err := builtin.CompileSecCompBPF(builtin.MustInterface("fuse-support"), "amd64")
+	c.Assert(err, IsNil)
+	c.Check(string(bpf), testutil.Contains, "bpf for amd64\n")
+	c.Check(string(bpf), testutil.Contains, "\nmount - - - |MS_NOSUID|MS_NODEV\n")
+
+	compatBpf, err := builtin.CompileSecCompBPF(builtin.MustInterface("fuse-support"), "i386")
+	c.Assert(err, IsNil)
+	c.Check(string(compatBpf), testutil.Contains, "bpf for i386\n")
+	c.Check(compatBpf, Not(DeepEquals), bpf)
+
+	calls := cmd.Calls()
+	c.Assert(calls, HasLen, 2)
+	c.Check(calls[0][:2], DeepEquals, []string{"snap-seccomp", "compile"})
+	c.Check(calls[0][4], Equals, "amd64")
+	c.Check(calls[1][4], Equals, "i386")
+}
+
+func (s *introspectSuite) TestCompileSecCompBPFErrors(c *C) {
+	cmd, restore := s.mockSnapSeccomp(c)
+	defer restore()
+
+	_, err := builtin.CompileSecCompBPF(builtin.MustInterface("fuse-support"), "s390x")
+	c.Check(err, ErrorMatches, `cannot compile the seccomp policy of interface "fuse-support": error: cannot compile for architecture "s390x" on this host`)
+
+	_, err = builtin.CompileSecCompBPF(builtin.MustInterface("cpuidle-control"), "amd64")
+	c.Check(err, ErrorMatches, `interface "cpuidle-control" has no seccomp policy`)
+
+	_, err = builtin.CompileSecCompBPF(builtin.MustInterface("content"), "amd64")
+	c.Check(err, NotNil)
+
+	restoreLookup := builtin.MockSecCompCompilerLookup(func(name string) (string, error) {
+		return "", fmt.Errorf("cannot find %s", name)
+	})
+	defer restoreLookup()
+	_, err = builtin.CompileSecCompBPF(builtin.MustInterface("fuse-support"), "amd64")
+	c.Check(err, ErrorMatches, `cannot find snap-seccomp`)
+
+	// only the interfaces granting syscalls were compiled
+	c.Check(cmd.Calls(), HasLen, 1)
+}
@@ -0,0 +1,67 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const ismControlSummary = `allows access to IBM ISM devices for SMC-D networking`
+
+const ismControlBaseDeclarationSlots = `
+  ism-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const ismControlConnectedPlugAppArmor = `
+# Description: Can use SMC-D (Shared Memory Communications - Direct Memory
+# Access) sockets over the IBM Internal Shared Memory devices of s390x
+# systems. See https://www.ibm.com/docs/en/linux-on-systems?topic=channels-smc
+
+network smc,
+
+/dev/ism* rw,
+
+# Enumerating the ISM devices
+/sys/bus/pci/drivers/ism/ r,
+/sys/bus/pci/drivers/ism/** r,
+`
+
+// AF_SMC is not allowed by the default template, unlike most of the other
+// socket families which are mediated by AppArmor only.
+const ismControlConnectedPlugSecComp = `
+# Description: Can create SMC sockets.
+
+socket AF_SMC
+`
+
+var ismControlConnectedPlugUDev = []string{`KERNEL=="ism*"`}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "ism-control",
+		summary:               ismControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  ismControlBaseDeclarationSlots,
+		connectedPlugAppArmor: ismControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  ismControlConnectedPlugSecComp,
+		connectedPlugUDev:     ismControlConnectedPlugUDev,
+	})
+}
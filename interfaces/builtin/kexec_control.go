@@ -0,0 +1,74 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const kexecControlSummary = `allows loading a new kernel for later execution with kexec`
+
+// Loading a kernel with kexec replaces the running kernel, and with it every
+// security guarantee snapd relies on, the moment it is executed. This is as
+// privileged as it gets, so the interface must never be auto-connected.
+const kexecControlBaseDeclarationSlots = `
+  kexec-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const kexecControlConnectedPlugAppArmor = `
+# Description: Can load a new kernel, either for an immediate kexec reboot
+# or as a crash kernel. This is effectively full control of the system:
+# the loaded kernel is executed without any of the confinement of the
+# running one.
+
+# Required by kexec_load(2) and kexec_file_load(2)
+capability sys_boot,
+
+# Allow querying the state of loaded kexec and crash kernels
+/sys/kernel/kexec_loaded r,
+/sys/kernel/kexec_crash_loaded r,
+/sys/kernel/kexec_crash_size rw,
+
+# Tools shipped by the snap typically read the boot parameters and memory
+# map of the running system to set up the new kernel
+@{PROC}/cmdline r,
+@{PROC}/iomem r,
+/sys/firmware/memmap/{,**} r,
+`
+
+const kexecControlConnectedPlugSecComp = `
+# Description: Can load a new kernel, either for an immediate kexec reboot
+# or as a crash kernel.
+
+kexec_load
+kexec_file_load
+`
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "kexec-control",
+		summary:               kexecControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  kexecControlBaseDeclarationSlots,
+		connectedPlugAppArmor: kexecControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  kexecControlConnectedPlugSecComp,
+	})
+}
@@ -179,13 +179,13 @@ capability dac_read_search,
 # kubelet calls out to systemd-run for some mounts, but not all of them and not
 # unmounts...
 capability sys_admin,
-mount /var/snap/@{SNAP_INSTANCE_NAME}/common/{,**} -> /var/snap/@{SNAP_INSTANCE_NAME}/common/{,**},
-mount options=(rw, rshared) -> /var/snap/@{SNAP_INSTANCE_NAME}/common/{,**},
+mount ###SNAP_COMMON###/{,**} -> ###SNAP_COMMON###/{,**},
+mount options=(rw, rshared) -> ###SNAP_COMMON###/{,**},
 
 /{,usr/}bin/mount ixr,
 /{,usr/}bin/umount ixr,
 deny /run/mount/utab{,.lock} rw,
-umount /var/snap/@{SNAP_INSTANCE_NAME}/common/**,
+umount ###SNAP_COMMON###/**,
 
 # When fsGroup is set, the pod's volume will be recursively chowned with the
 # setgid bit set on directories so new files will be owned by the fsGroup. See
@@ -197,19 +197,19 @@ const kubernetesSupportConnectedPlugAppArmorKubeletSystemdRun = `
   # kubelet mount rules
   capability sys_admin,
   /{,usr/}bin/mount ixr,
-  mount fstype="tmpfs" tmpfs -> /var/snap/@{SNAP_INSTANCE_NAME}/common/**,
+  mount fstype="tmpfs" tmpfs -> ###SNAP_COMMON###/**,
   deny /run/mount/utab{,.lock} rw,
 
   # For mounting volume subPaths
-  mount /var/snap/@{SNAP_INSTANCE_NAME}/common/{,**} -> /var/snap/@{SNAP_INSTANCE_NAME}/common/{,**},
-  mount options=(rw, remount, bind) -> /var/snap/@{SNAP_INSTANCE_NAME}/common/{,**},
+  mount ###SNAP_COMMON###/{,**} -> ###SNAP_COMMON###/{,**},
+  mount options=(rw, remount, bind) -> ###SNAP_COMMON###/{,**},
   # nvme0-99, 1-63 partitions with 1-63 optional namespaces
-  mount /dev/nvme{[0-9],[1-9][0-9]}n{[1-9],[1-5][0-9],6[0-3]}{,p{[1-9],[1-5][0-9],6[0-3]}} -> /var/snap/@{SNAP_INSTANCE_NAME}/common/**,
+  mount /dev/nvme{[0-9],[1-9][0-9]}n{[1-9],[1-5][0-9],6[0-3]}{,p{[1-9],[1-5][0-9],6[0-3]}} -> ###SNAP_COMMON###/**,
   # SCSI sda-sdiv, 1-15 partitions
-  mount /dev/sd{[a-z],[a-h][a-z],i[a-v]}{[1-9],1[0-5]} -> /var/snap/@{SNAP_INSTANCE_NAME}/common/**,
+  mount /dev/sd{[a-z],[a-h][a-z],i[a-v]}{[1-9],1[0-5]} -> ###SNAP_COMMON###/**,
   # virtio vda-vdz, 1-63 partitions
-  mount /dev/vd[a-z]{[1-9],[1-5][0-9],6[0-3]} -> /var/snap/@{SNAP_INSTANCE_NAME}/common/**,
-  umount /var/snap/@{SNAP_INSTANCE_NAME}/common/**,
+  mount /dev/vd[a-z]{[1-9],[1-5][0-9],6[0-3]} -> ###SNAP_COMMON###/**,
+  umount ###SNAP_COMMON###/**,
 
   # When mounting a volume subPath, kubelet binds mounts on an open fd (eg,
   # /proc/.../fd/N) which triggers a ptrace 'read' denial on the parent
@@ -0,0 +1,71 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+// Details about the MEI character device API can be found here:
+// https://docs.kernel.org/driver-api/mei/mei.html
+
+const meiControlSummary = `allows controlling the Intel Management Engine through MEI devices`
+
+const meiControlBaseDeclarationSlots = `
+  mei-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const meiControlConnectedPlugAppArmor = `
+# Description: Can talk to the clients of the Intel Management Engine
+# (ME/CSME) through the MEI character devices.
+
+/dev/mei[0-9]* rw,
+
+# Enumerating the devices and reading their firmware version, status and
+# protocol details
+/sys/class/mei/ r,
+/sys/devices/**/mei/mei[0-9]*/** r,
+`
+
+const meiControlConnectedPlugSecComp = `
+# Description: Can connect to the ME clients and use their notifications.
+
+ioctl - IOCTL_MEI_CONNECT_CLIENT
+ioctl - IOCTL_MEI_CONNECT_CLIENT_VTAG
+ioctl - IOCTL_MEI_NOTIFY_SET
+ioctl - IOCTL_MEI_NOTIFY_GET
+`
+
+var meiControlConnectedPlugUDev = []string{
+	`SUBSYSTEM=="mei", KERNEL=="mei[0-9]*"`,
+}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "mei-control",
+		summary:               meiControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  meiControlBaseDeclarationSlots,
+		connectedPlugAppArmor: meiControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  meiControlConnectedPlugSecComp,
+		connectedPlugUDev:     meiControlConnectedPlugUDev,
+	})
+}
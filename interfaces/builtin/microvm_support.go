@@ -0,0 +1,179 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/kmod"
+	"github.com/snapcore/snapd/interfaces/udev"
+)
+
+/*
+ * Micro virtual machine monitors, such as Firecracker or Cloud Hypervisor,
+ * run each guest in a process of their own with KVM for the virtualization
+ * acceleration and the vhost framework of the kernel for the data plane of
+ * the virtio network devices, which are backed by tap devices on the host.
+ * The memory of the guests is backed by memfd files, userfaultfd is used to
+ * restore it lazily from snapshots.
+ *
+ * Communicating with the guests over vsock is optional and enabled with the
+ * "enable-vsock" plug attribute.
+ */
+
+const microvmSupportSummary = `allows running KVM based micro virtual machines`
+
+const microvmSupportBaseDeclarationSlots = `
+  microvm-support:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const microvmSupportConnectedPlugAppArmor = `
+# Description: Can run micro virtual machines with KVM, with the network
+# devices of the guests backed by tap devices and accelerated by vhost-net.
+
+/dev/kvm rw,
+# Allow checking the support of nested virtualization
+/sys/module/kvm{,_intel,_amd,_hv}/parameters/nested r,
+
+# Used by the virtio network devices to offload their data plane to the kernel
+/dev/vhost-net rw,
+/sys/module/vhost/parameters/max_mem_regions r,
+
+# TUN/TAP - https://www.kernel.org/doc/Documentation/networking/tuntap.txt
+# Creating and configuring the tap devices backing the network devices of the
+# guests
+/dev/net/tun rw,
+capability net_admin,
+network netlink raw,
+/sys/devices/virtual/net/tap*/** rw,
+
+# Used to restore the memory of the guests lazily from snapshots, the device
+# exists since Linux 6.1, older kernels only provide the syscall
+/dev/userfaultfd rw,
+`
+
+// microvmSupportVsockConnectedPlugAppArmor is added when the plug sets
+// "enable-vsock: true".
+const microvmSupportVsockConnectedPlugAppArmor = `
+# Allow communicating with the guests over vsock
+/dev/vhost-vsock rw,
+network vsock,
+`
+
+const microvmSupportConnectedPlugSecComp = `
+# Description: Can run micro virtual machines with KVM. memfd_create, used for
+# the memory of the guests, is allowed by the default template already.
+
+# Used to restore the memory of the guests lazily from snapshots
+userfaultfd
+
+# Configuring the tap devices
+socket AF_NETLINK - NETLINK_ROUTE
+`
+
+/* The tap devices are virtual and don't show up in /dev, tagging
+ * /dev/net/tun is enough to create them.
+ */
+var microvmSupportConnectedPlugUDev = []string{
+	`KERNEL=="kvm"`,
+	`KERNEL=="vhost-net"`,
+	`KERNEL=="tun"`,
+	`KERNEL=="userfaultfd"`,
+}
+
+var microvmSupportVsockConnectedPlugUDev = []string{
+	`KERNEL=="vhost-vsock"`,
+}
+
+var microvmSupportConnectedPlugKmod = []string{
+	"vhost_net",
+}
+
+var microvmSupportVsockConnectedPlugKmod = []string{
+	"vhost_vsock",
+}
+
+const microvmSupportExample = `plugs:
+  microvm-support:
+    enable-vsock: true
+apps:
+  vmm:
+    plugs: [microvm-support]
+`
+
+type microvmSupportInterface struct {
+	commonInterface
+}
+
+// vsockEnabled returns whether the plug enables the vsock rules.
+func (iface *microvmSupportInterface) vsockEnabled(plug *interfaces.ConnectedPlug) bool {
+	enabled, _ := interfaces.AttrValue(plug, "enable-vsock", iface.plugAttrSchema)
+	return enabled == true
+}
+
+func (iface *microvmSupportInterface) UDevConnectedPlug(spec *udev.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	if err := iface.commonInterface.UDevConnectedPlug(spec, plug, slot); err != nil {
+		return err
+	}
+	if iface.vsockEnabled(plug) {
+		for _, rule := range microvmSupportVsockConnectedPlugUDev {
+			spec.TagDevice(rule)
+		}
+	}
+	return nil
+}
+
+func (iface *microvmSupportInterface) KModConnectedPlug(spec *kmod.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	if err := iface.commonInterface.KModConnectedPlug(spec, plug, slot); err != nil {
+		return err
+	}
+	if iface.vsockEnabled(plug) {
+		for _, m := range microvmSupportVsockConnectedPlugKmod {
+			if err := spec.AddModule(m); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func init() {
+	registerIface(&microvmSupportInterface{commonInterface{
+		name:                     "microvm-support",
+		summary:                  microvmSupportSummary,
+		example:                  microvmSupportExample,
+		implicitOnCore:           true,
+		implicitOnClassic:        true,
+		baseDeclarationSlots:     microvmSupportBaseDeclarationSlots,
+		connectedPlugAppArmor:    microvmSupportConnectedPlugAppArmor,
+		connectedPlugSecComp:     microvmSupportConnectedPlugSecComp,
+		connectedPlugUDev:        microvmSupportConnectedPlugUDev,
+		connectedPlugKModModules: microvmSupportConnectedPlugKmod,
+		plugAttrSchema: map[string]interfaces.AttrSpec{
+			"enable-vsock": {Type: interfaces.AttrBool, Default: false, Description: "allow communicating with the guests over vsock"},
+		},
+		connectedPlugAttrAppArmor: []attrSnippet{
+			{attr: "enable-vsock", value: true, snippet: microvmSupportVsockConnectedPlugAppArmor},
+		},
+	}})
+}
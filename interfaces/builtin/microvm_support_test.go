@@ -0,0 +1,173 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/kmod"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type microvmSupportInterfaceSuite struct {
+	iface         interfaces.Interface
+	slotInfo      *snap.SlotInfo
+	slot          *interfaces.ConnectedSlot
+	plugInfo      *snap.PlugInfo
+	plug          *interfaces.ConnectedPlug
+	vsockPlugInfo *snap.PlugInfo
+	vsockPlug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&microvmSupportInterfaceSuite{
+	iface: builtin.MustInterface("microvm-support"),
+})
+
+const microvmSupportConsumerYaml = `name: consumer
+version: 0
+plugs:
+ vsock:
+  interface: microvm-support
+  enable-vsock: true
+apps:
+ app:
+  plugs: [microvm-support]
+ vsock-app:
+  plugs: [vsock]
+`
+
+const microvmSupportCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  microvm-support:
+`
+
+func (s *microvmSupportInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, microvmSupportConsumerYaml, nil, "microvm-support")
+	s.vsockPlug, s.vsockPlugInfo = MockConnectedPlug(c, microvmSupportConsumerYaml, nil, "vsock")
+	s.slot, s.slotInfo = MockConnectedSlot(c, microvmSupportCoreYaml, nil, "microvm-support")
+}
+
+func (s *microvmSupportInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "microvm-support")
+}
+
+func (s *microvmSupportInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *microvmSupportInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.vsockPlugInfo), IsNil)
+
+	const badYaml = `name: consumer
+version: 0
+plugs:
+ microvm-support:
+  enable-vsock: "yes"
+`
+	_, plugInfo := MockConnectedPlug(c, badYaml, nil, "microvm-support")
+	c.Assert(interfaces.BeforePreparePlug(s.iface, plugInfo), ErrorMatches, `microvm-support "enable-vsock" attribute must be a boolean`)
+}
+
+func (s *microvmSupportInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/dev/kvm rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/dev/vhost-net rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/dev/net/tun rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/dev/userfaultfd rw,\n")
+	c.Check(snippet, Not(testutil.Contains), "vsock")
+
+	spec = apparmor.NewSpecification(s.vsockPlug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.vsockPlug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.vsock-app"})
+	snippet = spec.SnippetForTag("snap.consumer.vsock-app")
+	c.Check(snippet, testutil.Contains, "\n/dev/kvm rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/dev/vhost-vsock rw,\nnetwork vsock,\n")
+}
+
+func (s *microvmSupportInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\nuserfaultfd\n")
+	c.Check(snippet, testutil.Contains, "\nsocket AF_NETLINK - NETLINK_ROUTE\n")
+}
+
+func (s *microvmSupportInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 5)
+	c.Check(spec.Snippets(), testutil.Contains, `# microvm-support
+KERNEL=="kvm", TAG+="snap_consumer_app"`)
+	c.Check(spec.Snippets(), testutil.Contains, `# microvm-support
+KERNEL=="vhost-net", TAG+="snap_consumer_app"`)
+	c.Check(spec.Snippets(), Not(testutil.Contains), `# microvm-support
+KERNEL=="vhost-vsock", TAG+="snap_consumer_app"`)
+
+	spec = udev.NewSpecification(s.vsockPlug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.vsockPlug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 6)
+	c.Check(spec.Snippets(), testutil.Contains, `# microvm-support
+KERNEL=="vhost-vsock", TAG+="snap_consumer_vsock-app"`)
+}
+
+func (s *microvmSupportInterfaceSuite) TestKModSpec(c *C) {
+	spec := &kmod.Specification{}
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.Modules(), DeepEquals, map[string]bool{
+		"vhost_net": true,
+	})
+
+	spec = &kmod.Specification{}
+	c.Assert(spec.AddConnectedPlug(s.iface, s.vsockPlug, s.slot), IsNil)
+	c.Check(spec.Modules(), DeepEquals, map[string]bool{
+		"vhost_net":   true,
+		"vhost_vsock": true,
+	})
+}
+
+func (s *microvmSupportInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows running KVM based micro virtual machines`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "microvm-support")
+	c.Assert(si.Example, testutil.Contains, "enable-vsock: true")
+}
+
+func (s *microvmSupportInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *microvmSupportInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -111,7 +111,6 @@ fchown
 fchown32
 fchownat
 lchown
-lchownat
 `
 
 func init() {
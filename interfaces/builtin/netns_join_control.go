@@ -0,0 +1,72 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const netnsJoinControlSummary = `allows joining existing network namespaces`
+
+// Joining the network namespace of another process gives access to its network
+// interfaces and its sockets bound to them, connections need to be explicitly
+// granted.
+const netnsJoinControlBaseDeclarationSlots = `
+  netns-join-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const netnsJoinControlConnectedPlugAppArmor = `
+# Description: Can join the network namespaces of other processes or the ones
+# persisted by 'ip netns', e.g. to run a sidecar sharing the network of the
+# workload. Creating network namespaces is not allowed, see network-control.
+
+# setns(2) requires CAP_SYS_ADMIN in the user namespace owning the network
+# namespace
+capability sys_admin,
+
+# opening the namespace of another process requires ptrace read access to it
+@{PROC}/[0-9]*/ns/ r,
+@{PROC}/[0-9]*/ns/net r,
+@{PROC}/[0-9]*/task/[0-9]*/ns/net r,
+ptrace (read),
+
+# the network namespaces persisted by 'ip netns', see man ip-netns(8)
+/run/netns/ r,
+/run/netns/* r,
+`
+
+const netnsJoinControlConnectedPlugSecComp = `
+# Description: Can join existing network namespaces, only network namespaces
+# can be joined, the type of the fd is checked by the kernel when the flag is
+# set.
+setns - CLONE_NEWNET
+`
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "netns-join-control",
+		summary:               netnsJoinControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  netnsJoinControlBaseDeclarationSlots,
+		connectedPlugAppArmor: netnsJoinControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  netnsJoinControlConnectedPlugSecComp,
+	})
+}
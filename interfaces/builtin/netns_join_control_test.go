@@ -0,0 +1,120 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type netnsJoinControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&netnsJoinControlInterfaceSuite{
+	iface: builtin.MustInterface("netns-join-control"),
+})
+
+const netnsJoinControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [netns-join-control]
+`
+
+const netnsJoinControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  netns-join-control:
+`
+
+func (s *netnsJoinControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, netnsJoinControlConsumerYaml, nil, "netns-join-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, netnsJoinControlCoreYaml, nil, "netns-join-control")
+}
+
+func (s *netnsJoinControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "netns-join-control")
+}
+
+func (s *netnsJoinControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *netnsJoinControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+func (s *netnsJoinControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n@{PROC}/[0-9]*/ns/net r,\n")
+	c.Check(snippet, testutil.Contains, "\n/run/netns/* r,\n")
+	c.Check(snippet, testutil.Contains, "\ncapability sys_admin,\n")
+	// persisting network namespaces is left to network-control
+	c.Check(snippet, Not(testutil.Contains), "mount")
+}
+
+func (s *netnsJoinControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\nsetns - CLONE_NEWNET\n")
+	// setns is limited to network namespaces and new ones cannot be
+	// created
+	syscalls := builtin.SeccompSyscalls(snippet)
+	c.Check(syscalls, DeepEquals, []string{"setns"})
+	for _, line := range strings.Split(snippet, "\n") {
+		if strings.HasPrefix(line, "setns") {
+			c.Check(line, Equals, "setns - CLONE_NEWNET")
+		}
+	}
+}
+
+func (s *netnsJoinControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows joining existing network namespaces`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "netns-join-control")
+}
+
+func (s *netnsJoinControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *netnsJoinControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
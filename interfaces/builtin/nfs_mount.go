@@ -42,23 +42,23 @@ const nfsMountConnectedPlugAppArmor = `
 capability sys_admin,
 
 # Allow mounts to our snap-specific writable directories
-# parallel-installs: SNAP_{DATA,COMMON} are remapped, need to use SNAP_NAME, for
-# completeness allow SNAP_INSTANCE_NAME too
+# parallel-installs: SNAP_{DATA,COMMON} are remapped and use SNAP_NAME, the
+# directories of SNAP_INSTANCE_NAME are allowed too for parallel instances
 #
 # NFS mounts take the form use <host>:<path>, so match with *:**
 #
-mount fstype=nfs{,4} *:** -> /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/@{SNAP_REVISION}/{,**},
-mount fstype=nfs{,4} *:** -> /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/common/{,**},
+mount fstype=nfs{,4} *:** -> ###SNAP_DATA###/{,**},
+mount fstype=nfs{,4} *:** -> ###SNAP_COMMON###/{,**},
 
 # NOTE: due to LP: #1613403, fstype is not mediated and as such, these rules
 # allow, for example, unmounting bind mounts from the content interface
-# parallel-installs: SNAP_{DATA,COMMON} are remapped, need to use SNAP_NAME, for
-# completeness allow SNAP_INSTANCE_NAME too
+# parallel-installs: SNAP_{DATA,COMMON} are remapped and use SNAP_NAME, the
+# directories of SNAP_INSTANCE_NAME are allowed too for parallel instances
 #
 # Nonetheless, fstype has been included for when support for umount fstype mediation lands.
 #
-umount fstype=nfs{,4} /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/@{SNAP_REVISION}/{,**},
-umount fstype=nfs{,4} /var/snap/{@{SNAP_NAME},@{SNAP_INSTANCE_NAME}}/common/{,**},
+umount fstype=nfs{,4} ###SNAP_DATA###/{,**},
+umount fstype=nfs{,4} ###SNAP_COMMON###/{,**},
 
 # Due to an unsolved issue with namespace awareness of libmount the unmount tries to access
 # /run/mount/utab but fails. The resulting apparmor warning can be ignored. The log warning
@@ -85,7 +85,6 @@ fchown
 fchown32
 fchownat
 lchown
-lchownat
 `
 
 const nomadSupportServiceSnippet = interfaces.PlugServicesServiceSectionSnippet(`Delegate=true`)
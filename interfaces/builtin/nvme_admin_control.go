@@ -0,0 +1,68 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+package builtin
+
+const nvmeAdminControlSummary = `allows issuing NVMe admin commands to NVMe controllers`
+
+const nvmeAdminControlBaseDeclarationSlots = `
+  nvme-admin-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const nvmeAdminControlConnectedPlugAppArmor = `
+# Description: Can send admin commands, such as identify, firmware download
+# and commit, format or namespace management, to NVMe controllers through
+# the passthrough ioctls of their character devices. This does not grant
+# access to the namespace block devices, see block-devices for that.
+# See https://nvmexpress.org/specifications/
+
+/dev/nvme{[0-9],[1-9][0-9]} rw,
+
+# Enumerating the controllers and their attributes
+/sys/class/nvme/ r,
+/sys/devices/**/nvme/nvme{[0-9],[1-9][0-9]}/** r,
+`
+
+const nvmeAdminControlConnectedPlugSecComp = `
+# Description: Can send admin commands to NVMe controllers. The I/O
+# passthrough and reset ioctls are not part of the admin command set.
+
+ioctl - NVME_IOCTL_ADMIN_CMD
+ioctl - NVME_IOCTL_ADMIN64_CMD
+`
+
+var nvmeAdminControlConnectedPlugUDev = []string{
+	`SUBSYSTEM=="nvme", KERNEL=="nvme[0-9]*"`,
+}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "nvme-admin-control",
+		summary:               nvmeAdminControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  nvmeAdminControlBaseDeclarationSlots,
+		connectedPlugAppArmor: nvmeAdminControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  nvmeAdminControlConnectedPlugSecComp,
+		connectedPlugUDev:     nvmeAdminControlConnectedPlugUDev,
+	})
+}
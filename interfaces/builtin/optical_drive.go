@@ -20,11 +20,7 @@
 package builtin
 
 import (
-	"fmt"
-
 	"github.com/snapcore/snapd/interfaces"
-	"github.com/snapcore/snapd/interfaces/apparmor"
-	"github.com/snapcore/snapd/snap"
 )
 
 const opticalDriveSummary = `allows access to optical drives`
@@ -53,6 +49,15 @@ const opticalDriveConnectedPlugAppArmor = `
 /run/udev/data/b11:[0-9]* r,
 `
 
+// opticalDriveWriteConnectedPlugAppArmor is added when the plug sets
+// "write: true".
+const opticalDriveWriteConnectedPlugAppArmor = `
+# Allow write access to optical drives
+/dev/sr[0-9]* w,
+/dev/scd[0-9]* w,
+/dev/sg[0-9]* w,
+`
+
 var opticalDriveConnectedPlugUDev = []string{
 	`KERNEL=="sr[0-9]*"`,
 	`KERNEL=="scd[0-9]*"`,
@@ -62,50 +67,36 @@ var opticalDriveConnectedPlugUDev = []string{
 	`SUBSYSTEM=="scsi_generic", SUBSYSTEMS=="scsi", ATTRS{type}=="4|5"`,
 }
 
+const opticalDriveExample = `plugs:
+  optical-drive:
+    write: true
+apps:
+  burner:
+    plugs: [optical-drive]
+`
+
 // opticalDriveInterface is the type for optical drive interfaces.
 type opticalDriveInterface struct {
 	commonInterface
 }
 
-// BeforePrepareSlot checks and possibly modifies a slot.
-// Valid "optical-drive" slots may contain the attribute "write".
-// If defined, the attribute "write" must be either "true" or "false".
-func (iface *opticalDriveInterface) BeforePreparePlug(plug *snap.PlugInfo) error {
-	// It's fine if 'write' isn't specified, but if it is, it needs to be bool
-	if w, ok := plug.Attrs["write"]; ok {
-		_, ok = w.(bool)
-		if !ok {
-			return fmt.Errorf(`optical-drive "write" attribute must be a boolean`)
-		}
-	}
-
-	return nil
-}
-
-func (iface *opticalDriveInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
-	var write bool
-	_ = plug.Attr("write", &write)
-
-	// Add the common readonly policy
-	spec.AddSnippet(opticalDriveConnectedPlugAppArmor)
-
-	// 'write: true' grants write access to the devices
-	if write {
-		spec.AddSnippet("# Allow write access to optical drives")
-		spec.AddSnippet("/dev/sr[0-9]* w,")
-		spec.AddSnippet("/dev/scd[0-9]* w,")
-		spec.AddSnippet("/dev/sg[0-9]* w,")
-	}
-	return nil
-}
-
 func init() {
 	registerIface(&opticalDriveInterface{commonInterface: commonInterface{
 		name:                 "optical-drive",
 		summary:              opticalDriveSummary,
+		example:              opticalDriveExample,
 		implicitOnCore:       false,
 		implicitOnClassic:    true,
 		baseDeclarationSlots: opticalDriveBaseDeclarationSlots,
 		connectedPlugUDev:    opticalDriveConnectedPlugUDev,
+		// the common policy is read-only, 'write: true' grants write
+		// access to the devices
+		plugAttrSchema: map[string]interfaces.AttrSpec{
+			"write": {Type: interfaces.AttrBool, Default: false, Description: "grant write access to the optical drives, for burning discs"},
+		},
+		connectedPlugAppArmor: opticalDriveConnectedPlugAppArmor,
+		connectedPlugAttrAppArmor: []attrSnippet{
+			{attr: "write", value: true, snippet: opticalDriveWriteConnectedPlugAppArmor},
+		},
 	}})
 }
@@ -162,6 +162,18 @@ func (s *OpticalDriveInterfaceSuite) TestStaticInfo(c *C) {
 	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "optical-drive")
 }
 
+func (s *OpticalDriveInterfaceSuite) TestInfoDocumentsAttributes(c *C) {
+	repo := interfaces.NewRepository()
+	c.Assert(repo.AddInterface(s.iface), IsNil)
+	infos := repo.Info(&interfaces.InfoOptions{Names: []string{"optical-drive"}, Doc: true})
+	c.Assert(infos, HasLen, 1)
+	c.Check(infos[0].SecurityLevel, Equals, interfaces.SecurityLevelMedium)
+	c.Check(infos[0].Example, testutil.Contains, "write: true")
+	c.Check(infos[0].PlugAttrs["write"].Type, Equals, interfaces.AttrBool)
+	c.Check(infos[0].PlugAttrs["write"].Description, Not(Equals), "")
+	c.Check(infos[0].SlotAttrs, HasLen, 0)
+}
+
 func (s *OpticalDriveInterfaceSuite) TestInterfaces(c *C) {
 	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
 }
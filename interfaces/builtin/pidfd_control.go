@@ -0,0 +1,53 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const pidfdControlSummary = `allows operating on processes through process file descriptors`
+
+const pidfdControlBaseDeclarationSlots = `
+  pidfd-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const pidfdControlConnectedPlugSecComp = `
+# Description: Allow the snap to supervise processes through process file
+# descriptors: obtaining one for a process, signalling the process through it
+# and duplicating the file descriptors of the process. AppArmor still mediates
+# the signals and the access to the file descriptors of other processes, see
+# the signal and ptrace rules of the other interfaces. See 'man 2 pidfd_open',
+# 'man 2 pidfd_send_signal' and 'man 2 pidfd_getfd'.
+pidfd_open
+pidfd_send_signal
+pidfd_getfd
+`
+
+func init() {
+	registerIface(&commonInterface{
+		name:                 "pidfd-control",
+		summary:              pidfdControlSummary,
+		implicitOnCore:       true,
+		implicitOnClassic:    true,
+		baseDeclarationSlots: pidfdControlBaseDeclarationSlots,
+		connectedPlugSecComp: pidfdControlConnectedPlugSecComp,
+	})
+}
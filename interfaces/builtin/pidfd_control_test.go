@@ -0,0 +1,118 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type pidfdControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&pidfdControlInterfaceSuite{
+	iface: builtin.MustInterface("pidfd-control"),
+})
+
+const pidfdControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [pidfd-control]
+`
+
+const pidfdControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  pidfd-control:
+`
+
+func (s *pidfdControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, pidfdControlConsumerYaml, nil, "pidfd-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, pidfdControlCoreYaml, nil, "pidfd-control")
+}
+
+func (s *pidfdControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "pidfd-control")
+}
+
+func (s *pidfdControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *pidfdControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+func (s *pidfdControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	for _, syscall := range []string{"pidfd_open", "pidfd_send_signal", "pidfd_getfd"} {
+		c.Check(snippet, testutil.Contains, "\n"+syscall+"\n")
+	}
+}
+
+func (s *pidfdControlInterfaceSuite) TestSecCompSpecNotConnected(c *C) {
+	// without the connection the snap is not granted the syscalls
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddPermanentPlug(s.iface, s.plugInfo), IsNil)
+	c.Check(spec.SecurityTags(), HasLen, 0)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "pidfd")
+}
+
+func (s *pidfdControlInterfaceSuite) TestNoAppArmorOrUDev(c *C) {
+	apparmorSpec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(apparmorSpec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(apparmorSpec.SecurityTags(), HasLen, 0)
+
+	udevSpec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(udevSpec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(udevSpec.Snippets(), HasLen, 0)
+}
+
+func (s *pidfdControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows operating on processes through process file descriptors`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "pidfd-control")
+}
+
+func (s *pidfdControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *pidfdControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
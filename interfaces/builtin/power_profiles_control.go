@@ -0,0 +1,89 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const powerProfilesControlSummary = `allows switching power profiles via power-profiles-daemon`
+
+const powerProfilesControlBaseDeclarationSlots = `
+  power-profiles-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const powerProfilesControlConnectedPlugAppArmor = `
+# Description: Can query and switch the active power profile and hold or
+# release profiles through power-profiles-daemon, see:
+# https://gitlab.freedesktop.org/upower/power-profiles-daemon
+
+#include <abstractions/dbus-strict>
+
+# Both the legacy net.hadess.PowerProfiles and the newer
+# org.freedesktop.UPower.PowerProfiles names are exported by the daemon.
+
+# Introspection
+# do not use peer=(label=unconfined) here since this is DBus activated
+dbus (send)
+    bus=system
+    path=/{net/hadess,org/freedesktop/UPower}/PowerProfiles
+    interface=org.freedesktop.DBus.Introspectable
+    member=Introspect,
+
+# Read and set properties, including ActiveProfile
+# do not use peer=(label=unconfined) here since this is DBus activated
+dbus (send)
+    bus=system
+    path=/{net/hadess,org/freedesktop/UPower}/PowerProfiles
+    interface=org.freedesktop.DBus.Properties
+    member={Get,GetAll,Set},
+
+dbus (receive)
+    bus=system
+    path=/{net/hadess,org/freedesktop/UPower}/PowerProfiles
+    interface=org.freedesktop.DBus.Properties
+    member=PropertiesChanged
+    peer=(label=unconfined),
+
+dbus (send)
+    bus=system
+    path=/{net/hadess,org/freedesktop/UPower}/PowerProfiles
+    interface={net.hadess.PowerProfiles,org.freedesktop.UPower.PowerProfiles}
+    member={HoldProfile,ReleaseProfile}
+    peer=(label=unconfined),
+
+dbus (receive)
+    bus=system
+    path=/{net/hadess,org/freedesktop/UPower}/PowerProfiles
+    interface={net.hadess.PowerProfiles,org.freedesktop.UPower.PowerProfiles}
+    member=ProfileReleased
+    peer=(label=unconfined),
+`
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "power-profiles-control",
+		summary:               powerProfilesControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  powerProfilesControlBaseDeclarationSlots,
+		connectedPlugAppArmor: powerProfilesControlConnectedPlugAppArmor,
+	})
+}
@@ -0,0 +1,107 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type powerProfilesControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&powerProfilesControlInterfaceSuite{
+	iface: builtin.MustInterface("power-profiles-control"),
+})
+
+const powerProfilesControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [power-profiles-control]
+`
+
+const powerProfilesControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  power-profiles-control:
+`
+
+func (s *powerProfilesControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, powerProfilesControlConsumerYaml, nil, "power-profiles-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, powerProfilesControlCoreYaml, nil, "power-profiles-control")
+}
+
+func (s *powerProfilesControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "power-profiles-control")
+}
+
+func (s *powerProfilesControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *powerProfilesControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *powerProfilesControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	appSet, err := interfaces.NewSnapAppSet(s.plug.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec := apparmor.NewSpecification(appSet)
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "#include <abstractions/dbus-strict>\n")
+	c.Check(snippet, testutil.Contains, `dbus (send)
+    bus=system
+    path=/{net/hadess,org/freedesktop/UPower}/PowerProfiles
+    interface={net.hadess.PowerProfiles,org.freedesktop.UPower.PowerProfiles}
+    member={HoldProfile,ReleaseProfile}
+    peer=(label=unconfined),`)
+	c.Check(snippet, testutil.Contains, `    interface=org.freedesktop.DBus.Properties
+    member={Get,GetAll,Set},`)
+}
+
+func (s *powerProfilesControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows switching power profiles via power-profiles-daemon`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "power-profiles-control")
+}
+
+func (s *powerProfilesControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *powerProfilesControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -73,10 +73,20 @@ func (iface *pwmInterface) BeforePrepareSlot(slot *snap.SlotInfo) error {
 		return fmt.Errorf("pwm slot chip-number attribute must be an int")
 	}
 
+	// optional physical pin the PWM channel is routed to
+	if err := validateSlotPin(slot); err != nil {
+		return err
+	}
+
 	// slot is good
 	return nil
 }
 
+// SlotPin returns the physical pin used by the slot, if specified.
+func (iface *pwmInterface) SlotPin(slot *snap.SlotInfo) (int64, bool) {
+	return slotPin(slot)
+}
+
 func (iface *pwmInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
 	var chipNum int64
 	if err := slot.Attr("chip-number", &chipNum); err != nil {
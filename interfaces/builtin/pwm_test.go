@@ -161,6 +161,32 @@ func (s *PwmInterfaceSuite) TestSanitizeSlotOsSnap(c *C) {
 	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.osPwmSlotInfo), IsNil)
 }
 
+func (s *PwmInterfaceSuite) TestSanitizeSlotPin(c *C) {
+	info := snaptest.MockInfo(c, `
+name: my-device
+version: 0
+type: gadget
+slots:
+    with-pin:
+        interface: pwm
+        channel: 0
+        chip-number: 0
+        pin: 12
+    bad-pin:
+        interface: pwm
+        channel: 0
+        chip-number: 0
+        pin: twelve
+`, nil)
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, info.Slots["with-pin"]), IsNil)
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, info.Slots["bad-pin"]), ErrorMatches,
+		"pwm slot pin attribute must be a non-negative int")
+
+	pin, ok := s.iface.(interfaces.PinUser).SlotPin(info.Slots["with-pin"])
+	c.Check(ok, Equals, true)
+	c.Check(pin, Equals, int64(12))
+}
+
 func (s *PwmInterfaceSuite) TestSanitizePlug(c *C) {
 	c.Assert(interfaces.BeforePreparePlug(s.iface, s.gadgetPlugInfo), IsNil)
 }
@@ -0,0 +1,74 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const rpmbControlSummary = `allows access to replay protected memory blocks (RPMB)`
+
+const rpmbControlBaseDeclarationSlots = `
+  rpmb-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const rpmbControlConnectedPlugAppArmor = `
+# Description: Can access the replay protected memory block (RPMB) of eMMC
+# devices, as used for secure storage on embedded devices.
+# Writing requires the authentication key of the device, but a snap with
+# access can program the key if it has not been programmed yet, so this is
+# reserved for trusted snaps.
+
+# eMMC RPMB partitions
+/dev/mmcblk[0-9]*rpmb rw,
+
+# Identifying the storage device
+/sys/class/mmc_host/ r,
+/sys/devices/**/mmc_host/mmc[0-9]*/mmc[0-9]*:*/{cid,csd,name,type,serial,manfid,oemid,raw_rpmb_size_mult,rel_sectors} r,
+/sys/class/rpmb/ r,
+/sys/devices/**/rpmb/rpmb[0-9]*/** r,
+`
+
+const rpmbControlConnectedPlugSecComp = `
+# Description: Can access the replay protected memory block (RPMB) of eMMC
+# devices. Frames are exchanged with the MMC_IOC_CMD and MMC_IOC_MULTI_CMD
+# requests of ioctl(2). The generic RPMB subsystem has no userspace API, its
+# devices are only listed in sysfs.
+
+ioctl - MMC_IOC_CMD
+ioctl - MMC_IOC_MULTI_CMD
+`
+
+var rpmbControlConnectedPlugUDev = []string{
+	`KERNEL=="mmcblk[0-9]*rpmb"`,
+}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "rpmb-control",
+		summary:               rpmbControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  rpmbControlBaseDeclarationSlots,
+		connectedPlugAppArmor: rpmbControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  rpmbControlConnectedPlugSecComp,
+		connectedPlugUDev:     rpmbControlConnectedPlugUDev,
+	})
+}
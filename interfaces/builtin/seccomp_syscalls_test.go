@@ -0,0 +1,146 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/cmd/snap-seccomp/syscalls"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/ifacetest"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type secCompSyscallsSuite struct{}
+
+var _ = Suite(&secCompSyscallsSuite{})
+
+// slotSecCompSnippets returns the seccomp snippets which the slot side of the
+// given interface contributes to a providing application.
+func slotSecCompSnippets(iface interfaces.Interface) ([]string, error) {
+	plug, slot, err := builtin.IntrospectConnectionWithSlot(iface, builtin.IntrospectAppSlotSnapYaml)
+	if err != nil {
+		return nil, err
+	}
+	spec := seccomp.NewSpecification(slot.AppSet())
+	if err := spec.AddPermanentSlot(iface, slot.Snap().Slots[slot.Name()]); err != nil {
+		return nil, err
+	}
+	if err := spec.AddConnectedSlot(iface, plug, slot); err != nil {
+		return nil, err
+	}
+	var snippets []string
+	for _, tag := range spec.SecurityTags() {
+		snippets = append(snippets, spec.SnippetForTag(tag))
+	}
+	return snippets, nil
+}
+
+// findUnknownSyscalls returns a description of the syscalls allowed by the
+// seccomp snippets of the given interface which are not known to
+// snap-seccomp. The list of snap-seccomp covers the syscalls of all the
+// architectures supported by libseccomp, syscalls missing on the architecture
+// a profile is compiled for are skipped by snap-seccomp. The plug and slot
+// sides which cannot be connected without attributes are not checked, an error
+// is returned when neither can be.
+func findUnknownSyscalls(iface interfaces.Interface) ([]string, error) {
+	known := make(map[string]bool, len(syscalls.SeccompSyscalls))
+	for _, name := range syscalls.SeccompSyscalls {
+		known[name] = true
+	}
+
+	plugSnippets, plugErr := builtin.PlugSecCompSnippets(iface)
+	slotSnippets, slotErr := slotSecCompSnippets(iface)
+	if plugErr != nil && slotErr != nil {
+		return nil, plugErr
+	}
+	var found []string
+	for _, snippet := range append(plugSnippets, slotSnippets...) {
+		for _, name := range builtin.SeccompSyscalls(snippet) {
+			if !known[name] {
+				found = append(found, fmt.Sprintf("%s: unknown syscall %q", iface.Name(), name))
+			}
+		}
+	}
+	return found, nil
+}
+
+func (s *secCompSyscallsSuite) TestAllSyscallsKnown(c *C) {
+	checked := 0
+	for _, iface := range interfaces.AllInterfaces() {
+		found, err := findUnknownSyscalls(iface)
+		if err != nil {
+			// the interface cannot be connected without attributes
+			continue
+		}
+		checked++
+		c.Check(found, HasLen, 0, Commentf("%v", found))
+	}
+	// most interfaces can be checked
+	c.Check(checked > len(interfaces.AllInterfaces())/2, Equals, true)
+}
+
+func (s *secCompSyscallsSuite) TestFuseSupportMountKnown(c *C) {
+	iface := builtin.MustInterface("fuse-support")
+	snippets, err := builtin.PlugSecCompSnippets(iface)
+	c.Assert(err, IsNil)
+	c.Assert(snippets, HasLen, 1)
+	c.Check(builtin.SeccompSyscalls(snippets[0]), testutil.DeepContains, "mount")
+
+	found, err := findUnknownSyscalls(iface)
+	c.Assert(err, IsNil)
+	c.Check(found, HasLen, 0)
+}
+
+func (s *secCompSyscallsSuite) TestUnknownSyscallsFound(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "bad",
+		SecCompConnectedPlugCallback: func(spec *seccomp.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddSnippet(`
+# Description: comment
+mount
+mont
+umount2 - MNT_DETACH
+~ptrace
+?not-a-syscall
+`)
+			return nil
+		},
+		SecCompPermanentSlotCallback: func(spec *seccomp.Specification, slot *snap.SlotInfo) error {
+			spec.AddSnippet("bind\nbnid\n")
+			return nil
+		},
+	}
+	restore := builtin.MockInterface(iface)
+	defer restore()
+
+	found, err := findUnknownSyscalls(iface)
+	c.Assert(err, IsNil)
+	c.Check(found, DeepEquals, []string{
+		`bad: unknown syscall "mont"`,
+		`bad: unknown syscall "not-a-syscall"`,
+		`bad: unknown syscall "bnid"`,
+	})
+}
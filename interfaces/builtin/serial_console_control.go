@@ -0,0 +1,107 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/logger"
+)
+
+const serialConsoleControlSummary = `allows access to the serial port used as system console`
+
+// The system console receives kernel messages and typically provides a login
+// prompt, so access to it must be explicitly granted.
+const serialConsoleControlBaseDeclarationSlots = `
+  serial-console-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const serialConsoleControlConnectedPlugAppArmor = `
+# Description: Can read and write the serial port used as the system console
+# and configure it with the termios ioctls, which are allowed by the default
+# seccomp template.
+
+# Finding out which ports are used as console
+/sys/class/tty/console/active r,
+`
+
+// serialConsoleActivePath lists the devices of the active kernel consoles,
+// as configured with console= on the kernel command line.
+const serialConsoleActivePath = "/sys/class/tty/console/active"
+
+type serialConsoleControlInterface struct {
+	commonInterface
+}
+
+// serialConsoleDevices returns the names of the serial ports currently used
+// as kernel console. Virtual terminals, which are used as console as well,
+// are left out.
+func serialConsoleDevices() []string {
+	content, err := os.ReadFile(filepath.Join(dirs.GlobalRootDir, serialConsoleActivePath))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Noticef("cannot determine the serial console devices: %v", err)
+		}
+		return nil
+	}
+	var devices []string
+	for _, name := range strings.Fields(string(content)) {
+		if serialDeviceNodePattern.MatchString("/dev/" + name) {
+			devices = append(devices, name)
+		}
+	}
+	return devices
+}
+
+func (iface *serialConsoleControlInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	spec.AddSnippet(serialConsoleControlConnectedPlugAppArmor)
+	for _, name := range serialConsoleDevices() {
+		spec.AddSnippet(fmt.Sprintf("/dev/%s rwk,", name))
+	}
+	return nil
+}
+
+func (iface *serialConsoleControlInterface) UDevConnectedPlug(spec *udev.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	for _, name := range serialConsoleDevices() {
+		spec.TagDevice(fmt.Sprintf(`SUBSYSTEM=="tty", KERNEL=="%s"`, name))
+	}
+	return nil
+}
+
+func init() {
+	registerIface(&serialConsoleControlInterface{commonInterface{
+		name:                 "serial-console-control",
+		summary:              serialConsoleControlSummary,
+		implicitOnCore:       true,
+		implicitOnClassic:    true,
+		baseDeclarationSlots: serialConsoleControlBaseDeclarationSlots,
+	}})
+}
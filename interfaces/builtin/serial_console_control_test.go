@@ -0,0 +1,141 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type serialConsoleControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&serialConsoleControlInterfaceSuite{
+	iface: builtin.MustInterface("serial-console-control"),
+})
+
+const serialConsoleControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [serial-console-control]
+`
+
+const serialConsoleControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  serial-console-control:
+`
+
+func (s *serialConsoleControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, serialConsoleControlConsumerYaml, nil, "serial-console-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, serialConsoleControlCoreYaml, nil, "serial-console-control")
+}
+
+func (s *serialConsoleControlInterfaceSuite) TearDownTest(c *C) {
+	dirs.SetRootDir("")
+}
+
+func (s *serialConsoleControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "serial-console-control")
+}
+
+func (s *serialConsoleControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *serialConsoleControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *serialConsoleControlInterfaceSuite) mockActiveConsoles(c *C, active string) {
+	dirs.SetRootDir(c.MkDir())
+	path := filepath.Join(dirs.GlobalRootDir, "/sys/class/tty/console/active")
+	c.Assert(os.MkdirAll(filepath.Dir(path), 0755), IsNil)
+	c.Assert(os.WriteFile(path, []byte(active), 0644), IsNil)
+}
+
+func (s *serialConsoleControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	s.mockActiveConsoles(c, "tty0 ttyS0 ttyAMA1\n")
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "/sys/class/tty/console/active r,\n")
+	c.Check(snippet, testutil.Contains, "/dev/ttyS0 rwk,")
+	c.Check(snippet, testutil.Contains, "/dev/ttyAMA1 rwk,")
+	// virtual terminals are not granted
+	c.Check(snippet, Not(testutil.Contains), "/dev/tty0 ")
+}
+
+func (s *serialConsoleControlInterfaceSuite) TestAppArmorSpecNoSerialConsole(c *C) {
+	s.mockActiveConsoles(c, "tty0\n")
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "/dev/")
+}
+
+func (s *serialConsoleControlInterfaceSuite) TestUDevSpec(c *C) {
+	s.mockActiveConsoles(c, "tty0 ttyS0\n")
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 2)
+	c.Assert(spec.Snippets(), testutil.Contains, `# serial-console-control
+SUBSYSTEM=="tty", KERNEL=="ttyS0", TAG+="snap_consumer_app"`)
+}
+
+func (s *serialConsoleControlInterfaceSuite) TestUDevSpecNoConsoleInfo(c *C) {
+	dirs.SetRootDir(c.MkDir())
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 0)
+}
+
+func (s *serialConsoleControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows access to the serial port used as system console`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "serial-console-control")
+}
+
+func (s *serialConsoleControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *serialConsoleControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -0,0 +1,108 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+)
+
+const smbusControlSummary = `allows SMBus transactions on specific I2C bus`
+
+const smbusControlBaseDeclarationSlots = `
+  smbus-control:
+    allow-installation:
+      slot-snap-type:
+        - gadget
+        - core
+    deny-auto-connection: true
+`
+
+const smbusControlConnectedPlugAppArmor = `
+# Description: Can access the I2C bus %[1]d to talk to SMBus and PMBus
+# devices, such as sensors and voltage regulators.
+
+/dev/i2c-%[1]d rw,
+/sys/devices/**/i2c-%[1]d/name r,
+`
+
+const smbusControlConnectedPlugSecComp = `
+# Description: Can select the address of a device on the bus and run SMBus
+# transactions with it. ioctl() mediation relies on the access to the device
+# nodes, the plain I2C transfers of I2C_RDWR are not part of the SMBus
+# command set and are not listed.
+
+ioctl - I2C_SLAVE
+ioctl - I2C_FUNCS
+ioctl - I2C_PEC
+ioctl - I2C_SMBUS
+`
+
+// smbusControlMaxBus is the highest bus number of the i2c-dev device nodes,
+// limited by the minor numbers of the character devices.
+const smbusControlMaxBus = 1<<20 - 1
+
+type smbusControlInterface struct {
+	commonInterface
+}
+
+// BeforePrepareSlot checks that the slot pins a valid I2C bus with the "bus"
+// attribute.
+func (iface *smbusControlInterface) BeforePrepareSlot(slot *snap.SlotInfo) error {
+	bus, ok := slot.Attrs["bus"].(int64)
+	if !ok {
+		return fmt.Errorf("%s slot must have an integer bus attribute", iface.Name())
+	}
+	if bus < 0 || bus > smbusControlMaxBus {
+		return fmt.Errorf("%s slot bus attribute must be in the range 0-%d", iface.Name(), smbusControlMaxBus)
+	}
+	return nil
+}
+
+func (iface *smbusControlInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	var bus int64
+	if err := slot.Attr("bus", &bus); err != nil {
+		return err
+	}
+	spec.AddSnippet(fmt.Sprintf(smbusControlConnectedPlugAppArmor, bus))
+	return nil
+}
+
+func (iface *smbusControlInterface) UDevConnectedPlug(spec *udev.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	var bus int64
+	if err := slot.Attr("bus", &bus); err != nil {
+		return err
+	}
+	spec.TagDevice(fmt.Sprintf(`KERNEL=="i2c-%d"`, bus))
+	return nil
+}
+
+func init() {
+	registerIface(&smbusControlInterface{commonInterface{
+		name:                 "smbus-control",
+		summary:              smbusControlSummary,
+		baseDeclarationSlots: smbusControlBaseDeclarationSlots,
+		connectedPlugSecComp: smbusControlConnectedPlugSecComp,
+	}})
+}
@@ -0,0 +1,151 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/snap/snaptest"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type smbusControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&smbusControlInterfaceSuite{
+	iface: builtin.MustInterface("smbus-control"),
+})
+
+const smbusControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [smbus-control]
+`
+
+const smbusControlGadgetYaml = `name: gadget
+version: 0
+type: gadget
+slots:
+  smbus-bus-3:
+    interface: smbus-control
+    bus: 3
+`
+
+func (s *smbusControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, smbusControlConsumerYaml, nil, "smbus-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, smbusControlGadgetYaml, nil, "smbus-bus-3")
+}
+
+func (s *smbusControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "smbus-control")
+}
+
+func (s *smbusControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *smbusControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *smbusControlInterfaceSuite) TestSanitizeSlotBadBus(c *C) {
+	for _, t := range []struct {
+		bus string
+		err string
+	}{
+		{"", `smbus-control slot must have an integer bus attribute`},
+		{"bus: one", `smbus-control slot must have an integer bus attribute`},
+		{"bus: 1.5", `smbus-control slot must have an integer bus attribute`},
+		{"bus: -1", `smbus-control slot bus attribute must be in the range 0-1048575`},
+		{"bus: 1048576", `smbus-control slot bus attribute must be in the range 0-1048575`},
+	} {
+		yaml := fmt.Sprintf(`name: gadget
+version: 0
+type: gadget
+slots:
+  smbus:
+    interface: smbus-control
+    %s
+`, t.bus)
+		slotInfo := snaptest.MockInfo(c, yaml, nil).Slots["smbus"]
+		c.Check(interfaces.BeforePrepareSlot(s.iface, slotInfo), ErrorMatches, t.err, Commentf("%q", t.bus))
+	}
+}
+
+func (s *smbusControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/dev/i2c-3 rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/**/i2c-3/name r,\n")
+	// only the pinned bus
+	c.Check(snippet, Not(testutil.Contains), "/dev/i2c-[")
+}
+
+func (s *smbusControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\nioctl - I2C_SLAVE\n")
+	c.Check(snippet, testutil.Contains, "\nioctl - I2C_SMBUS\n")
+	c.Check(snippet, Not(testutil.Contains), "I2C_RDWR\n")
+}
+
+func (s *smbusControlInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 2)
+	c.Assert(spec.Snippets(), testutil.Contains, `# smbus-control
+KERNEL=="i2c-3", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+}
+
+func (s *smbusControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, false)
+	c.Assert(si.ImplicitOnClassic, Equals, false)
+	c.Assert(si.Summary, Equals, `allows SMBus transactions on specific I2C bus`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "smbus-control")
+}
+
+func (s *smbusControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *smbusControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
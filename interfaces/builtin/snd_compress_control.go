@@ -0,0 +1,84 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const sndCompressControlSummary = `allows access to the ALSA compressed audio offload devices`
+
+// The compressed audio offload devices feed the audio DSPs of the system
+// directly, bypassing the sound server and its mediation, so connections need
+// to be explicitly granted.
+const sndCompressControlBaseDeclarationSlots = `
+  snd-compress-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const sndCompressControlConnectedPlugAppArmor = `
+# Description: Can play and capture compressed audio streams through the
+# hardware decoders and encoders of the audio DSPs. See
+# https://www.kernel.org/doc/html/latest/sound/designs/compress-offload.html
+
+/dev/snd/comprC[0-9]*D[0-9]* rw,
+
+# Enumerating the compressed devices of the sound cards
+/sys/class/sound/ r,
+/sys/devices/**/sound/card[0-9]*/comprC[0-9]*D[0-9]*/{,**} r,
+`
+
+const sndCompressControlConnectedPlugSecComp = `
+# Description: Can query the capabilities of the devices, configure the codec
+# and control the compressed streams.
+
+ioctl - SNDRV_COMPRESS_IOCTL_VERSION
+ioctl - SNDRV_COMPRESS_GET_CAPS
+ioctl - SNDRV_COMPRESS_GET_CODEC_CAPS
+ioctl - SNDRV_COMPRESS_SET_PARAMS
+ioctl - SNDRV_COMPRESS_GET_PARAMS
+ioctl - SNDRV_COMPRESS_SET_METADATA
+ioctl - SNDRV_COMPRESS_GET_METADATA
+ioctl - SNDRV_COMPRESS_TSTAMP
+ioctl - SNDRV_COMPRESS_AVAIL
+ioctl - SNDRV_COMPRESS_PAUSE
+ioctl - SNDRV_COMPRESS_RESUME
+ioctl - SNDRV_COMPRESS_START
+ioctl - SNDRV_COMPRESS_STOP
+ioctl - SNDRV_COMPRESS_DRAIN
+ioctl - SNDRV_COMPRESS_NEXT_TRACK
+ioctl - SNDRV_COMPRESS_PARTIAL_DRAIN
+`
+
+var sndCompressControlConnectedPlugUDev = []string{
+	`SUBSYSTEM=="sound", KERNEL=="comprC[0-9]*D[0-9]*"`,
+}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "snd-compress-control",
+		summary:               sndCompressControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  sndCompressControlBaseDeclarationSlots,
+		connectedPlugAppArmor: sndCompressControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  sndCompressControlConnectedPlugSecComp,
+		connectedPlugUDev:     sndCompressControlConnectedPlugUDev,
+	})
+}
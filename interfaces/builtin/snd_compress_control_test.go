@@ -0,0 +1,130 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type sndCompressControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&sndCompressControlInterfaceSuite{
+	iface: builtin.MustInterface("snd-compress-control"),
+})
+
+const sndCompressControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [snd-compress-control]
+`
+
+const sndCompressControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  snd-compress-control:
+`
+
+func (s *sndCompressControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, sndCompressControlConsumerYaml, nil, "snd-compress-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, sndCompressControlCoreYaml, nil, "snd-compress-control")
+}
+
+func (s *sndCompressControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "snd-compress-control")
+}
+
+func (s *sndCompressControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *sndCompressControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *sndCompressControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/dev/snd/comprC[0-9]*D[0-9]* rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/class/sound/ r,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/**/sound/card[0-9]*/comprC[0-9]*D[0-9]*/{,**} r,\n")
+	// the other sound devices are not covered
+	c.Check(snippet, Not(testutil.Contains), "/dev/snd/* ")
+	c.Check(snippet, Not(testutil.Contains), "pcmC")
+}
+
+func (s *sndCompressControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	for _, ioctl := range []string{
+		"IOCTL_VERSION", "GET_CAPS", "GET_CODEC_CAPS", "SET_PARAMS", "GET_PARAMS", "SET_METADATA",
+		"GET_METADATA", "TSTAMP", "AVAIL", "PAUSE", "RESUME", "START", "STOP", "DRAIN",
+		"NEXT_TRACK", "PARTIAL_DRAIN",
+	} {
+		c.Check(snippet, testutil.Contains, "\nioctl - SNDRV_COMPRESS_"+ioctl+"\n")
+	}
+}
+
+func (s *sndCompressControlInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 2)
+	c.Assert(spec.Snippets(), testutil.Contains, `# snd-compress-control
+SUBSYSTEM=="sound", KERNEL=="comprC[0-9]*D[0-9]*", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+}
+
+func (s *sndCompressControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows access to the ALSA compressed audio offload devices`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "snd-compress-control")
+}
+
+func (s *sndCompressControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *sndCompressControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
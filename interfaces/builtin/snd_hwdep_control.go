@@ -0,0 +1,71 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const sndHwdepControlSummary = `allows loading firmware and DSP programs to sound hardware`
+
+const sndHwdepControlBaseDeclarationSlots = `
+  snd-hwdep-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const sndHwdepControlConnectedPlugAppArmor = `
+# Description: Can access the ALSA hardware dependent devices of sound cards,
+# used to load firmware and DSP programs onto the sound hardware. Loading a
+# DSP program may change the behaviour of the sound card for all users.
+# See https://www.kernel.org/doc/html/latest/sound/designs/index.html
+
+/dev/snd/ r,
+/dev/snd/hwC[0-9]*D[0-9]* rw,
+
+# Enumerating the hwdep devices and their cards
+/sys/class/sound/ r,
+/sys/devices/**/sound/card[0-9]*/hwC[0-9]*D[0-9]*/** r,
+`
+
+const sndHwdepControlConnectedPlugSecComp = `
+# Description: Can query the hwdep devices and the state of their DSPs and
+# load DSP images.
+
+ioctl - SNDRV_HWDEP_IOCTL_PVERSION
+ioctl - SNDRV_HWDEP_IOCTL_INFO
+ioctl - SNDRV_HWDEP_IOCTL_DSP_STATUS
+ioctl - SNDRV_HWDEP_IOCTL_DSP_LOAD
+`
+
+var sndHwdepControlConnectedPlugUDev = []string{
+	`SUBSYSTEM=="sound", KERNEL=="hwC[0-9]*D[0-9]*"`,
+}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "snd-hwdep-control",
+		summary:               sndHwdepControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  sndHwdepControlBaseDeclarationSlots,
+		connectedPlugAppArmor: sndHwdepControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  sndHwdepControlConnectedPlugSecComp,
+		connectedPlugUDev:     sndHwdepControlConnectedPlugUDev,
+	})
+}
@@ -0,0 +1,125 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type sndHwdepControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&sndHwdepControlInterfaceSuite{
+	iface: builtin.MustInterface("snd-hwdep-control"),
+})
+
+const sndHwdepControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [snd-hwdep-control]
+`
+
+const sndHwdepControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  snd-hwdep-control:
+`
+
+func (s *sndHwdepControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, sndHwdepControlConsumerYaml, nil, "snd-hwdep-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, sndHwdepControlCoreYaml, nil, "snd-hwdep-control")
+}
+
+func (s *sndHwdepControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "snd-hwdep-control")
+}
+
+func (s *sndHwdepControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *sndHwdepControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *sndHwdepControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/dev/snd/hwC[0-9]*D[0-9]* rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/**/sound/card[0-9]*/hwC[0-9]*D[0-9]*/** r,\n")
+	// the PCM and control devices are left to the audio interfaces
+	c.Check(snippet, Not(testutil.Contains), "/dev/snd/* rw,")
+	c.Check(snippet, Not(testutil.Contains), "controlC")
+}
+
+func (s *sndHwdepControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	for _, ioctl := range []string{"PVERSION", "INFO", "DSP_STATUS", "DSP_LOAD"} {
+		c.Check(snippet, testutil.Contains, "\nioctl - SNDRV_HWDEP_IOCTL_"+ioctl+"\n")
+	}
+}
+
+func (s *sndHwdepControlInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 2)
+	c.Assert(spec.Snippets(), testutil.Contains, `# snd-hwdep-control
+SUBSYSTEM=="sound", KERNEL=="hwC[0-9]*D[0-9]*", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+}
+
+func (s *sndHwdepControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows loading firmware and DSP programs to sound hardware`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "snd-hwdep-control")
+}
+
+func (s *sndHwdepControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *sndHwdepControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
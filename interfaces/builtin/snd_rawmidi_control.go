@@ -0,0 +1,75 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const sndRawmidiControlSummary = `allows access to raw MIDI devices`
+
+const sndRawmidiControlBaseDeclarationSlots = `
+  snd-rawmidi-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+// Unlike the alsa interface, snd-rawmidi-control neither grants the ALSA
+// sequencer nor the PCM and control devices of the sound cards.
+const sndRawmidiControlConnectedPlugAppArmor = `
+# Description: Can send and receive raw MIDI bytes through the ALSA rawmidi
+# devices of sound cards, bypassing the sequencer. See
+# https://www.alsa-project.org/alsa-doc/alsa-lib/rawmidi.html
+
+/dev/snd/ r,
+/dev/snd/midiC[0-9]*D[0-9]* rw,
+
+# Enumerating the rawmidi devices and their cards
+/sys/class/sound/ r,
+/sys/devices/**/sound/card[0-9]*/midiC[0-9]*D[0-9]*/** r,
+`
+
+const sndRawmidiControlConnectedPlugSecComp = `
+# Description: Can query and configure the rawmidi devices, and drop or drain
+# their buffers.
+
+ioctl - SNDRV_RAWMIDI_IOCTL_PVERSION
+ioctl - SNDRV_RAWMIDI_IOCTL_INFO
+ioctl - SNDRV_RAWMIDI_IOCTL_USER_PVERSION
+ioctl - SNDRV_RAWMIDI_IOCTL_PARAMS
+ioctl - SNDRV_RAWMIDI_IOCTL_STATUS
+ioctl - SNDRV_RAWMIDI_IOCTL_DROP
+ioctl - SNDRV_RAWMIDI_IOCTL_DRAIN
+`
+
+var sndRawmidiControlConnectedPlugUDev = []string{
+	`SUBSYSTEM=="sound", KERNEL=="midiC[0-9]*D[0-9]*"`,
+}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "snd-rawmidi-control",
+		summary:               sndRawmidiControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  sndRawmidiControlBaseDeclarationSlots,
+		connectedPlugAppArmor: sndRawmidiControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  sndRawmidiControlConnectedPlugSecComp,
+		connectedPlugUDev:     sndRawmidiControlConnectedPlugUDev,
+	})
+}
@@ -0,0 +1,125 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type sndRawmidiControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&sndRawmidiControlInterfaceSuite{
+	iface: builtin.MustInterface("snd-rawmidi-control"),
+})
+
+const sndRawmidiControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [snd-rawmidi-control]
+`
+
+const sndRawmidiControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  snd-rawmidi-control:
+`
+
+func (s *sndRawmidiControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, sndRawmidiControlConsumerYaml, nil, "snd-rawmidi-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, sndRawmidiControlCoreYaml, nil, "snd-rawmidi-control")
+}
+
+func (s *sndRawmidiControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "snd-rawmidi-control")
+}
+
+func (s *sndRawmidiControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *sndRawmidiControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+func (s *sndRawmidiControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/dev/snd/midiC[0-9]*D[0-9]* rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/sys/devices/**/sound/card[0-9]*/midiC[0-9]*D[0-9]*/** r,\n")
+	// the sequencer, PCM and control devices are left to the alsa interface
+	c.Check(snippet, Not(testutil.Contains), "/dev/snd/* rw,")
+	c.Check(snippet, Not(testutil.Contains), "controlC")
+	c.Check(snippet, Not(testutil.Contains), "/dev/snd/seq")
+}
+
+func (s *sndRawmidiControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	for _, ioctl := range []string{"PVERSION", "INFO", "USER_PVERSION", "PARAMS", "STATUS", "DROP", "DRAIN"} {
+		c.Check(snippet, testutil.Contains, "\nioctl - SNDRV_RAWMIDI_IOCTL_"+ioctl+"\n")
+	}
+}
+
+func (s *sndRawmidiControlInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 2)
+	c.Assert(spec.Snippets(), testutil.Contains, `# snd-rawmidi-control
+SUBSYSTEM=="sound", KERNEL=="midiC[0-9]*D[0-9]*", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+}
+
+func (s *sndRawmidiControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows access to raw MIDI devices`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "snd-rawmidi-control")
+}
+
+func (s *sndRawmidiControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *sndRawmidiControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
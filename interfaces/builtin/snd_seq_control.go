@@ -0,0 +1,121 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const sndSeqControlSummary = `allows routing MIDI events with the ALSA sequencer and timers`
+
+const sndSeqControlBaseDeclarationSlots = `
+  snd-seq-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const sndSeqControlConnectedPlugAppArmor = `
+# Description: Can use the ALSA sequencer to send, receive and route MIDI
+# events between clients, and the ALSA timers used to schedule them. This
+# does not grant access to the PCM devices of the sound cards.
+# See https://www.kernel.org/doc/html/latest/sound/designs/seq-oss.html
+
+/dev/snd/ r,
+/dev/snd/seq rw,
+/dev/snd/timer rw,
+
+# Listing the sequencer clients and timers
+@{PROC}/asound/seq/{,*} r,
+@{PROC}/asound/timers r,
+# From https://www.kernel.org/doc/Documentation/admin-guide/devices.txt
+/run/udev/data/c116:1 r,
+/run/udev/data/c116:33 r,
+`
+
+const sndSeqControlConnectedPlugSecComp = `
+# Description: Can use the ALSA sequencer and timers.
+
+# Sequencer clients, ports, subscriptions and queues
+ioctl - SNDRV_SEQ_IOCTL_PVERSION
+ioctl - SNDRV_SEQ_IOCTL_CLIENT_ID
+ioctl - SNDRV_SEQ_IOCTL_SYSTEM_INFO
+ioctl - SNDRV_SEQ_IOCTL_RUNNING_MODE
+ioctl - SNDRV_SEQ_IOCTL_USER_PVERSION
+ioctl - SNDRV_SEQ_IOCTL_GET_CLIENT_INFO
+ioctl - SNDRV_SEQ_IOCTL_SET_CLIENT_INFO
+ioctl - SNDRV_SEQ_IOCTL_CREATE_PORT
+ioctl - SNDRV_SEQ_IOCTL_DELETE_PORT
+ioctl - SNDRV_SEQ_IOCTL_GET_PORT_INFO
+ioctl - SNDRV_SEQ_IOCTL_SET_PORT_INFO
+ioctl - SNDRV_SEQ_IOCTL_SUBSCRIBE_PORT
+ioctl - SNDRV_SEQ_IOCTL_UNSUBSCRIBE_PORT
+ioctl - SNDRV_SEQ_IOCTL_CREATE_QUEUE
+ioctl - SNDRV_SEQ_IOCTL_DELETE_QUEUE
+ioctl - SNDRV_SEQ_IOCTL_GET_QUEUE_INFO
+ioctl - SNDRV_SEQ_IOCTL_SET_QUEUE_INFO
+ioctl - SNDRV_SEQ_IOCTL_GET_NAMED_QUEUE
+ioctl - SNDRV_SEQ_IOCTL_GET_QUEUE_STATUS
+ioctl - SNDRV_SEQ_IOCTL_GET_QUEUE_TEMPO
+ioctl - SNDRV_SEQ_IOCTL_SET_QUEUE_TEMPO
+ioctl - SNDRV_SEQ_IOCTL_GET_QUEUE_TIMER
+ioctl - SNDRV_SEQ_IOCTL_SET_QUEUE_TIMER
+ioctl - SNDRV_SEQ_IOCTL_GET_QUEUE_CLIENT
+ioctl - SNDRV_SEQ_IOCTL_SET_QUEUE_CLIENT
+ioctl - SNDRV_SEQ_IOCTL_GET_CLIENT_POOL
+ioctl - SNDRV_SEQ_IOCTL_SET_CLIENT_POOL
+ioctl - SNDRV_SEQ_IOCTL_REMOVE_EVENTS
+ioctl - SNDRV_SEQ_IOCTL_QUERY_SUBS
+ioctl - SNDRV_SEQ_IOCTL_GET_SUBSCRIPTION
+ioctl - SNDRV_SEQ_IOCTL_QUERY_NEXT_CLIENT
+ioctl - SNDRV_SEQ_IOCTL_QUERY_NEXT_PORT
+
+# Querying and driving the timers
+ioctl - SNDRV_TIMER_IOCTL_PVERSION
+ioctl - SNDRV_TIMER_IOCTL_NEXT_DEVICE
+ioctl - SNDRV_TIMER_IOCTL_TREAD_OLD
+ioctl - SNDRV_TIMER_IOCTL_TREAD64
+ioctl - SNDRV_TIMER_IOCTL_GINFO
+ioctl - SNDRV_TIMER_IOCTL_GPARAMS
+ioctl - SNDRV_TIMER_IOCTL_GSTATUS
+ioctl - SNDRV_TIMER_IOCTL_SELECT
+ioctl - SNDRV_TIMER_IOCTL_INFO
+ioctl - SNDRV_TIMER_IOCTL_PARAMS
+ioctl - SNDRV_TIMER_IOCTL_STATUS
+ioctl - SNDRV_TIMER_IOCTL_START
+ioctl - SNDRV_TIMER_IOCTL_STOP
+ioctl - SNDRV_TIMER_IOCTL_CONTINUE
+ioctl - SNDRV_TIMER_IOCTL_PAUSE
+`
+
+var sndSeqControlConnectedPlugUDev = []string{
+	`SUBSYSTEM=="sound", KERNEL=="seq"`,
+	`SUBSYSTEM=="sound", KERNEL=="timer"`,
+}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "snd-seq-control",
+		summary:               sndSeqControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  sndSeqControlBaseDeclarationSlots,
+		connectedPlugAppArmor: sndSeqControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  sndSeqControlConnectedPlugSecComp,
+		connectedPlugUDev:     sndSeqControlConnectedPlugUDev,
+	})
+}
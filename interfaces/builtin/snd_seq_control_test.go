@@ -0,0 +1,132 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type sndSeqControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&sndSeqControlInterfaceSuite{
+	iface: builtin.MustInterface("snd-seq-control"),
+})
+
+const sndSeqControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [snd-seq-control]
+`
+
+const sndSeqControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  snd-seq-control:
+`
+
+func (s *sndSeqControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, sndSeqControlConsumerYaml, nil, "snd-seq-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, sndSeqControlCoreYaml, nil, "snd-seq-control")
+}
+
+func (s *sndSeqControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "snd-seq-control")
+}
+
+func (s *sndSeqControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *sndSeqControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *sndSeqControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "/dev/snd/seq rw,\n")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "/dev/snd/timer rw,\n")
+	// raw PCM access is not granted
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "pcmC")
+}
+
+func (s *sndSeqControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	for _, ioctl := range []string{"SNDRV_SEQ_IOCTL_PVERSION", "SNDRV_SEQ_IOCTL_CREATE_PORT", "SNDRV_SEQ_IOCTL_SUBSCRIBE_PORT", "SNDRV_SEQ_IOCTL_CREATE_QUEUE", "SNDRV_TIMER_IOCTL_SELECT", "SNDRV_TIMER_IOCTL_STATUS"} {
+		c.Check(snippet, testutil.Contains, "\nioctl - "+ioctl+"\n")
+	}
+	// each rule restricts the request
+	for _, line := range strings.Split(snippet, "\n") {
+		if strings.HasPrefix(line, "ioctl") {
+			c.Check(line, Matches, `ioctl - SNDRV_(SEQ|TIMER)_IOCTL_[A-Z0-9_]+`)
+		}
+	}
+}
+
+func (s *sndSeqControlInterfaceSuite) TestUDevSpec(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 3)
+	c.Assert(spec.Snippets(), testutil.Contains, `# snd-seq-control
+SUBSYSTEM=="sound", KERNEL=="seq", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, `# snd-seq-control
+SUBSYSTEM=="sound", KERNEL=="timer", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+}
+
+func (s *sndSeqControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows routing MIDI events with the ALSA sequencer and timers`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "snd-seq-control")
+}
+
+func (s *sndSeqControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *sndSeqControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
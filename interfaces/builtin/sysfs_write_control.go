@@ -0,0 +1,120 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/snap"
+)
+
+const sysfsWriteControlSummary = `allows write access to specific sysfs paths`
+
+const sysfsWriteControlBaseDeclarationSlots = `
+  sysfs-write-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+        - gadget
+    deny-auto-connection: true
+`
+
+const sysfsWriteControlConnectedPlugAppArmor = `
+# Description: Can write to the sysfs paths listed by the slot. Note that
+# AppArmor mediates the path after symlinks are resolved, so paths under
+# /sys/class, /sys/bus and similar need to be listed by the /sys/devices
+# location they point to.
+`
+
+// sysfsWritePathPattern matches sysfs paths composed of characters which have
+// no special meaning in AppArmor rules, except for the '*' wildcard.
+var sysfsWritePathPattern = regexp.MustCompile(`^/sys(/[a-zA-Z0-9_.:@+*-]+)+$`)
+
+type sysfsWriteControlInterface struct {
+	commonInterface
+}
+
+func validateSysfsWritePath(path string) error {
+	if !sysfsWritePathPattern.MatchString(path) {
+		return fmt.Errorf(`%q must be a path under /sys/ and not contain special characters`, path)
+	}
+	if !cleanSubPath(path) {
+		return fmt.Errorf(`%q is not clean`, path)
+	}
+	// '**' would allow matching across directories
+	if strings.Contains(path, "**") {
+		return fmt.Errorf(`%q cannot contain "**"`, path)
+	}
+	// the top level sysfs directory must be fixed, such that a wildcard
+	// cannot match all of sysfs
+	topLevel := strings.SplitN(strings.TrimPrefix(path, "/sys/"), "/", 2)
+	if len(topLevel) < 2 || strings.Contains(topLevel[0], "*") {
+		return fmt.Errorf(`%q must be below a fixed top level sysfs directory`, path)
+	}
+	return nil
+}
+
+func (iface *sysfsWriteControlInterface) BeforePrepareSlot(slot *snap.SlotInfo) error {
+	rawPaths, ok := slot.Attrs["write-paths"]
+	if !ok {
+		return fmt.Errorf(`%s slot must have a "write-paths" attribute`, iface.Name())
+	}
+	paths, ok := rawPaths.([]any)
+	if !ok || len(paths) == 0 {
+		return fmt.Errorf(`%s "write-paths" attribute must be a non-empty list of strings`, iface.Name())
+	}
+	for _, rawPath := range paths {
+		path, ok := rawPath.(string)
+		if !ok {
+			return fmt.Errorf(`%s "write-paths" attribute must be a non-empty list of strings`, iface.Name())
+		}
+		if err := validateSysfsWritePath(path); err != nil {
+			return fmt.Errorf(`%s "write-paths" attribute is invalid: %v`, iface.Name(), err)
+		}
+	}
+	return nil
+}
+
+func (iface *sysfsWriteControlInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	var paths []string
+	if err := slot.Attr("write-paths", &paths); err != nil {
+		return err
+	}
+	buf := bytes.NewBufferString(sysfsWriteControlConnectedPlugAppArmor)
+	for _, path := range paths {
+		// paths were validated when the slot was prepared
+		fmt.Fprintf(buf, "%s rw,\n", path)
+	}
+	spec.AddSnippet(buf.String())
+	return nil
+}
+
+func init() {
+	registerIface(&sysfsWriteControlInterface{commonInterface{
+		name:                 "sysfs-write-control",
+		summary:              sysfsWriteControlSummary,
+		baseDeclarationSlots: sysfsWriteControlBaseDeclarationSlots,
+	}})
+}
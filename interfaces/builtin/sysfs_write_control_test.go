@@ -0,0 +1,182 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/snap/snaptest"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type sysfsWriteControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&sysfsWriteControlInterfaceSuite{
+	iface: builtin.MustInterface("sysfs-write-control"),
+})
+
+const sysfsWriteControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [sysfs-write-control]
+`
+
+const sysfsWriteControlGadgetYaml = `name: gadget
+version: 0
+type: gadget
+slots:
+  sysfs-write-control:
+    write-paths:
+      - /sys/devices/platform/foo/*/bar
+      - /sys/devices/platform/baz/enable
+`
+
+func (s *sysfsWriteControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, sysfsWriteControlConsumerYaml, nil, "sysfs-write-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, sysfsWriteControlGadgetYaml, nil, "sysfs-write-control")
+}
+
+func (s *sysfsWriteControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "sysfs-write-control")
+}
+
+func (s *sysfsWriteControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *sysfsWriteControlInterfaceSuite) TestSanitizeSlotValidPaths(c *C) {
+	for _, path := range []string{
+		"/sys/class/foo/bar",
+		"/sys/class/foo/*/bar",
+		"/sys/devices/platform/foo.0/leds/led:green/brightness",
+		"/sys/devices/platform/foo*/bar",
+		"/sys/kernel/mm/transparent_hugepage/enabled",
+	} {
+		slot := &snap.SlotInfo{
+			Snap:      &snap.Info{SuggestedName: "gadget", SnapType: snap.TypeGadget},
+			Name:      "sysfs-write-control",
+			Interface: "sysfs-write-control",
+			Attrs:     map[string]any{"write-paths": []any{path}},
+		}
+		c.Check(interfaces.BeforePrepareSlot(s.iface, slot), IsNil, Commentf("path %q", path))
+	}
+}
+
+func (s *sysfsWriteControlInterfaceSuite) TestSanitizeSlotInvalidPaths(c *C) {
+	for _, t := range []struct {
+		paths any
+		err   string
+	}{
+		{nil, `sysfs-write-control slot must have a "write-paths" attribute`},
+		{"/sys/class/foo/bar", `sysfs-write-control "write-paths" attribute must be a non-empty list of strings`},
+		{[]any{}, `sysfs-write-control "write-paths" attribute must be a non-empty list of strings`},
+		{[]any{42}, `sysfs-write-control "write-paths" attribute must be a non-empty list of strings`},
+		{[]any{"/sys"}, `sysfs-write-control "write-paths" attribute is invalid: "/sys" must be a path under /sys/ and not contain special characters`},
+		{[]any{"/proc/sys/kernel/foo"}, `.* "/proc/sys/kernel/foo" must be a path under /sys/ and not contain special characters`},
+		{[]any{"/sysfoo/bar"}, `.* "/sysfoo/bar" must be a path under /sys/ and not contain special characters`},
+		{[]any{"/sys/class/foo/"}, `.* "/sys/class/foo/" must be a path under /sys/ and not contain special characters`},
+		{[]any{"/sys/class/{foo,bar}/baz"}, `.* must be a path under /sys/ and not contain special characters`},
+		{[]any{"/sys/class/foo/ba?"}, `.* must be a path under /sys/ and not contain special characters`},
+		{[]any{"/sys/class/foo/[ab]"}, `.* must be a path under /sys/ and not contain special characters`},
+		{[]any{"/sys/class/foo bar/baz"}, `.* must be a path under /sys/ and not contain special characters`},
+		{[]any{"/sys/class/foo,bar"}, `.* must be a path under /sys/ and not contain special characters`},
+		{[]any{"/sys/class/../../etc/shadow"}, `.* "/sys/class/../../etc/shadow" is not clean`},
+		{[]any{"/sys/class/./foo"}, `.* "/sys/class/./foo" is not clean`},
+		{[]any{"/sys/class/foo/.."}, `.* "/sys/class/foo/.." is not clean`},
+		{[]any{"/sys/class/**"}, `.* "/sys/class/\*\*" cannot contain "\*\*"`},
+		{[]any{"/sys/*/foo"}, `.* "/sys/\*/foo" must be below a fixed top level sysfs directory`},
+		{[]any{"/sys/class"}, `.* "/sys/class" must be below a fixed top level sysfs directory`},
+		{[]any{"/sys/class/foo/bar", "/sys/../foo"}, `.* "/sys/../foo" is not clean`},
+	} {
+		attrs := map[string]any{}
+		if t.paths != nil {
+			attrs["write-paths"] = t.paths
+		}
+		slot := &snap.SlotInfo{
+			Snap:      &snap.Info{SuggestedName: "gadget", SnapType: snap.TypeGadget},
+			Name:      "sysfs-write-control",
+			Interface: "sysfs-write-control",
+			Attrs:     attrs,
+		}
+		c.Check(interfaces.BeforePrepareSlot(s.iface, slot), ErrorMatches, t.err, Commentf("paths %v", t.paths))
+	}
+}
+
+func (s *sysfsWriteControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *sysfsWriteControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	appSet, err := interfaces.NewSnapAppSet(s.plug.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec := apparmor.NewSpecification(appSet)
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	c.Assert(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "/sys/devices/platform/foo/*/bar rw,\n")
+	c.Assert(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "/sys/devices/platform/baz/enable rw,\n")
+}
+
+func (s *sysfsWriteControlInterfaceSuite) TestAppArmorSpecSlotWithoutPaths(c *C) {
+	slotSnap := snaptest.MockInfo(c, `name: gadget
+version: 0
+type: gadget
+slots:
+  sysfs-write-control:
+`, nil)
+	appSet, err := interfaces.NewSnapAppSet(s.plug.Snap(), nil)
+	c.Assert(err, IsNil)
+	slotAppSet, err := interfaces.NewSnapAppSet(slotSnap, nil)
+	c.Assert(err, IsNil)
+	slotInfo := &snap.SlotInfo{
+		Snap:      slotSnap,
+		Name:      "sysfs-write-control",
+		Interface: "sysfs-write-control",
+	}
+	slot := interfaces.NewConnectedSlot(slotInfo, slotAppSet, nil, nil)
+	spec := apparmor.NewSpecification(appSet)
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, slot), ErrorMatches, `snap "gadget" does not have attribute "write-paths" for interface "sysfs-write-control"`)
+}
+
+func (s *sysfsWriteControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, false)
+	c.Assert(si.ImplicitOnClassic, Equals, false)
+	c.Assert(si.Summary, Equals, `allows write access to specific sysfs paths`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "sysfs-write-control")
+}
+
+func (s *sysfsWriteControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *sysfsWriteControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
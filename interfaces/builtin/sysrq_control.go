@@ -0,0 +1,56 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const sysrqControlSummary = `allows triggering magic SysRq functions`
+
+// Magic SysRq lets the snap reboot or power off the system without syncing
+// the disks, kill all processes, change the console log level and dump kernel
+// state, irrespective of the confinement of the other snaps. This is only
+// meant for recovery and debug snaps, so connections need to be explicitly
+// granted.
+const sysrqControlBaseDeclarationSlots = `
+  sysrq-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const sysrqControlConnectedPlugAppArmor = `
+# Description: Can trigger magic SysRq functions, for instance to reboot a hung
+# system or to dump the state of its tasks to the kernel log.
+# https://www.kernel.org/doc/html/latest/admin-guide/sysrq.html
+@{PROC}/sysrq-trigger w,
+
+# the mask of the SysRq functions that are enabled on the keyboard, changing
+# it is left to the system
+@{PROC}/sys/kernel/sysrq r,
+`
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "sysrq-control",
+		summary:               sysrqControlSummary,
+		implicitOnCore:        true,
+		baseDeclarationSlots:  sysrqControlBaseDeclarationSlots,
+		connectedPlugAppArmor: sysrqControlConnectedPlugAppArmor,
+	})
+}
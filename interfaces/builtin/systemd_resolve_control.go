@@ -0,0 +1,116 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/polkit"
+)
+
+const systemdResolveControlSummary = `allows configuring DNS via systemd-resolved`
+
+const systemdResolveControlBaseDeclarationSlots = `
+  systemd-resolve-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const systemdResolveControlConnectedPlugAppArmor = `
+# Description: Can configure per-link DNS servers, search domains and related
+# settings through the systemd-resolved D-Bus API, see:
+# https://www.freedesktop.org/software/systemd/man/latest/org.freedesktop.resolve1.html
+
+#include <abstractions/dbus-strict>
+
+# Introspection of org.freedesktop.resolve1
+# do not use peer=(label=unconfined) here since this is DBus activated
+dbus (send)
+    bus=system
+    path=/org/freedesktop/resolve1{,/**}
+    interface=org.freedesktop.DBus.Introspectable
+    member=Introspect,
+
+# Read all properties of the manager and link objects
+# do not use peer=(label=unconfined) here since this is DBus activated
+dbus (send)
+    bus=system
+    path=/org/freedesktop/resolve1{,/**}
+    interface=org.freedesktop.DBus.Properties
+    member=Get{,All},
+
+dbus (receive)
+    bus=system
+    path=/org/freedesktop/resolve1{,/**}
+    interface=org.freedesktop.DBus.Properties
+    member=PropertiesChanged
+    peer=(label=unconfined),
+
+# Configure links through the manager object
+dbus (send)
+    bus=system
+    path=/org/freedesktop/resolve1
+    interface=org.freedesktop.resolve1.Manager
+    member="{GetLink,SetLinkDNS,SetLinkDNSEx,SetLinkDomains,SetLinkDefaultRoute,SetLinkLLMNR,SetLinkMulticastDNS,SetLinkDNSOverTLS,SetLinkDNSSEC,SetLinkDNSSECNegativeTrustAnchors,RevertLink,FlushCaches,ResetServerFeatures}"
+    peer=(label=unconfined),
+
+# Configure links through the per-link objects
+dbus (send)
+    bus=system
+    path=/org/freedesktop/resolve1/link/*
+    interface=org.freedesktop.resolve1.Link
+    member="{SetDNS,SetDNSEx,SetDomains,SetDefaultRoute,SetLLMNR,SetMulticastDNS,SetDNSOverTLS,SetDNSSEC,SetDNSSECNegativeTrustAnchors,Revert}"
+    peer=(label=unconfined),
+`
+
+// systemdResolveControlPolkitActions are the polkit actions guarding the
+// configuration methods allowed above. Without a rule granting them, the
+// calls of a snap service would fail as there is no agent to authenticate it.
+var systemdResolveControlPolkitActions = []string{
+	"org.freedesktop.resolve1.set-dns-servers",
+	"org.freedesktop.resolve1.set-domains",
+	"org.freedesktop.resolve1.set-default-route",
+	"org.freedesktop.resolve1.set-llmnr",
+	"org.freedesktop.resolve1.set-mdns",
+	"org.freedesktop.resolve1.set-dns-over-tls",
+	"org.freedesktop.resolve1.set-dnssec",
+	"org.freedesktop.resolve1.set-dnssec-negative-trust-anchors",
+	"org.freedesktop.resolve1.revert",
+}
+
+type systemdResolveControlInterface struct {
+	commonInterface
+}
+
+func (iface *systemdResolveControlInterface) PolkitConnectedPlug(spec *polkit.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	return spec.AddActionsRule("interface.systemd-resolve-control", plug.Snap().InstanceName(), systemdResolveControlPolkitActions)
+}
+
+func init() {
+	registerIface(&systemdResolveControlInterface{commonInterface{
+		name:                  "systemd-resolve-control",
+		summary:               systemdResolveControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  systemdResolveControlBaseDeclarationSlots,
+		connectedPlugAppArmor: systemdResolveControlConnectedPlugAppArmor,
+	}})
+}
@@ -0,0 +1,127 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/polkit"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type systemdResolveControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&systemdResolveControlInterfaceSuite{
+	iface: builtin.MustInterface("systemd-resolve-control"),
+})
+
+const systemdResolveControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [systemd-resolve-control]
+`
+
+const systemdResolveControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  systemd-resolve-control:
+`
+
+func (s *systemdResolveControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, systemdResolveControlConsumerYaml, nil, "systemd-resolve-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, systemdResolveControlCoreYaml, nil, "systemd-resolve-control")
+}
+
+func (s *systemdResolveControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "systemd-resolve-control")
+}
+
+func (s *systemdResolveControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *systemdResolveControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *systemdResolveControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	appSet, err := interfaces.NewSnapAppSet(s.plug.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec := apparmor.NewSpecification(appSet)
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "#include <abstractions/dbus-strict>\n")
+	c.Check(snippet, testutil.Contains, `dbus (send)
+    bus=system
+    path=/org/freedesktop/resolve1
+    interface=org.freedesktop.resolve1.Manager
+    member="{GetLink,SetLinkDNS,`)
+	c.Check(snippet, testutil.Contains, `dbus (send)
+    bus=system
+    path=/org/freedesktop/resolve1/link/*
+    interface=org.freedesktop.resolve1.Link
+    member="{SetDNS,`)
+	c.Check(snippet, testutil.Contains, "SetLinkDomains")
+}
+
+func (s *systemdResolveControlInterfaceSuite) TestPolkitSpec(c *C) {
+	spec := &polkit.Specification{}
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	rules := spec.Rules()
+	c.Assert(rules, HasLen, 1)
+	rule := string(rules["interface.systemd-resolve-control"])
+	c.Check(rule, testutil.Contains, `"org.freedesktop.resolve1.set-dns-servers"`)
+	c.Check(rule, testutil.Contains, `"org.freedesktop.resolve1.revert"`)
+	c.Check(rule, testutil.Contains, `subject.system_unit.indexOf("snap.consumer.") === 0`)
+
+	// nothing is granted to the slot side
+	spec = &polkit.Specification{}
+	c.Assert(spec.AddConnectedSlot(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.Rules(), HasLen, 0)
+}
+
+func (s *systemdResolveControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows configuring DNS via systemd-resolved`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "deny-auto-connection: true")
+}
+
+func (s *systemdResolveControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *systemdResolveControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -0,0 +1,84 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const teeControlSummary = `allows opening sessions with Trusted Execution Environment devices`
+
+const teeControlBaseDeclarationSlots = `
+  tee-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+// Unlike the tee interface, tee-control is restricted to the generic TEE
+// subsystem and spells out the ioctls of its devices.
+const teeControlConnectedPlugAppArmor = `
+# Description: Can open sessions with the trusted applications of the TEE
+# through /dev/tee[0-9]*, and act as the supplicant of the TEE through
+# /dev/teepriv[0-9]*. See https://docs.kernel.org/staging/tee.html
+
+/dev/tee[0-9]* rw,
+/dev/teepriv[0-9]* rw,
+
+# Enumerating the TEE devices
+/sys/class/tee/ r,
+/sys/devices/**/tee/tee{,priv}[0-9]*/** r,
+`
+
+const teeControlConnectedPlugSecComp = `
+# Description: Can query the TEE, share memory with it, open sessions with
+# trusted applications and serve the requests of the TEE as the supplicant.
+
+ioctl - TEE_IOC_VERSION
+ioctl - TEE_IOC_SHM_ALLOC
+ioctl - TEE_IOC_SHM_REGISTER
+ioctl - TEE_IOC_OPEN_SESSION
+ioctl - TEE_IOC_INVOKE
+ioctl - TEE_IOC_CANCEL
+ioctl - TEE_IOC_CLOSE_SESSION
+ioctl - TEE_IOC_SUPPL_RECV
+ioctl - TEE_IOC_SUPPL_SEND
+`
+
+// TEE devices are built into the SoC, the devices are only tagged on systems
+// having them.
+var teeControlConnectedPlugUDevDevices = []string{"/dev/tee[0-9]*", "/dev/teepriv[0-9]*"}
+
+var teeControlConnectedPlugUDev = []string{
+	`SUBSYSTEM=="tee", KERNEL=="tee[0-9]*"`,
+	`SUBSYSTEM=="tee", KERNEL=="teepriv[0-9]*"`,
+}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "tee-control",
+		summary:               teeControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  teeControlBaseDeclarationSlots,
+		connectedPlugAppArmor: teeControlConnectedPlugAppArmor,
+		connectedPlugSecComp:  teeControlConnectedPlugSecComp,
+		connectedPlugUDev:     teeControlConnectedPlugUDev,
+
+		connectedPlugUDevDevices: teeControlConnectedPlugUDevDevices,
+	})
+}
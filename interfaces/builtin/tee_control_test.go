@@ -0,0 +1,167 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/logger"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type teeControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&teeControlInterfaceSuite{
+	iface: builtin.MustInterface("tee-control"),
+})
+
+const teeControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [tee-control]
+`
+
+const teeControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  tee-control:
+`
+
+func (s *teeControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, teeControlConsumerYaml, nil, "tee-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, teeControlCoreYaml, nil, "tee-control")
+	dirs.SetRootDir(c.MkDir())
+}
+
+func (s *teeControlInterfaceSuite) TearDownTest(c *C) {
+	dirs.SetRootDir("")
+}
+
+func (s *teeControlInterfaceSuite) mockDevice(c *C, name string) {
+	c.Assert(os.MkdirAll(filepath.Join(dirs.GlobalRootDir, "/dev"), 0755), IsNil)
+	c.Assert(os.WriteFile(filepath.Join(dirs.GlobalRootDir, "/dev", name), nil, 0644), IsNil)
+}
+
+func (s *teeControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "tee-control")
+}
+
+func (s *teeControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *teeControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *teeControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n/dev/tee[0-9]* rw,\n")
+	c.Check(snippet, testutil.Contains, "\n/dev/teepriv[0-9]* rw,\n")
+	// the Qualcomm specific device is left to the tee interface
+	c.Check(snippet, Not(testutil.Contains), "qseecom")
+}
+
+func (s *teeControlInterfaceSuite) TestSecCompSpec(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	for _, ioctl := range []string{"VERSION", "SHM_ALLOC", "SHM_REGISTER", "OPEN_SESSION", "INVOKE", "CANCEL", "CLOSE_SESSION", "SUPPL_RECV", "SUPPL_SEND"} {
+		c.Check(snippet, testutil.Contains, "\nioctl - TEE_IOC_"+ioctl+"\n")
+	}
+}
+
+func (s *teeControlInterfaceSuite) TestUDevSpec(c *C) {
+	s.mockDevice(c, "tee0")
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 3)
+	c.Assert(spec.Snippets(), testutil.Contains, `# tee-control
+SUBSYSTEM=="tee", KERNEL=="tee[0-9]*", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, `# tee-control
+SUBSYSTEM=="tee", KERNEL=="teepriv[0-9]*", TAG+="snap_consumer_app"`)
+	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.StripRootDir(dirs.DistroLibExecDir)))
+}
+
+func (s *teeControlInterfaceSuite) TestUDevSpecDeviceAbsent(c *C) {
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 0)
+
+	// the supplicant device alone is enough
+	s.mockDevice(c, "teepriv0")
+	spec = udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 3)
+}
+
+func (s *teeControlInterfaceSuite) TestBeforeConnectPlugDeviceAbsent(c *C) {
+	logbuf, restore := logger.MockLogger()
+	defer restore()
+	c.Assert(interfaces.BeforeConnectPlug(s.iface, s.plug), IsNil)
+	c.Check(logbuf.String(), testutil.Contains, `tee-control: no device matching /dev/tee[0-9]* or /dev/teepriv[0-9]* is present, snap "consumer" will not be given access to any device`)
+}
+
+func (s *teeControlInterfaceSuite) TestBeforeConnectPlugDevicePresent(c *C) {
+	logbuf, restore := logger.MockLogger()
+	defer restore()
+	s.mockDevice(c, "tee0")
+	c.Assert(interfaces.BeforeConnectPlug(s.iface, s.plug), IsNil)
+	c.Check(logbuf.String(), Equals, "")
+}
+
+func (s *teeControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows opening sessions with Trusted Execution Environment devices`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "tee-control")
+}
+
+func (s *teeControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *teeControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
@@ -19,14 +19,29 @@
 
 package builtin
 
+import (
+	"strings"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/dbus"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+)
+
 const timeControlSummary = `allows setting system date and time`
 
 const timeControlBaseDeclarationSlots = `
   time-control:
     allow-installation:
       slot-snap-type:
+        - app
         - core
     deny-auto-connection: true
+    deny-connection:
+      slot-snap-type:
+        - app
 `
 
 const timeControlConnectedPlugAppArmor = `
@@ -50,7 +65,7 @@ dbus (send)
     path=/org/freedesktop/timedate1
     interface=org.freedesktop.timedate1
     member="Set{Time,LocalRTC}"
-    peer=(label=unconfined),
+    peer=(label=###SLOT_SECURITY_TAGS###),
 
 # Read all properties from timedate1
 # do not use peer=(label=unconfined) here since this is DBus activated
@@ -66,7 +81,7 @@ dbus (receive)
     path=/org/freedesktop/timedate1
     interface=org.freedesktop.DBus.Properties
     member=PropertiesChanged
-    peer=(label=unconfined),
+    peer=(label=###SLOT_SECURITY_TAGS###),
 
 # As the core snap ships the timedatectl utility we can also allow
 # clients to use it now that they have access to the relevant
@@ -136,15 +151,172 @@ var timeControlConnectedPlugUDev = []string{
 	`KERNEL=="pps[0-9]*"`,
 }
 
+const timeControlPermanentSlotAppArmor = `
+# Description: Allow operating as a timedated compatible service, for example
+# one backed by chrony. This gives privileged access to the system clock, the
+# RTC device nodes and the relevant parts of sysfs.
+
+#include <abstractions/dbus-strict>
+
+capability sys_time,
+
+/dev/rtc[0-9]* rw,
+/sys/class/rtc/*/ rw,
+/sys/class/rtc/*/** rw,
+/sys/devices/**/rtc/*/** rw,
+
+/dev/pps[0-9]* rw,
+/sys/devices/virtual/pps/*/** rw,
+
+dbus (send)
+    bus=system
+    path=/org/freedesktop/DBus
+    interface=org.freedesktop.DBus
+    member="{Request,Release}Name"
+    peer=(name=org.freedesktop.DBus, label=unconfined),
+
+dbus (send)
+    bus=system
+    path=/org/freedesktop/DBus
+    interface=org.freedesktop.DBus
+    member="GetConnectionUnix{ProcessID,User}"
+    peer=(name=org.freedesktop.DBus, label=unconfined),
+
+# Allow binding the service to the requested connection name
+dbus (bind)
+    bus=system
+    name="org.freedesktop.timedate1",
+
+# Allow emitting property changed events to any client
+dbus (send)
+    bus=system
+    path=/org/freedesktop/timedate1
+    interface=org.freedesktop.DBus.Properties
+    member=PropertiesChanged
+    peer=(label=unconfined),
+`
+
+const timeControlConnectedSlotAppArmor = `
+# Allow connected clients to call the timedate1 service and read its
+# properties
+dbus (receive)
+    bus=system
+    path=/org/freedesktop/timedate1
+    interface=org.freedesktop.timedate1
+    peer=(label=###PLUG_SECURITY_TAGS###),
+
+dbus (receive)
+    bus=system
+    path=/org/freedesktop/timedate1
+    interface=org.freedesktop.DBus.{Introspectable,Properties}
+    peer=(label=###PLUG_SECURITY_TAGS###),
+
+dbus (send)
+    bus=system
+    path=/org/freedesktop/timedate1
+    interface=org.freedesktop.DBus.Properties
+    member=PropertiesChanged
+    peer=(label=###PLUG_SECURITY_TAGS###),
+`
+
+const timeControlPermanentSlotSecComp = `
+# Description: Allow operating as a timedated compatible service.
+
+settimeofday
+adjtimex
+clock_adjtime
+clock_adjtime64
+clock_settime
+clock_settime64
+`
+
+const timeControlPermanentSlotDBus = `
+<!-- Only root can own the service -->
+<policy user="root">
+  <allow own="org.freedesktop.timedate1"/>
+  <allow send_destination="org.freedesktop.timedate1"/>
+</policy>
+<policy context="default">
+  <deny own="org.freedesktop.timedate1"/>
+
+  <allow send_destination="org.freedesktop.timedate1"
+         send_interface="org.freedesktop.DBus.Introspectable"/>
+  <allow send_destination="org.freedesktop.timedate1"
+         send_interface="org.freedesktop.DBus.Peer"/>
+  <allow send_destination="org.freedesktop.timedate1"
+         send_interface="org.freedesktop.DBus.Properties"/>
+  <allow send_destination="org.freedesktop.timedate1"
+         send_interface="org.freedesktop.timedate1"/>
+</policy>
+`
+
+// timeControlInterface is a commonInterface which can additionally be
+// provided by an application snap implementing the timedate1 D-Bus API.
+type timeControlInterface struct {
+	commonInterface
+}
+
+func (iface *timeControlInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	old := "###SLOT_SECURITY_TAGS###"
+	new := slot.LabelExpression()
+	if implicitSystemConnectedSlot(slot) {
+		// timedated runs unconfined on the host
+		new = "unconfined"
+	}
+	snippet := strings.Replace(timeControlConnectedPlugAppArmor, old, new, -1)
+	spec.AddSnippet(snippet)
+	return nil
+}
+
+func (iface *timeControlInterface) AppArmorConnectedSlot(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	if implicitSystemConnectedSlot(slot) {
+		return nil
+	}
+	old := "###PLUG_SECURITY_TAGS###"
+	new := plug.LabelExpression()
+	snippet := strings.Replace(timeControlConnectedSlotAppArmor, old, new, -1)
+	spec.AddSnippet(snippet)
+	return nil
+}
+
+func (iface *timeControlInterface) AppArmorPermanentSlot(spec *apparmor.Specification, slot *snap.SlotInfo) error {
+	if !implicitSystemPermanentSlot(slot) {
+		spec.AddSnippet(timeControlPermanentSlotAppArmor)
+	}
+	return nil
+}
+
+func (iface *timeControlInterface) SecCompPermanentSlot(spec *seccomp.Specification, slot *snap.SlotInfo) error {
+	if !implicitSystemPermanentSlot(slot) {
+		spec.AddSnippet(timeControlPermanentSlotSecComp)
+	}
+	return nil
+}
+
+func (iface *timeControlInterface) DBusPermanentSlot(spec *dbus.Specification, slot *snap.SlotInfo) error {
+	if !implicitSystemPermanentSlot(slot) {
+		spec.AddSnippet(timeControlPermanentSlotDBus)
+	}
+	return nil
+}
+
+func (iface *timeControlInterface) UDevPermanentSlot(spec *udev.Specification, slot *snap.SlotInfo) error {
+	if !implicitSystemPermanentSlot(slot) {
+		for _, rule := range timeControlConnectedPlugUDev {
+			spec.TagDevice(rule)
+		}
+	}
+	return nil
+}
+
 func init() {
-	registerIface(&commonInterface{
-		name:                  "time-control",
-		summary:               timeControlSummary,
-		implicitOnCore:        true,
-		implicitOnClassic:     true,
-		baseDeclarationSlots:  timeControlBaseDeclarationSlots,
-		connectedPlugAppArmor: timeControlConnectedPlugAppArmor,
-		connectedPlugSecComp:  timeControlConnectedPlugSecComp,
-		connectedPlugUDev:     timeControlConnectedPlugUDev,
-	})
+	registerIface(&timeControlInterface{commonInterface{
+		name:                 "time-control",
+		summary:              timeControlSummary,
+		implicitOnCore:       true,
+		implicitOnClassic:    true,
+		baseDeclarationSlots: timeControlBaseDeclarationSlots,
+		connectedPlugSecComp: timeControlConnectedPlugSecComp,
+		connectedPlugUDev:    timeControlConnectedPlugUDev,
+	}})
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/interfaces/apparmor"
 	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/dbus"
 	"github.com/snapcore/snapd/interfaces/seccomp"
 	"github.com/snapcore/snapd/interfaces/udev"
 	"github.com/snapcore/snapd/snap"
@@ -35,11 +36,13 @@ import (
 )
 
 type TimeControlInterfaceSuite struct {
-	iface    interfaces.Interface
-	slotInfo *snap.SlotInfo
-	slot     *interfaces.ConnectedSlot
-	plugInfo *snap.PlugInfo
-	plug     *interfaces.ConnectedPlug
+	iface       interfaces.Interface
+	slotInfo    *snap.SlotInfo
+	slot        *interfaces.ConnectedSlot
+	appSlotInfo *snap.SlotInfo
+	appSlot     *interfaces.ConnectedSlot
+	plugInfo    *snap.PlugInfo
+	plug        *interfaces.ConnectedPlug
 }
 
 var _ = Suite(&TimeControlInterfaceSuite{
@@ -60,9 +63,17 @@ slots:
   time-control:
 `
 
+const timectlProducerYaml = `name: chrony
+version: 0
+apps:
+ chronyd:
+  slots: [time-control]
+`
+
 func (s *TimeControlInterfaceSuite) SetUpTest(c *C) {
 	s.plug, s.plugInfo = MockConnectedPlug(c, timectlConsumerYaml, nil, "time-control")
 	s.slot, s.slotInfo = MockConnectedSlot(c, timectlCoreYaml, nil, "time-control")
+	s.appSlot, s.appSlotInfo = MockConnectedSlot(c, timectlProducerYaml, nil, "time-control")
 }
 
 func (s *TimeControlInterfaceSuite) TestName(c *C) {
@@ -84,6 +95,36 @@ func (s *TimeControlInterfaceSuite) TestAppArmorSpec(c *C) {
 	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
 	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
 	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "org/freedesktop/timedate1")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "member=\"Set{Time,LocalRTC}\"\n    peer=(label=unconfined),")
+
+	// the implicit slot doesn't get any policy
+	appSet, err = interfaces.NewSnapAppSet(s.slot.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec = apparmor.NewSpecification(appSet)
+	c.Assert(spec.AddConnectedSlot(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.AddPermanentSlot(s.iface, s.slotInfo), IsNil)
+	c.Assert(spec.SecurityTags(), HasLen, 0)
+}
+
+func (s *TimeControlInterfaceSuite) TestAppArmorSpecAppSlot(c *C) {
+	appSet, err := interfaces.NewSnapAppSet(s.plug.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec := apparmor.NewSpecification(appSet)
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.appSlot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains, "member=\"Set{Time,LocalRTC}\"\n    peer=(label=\"snap.chrony.chronyd\"),")
+	c.Check(spec.SnippetForTag("snap.consumer.app"), Not(testutil.Contains), "    peer=(label=unconfined),")
+
+	appSet, err = interfaces.NewSnapAppSet(s.appSlot.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec = apparmor.NewSpecification(appSet)
+	c.Assert(spec.AddConnectedSlot(s.iface, s.plug, s.appSlot), IsNil)
+	c.Assert(spec.AddPermanentSlot(s.iface, s.appSlotInfo), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.chrony.chronyd"})
+	snippet := spec.SnippetForTag("snap.chrony.chronyd")
+	c.Check(snippet, testutil.Contains, "capability sys_time,\n")
+	c.Check(snippet, testutil.Contains, "name=\"org.freedesktop.timedate1\",")
+	c.Check(snippet, testutil.Contains, "interface=org.freedesktop.timedate1\n    peer=(label=\"snap.consumer.app\"),")
 }
 
 func (s *TimeControlInterfaceSuite) TestSecCompSpec(c *C) {
@@ -105,6 +146,35 @@ func (s *TimeControlInterfaceSuite) TestSecCompSpec(c *C) {
 	} {
 		c.Check(snippet, testutil.Contains, needle)
 	}
+
+	// the implicit slot doesn't get any policy
+	appSet, err = interfaces.NewSnapAppSet(s.slot.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec = seccomp.NewSpecification(appSet)
+	c.Assert(spec.AddPermanentSlot(s.iface, s.slotInfo), IsNil)
+	c.Assert(spec.SecurityTags(), HasLen, 0)
+
+	appSet, err = interfaces.NewSnapAppSet(s.appSlot.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec = seccomp.NewSpecification(appSet)
+	c.Assert(spec.AddPermanentSlot(s.iface, s.appSlotInfo), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.chrony.chronyd"})
+	c.Check(spec.SnippetForTag("snap.chrony.chronyd"), testutil.Contains, "clock_settime\n")
+}
+
+func (s *TimeControlInterfaceSuite) TestDBusSpec(c *C) {
+	appSet, err := interfaces.NewSnapAppSet(s.slot.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec := dbus.NewSpecification(appSet)
+	c.Assert(spec.AddPermanentSlot(s.iface, s.slotInfo), IsNil)
+	c.Assert(spec.SecurityTags(), HasLen, 0)
+
+	appSet, err = interfaces.NewSnapAppSet(s.appSlot.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec = dbus.NewSpecification(appSet)
+	c.Assert(spec.AddPermanentSlot(s.iface, s.appSlotInfo), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.chrony.chronyd"})
+	c.Check(spec.SnippetForTag("snap.chrony.chronyd"), testutil.Contains, `<allow own="org.freedesktop.timedate1"/>`)
 }
 
 func (s *TimeControlInterfaceSuite) TestUDevSpec(c *C) {
@@ -118,6 +188,14 @@ SUBSYSTEM=="rtc", TAG+="snap_consumer_app"`)
 	c.Assert(spec.Snippets(), testutil.Contains, `# time-control
 KERNEL=="pps[0-9]*", TAG+="snap_consumer_app"`)
 	c.Assert(spec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+
+	appSet, err = interfaces.NewSnapAppSet(s.appSlot.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec = udev.NewSpecification(appSet)
+	c.Assert(spec.AddPermanentSlot(s.iface, s.appSlotInfo), IsNil)
+	c.Assert(spec.Snippets(), HasLen, 3)
+	c.Assert(spec.Snippets(), testutil.Contains, `# time-control
+SUBSYSTEM=="rtc", TAG+="snap_chrony_chronyd"`)
 }
 
 func (s *TimeControlInterfaceSuite) TestStaticInfo(c *C) {
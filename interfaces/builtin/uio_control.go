@@ -0,0 +1,63 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+// https://www.kernel.org/doc/html/latest/driver-api/uio-howto.html
+const uioControlSummary = `allows access to all userspace I/O devices`
+
+const uioControlBaseDeclarationSlots = `
+  uio-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const uioControlConnectedPlugAppArmor = `
+# Description: Can access all userspace I/O (UIO) devices, as used by
+# userspace drivers. Unlike the uio interface, which grants access to a
+# single device node defined by a slot, this grants access to every UIO
+# device on the system.
+
+# Userspace drivers mmap the memory regions of the device and read or write
+# the device node to wait for and acknowledge interrupts
+/dev/uio[0-9]* rwm,
+
+# Device information and memory and port mappings of uio devices, see
+# $sysfs_base/{name,version,event}, $sysfs_base/maps/map[0-9]+/* and
+# $sysfs_base/portio/port[0-9]+/* in the kernel documentation.
+/sys/class/uio/ r,
+/sys/class/uio/uio[0-9]* r,
+/sys/devices/**/uio/uio[0-9]*/** r,
+`
+
+var uioControlConnectedPlugUDev = []string{`SUBSYSTEM=="uio"`}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                  "uio-control",
+		summary:               uioControlSummary,
+		implicitOnCore:        true,
+		implicitOnClassic:     true,
+		baseDeclarationSlots:  uioControlBaseDeclarationSlots,
+		connectedPlugAppArmor: uioControlConnectedPlugAppArmor,
+		connectedPlugUDev:     uioControlConnectedPlugUDev,
+	})
+}
@@ -0,0 +1,78 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+const vethControlSummary = `allows creating and configuring virtual ethernet pairs`
+
+// Creating network devices requires CAP_NET_ADMIN in the initial network
+// namespace, which allows reconfiguring the networking of the whole system.
+const vethControlBaseDeclarationSlots = `
+  veth-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+const vethControlConnectedPlugAppArmor = `
+# Description: Can create virtual ethernet (veth) pairs with rtnetlink, set
+# them up and move their ends to other network namespaces. This is restricted
+# because it gives privileged access to networking.
+
+# Required for RTM_NEWLINK and related rtnetlink requests
+capability net_admin,
+network netlink raw,
+
+# Configuring the devices through sysfs. Entries in /sys/class/net are
+# symlinks to the device directories, veth devices are virtual ones.
+/sys/class/net/ r,
+/sys/class/net/** rw,
+/sys/devices/virtual/net/ r,
+/sys/devices/virtual/net/** rw,
+
+# Moving a veth end to the network namespace of a running process
+@{PROC}/[0-9]*/ns/net r,
+/run/netns/ r,
+/run/netns/* r,
+`
+
+const vethControlConnectedPlugSecComp = `
+# Description: Can create virtual ethernet (veth) pairs with rtnetlink.
+
+bind
+socket AF_NETLINK - NETLINK_ROUTE
+`
+
+// The veth driver is requested by the kernel when creating the first pair,
+// load it upfront as the request can be denied in confined environments
+var vethControlConnectedPlugKmod = []string{"veth"}
+
+func init() {
+	registerIface(&commonInterface{
+		name:                     "veth-control",
+		summary:                  vethControlSummary,
+		implicitOnCore:           true,
+		implicitOnClassic:        true,
+		baseDeclarationSlots:     vethControlBaseDeclarationSlots,
+		connectedPlugAppArmor:    vethControlConnectedPlugAppArmor,
+		connectedPlugSecComp:     vethControlConnectedPlugSecComp,
+		connectedPlugKModModules: vethControlConnectedPlugKmod,
+	})
+}
@@ -0,0 +1,76 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin
+
+import (
+	"strings"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+)
+
+const watchdogUserspaceControlSummary = `allows supervising services through the software watchdog of the service manager`
+
+const watchdogUserspaceControlBaseDeclarationSlots = `
+  watchdog-userspace-control:
+    allow-installation:
+      slot-snap-type:
+        - core
+    deny-auto-connection: true
+`
+
+// The watchdog timeout is passed to the services in the WATCHDOG_USEC and
+// WATCHDOG_PID environment variables, the keep-alive pings and the changes
+// of the timeout are sent over the notify socket, like other notifications.
+const watchdogUserspaceControlConnectedPlugAppArmorTemplate = `
+# Description: Can send the WATCHDOG=1 keep-alive pings and WATCHDOG_USEC=
+# timeout changes of services to the software watchdog of systemd through the
+# notify socket, as done with sd_notify(3), and report the watchdog as
+# triggered with WATCHDOG=trigger.
+{{notify-socket-rule}},
+
+# Allow using systemd-notify in shell scripts.
+/{,usr/}bin/systemd-notify ixr,
+`
+
+type watchdogUserspaceControlInterface struct {
+	commonInterface
+}
+
+func (iface *watchdogUserspaceControlInterface) AppArmorConnectedPlug(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	rule, err := notifySocketRule()
+	if err != nil {
+		return err
+	}
+	snippet := strings.Replace(watchdogUserspaceControlConnectedPlugAppArmorTemplate,
+		"{{notify-socket-rule}}", rule, 1)
+	spec.AddSnippet(snippet)
+	return nil
+}
+
+func init() {
+	registerIface(&watchdogUserspaceControlInterface{commonInterface: commonInterface{
+		name:                 "watchdog-userspace-control",
+		summary:              watchdogUserspaceControlSummary,
+		implicitOnCore:       true,
+		implicitOnClassic:    true,
+		baseDeclarationSlots: watchdogUserspaceControlBaseDeclarationSlots,
+	}})
+}
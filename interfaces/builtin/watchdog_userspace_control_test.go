@@ -0,0 +1,126 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package builtin_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+type watchdogUserspaceControlInterfaceSuite struct {
+	iface    interfaces.Interface
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+}
+
+var _ = Suite(&watchdogUserspaceControlInterfaceSuite{
+	iface: builtin.MustInterface("watchdog-userspace-control"),
+})
+
+const watchdogUserspaceControlConsumerYaml = `name: consumer
+version: 0
+apps:
+ app:
+  plugs: [watchdog-userspace-control]
+`
+
+const watchdogUserspaceControlCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  watchdog-userspace-control:
+`
+
+func (s *watchdogUserspaceControlInterfaceSuite) SetUpTest(c *C) {
+	s.plug, s.plugInfo = MockConnectedPlug(c, watchdogUserspaceControlConsumerYaml, nil, "watchdog-userspace-control")
+	s.slot, s.slotInfo = MockConnectedSlot(c, watchdogUserspaceControlCoreYaml, nil, "watchdog-userspace-control")
+}
+
+func (s *watchdogUserspaceControlInterfaceSuite) TestName(c *C) {
+	c.Assert(s.iface.Name(), Equals, "watchdog-userspace-control")
+}
+
+func (s *watchdogUserspaceControlInterfaceSuite) TestSanitizeSlot(c *C) {
+	c.Assert(interfaces.BeforePrepareSlot(s.iface, s.slotInfo), IsNil)
+}
+
+func (s *watchdogUserspaceControlInterfaceSuite) TestSanitizePlug(c *C) {
+	c.Assert(interfaces.BeforePreparePlug(s.iface, s.plugInfo), IsNil)
+}
+
+func (s *watchdogUserspaceControlInterfaceSuite) TestAppArmorSpec(c *C) {
+	restore := builtin.MockOsGetenv(func(what string) string {
+		c.Assert(what, Equals, "NOTIFY_SOCKET")
+		return ""
+	})
+	defer restore()
+
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.SecurityTags(), DeepEquals, []string{"snap.consumer.app"})
+	snippet := spec.SnippetForTag("snap.consumer.app")
+	c.Check(snippet, testutil.Contains, "\n\"/run/systemd/notify\" w,\n")
+	c.Check(snippet, testutil.Contains, "\n/{,usr/}bin/systemd-notify ixr,\n")
+}
+
+func (s *watchdogUserspaceControlInterfaceSuite) TestAppArmorSpecAbstractSocket(c *C) {
+	restore := builtin.MockOsGetenv(func(what string) string {
+		return "@/org/freedesktop/systemd1/notify/13334051644891137417"
+	})
+	defer restore()
+
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.consumer.app"), testutil.Contains,
+		"\nunix (connect, send) type=dgram peer=(label=unconfined,addr=\"@/org/freedesktop/systemd1/notify/[0-9]*\"),\n")
+}
+
+func (s *watchdogUserspaceControlInterfaceSuite) TestAppArmorSpecBadSocket(c *C) {
+	restore := builtin.MockOsGetenv(func(what string) string {
+		return "foo/bar"
+	})
+	defer restore()
+
+	spec := apparmor.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), ErrorMatches, `cannot use "foo/bar" as notify socket path: not absolute`)
+}
+
+func (s *watchdogUserspaceControlInterfaceSuite) TestStaticInfo(c *C) {
+	si := interfaces.StaticInfoOf(s.iface)
+	c.Assert(si.ImplicitOnCore, Equals, true)
+	c.Assert(si.ImplicitOnClassic, Equals, true)
+	c.Assert(si.Summary, Equals, `allows supervising services through the software watchdog of the service manager`)
+	c.Assert(si.BaseDeclarationSlots, testutil.Contains, "watchdog-userspace-control")
+}
+
+func (s *watchdogUserspaceControlInterfaceSuite) TestAutoConnect(c *C) {
+	c.Assert(s.iface.AutoConnect(s.plugInfo, s.slotInfo), Equals, true)
+}
+
+func (s *watchdogUserspaceControlInterfaceSuite) TestInterfaces(c *C) {
+	c.Check(builtin.Interfaces(), testutil.DeepContains, s.iface)
+}
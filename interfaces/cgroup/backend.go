@@ -0,0 +1,210 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+// Package cgroup implements integration between snappy interfaces and the
+// cgroup v2 configuration of the services of snaps.
+//
+// The backend writes systemd drop-in files setting Delegate= and the resource
+// limits (TasksMax=, IO*BandwidthMax= and AllowedCPUs=) of the services
+// affected by the interfaces which requested them. The limits apply to the
+// cgroup of each service, which is nested in the slice of the quota group of
+// the snap, if any, so that the limits of both are enforced. The configuration
+// takes effect the next time the services are started.
+package cgroup
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/logger"
+	"github.com/snapcore/snapd/osutil"
+	"github.com/snapcore/snapd/strutil"
+	sysd "github.com/snapcore/snapd/systemd"
+	"github.com/snapcore/snapd/timings"
+)
+
+// dropInName is the name of the drop-in file written for each affected service.
+const dropInName = "snapd-interfaces-cgroup.conf"
+
+func dropInGlob(snapName string) string {
+	return filepath.Join(dirs.SnapServicesDir, fmt.Sprintf("snap.%s.*.service.d", snapName), dropInName)
+}
+
+// Backend is responsible for maintaining the cgroup configuration of services.
+type Backend struct {
+	preseed bool
+}
+
+var _ = interfaces.SecurityBackend(&Backend{})
+
+// Initialize does nothing.
+func (b *Backend) Initialize(opts *interfaces.SecurityBackendOptions) error {
+	if opts != nil && opts.Preseed {
+		b.preseed = true
+	}
+	return nil
+}
+
+// Name returns the name of the backend.
+func (b *Backend) Name() interfaces.SecuritySystem {
+	return interfaces.SecurityCgroup
+}
+
+// Setup writes the systemd drop-in files delegating cgroup controllers to and
+// limiting the resources of the services of a given snap.
+//
+// This method should be called after changing plug, slots, connections between
+// them or application present in the snap.
+func (b *Backend) Setup(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, repo *interfaces.Repository, tm timings.Measurer) error {
+	snapName := appSet.InstanceName()
+	spec, err := repo.SnapSpecification(b.Name(), appSet, opts)
+	if err != nil {
+		return fmt.Errorf("cannot obtain cgroup specification for snap %q: %s", snapName, err)
+	}
+	content := deriveContent(spec.(*Specification), appSet)
+
+	changed := false
+	existing, err := filepath.Glob(dropInGlob(snapName))
+	if err != nil {
+		return err
+	}
+	for _, path := range existing {
+		if _, ok := content[path]; ok {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("cannot remove cgroup drop-in %q: %s", path, err)
+		}
+		// the directory is left alone if something else placed files in it
+		os.Remove(filepath.Dir(path))
+		changed = true
+	}
+	for path, state := range content {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("cannot create directory for cgroup drop-in %q: %s", path, err)
+		}
+		err := osutil.EnsureFileState(path, state)
+		if err == osutil.ErrSameState {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("cannot write cgroup drop-in %q: %s", path, err)
+		}
+		changed = true
+	}
+	if changed {
+		b.daemonReload()
+	}
+	return nil
+}
+
+// Remove removes the systemd drop-in files of a given snap.
+func (b *Backend) Remove(snapName string) error {
+	existing, err := filepath.Glob(dropInGlob(snapName))
+	if err != nil {
+		return err
+	}
+	for _, path := range existing {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("cannot remove cgroup drop-in %q: %s", path, err)
+		}
+		os.Remove(filepath.Dir(path))
+	}
+	if len(existing) > 0 {
+		b.daemonReload()
+	}
+	return nil
+}
+
+// NewSpecification returns a new cgroup specification.
+func (b *Backend) NewSpecification(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions) interfaces.Specification {
+	return NewSpecification(appSet)
+}
+
+// SandboxFeatures returns the list of features supported by snapd for the
+// cgroup configuration of services.
+func (b *Backend) SandboxFeatures() []string {
+	return []string{"delegate-controllers", "resource-limits"}
+}
+
+func (b *Backend) daemonReload() {
+	if b.preseed {
+		return
+	}
+	systemd := sysd.New(sysd.SystemMode, &noopReporter{})
+	if err := systemd.DaemonReload(); err != nil {
+		logger.Noticef("cannot reload systemd state: %s", err)
+	}
+}
+
+// deriveContent computes the drop-in files of the services of the snap which
+// have controllers to delegate or resource limits.
+func deriveContent(spec *Specification, appSet *interfaces.SnapAppSet) map[string]osutil.FileState {
+	var content map[string]osutil.FileState
+	for _, app := range appSet.Info().Apps {
+		if !app.IsService() {
+			continue
+		}
+		controllers := spec.Controllers(app.SecurityTag())
+		limits := spec.Limits(app.SecurityTag())
+		if len(controllers) == 0 && limits == nil {
+			continue
+		}
+		var buf bytes.Buffer
+		buf.WriteString("[Service]\n")
+		if len(controllers) > 0 {
+			fmt.Fprintf(&buf, "Delegate=%s\n", strings.Join(controllers, " "))
+		}
+		if limits != nil {
+			if limits.PidsMax != 0 {
+				fmt.Fprintf(&buf, "TasksMax=%d\n", limits.PidsMax)
+			}
+			for _, io := range limits.IO {
+				if io.ReadBps != 0 {
+					fmt.Fprintf(&buf, "IOReadBandwidthMax=%s %d\n", io.Device, io.ReadBps)
+				}
+				if io.WriteBps != 0 {
+					fmt.Fprintf(&buf, "IOWriteBandwidthMax=%s %d\n", io.Device, io.WriteBps)
+				}
+			}
+			if len(limits.AllowedCPUs) > 0 {
+				fmt.Fprintf(&buf, "AllowedCPUs=%s\n", strutil.IntsToCommaSeparated(limits.AllowedCPUs))
+			}
+		}
+		if content == nil {
+			content = make(map[string]osutil.FileState)
+		}
+		path := filepath.Join(dirs.SnapServicesDir, app.ServiceName()+".d", dropInName)
+		content[path] = &osutil.MemoryFileState{
+			Content: buf.Bytes(),
+			Mode:    0644,
+		}
+	}
+	return content
+}
+
+type noopReporter struct{}
+
+func (dr *noopReporter) Notify(msg string) {
+}
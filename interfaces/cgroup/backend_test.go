@@ -0,0 +1,199 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package cgroup_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/cgroup"
+	"github.com/snapcore/snapd/interfaces/ifacetest"
+	"github.com/snapcore/snapd/snap"
+	sysd "github.com/snapcore/snapd/systemd"
+	"github.com/snapcore/snapd/testutil"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+type backendSuite struct {
+	ifacetest.BackendSuite
+
+	systemctlArgs [][]string
+}
+
+var _ = Suite(&backendSuite{})
+
+var testedConfinementOpts = []interfaces.ConfinementOptions{
+	{},
+	{DevMode: true},
+	{JailMode: true},
+	{Classic: true},
+}
+
+const sambaYaml = `
+name: samba
+version: 1
+developer: acme
+apps:
+    smbd:
+        daemon: simple
+    nmbd:
+        daemon: simple
+    cli:
+slots:
+    slot:
+        interface: iface
+`
+
+func (s *backendSuite) SetUpTest(c *C) {
+	s.Backend = &cgroup.Backend{}
+	s.BackendSuite.SetUpTest(c)
+	c.Assert(s.Repo.AddBackend(s.Backend), IsNil)
+
+	s.systemctlArgs = nil
+	s.AddCleanup(sysd.MockSystemctl(func(args ...string) ([]byte, error) {
+		s.systemctlArgs = append(s.systemctlArgs, append([]string{"systemctl"}, args...))
+		return []byte{}, nil
+	}))
+}
+
+func (s *backendSuite) TearDownTest(c *C) {
+	s.BackendSuite.TearDownTest(c)
+}
+
+func (s *backendSuite) TestName(c *C) {
+	c.Check(s.Backend.Name(), Equals, interfaces.SecurityCgroup)
+}
+
+func (s *backendSuite) TestSandboxFeatures(c *C) {
+	c.Check(s.Backend.SandboxFeatures(), DeepEquals, []string{"delegate-controllers", "resource-limits"})
+}
+
+func dropInPath(service string) string {
+	return filepath.Join(dirs.SnapServicesDir, service+".d", "snapd-interfaces-cgroup.conf")
+}
+
+func (s *backendSuite) TestInstallingSnapWritesDropIns(c *C) {
+	s.Iface.CgroupPermanentSlotCallback = func(spec *cgroup.Specification, slot *snap.SlotInfo) error {
+		return spec.DelegateControllers("pids", "memory")
+	}
+	for _, opts := range testedConfinementOpts {
+		s.systemctlArgs = nil
+		snapInfo := s.InstallSnap(c, opts, "", sambaYaml, 0)
+
+		// only services get the drop-in
+		c.Check(dropInPath("snap.samba.smbd.service"), testutil.FileEquals, "[Service]\nDelegate=memory pids\n")
+		c.Check(dropInPath("snap.samba.nmbd.service"), testutil.FileEquals, "[Service]\nDelegate=memory pids\n")
+		c.Check(filepath.Join(dirs.SnapServicesDir, "snap.samba.cli.service.d"), testutil.FileAbsent)
+		c.Check(s.systemctlArgs, DeepEquals, [][]string{{"systemctl", "daemon-reload"}})
+
+		s.systemctlArgs = nil
+		s.RemoveSnap(c, snapInfo)
+		c.Check(filepath.Join(dirs.SnapServicesDir, "snap.samba.smbd.service.d"), testutil.FileAbsent)
+		c.Check(filepath.Join(dirs.SnapServicesDir, "snap.samba.nmbd.service.d"), testutil.FileAbsent)
+		c.Check(s.systemctlArgs, DeepEquals, [][]string{{"systemctl", "daemon-reload"}})
+	}
+}
+
+func (s *backendSuite) TestInstallingSnapWritesLimits(c *C) {
+	s.Iface.CgroupPermanentSlotCallback = func(spec *cgroup.Specification, slot *snap.SlotInfo) error {
+		if err := spec.DelegateControllers("cpu"); err != nil {
+			return err
+		}
+		if err := spec.LimitPids(64); err != nil {
+			return err
+		}
+		if err := spec.LimitIO("/dev/sda", 1048576, 0); err != nil {
+			return err
+		}
+		if err := spec.LimitIO("/dev/nvme0n1", 0, 2097152); err != nil {
+			return err
+		}
+		return spec.RestrictCPUs(0, 1)
+	}
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", sambaYaml, 0)
+	c.Check(dropInPath("snap.samba.smbd.service"), testutil.FileEquals, `[Service]
+Delegate=cpu
+TasksMax=64
+IOWriteBandwidthMax=/dev/nvme0n1 2097152
+IOReadBandwidthMax=/dev/sda 1048576
+AllowedCPUs=0,1
+`)
+	c.Check(filepath.Join(dirs.SnapServicesDir, "snap.samba.cli.service.d"), testutil.FileAbsent)
+	c.Check(s.systemctlArgs, DeepEquals, [][]string{{"systemctl", "daemon-reload"}})
+
+	// limits alone are written as well
+	s.Iface.CgroupPermanentSlotCallback = func(spec *cgroup.Specification, slot *snap.SlotInfo) error {
+		return spec.LimitPids(32)
+	}
+	s.UpdateSnap(c, snapInfo, interfaces.ConfinementOptions{}, sambaYaml, 0)
+	c.Check(dropInPath("snap.samba.nmbd.service"), testutil.FileEquals, "[Service]\nTasksMax=32\n")
+}
+
+func (s *backendSuite) TestSetupUnchangedDoesNotReload(c *C) {
+	s.Iface.CgroupPermanentSlotCallback = func(spec *cgroup.Specification, slot *snap.SlotInfo) error {
+		return spec.DelegateControllers("cpu")
+	}
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", sambaYaml, 0)
+	c.Check(s.systemctlArgs, HasLen, 1)
+
+	s.systemctlArgs = nil
+	s.UpdateSnap(c, snapInfo, interfaces.ConfinementOptions{}, sambaYaml, 0)
+	c.Check(dropInPath("snap.samba.smbd.service"), testutil.FileEquals, "[Service]\nDelegate=cpu\n")
+	c.Check(s.systemctlArgs, HasLen, 0)
+}
+
+func (s *backendSuite) TestSetupWithoutControllersRemovesDropIns(c *C) {
+	s.Iface.CgroupPermanentSlotCallback = func(spec *cgroup.Specification, slot *snap.SlotInfo) error {
+		return spec.DelegateControllers("cpu")
+	}
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", sambaYaml, 0)
+	c.Check(dropInPath("snap.samba.smbd.service"), testutil.FilePresent)
+
+	// a drop-in placed by someone else is preserved along with its directory
+	other := filepath.Join(dirs.SnapServicesDir, "snap.samba.nmbd.service.d", "other.conf")
+	c.Assert(os.WriteFile(other, nil, 0644), IsNil)
+
+	// the interface no longer requests delegation, as after a disconnect
+	s.Iface.CgroupPermanentSlotCallback = nil
+	s.systemctlArgs = nil
+	s.UpdateSnap(c, snapInfo, interfaces.ConfinementOptions{}, sambaYaml, 0)
+	c.Check(filepath.Join(dirs.SnapServicesDir, "snap.samba.smbd.service.d"), testutil.FileAbsent)
+	c.Check(dropInPath("snap.samba.nmbd.service"), testutil.FileAbsent)
+	c.Check(other, testutil.FilePresent)
+	c.Check(s.systemctlArgs, DeepEquals, [][]string{{"systemctl", "daemon-reload"}})
+}
+
+func (s *backendSuite) TestSetupPreseedDoesNotReload(c *C) {
+	c.Assert(s.Backend.Initialize(&interfaces.SecurityBackendOptions{Preseed: true}), IsNil)
+	s.Iface.CgroupPermanentSlotCallback = func(spec *cgroup.Specification, slot *snap.SlotInfo) error {
+		return spec.DelegateControllers("io")
+	}
+	s.InstallSnap(c, interfaces.ConfinementOptions{}, "", sambaYaml, 0)
+	c.Check(dropInPath("snap.samba.smbd.service"), testutil.FileEquals, "[Service]\nDelegate=io\n")
+	c.Check(s.systemctlArgs, HasLen, 0)
+}
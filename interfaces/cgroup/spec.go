@@ -0,0 +1,306 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package cgroup
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/strutil"
+)
+
+// delegatableControllers are the cgroup v2 controllers which interfaces may
+// ask systemd to delegate to the services of a snap.
+var delegatableControllers = []string{"cpu", "cpuset", "io", "memory", "pids"}
+
+// IOLimit is the I/O bandwidth limit of a block device, in bytes per second.
+// A zero bandwidth leaves the given direction unlimited.
+type IOLimit struct {
+	Device   string
+	ReadBps  uint64
+	WriteBps uint64
+}
+
+// Limits are the cgroup v2 resource limits of a service.
+type Limits struct {
+	// PidsMax is the maximum number of tasks (pids.max), 0 if unlimited.
+	PidsMax int
+	// IO lists the I/O bandwidth limits (io.max), sorted by device.
+	IO []IOLimit
+	// AllowedCPUs is the sorted list of CPUs the service may run on
+	// (cpuset.cpus), any CPU if empty.
+	AllowedCPUs []int
+}
+
+// Specification assists in collecting the cgroup controllers which should be
+// delegated to the services of a snap and the resource limits of these
+// services.
+//
+// Unlike the Backend itself (which is stateless and non-persistent) this type
+// holds internal state that is used by the cgroup backend during the interface
+// setup process.
+type Specification struct {
+	appSet *interfaces.SnapAppSet
+
+	// controllers maps security tags to the set of controllers to delegate
+	controllers map[string]map[string]bool
+	// limits maps security tags to the resource limits of the services
+	limits       map[string]*Limits
+	securityTags []string
+}
+
+// NewSpecification returns a new cgroup specification for the given snap.
+func NewSpecification(appSet *interfaces.SnapAppSet) *Specification {
+	return &Specification{appSet: appSet}
+}
+
+// SnapAppSet returns the snap app set associated with the specification.
+func (spec *Specification) SnapAppSet() *interfaces.SnapAppSet {
+	return spec.appSet
+}
+
+// DelegateControllers requests the given cgroup controllers to be delegated to
+// the subtree of the services affected by the interface being processed.
+func (spec *Specification) DelegateControllers(controllers ...string) error {
+	for _, controller := range controllers {
+		if !strutil.ListContains(delegatableControllers, controller) {
+			return fmt.Errorf("cannot delegate cgroup controller %q, supported controllers are: %s",
+				controller, strutil.Quoted(delegatableControllers))
+		}
+	}
+	for _, tag := range spec.securityTags {
+		if spec.controllers == nil {
+			spec.controllers = make(map[string]map[string]bool)
+		}
+		if spec.controllers[tag] == nil {
+			spec.controllers[tag] = make(map[string]bool)
+		}
+		for _, controller := range controllers {
+			spec.controllers[tag][controller] = true
+		}
+	}
+	return nil
+}
+
+func (spec *Specification) limitsOf(tag string) *Limits {
+	if spec.limits == nil {
+		spec.limits = make(map[string]*Limits)
+	}
+	if spec.limits[tag] == nil {
+		spec.limits[tag] = &Limits{}
+	}
+	return spec.limits[tag]
+}
+
+// LimitPids limits the number of tasks of the services affected by the
+// interface being processed. When several interfaces limit the number of
+// tasks, the lowest limit applies.
+func (spec *Specification) LimitPids(max int) error {
+	if max <= 0 {
+		return fmt.Errorf("cannot limit the number of tasks to %d", max)
+	}
+	for _, tag := range spec.securityTags {
+		limits := spec.limitsOf(tag)
+		if limits.PidsMax == 0 || max < limits.PidsMax {
+			limits.PidsMax = max
+		}
+	}
+	return nil
+}
+
+// LimitIO limits the I/O bandwidth, in bytes per second, of the services
+// affected by the interface being processed on the given block device. A zero
+// bandwidth leaves the given direction unlimited. When several interfaces
+// limit the bandwidth of a device, the lowest limit applies.
+func (spec *Specification) LimitIO(device string, readBps, writeBps uint64) error {
+	if !strings.HasPrefix(device, "/dev/") || filepath.Clean(device) != device {
+		return fmt.Errorf("cannot limit I/O bandwidth of %q: not a device path", device)
+	}
+	if readBps == 0 && writeBps == 0 {
+		return fmt.Errorf("cannot limit I/O bandwidth of %q: no limit given", device)
+	}
+	lower := func(current, requested uint64) uint64 {
+		if current == 0 || (requested != 0 && requested < current) {
+			return requested
+		}
+		return current
+	}
+	for _, tag := range spec.securityTags {
+		limits := spec.limitsOf(tag)
+		idx := sort.Search(len(limits.IO), func(i int) bool { return limits.IO[i].Device >= device })
+		if idx == len(limits.IO) || limits.IO[idx].Device != device {
+			limits.IO = append(limits.IO, IOLimit{})
+			copy(limits.IO[idx+1:], limits.IO[idx:])
+			limits.IO[idx] = IOLimit{Device: device}
+		}
+		limits.IO[idx].ReadBps = lower(limits.IO[idx].ReadBps, readBps)
+		limits.IO[idx].WriteBps = lower(limits.IO[idx].WriteBps, writeBps)
+	}
+	return nil
+}
+
+// RestrictCPUs restricts the services affected by the interface being
+// processed to the given CPUs. When several interfaces restrict the CPUs, the
+// services may only run on the CPUs allowed by all of them.
+func (spec *Specification) RestrictCPUs(cpus ...int) error {
+	if len(cpus) == 0 {
+		return fmt.Errorf("cannot restrict services to an empty set of CPUs")
+	}
+	for _, cpu := range cpus {
+		if cpu < 0 {
+			return fmt.Errorf("cannot restrict services to invalid CPU %d", cpu)
+		}
+	}
+	for _, tag := range spec.securityTags {
+		limits := spec.limitsOf(tag)
+		var allowed []int
+		for _, cpu := range cpus {
+			if len(limits.AllowedCPUs) != 0 && !intsContain(limits.AllowedCPUs, cpu) {
+				continue
+			}
+			if !intsContain(allowed, cpu) {
+				allowed = append(allowed, cpu)
+			}
+		}
+		if len(allowed) == 0 {
+			return fmt.Errorf("cannot restrict %s to CPUs %s: no CPU is allowed by all interfaces",
+				tag, strutil.IntsToCommaSeparated(cpus))
+		}
+		sort.Ints(allowed)
+		limits.AllowedCPUs = allowed
+	}
+	return nil
+}
+
+func intsContain(ints []int, i int) bool {
+	for _, v := range ints {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
+
+// Limits returns the resource limits of the services with the given security
+// tag, or nil if there are none.
+func (spec *Specification) Limits(securityTag string) *Limits {
+	return spec.limits[securityTag]
+}
+
+// Controllers returns the sorted list of controllers to delegate for the given
+// security tag.
+func (spec *Specification) Controllers(securityTag string) []string {
+	result := make([]string, 0, len(spec.controllers[securityTag]))
+	for controller := range spec.controllers[securityTag] {
+		result = append(result, controller)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// SecurityTags returns the sorted list of security tags with controllers to
+// delegate or resource limits.
+func (spec *Specification) SecurityTags() []string {
+	tags := make([]string, 0, len(spec.controllers)+len(spec.limits))
+	for tag := range spec.controllers {
+		tags = append(tags, tag)
+	}
+	for tag := range spec.limits {
+		if spec.controllers[tag] == nil {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Implementation of methods required by interfaces.Specification
+
+// AddConnectedPlug records cgroup-specific side-effects of having a connected plug.
+func (spec *Specification) AddConnectedPlug(iface interfaces.Interface, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	type definer interface {
+		CgroupConnectedPlug(spec *Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error
+	}
+	if iface, ok := iface.(definer); ok {
+		tags, err := spec.appSet.SecurityTagsForConnectedPlug(plug)
+		if err != nil {
+			return err
+		}
+		spec.securityTags = tags
+		defer func() { spec.securityTags = nil }()
+		return iface.CgroupConnectedPlug(spec, plug, slot)
+	}
+	return nil
+}
+
+// AddConnectedSlot records cgroup-specific side-effects of having a connected slot.
+func (spec *Specification) AddConnectedSlot(iface interfaces.Interface, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	type definer interface {
+		CgroupConnectedSlot(spec *Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error
+	}
+	if iface, ok := iface.(definer); ok {
+		tags, err := spec.appSet.SecurityTagsForConnectedSlot(slot)
+		if err != nil {
+			return err
+		}
+		spec.securityTags = tags
+		defer func() { spec.securityTags = nil }()
+		return iface.CgroupConnectedSlot(spec, plug, slot)
+	}
+	return nil
+}
+
+// AddPermanentPlug records cgroup-specific side-effects of having a plug.
+func (spec *Specification) AddPermanentPlug(iface interfaces.Interface, plug *snap.PlugInfo) error {
+	type definer interface {
+		CgroupPermanentPlug(spec *Specification, plug *snap.PlugInfo) error
+	}
+	if iface, ok := iface.(definer); ok {
+		tags, err := spec.appSet.SecurityTagsForPlug(plug)
+		if err != nil {
+			return err
+		}
+		spec.securityTags = tags
+		defer func() { spec.securityTags = nil }()
+		return iface.CgroupPermanentPlug(spec, plug)
+	}
+	return nil
+}
+
+// AddPermanentSlot records cgroup-specific side-effects of having a slot.
+func (spec *Specification) AddPermanentSlot(iface interfaces.Interface, slot *snap.SlotInfo) error {
+	type definer interface {
+		CgroupPermanentSlot(spec *Specification, slot *snap.SlotInfo) error
+	}
+	if iface, ok := iface.(definer); ok {
+		tags, err := spec.appSet.SecurityTagsForSlot(slot)
+		if err != nil {
+			return err
+		}
+		spec.securityTags = tags
+		defer func() { spec.securityTags = nil }()
+		return iface.CgroupPermanentSlot(spec, slot)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package cgroup_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/cgroup"
+	"github.com/snapcore/snapd/interfaces/ifacetest"
+	"github.com/snapcore/snapd/snap"
+)
+
+type specSuite struct {
+	iface    *ifacetest.TestInterface
+	plugInfo *snap.PlugInfo
+	plug     *interfaces.ConnectedPlug
+	slotInfo *snap.SlotInfo
+	slot     *interfaces.ConnectedSlot
+}
+
+var _ = Suite(&specSuite{
+	iface: &ifacetest.TestInterface{
+		InterfaceName: "test",
+		CgroupConnectedPlugCallback: func(spec *cgroup.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			return spec.DelegateControllers("pids", "memory")
+		},
+		CgroupConnectedSlotCallback: func(spec *cgroup.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			return spec.DelegateControllers("io")
+		},
+		CgroupPermanentPlugCallback: func(spec *cgroup.Specification, plug *snap.PlugInfo) error {
+			return spec.DelegateControllers("cpu", "memory")
+		},
+		CgroupPermanentSlotCallback: func(spec *cgroup.Specification, slot *snap.SlotInfo) error {
+			return spec.DelegateControllers("cpuset")
+		},
+	},
+})
+
+func (s *specSuite) SetUpTest(c *C) {
+	const plugYaml = `name: snap1
+version: 1
+apps:
+ app1:
+  plugs: [name]
+ app2:
+`
+	s.plug, s.plugInfo = ifacetest.MockConnectedPlug(c, plugYaml, nil, "name")
+
+	const slotYaml = `name: snap2
+version: 1
+slots:
+ name:
+  interface: test
+apps:
+ app:
+`
+	s.slot, s.slotInfo = ifacetest.MockConnectedSlot(c, slotYaml, nil, "name")
+}
+
+func (s *specSuite) TestSpecificationIface(c *C) {
+	var r interfaces.Specification = cgroup.NewSpecification(s.plug.AppSet())
+	c.Assert(r.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(r.AddPermanentPlug(s.iface, s.plugInfo), IsNil)
+
+	spec := r.(*cgroup.Specification)
+	c.Check(spec.SecurityTags(), DeepEquals, []string{"snap.snap1.app1"})
+	c.Check(spec.Controllers("snap.snap1.app1"), DeepEquals, []string{"cpu", "memory", "pids"})
+	c.Check(spec.Controllers("snap.snap1.app2"), HasLen, 0)
+
+	r = cgroup.NewSpecification(s.slot.AppSet())
+	c.Assert(r.AddConnectedSlot(s.iface, s.plug, s.slot), IsNil)
+	c.Assert(r.AddPermanentSlot(s.iface, s.slotInfo), IsNil)
+
+	spec = r.(*cgroup.Specification)
+	c.Check(spec.SecurityTags(), DeepEquals, []string{"snap.snap2.app"})
+	c.Check(spec.Controllers("snap.snap2.app"), DeepEquals, []string{"cpuset", "io"})
+}
+
+func (s *specSuite) TestDelegateControllersUnsupported(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "test",
+		CgroupConnectedPlugCallback: func(spec *cgroup.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			return spec.DelegateControllers("memory", "devices")
+		},
+	}
+	spec := cgroup.NewSpecification(s.plug.AppSet())
+	err := spec.AddConnectedPlug(iface, s.plug, s.slot)
+	c.Assert(err, ErrorMatches, `cannot delegate cgroup controller "devices", supported controllers are: "cpu", "cpuset", "io", "memory", "pids"`)
+	c.Check(spec.SecurityTags(), HasLen, 0)
+}
+
+func (s *specSuite) TestDelegateControllersOutsideOfInterface(c *C) {
+	// there are no security tags to record the controllers for
+	spec := cgroup.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.DelegateControllers("memory"), IsNil)
+	c.Check(spec.SecurityTags(), HasLen, 0)
+}
+
+func (s *specSuite) TestLimits(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "test",
+		CgroupConnectedPlugCallback: func(spec *cgroup.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			if err := spec.LimitPids(100); err != nil {
+				return err
+			}
+			if err := spec.LimitIO("/dev/sdb", 2000, 0); err != nil {
+				return err
+			}
+			if err := spec.LimitIO("/dev/sda", 1000, 1000); err != nil {
+				return err
+			}
+			return spec.RestrictCPUs(3, 1, 2)
+		},
+		CgroupPermanentPlugCallback: func(spec *cgroup.Specification, plug *snap.PlugInfo) error {
+			if err := spec.LimitPids(200); err != nil {
+				return err
+			}
+			if err := spec.LimitIO("/dev/sda", 0, 500); err != nil {
+				return err
+			}
+			return spec.RestrictCPUs(0, 1, 2)
+		},
+	}
+	spec := cgroup.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.AddPermanentPlug(iface, s.plugInfo), IsNil)
+
+	// the most restrictive limits apply
+	c.Check(spec.SecurityTags(), DeepEquals, []string{"snap.snap1.app1"})
+	c.Check(spec.Limits("snap.snap1.app1"), DeepEquals, &cgroup.Limits{
+		PidsMax: 100,
+		IO: []cgroup.IOLimit{
+			{Device: "/dev/sda", ReadBps: 1000, WriteBps: 500},
+			{Device: "/dev/sdb", ReadBps: 2000},
+		},
+		AllowedCPUs: []int{1, 2},
+	})
+	c.Check(spec.Limits("snap.snap1.app2"), IsNil)
+	c.Check(spec.Controllers("snap.snap1.app1"), HasLen, 0)
+}
+
+func (s *specSuite) TestLimitsErrors(c *C) {
+	for _, t := range []struct {
+		limit func(spec *cgroup.Specification) error
+		err   string
+	}{
+		{func(spec *cgroup.Specification) error { return spec.LimitPids(0) }, `cannot limit the number of tasks to 0`},
+		{func(spec *cgroup.Specification) error { return spec.LimitIO("sda", 1, 1) }, `cannot limit I/O bandwidth of "sda": not a device path`},
+		{func(spec *cgroup.Specification) error { return spec.LimitIO("/dev/../etc/foo", 1, 1) }, `cannot limit I/O bandwidth of "/dev/../etc/foo": not a device path`},
+		{func(spec *cgroup.Specification) error { return spec.LimitIO("/dev/sda", 0, 0) }, `cannot limit I/O bandwidth of "/dev/sda": no limit given`},
+		{func(spec *cgroup.Specification) error { return spec.RestrictCPUs() }, `cannot restrict services to an empty set of CPUs`},
+		{func(spec *cgroup.Specification) error { return spec.RestrictCPUs(-1) }, `cannot restrict services to invalid CPU -1`},
+		{func(spec *cgroup.Specification) error {
+			if err := spec.RestrictCPUs(0, 1); err != nil {
+				return err
+			}
+			return spec.RestrictCPUs(2, 3)
+		}, `cannot restrict snap.snap1.app1 to CPUs 2,3: no CPU is allowed by all interfaces`},
+	} {
+		iface := &ifacetest.TestInterface{
+			InterfaceName: "test",
+			CgroupConnectedPlugCallback: func(spec *cgroup.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+				return t.limit(spec)
+			},
+		}
+		spec := cgroup.NewSpecification(s.plug.AppSet())
+		c.Check(spec.AddConnectedPlug(iface, s.plug, s.slot), ErrorMatches, t.err)
+	}
+}
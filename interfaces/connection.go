@@ -21,6 +21,7 @@ package interfaces
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/snapcore/snapd/interfaces/utils"
@@ -192,7 +193,17 @@ func (plug *ConnectedPlug) Runnables() []snap.Runnable {
 		hooks = append(hooks, component.HooksForPlug(plug.plugInfo)...)
 	}
 
-	return appAndHookRunnables(apps, hooks)
+	runnables := appAndHookRunnables(apps, hooks)
+	if !plugIsHooksOnly(plug.plugInfo) {
+		return runnables
+	}
+	hookRunnables := make([]snap.Runnable, 0, len(runnables))
+	for _, r := range runnables {
+		if isHookSecurityTag(r.SecurityTag) {
+			hookRunnables = append(hookRunnables, r)
+		}
+	}
+	return hookRunnables
 }
 
 func appAndHookRunnables(apps []*snap.AppInfo, hooks []*snap.HookInfo) []snap.Runnable {
@@ -314,6 +325,26 @@ func (slot *ConnectedSlot) Ref() *SlotRef {
 	return &SlotRef{Snap: slot.Snap().InstanceName(), Name: slot.Name()}
 }
 
+// MatchAttr returns an error if the plug and the slot do not have the same
+// value for the given attribute. The attribute may be unset on both sides,
+// interfaces can use it to let a slot require plugs to declare a matching
+// attribute, such as a role, only when the slot sets it.
+func MatchAttr(plug, slot Attrer, name string) error {
+	plugValue, plugOk := plug.Lookup(name)
+	slotValue, slotOk := slot.Lookup(name)
+	switch {
+	case plugOk && slotOk:
+		if !reflect.DeepEqual(plugValue, slotValue) {
+			return fmt.Errorf("plug attribute %q (%v) does not match the one of the slot (%v)", name, plugValue, slotValue)
+		}
+	case slotOk:
+		return fmt.Errorf("plug must have attribute %q matching the one of the slot (%v)", name, slotValue)
+	case plugOk:
+		return fmt.Errorf("plug attribute %q (%v) is not set by the slot", name, plugValue)
+	}
+	return nil
+}
+
 // Interface returns the name of the interface for this connection.
 func (conn *Connection) Interface() string {
 	return conn.Plug.plugInfo.Interface
@@ -487,3 +487,21 @@ version: 1
 	connectedPlug = interfaces.NewConnectedSlot(info.Slots["hook-slot"], appSet, nil, nil)
 	c.Check(connectedPlug.LabelExpression(), Equals, `"snap.producer.hook.install"`)
 }
+
+func (s *connSuite) TestMatchAttr(c *C) {
+	plug := interfaces.NewConnectedPlug(s.plug, s.plugAppSet, nil, nil)
+	slot := interfaces.NewConnectedSlot(s.slot, s.slotAppSet, nil, nil)
+
+	c.Check(interfaces.MatchAttr(plug, slot, "attr"), IsNil)
+	// unset on both sides
+	c.Check(interfaces.MatchAttr(plug, slot, "unknown"), IsNil)
+	c.Check(interfaces.MatchAttr(plug, slot, "complex"), ErrorMatches, `plug attribute "complex" \(map\[c:d\]\) does not match the one of the slot \(map\[a:b\]\)`)
+	c.Check(interfaces.MatchAttr(plug, slot, "number"), ErrorMatches, `plug must have attribute "number" matching the one of the slot \(100\)`)
+	c.Check(interfaces.MatchAttr(slot, plug, "number"), ErrorMatches, `plug attribute "number" \(100\) is not set by the slot`)
+
+	// dynamic attributes are taken into account, as are the ones of the
+	// plug and slot infos
+	plug = interfaces.NewConnectedPlug(s.plug, s.plugAppSet, nil, map[string]any{"number": int64(100)})
+	c.Check(interfaces.MatchAttr(plug, slot, "number"), IsNil)
+	c.Check(interfaces.MatchAttr(s.plug, s.slot, "attr"), IsNil)
+}
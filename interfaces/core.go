@@ -22,8 +22,10 @@ package interfaces
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/snapcore/snapd/release"
 	"github.com/snapcore/snapd/snap"
 )
 
@@ -33,6 +35,12 @@ func BeforePreparePlug(iface Interface, plugInfo *snap.PlugInfo) error {
 		return fmt.Errorf("cannot sanitize plug %q (interface %q) using interface %q",
 			PlugRef{Snap: plugInfo.Snap.InstanceName(), Name: plugInfo.Name}, plugInfo.Interface, iface.Name())
 	}
+	if StaticInfoOf(iface).ProviderOnly {
+		return fmt.Errorf("interface %q is provider-only and cannot be used by plugs", iface.Name())
+	}
+	if err := validatePlugAttrs(iface, plugInfo); err != nil {
+		return err
+	}
 	var err error
 	if iface, ok := iface.(PlugSanitizer); ok {
 		err = iface.BeforePreparePlug(plugInfo)
@@ -52,6 +60,21 @@ func BeforeConnectPlug(iface Interface, plug *ConnectedPlug) error {
 	return err
 }
 
+// BeforeConnect sanitizes a connection between a plug and a slot with a given
+// snapd interface.
+func BeforeConnect(iface Interface, plug *ConnectedPlug, slot *ConnectedSlot) error {
+	if iface.Name() != plug.plugInfo.Interface || iface.Name() != slot.slotInfo.Interface {
+		return fmt.Errorf("cannot sanitize connection of plug %q (interface %q) and slot %q (interface %q) using interface %q",
+			PlugRef{Snap: plug.plugInfo.Snap.InstanceName(), Name: plug.plugInfo.Name}, plug.plugInfo.Interface,
+			SlotRef{Snap: slot.slotInfo.Snap.InstanceName(), Name: slot.slotInfo.Name}, slot.slotInfo.Interface, iface.Name())
+	}
+	var err error
+	if iface, ok := iface.(ConnSanitizer); ok {
+		err = iface.BeforeConnect(plug, slot)
+	}
+	return err
+}
+
 // ByName returns an Interface for the given interface name. Note that in order for
 // this to work properly, the package "interfaces/builtin" must also eventually be
 // imported to populate the full list of interfaces.
@@ -84,6 +107,9 @@ func BeforePrepareSlot(iface Interface, slotInfo *snap.SlotInfo) error {
 		return fmt.Errorf("cannot sanitize slot %q (interface %q) using interface %q",
 			SlotRef{Snap: slotInfo.Snap.InstanceName(), Name: slotInfo.Name}, slotInfo.Interface, iface.Name())
 	}
+	if err := validateSlotAttrs(iface, slotInfo); err != nil {
+		return err
+	}
 	var err error
 	if iface, ok := iface.(SlotSanitizer); ok {
 		err = iface.BeforePrepareSlot(slotInfo)
@@ -119,11 +145,18 @@ type Interfaces struct {
 
 // Info holds information about a given interface and its instances.
 type Info struct {
-	Name    string
-	Summary string
-	DocURL  string
-	Plugs   []*snap.PlugInfo
-	Slots   []*snap.SlotInfo
+	Name          string
+	Summary       string
+	DocURL        string
+	SecurityNotes []string
+	SecurityLevel SecurityLevel
+	Example       string
+	// PlugAttrs and SlotAttrs describe the attributes supported by the
+	// plugs and slots of the interface.
+	PlugAttrs map[string]AttrSpec
+	SlotAttrs map[string]AttrSpec
+	Plugs     []*snap.PlugInfo
+	Slots     []*snap.SlotInfo
 }
 
 // ConnRef holds information about plug and slot reference that form a particular connection.
@@ -192,6 +225,12 @@ type ConnPlugSanitizer interface {
 	BeforeConnectPlug(plug *ConnectedPlug) error
 }
 
+// ConnSanitizer can be implemented by Interfaces that need to validate the
+// plug and the slot against each other before a connection is performed.
+type ConnSanitizer interface {
+	BeforeConnect(plug *ConnectedPlug, slot *ConnectedSlot) error
+}
+
 // PlugSanitizer can be implemented by Interfaces that have reasons to sanitize their plugs.
 type PlugSanitizer interface {
 	BeforePreparePlug(plug *snap.PlugInfo) error
@@ -250,6 +289,37 @@ type ConflictingConnectedInterfacesDefiner interface {
 	ConflictsWithOtherConnectedInterfaces() []string
 }
 
+// DisconnectOrderDefiner can be implemented by Interfaces whose connections
+// must be removed before the connections of other interfaces, for instance
+// because they use resources provided through the latter.
+type DisconnectOrderDefiner interface {
+	// DisconnectAfter returns a list of interface names whose connections
+	// are removed after the ones of this interface when disconnecting all
+	// the connections of a snap.
+	DisconnectAfter() []string
+}
+
+// DevicePresenceUser can be implemented by Interfaces whose connected plug
+// snippets depend on whether some device nodes exist on the system, see
+// udev.Specification.TagDeviceIfPresent.
+type DevicePresenceUser interface {
+	// PlugDevicePatterns returns the glob patterns, such as
+	// "/dev/tee[0-9]*", of the device nodes whose presence affects the
+	// snippets of connected plugs.
+	PlugDevicePatterns() []string
+}
+
+// PinUser can be implemented by Interfaces whose slots can be bound to a
+// physical pin of the device, for instance because the pin can be used
+// either as GPIO or as PWM output but not both at the same time.
+type PinUser interface {
+	// SlotPin returns the pin used by the given slot, if any.
+	//
+	// A plug snap cannot be connected at the same time to two slots of the
+	// same slot snap using the same pin.
+	SlotPin(slot *snap.SlotInfo) (pin int64, ok bool)
+}
+
 // StaticInfo describes various static-info of a given interface.
 //
 // The Summary must be a one-line string of length suitable for listing views.
@@ -258,11 +328,24 @@ type ConflictingConnectedInterfacesDefiner interface {
 type StaticInfo struct {
 	Summary string
 	DocURL  string
+	// SecurityNotes describe the implications of granting the interface to
+	// a snap, they are displayed by the store for privileged interfaces.
+	SecurityNotes []string
+	// SecurityLevel overrides the security level derived from the base
+	// declaration, see SecurityLevelOf.
+	SecurityLevel SecurityLevel
+	// Example is a snapcraft.yaml stanza showing how the interface is
+	// used.
+	Example string
 
 	// ImplicitOnCore controls if a slot is automatically added to core (non-classic) systems.
 	ImplicitOnCore bool
 	// ImplicitOnClassic controls if a slot is automatically added to classic systems.
 	ImplicitOnClassic bool
+	// ImplicitOnClassicMinVersions maps os-release IDs to the oldest
+	// VERSION_ID of the distribution on which a slot is automatically added
+	// to classic systems, on older releases ImplicitOnClassic is unset.
+	ImplicitOnClassicMinVersions map[string]string
 
 	// ImplicitOnCore controls if a plug is automatically added to core (non-classic) systems.
 	ImplicitPlugOnCore bool
@@ -297,6 +380,20 @@ type StaticInfo struct {
 	// Similarly, AppArmorUnconfinedSlots results in the snap that slots this interface
 	// being granted the AppArmor unconfined profile mode
 	AppArmorUnconfinedSlots bool
+
+	// SeedOnly tells that connections of this interface can only be
+	// established while the device is being seeded, connecting once seeding
+	// has completed is refused.
+	SeedOnly bool
+
+	// ProviderOnly tells that the interface can only be used by slots,
+	// plugs of the interface are rejected.
+	ProviderOnly bool
+
+	// DefaultProvider is the name of the snap which usually provides the
+	// slots of the interface. When auto-connecting a plug for which the
+	// system has no candidate slot, installing it is suggested.
+	DefaultProvider string
 }
 
 // PlugServicesSnippetSection is the target systemd unit section for
@@ -363,8 +460,18 @@ func PermanentPlugServiceSnippets(iface Interface, plug *snap.PlugInfo) (snips [
 	return snips, nil
 }
 
-// StaticInfoOf returns the static-info of the given interface.
-func StaticInfoOf(iface Interface) (si StaticInfo) {
+// forRelease returns the static-info as evaluated on a host system of the
+// given release.
+func (si StaticInfo) forRelease(rel release.OS) StaticInfo {
+	for id, version := range si.ImplicitOnClassicMinVersions {
+		if rel.ID == id && !rel.AtLeast(id, version) {
+			si.ImplicitOnClassic = false
+		}
+	}
+	return si
+}
+
+func staticInfoOf(iface Interface) (si StaticInfo) {
 	type metaDataProvider interface {
 		StaticInfo() StaticInfo
 	}
@@ -374,6 +481,50 @@ func StaticInfoOf(iface Interface) (si StaticInfo) {
 	return si
 }
 
+// StaticInfoOf returns the static-info of the given interface, as evaluated
+// on the currently running system.
+func StaticInfoOf(iface Interface) StaticInfo {
+	return staticInfoOf(iface).forRelease(release.ReleaseInfo)
+}
+
+// IsImplicitOnClassic returns whether a slot of the given interface is
+// implicitly added to the core or snapd snap on classic systems. Some
+// interfaces evaluate this depending on the host release, the value returned
+// is the one for the currently running system.
+func IsImplicitOnClassic(iface Interface) bool {
+	return StaticInfoOf(iface).ImplicitOnClassic
+}
+
+// ReleaseStaticInfoProvider can be implemented by Interfaces whose static
+// information depends on the release of the host system.
+type ReleaseStaticInfoProvider interface {
+	StaticInfoForRelease(rel release.OS) StaticInfo
+}
+
+// StaticInfoForRelease returns the static-info of the given interface as
+// evaluated on a host system of the given release.
+func StaticInfoForRelease(iface Interface, rel release.OS) StaticInfo {
+	if iface, ok := iface.(ReleaseStaticInfoProvider); ok {
+		return iface.StaticInfoForRelease(rel).forRelease(rel)
+	}
+	return staticInfoOf(iface).forRelease(rel)
+}
+
+// ImplicitSlots returns the sorted names of the interfaces which a slot is
+// implicitly added for to the core or snapd snap, on a classic or core system
+// of the given release.
+func ImplicitSlots(onClassic bool, rel release.OS) []string {
+	var names []string
+	for _, iface := range AllInterfaces() {
+		si := StaticInfoForRelease(iface, rel)
+		if (onClassic && si.ImplicitOnClassic) || (!onClassic && si.ImplicitOnCore) {
+			names = append(names, iface.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Specification describes interactions between backends and interfaces.
 type Specification interface {
 	// AddPermanentSlot records side-effects of having a slot.
@@ -412,6 +563,8 @@ const (
 	SecurityConfigfiles SecuritySystem = "configfiles"
 	// SecuritySymlinks identifies the symlinks security system.
 	SecuritySymlinks SecuritySystem = "symlinks"
+	// SecurityCgroup identifies the cgroup controller delegation security system.
+	SecurityCgroup SecuritySystem = "cgroup"
 )
 
 var isValidBusName = regexp.MustCompile(`^[a-zA-Z_-][a-zA-Z0-9_-]*(\.[a-zA-Z_-][a-zA-Z0-9_-]*)+$`).MatchString
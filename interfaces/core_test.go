@@ -21,6 +21,7 @@ package interfaces_test
 
 import (
 	"fmt"
+	"sort"
 	"testing"
 
 	. "gopkg.in/check.v1"
@@ -28,6 +29,7 @@ import (
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/interfaces/builtin"
 	"github.com/snapcore/snapd/interfaces/ifacetest"
+	"github.com/snapcore/snapd/release"
 	"github.com/snapcore/snapd/snap"
 	"github.com/snapcore/snapd/snap/snaptest"
 	"github.com/snapcore/snapd/testutil"
@@ -266,6 +268,29 @@ plugs:
 	}, plug), ErrorMatches, `cannot sanitize plug "snap:plug" \(interface "iface"\) using interface "other"`)
 }
 
+func (s *CoreSuite) TestSanitizePlugProviderOnly(c *C) {
+	info := snaptest.MockInfo(c, `
+name: snap
+version: 0
+plugs:
+  plug:
+    interface: iface
+slots:
+  slot:
+    interface: iface
+`, nil)
+	iface := &ifacetest.TestInterface{
+		InterfaceName:       "iface",
+		InterfaceStaticInfo: interfaces.StaticInfo{ProviderOnly: true},
+		BeforePreparePlugCallback: func(plug *snap.PlugInfo) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+	}
+	c.Assert(interfaces.BeforePreparePlug(iface, info.Plugs["plug"]), ErrorMatches, `interface "iface" is provider-only and cannot be used by plugs`)
+	c.Assert(interfaces.BeforePrepareSlot(iface, info.Slots["slot"]), IsNil)
+}
+
 func (s *CoreSuite) TestSanitizeSlot(c *C) {
 	info := snaptest.MockInfo(c, `
 name: snap
@@ -286,3 +311,109 @@ slots:
 		InterfaceName: "other",
 	}, slot), ErrorMatches, `cannot sanitize slot "snap:slot" \(interface "iface"\) using interface "other"`)
 }
+
+func (s *CoreSuite) TestIsImplicitOnClassic(c *C) {
+	c.Check(interfaces.IsImplicitOnClassic(&ifacetest.TestInterface{
+		InterfaceName:       "iface",
+		InterfaceStaticInfo: interfaces.StaticInfo{ImplicitOnClassic: true},
+	}), Equals, true)
+	c.Check(interfaces.IsImplicitOnClassic(&ifacetest.TestInterface{
+		InterfaceName: "iface",
+	}), Equals, false)
+}
+
+func (s *CoreSuite) TestIsImplicitOnClassicEvaluatesRelease(c *C) {
+	iface, err := interfaces.ByName("fuse-support")
+	c.Assert(err, IsNil)
+
+	restore := release.MockReleaseInfo(&release.OS{ID: "ubuntu", VersionID: "14.04"})
+	defer restore()
+	c.Check(interfaces.IsImplicitOnClassic(iface), Equals, false)
+
+	release.MockReleaseInfo(&release.OS{ID: "ubuntu", VersionID: "24.04"})
+	c.Check(interfaces.IsImplicitOnClassic(iface), Equals, true)
+}
+
+func (s *CoreSuite) TestStaticInfoImplicitOnClassicMinVersions(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "iface",
+		InterfaceStaticInfo: interfaces.StaticInfo{
+			ImplicitOnClassic:            true,
+			ImplicitOnClassicMinVersions: map[string]string{"ubuntu": "16.04", "debian": "10"},
+		},
+	}
+	for _, t := range []struct {
+		rel      release.OS
+		implicit bool
+	}{
+		{release.OS{ID: "ubuntu", VersionID: "14.04"}, false},
+		{release.OS{ID: "ubuntu", VersionID: "16.04"}, true},
+		{release.OS{ID: "ubuntu", VersionID: "24.04"}, true},
+		{release.OS{ID: "debian", VersionID: "9"}, false},
+		{release.OS{ID: "debian", VersionID: "12"}, true},
+		// other distributions are not affected
+		{release.OS{ID: "fedora", VersionID: "9"}, true},
+		// rolling releases have no VERSION_ID
+		{release.OS{ID: "debian"}, true},
+	} {
+		comment := Commentf("%v", t.rel)
+		c.Check(interfaces.StaticInfoForRelease(iface, t.rel).ImplicitOnClassic, Equals, t.implicit, comment)
+
+		restore := release.MockReleaseInfo(&t.rel)
+		c.Check(interfaces.StaticInfoOf(iface).ImplicitOnClassic, Equals, t.implicit, comment)
+		c.Check(interfaces.IsImplicitOnClassic(iface), Equals, t.implicit, comment)
+		restore()
+	}
+
+	// the minimum versions do not make a slot implicit
+	iface.InterfaceStaticInfo.ImplicitOnClassic = false
+	c.Check(interfaces.StaticInfoForRelease(iface, release.OS{ID: "ubuntu", VersionID: "24.04"}).ImplicitOnClassic, Equals, false)
+}
+
+func (s *CoreSuite) TestImplicitSlots(c *C) {
+	trusty := release.OS{ID: "ubuntu", VersionID: "14.04"}
+	noble := release.OS{ID: "ubuntu", VersionID: "24.04"}
+
+	// the running system does not matter
+	restore := release.MockReleaseInfo(&trusty)
+	defer restore()
+
+	onClassic := interfaces.ImplicitSlots(true, noble)
+	c.Check(onClassic, testutil.Contains, "fuse-support")
+	c.Check(onClassic, testutil.Contains, "network")
+	c.Check(sort.StringsAreSorted(onClassic), Equals, true)
+
+	c.Check(interfaces.ImplicitSlots(true, trusty), Not(testutil.Contains), "fuse-support")
+	c.Check(interfaces.ImplicitSlots(true, trusty), testutil.Contains, "network")
+	// the 14.04 exclusion only applies to classic systems
+	c.Check(interfaces.ImplicitSlots(false, trusty), testutil.Contains, "fuse-support")
+	c.Check(interfaces.ImplicitSlots(false, noble), testutil.Contains, "fuse-support")
+
+	// slots of other snaps, or of the gadget, are not implicit
+	c.Check(onClassic, Not(testutil.Contains), "content")
+	c.Check(interfaces.ImplicitSlots(false, noble), Not(testutil.Contains), "gpio-memory-map-control")
+}
+
+func (s *CoreSuite) TestStaticInfoForRelease(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName:       "iface",
+		InterfaceStaticInfo: interfaces.StaticInfo{ImplicitOnClassic: true},
+	}
+	c.Check(interfaces.StaticInfoForRelease(iface, release.OS{ID: "ubuntu", VersionID: "14.04"}).ImplicitOnClassic, Equals, true)
+}
+
+func (s *CoreSuite) TestConfinementOptionsConfinement(c *C) {
+	for _, t := range []struct {
+		opts        interfaces.ConfinementOptions
+		confinement snap.ConfinementType
+	}{
+		{interfaces.ConfinementOptions{}, snap.StrictConfinement},
+		{interfaces.ConfinementOptions{DevMode: true}, snap.DevModeConfinement},
+		{interfaces.ConfinementOptions{Classic: true}, snap.ClassicConfinement},
+		{interfaces.ConfinementOptions{Classic: true, DevMode: true}, snap.ClassicConfinement},
+		{interfaces.ConfinementOptions{Classic: true, JailMode: true}, snap.StrictConfinement},
+		{interfaces.ConfinementOptions{DevMode: true, JailMode: true}, snap.StrictConfinement},
+	} {
+		c.Check(t.opts.Confinement(), Equals, t.confinement, Commentf("%+v", t.opts))
+	}
+}
@@ -192,6 +192,22 @@ func (b *Backend) Setup(appSet *interfaces.SnapAppSet, opts interfaces.Confineme
 	return nil
 }
 
+// Profiles returns the DBus configuration files of the apps and hooks of a
+// given snap, as Setup would write them.
+func (b *Backend) Profiles(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, repo *interfaces.Repository) (map[string][]byte, error) {
+	spec, err := repo.SnapSpecification(b.Name(), appSet, opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot obtain dbus specification for snap %q: %s", appSet.InstanceName(), err)
+	}
+
+	content := b.deriveContent(spec.(*Specification), appSet)
+	profiles := make(map[string][]byte, len(content))
+	for name, state := range content {
+		profiles[filepath.Join(dirs.SnapDBusSystemPolicyDir, name)] = state.(*osutil.MemoryFileState).Content
+	}
+	return profiles, nil
+}
+
 func profileGlobs(snapName string) []string {
 	var globs []string
 	for _, g := range interfaces.SecurityTagGlobs(snapName) {
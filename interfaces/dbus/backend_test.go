@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	. "gopkg.in/check.v1"
 
@@ -575,3 +576,25 @@ func (s *backendSuite) TestSetupWritesDbusFilesBothSnapdAndCoreInstalled(c *C) {
 		c.Check(filepath.Join(dirs.GlobalRootDir, fn), testutil.FileEquals, fmt.Sprintf("content of %s for snap snapd", filepath.Base(fn)))
 	}
 }
+
+func (s *backendSuite) TestProfilesMatchSetup(c *C) {
+	s.Iface.DBusPermanentSlotCallback = func(spec *dbus.Specification, slot *snap.SlotInfo) error {
+		spec.AddSnippet("<policy/>")
+		return nil
+	}
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", ifacetest.SambaYamlV1, 1)
+	appSet, err := interfaces.NewSnapAppSet(snapInfo, nil)
+	c.Assert(err, IsNil)
+
+	profiles, err := s.Backend.(interfaces.SecurityBackendProfiles).Profiles(appSet, interfaces.ConfinementOptions{}, s.Repo)
+	c.Assert(err, IsNil)
+	paths := make([]string, 0, len(profiles))
+	for path, content := range profiles {
+		paths = append(paths, path)
+		c.Check(path, testutil.FileEquals, string(content))
+	}
+	sort.Strings(paths)
+	c.Check(paths, DeepEquals, []string{
+		filepath.Join(dirs.SnapDBusSystemPolicyDir, "snap.samba.smbd.conf"),
+	})
+}
@@ -88,3 +88,9 @@ func MockApparmorPromptingSupportedByFeatures(f func(apparmorFeatures *apparmor.
 	apparmorPromptingSupportedByFeatures = f
 	return restore
 }
+
+func MockRuntimeNumCPU(f func() int) (restore func()) {
+	restore = testutil.Backup(&runtimeNumCPU)
+	runtimeNumCPU = f
+	return restore
+}
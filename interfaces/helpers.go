@@ -21,6 +21,8 @@ package interfaces
 
 import (
 	"fmt"
+	"runtime"
+	"sync"
 
 	"github.com/snapcore/snapd/timings"
 )
@@ -34,6 +36,8 @@ func SetupMany(repo *Repository, backend SecurityBackend, appSets []*SnapAppSet,
 		timings.Run(tm, "setup-security-backend[many]", fmt.Sprintf("setup security backend %q for %d snaps", backend.Name(), len(appSets)), func(nesttm timings.Measurer) {
 			errors = setupManyInterface.SetupMany(appSets, confinementOpts, repo, nesttm)
 		})
+	} else if concurrent, ok := backend.(SecurityBackendConcurrentSetup); ok && concurrent.CanSetupConcurrently() && len(appSets) > 1 {
+		errors = setupConcurrently(repo, backend, appSets, confinementOpts, tm)
 	} else {
 		// For each snap:
 		for _, set := range appSets {
@@ -52,3 +56,63 @@ func SetupMany(repo *Repository, backend SecurityBackend, appSets []*SnapAppSet,
 	}
 	return errors
 }
+
+// lockedMeasurer serializes the creation of spans of a measurer shared by
+// multiple goroutines.
+type lockedMeasurer struct {
+	mu   sync.Mutex
+	meas timings.Measurer
+}
+
+func (l *lockedMeasurer) StartSpan(label, summary string) *timings.Span {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.meas.StartSpan(label, summary)
+}
+
+var runtimeNumCPU = runtime.NumCPU
+
+// setupConcurrently calls Setup of the backend for each snap using a pool of
+// workers. The errors are returned in the order of the given snaps.
+func setupConcurrently(repo *Repository, backend SecurityBackend, appSets []*SnapAppSet, confinementOpts func(snapName string) ConfinementOptions, tm timings.Measurer) []error {
+	numWorkers := runtimeNumCPU()
+	if numWorkers > len(appSets) {
+		numWorkers = len(appSets)
+	}
+
+	// confinementOpts is not expected to be safe for concurrent use
+	opts := make([]ConfinementOptions, len(appSets))
+	for i, set := range appSets {
+		opts[i] = confinementOpts(set.Info().InstanceName())
+	}
+
+	meas := &lockedMeasurer{meas: tm}
+	results := make([]error, len(appSets))
+	queue := make(chan int, len(appSets))
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range queue {
+				set := appSets[idx]
+				timings.Run(meas, "setup-security-backend", fmt.Sprintf("setup security backend %q for snap %q", backend.Name(), set.Info().InstanceName()), func(nesttm timings.Measurer) {
+					results[idx] = backend.Setup(set, opts[idx], repo, nesttm)
+				})
+			}
+		}()
+	}
+	for idx := range appSets {
+		queue <- idx
+	}
+	close(queue)
+	wg.Wait()
+
+	var errors []error
+	for _, err := range results {
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return errors
+}
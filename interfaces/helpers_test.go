@@ -21,6 +21,7 @@ package interfaces_test
 
 import (
 	"fmt"
+	"sync"
 
 	. "gopkg.in/check.v1"
 
@@ -179,3 +180,37 @@ func (s *HelpersSuite) TestSetupManySetupNotOK(c *C) {
 	c.Check(errs, HasLen, 2)
 	c.Check(setupCalls, Equals, 2)
 }
+
+func (s *HelpersSuite) TestSetupManyRunsSetupConcurrentlyIfSupported(c *C) {
+	restore := interfaces.MockRuntimeNumCPU(func() int { return 4 })
+	defer restore()
+
+	var mu sync.Mutex
+	confinementOptsCalls := 0
+	confinementOpts := func(snapName string) interfaces.ConfinementOptions {
+		confinementOptsCalls++
+		return interfaces.ConfinementOptions{DevMode: snapName == "other-snap"}
+	}
+
+	setupSnaps := make(map[string]bool)
+	backend := &ifacetest.TestSecurityBackendConcurrentSetup{
+		TestSecurityBackend: ifacetest.TestSecurityBackend{
+			BackendName: "fake",
+			SetupCallback: func(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, repo *interfaces.Repository) error {
+				mu.Lock()
+				defer mu.Unlock()
+				setupSnaps[appSet.InstanceName()] = opts.DevMode
+				if appSet.InstanceName() == "other-snap" {
+					return fmt.Errorf("error")
+				}
+				return nil
+			},
+		},
+	}
+
+	errs := interfaces.SetupMany(s.repo, backend, []*interfaces.SnapAppSet{s.snap1, s.snap2}, confinementOpts, s.tm)
+	c.Check(errs, DeepEquals, []error{fmt.Errorf("error")})
+	c.Check(backend.SetupCalls, HasLen, 2)
+	c.Check(setupSnaps, DeepEquals, map[string]bool{"some-snap": false, "other-snap": true})
+	c.Check(confinementOptsCalls, Equals, 2)
+}
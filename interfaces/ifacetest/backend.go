@@ -20,6 +20,8 @@
 package ifacetest
 
 import (
+	"sync"
+
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/snap"
 	"github.com/snapcore/snapd/timings"
@@ -112,6 +114,26 @@ func (b *TestSecurityBackendSetupMany) SetupMany(appSets []*interfaces.SnapAppSe
 	return b.SetupManyCallback(appSets, confinement, repo, tm)
 }
 
+// TestSecurityBackendConcurrentSetup is a security backend that can be set up
+// concurrently for multiple snaps on top of TestSecurityBackend.
+type TestSecurityBackendConcurrentSetup struct {
+	TestSecurityBackend
+
+	mu sync.Mutex
+}
+
+// Setup records information about the call and calls the setup callback if
+// one is defined, one call at a time.
+func (b *TestSecurityBackendConcurrentSetup) Setup(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, repo *interfaces.Repository, tm timings.Measurer) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.TestSecurityBackend.Setup(appSet, opts, repo, tm)
+}
+
+func (b *TestSecurityBackendConcurrentSetup) CanSetupConcurrently() bool {
+	return true
+}
+
 // TestSecurityBackendDiscardingLate implements RemoveLate on top of TestSecurityBackend.
 type TestSecurityBackendDiscardingLate struct {
 	TestSecurityBackend
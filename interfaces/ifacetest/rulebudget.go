@@ -0,0 +1,58 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package ifacetest
+
+import (
+	"strings"
+
+	"gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+)
+
+// CountRuleLines returns the number of lines of an apparmor snippet which are
+// neither blank nor comments.
+func CountRuleLines(snippet string) int {
+	count := 0
+	for _, line := range strings.Split(snippet, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// AssertMaxRuleCount checks that the plug side of a connection of the given
+// interface between an app and the core snap, with no attributes set, does
+// not emit more than max apparmor rule lines. This guards against unintended
+// growth of the policy of an interface.
+func AssertMaxRuleCount(c *check.C, iface interfaces.Interface, max int) {
+	plug, plugInfo, slot, _ := mockStandardConnection(c, iface)
+
+	spec := apparmor.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddPermanentPlug(iface, plugInfo), check.IsNil)
+	c.Assert(spec.AddConnectedPlug(iface, plug, slot), check.IsNil)
+
+	count := CountRuleLines(spec.SnippetForTag("snap.consumer.app"))
+	c.Assert(count <= max, check.Equals, true, check.Commentf("%s emits %d apparmor rule lines, over its budget of %d", iface.Name(), count, max))
+}
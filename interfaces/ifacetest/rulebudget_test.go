@@ -0,0 +1,41 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package ifacetest_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces/ifacetest"
+)
+
+type ruleBudgetSuite struct{}
+
+var _ = Suite(&ruleBudgetSuite{})
+
+func (s *ruleBudgetSuite) TestCountRuleLines(c *C) {
+	c.Check(ifacetest.CountRuleLines(""), Equals, 0)
+	c.Check(ifacetest.CountRuleLines(`
+# comment
+/dev/foo rw,
+  #include <abstractions/base>
+
+  capability sys_admin,
+`), Equals, 2)
+}
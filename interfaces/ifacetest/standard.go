@@ -0,0 +1,131 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+package ifacetest
+
+import (
+	"fmt"
+
+	"gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/dirs"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/testutil"
+)
+
+const standardConsumerYaml = `name: consumer
+version: 0
+apps:
+  app:
+    plugs: [plug]
+plugs:
+  plug:
+    interface: %s
+`
+
+const standardCoreYaml = `name: core
+version: 0
+type: os
+slots:
+  slot:
+    interface: %s
+`
+
+// mockStandardConnection returns a connection of the given interface between
+// the "plug" plug of the single app of a consumer snap and the "slot" slot of
+// the core snap, with no attributes set.
+func mockStandardConnection(c *check.C, iface interfaces.Interface) (*interfaces.ConnectedPlug, *snap.PlugInfo, *interfaces.ConnectedSlot, *snap.SlotInfo) {
+	plug, plugInfo := MockConnectedPlug(c, fmt.Sprintf(standardConsumerYaml, iface.Name()), nil, "plug")
+	slot, slotInfo := MockConnectedSlot(c, fmt.Sprintf(standardCoreYaml, iface.Name()), nil, "slot")
+	return plug, plugInfo, slot, slotInfo
+}
+
+// Config describes the expected behavior of an interface for
+// StandardInterfaceTests.
+type Config struct {
+	Iface interfaces.Interface
+	// ExpectedAppArmor and ExpectedSeccomp are fragments which the
+	// profiles of the consuming app must contain, none are expected when
+	// empty.
+	ExpectedAppArmor []string
+	ExpectedSeccomp  []string
+	// ExpectedUDev are the udev rules tagging the devices of the consuming
+	// app, as returned by the udev specification, none are expected when
+	// empty.
+	ExpectedUDev []string
+	// AutoConnect is the expected result of AutoConnect.
+	AutoConnect bool
+}
+
+// StandardInterfaceTests runs the assertions common to all interfaces on a
+// connection between an app and the core snap with no attributes set: plug
+// and slot sanitization, the plug side apparmor, seccomp and udev policy and
+// auto-connection.
+func StandardInterfaceTests(c *check.C, config Config) {
+	iface := config.Iface
+	plug, plugInfo, slot, slotInfo := mockStandardConnection(c, iface)
+	const tag = "snap.consumer.app"
+
+	c.Check(iface.Name(), check.Not(check.Equals), "")
+	c.Check(interfaces.BeforePreparePlug(iface, plugInfo), check.IsNil)
+	c.Check(interfaces.BeforePrepareSlot(iface, slotInfo), check.IsNil)
+
+	apparmorSpec := apparmor.NewSpecification(plug.AppSet())
+	c.Assert(apparmorSpec.AddPermanentPlug(iface, plugInfo), check.IsNil)
+	c.Assert(apparmorSpec.AddConnectedPlug(iface, plug, slot), check.IsNil)
+	if len(config.ExpectedAppArmor) == 0 {
+		c.Check(apparmorSpec.SecurityTags(), check.HasLen, 0)
+	} else {
+		c.Check(apparmorSpec.SecurityTags(), check.DeepEquals, []string{tag})
+	}
+	for _, expected := range config.ExpectedAppArmor {
+		c.Check(apparmorSpec.SnippetForTag(tag), testutil.Contains, expected)
+	}
+
+	seccompSpec := seccomp.NewSpecification(plug.AppSet())
+	c.Assert(seccompSpec.AddPermanentPlug(iface, plugInfo), check.IsNil)
+	c.Assert(seccompSpec.AddConnectedPlug(iface, plug, slot), check.IsNil)
+	if len(config.ExpectedSeccomp) == 0 {
+		c.Check(seccompSpec.SecurityTags(), check.HasLen, 0)
+	} else {
+		c.Check(seccompSpec.SecurityTags(), check.DeepEquals, []string{tag})
+	}
+	for _, expected := range config.ExpectedSeccomp {
+		c.Check(seccompSpec.SnippetForTag(tag), testutil.Contains, expected)
+	}
+
+	udevSpec := udev.NewSpecification(plug.AppSet())
+	c.Assert(udevSpec.AddPermanentPlug(iface, plugInfo), check.IsNil)
+	c.Assert(udevSpec.AddConnectedPlug(iface, plug, slot), check.IsNil)
+	if len(config.ExpectedUDev) == 0 {
+		c.Check(udevSpec.Snippets(), check.HasLen, 0)
+	} else {
+		// tagged devices are handed over to snap-device-helper
+		c.Check(udevSpec.Snippets(), check.HasLen, len(config.ExpectedUDev)+1)
+		c.Check(udevSpec.Snippets(), testutil.Contains, fmt.Sprintf(`TAG=="snap_consumer_app", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="%v/snap-device-helper $env{ACTION} snap_consumer_app $devpath $major:$minor"`, dirs.DistroLibExecDir))
+	}
+	for _, expected := range config.ExpectedUDev {
+		c.Check(udevSpec.Snippets(), testutil.Contains, expected)
+	}
+
+	c.Check(iface.AutoConnect(plugInfo, slotInfo), check.Equals, config.AutoConnect)
+}
@@ -0,0 +1,70 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+package ifacetest_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/ifacetest"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/snap"
+)
+
+type standardSuite struct{}
+
+var _ = Suite(&standardSuite{})
+
+func (s *standardSuite) TestStandardInterfaceTests(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "standard",
+		AppArmorConnectedPlugCallback: func(spec *apparmor.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddSnippet("/dev/standard rw,")
+			return nil
+		},
+		SecCompConnectedPlugCallback: func(spec *seccomp.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddSnippet("ioctl")
+			return nil
+		},
+		UDevConnectedPlugCallback: func(spec *udev.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.TagDevice(`KERNEL=="standard"`)
+			return nil
+		},
+		AutoConnectCallback: func(*snap.PlugInfo, *snap.SlotInfo) bool {
+			return false
+		},
+	}
+	ifacetest.StandardInterfaceTests(c, ifacetest.Config{
+		Iface:            iface,
+		ExpectedAppArmor: []string{"/dev/standard rw,"},
+		ExpectedSeccomp:  []string{"ioctl"},
+		ExpectedUDev: []string{`# standard
+KERNEL=="standard", TAG+="snap_consumer_app"`},
+		AutoConnect: false,
+	})
+}
+
+func (s *standardSuite) TestStandardInterfaceTestsNoPolicy(c *C) {
+	ifacetest.StandardInterfaceTests(c, ifacetest.Config{
+		Iface:       &ifacetest.TestInterface{InterfaceName: "standard"},
+		AutoConnect: true,
+	})
+}
@@ -22,6 +22,7 @@ package ifacetest
 import (
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/interfaces/apparmor"
+	"github.com/snapcore/snapd/interfaces/cgroup"
 	"github.com/snapcore/snapd/interfaces/configfiles"
 	"github.com/snapcore/snapd/interfaces/dbus"
 	"github.com/snapcore/snapd/interfaces/hotplug"
@@ -51,6 +52,7 @@ type TestInterface struct {
 
 	BeforeConnectPlugCallback func(plug *interfaces.ConnectedPlug) error
 	BeforeConnectSlotCallback func(slot *interfaces.ConnectedSlot) error
+	BeforeConnectCallback     func(plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error
 
 	// Support for interacting with the test backend.
 
@@ -135,6 +137,13 @@ type TestInterface struct {
 	SymlinksConnectedSlotCallback func(spec *symlinks.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error
 	SymlinksPermanentPlugCallback func(spec *symlinks.Specification, plug *snap.PlugInfo) error
 	SymlinksPermanentSlotCallback func(spec *symlinks.Specification, slot *snap.SlotInfo) error
+
+	// Support for interacting with the cgroup backend.
+
+	CgroupConnectedPlugCallback func(spec *cgroup.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error
+	CgroupConnectedSlotCallback func(spec *cgroup.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error
+	CgroupPermanentPlugCallback func(spec *cgroup.Specification, plug *snap.PlugInfo) error
+	CgroupPermanentSlotCallback func(spec *cgroup.Specification, slot *snap.SlotInfo) error
 }
 
 // TestHotplugInterface is an interface for various kinds of tests
@@ -177,6 +186,22 @@ type TestConflictingConnectionInterface struct {
 	ConflictingConnectedInterfaces []string
 }
 
+// TestDisconnectOrderInterface is used to test support for ordering the
+// disconnection of interfaces, which needs interfaces implementing
+// DisconnectAfter.
+type TestDisconnectOrderInterface struct {
+	TestInterface
+
+	// Support for ordering the disconnection of interfaces.
+	DisconnectAfterInterfaces []string
+}
+
+// TestPinUserInterface is used to test support for detecting slots
+// sharing a pin, which needs interfaces implementing SlotPin.
+type TestPinUserInterface struct {
+	TestInterface
+}
+
 // String() returns the same value as Name().
 func (t *TestInterface) String() string {
 	return t.Name()
@@ -221,6 +246,13 @@ func (t *TestInterface) BeforeConnectSlot(slot *interfaces.ConnectedSlot) error
 	return nil
 }
 
+func (t *TestInterface) BeforeConnect(plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	if t.BeforeConnectCallback != nil {
+		return t.BeforeConnectCallback(plug, slot)
+	}
+	return nil
+}
+
 // AutoConnect returns whether plug and slot should be implicitly
 // auto-connected assuming they will be an unambiguous connection
 // candidate.
@@ -592,6 +624,36 @@ func (t *TestInterface) SymlinksPermanentSlot(spec *symlinks.Specification, slot
 	return nil
 }
 
+// Support for interacting with the cgroup backend.
+
+func (t *TestInterface) CgroupConnectedPlug(spec *cgroup.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	if t.CgroupConnectedPlugCallback != nil {
+		return t.CgroupConnectedPlugCallback(spec, plug, slot)
+	}
+	return nil
+}
+
+func (t *TestInterface) CgroupConnectedSlot(spec *cgroup.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+	if t.CgroupConnectedSlotCallback != nil {
+		return t.CgroupConnectedSlotCallback(spec, plug, slot)
+	}
+	return nil
+}
+
+func (t *TestInterface) CgroupPermanentPlug(spec *cgroup.Specification, plug *snap.PlugInfo) error {
+	if t.CgroupPermanentPlugCallback != nil {
+		return t.CgroupPermanentPlugCallback(spec, plug)
+	}
+	return nil
+}
+
+func (t *TestInterface) CgroupPermanentSlot(spec *cgroup.Specification, slot *snap.SlotInfo) error {
+	if t.CgroupPermanentSlotCallback != nil {
+		return t.CgroupPermanentSlotCallback(spec, slot)
+	}
+	return nil
+}
+
 // Support for interacting with hotplug subsystem.
 
 func (t *TestHotplugInterface) HotplugKey(deviceInfo *hotplug.HotplugDeviceInfo) (snap.HotplugKey, error) {
@@ -638,3 +700,16 @@ func (t *TestSymlinksInterface) TrackedDirectories() []string {
 func (t *TestConflictingConnectionInterface) ConflictsWithOtherConnectedInterfaces() []string {
 	return t.ConflictingConnectedInterfaces
 }
+
+// Support for ordering the disconnection of interfaces.
+
+func (t *TestDisconnectOrderInterface) DisconnectAfter() []string {
+	return t.DisconnectAfterInterfaces
+}
+
+// Support for detecting slots sharing a pin.
+
+func (t *TestPinUserInterface) SlotPin(slot *snap.SlotInfo) (int64, bool) {
+	pin, ok := slot.Attrs["pin"].(int64)
+	return pin, ok
+}
@@ -118,6 +118,30 @@ func (s *backendSuite) TestRemovingSnapRemovesModulesConf(c *C) {
 	}
 }
 
+func (s *backendSuite) TestSetupWithoutModulesRemovesModulesConf(c *C) {
+	s.Iface.KModPermanentSlotCallback = func(spec *kmod.Specification, slot *snap.SlotInfo) error {
+		spec.AddModule("module1")
+		return nil
+	}
+
+	path := filepath.Join(dirs.SnapKModModulesDir, "snap.samba.conf")
+	for _, opts := range testedConfinementOpts {
+		snapInfo := s.InstallSnap(c, opts, "", ifacetest.SambaYamlV1, 0)
+		c.Assert(osutil.FileExists(path), Equals, true)
+
+		// the interface no longer requests modules, as after a disconnect
+		s.Iface.KModPermanentSlotCallback = nil
+		snapInfo = s.UpdateSnap(c, snapInfo, opts, ifacetest.SambaYamlV1, 0)
+		c.Assert(osutil.FileExists(path), Equals, false)
+		s.RemoveSnap(c, snapInfo)
+
+		s.Iface.KModPermanentSlotCallback = func(spec *kmod.Specification, slot *snap.SlotInfo) error {
+			spec.AddModule("module1")
+			return nil
+		}
+	}
+}
+
 func (s *backendSuite) TestInstallingSnapCreatesModprobeConf(c *C) {
 	s.Iface.KModPermanentSlotCallback = func(spec *kmod.Specification, slot *snap.SlotInfo) error {
 		spec.AddModule("module1")
@@ -58,6 +58,12 @@ func (b *Backend) Name() interfaces.SecuritySystem {
 	return interfaces.SecurityMount
 }
 
+// CanSetupConcurrently returns true as the mount profile and the mount
+// namespace of a snap are independent of other snaps.
+func (b *Backend) CanSetupConcurrently() bool {
+	return true
+}
+
 // Setup creates mount mount profile files specific to a given snap.
 func (b *Backend) Setup(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, repo *interfaces.Repository, tm timings.Measurer) error {
 	// Record all changes to the mount system for this snap.
@@ -72,6 +72,12 @@ func (s *backendSuite) TestName(c *C) {
 	c.Check(s.Backend.Name(), Equals, interfaces.SecurityMount)
 }
 
+func (s *backendSuite) TestCanSetupConcurrently(c *C) {
+	backend, ok := s.Backend.(interfaces.SecurityBackendConcurrentSetup)
+	c.Assert(ok, Equals, true)
+	c.Check(backend.CanSetupConcurrently(), Equals, true)
+}
+
 func (s *backendSuite) TestRemove(c *C) {
 	appCanaryToGo := filepath.Join(dirs.SnapMountPolicyDir, "snap.hello-world.hello-world.fstab")
 	err := os.WriteFile(appCanaryToGo, []byte("ni! ni! ni!"), 0644)
@@ -84,12 +84,35 @@ func composeBaseDeclaration(ifaces []interfaces.Interface) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// checkBaseDeclaration verifies that the policy of each interface ended up in
+// the base-declaration under the name of that interface. A typo in the
+// interface name or broken indentation in the
+// StaticInfo.BaseDeclarationSlots/BaseDeclarationPlugs snippets would
+// otherwise go unnoticed or attach the policy to another interface.
+func checkBaseDeclaration(baseDecl *asserts.BaseDeclaration, ifaces []interfaces.Interface) error {
+	for _, iface := range ifaces {
+		si := interfaces.StaticInfoOf(iface)
+		name := iface.Name()
+		if strings.TrimSpace(si.BaseDeclarationPlugs) != "" && baseDecl.PlugRule(name) == nil {
+			return fmt.Errorf("base-declaration plugs policy of interface %q does not define a rule for it", name)
+		}
+		if strings.TrimSpace(si.BaseDeclarationSlots) != "" && baseDecl.SlotRule(name) == nil {
+			return fmt.Errorf("base-declaration slots policy of interface %q does not define a rule for it", name)
+		}
+	}
+	return nil
+}
+
 func init() {
-	decl, err := composeBaseDeclaration(builtin.Interfaces())
+	ifaces := builtin.Interfaces()
+	decl, err := composeBaseDeclaration(ifaces)
 	if err != nil {
 		panic(fmt.Sprintf("cannot compose base-declaration: %v", err))
 	}
 	if err := asserts.InitBuiltinBaseDeclaration(decl); err != nil {
 		panic(fmt.Sprintf("cannot initialize the builtin base-declaration: %v", err))
 	}
+	if err := checkBaseDeclaration(asserts.BuiltinBaseDeclaration(), ifaces); err != nil {
+		panic(fmt.Sprintf("invalid builtin base-declaration: %v", err))
+	}
 }
@@ -246,6 +246,7 @@ func (s *baseDeclSuite) TestAutoConnectPlugSlot(c *C) {
 		"classic-support": true,
 		"content":         true,
 		"cups-control":    true,
+		"fuse-support":    true,
 		"home":            true,
 		"lxd-support":     true,
 		// netlink-driver needs the family-name attributes to match
@@ -402,6 +403,80 @@ plugs:
 	c.Check(err, NotNil)
 }
 
+func (s *baseDeclSuite) TestAutoConnectionFuseSupport(c *C) {
+	// the system slot does not auto-connect
+	cand := s.connectCand(c, "fuse-support", `name: core
+version: 0
+type: os
+slots:
+  fuse-support:
+`, "")
+	_, err := cand.CheckAutoConnect()
+	c.Check(err, ErrorMatches, `auto-connection denied by slot rule of interface "fuse-support"`)
+
+	// an app snap cannot grant itself the interface with its own slot
+	const sameSnapYaml = `name: fuse-snap
+version: 0
+plugs:
+  fuse-support:
+slots:
+  fuse-helper:
+    interface: fuse-support
+`
+	ic := s.installSlotCand(c, "fuse-support", snap.TypeApp, sameSnapYaml)
+	c.Check(ic.Check(), ErrorMatches, `installation not allowed by "fuse-helper" slot rule of interface "fuse-support"`)
+
+	sameSnapDecl := s.mockSnapDecl(c, "fuse-snap", "fuse-snap-id", "pub1", "")
+	plug, _ := ifacetest.MockConnectedPlug(c, sameSnapYaml, nil, "fuse-support")
+	slot, _ := ifacetest.MockConnectedSlot(c, sameSnapYaml, nil, "fuse-helper")
+	cand = &policy.ConnectCandidate{
+		Plug:                plug,
+		Slot:                slot,
+		PlugSnapDeclaration: sameSnapDecl,
+		SlotSnapDeclaration: sameSnapDecl,
+		BaseDeclaration:     s.baseDecl,
+	}
+	_, err = cand.CheckAutoConnect()
+	c.Check(err, ErrorMatches, `auto-connection denied by slot rule of interface "fuse-support"`)
+}
+
+func (s *baseDeclSuite) TestAutoConnectionFuseSupportSameSnap(c *C) {
+	const sameSnapYaml = `name: fuse-snap
+version: 0
+plugs:
+  fuse-support:
+slots:
+  fuse-helper:
+    interface: fuse-support
+`
+	plug, _ := ifacetest.MockConnectedPlug(c, sameSnapYaml, nil, "fuse-support")
+	slot, _ := ifacetest.MockConnectedSlot(c, sameSnapYaml, nil, "fuse-helper")
+	sameSnapDecl := s.mockSnapDecl(c, "fuse-snap", "fuse-snap-id", "pub1", "")
+	cand := &policy.ConnectCandidate{
+		Plug:                plug,
+		Slot:                slot,
+		PlugSnapDeclaration: sameSnapDecl,
+		SlotSnapDeclaration: sameSnapDecl,
+		BaseDeclaration:     s.baseDecl,
+	}
+	// a snap cannot grant itself the auto-connection by providing a slot
+	_, err := cand.CheckAutoConnect()
+	c.Check(err, ErrorMatches, `auto-connection denied by slot rule of interface "fuse-support"`)
+
+	// the store has to grant it
+	plugsSlots := `
+plugs:
+  fuse-support:
+    allow-auto-connection: true
+`
+	grantDecl := s.mockSnapDecl(c, "fuse-snap", "fuse-snap-id", "pub1", plugsSlots)
+	cand.PlugSnapDeclaration = grantDecl
+	cand.SlotSnapDeclaration = grantDecl
+	arity, err := cand.CheckAutoConnect()
+	c.Check(err, IsNil)
+	c.Check(arity.SlotsPerPlugAny(), Equals, false)
+}
+
 func (s *baseDeclSuite) TestAutoConnectionSharedMemory(c *C) {
 	// random snaps cannot connect with shared-memory
 	// (Sanitize* will now also block this)
@@ -826,9 +901,12 @@ var (
 		"desktop-launch":            {"core"},
 		"dsp":                       {"core", "gadget"},
 		"empty":                     {"app"},
+		"fuse-support":              {"core", "gadget"},
 		"fwupd":                     {"app", "core"},
 		"gpio":                      {"core", "gadget"},
 		"gpio-control":              {"core"},
+		"gpio-memory-map-control":   {"core", "gadget"},
+		"gpiod-chardev-control":     {"core", "gadget"},
 		"greengrass-support":        {"core"},
 		"hidraw":                    {"core", "gadget"},
 		"i2c":                       {"core", "gadget"},
@@ -865,15 +943,18 @@ var (
 		"scsi-generic":              {"core"},
 		"sd-control":                {"core"},
 		"serial-port":               {"core", "gadget"},
+		"smbus-control":             {"core", "gadget"},
 		"spi":                       {"core", "gadget"},
 		"screen-inhibit-control":    {"core", "app"},
 		"steam-support":             {"core"},
 		"storage-framework-service": {"app"},
 		"thumbnailer-service":       {"app"},
+		"time-control":              {"app", "core"},
 		"ubuntu-download-manager":   {"app"},
 		"udisks2":                   {"app", "core"},
 		"uhid":                      {"core"},
 		"uio":                       {"core", "gadget"},
+		"sysfs-write-control":       {"core", "gadget"},
 		"unity8":                    {"app"},
 		"unity8-calendar":           {"app"},
 		"unity8-contacts":           {"app"},
@@ -1055,6 +1136,7 @@ func (s *baseDeclSuite) TestPlugInstallation(c *C) {
 		"block-devices":                    true,
 		"checkbox-support":                 true,
 		"classic-support":                  true,
+		"container-runtime-support":        true,
 		"cuda-driver-libs":                 true,
 		"desktop-launch":                   true,
 		"dm-crypt":                         true,
@@ -1172,6 +1254,7 @@ func (s *baseDeclSuite) TestConnection(c *C) {
 		"shared-memory":             true,
 		"storage-framework-service": true,
 		"thumbnailer-service":       true,
+		"time-control":              true,
 		"ubuntu-download-manager":   true,
 		"unity8-calendar":           true,
 		"unity8-contacts":           true,
@@ -1202,6 +1285,7 @@ func (s *baseDeclSuite) TestConnectionImplicitSlotOnly(c *C) {
 		"desktop":                true,
 		"qualcomm-ipc-router":    true,
 		"screen-inhibit-control": true,
+		"time-control":           true,
 		"upower-observe":         true,
 	}
 
@@ -1370,6 +1454,7 @@ func (s *baseDeclSuite) TestValidity(c *C) {
 		"classic-support":                  true,
 		"checkbox-support":                 true,
 		"core-support":                     true,
+		"container-runtime-support":        true,
 		"cuda-driver-libs":                 true,
 		"custom-device":                    true,
 		"desktop":                          true,
@@ -1716,6 +1801,104 @@ func (s *baseDeclSuite) TestDoesNotPanic(c *C) {
 	c.Assert(err, IsNil)
 }
 
+// checkInterfaceBaseDeclaration parses a base-declaration holding only the
+// policy of the given interface, next to a filler interface as neither the
+// plugs nor the slots section may be empty. It replaces the builtin
+// base-declaration, the caller is responsible for restoring it.
+func checkInterfaceBaseDeclaration(iface interfaces.Interface) error {
+	filler := &ifacetest.TestInterface{
+		InterfaceName: "filler",
+		InterfaceStaticInfo: interfaces.StaticInfo{
+			BaseDeclarationPlugs: `
+  filler:
+    allow-installation: true
+`,
+			BaseDeclarationSlots: `
+  filler:
+    allow-installation: true
+`,
+		},
+	}
+	decl, err := policy.ComposeBaseDeclaration([]interfaces.Interface{filler, iface})
+	if err != nil {
+		return err
+	}
+	if err := asserts.InitBuiltinBaseDeclaration(decl); err != nil {
+		return err
+	}
+	return policy.CheckBaseDeclaration(asserts.BuiltinBaseDeclaration(), []interfaces.Interface{iface})
+}
+
+func (s *baseDeclSuite) restoreBaseDeclaration(c *C) {
+	decl, err := policy.ComposeBaseDeclaration(builtin.Interfaces())
+	c.Assert(err, IsNil)
+	c.Assert(asserts.InitBuiltinBaseDeclaration(decl), IsNil)
+}
+
+func (s *baseDeclSuite) TestEachInterfaceBaseDeclaration(c *C) {
+	defer s.restoreBaseDeclaration(c)
+
+	for _, iface := range builtin.Interfaces() {
+		c.Check(checkInterfaceBaseDeclaration(iface), IsNil, Commentf("interface %q", iface.Name()))
+	}
+}
+
+func (s *baseDeclSuite) TestInterfaceBaseDeclarationErrors(c *C) {
+	defer s.restoreBaseDeclaration(c)
+
+	for _, t := range []struct {
+		slots string
+		plugs string
+		err   string
+	}{{
+		// typo in the interface name
+		slots: `
+  tset-iface:
+    allow-installation: false
+`,
+		err: `base-declaration slots policy of interface "test-iface" does not define a rule for it`,
+	}, {
+		plugs: `
+  tset-iface:
+    allow-installation: false
+`,
+		err: `base-declaration plugs policy of interface "test-iface" does not define a rule for it`,
+	}, {
+		// broken indentation
+		slots: `
+  test-iface:
+  allow-installation: false
+`,
+		err: `expected 6 chars nesting prefix after multiline introduction "  test-iface:": "  allow-installation: false"`,
+	}, {
+		// unknown snap type
+		slots: `
+  test-iface:
+    allow-installation:
+      slot-snap-type:
+        - os
+`,
+		err: `.* slot-snap-type in allow-installation in slot rule for interface "test-iface" contains an invalid element: "os"`,
+	}, {
+		// unknown constraint
+		slots: `
+  test-iface:
+    deny-connection:
+      on-closed-moon: true
+`,
+		err: `.* deny-connection in slot rule for interface "test-iface" must specify at least one of .*`,
+	}} {
+		iface := &ifacetest.TestInterface{
+			InterfaceName: "test-iface",
+			InterfaceStaticInfo: interfaces.StaticInfo{
+				BaseDeclarationSlots: t.slots,
+				BaseDeclarationPlugs: t.plugs,
+			},
+		}
+		c.Check(checkInterfaceBaseDeclaration(iface), ErrorMatches, t.err, Commentf("slots: %q plugs: %q", t.slots, t.plugs))
+	}
+}
+
 func (s *baseDeclSuite) TestBrowserSupportAllowSandbox(c *C) {
 	const plugYaml = `name: plug-snap
 version: 0
@@ -22,5 +22,6 @@ package policy
 var (
 	NestedGet              = nestedGet
 	ComposeBaseDeclaration = composeBaseDeclaration
+	CheckBaseDeclaration   = checkBaseDeclaration
 	CheckSnapType          = checkSnapType
 )
@@ -103,6 +103,15 @@ func checkDeviceScope(c *asserts.DeviceScopeConstraint, model *asserts.Model, st
 	return c.Check(model, store, &opts)
 }
 
+// modelBrandID returns the brand of the device model, to be matched by $BRAND
+// in publisher id constraints, or "" if the model is unknown.
+func modelBrandID(model *asserts.Model) string {
+	if model == nil {
+		return ""
+	}
+	return model.BrandID()
+}
+
 func checkNameConstraints(c *asserts.NameConstraints, iface, which, name string) error {
 	if c == nil {
 		return nil
@@ -135,6 +144,7 @@ func checkPlugConnectionConstraints1(connc *ConnectCandidate, constraints *asser
 	}
 	err := checkID("publisher id", connc.SlotPublisherID(), constraints.SlotPublisherIDs, map[string]string{
 		"$PLUG_PUBLISHER_ID": connc.PlugPublisherID(),
+		"$BRAND":             modelBrandID(connc.Model),
 	})
 	if err != nil {
 		return err
@@ -191,6 +201,7 @@ func checkSlotConnectionConstraints1(connc *ConnectCandidate, constraints *asser
 	}
 	err := checkID("publisher id", connc.PlugPublisherID(), constraints.PlugPublisherIDs, map[string]string{
 		"$SLOT_PUBLISHER_ID": connc.SlotPublisherID(),
+		"$BRAND":             modelBrandID(connc.Model),
 	})
 	if err != nil {
 		return err
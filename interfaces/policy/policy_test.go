@@ -1398,6 +1398,80 @@ AXNpZw==`))
 	c.Check(cand.Check(), IsNil)
 }
 
+func (s *policySuite) TestBrandPublisherIDCheckAutoConnection(c *C) {
+	a, err := asserts.Decode([]byte(`type: base-declaration
+authority-id: canonical
+series: 16
+slots:
+  brand-publisher-id:
+    allow-installation:
+      slot-snap-type:
+        - core
+    allow-auto-connection:
+      plug-publisher-id:
+        - $BRAND
+timestamp: 2016-09-30T12:00:00Z
+sign-key-sha3-384: Jv8_JiHiIzJVcO9M55pPdqSDWUvuhfDIBJUS-3VW7F_idjix7Ffn5qMxB21ZQuij
+
+AXNpZw==`))
+	c.Assert(err, IsNil)
+	baseDecl := a.(*asserts.BaseDeclaration)
+
+	coreAppSet := ifacetest.MockInfoAndAppSet(c, `
+name: core
+version: 0
+type: os
+slots:
+  brand-publisher-id:
+`, nil, nil)
+
+	brandPlugAppSet := ifacetest.MockInfoAndAppSet(c, `
+name: brand-plug-snap
+version: 0
+plugs:
+  brand-publisher-id:
+`, nil, nil)
+
+	a, err = asserts.Decode([]byte(`type: snap-declaration
+authority-id: canonical
+series: 16
+snap-name: brand-plug-snap
+snap-id: brandplugsnapidididididididididi
+publisher-id: my-brand
+timestamp: 2016-09-30T12:00:00Z
+sign-key-sha3-384: Jv8_JiHiIzJVcO9M55pPdqSDWUvuhfDIBJUS-3VW7F_idjix7Ffn5qMxB21ZQuij
+
+AXNpZw==`))
+	c.Assert(err, IsNil)
+	brandPlugDecl := a.(*asserts.SnapDeclaration)
+
+	tests := []struct {
+		model *asserts.Model
+		err   string // "" => no error
+	}{
+		{nil, `auto-connection not allowed by slot rule of interface "brand-publisher-id"`},
+		{otherModel, `auto-connection not allowed by slot rule of interface "brand-publisher-id"`},
+		{myModel1, ""},
+		{myModel2, `auto-connection not allowed by slot rule of interface "brand-publisher-id"`},
+	}
+
+	for _, t := range tests {
+		cand := policy.ConnectCandidate{
+			Plug:                interfaces.NewConnectedPlug(brandPlugAppSet.Info().Plugs["brand-publisher-id"], brandPlugAppSet, nil, nil),
+			PlugSnapDeclaration: brandPlugDecl,
+			Slot:                interfaces.NewConnectedSlot(coreAppSet.Info().Slots["brand-publisher-id"], coreAppSet, nil, nil),
+			BaseDeclaration:     baseDecl,
+			Model:               t.model,
+		}
+		_, err := cand.CheckAutoConnect()
+		if t.err == "" {
+			c.Check(err, IsNil)
+		} else {
+			c.Check(err, ErrorMatches, t.err)
+		}
+	}
+}
+
 func (s *policySuite) TestDollarSlotPublisherIDCheckConnection(c *C) {
 	// no known publishers
 	cand := policy.ConnectCandidate{
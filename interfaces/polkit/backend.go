@@ -61,6 +61,12 @@ func (b *Backend) Name() interfaces.SecuritySystem {
 	return interfaces.SecurityPolkit
 }
 
+// CanSetupConcurrently returns true as the polkit policy and rule files are
+// specific to each snap.
+func (b *Backend) CanSetupConcurrently() bool {
+	return true
+}
+
 // Setup installs the polkit policy and rule files specific to a given snap.
 //
 // Polkit has no concept of a complain mode so confinment type is ignored.
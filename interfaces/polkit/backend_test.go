@@ -67,6 +67,12 @@ func (s *backendSuite) TestName(c *C) {
 	c.Check(s.Backend.Name(), Equals, interfaces.SecurityPolkit)
 }
 
+func (s *backendSuite) TestCanSetupConcurrently(c *C) {
+	backend, ok := s.Backend.(interfaces.SecurityBackendConcurrentSetup)
+	c.Assert(ok, Equals, true)
+	c.Check(backend.CanSetupConcurrently(), Equals, true)
+}
+
 func (s *backendSuite) TestInstallingSnapWritesPolicyFiles(c *C) {
 	// NOTE: Hand out a permanent policy so that .policy file is generated.
 	s.Iface.PolkitPermanentSlotCallback = func(spec *polkit.Specification, slot *snap.SlotInfo) error {
@@ -223,6 +229,32 @@ func (s *backendSuite) TestUnexpectedRuleFilesRemoved(c *C) {
 	}
 }
 
+func (s *backendSuite) TestConnectionRulesFollowConnection(c *C) {
+	s.Iface.PolkitConnectedPlugCallback = func(spec *polkit.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+		return spec.AddActionsRule("foo", plug.Snap().InstanceName(), []string{"org.example.foo"})
+	}
+	c.Assert(os.MkdirAll(dirs.SnapPolkitRuleDir, 0755), IsNil)
+
+	slotSnapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", ifacetest.SambaYamlV1, 0)
+	plugSnapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", ifacetest.HookYaml, 0)
+	appSet, err := interfaces.NewSnapAppSet(plugSnapInfo, nil)
+	c.Assert(err, IsNil)
+	rule := filepath.Join(dirs.SnapPolkitRuleDir, "70-snap.foo.foo.rules")
+	c.Check(rule, testutil.FileAbsent)
+
+	// the rule is written once the plug is connected
+	connRef := interfaces.NewConnRef(plugSnapInfo.Plugs["plug"], slotSnapInfo.Slots["slot"])
+	_, err = s.Repo.Connect(connRef, nil, nil, nil, nil, nil)
+	c.Assert(err, IsNil)
+	c.Assert(s.Backend.Setup(appSet, interfaces.ConfinementOptions{}, s.Repo, nil), IsNil)
+	c.Check(rule, testutil.FileContains, `var actions = ["org.example.foo"];`)
+
+	// and removed once it is disconnected
+	c.Assert(s.Repo.Disconnect("foo", "plug", "samba", "slot"), IsNil)
+	c.Assert(s.Backend.Setup(appSet, interfaces.ConfinementOptions{}, s.Repo, nil), IsNil)
+	c.Check(rule, testutil.FileAbsent)
+}
+
 func (s *backendSuite) TestSandboxFeatures(c *C) {
 	c.Assert(s.Backend.SandboxFeatures(), HasLen, 0)
 }
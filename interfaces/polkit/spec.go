@@ -21,7 +21,9 @@ package polkit
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"regexp"
 
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/polkit/validate"
@@ -77,6 +79,47 @@ func (spec *Specification) AddRule(nameSuffix string, content Rule) error {
 	return nil
 }
 
+// actionIDRegexp matches polkit action IDs, such as
+// "org.freedesktop.resolve1.set-dns-servers".
+var actionIDRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+)+$`)
+
+const actionsRuleTemplate = `// Allow the apps of snap %[1]q to perform the following actions
+// without authentication.
+polkit.addRule(function(action, subject) {
+    var actions = %[2]s;
+    if (actions.indexOf(action.id) >= 0 &&
+        subject.system_unit !== undefined &&
+        subject.system_unit.indexOf(%[3]q) === 0) {
+        return polkit.Result.YES;
+    }
+});
+`
+
+// AddActionsRule adds a polkit rule file granting the given actions to the
+// apps of the snap. This is meant for interfaces which need the apps to
+// perform privileged D-Bus actions guarded by polkit.
+//
+// The apps are identified by the systemd unit they run in, which is only
+// exposed to rules by polkit 124 and later, hence only the services of the
+// snap are granted the actions.
+func (spec *Specification) AddActionsRule(nameSuffix, snapInstanceName string, actionIDs []string) error {
+	if len(actionIDs) == 0 {
+		return fmt.Errorf("internal error: polkit rule %q grants no actions", nameSuffix)
+	}
+	for _, id := range actionIDs {
+		if !actionIDRegexp.MatchString(id) {
+			return fmt.Errorf("internal error: invalid polkit action ID %q", id)
+		}
+	}
+	// the IDs were validated, the JSON encoding is a valid JavaScript array
+	actions, err := json.Marshal(actionIDs)
+	if err != nil {
+		return err
+	}
+	unitPrefix := snap.SecurityTag(snapInstanceName) + "."
+	return spec.AddRule(nameSuffix, Rule(fmt.Sprintf(actionsRuleTemplate, snapInstanceName, actions, unitPrefix)))
+}
+
 // Rules returns a map of polkit rules added to the Specification.
 // This maps from rule name suffixes (without the ".rules" suffix)
 // to the content required to be installed.
@@ -120,3 +120,28 @@ func (s *specSuite) TestSpecificationIfaceAddRuleInvalidSuffix(c *C) {
 	c.Assert(s.spec.AddRule("?", polkit.Rule("content")), ErrorMatches, `"\?" does not match .*`)
 	c.Assert(s.spec.AddRule("..", polkit.Rule("content")), ErrorMatches, `"\.\." does not match .*`)
 }
+
+func (s *specSuite) TestSpecificationAddActionsRule(c *C) {
+	c.Assert(s.spec.AddActionsRule("test", "snap1_foo", []string{"org.example.foo", "org.example.bar-baz"}), IsNil)
+	c.Check(s.spec.Rules(), DeepEquals, map[string]polkit.Rule{
+		"test": polkit.Rule(`// Allow the apps of snap "snap1_foo" to perform the following actions
+// without authentication.
+polkit.addRule(function(action, subject) {
+    var actions = ["org.example.foo","org.example.bar-baz"];
+    if (actions.indexOf(action.id) >= 0 &&
+        subject.system_unit !== undefined &&
+        subject.system_unit.indexOf("snap.snap1_foo.") === 0) {
+        return polkit.Result.YES;
+    }
+});
+`),
+	})
+}
+
+func (s *specSuite) TestSpecificationAddActionsRuleErrors(c *C) {
+	c.Check(s.spec.AddActionsRule("test", "snap1", nil), ErrorMatches, `internal error: polkit rule "test" grants no actions`)
+	for _, id := range []string{"", "foo", `org.example.foo"]; evil(); ["`, "org..example", "org.example.*"} {
+		c.Check(s.spec.AddActionsRule("test", "snap1", []string{id}), ErrorMatches, `internal error: invalid polkit action ID .*`, Commentf("id %q", id))
+	}
+	c.Check(s.spec.Rules(), IsNil)
+}
@@ -52,6 +52,9 @@ type Repository struct {
 	// indexed by [ifaceName1][ifaceName2] indicates that interface "ifaceName1"
 	// cannot be connected if interface "ifaceName2" already has a connection
 	conflictingConnectedInterfaces map[string]map[string]bool
+	// indexed by [ifaceName1][ifaceName2] indicates that the connections of
+	// interface "ifaceName1" are removed before the ones of "ifaceName2"
+	disconnectAfter map[string]map[string]bool
 }
 
 // defaultIfaceDocURLTemplate is used as template for generating the default interface
@@ -69,6 +72,7 @@ func NewRepository() *Repository {
 		plugSlots:                      make(map[*snap.PlugInfo]map[*snap.SlotInfo]*Connection),
 		appSets:                        make(map[string]*SnapAppSet),
 		conflictingConnectedInterfaces: make(map[string]map[string]bool),
+		disconnectAfter:                make(map[string]map[string]bool),
 	}
 
 	return repo
@@ -84,6 +88,7 @@ func ResetRepository(repo *Repository) {
 	repo.plugSlots = make(map[*snap.PlugInfo]map[*snap.SlotInfo]*Connection)
 	repo.appSets = make(map[string]*SnapAppSet)
 	repo.conflictingConnectedInterfaces = map[string]map[string]bool{}
+	repo.disconnectAfter = map[string]map[string]bool{}
 }
 
 // Interface returns an interface with a given name.
@@ -94,6 +99,20 @@ func (r *Repository) Interface(interfaceName string) Interface {
 	return r.ifaces[interfaceName]
 }
 
+// InterfaceSecurityLevel returns the security level of the interface with the
+// given name, see SecurityLevelOf. The security level of unknown interfaces is
+// empty.
+func (r *Repository) InterfaceSecurityLevel(interfaceName string) SecurityLevel {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	iface, ok := r.ifaces[interfaceName]
+	if !ok {
+		return ""
+	}
+	return SecurityLevelOf(iface)
+}
+
 // AddInterface adds the provided interface to the repository.
 func (r *Repository) AddInterface(i Interface) error {
 	r.m.Lock()
@@ -135,9 +154,56 @@ func (r *Repository) AddInterface(i Interface) error {
 		}
 	}
 
+	if iface, ok := i.(DisconnectOrderDefiner); ok {
+		for _, otherInterfaceName := range iface.DisconnectAfter() {
+			if otherInterfaceName == interfaceName {
+				return fmt.Errorf("internal error: cannot define disconnection order for the %q interface with itself", interfaceName)
+			}
+			// the tasks of the disconnects wait on each other, a
+			// cycle would never complete
+			if r.disconnectsBefore(otherInterfaceName, interfaceName) {
+				return fmt.Errorf("internal error: cannot disconnect %q before %q, the opposite order was already defined", interfaceName, otherInterfaceName)
+			}
+			if r.disconnectAfter[interfaceName] == nil {
+				r.disconnectAfter[interfaceName] = make(map[string]bool)
+			}
+			r.disconnectAfter[interfaceName][otherInterfaceName] = true
+		}
+	}
+
 	return nil
 }
 
+// DisconnectsBefore returns whether the connections of the given interface are
+// removed before the ones of the other interface when disconnecting all the
+// connections of a snap, as defined directly or transitively by interfaces
+// implementing DisconnectOrderDefiner.
+func (r *Repository) DisconnectsBefore(interfaceName, otherInterfaceName string) bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	return r.disconnectsBefore(interfaceName, otherInterfaceName)
+}
+
+func (r *Repository) disconnectsBefore(interfaceName, otherInterfaceName string) bool {
+	seen := make(map[string]bool)
+	pending := []string{interfaceName}
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		for after := range r.disconnectAfter[name] {
+			if after == otherInterfaceName {
+				return true
+			}
+			if !seen[after] {
+				seen[after] = true
+				pending = append(pending, after)
+			}
+		}
+	}
+	return false
+}
+
 // AllInterfaces returns all the interfaces added to the repository, ordered by name.
 func (r *Repository) AllInterfaces() []Interface {
 	r.m.Lock()
@@ -193,6 +259,15 @@ func (r *Repository) interfaceInfo(iface Interface, opts *InfoOptions) *Info {
 		} else {
 			ii.DocURL = fmt.Sprintf(defaultIfaceDocURLTemplate, ifaceName)
 		}
+		ii.SecurityNotes = si.SecurityNotes
+		ii.SecurityLevel = SecurityLevelOf(iface)
+		ii.Example = si.Example
+		if withSchema, ok := iface.(PlugAttributeSchema); ok {
+			ii.PlugAttrs = withSchema.AttributeSchema()
+		}
+		if withSchema, ok := iface.(SlotAttributeSchema); ok {
+			ii.SlotAttrs = withSchema.SlotAttributeSchema()
+		}
 	}
 	if opts != nil && opts.Plugs {
 		// Collect all plugs of this interface type.
@@ -541,6 +616,36 @@ type plugValidator interface {
 
 type PolicyFunc func(*ConnectedPlug, *ConnectedSlot) (bool, error)
 
+// checkPinConflicts ensures that connecting the given plug and slot does not
+// make the plug snap use the same pin of the slot snap through two different
+// slots.
+func (r *Repository) checkPinConflicts(iface Interface, plug *snap.PlugInfo, slot *snap.SlotInfo) error {
+	pinUser, ok := iface.(PinUser)
+	if !ok {
+		return nil
+	}
+	pin, ok := pinUser.SlotPin(slot)
+	if !ok {
+		return nil
+	}
+	for _, otherPlug := range r.plugs[plug.Snap.InstanceName()] {
+		for otherSlot := range r.plugSlots[otherPlug] {
+			if otherSlot == slot || otherSlot.Snap.InstanceName() != slot.Snap.InstanceName() {
+				continue
+			}
+			otherPinUser, ok := r.ifaces[otherSlot.Interface].(PinUser)
+			if !ok {
+				continue
+			}
+			if otherPin, ok := otherPinUser.SlotPin(otherSlot); ok && otherPin == pin {
+				return fmt.Errorf("cannot connect plug %q of snap %q to slot %q of snap %q: pin %d is already used by connected slot %q (interface %q)",
+					plug.Name, plug.Snap.InstanceName(), slot.Name, slot.Snap.InstanceName(), pin, otherSlot.Name, otherSlot.Interface)
+			}
+		}
+	}
+	return nil
+}
+
 // Connect establishes a connection between a plug and a slot.
 // The plug and the slot must have the same interface.
 // When connections are reloaded policyCheck is null (we don't check policy again).
@@ -548,6 +653,52 @@ func (r *Repository) Connect(ref *ConnRef, plugStaticAttrs, plugDynamicAttrs, sl
 	r.m.Lock()
 	defer r.m.Unlock()
 
+	// connections are only sanitized when they are not reloaded
+	conn, err := r.newConnection(ref, plugStaticAttrs, plugDynamicAttrs, slotStaticAttrs, slotDynamicAttrs, policyCheck != nil)
+	if err != nil {
+		return nil, err
+	}
+	cplug, cslot := conn.Plug, conn.Slot
+	plug, slot := cplug.plugInfo, cslot.slotInfo
+
+	// policyCheck is null when reloading connections
+	if policyCheck != nil {
+		// autoconnect policy checker returns false to indicate disallowed auto-connection, but it's not an error.
+		ok, err := policyCheck(cplug, cslot)
+		if err != nil || !ok {
+			return nil, err
+		}
+	}
+
+	// Connect the plug
+	if r.slotPlugs[slot] == nil {
+		r.slotPlugs[slot] = make(map[*snap.PlugInfo]*Connection)
+	}
+	if r.plugSlots[plug] == nil {
+		r.plugSlots[plug] = make(map[*snap.SlotInfo]*Connection)
+	}
+
+	r.slotPlugs[slot][plug] = conn
+	r.plugSlots[plug][slot] = conn
+	logger.Trace("interface-connection", "interface", slot.Interface, "slot", slot.Snap.SnapType, "plug", plug.Snap.SnapType)
+	return conn, nil
+}
+
+// PreviewConnect returns the connection between a plug and a slot which
+// Connect would establish with the given dynamic attributes, checked by the
+// interface in the same way, without establishing it. The policy is not
+// checked.
+func (r *Repository) PreviewConnect(ref *ConnRef, plugDynamicAttrs, slotDynamicAttrs map[string]any) (*Connection, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	return r.newConnection(ref, nil, plugDynamicAttrs, nil, slotDynamicAttrs, true)
+}
+
+// newConnection returns the connection between the given plug and slot after
+// checking that they can be connected, and when sanitize is set after
+// checking the connection with the interface.
+func (r *Repository) newConnection(ref *ConnRef, plugStaticAttrs, plugDynamicAttrs, slotStaticAttrs, slotDynamicAttrs map[string]any, sanitize bool) (*Connection, error) {
 	plugSnapName := ref.PlugRef.Snap
 	plugName := ref.PlugRef.Name
 	slotSnapName := ref.SlotRef.Snap
@@ -587,6 +738,15 @@ func (r *Repository) Connect(ref *ConnRef, plugStaticAttrs, plugDynamicAttrs, sl
 		}
 	}
 
+	if err := r.checkPinConflicts(iface, plug, slot); err != nil {
+		return nil, err
+	}
+
+	if StaticInfoOf(iface).ProviderOnly {
+		return nil, fmt.Errorf("cannot connect plug %q of snap %q: interface %q is provider-only",
+			plugName, plugSnapName, iface.Name())
+	}
+
 	plugAppSet := r.appSets[plugSnapName]
 	if plugAppSet == nil {
 		return nil, fmt.Errorf("internal error: no app set for plug snap %q", plugSnapName)
@@ -600,8 +760,7 @@ func (r *Repository) Connect(ref *ConnRef, plugStaticAttrs, plugDynamicAttrs, sl
 	cplug := NewConnectedPlug(plug, plugAppSet, plugStaticAttrs, plugDynamicAttrs)
 	cslot := NewConnectedSlot(slot, slotAppSet, slotStaticAttrs, slotDynamicAttrs)
 
-	// policyCheck is null when reloading connections
-	if policyCheck != nil {
+	if sanitize {
 		if i, ok := iface.(plugValidator); ok {
 			if err := i.BeforeConnectPlug(cplug); err != nil {
 				return nil, fmt.Errorf("cannot connect plug %q of snap %q: %s", plug.Name, plug.Snap.InstanceName(), err)
@@ -612,26 +771,76 @@ func (r *Repository) Connect(ref *ConnRef, plugStaticAttrs, plugDynamicAttrs, sl
 				return nil, fmt.Errorf("cannot connect slot %q of snap %q: %s", slot.Name, slot.Snap.InstanceName(), err)
 			}
 		}
-
-		// autoconnect policy checker returns false to indicate disallowed auto-connection, but it's not an error.
-		ok, err := policyCheck(cplug, cslot)
-		if err != nil || !ok {
-			return nil, err
+		if i, ok := iface.(ConnSanitizer); ok {
+			if err := i.BeforeConnect(cplug, cslot); err != nil {
+				return nil, fmt.Errorf("cannot connect plug %q of snap %q to slot %q of snap %q: %s",
+					plug.Name, plug.Snap.InstanceName(), slot.Name, slot.Snap.InstanceName(), err)
+			}
 		}
 	}
+	return &Connection{Plug: cplug, Slot: cslot}, nil
+}
 
-	// Connect the plug
-	if r.slotPlugs[slot] == nil {
-		r.slotPlugs[slot] = make(map[*snap.PlugInfo]*Connection)
+// UpdateConnectionAttrs replaces the dynamic attributes of both sides of an
+// established connection. The static attributes are kept. The connection is
+// checked again by the interface and by policyCheck, when not null, and left
+// unchanged when any of them fails.
+func (r *Repository) UpdateConnectionAttrs(ref *ConnRef, plugDynamicAttrs, slotDynamicAttrs map[string]any, policyCheck PolicyFunc) (*Connection, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	plug := r.plugs[ref.PlugRef.Snap][ref.PlugRef.Name]
+	slot := r.slots[ref.SlotRef.Snap][ref.SlotRef.Name]
+	if plug == nil || slot == nil {
+		return nil, &NoPlugOrSlotError{
+			message: fmt.Sprintf("cannot update connection %s:%s %s:%s: no such plug or slot",
+				ref.PlugRef.Snap, ref.PlugRef.Name, ref.SlotRef.Snap, ref.SlotRef.Name)}
 	}
-	if r.plugSlots[plug] == nil {
-		r.plugSlots[plug] = make(map[*snap.SlotInfo]*Connection)
+	old, ok := r.slotPlugs[slot][plug]
+	if !ok {
+		return nil, &NotConnectedError{
+			message: fmt.Sprintf("cannot update connection %s:%s %s:%s: not connected",
+				ref.PlugRef.Snap, ref.PlugRef.Name, ref.SlotRef.Snap, ref.SlotRef.Name)}
+	}
+
+	iface, ok := r.ifaces[plug.Interface]
+	if !ok {
+		return nil, fmt.Errorf("internal error: unknown interface %q", plug.Interface)
+	}
+
+	cplug := NewConnectedPlug(plug, old.Plug.AppSet(), old.Plug.StaticAttrs(), plugDynamicAttrs)
+	cslot := NewConnectedSlot(slot, old.Slot.AppSet(), old.Slot.StaticAttrs(), slotDynamicAttrs)
+
+	if policyCheck != nil {
+		if i, ok := iface.(plugValidator); ok {
+			if err := i.BeforeConnectPlug(cplug); err != nil {
+				return nil, fmt.Errorf("cannot update plug %q of snap %q: %s", plug.Name, plug.Snap.InstanceName(), err)
+			}
+		}
+		if i, ok := iface.(slotValidator); ok {
+			if err := i.BeforeConnectSlot(cslot); err != nil {
+				return nil, fmt.Errorf("cannot update slot %q of snap %q: %s", slot.Name, slot.Snap.InstanceName(), err)
+			}
+		}
+		if i, ok := iface.(ConnSanitizer); ok {
+			if err := i.BeforeConnect(cplug, cslot); err != nil {
+				return nil, fmt.Errorf("cannot update connection of plug %q of snap %q to slot %q of snap %q: %s",
+					plug.Name, plug.Snap.InstanceName(), slot.Name, slot.Snap.InstanceName(), err)
+			}
+		}
+		ok, err := policyCheck(cplug, cslot)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot update connection of plug %q of snap %q to slot %q of snap %q: not allowed",
+				plug.Name, plug.Snap.InstanceName(), slot.Name, slot.Snap.InstanceName())
+		}
 	}
 
 	conn := &Connection{Plug: cplug, Slot: cslot}
 	r.slotPlugs[slot][plug] = conn
 	r.plugSlots[plug][slot] = conn
-	logger.Trace("interface-connection", "interface", slot.Interface, "slot", slot.Snap.SnapType, "plug", plug.Snap.SnapType)
 	return conn, nil
 }
 
@@ -931,17 +1140,9 @@ func (r *Repository) SnapSpecification(securitySystem SecuritySystem, appSet *Sn
 	r.m.Lock()
 	defer r.m.Unlock()
 
-	var backend SecurityBackend
-	for _, b := range r.backends {
-		if b.Name() == securitySystem {
-			backend = b
-			break
-		}
-	}
-
-	snapName := appSet.InstanceName()
-	if backend == nil {
-		return nil, fmt.Errorf("cannot handle interfaces of snap %q, security system %q is not known", snapName, securitySystem)
+	backend, err := r.backendFor(securitySystem, appSet.InstanceName())
+	if err != nil {
+		return nil, err
 	}
 
 	spec := backend.NewSpecification(appSet, opts)
@@ -961,31 +1162,96 @@ func (r *Repository) SnapSpecification(securitySystem SecuritySystem, appSet *Sn
 	// if the error is transient so we also don't want to infinitely loop trying
 	// to add a connected plug that will never work.
 
+	err = r.addSnapSpecification(appSet.InstanceName(), func(*SpecificationPart) Specification {
+		return spec
+	})
+	if err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// SpecificationPart is the part of the specification of a snap contributed
+// by a single plug or slot, or by a single connection when both Plug and
+// Slot are set.
+type SpecificationPart struct {
+	Interface string
+	Plug      *PlugRef
+	Slot      *SlotRef
+	Spec      Specification
+}
+
+// SnapSpecificationParts returns the specification of a given snap in a
+// given security system broken down by the plugs, slots and connections
+// contributing to it, in the order in which SnapSpecification considers
+// them. Parts which do not contribute anything are included as well.
+func (r *Repository) SnapSpecificationParts(securitySystem SecuritySystem, appSet *SnapAppSet, opts ConfinementOptions) ([]*SpecificationPart, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	backend, err := r.backendFor(securitySystem, appSet.InstanceName())
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []*SpecificationPart
+	err = r.addSnapSpecification(appSet.InstanceName(), func(part *SpecificationPart) Specification {
+		part.Spec = backend.NewSpecification(appSet, opts)
+		parts = append(parts, part)
+		return part.Spec
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+func (r *Repository) backendFor(securitySystem SecuritySystem, snapName string) (SecurityBackend, error) {
+	for _, b := range r.backends {
+		if b.Name() == securitySystem {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot handle interfaces of snap %q, security system %q is not known", snapName, securitySystem)
+}
+
+// addSnapSpecification adds the side-effects of the plugs, slots and
+// connections of the given snap to the specifications returned by specFor,
+// which is called with the description of each contributing part.
+func (r *Repository) addSnapSpecification(snapName string, specFor func(part *SpecificationPart) Specification) error {
 	// slot side
 	for _, slotInfo := range r.slots[snapName] {
 		iface := r.ifaces[slotInfo.Interface]
+		slotRef := &SlotRef{Snap: slotInfo.Snap.InstanceName(), Name: slotInfo.Name}
+		spec := specFor(&SpecificationPart{Interface: iface.Name(), Slot: slotRef})
 		if err := spec.AddPermanentSlot(iface, slotInfo); err != nil {
-			return nil, err
+			return err
 		}
 		for _, conn := range r.slotPlugs[slotInfo] {
+			plugRef := &PlugRef{Snap: conn.Plug.Snap().InstanceName(), Name: conn.Plug.Name()}
+			spec := specFor(&SpecificationPart{Interface: iface.Name(), Plug: plugRef, Slot: slotRef})
 			if err := spec.AddConnectedSlot(iface, conn.Plug, conn.Slot); err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
 	// plug side
 	for _, plugInfo := range r.plugs[snapName] {
 		iface := r.ifaces[plugInfo.Interface]
+		plugRef := &PlugRef{Snap: plugInfo.Snap.InstanceName(), Name: plugInfo.Name}
+		spec := specFor(&SpecificationPart{Interface: iface.Name(), Plug: plugRef})
 		if err := spec.AddPermanentPlug(iface, plugInfo); err != nil {
-			return nil, err
+			return err
 		}
 		for _, conn := range r.plugSlots[plugInfo] {
+			slotRef := &SlotRef{Snap: conn.Slot.Snap().InstanceName(), Name: conn.Slot.Name()}
+			spec := specFor(&SpecificationPart{Interface: iface.Name(), Plug: plugRef, Slot: slotRef})
 			if err := spec.AddConnectedPlug(iface, conn.Plug, conn.Slot); err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
-	return spec, nil
+	return nil
 }
 
 // AddAppSet adds plugs and slots declared by the given app set (snap +
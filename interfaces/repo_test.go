@@ -220,6 +220,54 @@ func (s *RepositorySuite) TestAddInterfaceConflictingConnectedInterfacesErrors(c
 	c.Check(s.testRepo.AddInterface(iface4), ErrorMatches, `internal error: cannot define mutually exclusive connection relation for the "interface-4" interface with itself`)
 }
 
+func (s *RepositorySuite) TestAddInterfaceDisconnectOrderErrors(c *C) {
+	iface1 := &ifacetest.TestDisconnectOrderInterface{
+		TestInterface:             ifacetest.TestInterface{InterfaceName: "interface-1"},
+		DisconnectAfterInterfaces: []string{"interface-2"},
+	}
+	iface2 := &ifacetest.TestDisconnectOrderInterface{
+		TestInterface:             ifacetest.TestInterface{InterfaceName: "interface-2"},
+		DisconnectAfterInterfaces: []string{"interface-3"},
+	}
+	iface3 := &ifacetest.TestDisconnectOrderInterface{
+		TestInterface:             ifacetest.TestInterface{InterfaceName: "interface-3"},
+		DisconnectAfterInterfaces: []string{"interface-1"},
+	}
+	iface4 := &ifacetest.TestDisconnectOrderInterface{
+		TestInterface:             ifacetest.TestInterface{InterfaceName: "interface-4"},
+		DisconnectAfterInterfaces: []string{"interface-4"},
+	}
+
+	c.Check(s.testRepo.AddInterface(iface1), IsNil)
+	c.Check(s.testRepo.AddInterface(iface2), IsNil)
+	c.Check(s.testRepo.AddInterface(iface3), ErrorMatches, `internal error: cannot disconnect "interface-3" before "interface-1", the opposite order was already defined`)
+	c.Check(s.testRepo.AddInterface(iface4), ErrorMatches, `internal error: cannot define disconnection order for the "interface-4" interface with itself`)
+}
+
+func (s *RepositorySuite) TestDisconnectsBefore(c *C) {
+	c.Assert(s.emptyRepo.AddInterface(&ifacetest.TestDisconnectOrderInterface{
+		TestInterface:             ifacetest.TestInterface{InterfaceName: "interface-1"},
+		DisconnectAfterInterfaces: []string{"interface-2"},
+	}), IsNil)
+	c.Assert(s.emptyRepo.AddInterface(&ifacetest.TestDisconnectOrderInterface{
+		TestInterface:             ifacetest.TestInterface{InterfaceName: "interface-2"},
+		DisconnectAfterInterfaces: []string{"interface-3"},
+	}), IsNil)
+	c.Assert(s.emptyRepo.AddInterface(&ifacetest.TestInterface{InterfaceName: "interface-3"}), IsNil)
+	c.Assert(s.emptyRepo.AddInterface(&ifacetest.TestInterface{InterfaceName: "interface-4"}), IsNil)
+
+	c.Check(s.emptyRepo.DisconnectsBefore("interface-1", "interface-2"), Equals, true)
+	c.Check(s.emptyRepo.DisconnectsBefore("interface-2", "interface-3"), Equals, true)
+	// the order is transitive
+	c.Check(s.emptyRepo.DisconnectsBefore("interface-1", "interface-3"), Equals, true)
+
+	c.Check(s.emptyRepo.DisconnectsBefore("interface-2", "interface-1"), Equals, false)
+	c.Check(s.emptyRepo.DisconnectsBefore("interface-3", "interface-1"), Equals, false)
+	c.Check(s.emptyRepo.DisconnectsBefore("interface-1", "interface-4"), Equals, false)
+	c.Check(s.emptyRepo.DisconnectsBefore("interface-4", "interface-1"), Equals, false)
+	c.Check(s.emptyRepo.DisconnectsBefore("interface-1", "interface-1"), Equals, false)
+}
+
 // Tests for Repository.AllInterfaces()
 
 func (s *RepositorySuite) TestAllInterfaces(c *C) {
@@ -269,6 +317,24 @@ func (s *RepositorySuite) TestInterface(c *C) {
 	c.Assert(iface, Equals, s.iface)
 }
 
+func (s *RepositorySuite) TestInterfaceSecurityLevel(c *C) {
+	privileged := &ifacetest.TestInterface{
+		InterfaceName: "privileged",
+		InterfaceStaticInfo: StaticInfo{
+			BaseDeclarationPlugs: `
+  privileged:
+    allow-installation: false
+`,
+		},
+	}
+	c.Assert(s.emptyRepo.AddInterface(s.iface), IsNil)
+	c.Assert(s.emptyRepo.AddInterface(privileged), IsNil)
+
+	c.Check(s.emptyRepo.InterfaceSecurityLevel(s.iface.Name()), Equals, SecurityLevelLow)
+	c.Check(s.emptyRepo.InterfaceSecurityLevel("privileged"), Equals, SecurityLevelHigh)
+	c.Check(s.emptyRepo.InterfaceSecurityLevel("unknown"), Equals, SecurityLevel(""))
+}
+
 func (s *RepositorySuite) TestInterfaceSearch(c *C) {
 	ifaceA := &ifacetest.TestInterface{InterfaceName: "a"}
 	ifaceB := &ifacetest.TestInterface{InterfaceName: "b"}
@@ -1048,6 +1114,30 @@ func (s *RepositorySuite) TestConnectFailsWhenSlotAndPlugAreIncompatible(c *C) {
 	c.Assert(err, ErrorMatches, `cannot connect plug "consumer:plug" \(interface "other-interface"\) to "producer:slot" \(interface "interface"\)`)
 }
 
+func (s *RepositorySuite) TestConnectFailsForProviderOnlyInterface(c *C) {
+	providerOnly := &ifacetest.TestInterface{
+		InterfaceName:       "provider-only",
+		InterfaceStaticInfo: StaticInfo{ProviderOnly: true},
+	}
+	err := s.testRepo.AddInterface(providerOnly)
+	c.Assert(err, IsNil)
+
+	// sanitization is mocked away so the plug is not dropped
+	consumer := buildAppSetWithPlugsAndSlots(c, "consumer", []*snap.PlugInfo{
+		{Name: "plug", Interface: "provider-only"},
+	}, nil)
+	c.Assert(s.testRepo.AddAppSet(consumer), IsNil)
+	producer := buildAppSetWithPlugsAndSlots(c, "producer", nil, []*snap.SlotInfo{
+		{Name: "slot", Interface: "provider-only"},
+	})
+	c.Assert(s.testRepo.AddAppSet(producer), IsNil)
+
+	connRef := NewConnRef(consumer.Info().Plugs["plug"], producer.Info().Slots["slot"])
+	_, err = s.testRepo.Connect(connRef, nil, nil, nil, nil, nil)
+	c.Assert(err, ErrorMatches, `cannot connect plug "plug" of snap "consumer": interface "provider-only" is provider-only`)
+	c.Check(s.testRepo.Interfaces().Connections, HasLen, 0)
+}
+
 func (s *RepositorySuite) TestConnectFailsForConflictingInterfaceConnections(c *C) {
 	conflictingInterface := &ifacetest.TestConflictingConnectionInterface{
 		TestInterface:                  ifacetest.TestInterface{InterfaceName: "conflicting-interface"},
@@ -1110,6 +1200,48 @@ func (s *RepositorySuite) TestConnectFailsForConflictingInterfaceConnections(c *
 	c.Assert(err, ErrorMatches, `interface "interface" and "conflicting-interface" cannot be connected at the same time: "conflicting-interface" is already connected`)
 }
 
+func (s *RepositorySuite) TestConnectFailsForSlotsSharingPin(c *C) {
+	err := s.testRepo.AddInterface(&ifacetest.TestPinUserInterface{
+		TestInterface: ifacetest.TestInterface{InterfaceName: "pin-1"},
+	})
+	c.Assert(err, IsNil)
+	err = s.testRepo.AddInterface(&ifacetest.TestPinUserInterface{
+		TestInterface: ifacetest.TestInterface{InterfaceName: "pin-2"},
+	})
+	c.Assert(err, IsNil)
+
+	consumer := buildAppSetWithPlugsAndSlots(c, "pin-consumer", []*snap.PlugInfo{
+		{Name: "plug-1", Interface: "pin-1"},
+		{Name: "plug-2", Interface: "pin-2"},
+	}, nil)
+	otherConsumer := buildAppSetWithPlugsAndSlots(c, "other-pin-consumer", []*snap.PlugInfo{
+		{Name: "plug-2", Interface: "pin-2"},
+	}, nil)
+	producer := buildAppSetWithPlugsAndSlots(c, "pin-producer", nil, []*snap.SlotInfo{
+		{Name: "slot-1", Interface: "pin-1", Attrs: map[string]any{"pin": int64(7)}},
+		{Name: "slot-2", Interface: "pin-2", Attrs: map[string]any{"pin": int64(7)}},
+		{Name: "slot-3", Interface: "pin-2", Attrs: map[string]any{"pin": int64(8)}},
+	})
+	c.Assert(s.testRepo.AddAppSet(consumer), IsNil)
+	c.Assert(s.testRepo.AddAppSet(otherConsumer), IsNil)
+	c.Assert(s.testRepo.AddAppSet(producer), IsNil)
+
+	connect := func(appSet *SnapAppSet, plug, slot string) error {
+		ref := NewConnRef(appSet.Info().Plugs[plug], producer.Info().Slots[slot])
+		_, err := s.testRepo.Connect(ref, nil, nil, nil, nil, nil)
+		return err
+	}
+
+	c.Assert(connect(consumer, "plug-1", "slot-1"), IsNil)
+	// the same pin cannot be used by the same snap through another slot
+	c.Assert(connect(consumer, "plug-2", "slot-2"), ErrorMatches,
+		`cannot connect plug "plug-2" of snap "pin-consumer" to slot "slot-2" of snap "pin-producer": pin 7 is already used by connected slot "slot-1" \(interface "pin-1"\)`)
+	// but a different pin can
+	c.Assert(connect(consumer, "plug-2", "slot-3"), IsNil)
+	// and other snaps are not affected
+	c.Assert(connect(otherConsumer, "plug-2", "slot-2"), IsNil)
+}
+
 func (s *RepositorySuite) TestConnectSucceeds(c *C) {
 	err := s.testRepo.AddAppSet(s.consumer)
 	c.Assert(err, IsNil)
@@ -1393,6 +1525,57 @@ func (s *RepositorySuite) TestSnapSpecification(c *C) {
 	})
 }
 
+func (s *RepositorySuite) TestSnapSpecificationParts(c *C) {
+	repo := s.emptyRepo
+	backend := &ifacetest.TestSecurityBackend{BackendName: testSecurity}
+	c.Assert(repo.AddBackend(backend), IsNil)
+	c.Assert(repo.AddInterface(testInterface), IsNil)
+	c.Assert(repo.AddAppSet(s.consumer), IsNil)
+	c.Assert(repo.AddAppSet(s.producer), IsNil)
+	connRef := NewConnRef(s.consumerPlug, s.producerSlot)
+	_, err := repo.Connect(connRef, nil, nil, nil, nil, nil)
+	c.Assert(err, IsNil)
+
+	emptyOpts := interfaces.ConfinementOptions{}
+
+	type part struct {
+		plug     *PlugRef
+		slot     *SlotRef
+		snippets []string
+	}
+	check := func(parts []*SpecificationPart, expected []part) {
+		c.Assert(parts, HasLen, len(expected))
+		for i, p := range parts {
+			c.Check(p.Interface, Equals, "interface")
+			c.Check(p.Plug, DeepEquals, expected[i].plug)
+			c.Check(p.Slot, DeepEquals, expected[i].slot)
+			c.Check(p.Spec.(*ifacetest.Specification).Snippets, DeepEquals, expected[i].snippets)
+		}
+	}
+
+	consumerPlug := &PlugRef{Snap: "consumer", Name: "plug"}
+	producerSlot := &SlotRef{Snap: "producer", Name: "slot"}
+	producerPlug := &PlugRef{Snap: "producer", Name: "self"}
+
+	parts, err := repo.SnapSpecificationParts(testSecurity, s.consumer, emptyOpts)
+	c.Assert(err, IsNil)
+	check(parts, []part{
+		{plug: consumerPlug, snippets: []string{"static plug snippet"}},
+		{plug: consumerPlug, slot: producerSlot, snippets: []string{"connection-specific plug snippet"}},
+	})
+
+	parts, err = repo.SnapSpecificationParts(testSecurity, s.producer, emptyOpts)
+	c.Assert(err, IsNil)
+	check(parts, []part{
+		{slot: producerSlot, snippets: []string{"static slot snippet"}},
+		{plug: consumerPlug, slot: producerSlot, snippets: []string{"connection-specific slot snippet"}},
+		{plug: producerPlug, snippets: []string{"static plug snippet"}},
+	})
+
+	_, err = repo.SnapSpecificationParts("unknown", s.producer, emptyOpts)
+	c.Assert(err, ErrorMatches, `cannot handle interfaces of snap "producer", security system "unknown" is not known`)
+}
+
 func (s *RepositorySuite) TestSnapSpecificationFailureWithConnectionSnippets(c *C) {
 	var testSecurity SecuritySystem = "security"
 	backend := &ifacetest.TestSecurityBackend{BackendName: testSecurity}
@@ -1991,7 +2174,7 @@ func (s *RepositorySuite) TestInfo(c *C) {
 
 	// Add some test interfaces.
 	i1 := &ifacetest.TestInterface{InterfaceName: "i1", InterfaceStaticInfo: StaticInfo{Summary: "i1 summary", DocURL: "http://example.com/i1"}}
-	i2 := &ifacetest.TestInterface{InterfaceName: "i2", InterfaceStaticInfo: StaticInfo{Summary: "i2 summary", DocURL: "http://example.com/i2"}}
+	i2 := &ifacetest.TestInterface{InterfaceName: "i2", InterfaceStaticInfo: StaticInfo{Summary: "i2 summary", DocURL: "http://example.com/i2", SecurityNotes: []string{"i2 note"}}}
 	i3 := &ifacetest.TestInterface{InterfaceName: "i3", InterfaceStaticInfo: StaticInfo{Summary: "i3 summary", DocURL: ""}}
 	c.Assert(r.AddInterface(i1), IsNil)
 	c.Assert(r.AddInterface(i2), IsNil)
@@ -2066,11 +2249,11 @@ apps:
 		{Name: "i2", Summary: "i2 summary"},
 	})
 
-	// We can ask for documentation.
+	// We can ask for documentation, including the security notes.
 	infos = r.Info(&InfoOptions{Names: []string{"i2", "i3"}, Doc: true})
 	c.Assert(infos, DeepEquals, []*Info{
-		{Name: "i2", Summary: "i2 summary", DocURL: "http://example.com/i2"},
-		{Name: "i3", Summary: "i3 summary", DocURL: "https://snapcraft.io/docs/i3-interface"},
+		{Name: "i2", Summary: "i2 summary", DocURL: "http://example.com/i2", SecurityNotes: []string{"i2 note"}, SecurityLevel: SecurityLevelLow},
+		{Name: "i3", Summary: "i3 summary", DocURL: "https://snapcraft.io/docs/i3-interface", SecurityLevel: SecurityLevelLow},
 	})
 
 	// We can ask for a list of plugs.
@@ -2181,6 +2364,133 @@ func (s *RepositorySuite) TestBeforeConnectValidationFailure(c *C) {
 	c.Assert(conn, IsNil)
 }
 
+func (s *RepositorySuite) TestPreviewConnect(c *C) {
+	err := s.emptyRepo.AddInterface(&ifacetest.TestInterface{
+		InterfaceName: "iface2",
+		BeforeConnectPlugCallback: func(plug *ConnectedPlug) error {
+			var val string
+			if err := plug.Attr("attr1", &val); err != nil {
+				return err
+			}
+			if val == "invalid" {
+				return fmt.Errorf("invalid plug")
+			}
+			return plug.SetAttr("attr1", fmt.Sprintf("%s-validated", val))
+		},
+	})
+	c.Assert(err, IsNil)
+
+	s1 := ifacetest.MockInfoAndAppSet(c, ifacehooksSnap1, nil, nil)
+	c.Assert(s.emptyRepo.AddAppSet(s1), IsNil)
+	s2 := ifacetest.MockInfoAndAppSet(c, ifacehooksSnap2, nil, nil)
+	c.Assert(s.emptyRepo.AddAppSet(s2), IsNil)
+
+	connRef := &ConnRef{PlugRef: PlugRef{Snap: "s1", Name: "consumer"}, SlotRef: SlotRef{Snap: "s2", Name: "producer"}}
+	conn, err := s.emptyRepo.PreviewConnect(connRef, map[string]any{"attr1": "val1"}, nil)
+	c.Assert(err, IsNil)
+	c.Check(conn.Plug.StaticAttrs(), DeepEquals, map[string]any{"attr0": "val0"})
+	c.Check(conn.Plug.DynamicAttrs(), DeepEquals, map[string]any{"attr1": "val1-validated"})
+	c.Check(conn.Slot.Name(), Equals, "producer")
+
+	// the connection is not established
+	_, err = s.emptyRepo.Connection(connRef)
+	c.Check(err, FitsTypeOf, &NotConnectedError{})
+
+	_, err = s.emptyRepo.PreviewConnect(connRef, map[string]any{"attr1": "invalid"}, nil)
+	c.Check(err, ErrorMatches, `cannot connect plug "consumer" of snap "s1": invalid plug`)
+
+	connRef.SlotRef.Name = "missing"
+	_, err = s.emptyRepo.PreviewConnect(connRef, nil, nil)
+	c.Check(err, ErrorMatches, `cannot connect slot "missing" from snap "s2": no such slot`)
+}
+
+func (s *RepositorySuite) TestUpdateConnectionAttrs(c *C) {
+	err := s.emptyRepo.AddInterface(&ifacetest.TestInterface{
+		InterfaceName: "iface2",
+		BeforeConnectPlugCallback: func(plug *ConnectedPlug) error {
+			var val string
+			if err := plug.Attr("attr1", &val); err != nil {
+				return err
+			}
+			if val == "invalid" {
+				return fmt.Errorf("invalid plug")
+			}
+			return nil
+		},
+	})
+	c.Assert(err, IsNil)
+
+	s1 := ifacetest.MockInfoAndAppSet(c, ifacehooksSnap1, nil, nil)
+	c.Assert(s.emptyRepo.AddAppSet(s1), IsNil)
+	s2 := ifacetest.MockInfoAndAppSet(c, ifacehooksSnap2, nil, nil)
+	c.Assert(s.emptyRepo.AddAppSet(s2), IsNil)
+
+	connRef := &ConnRef{PlugRef: PlugRef{Snap: "s1", Name: "consumer"}, SlotRef: SlotRef{Snap: "s2", Name: "producer"}}
+	policyCheck := func(plug *ConnectedPlug, slot *ConnectedSlot) (bool, error) { return true, nil }
+
+	// not connected yet
+	_, err = s.emptyRepo.UpdateConnectionAttrs(connRef, nil, nil, policyCheck)
+	c.Assert(err, ErrorMatches, `cannot update connection s1:consumer s2:producer: not connected`)
+	c.Check(err, FitsTypeOf, &NotConnectedError{})
+
+	_, err = s.emptyRepo.Connect(connRef, nil, map[string]any{"attr1": "val1"}, nil, map[string]any{"attr1": "val1"}, policyCheck)
+	c.Assert(err, IsNil)
+
+	conn, err := s.emptyRepo.UpdateConnectionAttrs(connRef, map[string]any{"attr1": "val2"}, map[string]any{"attr1": "val3"}, policyCheck)
+	c.Assert(err, IsNil)
+	c.Check(conn.Plug.StaticAttrs(), DeepEquals, map[string]any{"attr0": "val0"})
+	c.Check(conn.Plug.DynamicAttrs(), DeepEquals, map[string]any{"attr1": "val2"})
+	c.Check(conn.Slot.StaticAttrs(), DeepEquals, map[string]any{"attr0": "val0"})
+	c.Check(conn.Slot.DynamicAttrs(), DeepEquals, map[string]any{"attr1": "val3"})
+
+	current, err := s.emptyRepo.Connection(connRef)
+	c.Assert(err, IsNil)
+	c.Check(current, Equals, conn)
+
+	// the connection is left unchanged on validation failures
+	_, err = s.emptyRepo.UpdateConnectionAttrs(connRef, map[string]any{"attr1": "invalid"}, nil, policyCheck)
+	c.Assert(err, ErrorMatches, `cannot update plug "consumer" of snap "s1": invalid plug`)
+	refused := func(plug *ConnectedPlug, slot *ConnectedSlot) (bool, error) { return false, nil }
+	_, err = s.emptyRepo.UpdateConnectionAttrs(connRef, map[string]any{"attr1": "val4"}, nil, refused)
+	c.Assert(err, ErrorMatches, `cannot update connection of plug "consumer" of snap "s1" to slot "producer" of snap "s2": not allowed`)
+
+	current, err = s.emptyRepo.Connection(connRef)
+	c.Assert(err, IsNil)
+	c.Check(current, Equals, conn)
+}
+
+func (s *RepositorySuite) TestBeforeConnectMatchingAttrs(c *C) {
+	err := s.emptyRepo.AddInterface(&ifacetest.TestInterface{
+		InterfaceName: "iface2",
+		BeforeConnectCallback: func(plug *ConnectedPlug, slot *ConnectedSlot) error {
+			return MatchAttr(plug, slot, "attr1")
+		},
+	})
+	c.Assert(err, IsNil)
+
+	s1 := ifacetest.MockInfoAndAppSet(c, ifacehooksSnap1, nil, nil)
+	c.Assert(s.emptyRepo.AddAppSet(s1), IsNil)
+	s2 := ifacetest.MockInfoAndAppSet(c, ifacehooksSnap2, nil, nil)
+	c.Assert(s.emptyRepo.AddAppSet(s2), IsNil)
+
+	policyCheck := func(plug *ConnectedPlug, slot *ConnectedSlot) (bool, error) { return true, nil }
+	connRef := &ConnRef{PlugRef: PlugRef{Snap: "s1", Name: "consumer"}, SlotRef: SlotRef{Snap: "s2", Name: "producer"}}
+
+	conn, err := s.emptyRepo.Connect(connRef, nil, map[string]any{"attr1": "val1"}, nil, map[string]any{"attr1": "val2"}, policyCheck)
+	c.Assert(err, ErrorMatches, `cannot connect plug "consumer" of snap "s1" to slot "producer" of snap "s2": plug attribute "attr1" \(val1\) does not match the one of the slot \(val2\)`)
+	c.Assert(conn, IsNil)
+
+	// the connection is not checked again when reloaded
+	conn, err = s.emptyRepo.Connect(connRef, nil, map[string]any{"attr1": "val1"}, nil, map[string]any{"attr1": "val2"}, nil)
+	c.Assert(err, IsNil)
+	c.Assert(conn, NotNil)
+	c.Assert(s.emptyRepo.Disconnect("s1", "consumer", "s2", "producer"), IsNil)
+
+	conn, err = s.emptyRepo.Connect(connRef, nil, map[string]any{"attr1": "val1"}, nil, map[string]any{"attr1": "val1"}, policyCheck)
+	c.Assert(err, IsNil)
+	c.Assert(conn, NotNil)
+}
+
 func (s *RepositorySuite) TestBeforeConnectValidationPolicyCheckFailure(c *C) {
 	err := s.emptyRepo.AddInterface(&ifacetest.TestInterface{
 		InterfaceName:             "iface2",
@@ -2345,3 +2655,54 @@ func (s *RepositorySuite) TestUpdateHotplugSlotAttrsConnectedError(c *C) {
 	c.Assert(err, ErrorMatches, `internal error: cannot update slot test-slot while connected`)
 	c.Assert(slot, IsNil)
 }
+
+type attrDocInterface struct {
+	ifacetest.TestInterface
+}
+
+func (iface *attrDocInterface) AttributeSchema() map[string]AttrSpec {
+	return map[string]AttrSpec{
+		"write": {Type: AttrBool, Default: false, Description: "grant write access"},
+	}
+}
+
+func (iface *attrDocInterface) SlotAttributeSchema() map[string]AttrSpec {
+	return map[string]AttrSpec{
+		"path": {Type: AttrString, Required: true, Description: "path of the device"},
+	}
+}
+
+func (s *RepositorySuite) TestInfoAttributesDoc(c *C) {
+	r := NewRepository()
+	iface := &attrDocInterface{TestInterface: ifacetest.TestInterface{
+		InterfaceName: "i1",
+		InterfaceStaticInfo: StaticInfo{
+			Summary: "i1 summary",
+			Example: "plugs:\n  i1:\n    write: true\n",
+			BaseDeclarationSlots: `
+  i1:
+    deny-auto-connection: true
+`,
+		},
+	}}
+	c.Assert(r.AddInterface(iface), IsNil)
+
+	// attributes are only documented on request
+	infos := r.Info(&InfoOptions{Names: []string{"i1"}})
+	c.Assert(infos, DeepEquals, []*Info{{Name: "i1", Summary: "i1 summary"}})
+
+	infos = r.Info(&InfoOptions{Names: []string{"i1"}, Doc: true})
+	c.Assert(infos, DeepEquals, []*Info{{
+		Name:          "i1",
+		Summary:       "i1 summary",
+		DocURL:        "https://snapcraft.io/docs/i1-interface",
+		SecurityLevel: SecurityLevelMedium,
+		Example:       "plugs:\n  i1:\n    write: true\n",
+		PlugAttrs: map[string]AttrSpec{
+			"write": {Type: AttrBool, Default: false, Description: "grant write access"},
+		},
+		SlotAttrs: map[string]AttrSpec{
+			"path": {Type: AttrString, Required: true, Description: "path of the device"},
+		},
+	}})
+}
@@ -29,20 +29,26 @@
 // application the profile is parsed and re-compiled.
 //
 // The actual profiles are stored in /var/lib/snappy/seccomp/bpf/*.{src,bin}.
-// This directory is hard-coded in snap-confine.
+// This directory is hard-coded in snap-confine. Profiles with an identical
+// source are compiled only once, the compiled profiles are cached in
+// /var/lib/snappy/seccomp/cache, keyed by the digest of their source.
 package seccomp
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"syscall"
 
 	"github.com/snapcore/snapd/arch"
 	"github.com/snapcore/snapd/dirs"
 	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/logger"
 	"github.com/snapcore/snapd/osutil"
 	"github.com/snapcore/snapd/release"
 	"github.com/snapcore/snapd/sandbox/apparmor"
@@ -113,23 +119,113 @@ func bpfBinPath(srcName string) string {
 	return filepath.Join(dirs.SnapSeccompDir, strings.TrimSuffix(srcName, ".src")+".bin2")
 }
 
+// compiledCachePath returns the path of the compiled profile cached for the
+// given profile source, the cache is keyed by the digest of the source which
+// includes the snap-seccomp version information. An empty path is returned
+// when the source cannot be read.
+func compiledCachePath(in string) string {
+	content, err := os.ReadFile(in)
+	if err != nil {
+		return ""
+	}
+	digest := sha256.Sum256(content)
+	return filepath.Join(dirs.SnapSeccompCacheDir, hex.EncodeToString(digest[:])+".bin2")
+}
+
+// compileCached compiles the given profile, unless an identical profile was
+// compiled already, in which case the compiled profile is taken from the cache.
+// The cache holds hard links to the compiled profiles, which keeps them
+// consistent with the profiles in use and cheap to share.
+func compileCached(compiler Compiler, in, out, cached string) error {
+	if cached != "" {
+		if err := linkCached(cached, out); err == nil {
+			return nil
+		}
+	}
+	if err := compiler.Compile(in, out); err != nil {
+		return err
+	}
+	if cached != "" {
+		// caching is best effort, the profile is compiled already
+		if err := os.MkdirAll(dirs.SnapSeccompCacheDir, 0755); err == nil {
+			if err := os.Link(out, cached); err != nil && !os.IsExist(err) && !os.IsNotExist(err) {
+				logger.Debugf("cannot cache compiled seccomp profile %s: %v", out, err)
+			}
+		}
+	}
+	return nil
+}
+
+// linkCached replaces out with a hard link to the cached compiled profile.
+// The link is made under a temporary name next to out first, as a link cannot
+// replace an existing file.
+func linkCached(cached, out string) error {
+	tmp := out + ".cached~"
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Link(cached, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, out); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// pruneCompiledCache removes the cached compiled profiles which are no longer
+// used by any snap, that is the ones without any other hard link.
+func pruneCompiledCache() {
+	entries, err := os.ReadDir(dirs.SnapSeccompCacheDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dirs.SnapSeccompCacheDir, entry.Name())
+		var st syscall.Stat_t
+		if err := syscall.Stat(path, &st); err != nil || st.Nlink > 1 {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logger.Debugf("cannot remove unused compiled seccomp profile %s: %v", path, err)
+		}
+	}
+}
+
 func parallelCompile(compiler Compiler, profiles []string) error {
 	if len(profiles) == 0 {
 		// no profiles, nothing to do
 		return nil
 	}
 
-	profilesQueue := make(chan string, len(profiles))
+	// profiles with an identical source are compiled only once, the other
+	// ones are taken from the cache afterwards
+	cachePaths := make(map[string]string, len(profiles))
+	seen := make(map[string]bool, len(profiles))
+	var unique, duplicates []string
+	for _, p := range profiles {
+		cached := compiledCachePath(bpfSrcPath(p))
+		cachePaths[p] = cached
+		if cached != "" && seen[cached] {
+			duplicates = append(duplicates, p)
+			continue
+		}
+		seen[cached] = true
+		unique = append(unique, p)
+	}
+
+	profilesQueue := make(chan string, len(unique))
 	numWorkers := runtime.NumCPU()
 	if numWorkers >= 2 {
 		numWorkers -= 1
 	}
-	if numWorkers > len(profiles) {
-		numWorkers = len(profiles)
+	if numWorkers > len(unique) {
+		numWorkers = len(unique)
 	}
 	resultsBufferSize := numWorkers * 2
-	if resultsBufferSize > len(profiles) {
-		resultsBufferSize = len(profiles)
+	if resultsBufferSize > len(unique) {
+		resultsBufferSize = len(unique)
 	}
 	res := make(chan error, resultsBufferSize)
 
@@ -153,7 +249,7 @@ func parallelCompile(compiler Compiler, profiles []string) error {
 
 				// snap-seccomp uses AtomicWriteFile internally, on failure the
 				// output file is unlinked
-				if err := compiler.Compile(in, out); err != nil {
+				if err := compileCached(compiler, in, out, cachePaths[profile]); err != nil {
 					res <- fmt.Errorf("cannot compile %s: %v", in, err)
 				} else {
 					res <- nil
@@ -162,14 +258,14 @@ func parallelCompile(compiler Compiler, profiles []string) error {
 		}()
 	}
 
-	for _, p := range profiles {
+	for _, p := range unique {
 		profilesQueue <- p
 	}
 	// signal workers to exit
 	close(profilesQueue)
 
 	var firstErr error
-	for i := 0; i < len(profiles); i++ {
+	for i := 0; i < len(unique); i++ {
 		maybeErr := <-res
 		if maybeErr != nil && firstErr == nil {
 			firstErr = maybeErr
@@ -180,6 +276,19 @@ func parallelCompile(compiler Compiler, profiles []string) error {
 	// not expecting any more results
 	close(res)
 
+	for _, p := range duplicates {
+		if firstErr != nil {
+			break
+		}
+		in := bpfSrcPath(p)
+		out := bpfBinPath(p)
+		if err := os.Remove(out); err != nil && !os.IsNotExist(err) {
+			firstErr = err
+		} else if err := compileCached(compiler, in, out, cachePaths[p]); err != nil {
+			firstErr = fmt.Errorf("cannot compile %s: %v", in, err)
+		}
+	}
+
 	if firstErr != nil {
 		for _, p := range profiles {
 			out := bpfBinPath(p)
@@ -242,16 +351,43 @@ func (b *Backend) Setup(appSet *interfaces.SnapAppSet, opts interfaces.Confineme
 		}
 	}
 
-	return parallelCompile(b.snapSeccomp, changed)
+	err = parallelCompile(b.snapSeccomp, changed)
+	if len(changed) > 0 || len(removed) > 0 {
+		pruneCompiledCache()
+	}
+	return err
+}
+
+// Profiles returns the sources of the seccomp profiles of the apps and hooks
+// of a given snap, as Setup would write them.
+func (b *Backend) Profiles(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, repo *interfaces.Repository) (map[string][]byte, error) {
+	snapName := appSet.InstanceName()
+	spec, err := repo.SnapSpecification(b.Name(), appSet, opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot obtain seccomp specification for snap %q: %s", snapName, err)
+	}
+
+	content, err := b.deriveContent(spec.(*Specification), opts, appSet)
+	if err != nil {
+		return nil, fmt.Errorf("cannot obtain expected security files for snap %q: %s", snapName, err)
+	}
+	profiles := make(map[string][]byte, len(content))
+	for name, state := range content {
+		profiles[filepath.Join(dirs.SnapSeccompDir, name)] = state.(*osutil.MemoryFileState).Content
+	}
+	return profiles, nil
 }
 
 // Remove removes seccomp profiles of a given snap.
 func (b *Backend) Remove(snapName string) error {
 	globs := interfaces.SecurityTagGlobs(snapName)
-	_, _, err := osutil.EnsureDirStateGlobs(dirs.SnapSeccompDir, globs, nil)
+	_, removed, err := osutil.EnsureDirStateGlobs(dirs.SnapSeccompDir, globs, nil)
 	if err != nil {
 		return fmt.Errorf("cannot synchronize security files for snap %q: %s", snapName, err)
 	}
+	if len(removed) > 0 {
+		pruneCompiledCache()
+	}
 	return nil
 }
 
@@ -290,7 +426,7 @@ func (b *Backend) deriveContent(spec *Specification, opts interfaces.Confinement
 
 		path := r.SecurityTag + ".src"
 		content[path] = &osutil.MemoryFileState{
-			Content: generateContent(opts, spec.SnippetForTag(r.SecurityTag), addSocketcall, b.versionInfo, uidGidChownSyscalls.String()),
+			Content: generateContent(opts, spec.SnippetForTag(r.SecurityTag), addSocketcall, b.versionInfo, uidGidChownSyscalls.String(), spec.MissingActions()),
 			Mode:    0644,
 		}
 	}
@@ -298,13 +434,16 @@ func (b *Backend) deriveContent(spec *Specification, opts interfaces.Confinement
 	return content, nil
 }
 
-func generateContent(opts interfaces.ConfinementOptions, snippetForTag string, addSocketcall bool, versionInfo seccomp.VersionInfo, uidGidChownSyscalls string) []byte {
+func generateContent(opts interfaces.ConfinementOptions, snippetForTag string, addSocketcall bool, versionInfo seccomp.VersionInfo, uidGidChownSyscalls string, missingActions []string) []byte {
 	var buffer bytes.Buffer
 
 	if versionInfo != "" {
 		buffer.WriteString("# snap-seccomp version information:\n")
 		fmt.Fprintf(&buffer, "# %s\n", versionInfo)
 	}
+	for _, action := range missingActions {
+		fmt.Fprintf(&buffer, "# seccomp action %q unavailable, rules requiring it are degraded\n", action)
+	}
 
 	if opts.Classic && !opts.JailMode {
 		// NOTE: This is understood by snap-confine
@@ -338,7 +477,15 @@ func generateContent(opts interfaces.ConfinementOptions, snippetForTag string, a
 
 // NewSpecification returns an empty seccomp specification.
 func (b *Backend) NewSpecification(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions) interfaces.Specification {
-	return &Specification{appSet: appSet}
+	return &Specification{
+		appSet:                appSet,
+		confinement:           opts.Confinement(),
+		auditedPlugs:          opts.AuditedPlugs,
+		logPrivilegedSyscalls: opts.LogPrivilegedSyscalls,
+		// argument filtering is unreliable with old libseccomp and
+		// golang-seccomp versions
+		noArgumentFiltering: b.versionInfo.SupportsRobustArgumentFiltering() != nil,
+	}
 }
 
 // SandboxFeatures returns the list of seccomp features supported by the kernel
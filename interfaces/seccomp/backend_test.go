@@ -450,6 +450,36 @@ func (s *backendSuite) TestCombineSnippets(c *C) {
 	}
 }
 
+func (s *backendSuite) TestAuditedPlugsEnforce(c *C) {
+	restore := apparmor_sandbox.MockLevel(apparmor_sandbox.Full)
+	defer restore()
+	restore = seccomp_sandbox.MockActions([]string{"log"})
+	defer restore()
+	restore = seccomp.MockRequiresSocketcall(func(string) bool { return false })
+	defer restore()
+	restore = seccomp.MockTemplate([]byte("default\n"))
+	defer restore()
+
+	const auditedPlugYaml = `
+name: samba
+version: 1
+developer: acme
+apps:
+    smbd:
+        plugs: [plug]
+    nmbd:
+plugs:
+    plug:
+        interface: iface
+`
+	// the filters of the apps bound to an audited plug are still
+	// enforcing, only the rules of the plug are logged
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{AuditedPlugs: []string{"plug"}}, "", auditedPlugYaml, 0)
+	c.Check(filepath.Join(dirs.SnapSeccompDir, "snap.samba.smbd.src"), testutil.FileEquals, s.profileHeader+"default\n")
+	c.Check(filepath.Join(dirs.SnapSeccompDir, "snap.samba.nmbd.src"), testutil.FileEquals, s.profileHeader+"default\n")
+	s.RemoveSnap(c, snapInfo)
+}
+
 const snapYaml = `
 name: foo
 version: 1
@@ -561,6 +591,31 @@ func (s *backendSuite) TestSystemKeyRetLogSupported(c *C) {
 	s.RemoveSnap(c, snapInfo)
 }
 
+func (s *backendSuite) TestMissingActionsReported(c *C) {
+	restore := seccomp_sandbox.MockActions([]string{"allow", "errno", "log"})
+	defer restore()
+
+	s.Iface.SecCompPermanentSlotCallback = func(spec *seccomp.Specification, slot *snap.SlotInfo) error {
+		if supported, err := spec.RequireAction("user_notif"); err != nil || supported {
+			return err
+		}
+		spec.AddSnippet("# degraded")
+		return nil
+	}
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", ifacetest.SambaYamlV1, 0)
+	profile := filepath.Join(dirs.SnapSeccompDir, "snap.samba.smbd")
+	c.Check(profile+".src", testutil.FileContains, "# seccomp action \"user_notif\" unavailable, rules requiring it are degraded\n")
+	c.Check(profile+".src", testutil.FileContains, "# degraded\n")
+	s.RemoveSnap(c, snapInfo)
+
+	// nothing is reported when the action is supported
+	restore = seccomp_sandbox.MockActions([]string{"allow", "errno", "log", "user_notif"})
+	defer restore()
+	s.InstallSnap(c, interfaces.ConfinementOptions{}, "", ifacetest.SambaYamlV1, 0)
+	c.Check(profile+".src", Not(testutil.FileContains), "unavailable")
+	c.Check(profile+".src", Not(testutil.FileContains), "# degraded\n")
+}
+
 func (s *backendSuite) TestSystemKeyRetLogUnsupported(c *C) {
 	restore := seccomp_sandbox.MockActions([]string{"allow", "errno", "kill", "trace", "trap"})
 	defer restore()
@@ -600,6 +655,33 @@ fi`)
 	c.Assert(s.Backend.SandboxFeatures(), DeepEquals, []string{"kernel:foo", "kernel:bar", "bpf-argument-filtering", "bpf-actlog"})
 }
 
+func (s *backendSuite) TestNewSpecificationArgumentFiltering(c *C) {
+	// libseccomp 1.2.3 is too old to filter arguments reliably
+	spec := s.Backend.NewSpecification(nil, interfaces.ConfinementOptions{}).(*seccomp.Specification)
+	c.Check(spec.ArgumentsRule("mount - - - |MS_NOSUID|MS_NODEV"), Equals, "mount")
+
+	snapSeccomp := testutil.MockLockedCommand(c, filepath.Join(dirs.DistroLibExecDir, "snap-seccomp"), `
+if [ "$1" = "version-info" ]; then
+    echo "abcdef 2.4.1 1234abcd bpf-actlog"
+fi`)
+	defer snapSeccomp.Restore()
+	c.Assert(s.Backend.Initialize(nil), IsNil)
+
+	spec = s.Backend.NewSpecification(nil, interfaces.ConfinementOptions{}).(*seccomp.Specification)
+	c.Check(spec.ArgumentsRule("mount - - - |MS_NOSUID|MS_NODEV"), Equals, "mount - - - |MS_NOSUID|MS_NODEV")
+
+	// golang-seccomp must generate correct filters as well
+	snapSeccomp = testutil.MockLockedCommand(c, filepath.Join(dirs.DistroLibExecDir, "snap-seccomp"), `
+if [ "$1" = "version-info" ]; then
+    echo "abcdef 2.4.1 1234abcd -"
+fi`)
+	defer snapSeccomp.Restore()
+	c.Assert(s.Backend.Initialize(nil), IsNil)
+
+	spec = s.Backend.NewSpecification(nil, interfaces.ConfinementOptions{}).(*seccomp.Specification)
+	c.Check(spec.ArgumentsRule("mount - - - |MS_NOSUID|MS_NODEV"), Equals, "mount")
+}
+
 func (s *backendSuite) TestRequiresSocketcallByNotNeededArch(c *C) {
 	testArchs := []string{"amd64", "armhf", "arm64", "powerpc", "ppc64el", "unknownDefault"}
 	for _, arch := range testArchs {
@@ -976,21 +1058,12 @@ fi
 		c.Check(profile+".bin", testutil.FileAbsent)
 	}
 
-	// 2 compile calls + 1 version-info
-	c.Check(snapSeccomp.Calls(), HasLen, 3)
-	seen := make(map[string]bool, 2)
-	for _, call := range snapSeccomp.Calls() {
-		if len(call) == 2 && call[1] == "version-info" {
-			continue
-		}
-		c.Assert(call, HasLen, 4)
-		seen[call[2]] = true
-	}
-	c.Check(seen, DeepEquals, map[string]bool{
-		nmbdProfile + ".src": true,
-		smbdProfile + ".src": true,
+	// the profiles are identical and compiled only once, 1 compile call
+	// + 1 version-info
+	c.Check(snapSeccomp.Calls(), DeepEquals, [][]string{
+		{"snap-seccomp", "version-info"},
+		{"snap-seccomp", "compile", nmbdProfile + ".src", nmbdProfile + ".bin2"},
 	})
-
 }
 
 type mockedSyncedCompiler struct {
@@ -1087,3 +1160,93 @@ func (s *backendSuite) TestParallelCompileRemovesFirst(c *C) {
 	err = seccomp.ParallelCompile(&m, []string{"profile-001"})
 	c.Assert(err, ErrorMatches, "remove .*/profile-001.bin2: permission denied")
 }
+
+func (s *backendSuite) TestParallelCompileCachesIdenticalProfiles(c *C) {
+	for _, p := range []string{"profile-001.src", "profile-002.src"} {
+		c.Assert(os.WriteFile(filepath.Join(dirs.SnapSeccompDir, p), []byte("identical\n"), 0644), IsNil)
+	}
+	c.Assert(os.WriteFile(filepath.Join(dirs.SnapSeccompDir, "profile-003.src"), []byte("different\n"), 0644), IsNil)
+
+	m := mockedSyncedCompiler{}
+	c.Assert(seccomp.ParallelCompile(&m, []string{"profile-001.src", "profile-003.src"}), IsNil)
+	sort.Strings(m.profiles)
+	c.Check(m.profiles, DeepEquals, []string{"profile-001.src", "profile-003.src"})
+
+	// the identical profile is taken from the cache
+	m.profiles = nil
+	c.Assert(seccomp.ParallelCompile(&m, []string{"profile-002.src"}), IsNil)
+	c.Check(m.profiles, HasLen, 0)
+	c.Check(filepath.Join(dirs.SnapSeccompDir, "profile-002.bin2"), testutil.FileEquals, "done profile-001.bin2")
+
+	entries, err := os.ReadDir(dirs.SnapSeccompCacheDir)
+	c.Assert(err, IsNil)
+	c.Check(entries, HasLen, 2)
+}
+
+func (s *backendSuite) TestCompileCachedReplacesExistingProfile(c *C) {
+	c.Assert(os.MkdirAll(dirs.SnapSeccompCacheDir, 0755), IsNil)
+	in := filepath.Join(dirs.SnapSeccompDir, "profile-001.src")
+	out := filepath.Join(dirs.SnapSeccompDir, "profile-001.bin2")
+	cached := filepath.Join(dirs.SnapSeccompCacheDir, "digest.bin2")
+	c.Assert(os.WriteFile(in, []byte("source\n"), 0644), IsNil)
+	c.Assert(os.WriteFile(out, []byte("old"), 0644), IsNil)
+	c.Assert(os.WriteFile(cached, []byte("cached"), 0644), IsNil)
+
+	m := mockedSyncedCompiler{}
+	c.Assert(seccomp.CompileCached(&m, in, out, cached), IsNil)
+	// the existing profile is replaced by the cached one, without compiling
+	c.Check(m.profiles, HasLen, 0)
+	c.Check(out, testutil.FileEquals, "cached")
+	outInfo, err := os.Stat(out)
+	c.Assert(err, IsNil)
+	cachedInfo, err := os.Stat(cached)
+	c.Assert(err, IsNil)
+	c.Check(os.SameFile(outInfo, cachedInfo), Equals, true)
+	c.Check(out+".cached~", testutil.FileAbsent)
+}
+
+func (s *backendSuite) TestSetupAndRemovePruneCompiledCache(c *C) {
+	snapSeccomp := testutil.MockLockedCommand(c, filepath.Join(dirs.DistroLibExecDir, "snap-seccomp"), `
+if [ "$1" = "version-info" ]; then
+    echo "abcdef 1.2.3 1234abcd -"
+elif [ "$1" = "compile" ]; then
+    echo compiled > "$3"
+fi`)
+	defer snapSeccomp.Restore()
+	c.Assert(s.Backend.Initialize(nil), IsNil)
+	snapSeccomp.ForgetCalls()
+
+	// the two apps have identical profiles, only one is compiled
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", ifacetest.SambaYamlV1WithNmbd, 0)
+	c.Check(snapSeccomp.Calls(), HasLen, 1)
+	entries, err := os.ReadDir(dirs.SnapSeccompCacheDir)
+	c.Assert(err, IsNil)
+	c.Check(entries, HasLen, 1)
+	for _, app := range []string{"smbd", "nmbd"} {
+		c.Check(filepath.Join(dirs.SnapSeccompDir, "snap.samba."+app+".bin2"), testutil.FileEquals, "compiled\n")
+	}
+
+	// the unused cached profile is removed along with the snap
+	s.RemoveSnap(c, snapInfo)
+	entries, err = os.ReadDir(dirs.SnapSeccompCacheDir)
+	c.Assert(err, IsNil)
+	c.Check(entries, HasLen, 0)
+}
+
+func (s *backendSuite) TestProfilesMatchSetup(c *C) {
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", ifacetest.SambaYamlV1, 1)
+	appSet, err := interfaces.NewSnapAppSet(snapInfo, nil)
+	c.Assert(err, IsNil)
+
+	profiles, err := s.Backend.(interfaces.SecurityBackendProfiles).Profiles(appSet, interfaces.ConfinementOptions{}, s.Repo)
+	c.Assert(err, IsNil)
+	paths := make([]string, 0, len(profiles))
+	for path, content := range profiles {
+		paths = append(paths, path)
+		c.Check(path, testutil.FileEquals, string(content))
+	}
+	sort.Strings(paths)
+	c.Check(paths, DeepEquals, []string{
+		filepath.Join(dirs.SnapSeccompDir, "snap.samba.smbd.src"),
+	})
+}
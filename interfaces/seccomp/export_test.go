@@ -93,4 +93,5 @@ func (b *Backend) VersionInfo() seccomp_compiler.VersionInfo {
 var (
 	RequiresSocketcall = requiresSocketcall
 	ParallelCompile    = parallelCompile
+	CompileCached      = compileCached
 )
@@ -21,10 +21,14 @@ package seccomp
 
 import (
 	"bytes"
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/sandbox/seccomp"
 	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/strutil"
 )
 
 // Specification keeps all the seccomp snippets.
@@ -33,6 +37,26 @@ type Specification struct {
 	// Snippets are indexed by security tag.
 	snippets     map[string][]string
 	securityTags []string
+	// missingActions are the actions required by interfaces which the
+	// kernel does not support.
+	missingActions map[string]bool
+	// confinement is the type of confinement the profiles are generated
+	// for, see Confinement
+	confinement snap.ConfinementType
+	// noArgumentFiltering is set when snap-seccomp cannot reliably
+	// filter syscalls on their arguments, see ArgumentsRule
+	noArgumentFiltering bool
+	// auditedPlugs are the plugs of the snap with connections in audit
+	// mode, the rules added for them use the log action such that the
+	// syscalls they allow are logged. auditing is set while the rules of
+	// such a plug are added.
+	auditedPlugs []string
+	auditing     bool
+	// logPrivilegedSyscalls enables a diagnostic mode in which privileged
+	// interfaces grant their syscalls with the log action instead of the
+	// allow one, such that it can be observed whether the syscalls are
+	// actually used, see PrivilegedRule.
+	logPrivilegedSyscalls bool
 }
 
 func NewSpecification(appSet *interfaces.SnapAppSet) *Specification {
@@ -45,19 +69,258 @@ func (spec *Specification) SnapAppSet() *interfaces.SnapAppSet {
 	return spec.appSet
 }
 
-// AddSnippet adds a new seccomp snippet.
+// Confinement returns the type of confinement the seccomp profiles are
+// generated for, falling back to the one of the snap when the specification
+// was not prepared by the backend.
+func (spec *Specification) Confinement() snap.ConfinementType {
+	if spec.confinement != "" {
+		return spec.confinement
+	}
+	if spec.appSet != nil && spec.appSet.Info().Confinement != "" {
+		return spec.appSet.Info().Confinement
+	}
+	return snap.StrictConfinement
+}
+
+// AddSnippet adds a new seccomp snippet, unless an identical one was added
+// already. The snap variables which the snippet refers to are expanded to the
+// values of the snap, see interfaces.SnippetVariables.
 func (spec *Specification) AddSnippet(snippet string) {
 	if len(spec.securityTags) == 0 {
 		return
 	}
+	if spec.appSet != nil {
+		snippet = interfaces.SnapSnippetVariables(spec.appSet.Info()).Expand(snippet)
+	}
+	if spec.auditing {
+		if supported, _ := spec.RequireAction("log"); supported {
+			snippet = loggedSnippet(snippet)
+		}
+	}
 	if spec.snippets == nil {
 		spec.snippets = make(map[string][]string)
 	}
 	for _, tag := range spec.securityTags {
+		// identical snippets are only kept once, in the order they were
+		// first added
+		if strutil.ListContains(spec.snippets[tag], snippet) {
+			continue
+		}
 		spec.snippets[tag] = append(spec.snippets[tag], snippet)
 	}
 }
 
+// loggedSnippet returns the given snippet with its rules allowing syscalls
+// prefixed with '?', such that the syscalls are allowed but logged. Comments,
+// rules denying a syscall and special directives are left as is.
+func loggedSnippet(snippet string) string {
+	lines := strings.Split(snippet, "\n")
+	for i, line := range lines {
+		rule := strings.TrimSpace(line)
+		if rule == "" || strings.ContainsAny(rule[:1], "#~?@") {
+			continue
+		}
+		lines[i] = "?" + rule
+	}
+	return strings.Join(lines, "\n")
+}
+
+// PrivilegedRule returns the given seccomp rule with the action to use for a
+// syscall granted by a privileged interface, the rule is prefixed with '?' to
+// be logged when the diagnostic mode is enabled, as is otherwise. The rule is
+// also returned as is when the kernel cannot log syscalls.
+func (spec *Specification) PrivilegedRule(rule string) string {
+	if spec.logPrivilegedSyscalls {
+		if supported, _ := spec.RequireAction("log"); supported {
+			return "?" + rule
+		}
+	}
+	return rule
+}
+
+// ArgumentsRule returns the given seccomp rule, which only allows a syscall
+// with some arguments, when snap-seccomp can filter syscalls on their
+// arguments reliably. Otherwise the syscall is allowed with any argument, so
+// that the profile can still be compiled. The rule must be an allow rule, for
+// instance "mount - - - |MS_NOSUID|MS_NODEV".
+func (spec *Specification) ArgumentsRule(rule string) string {
+	if spec.noArgumentFiltering {
+		if fields := strings.Fields(rule); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	return rule
+}
+
+// maxSyscallArgs is the number of syscall arguments which can be filtered on,
+// as supported by snap-seccomp.
+const maxSyscallArgs = 6
+
+// SyscallArg is a filter on the value of a syscall argument, in a
+// SyscallRule. The zero value matches any value.
+type SyscallArg struct {
+	// prefix is the prefix of the comparison in the snap-seccomp syntax,
+	// such as "!" or "u:"
+	prefix string
+	values []string
+}
+
+// AnyArg matches any value of the syscall argument.
+var AnyArg = SyscallArg{}
+
+// ArgEqual matches when the argument is equal to the given value, which is a
+// number or a symbolic constant known to snap-seccomp, such as "AF_UNIX".
+func ArgEqual(value string) SyscallArg { return SyscallArg{values: []string{value}} }
+
+// ArgNotEqual matches when the argument differs from the given value.
+func ArgNotEqual(value string) SyscallArg { return SyscallArg{prefix: "!", values: []string{value}} }
+
+// ArgLess matches when the argument is less than the given value.
+func ArgLess(value string) SyscallArg { return SyscallArg{prefix: "<", values: []string{value}} }
+
+// ArgLessOrEqual matches when the argument is less than or equal to the given
+// value.
+func ArgLessOrEqual(value string) SyscallArg {
+	return SyscallArg{prefix: "<=", values: []string{value}}
+}
+
+// ArgGreater matches when the argument is greater than the given value.
+func ArgGreater(value string) SyscallArg { return SyscallArg{prefix: ">", values: []string{value}} }
+
+// ArgGreaterOrEqual matches when the argument is greater than or equal to the
+// given value.
+func ArgGreaterOrEqual(value string) SyscallArg {
+	return SyscallArg{prefix: ">=", values: []string{value}}
+}
+
+// ArgFlagsSet matches when all of the given flags are set in the argument,
+// for instance ArgFlagsSet("MS_RDONLY", "MS_NOSUID") for the flags of mount.
+func ArgFlagsSet(flags ...string) SyscallArg { return SyscallArg{prefix: "|", values: flags} }
+
+// ArgMaskedEqual matches when the argument masked with the given mask is
+// equal to the given value.
+func ArgMaskedEqual(mask, value string) SyscallArg { return SyscallArg{values: []string{mask, value}} }
+
+// ArgUser matches when the argument is the uid of the given user.
+func ArgUser(name string) SyscallArg { return SyscallArg{prefix: "u:", values: []string{name}} }
+
+// ArgGroup matches when the argument is the gid of the given group.
+func ArgGroup(name string) SyscallArg { return SyscallArg{prefix: "g:", values: []string{name}} }
+
+func (a SyscallArg) isAny() bool {
+	return a.prefix == "" && a.values == nil
+}
+
+// String returns the argument filter in the snap-seccomp syntax.
+func (a SyscallArg) String() string {
+	if a.isAny() {
+		return "-"
+	}
+	return a.prefix + strings.Join(a.values, "|")
+}
+
+// SyscallRule allows a syscall with the given arguments, which are filtered
+// by position. Missing trailing arguments match any value.
+type SyscallRule struct {
+	Syscall string
+	Args    []SyscallArg
+}
+
+// String returns the rule in the snap-seccomp syntax, for instance
+// "mount - - - |MS_RDONLY|MS_NOSUID".
+func (r SyscallRule) String() string {
+	args := r.Args
+	// trailing arguments matching any value are implied
+	for len(args) > 0 && args[len(args)-1].isAny() {
+		args = args[:len(args)-1]
+	}
+	fields := make([]string, 0, len(args)+1)
+	fields = append(fields, r.Syscall)
+	for _, arg := range args {
+		fields = append(fields, arg.String())
+	}
+	return strings.Join(fields, " ")
+}
+
+func (r SyscallRule) validate() error {
+	if r.Syscall == "" || strings.ContainsAny(r.Syscall, " \t\n#~?") {
+		return fmt.Errorf("invalid syscall name %q", r.Syscall)
+	}
+	if len(r.Args) > maxSyscallArgs {
+		return fmt.Errorf("cannot filter syscall %q on more than %d arguments", r.Syscall, maxSyscallArgs)
+	}
+	for i, arg := range r.Args {
+		if arg.isAny() {
+			continue
+		}
+		valid := len(arg.values) > 0
+		for _, value := range arg.values {
+			if value == "" || strings.ContainsAny(value, " \t\n|") {
+				valid = false
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid filter %q for argument %d of syscall %q", arg.String(), i, r.Syscall)
+		}
+	}
+	return nil
+}
+
+// AddSyscallRule adds a rule allowing a syscall, possibly only with some
+// arguments, to the apps and hooks in scope. The arguments are only filtered
+// when snap-seccomp can do so reliably, see ArgumentsRule.
+func (spec *Specification) AddSyscallRule(rule SyscallRule) error {
+	if err := rule.validate(); err != nil {
+		return err
+	}
+	spec.AddSnippet(spec.ArgumentsRule(rule.String()))
+	return nil
+}
+
+// knownActions are the seccomp actions, as listed by the kernel in
+// /proc/sys/kernel/seccomp/actions_avail. Older kernels use "kill" for
+// "kill_thread".
+var knownActions = map[string]bool{
+	"allow":        true,
+	"errno":        true,
+	"kill":         true,
+	"kill_process": true,
+	"kill_thread":  true,
+	"log":          true,
+	"trace":        true,
+	"trap":         true,
+	"user_notif":   true,
+}
+
+// RequireAction returns whether the kernel supports the given seccomp action,
+// such that interfaces can use rules relying on it or degrade gracefully
+// otherwise. Unsupported actions are recorded, they are reported in the
+// generated profiles. An error is returned for unknown actions.
+func (spec *Specification) RequireAction(action string) (bool, error) {
+	if !knownActions[action] {
+		return false, fmt.Errorf("unknown seccomp action %q", action)
+	}
+	if seccomp.SupportsAction(action) {
+		return true, nil
+	}
+	if spec.missingActions == nil {
+		spec.missingActions = make(map[string]bool)
+	}
+	spec.missingActions[action] = true
+	return false, nil
+}
+
+// MissingActions returns the sorted list of the seccomp actions required by
+// interfaces which the kernel does not support.
+func (spec *Specification) MissingActions() []string {
+	var actions []string
+	for action := range spec.missingActions {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
 // Snippets returns a deep copy of all the added snippets.
 func (spec *Specification) Snippets() map[string][]string {
 	result := make(map[string][]string, len(spec.snippets))
@@ -106,6 +369,10 @@ func (spec *Specification) AddConnectedPlug(iface interfaces.Interface, plug *in
 
 		spec.securityTags = tags
 		defer func() { spec.securityTags = nil }()
+		if strutil.ListContains(spec.auditedPlugs, plug.Name()) {
+			spec.auditing = true
+			defer func() { spec.auditing = false }()
+		}
 		return iface.SecCompConnectedPlug(spec, plug, slot)
 	}
 	return nil
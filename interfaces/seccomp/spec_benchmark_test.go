@@ -0,0 +1,106 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package seccomp_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/ifacetest"
+	"github.com/snapcore/snapd/interfaces/seccomp"
+	"github.com/snapcore/snapd/snap"
+)
+
+// benchmarkSnippet is shaped like the fuse-support policy.
+const benchmarkSnippet = `
+# Description: Can run a FUSE filesystem.
+mount
+umount2
+`
+
+const benchmarkPlugs = 20
+
+// benchmarkConnections returns the connected plugs of a snap with many plugs
+// of the same interface.
+func benchmarkConnections(b *testing.B) (*interfaces.SnapAppSet, []*interfaces.ConnectedPlug, *interfaces.ConnectedSlot) {
+	b.Cleanup(snap.MockSanitizePlugsSlots(func(snapInfo *snap.Info) {}))
+
+	var yaml strings.Builder
+	yaml.WriteString("name: consumer\nversion: 0\napps:\n app:\n  plugs:\n")
+	for i := 0; i < benchmarkPlugs; i++ {
+		fmt.Fprintf(&yaml, "   - plug-%d\n", i)
+	}
+	yaml.WriteString("plugs:\n")
+	for i := 0; i < benchmarkPlugs; i++ {
+		fmt.Fprintf(&yaml, " plug-%d:\n  interface: test\n", i)
+	}
+	info, err := snap.InfoFromSnapYaml([]byte(yaml.String()))
+	if err != nil {
+		b.Fatal(err)
+	}
+	appSet, err := interfaces.NewSnapAppSet(info, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	slotInfo, err := snap.InfoFromSnapYaml([]byte("name: core\nversion: 0\ntype: os\nslots:\n test:\n"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	slotAppSet, err := interfaces.NewSnapAppSet(slotInfo, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	slot := interfaces.NewConnectedSlot(slotInfo.Slots["test"], slotAppSet, nil, nil)
+	var plugs []*interfaces.ConnectedPlug
+	for i := 0; i < benchmarkPlugs; i++ {
+		plugs = append(plugs, interfaces.NewConnectedPlug(info.Plugs[fmt.Sprintf("plug-%d", i)], appSet, nil, nil))
+	}
+	return appSet, plugs, slot
+}
+
+// BenchmarkAddSnippetManyPlugs reports the size of the snippets of a filter
+// for a snap connecting many plugs which share the same snippet, next to the
+// size it would have without de-duplication.
+func BenchmarkAddSnippetManyPlugs(b *testing.B) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "test",
+		SecCompConnectedPlugCallback: func(spec *seccomp.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddSnippet(benchmarkSnippet)
+			return nil
+		},
+	}
+	appSet, plugs, slot := benchmarkConnections(b)
+
+	var snippet string
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		spec := seccomp.NewSpecification(appSet)
+		for _, plug := range plugs {
+			if err := spec.AddConnectedPlug(iface, plug, slot); err != nil {
+				b.Fatal(err)
+			}
+		}
+		snippet = spec.SnippetForTag("snap.consumer.app")
+	}
+	b.ReportMetric(float64(len(snippet)), "bytes/filter")
+	b.ReportMetric(float64(benchmarkPlugs*len(benchmarkSnippet)), "bytes/filter-without-dedup")
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/interfaces/ifacetest"
 	"github.com/snapcore/snapd/interfaces/seccomp"
+	seccomp_sandbox "github.com/snapcore/snapd/sandbox/seccomp"
 	"github.com/snapcore/snapd/snap"
 )
 
@@ -107,3 +108,241 @@ func (s *specSuite) TestSpecificationIface(c *C) {
 
 	c.Assert(spec.SnippetForTag("non-existing"), Equals, "")
 }
+
+func (s *specSuite) TestAddSnippetDeduplicates(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	// the same interface connected several times adds its snippet once,
+	// distinct snippets keep their order
+	for i := 0; i < 3; i++ {
+		c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+		c.Assert(spec.AddPermanentPlug(s.iface, s.plugInfo), IsNil)
+	}
+	c.Assert(spec.Snippets(), DeepEquals, map[string][]string{
+		"snap.snap1.app1": {"connected-plug", "permanent-plug"},
+	})
+	c.Assert(spec.SnippetForTag("snap.snap1.app1"), Equals, "connected-plug\npermanent-plug\n")
+}
+
+func (s *specSuite) TestAddSnippetExpandsSnippetVariables(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "test",
+		SecCompConnectedPlugCallback: func(spec *seccomp.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddSnippet("# ###SNAP_INSTANCE_NAME### ###SNAP_REVISION### ###OTHER###")
+			return nil
+		},
+	}
+	s.plug.Snap().InstanceKey = "foo"
+	appSet, err := interfaces.NewSnapAppSet(s.plug.Snap(), nil)
+	c.Assert(err, IsNil)
+	spec := seccomp.NewSpecification(appSet)
+	c.Assert(spec.AddConnectedPlug(iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), DeepEquals, map[string][]string{
+		"snap.snap1_foo.app1": {"# snap1_foo unset ###OTHER###"},
+	})
+}
+
+func (s *specSuite) TestPrivilegedRule(c *C) {
+	restore := seccomp_sandbox.MockActions([]string{"allow", "errno", "log"})
+	defer restore()
+
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Check(spec.PrivilegedRule("mount"), Equals, "mount")
+
+	backend := &seccomp.Backend{}
+	spec = backend.NewSpecification(s.plug.AppSet(), interfaces.ConfinementOptions{LogPrivilegedSyscalls: true}).(*seccomp.Specification)
+	c.Check(spec.PrivilegedRule("mount"), Equals, "?mount")
+	c.Check(spec.PrivilegedRule("ioctl - TIOCSTI"), Equals, "?ioctl - TIOCSTI")
+	c.Check(spec.MissingActions(), HasLen, 0)
+}
+
+func (s *specSuite) TestPrivilegedRuleLogUnsupported(c *C) {
+	restore := seccomp_sandbox.MockActions([]string{"allow", "errno"})
+	defer restore()
+
+	// the syscall is allowed instead
+	backend := &seccomp.Backend{}
+	spec := backend.NewSpecification(s.plug.AppSet(), interfaces.ConfinementOptions{LogPrivilegedSyscalls: true}).(*seccomp.Specification)
+	c.Check(spec.PrivilegedRule("mount"), Equals, "mount")
+	c.Check(spec.MissingActions(), DeepEquals, []string{"log"})
+}
+
+func (s *specSuite) TestAuditedPlugRules(c *C) {
+	restore := seccomp_sandbox.MockActions([]string{"allow", "errno", "log"})
+	defer restore()
+
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "test",
+		SecCompConnectedPlugCallback: func(spec *seccomp.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddSnippet(`# Description: comment
+mount - - - |MS_NOSUID
+~ptrace
+?umount2
+@unrestricted`)
+			return nil
+		},
+	}
+	backend := &seccomp.Backend{}
+
+	// the rules of an audited plug are allowed but logged
+	spec := backend.NewSpecification(s.plug.AppSet(), interfaces.ConfinementOptions{AuditedPlugs: []string{"name"}}).(*seccomp.Specification)
+	c.Assert(spec.AddConnectedPlug(iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.AddPermanentPlug(s.iface, s.plugInfo), IsNil)
+	c.Check(spec.SnippetForTag("snap.snap1.app1"), Equals, `# Description: comment
+?mount - - - |MS_NOSUID
+~ptrace
+?umount2
+@unrestricted
+permanent-plug
+`)
+	c.Check(spec.MissingActions(), HasLen, 0)
+
+	// the rules of other plugs are not
+	spec = backend.NewSpecification(s.plug.AppSet(), interfaces.ConfinementOptions{AuditedPlugs: []string{"other"}}).(*seccomp.Specification)
+	c.Assert(spec.AddConnectedPlug(iface, s.plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.snap1.app1"), Equals, `# Description: comment
+mount - - - |MS_NOSUID
+~ptrace
+?umount2
+@unrestricted
+`)
+}
+
+func (s *specSuite) TestAuditedPlugRulesLogUnsupported(c *C) {
+	restore := seccomp_sandbox.MockActions([]string{"allow", "errno"})
+	defer restore()
+
+	// the syscalls are allowed instead
+	backend := &seccomp.Backend{}
+	spec := backend.NewSpecification(s.plug.AppSet(), interfaces.ConfinementOptions{AuditedPlugs: []string{"name"}}).(*seccomp.Specification)
+	c.Assert(spec.AddConnectedPlug(s.iface, s.plug, s.slot), IsNil)
+	c.Check(spec.SnippetForTag("snap.snap1.app1"), Equals, "connected-plug\n")
+	c.Check(spec.MissingActions(), DeepEquals, []string{"log"})
+}
+
+func (s *specSuite) TestArgumentsRule(c *C) {
+	// specifications which are not prepared by the backend assume that
+	// arguments can be filtered
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Check(spec.ArgumentsRule("mount - - - |MS_NOSUID|MS_NODEV"), Equals, "mount - - - |MS_NOSUID|MS_NODEV")
+	c.Check(spec.ArgumentsRule("mount"), Equals, "mount")
+}
+
+func (s *specSuite) TestAddSyscallRule(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "test",
+		SecCompConnectedPlugCallback: func(spec *seccomp.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			if err := spec.AddSyscallRule(seccomp.SyscallRule{
+				Syscall: "mount",
+				Args:    []seccomp.SyscallArg{seccomp.AnyArg, seccomp.AnyArg, seccomp.AnyArg, seccomp.ArgFlagsSet("MS_RDONLY", "MS_NOSUID")},
+			}); err != nil {
+				return err
+			}
+			if err := spec.AddSyscallRule(seccomp.SyscallRule{
+				Syscall: "socket",
+				Args:    []seccomp.SyscallArg{seccomp.ArgEqual("AF_NETLINK"), seccomp.AnyArg, seccomp.ArgEqual("NETLINK_ROUTE"), seccomp.AnyArg},
+			}); err != nil {
+				return err
+			}
+			return spec.AddSyscallRule(seccomp.SyscallRule{Syscall: "sync"})
+		},
+	}
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), DeepEquals, map[string][]string{
+		"snap.snap1.app1": {
+			"mount - - - |MS_RDONLY|MS_NOSUID",
+			"socket AF_NETLINK - NETLINK_ROUTE",
+			"sync",
+		},
+	})
+}
+
+func (s *specSuite) TestSyscallRuleString(c *C) {
+	for _, t := range []struct {
+		rule     seccomp.SyscallRule
+		expected string
+	}{
+		{seccomp.SyscallRule{Syscall: "sync"}, "sync"},
+		{seccomp.SyscallRule{Syscall: "sync", Args: []seccomp.SyscallArg{seccomp.AnyArg}}, "sync"},
+		{seccomp.SyscallRule{Syscall: "ioctl", Args: []seccomp.SyscallArg{seccomp.AnyArg, seccomp.ArgEqual("TIOCSTI")}}, "ioctl - TIOCSTI"},
+		{seccomp.SyscallRule{Syscall: "setpriority", Args: []seccomp.SyscallArg{seccomp.ArgNotEqual("1"), seccomp.ArgLess("2"), seccomp.ArgLessOrEqual("3")}}, "setpriority !1 <2 <=3"},
+		{seccomp.SyscallRule{Syscall: "s", Args: []seccomp.SyscallArg{seccomp.ArgGreater("1"), seccomp.ArgGreaterOrEqual("2"), seccomp.ArgMaskedEqual("3", "1")}}, "s >1 >=2 3|1"},
+		{seccomp.SyscallRule{Syscall: "chown", Args: []seccomp.SyscallArg{seccomp.AnyArg, seccomp.ArgUser("root"), seccomp.ArgGroup("root")}}, "chown - u:root g:root"},
+	} {
+		c.Check(t.rule.String(), Equals, t.expected)
+	}
+}
+
+func (s *specSuite) TestAddSyscallRuleInvalid(c *C) {
+	var args []seccomp.SyscallArg
+	for i := 0; i < 7; i++ {
+		args = append(args, seccomp.ArgEqual("1"))
+	}
+	for _, t := range []struct {
+		rule seccomp.SyscallRule
+		err  string
+	}{
+		{seccomp.SyscallRule{}, `invalid syscall name ""`},
+		{seccomp.SyscallRule{Syscall: "~mount"}, `invalid syscall name "~mount"`},
+		{seccomp.SyscallRule{Syscall: "mount -"}, `invalid syscall name "mount -"`},
+		{seccomp.SyscallRule{Syscall: "mount", Args: args}, `cannot filter syscall "mount" on more than 6 arguments`},
+		{seccomp.SyscallRule{Syscall: "mount", Args: []seccomp.SyscallArg{seccomp.ArgEqual("")}}, `invalid filter "" for argument 0 of syscall "mount"`},
+		{seccomp.SyscallRule{Syscall: "mount", Args: []seccomp.SyscallArg{seccomp.AnyArg, seccomp.ArgNotEqual("")}}, `invalid filter "!" for argument 1 of syscall "mount"`},
+		{seccomp.SyscallRule{Syscall: "mount", Args: []seccomp.SyscallArg{seccomp.ArgEqual("1 2")}}, `invalid filter "1 2" for argument 0 of syscall "mount"`},
+		{seccomp.SyscallRule{Syscall: "mount", Args: []seccomp.SyscallArg{seccomp.ArgFlagsSet("MS_RDONLY", "")}}, `invalid filter "\\|MS_RDONLY\\|" for argument 0 of syscall "mount"`},
+		{seccomp.SyscallRule{Syscall: "chown", Args: []seccomp.SyscallArg{seccomp.ArgUser("")}}, `invalid filter "u:" for argument 0 of syscall "chown"`},
+	} {
+		spec := seccomp.NewSpecification(s.plug.AppSet())
+		c.Check(spec.AddSyscallRule(t.rule), ErrorMatches, t.err)
+		c.Check(spec.Snippets(), HasLen, 0)
+	}
+}
+
+func (s *specSuite) TestRequireAction(c *C) {
+	restore := seccomp_sandbox.MockActions([]string{"allow", "errno", "kill_process", "log"})
+	defer restore()
+
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	supported, err := spec.RequireAction("log")
+	c.Assert(err, IsNil)
+	c.Check(supported, Equals, true)
+	c.Check(spec.MissingActions(), HasLen, 0)
+
+	for _, action := range []string{"user_notif", "trap", "user_notif"} {
+		supported, err = spec.RequireAction(action)
+		c.Assert(err, IsNil)
+		c.Check(supported, Equals, false)
+	}
+	c.Check(spec.MissingActions(), DeepEquals, []string{"trap", "user_notif"})
+}
+
+func (s *specSuite) TestRequireActionUnknown(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	supported, err := spec.RequireAction("notify")
+	c.Check(err, ErrorMatches, `unknown seccomp action "notify"`)
+	c.Check(supported, Equals, false)
+	c.Check(spec.MissingActions(), HasLen, 0)
+}
+
+func (s *specSuite) TestConfinement(c *C) {
+	spec := seccomp.NewSpecification(s.plug.AppSet())
+	c.Check(spec.Confinement(), Equals, snap.StrictConfinement)
+
+	const classicYaml = `name: snap1
+version: 1
+confinement: classic
+apps:
+ app1:
+  plugs: [name]
+`
+	plug, _ := ifacetest.MockConnectedPlug(c, classicYaml, nil, "name")
+	spec = seccomp.NewSpecification(plug.AppSet())
+	c.Check(spec.Confinement(), Equals, snap.ClassicConfinement)
+
+	// the backend prepares specifications for the effective confinement
+	backend := &seccomp.Backend{}
+	spec = backend.NewSpecification(plug.AppSet(), interfaces.ConfinementOptions{Classic: true, JailMode: true}).(*seccomp.Specification)
+	c.Check(spec.Confinement(), Equals, snap.StrictConfinement)
+	spec = backend.NewSpecification(s.plug.AppSet(), interfaces.ConfinementOptions{DevMode: true}).(*seccomp.Specification)
+	c.Check(spec.Confinement(), Equals, snap.DevModeConfinement)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/snapcore/snapd/dirs"
 	"github.com/snapcore/snapd/logger"
 	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/snap/naming"
 )
 
 // SnapAppSet is a helper that provides information about executable elements of
@@ -144,11 +145,42 @@ func (a *SnapAppSet) SecurityTagsForPlug(plug *snap.PlugInfo) ([]string, error)
 		tags = append(tags, hook.SecurityTag())
 	}
 
+	if plugIsHooksOnly(plug) {
+		tags = hookSecurityTags(tags)
+	}
+
 	sort.Strings(tags)
 
 	return tags, nil
 }
 
+// plugIsHooksOnly returns true if the plug sets the "hooks-only" attribute,
+// in which case the plug only applies to the hooks bound to it, and not to
+// any of the apps.
+func plugIsHooksOnly(plug *snap.PlugInfo) bool {
+	hooksOnly, _ := plug.Attrs["hooks-only"].(bool)
+	return hooksOnly
+}
+
+// isHookSecurityTag returns true if the security tag is the one of a snap or
+// component hook.
+func isHookSecurityTag(tag string) bool {
+	_, err := naming.ParseHookSecurityTag(tag)
+	return err == nil
+}
+
+// hookSecurityTags returns the subset of the given security tags which belong
+// to hooks.
+func hookSecurityTags(tags []string) []string {
+	hookTags := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if isHookSecurityTag(tag) {
+			hookTags = append(hookTags, tag)
+		}
+	}
+	return hookTags
+}
+
 // SecurityTagsForConnectedSlot returns the security tags for the given slot. These
 // are derived from the security tags of the apps and hooks that are associated
 // with the slot.
@@ -256,6 +256,82 @@ slots:
 	})
 }
 
+func (s *snapAppSetSuite) TestPlugSecurityTagsSorted(c *C) {
+	const yaml = `name: name
+version: 1
+apps:
+  zeta:
+    plugs: [plug]
+  alpha:
+    plugs: [plug]
+  middle:
+hooks:
+  remove:
+    plugs: [plug]
+  configure:
+    plugs: [plug]
+plugs:
+  plug:
+    interface: fuse-support`
+
+	set, connectedPlug := mockAppSetAndConnectedPlug(c, yaml, nil, nil, "plug")
+
+	tags, err := set.SecurityTagsForConnectedPlug(connectedPlug)
+	c.Assert(err, IsNil)
+	c.Assert(tags, DeepEquals, []string{
+		"snap.name.alpha",
+		"snap.name.hook.configure",
+		"snap.name.hook.remove",
+		"snap.name.zeta",
+	})
+}
+
+func (s *snapAppSetSuite) TestPlugSecurityTagsHooksOnly(c *C) {
+	const yaml = `name: name
+version: 1
+apps:
+  app1:
+  app2:
+components:
+  comp:
+    type: standard
+    hooks:
+      install:
+hooks:
+  install:
+  configure:
+plugs:
+  plug:
+    interface: fuse-support
+    hooks-only: true
+  other:
+    interface: fuse-support
+    hooks-only: false`
+
+	set, connectedPlug := mockAppSetAndConnectedPlug(c, yaml, []string{
+		"component: name+comp\ntype: standard\nversion: 1",
+	}, nil, "plug")
+
+	tags, err := set.SecurityTagsForConnectedPlug(connectedPlug)
+	c.Assert(err, IsNil)
+	c.Assert(tags, DeepEquals, []string{
+		"snap.name+comp.hook.install",
+		"snap.name.hook.configure",
+		"snap.name.hook.install",
+	})
+	c.Check(connectedPlug.LabelExpression(), Equals, `"snap.name{+comp.hook.install,.hook.configure,.hook.install}"`)
+
+	tags, err = set.SecurityTagsForPlug(set.Info().Plugs["other"])
+	c.Assert(err, IsNil)
+	c.Assert(tags, DeepEquals, []string{
+		"snap.name+comp.hook.install",
+		"snap.name.app1",
+		"snap.name.app2",
+		"snap.name.hook.configure",
+		"snap.name.hook.install",
+	})
+}
+
 func (s *snapAppSetSuite) TestPlugSecurityTagsWrongSnap(c *C) {
 	const yaml = `name: name
 version: 1`
@@ -444,6 +520,30 @@ plugs:
 	}
 }
 
+func (s *snapAppSetSuite) TestPlugRunnablesHooksOnly(c *C) {
+	const yaml = `name: name
+version: 1
+apps:
+  app:
+hooks:
+  install:
+plugs:
+  plug:
+    hooks-only: true
+`
+	info := snaptest.MockInfo(c, yaml, nil)
+	set, err := interfaces.NewSnapAppSet(info, nil)
+	c.Assert(err, IsNil)
+
+	plug := interfaces.NewConnectedPlug(info.Plugs["plug"], set, nil, nil)
+	c.Check(plug.Runnables(), DeepEquals, []snap.Runnable{
+		{
+			CommandName: "hook.install",
+			SecurityTag: "snap.name.hook.install",
+		},
+	})
+}
+
 func (s *snapAppSetSuite) TestSlotRunnables(c *C) {
 	const yaml = `name: name
 version: 1
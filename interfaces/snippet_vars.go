@@ -0,0 +1,97 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package interfaces
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/snapcore/snapd/snap"
+)
+
+// SnippetVariables holds the values which the snap variables of security
+// snippets expand to, so that interfaces do not need to account for parallel
+// instances by themselves. The variables are:
+//
+//   - ###SNAP_NAME###, the name of the snap without the instance key
+//   - ###SNAP_INSTANCE_NAME###, the name of the snap with the instance key
+//   - ###SNAP_REVISION###, the revision of the snap
+//   - ###SNAP_DATA### and ###SNAP_COMMON###, the system writable directories
+//     of the snap
+//   - ###SNAP_USER_DATA### and ###SNAP_USER_COMMON###, the writable
+//     directories of the snap in the home directories of the users
+//
+// parallel-installs: SNAP_{DATA,COMMON} are remapped to the directories of
+// the snap name in the mount namespace of the instance, they expand to the
+// directories of both names for a parallel instance. SNAP_USER_{DATA,COMMON}
+// are not remapped and only refer to the instance name.
+type SnippetVariables struct {
+	SnapName     string
+	InstanceName string
+	Revision     string
+	// ParallelInstance is set when the snap is a parallel instance.
+	ParallelInstance bool
+}
+
+// SnapSnippetVariables returns the snippet variables with the values of the
+// given snap.
+func SnapSnippetVariables(info *snap.Info) *SnippetVariables {
+	return &SnippetVariables{
+		SnapName:         info.SnapName(),
+		InstanceName:     info.InstanceName(),
+		Revision:         info.Revision.String(),
+		ParallelInstance: info.InstanceKey != "",
+	}
+}
+
+var snippetVariablePattern = regexp.MustCompile(`###SNAP_[A-Z_]+###`)
+
+// Expand returns the given snippet with the snap variables it refers to
+// replaced by their values. Other placeholders are left as they are, as is
+// the snippet when v is nil.
+func (v *SnippetVariables) Expand(snippet string) string {
+	if v == nil || !strings.Contains(snippet, "###SNAP_") {
+		return snippet
+	}
+	systemDir := "/var/snap/" + v.SnapName
+	if v.ParallelInstance {
+		systemDir = "/var/snap/{" + v.SnapName + "," + v.InstanceName + "}"
+	}
+	userDir := "/home/*/snap/" + v.InstanceName
+	return snippetVariablePattern.ReplaceAllStringFunc(snippet, func(placeholder string) string {
+		switch placeholder {
+		case "###SNAP_NAME###":
+			return v.SnapName
+		case "###SNAP_INSTANCE_NAME###":
+			return v.InstanceName
+		case "###SNAP_REVISION###":
+			return v.Revision
+		case "###SNAP_DATA###":
+			return systemDir + "/" + v.Revision
+		case "###SNAP_COMMON###":
+			return systemDir + "/common"
+		case "###SNAP_USER_DATA###":
+			return userDir + "/" + v.Revision
+		case "###SNAP_USER_COMMON###":
+			return userDir + "/common"
+		}
+		return placeholder
+	})
+}
@@ -0,0 +1,63 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package interfaces_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	. "github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/snap"
+	"github.com/snapcore/snapd/snap/snaptest"
+)
+
+type SnippetVarsSuite struct{}
+
+var _ = Suite(&SnippetVarsSuite{})
+
+const snippetVarsTemplate = `###SNAP_NAME### ###SNAP_INSTANCE_NAME### ###SNAP_REVISION###
+###SNAP_DATA### ###SNAP_COMMON###
+###SNAP_USER_DATA### ###SNAP_USER_COMMON###
+###SNAP_UNKNOWN### ###SLOT_SECURITY_TAGS###`
+
+func (s *SnippetVarsSuite) TestSnapSnippetVariables(c *C) {
+	info := snaptest.MockInfo(c, "name: foo\nversion: 1\n", &snap.SideInfo{Revision: snap.R(42)})
+	vars := SnapSnippetVariables(info)
+	c.Check(vars, DeepEquals, &SnippetVariables{SnapName: "foo", InstanceName: "foo", Revision: "42"})
+	c.Check(vars.Expand(snippetVarsTemplate), Equals, `foo foo 42
+/var/snap/foo/42 /var/snap/foo/common
+/home/*/snap/foo/42 /home/*/snap/foo/common
+###SNAP_UNKNOWN### ###SLOT_SECURITY_TAGS###`)
+}
+
+func (s *SnippetVarsSuite) TestSnapSnippetVariablesParallelInstance(c *C) {
+	info := snaptest.MockInfo(c, "name: foo\nversion: 1\n", &snap.SideInfo{Revision: snap.R(42)})
+	info.InstanceKey = "bar"
+	vars := SnapSnippetVariables(info)
+	c.Check(vars, DeepEquals, &SnippetVariables{SnapName: "foo", InstanceName: "foo_bar", Revision: "42", ParallelInstance: true})
+	c.Check(vars.Expand(snippetVarsTemplate), Equals, `foo foo_bar 42
+/var/snap/{foo,foo_bar}/42 /var/snap/{foo,foo_bar}/common
+/home/*/snap/foo_bar/42 /home/*/snap/foo_bar/common
+###SNAP_UNKNOWN### ###SLOT_SECURITY_TAGS###`)
+}
+
+func (s *SnippetVarsSuite) TestExpandNoVariables(c *C) {
+	var vars *SnippetVariables
+	c.Check(vars.Expand(snippetVarsTemplate), Equals, snippetVarsTemplate)
+}
@@ -361,6 +361,51 @@ func CurrentSystemKey() (any, error) {
 	return currentSystemKey, err
 }
 
+// BackendSystemKeys returns, for each of the given security backends, the parts
+// of the current system key which affect the security profiles generated by
+// that backend. The returned values are opaque and only meant to be compared
+// with values obtained earlier, they change whenever snapd itself changes.
+//
+// Unknown security backends are assumed to depend on the whole system key.
+func BackendSystemKeys(backends []SecuritySystem, extraData SystemKeyExtraData) (map[SecuritySystem]string, error) {
+	sk, err := generateSystemKey()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[SecuritySystem]string, len(backends))
+	for _, backend := range backends {
+		bk := systemKey{
+			Version: sk.Version,
+			BuildID: sk.BuildID,
+		}
+		switch backend {
+		case SecurityAppArmor:
+			// apparmor-parser-features is derived from
+			// apparmor-parser-mtime, see SystemKeyMismatch
+			bk.AppArmorFeatures = sk.AppArmorFeatures
+			bk.AppArmorParserMtime = sk.AppArmorParserMtime
+			bk.AppArmorPrompting = extraData.AppArmorPrompting
+			bk.NFSHome = sk.NFSHome
+			bk.OverlayRoot = sk.OverlayRoot
+		case SecuritySecComp:
+			bk.SecCompActions = sk.SecCompActions
+			bk.SeccompCompilerVersion = sk.SeccompCompilerVersion
+		case SecurityUDev, SecurityCgroup:
+			bk.CgroupVersion = sk.CgroupVersion
+		case SecurityDBus, SecurityMount, SecurityKMod, SecuritySystemd, SecurityPolkit,
+			SecurityLdconfig, SecurityConfigfiles, SecuritySymlinks:
+			// only depend on snapd itself
+		default:
+			bk = *sk
+			bk.AppArmorParserFeatures = nil
+			bk.AppArmorPrompting = extraData.AppArmorPrompting
+		}
+		keys[backend] = bk.String()
+	}
+	return keys, nil
+}
+
 // SystemKeysMatch returns whether the given system keys match.
 func SystemKeysMatch(systemKey1, systemKey2 any) (bool, error) {
 	// precondition check
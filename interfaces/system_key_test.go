@@ -193,6 +193,85 @@ func (s *systemKeySuite) TestInterfaceWriteSystemKeyErrorOnBuildID(c *C) {
 	c.Assert(err, ErrorMatches, "no build ID for you")
 }
 
+func (s *systemKeySuite) TestBackendSystemKeys(c *C) {
+	backends := []interfaces.SecuritySystem{
+		interfaces.SecurityAppArmor,
+		interfaces.SecuritySecComp,
+		interfaces.SecurityUDev,
+		interfaces.SecurityMount,
+		"other",
+	}
+	extraData := interfaces.SystemKeyExtraData{}
+
+	restore := interfaces.MockSystemKey(`
+{
+"build-id": "7a94e9736c091b3984bd63f5aebfc883c4d859e0",
+"apparmor-features": ["caps", "dbus"],
+"seccomp-features": ["allow"],
+"cgroup-version": "2"
+}
+`)
+	keys, err := interfaces.BackendSystemKeys(backends, extraData)
+	restore()
+	c.Assert(err, IsNil)
+	c.Assert(keys, HasLen, 5)
+	c.Check(keys[interfaces.SecurityMount], Equals, `{"version":0,"build-id":"7a94e9736c091b3984bd63f5aebfc883c4d859e0",`+
+		`"apparmor-features":null,"apparmor-parser-mtime":0,`+
+		`"apparmor-parser-features":null,"apparmor-prompting":false,"nfs-home":false,`+
+		`"overlay-root":"","seccomp-features":null,"seccomp-compiler-version":"",`+
+		`"cgroup-version":""}`)
+
+	// more apparmor features only affect apparmor and unknown backends
+	restore = interfaces.MockSystemKey(`
+{
+"build-id": "7a94e9736c091b3984bd63f5aebfc883c4d859e0",
+"apparmor-features": ["caps", "dbus", "more"],
+"seccomp-features": ["allow"],
+"cgroup-version": "2"
+}
+`)
+	moreKeys, err := interfaces.BackendSystemKeys(backends, extraData)
+	restore()
+	c.Assert(err, IsNil)
+	c.Check(moreKeys[interfaces.SecurityAppArmor], Not(Equals), keys[interfaces.SecurityAppArmor])
+	c.Check(moreKeys["other"], Not(Equals), keys["other"])
+	c.Check(moreKeys[interfaces.SecuritySecComp], Equals, keys[interfaces.SecuritySecComp])
+	c.Check(moreKeys[interfaces.SecurityUDev], Equals, keys[interfaces.SecurityUDev])
+	c.Check(moreKeys[interfaces.SecurityMount], Equals, keys[interfaces.SecurityMount])
+
+	// prompting only affects apparmor and unknown backends
+	restore = interfaces.MockSystemKey(`
+{
+"build-id": "7a94e9736c091b3984bd63f5aebfc883c4d859e0",
+"apparmor-features": ["caps", "dbus"],
+"seccomp-features": ["allow"],
+"cgroup-version": "2"
+}
+`)
+	promptingKeys, err := interfaces.BackendSystemKeys(backends, interfaces.SystemKeyExtraData{AppArmorPrompting: true})
+	restore()
+	c.Assert(err, IsNil)
+	c.Check(promptingKeys[interfaces.SecurityAppArmor], Not(Equals), keys[interfaces.SecurityAppArmor])
+	c.Check(promptingKeys["other"], Not(Equals), keys["other"])
+	c.Check(promptingKeys[interfaces.SecuritySecComp], Equals, keys[interfaces.SecuritySecComp])
+
+	// a different snapd affects all backends
+	restore = interfaces.MockSystemKey(`
+{
+"build-id": "0000000000000000000000000000000000000000",
+"apparmor-features": ["caps", "dbus"],
+"seccomp-features": ["allow"],
+"cgroup-version": "2"
+}
+`)
+	newKeys, err := interfaces.BackendSystemKeys(backends, extraData)
+	restore()
+	c.Assert(err, IsNil)
+	for _, backend := range backends {
+		c.Check(newKeys[backend], Not(Equals), keys[backend], Commentf("backend %q", backend))
+	}
+}
+
 func (s *systemKeySuite) TestInterfaceSystemKeyMismatchHappy(c *C) {
 	s.AddCleanup(interfaces.MockSystemKey(`
 {
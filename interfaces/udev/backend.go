@@ -31,6 +31,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/snapcore/snapd/dirs"
@@ -133,22 +134,8 @@ func (b *Backend) Setup(appSet *interfaces.SnapAppSet, opts interfaces.Confineme
 			needReload = true
 		}
 	} else {
-		var rulesBuf bytes.Buffer
-		rulesBuf.WriteString("# This file is automatically generated.\n")
-		if (opts.DevMode || opts.Classic) && !opts.JailMode {
-			rulesBuf.WriteString("# udev tagging/device cgroups disabled with non-strict mode snaps\n")
-		}
-		for _, snippet := range content {
-			if (opts.DevMode || opts.Classic) && !opts.JailMode {
-				rulesBuf.WriteRune('#')
-				snippet = strings.Replace(snippet, "\n", "\n#", -1)
-			}
-			rulesBuf.WriteString(snippet)
-			rulesBuf.WriteByte('\n')
-		}
-
 		rulesFileState := &osutil.MemoryFileState{
-			Content: rulesBuf.Bytes(),
+			Content: rulesFileContent(content, opts),
 			Mode:    0644,
 		}
 
@@ -174,6 +161,38 @@ func (b *Backend) Setup(appSet *interfaces.SnapAppSet, opts interfaces.Confineme
 		}
 	}
 
+	// the file serves as a checkpoint that udev backend was set up
+	err = osutil.EnsureFileState(selfManageDeviceCgroupPath, &osutil.MemoryFileState{
+		Content: deviceFileContent(udevSpec, opts),
+		Mode:    0644,
+	})
+	if err != nil && !errors.Is(err, osutil.ErrSameState) {
+		return err
+	}
+	return nil
+}
+
+// rulesFileContent returns the content of the udev rules file of the snap.
+func rulesFileContent(content []string, opts interfaces.ConfinementOptions) []byte {
+	var rulesBuf bytes.Buffer
+	rulesBuf.WriteString("# This file is automatically generated.\n")
+	if (opts.DevMode || opts.Classic) && !opts.JailMode {
+		rulesBuf.WriteString("# udev tagging/device cgroups disabled with non-strict mode snaps\n")
+	}
+	for _, snippet := range content {
+		if (opts.DevMode || opts.Classic) && !opts.JailMode {
+			rulesBuf.WriteRune('#')
+			snippet = strings.Replace(snippet, "\n", "\n#", -1)
+		}
+		rulesBuf.WriteString(snippet)
+		rulesBuf.WriteByte('\n')
+	}
+	return rulesBuf.Bytes()
+}
+
+// deviceFileContent returns the content of the file telling snap-confine how
+// to set up the device cgroup of the snap.
+func deviceFileContent(udevSpec *Specification, opts interfaces.ConfinementOptions) []byte {
 	var deviceBuf bytes.Buffer
 	deviceBuf.WriteString("# This file is automatically generated.\n")
 
@@ -189,16 +208,43 @@ func (b *Backend) Setup(appSet *interfaces.SnapAppSet, opts interfaces.Confineme
 		deviceBuf.WriteString("# snap uses non-strict confinement.\n")
 		deviceBuf.WriteString("non-strict=true\n")
 	}
+	if deviceRules := udevSpec.DeviceRules(); len(deviceRules) > 0 {
+		// Devices explicitly allowed by interfaces, snap-confine adds
+		// those to the device cgroup of the respective app or hook.
+		deviceBuf.WriteString("# devices allowed in the device cgroup.\n")
+		securityTags := make([]string, 0, len(deviceRules))
+		for securityTag := range deviceRules {
+			securityTags = append(securityTags, securityTag)
+		}
+		sort.Strings(securityTags)
+		for _, securityTag := range securityTags {
+			rules := make([]string, 0, len(deviceRules[securityTag]))
+			for _, rule := range deviceRules[securityTag] {
+				rules = append(rules, rule.String())
+			}
+			fmt.Fprintf(&deviceBuf, "allow-devices.%s=%s\n", securityTag, strings.Join(rules, ","))
+		}
+	}
+	return deviceBuf.Bytes()
+}
 
-	// the file serves as a checkpoint that udev backend was set up
-	err = osutil.EnsureFileState(selfManageDeviceCgroupPath, &osutil.MemoryFileState{
-		Content: deviceBuf.Bytes(),
-		Mode:    0644,
-	})
-	if err != nil && !errors.Is(err, osutil.ErrSameState) {
-		return err
+// Profiles returns the udev rules and the device cgroup settings of a given
+// snap, as Setup would write them.
+func (b *Backend) Profiles(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, repo *interfaces.Repository) (map[string][]byte, error) {
+	snapName := appSet.InstanceName()
+	spec, err := repo.SnapSpecification(b.Name(), appSet, opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot obtain udev specification for snap %q: %w", snapName, err)
 	}
-	return nil
+
+	udevSpec := spec.(*Specification)
+	profiles := map[string][]byte{
+		snapDeviceCgroupSelfManageFilePath(snapName): deviceFileContent(udevSpec, opts),
+	}
+	if content := b.deriveContent(udevSpec); len(content) > 0 && !udevSpec.ControlsDeviceCgroup() {
+		profiles[snapRulesFilePath(snapName)] = rulesFileContent(content, opts)
+	}
+	return profiles, nil
 }
 
 // Remove removes udev rules specific to a given snap.
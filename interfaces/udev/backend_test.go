@@ -23,6 +23,7 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"sort"
 
 	. "gopkg.in/check.v1"
 
@@ -442,6 +443,47 @@ func (s *backendSuite) TestDeviceCgroupAlwaysPresent(c *C) {
 	s.RemoveSnap(c, snapInfo)
 }
 
+func (s *backendSuite) TestAllowDeviceCharAndBlock(c *C) {
+	s.Iface.UDevPermanentSlotCallback = func(spec *udev.Specification, slot *snap.SlotInfo) error {
+		if err := spec.AllowDevice(udev.CharDevice, 10, 229); err != nil {
+			return err
+		}
+		return spec.AllowDevice(udev.BlockDevice, 7, 0)
+	}
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", ifacetest.SambaYamlV1, 0)
+	fname := filepath.Join(dirs.SnapUdevRulesDir, "70-snap.samba.rules")
+	c.Check(fname, testutil.FileEquals, `# This file is automatically generated.
+# iface
+SUBSYSTEM!="block", ENV{MAJOR}=="10", ENV{MINOR}=="229", TAG+="snap_samba_smbd"
+# iface
+SUBSYSTEM=="block", ENV{MAJOR}=="7", ENV{MINOR}=="0", TAG+="snap_samba_smbd"
+TAG=="snap_samba_smbd", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="/usr/lib/snapd/snap-device-helper $env{ACTION} snap_samba_smbd $devpath $major:$minor"
+`)
+	// the devices are also listed for snap-confine
+	cgroupFname := filepath.Join(dirs.SnapCgroupPolicyDir, "snap.samba.device")
+	c.Check(cgroupFname, testutil.FileEquals, "# This file is automatically generated.\n"+
+		"# devices allowed in the device cgroup.\n"+
+		"allow-devices.snap.samba.smbd=b 7:0 rwm,c 10:229 rwm\n",
+	)
+	s.RemoveSnap(c, snapInfo)
+	c.Check(cgroupFname, testutil.FileAbsent)
+}
+
+func (s *backendSuite) TestAllowDeviceControlsDeviceCgroup(c *C) {
+	s.Iface.UDevPermanentSlotCallback = func(spec *udev.Specification, slot *snap.SlotInfo) error {
+		spec.SetControlsDeviceCgroup()
+		return spec.AllowDeviceRule("c 10:229 rwm")
+	}
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", ifacetest.SambaYamlV1, 0)
+	// the snap manages the device cgroup by itself, no devices are listed
+	cgroupFname := filepath.Join(dirs.SnapCgroupPolicyDir, "snap.samba.device")
+	c.Check(cgroupFname, testutil.FileEquals, "# This file is automatically generated.\n"+
+		"# snap is allowed to manage own device cgroup.\n"+
+		"self-managed=true\n",
+	)
+	s.RemoveSnap(c, snapInfo)
+}
+
 func (s *backendSuite) TestCombineSnippetsWithActualSnippetsWithNewline(c *C) {
 	// NOTE: Hand out a permanent snippet so that .rules file is generated.
 	s.Iface.UDevPermanentSlotCallback = func(spec *udev.Specification, slot *snap.SlotInfo) error {
@@ -659,3 +701,26 @@ func (s *backendSuite) TestPreseed(c *C) {
 
 	c.Check(s.udevadmCmd.Calls(), HasLen, 0)
 }
+
+func (s *backendSuite) TestProfilesMatchSetup(c *C) {
+	s.Iface.UDevPermanentSlotCallback = func(spec *udev.Specification, slot *snap.SlotInfo) error {
+		spec.AddSnippet("sample")
+		return nil
+	}
+	snapInfo := s.InstallSnap(c, interfaces.ConfinementOptions{}, "", ifacetest.SambaYamlV1, 1)
+	appSet, err := interfaces.NewSnapAppSet(snapInfo, nil)
+	c.Assert(err, IsNil)
+
+	profiles, err := s.Backend.(interfaces.SecurityBackendProfiles).Profiles(appSet, interfaces.ConfinementOptions{}, s.Repo)
+	c.Assert(err, IsNil)
+	paths := make([]string, 0, len(profiles))
+	for path, content := range profiles {
+		paths = append(paths, path)
+		c.Check(path, testutil.FileEquals, string(content))
+	}
+	sort.Strings(paths)
+	c.Check(paths, DeepEquals, []string{
+		filepath.Join(dirs.SnapUdevRulesDir, "70-snap.samba.rules"),
+		filepath.Join(dirs.SnapCgroupPolicyDir, "snap.samba.device"),
+	})
+}
@@ -21,7 +21,10 @@ package udev
 
 import (
 	"fmt"
+	"math"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/snapcore/snapd/dirs"
@@ -48,6 +51,10 @@ type Specification struct {
 	securityTags             []string
 	udevadmSubsystemTriggers []string
 	controlsDeviceCgroup     bool
+
+	// deviceRules maps security tags to the device cgroup entries
+	// explicitly allowed for them
+	deviceRules map[string][]DeviceRule
 }
 
 func NewSpecification(appSet *interfaces.SnapAppSet) *Specification {
@@ -109,7 +116,10 @@ func udevTag(securityTag string) string {
 }
 
 // TagDevice adds an app/hook specific udev tag to devices described by the
-// snippet and adds an app/hook-specific RUN rule for hotplugging.
+// snippet and adds an app/hook-specific RUN rule for hotplugging. Devices are
+// tagged for all the apps, user services included, and hooks, component hooks
+// included, which are bound to the plug or slot in scope, interfaces do not
+// need to handle them separately.
 func (spec *Specification) TagDevice(snippet string) {
 	for _, securityTag := range spec.securityTags {
 		tag := udevTag(securityTag)
@@ -123,6 +133,178 @@ func (spec *Specification) TagDevice(snippet string) {
 	}
 }
 
+// DevicePresent returns whether a device node matching one of the given glob
+// patterns, such as "/dev/tee[0-9]*", exists on the system.
+func DevicePresent(patterns ...string) bool {
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(dirs.GlobalRootDir, pattern))
+		if err == nil && len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// TagDeviceIfPresent is like TagDevice but only tags the devices when a device
+// node matching one of the given patterns is present, it returns whether the
+// devices were tagged. The rules are generated along with the security
+// profiles, so this is meant for built-in hardware which is present at boot
+// and not for hotplugged devices.
+func (spec *Specification) TagDeviceIfPresent(snippet string, patterns ...string) bool {
+	if !DevicePresent(patterns...) {
+		return false
+	}
+	spec.TagDevice(snippet)
+	return true
+}
+
+// DeviceType is the type of a device node, as distinguished by the device
+// cgroup. Character and block devices have independent major:minor number
+// spaces.
+type DeviceType string
+
+const (
+	// CharDevice is the type of character devices, e.g. /dev/fuse.
+	CharDevice DeviceType = "c"
+	// BlockDevice is the type of block devices, e.g. /dev/sda.
+	BlockDevice DeviceType = "b"
+)
+
+// AnyMinor matches all the minor numbers of a given major device number.
+const AnyMinor uint32 = math.MaxUint32
+
+// DeviceRule is an entry of the device cgroup allow-list, in the same notation
+// as used by the devices.allow file of the cgroup v1 devices controller, such
+// as "c 10:229 rwm".
+type DeviceRule struct {
+	Type  DeviceType
+	Major uint32
+	Minor uint32
+}
+
+// String returns the device cgroup notation of the rule.
+func (r DeviceRule) String() string {
+	minor := "*"
+	if r.Minor != AnyMinor {
+		minor = strconv.FormatUint(uint64(r.Minor), 10)
+	}
+	return fmt.Sprintf("%s %d:%s rwm", r.Type, r.Major, minor)
+}
+
+// ParseDeviceRule parses a device cgroup entry such as "c 10:229 rwm" or
+// "b 7:* rwm". The access may be omitted and when given it must be "rwm", as
+// this is the only access snap-confine grants in the device cgroup.
+func ParseDeviceRule(rule string) (DeviceRule, error) {
+	fields := strings.Fields(rule)
+	if len(fields) < 2 || len(fields) > 3 {
+		return DeviceRule{}, fmt.Errorf("invalid device rule %q", rule)
+	}
+	devType := DeviceType(fields[0])
+	if devType != CharDevice && devType != BlockDevice {
+		return DeviceRule{}, fmt.Errorf("invalid device type %q in device rule %q", devType, rule)
+	}
+	majorStr, minorStr, ok := strings.Cut(fields[1], ":")
+	if !ok {
+		return DeviceRule{}, fmt.Errorf("invalid device number %q in device rule %q", fields[1], rule)
+	}
+	major, err := strconv.ParseUint(majorStr, 10, 32)
+	if err != nil {
+		return DeviceRule{}, fmt.Errorf("invalid device number %q in device rule %q", fields[1], rule)
+	}
+	minor := uint64(AnyMinor)
+	if minorStr != "*" {
+		minor, err = strconv.ParseUint(minorStr, 10, 32)
+		if err != nil || minor == uint64(AnyMinor) {
+			return DeviceRule{}, fmt.Errorf("invalid device number %q in device rule %q", fields[1], rule)
+		}
+	}
+	if len(fields) == 3 && fields[2] != "rwm" {
+		return DeviceRule{}, fmt.Errorf("unsupported access %q in device rule %q", fields[2], rule)
+	}
+	return DeviceRule{Type: devType, Major: uint32(major), Minor: uint32(minor)}, nil
+}
+
+// AllowDevice adds an app/hook specific udev tag to the device of the given
+// type and major:minor number, so that it is added to the device cgroup of the
+// apps and hooks in scope. The device type is matched through the udev
+// subsystem, which is also what snap-device-helper uses to pick the type of
+// the device cgroup entry. Use AnyMinor to allow all the devices of the given
+// major number.
+//
+// The device is also recorded in the device cgroup allow-list of the apps and
+// hooks, which snap-confine applies when setting up the device cgroup, with
+// both cgroup v1 and cgroup v2 (eBPF) device filtering. This lets the apps
+// access the device also when it has not been tagged by udev yet.
+func (spec *Specification) AllowDevice(devType DeviceType, major, minor uint32) error {
+	var subsystem string
+	switch devType {
+	case CharDevice:
+		subsystem = `SUBSYSTEM!="block"`
+	case BlockDevice:
+		subsystem = `SUBSYSTEM=="block"`
+	default:
+		return fmt.Errorf("invalid device type %q", devType)
+	}
+	if minor == AnyMinor {
+		spec.TagDevice(fmt.Sprintf(`%s, ENV{MAJOR}=="%d"`, subsystem, major))
+	} else {
+		spec.TagDevice(fmt.Sprintf(`%s, ENV{MAJOR}=="%d", ENV{MINOR}=="%d"`, subsystem, major, minor))
+	}
+
+	rule := DeviceRule{Type: devType, Major: major, Minor: minor}
+	for _, securityTag := range spec.securityTags {
+		if spec.deviceRules == nil {
+			spec.deviceRules = make(map[string][]DeviceRule)
+		}
+		rules := spec.deviceRules[securityTag]
+		found := false
+		for _, r := range rules {
+			if r == rule {
+				found = true
+				break
+			}
+		}
+		if !found {
+			spec.deviceRules[securityTag] = append(rules, rule)
+		}
+	}
+	return nil
+}
+
+// AllowDeviceRule is like AllowDevice but takes a device cgroup entry, such
+// as "c 10:229 rwm", see ParseDeviceRule.
+func (spec *Specification) AllowDeviceRule(rule string) error {
+	r, err := ParseDeviceRule(rule)
+	if err != nil {
+		return err
+	}
+	return spec.AllowDevice(r.Type, r.Major, r.Minor)
+}
+
+// DeviceRules returns the device cgroup entries allowed so far, keyed by
+// security tag. No entries are returned when the snap controls its own device
+// cgroup.
+func (spec *Specification) DeviceRules() map[string][]DeviceRule {
+	if spec.ControlsDeviceCgroup() || len(spec.deviceRules) == 0 {
+		return nil
+	}
+	result := make(map[string][]DeviceRule, len(spec.deviceRules))
+	for tag, rules := range spec.deviceRules {
+		rules = append([]DeviceRule(nil), rules...)
+		sort.Slice(rules, func(i, j int) bool {
+			if rules[i].Type != rules[j].Type {
+				return rules[i].Type < rules[j].Type
+			}
+			if rules[i].Major != rules[j].Major {
+				return rules[i].Major < rules[j].Major
+			}
+			return rules[i].Minor < rules[j].Minor
+		})
+		result[tag] = rules
+	}
+	return result
+}
+
 type byTagAndSnippet []entry
 
 func (c byTagAndSnippet) Len() int      { return len(c) }
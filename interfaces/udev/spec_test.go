@@ -21,6 +21,9 @@ package udev_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	. "gopkg.in/check.v1"
 
@@ -151,6 +154,76 @@ func (s *specSuite) TestTagDevice(c *C) {
 	s.testTagDevice(c, "/usr/lib/snapd")
 }
 
+func (s *specSuite) TestTagDeviceUserServicesAndHooks(c *C) {
+	// user services are apps like any other, devices are tagged for them
+	// as well as for the hooks bound to the plug
+	const plugYaml = `name: snap1
+version: 0
+plugs:
+  name:
+    interface: test
+apps:
+  system-svc:
+    daemon: simple
+  user-svc:
+    daemon: simple
+    daemon-scope: user
+hooks:
+  install:
+  configure:
+`
+	plug, _ := ifacetest.MockConnectedPlug(c, plugYaml, nil, "name")
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "iface-1",
+		UDevConnectedPlugCallback: func(spec *udev.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.TagDevice(`KERNEL=="voodoo"`)
+			return nil
+		},
+	}
+	spec := udev.NewSpecification(plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(iface, plug, s.slot), IsNil)
+
+	var tagged []string
+	for _, snippet := range spec.Snippets() {
+		if strings.HasPrefix(snippet, "# iface-1\n") {
+			tagged = append(tagged, snippet[strings.Index(snippet, `TAG+="`):])
+		}
+	}
+	c.Check(tagged, DeepEquals, []string{
+		`TAG+="snap_snap1_hook_configure"`,
+		`TAG+="snap_snap1_hook_install"`,
+		`TAG+="snap_snap1_system-svc"`,
+		`TAG+="snap_snap1_user-svc"`,
+	})
+}
+
+func (s *specSuite) TestTagDeviceIfPresent(c *C) {
+	var tagged []bool
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "iface-1",
+		UDevConnectedPlugCallback: func(spec *udev.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			tagged = append(tagged, spec.TagDeviceIfPresent(`KERNEL=="voodoo[0-9]*"`, "/dev/voodoo[0-9]*"))
+			return nil
+		},
+	}
+
+	// the device is absent
+	c.Assert(s.spec.AddConnectedPlug(iface, s.plug, s.slot), IsNil)
+	c.Check(tagged, DeepEquals, []bool{false})
+	c.Check(s.spec.Snippets(), HasLen, 0)
+	c.Check(udev.DevicePresent("/dev/voodoo[0-9]*"), Equals, false)
+
+	// the device is present
+	c.Assert(os.MkdirAll(filepath.Join(dirs.GlobalRootDir, "/dev"), 0755), IsNil)
+	c.Assert(os.WriteFile(filepath.Join(dirs.GlobalRootDir, "/dev/voodoo0"), nil, 0644), IsNil)
+	c.Check(udev.DevicePresent("/dev/hoodoo", "/dev/voodoo[0-9]*"), Equals, true)
+	spec := udev.NewSpecification(s.plug.AppSet())
+	c.Assert(spec.AddConnectedPlug(iface, s.plug, s.slot), IsNil)
+	c.Check(tagged, DeepEquals, []bool{false, true})
+	c.Check(spec.Snippets(), testutil.Contains, `# iface-1
+KERNEL=="voodoo[0-9]*", TAG+="snap_snap1_foo"`)
+}
+
 func (s *specSuite) TestTagDeviceAltLibexecdir(c *C) {
 	dirstest.MustMockAltLibExecDir(dirs.GlobalRootDir)
 	dirs.SetRootDir(dirs.GlobalRootDir)
@@ -159,6 +232,115 @@ func (s *specSuite) TestTagDeviceAltLibexecdir(c *C) {
 	s.testTagDevice(c, "/usr/libexec/snapd")
 }
 
+func (s *specSuite) TestAllowDevice(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "iface-1",
+		UDevConnectedPlugCallback: func(spec *udev.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			// /dev/fuse
+			if err := spec.AllowDevice(udev.CharDevice, 10, 229); err != nil {
+				return err
+			}
+			// /dev/sda
+			return spec.AllowDevice(udev.BlockDevice, 8, 0)
+		},
+	}
+	appSet, err := interfaces.NewSnapAppSet(s.plugInfo.Snap, nil)
+	c.Assert(err, IsNil)
+	spec := udev.NewSpecification(appSet)
+	c.Assert(spec.AddConnectedPlug(iface, s.plug, s.slot), IsNil)
+	c.Assert(spec.Snippets(), DeepEquals, []string{
+		`# iface-1
+SUBSYSTEM!="block", ENV{MAJOR}=="10", ENV{MINOR}=="229", TAG+="snap_snap1_foo"`,
+		`# iface-1
+SUBSYSTEM=="block", ENV{MAJOR}=="8", ENV{MINOR}=="0", TAG+="snap_snap1_foo"`,
+		`TAG=="snap_snap1_foo", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="/usr/lib/snapd/snap-device-helper $env{ACTION} snap_snap1_foo $devpath $major:$minor"`,
+		`# iface-1
+SUBSYSTEM!="block", ENV{MAJOR}=="10", ENV{MINOR}=="229", TAG+="snap_snap1_hook_configure"`,
+		`# iface-1
+SUBSYSTEM=="block", ENV{MAJOR}=="8", ENV{MINOR}=="0", TAG+="snap_snap1_hook_configure"`,
+		`TAG=="snap_snap1_hook_configure", SUBSYSTEM!="module", SUBSYSTEM!="subsystem", RUN+="/usr/lib/snapd/snap-device-helper $env{ACTION} snap_snap1_hook_configure $devpath $major:$minor"`,
+	})
+}
+
+func (s *specSuite) TestAllowDeviceRules(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "iface-1",
+		UDevConnectedPlugCallback: func(spec *udev.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			if err := spec.AllowDeviceRule("c 10:229 rwm"); err != nil {
+				return err
+			}
+			// duplicates are ignored
+			if err := spec.AllowDevice(udev.CharDevice, 10, 229); err != nil {
+				return err
+			}
+			if err := spec.AllowDeviceRule("b 7:*"); err != nil {
+				return err
+			}
+			return spec.AllowDeviceRule("c 1:3")
+		},
+	}
+	appSet, err := interfaces.NewSnapAppSet(s.plugInfo.Snap, nil)
+	c.Assert(err, IsNil)
+	spec := udev.NewSpecification(appSet)
+	c.Assert(spec.AddConnectedPlug(iface, s.plug, s.slot), IsNil)
+	rules := []udev.DeviceRule{
+		{Type: udev.BlockDevice, Major: 7, Minor: udev.AnyMinor},
+		{Type: udev.CharDevice, Major: 1, Minor: 3},
+		{Type: udev.CharDevice, Major: 10, Minor: 229},
+	}
+	c.Assert(spec.DeviceRules(), DeepEquals, map[string][]udev.DeviceRule{
+		"snap.snap1.foo":            rules,
+		"snap.snap1.hook.configure": rules,
+	})
+	c.Check(rules[0].String(), Equals, "b 7:* rwm")
+	c.Check(rules[2].String(), Equals, "c 10:229 rwm")
+	// any minor number is matched by omitting the minor in udev
+	c.Check(spec.Snippets(), testutil.Contains, `# iface-1
+SUBSYSTEM=="block", ENV{MAJOR}=="7", TAG+="snap_snap1_foo"`)
+
+	spec.SetControlsDeviceCgroup()
+	c.Check(spec.DeviceRules(), IsNil)
+}
+
+func (s *specSuite) TestParseDeviceRule(c *C) {
+	for _, t := range []struct {
+		rule     string
+		expected udev.DeviceRule
+		err      string
+	}{
+		{rule: "c 10:229 rwm", expected: udev.DeviceRule{Type: udev.CharDevice, Major: 10, Minor: 229}},
+		{rule: "b 8:0", expected: udev.DeviceRule{Type: udev.BlockDevice, Major: 8, Minor: 0}},
+		{rule: "c 136:* rwm", expected: udev.DeviceRule{Type: udev.CharDevice, Major: 136, Minor: udev.AnyMinor}},
+		{rule: "", err: `invalid device rule ""`},
+		{rule: "c 10:229 rwm foo", err: `invalid device rule "c 10:229 rwm foo"`},
+		{rule: "a 10:229", err: `invalid device type "a" in device rule "a 10:229"`},
+		{rule: "c 10", err: `invalid device number "10" in device rule "c 10"`},
+		{rule: "c *:229", err: `invalid device number "\*:229" in device rule "c \*:229"`},
+		{rule: "c 10:-1", err: `invalid device number "10:-1" in device rule "c 10:-1"`},
+		{rule: "c 10:4294967295", err: `invalid device number "10:4294967295" in device rule "c 10:4294967295"`},
+		{rule: "c 10:229 r", err: `unsupported access "r" in device rule "c 10:229 r"`},
+	} {
+		rule, err := udev.ParseDeviceRule(t.rule)
+		if t.err != "" {
+			c.Check(err, ErrorMatches, t.err, Commentf("rule %q", t.rule))
+			continue
+		}
+		c.Check(err, IsNil, Commentf("rule %q", t.rule))
+		c.Check(rule, Equals, t.expected)
+	}
+}
+
+func (s *specSuite) TestAllowDeviceInvalidType(c *C) {
+	iface := &ifacetest.TestInterface{
+		InterfaceName: "iface-1",
+		UDevConnectedPlugCallback: func(spec *udev.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			return spec.AllowDevice("p", 10, 229)
+		},
+	}
+	c.Assert(s.spec.AddConnectedPlug(iface, s.plug, s.slot), ErrorMatches, `invalid device type "p"`)
+	c.Assert(s.spec.Snippets(), HasLen, 0)
+}
+
 // The spec.Specification can be used through the interfaces.Specification interface
 func (s *specSuite) TestSpecificationIface(c *C) {
 	appSet, err := interfaces.NewSnapAppSet(s.plugInfo.Snap, nil)
@@ -28,15 +28,19 @@ import (
 	"github.com/snapcore/snapd/dirs"
 	"github.com/snapcore/snapd/logger"
 	"github.com/snapcore/snapd/osutil"
+	"github.com/snapcore/snapd/overlord/configstate/config"
+	"github.com/snapcore/snapd/overlord/restart"
 	"github.com/snapcore/snapd/strutil"
 	"github.com/snapcore/snapd/systemd"
 )
 
 const (
-	optionDebugSnapdLog            = "debug.snapd.log"
-	optionDebugSystemdLogLevel     = "debug.systemd.log-level"
-	coreOptionDebugSnapdLog        = "core." + optionDebugSnapdLog
-	coreOptionDebugSystemdLogLevel = "core." + optionDebugSystemdLogLevel
+	optionDebugSnapdLog                         = "debug.snapd.log"
+	optionDebugSystemdLogLevel                  = "debug.systemd.log-level"
+	optionDebugSeccompLogPrivilegedSyscalls     = "debug.seccomp.log-privileged-syscalls"
+	coreOptionDebugSnapdLog                     = "core." + optionDebugSnapdLog
+	coreOptionDebugSystemdLogLevel              = "core." + optionDebugSystemdLogLevel
+	coreOptionDebugSeccompLogPrivilegedSyscalls = "core." + optionDebugSeccompLogPrivilegedSyscalls
 )
 
 var loggerSimpleSetup = logger.SimpleSetup
@@ -44,6 +48,7 @@ var loggerSimpleSetup = logger.SimpleSetup
 func init() {
 	supportedConfigurations[coreOptionDebugSnapdLog] = true
 	supportedConfigurations[coreOptionDebugSystemdLogLevel] = true
+	supportedConfigurations[coreOptionDebugSeccompLogPrivilegedSyscalls] = true
 }
 
 func validateDebugSnapdLogSetting(tr RunTransaction) error {
@@ -165,3 +170,33 @@ func handleDebugSystemdLogLevelConfiguration(tr RunTransaction, opts *fsOnlyCont
 	// Set log level for the current systemd instance
 	return sysd.SetLogLevel(logLevel)
 }
+
+func validateDebugSeccompLogPrivilegedSyscallsSetting(tr RunTransaction) error {
+	return validateBoolFlag(tr, optionDebugSeccompLogPrivilegedSyscalls)
+}
+
+// handleDebugSeccompLogPrivilegedSyscallsConfiguration restarts snapd when the
+// logging of the syscalls granted by privileged interfaces is toggled, the
+// interface manager regenerates the seccomp profiles on startup then.
+func handleDebugSeccompLogPrivilegedSyscallsConfiguration(tr RunTransaction, opts *fsOnlyContext) error {
+	if !strutil.ListContains(tr.Changes(), coreOptionDebugSeccompLogPrivilegedSyscalls) {
+		return nil
+	}
+	logPrivileged, err := coreCfg(tr, optionDebugSeccompLogPrivilegedSyscalls)
+	if err != nil {
+		return err
+	}
+	var prevLogPrivileged any = ""
+	if err := tr.GetPristine("core", optionDebugSeccompLogPrivilegedSyscalls, &prevLogPrivileged); err != nil && !config.IsNoOption(err) {
+		return err
+	}
+	if (logPrivileged == "true") == (fmt.Sprintf("%v", prevLogPrivileged) == "true") {
+		return nil
+	}
+
+	st := tr.State()
+	st.Lock()
+	defer st.Unlock()
+	restartRequest(st, restart.RestartDaemon, nil)
+	return nil
+}
@@ -27,9 +27,12 @@ import (
 
 	. "gopkg.in/check.v1"
 
+	"github.com/snapcore/snapd/boot"
 	"github.com/snapcore/snapd/dirs"
 	"github.com/snapcore/snapd/logger"
 	"github.com/snapcore/snapd/overlord/configstate/configcore"
+	"github.com/snapcore/snapd/overlord/restart"
+	"github.com/snapcore/snapd/overlord/state"
 	"github.com/snapcore/snapd/systemd"
 	"github.com/snapcore/snapd/testutil"
 )
@@ -205,3 +208,57 @@ func (s *debugSuite) TestConfigureSystemdLogLevelOldSystemd(c *C) {
 	c.Check(systemctlArgs, DeepEquals, []string{"log-level", val})
 	c.Check(sysdAnalyzeCmd.Calls(), DeepEquals, [][]string{{"systemd-analyze", "set-log-level", val}})
 }
+
+func (s *debugSuite) TestConfigureDebugSeccompLogPrivilegedSyscalls(c *C) {
+	var restarts []restart.RestartType
+	restore := configcore.MockRestartRequest(func(st *state.State, t restart.RestartType, rebootInfo *boot.RebootInfo) {
+		c.Check(st, Equals, s.state)
+		c.Check(rebootInfo, IsNil)
+		restarts = append(restarts, t)
+	})
+	defer restore()
+
+	for _, t := range []struct {
+		prev, val any
+		restart   bool
+	}{
+		{"", "true", true},
+		{"false", "true", true},
+		{"true", "false", true},
+		{"true", "", true},
+		{true, "false", true},
+		{"", "false", false},
+		{"true", "true", false},
+		{true, "true", false},
+	} {
+		restarts = nil
+		err := configcore.Run(coreDev, &mockConf{
+			state:   s.state,
+			conf:    map[string]any{"debug.seccomp.log-privileged-syscalls": t.prev},
+			changes: map[string]any{"debug.seccomp.log-privileged-syscalls": t.val},
+		})
+		c.Assert(err, IsNil)
+		if t.restart {
+			c.Check(restarts, DeepEquals, []restart.RestartType{restart.RestartDaemon}, Commentf("%v -> %v", t.prev, t.val))
+		} else {
+			c.Check(restarts, HasLen, 0, Commentf("%v -> %v", t.prev, t.val))
+		}
+	}
+}
+
+func (s *debugSuite) TestConfigureDebugSeccompLogPrivilegedSyscallsBadVals(c *C) {
+	restore := configcore.MockRestartRequest(func(st *state.State, t restart.RestartType, rebootInfo *boot.RebootInfo) {
+		c.Error("unexpected restart requested")
+	})
+	defer restore()
+
+	for _, val := range []string{"1", "foo"} {
+		err := configcore.Run(coreDev, &mockConf{
+			state:   s.state,
+			conf:    map[string]any{"debug.seccomp.log-privileged-syscalls": ""},
+			changes: map[string]any{"debug.seccomp.log-privileged-syscalls": val},
+		})
+		c.Check(err, ErrorMatches,
+			"debug.seccomp.log-privileged-syscalls can only be set to 'true' or 'false'")
+	}
+}
@@ -73,6 +73,9 @@ func init() {
 	// debug.systemd.log-level
 	addWithStateHandler(validateDebugSystemdLogLevelSetting, handleDebugSystemdLogLevelConfiguration, coreOnly)
 
+	// debug.seccomp.log-privileged-syscalls
+	addWithStateHandler(validateDebugSeccompLogPrivilegedSyscallsSetting, handleDebugSeccompLogPrivilegedSyscallsConfiguration, nil)
+
 	// experimental.apparmor-prompting
 	addWithStateHandler(nil, doExperimentalApparmorPromptingDaemonRestart, nil)
 
@@ -0,0 +1,305 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package ifacestate
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/snapcore/snapd/features"
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/overlord/configstate/config"
+	"github.com/snapcore/snapd/overlord/state"
+	"github.com/snapcore/snapd/snap/naming"
+	"github.com/snapcore/snapd/strutil"
+)
+
+// ErrDenialStatsDisabled is returned by DenialStats when the collection of
+// denial statistics was not enabled with the denial-stats feature.
+var ErrDenialStatsDisabled = errors.New("collection of denial statistics is disabled")
+
+// DenialStat holds the number of times the AppArmor or seccomp profile of an
+// app or hook of a snap denied a given operation. Denials are anonymized, only
+// the kind of operation which was denied is retained.
+type DenialStat struct {
+	Snap      string                    `json:"snap"`
+	App       string                    `json:"app,omitempty"`
+	Hook      string                    `json:"hook,omitempty"`
+	Backend   interfaces.SecuritySystem `json:"backend"`
+	Operation string                    `json:"operation"`
+	Count     int                       `json:"count"`
+	// Interfaces lists the interfaces of the plugs of the app or hook
+	// which are connected when the statistics are obtained.
+	Interfaces []string `json:"interfaces,omitempty"`
+}
+
+func (d *DenialStat) key() string {
+	return strings.Join([]string{d.Snap, d.App, d.Hook, string(d.Backend), d.Operation}, " ")
+}
+
+var (
+	denialStatsCollectInterval = 10 * time.Minute
+
+	// denialStatsKernelLog returns the kernel messages logged after the
+	// given journal cursor, or since boot if the cursor is empty, in the
+	// format of journalctl --output=cat --show-cursor.
+	denialStatsKernelLog = func(cursor string) ([]byte, error) {
+		args := []string{"--dmesg", "--output=cat", "--show-cursor", "--no-pager", "--quiet"}
+		if cursor != "" {
+			args = append(args, "--after-cursor="+cursor)
+		}
+		return exec.Command("journalctl", args...).Output()
+	}
+)
+
+const journalCursorPrefix = "-- cursor: "
+
+// parseAuditFields parses the space separated key=value fields of a kernel
+// audit record, values may be double quoted.
+func parseAuditFields(record string) map[string]string {
+	fields := make(map[string]string)
+	for record != "" {
+		record = strings.TrimLeft(record, " ")
+		idx := strings.IndexByte(record, '=')
+		if idx < 0 {
+			break
+		}
+		key := record[:idx]
+		record = record[idx+1:]
+		var value string
+		if strings.HasPrefix(record, `"`) {
+			end := strings.IndexByte(record[1:], '"')
+			if end < 0 {
+				break
+			}
+			value = record[1 : end+1]
+			record = record[end+2:]
+		} else {
+			end := strings.IndexByte(record, ' ')
+			if end < 0 {
+				end = len(record)
+			}
+			value = record[:end]
+			record = record[end:]
+		}
+		fields[key] = value
+	}
+	return fields
+}
+
+// parseDenial parses a kernel audit record of an AppArmor or seccomp denial
+// of an app or hook of a snap. Any other records are ignored and nil is
+// returned.
+func parseDenial(line string) *DenialStat {
+	// audit: type=1400 audit(1700000000.123:456): apparmor="DENIED" ...
+	idx := strings.Index(line, "): ")
+	if !strings.HasPrefix(line, "audit: type=") || idx < 0 {
+		return nil
+	}
+	fields := parseAuditFields(line[idx+len("): "):])
+
+	var label string
+	denial := &DenialStat{Count: 1}
+	switch {
+	case strings.HasPrefix(line, "audit: type=1400 "):
+		if fields["apparmor"] != "DENIED" {
+			return nil
+		}
+		label = fields["profile"]
+		denial.Backend = interfaces.SecurityAppArmor
+		denial.Operation = fields["operation"]
+		if capname := fields["capname"]; capname != "" {
+			denial.Operation += ":" + capname
+		}
+	case strings.HasPrefix(line, "audit: type=1326 "):
+		// seccomp denials can only be attributed to the snap through
+		// the AppArmor label of the process
+		label = fields["subj"]
+		denial.Backend = interfaces.SecuritySecComp
+		denial.Operation = "syscall:" + fields["syscall"]
+	default:
+		return nil
+	}
+	// drop any child profile, e.g. snap.foo.app//null-/usr/bin/bar
+	if idx := strings.Index(label, "//"); idx >= 0 {
+		label = label[:idx]
+	}
+	tag, err := naming.ParseSecurityTag(label)
+	if err != nil || denial.Operation == "" {
+		return nil
+	}
+	denial.Snap = tag.InstanceName()
+	switch tag := tag.(type) {
+	case naming.AppSecurityTag:
+		denial.App = tag.AppName()
+	case naming.HookSecurityTag:
+		denial.Hook = tag.HookName()
+	}
+	return denial
+}
+
+func getDenialStats(st *state.State) ([]*DenialStat, error) {
+	var stats []*DenialStat
+	if err := st.Get("denial-stats", &stats); err != nil && !errors.Is(err, state.ErrNoState) {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// addDenialStats merges the denials found in the given kernel log into the
+// denial statistics in the state, and records the journal cursor the next
+// collection starts from.
+func addDenialStats(st *state.State, log []byte) error {
+	stats, err := getDenialStats(st)
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]*DenialStat, len(stats))
+	for _, stat := range stats {
+		byKey[stat.key()] = stat
+	}
+
+	var cursor string
+	scanner := bufio.NewScanner(bytes.NewReader(log))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, journalCursorPrefix) {
+			cursor = strings.TrimPrefix(line, journalCursorPrefix)
+			continue
+		}
+		denial := parseDenial(line)
+		if denial == nil {
+			continue
+		}
+		if stat, ok := byKey[denial.key()]; ok {
+			stat.Count++
+			continue
+		}
+		byKey[denial.key()] = denial
+		stats = append(stats, denial)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].key() < stats[j].key()
+	})
+	st.Set("denial-stats", stats)
+	if cursor != "" {
+		st.Set("denial-stats-cursor", cursor)
+	}
+	return nil
+}
+
+func denialStatsCursor(st *state.State) (string, error) {
+	var cursor string
+	if err := st.Get("denial-stats-cursor", &cursor); err != nil && !errors.Is(err, state.ErrNoState) {
+		return "", err
+	}
+	return cursor, nil
+}
+
+// forgetDenialStats drops any denial statistics collected so far.
+func forgetDenialStats(st *state.State) {
+	var stats []*DenialStat
+	var cursor string
+	errStats := st.Get("denial-stats", &stats)
+	errCursor := st.Get("denial-stats-cursor", &cursor)
+	if errors.Is(errStats, state.ErrNoState) && errors.Is(errCursor, state.ErrNoState) {
+		// avoid needlessly marking the state as modified
+		return
+	}
+	st.Set("denial-stats", nil)
+	st.Set("denial-stats-cursor", nil)
+}
+
+func denialStatsEnabled(st *state.State) (bool, error) {
+	tr := config.NewTransaction(st)
+	return features.Flag(tr, features.DenialStats)
+}
+
+// DenialStats returns the statistics of the denials of the snap with the given
+// instance name, or of all snaps if the name is empty. Each statistic lists the
+// interfaces which are currently connected to the plugs of the app or hook.
+func (m *InterfaceManager) DenialStats(instanceName string) ([]*DenialStat, error) {
+	st := m.state
+	st.Lock()
+	defer st.Unlock()
+
+	enabled, err := denialStatsEnabled(st)
+	if err != nil {
+		return nil, fmt.Errorf("cannot check denial statistics feature: %v", err)
+	}
+	if !enabled {
+		return nil, ErrDenialStatsDisabled
+	}
+
+	stats, err := getDenialStats(st)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*DenialStat, 0, len(stats))
+	for _, stat := range stats {
+		if instanceName != "" && stat.Snap != instanceName {
+			continue
+		}
+		stat.Interfaces = m.connectedInterfacesOf(stat.Snap, stat.App, stat.Hook)
+		res = append(res, stat)
+	}
+	return res, nil
+}
+
+// connectedInterfacesOf returns the sorted list of interfaces of the connected
+// plugs of the given app or hook of a snap.
+func (m *InterfaceManager) connectedInterfacesOf(instanceName, app, hook string) []string {
+	connRefs, err := m.repo.Connections(instanceName)
+	if err != nil {
+		// the snap is gone
+		return nil
+	}
+	var ifaces []string
+	for _, connRef := range connRefs {
+		if connRef.PlugRef.Snap != instanceName {
+			continue
+		}
+		plug := m.repo.Plug(connRef.PlugRef.Snap, connRef.PlugRef.Name)
+		if plug == nil {
+			continue
+		}
+		if _, ok := plug.Apps[app]; app != "" && !ok {
+			continue
+		}
+		if hookInfo := plug.Snap.Hooks[hook]; hook != "" && (hookInfo == nil || hookInfo.Plugs[plug.Name] == nil) {
+			continue
+		}
+		if !strutil.ListContains(ifaces, plug.Interface) {
+			ifaces = append(ifaces, plug.Interface)
+		}
+	}
+	sort.Strings(ifaces)
+	return ifaces
+}
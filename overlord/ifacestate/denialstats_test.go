@@ -0,0 +1,145 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package ifacestate_test
+
+import (
+	. "gopkg.in/check.v1"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/ifacetest"
+	"github.com/snapcore/snapd/overlord/configstate/config"
+	"github.com/snapcore/snapd/overlord/ifacestate"
+	"github.com/snapcore/snapd/overlord/state"
+	"github.com/snapcore/snapd/testutil"
+)
+
+func (s *interfaceManagerSuite) TestParseDenial(c *C) {
+	for _, tc := range []struct {
+		line     string
+		expected *ifacestate.DenialStat
+	}{{
+		line: `audit: type=1400 audit(1700000000.123:456): apparmor="DENIED" operation="open" class="file" profile="snap.consumer.app" name="/etc/shadow" pid=1234 comm="cat" requested_mask="r" denied_mask="r" fsuid=1000 ouid=0`,
+		expected: &ifacestate.DenialStat{
+			Snap: "consumer", App: "app", Backend: interfaces.SecurityAppArmor, Operation: "open", Count: 1,
+		},
+	}, {
+		line: `audit: type=1400 audit(1700000000.123:457): apparmor="DENIED" operation="capable" class="cap" profile="snap.consumer_foo.hook.configure//null-/usr/bin/foo" pid=1234 comm="foo" capability=21 capname="sys_admin"`,
+		expected: &ifacestate.DenialStat{
+			Snap: "consumer_foo", Hook: "configure", Backend: interfaces.SecurityAppArmor, Operation: "capable:sys_admin", Count: 1,
+		},
+	}, {
+		line: `audit: type=1326 audit(1700000000.123:458): auid=1000 uid=1000 gid=1000 ses=2 subj=snap.consumer.app pid=1234 comm="app" exe="/snap/consumer/1/bin/app" sig=0 arch=c000003e syscall=165 compat=0 ip=0x7f0000000000 code=0x50000`,
+		expected: &ifacestate.DenialStat{
+			Snap: "consumer", App: "app", Backend: interfaces.SecuritySecComp, Operation: "syscall:165", Count: 1,
+		},
+	}, {
+		// allowed in complain mode
+		line: `audit: type=1400 audit(1700000000.123:459): apparmor="ALLOWED" operation="open" profile="snap.consumer.app" name="/etc/shadow"`,
+	}, {
+		// not a snap
+		line: `audit: type=1400 audit(1700000000.123:460): apparmor="DENIED" operation="open" profile="/usr/sbin/cupsd" name="/etc/shadow"`,
+	}, {
+		// not attributable to a snap
+		line: `audit: type=1326 audit(1700000000.123:461): auid=1000 uid=1000 pid=1234 comm="app" sig=0 arch=c000003e syscall=165`,
+	}, {
+		line: `usb 1-1: new high-speed USB device number 2 using xhci_hcd`,
+	}} {
+		c.Check(ifacestate.ParseDenial(tc.line), DeepEquals, tc.expected, Commentf("line %q", tc.line))
+	}
+}
+
+func (s *interfaceManagerSuite) TestDenialStats(c *C) {
+	s.mockIfaces(&ifacetest.TestInterface{InterfaceName: "test"}, &ifacetest.TestInterface{InterfaceName: "test2"})
+	mgr := s.manager(c)
+	repo := mgr.Repository()
+
+	c.Assert(repo.AddAppSet(s.mockAppSet(c, producerYaml)), IsNil)
+	c.Assert(repo.AddAppSet(s.mockAppSet(c, consumerYaml+`apps:
+ app:
+  plugs: [plug]
+`)), IsNil)
+	_, err := repo.Connect(&interfaces.ConnRef{
+		PlugRef: interfaces.PlugRef{Snap: "consumer", Name: "plug"},
+		SlotRef: interfaces.SlotRef{Snap: "producer", Name: "slot"},
+	}, nil, nil, nil, nil, nil)
+	c.Assert(err, IsNil)
+
+	restore := ifacestate.MockDenialStatsCollectInterval(0)
+	defer restore()
+	var cursors []string
+	log := `audit: type=1400 audit(1700000000.123:456): apparmor="DENIED" operation="open" profile="snap.consumer.app" name="/etc/shadow"
+audit: type=1400 audit(1700000000.123:457): apparmor="DENIED" operation="open" profile="snap.consumer.app" name="/etc/gshadow"
+audit: type=1326 audit(1700000000.123:458): subj=snap.producer.hook.configure pid=1234 syscall=165
+-- cursor: s=abc;i=1
+`
+	restore = ifacestate.MockDenialStatsKernelLog(func(cursor string) ([]byte, error) {
+		cursors = append(cursors, cursor)
+		return []byte(log), nil
+	})
+	defer restore()
+
+	_, err = mgr.DenialStats("")
+	c.Check(err, Equals, ifacestate.ErrDenialStatsDisabled)
+
+	// nothing is collected unless enabled
+	c.Assert(mgr.Ensure(), IsNil)
+	c.Check(cursors, HasLen, 0)
+
+	s.state.Lock()
+	tr := config.NewTransaction(s.state)
+	c.Assert(tr.Set("core", "experimental.denial-stats", true), IsNil)
+	tr.Commit()
+	s.state.Unlock()
+
+	c.Assert(mgr.Ensure(), IsNil)
+	log = `audit: type=1400 audit(1700000000.123:459): apparmor="DENIED" operation="open" profile="snap.consumer.app" name="/etc/shadow"
+-- cursor: s=abc;i=2
+`
+	c.Assert(mgr.Ensure(), IsNil)
+	c.Check(cursors, DeepEquals, []string{"", "s=abc;i=1"})
+
+	stats, err := mgr.DenialStats("")
+	c.Assert(err, IsNil)
+	c.Check(stats, DeepEquals, []*ifacestate.DenialStat{{
+		Snap: "consumer", App: "app", Backend: interfaces.SecurityAppArmor, Operation: "open", Count: 3,
+		Interfaces: []string{"test"},
+	}, {
+		Snap: "producer", Hook: "configure", Backend: interfaces.SecuritySecComp, Operation: "syscall:165", Count: 1,
+	}})
+
+	stats, err = mgr.DenialStats("producer")
+	c.Assert(err, IsNil)
+	c.Check(stats, HasLen, 1)
+
+	// the statistics are dropped when disabled
+	s.state.Lock()
+	tr = config.NewTransaction(s.state)
+	c.Assert(tr.Set("core", "experimental.denial-stats", false), IsNil)
+	tr.Commit()
+	s.state.Unlock()
+	c.Assert(mgr.Ensure(), IsNil)
+
+	s.state.Lock()
+	defer s.state.Unlock()
+	var saved []*ifacestate.DenialStat
+	c.Check(s.state.Get("denial-stats", &saved), testutil.ErrorIs, state.ErrNoState)
+	var cursor string
+	c.Check(s.state.Get("denial-stats-cursor", &cursor), testutil.ErrorIs, state.ErrNoState)
+}
@@ -224,3 +224,16 @@ func (m *InterfaceManager) SetupSecurityByBackend(task *state.Task, appSets []*i
 func MockIsSnapVerified(new func(st *state.State, snapID string) bool) (restore func()) {
 	return testutil.Mock(&isSnapVerified, new)
 }
+
+var ForgetProfileDigests = forgetProfileDigests
+
+var ParseDenial = parseDenial
+
+func MockDenialStatsKernelLog(f func(cursor string) ([]byte, error)) (restore func()) {
+	return testutil.Mock(&denialStatsKernelLog, f)
+}
+
+func MockDenialStatsCollectInterval(d time.Duration) (restore func()) {
+	return testutil.Mock(&denialStatsCollectInterval, d)
+}
+var ProfileDigestsForSnap = profileDigestsForSnap
@@ -20,6 +20,8 @@
 package ifacestate
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path"
@@ -35,6 +37,8 @@ import (
 	"github.com/snapcore/snapd/i18n"
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/interfaces/hotplug"
+	"github.com/snapcore/snapd/interfaces/utils"
+	"github.com/snapcore/snapd/jsonutil"
 	"github.com/snapcore/snapd/logger"
 	"github.com/snapcore/snapd/osutil"
 	"github.com/snapcore/snapd/overlord/assertstate"
@@ -46,6 +50,7 @@ import (
 	"github.com/snapcore/snapd/overlord/state"
 	"github.com/snapcore/snapd/snap"
 	"github.com/snapcore/snapd/snap/quota"
+	"github.com/snapcore/snapd/strutil"
 	"github.com/snapcore/snapd/timings"
 )
 
@@ -97,16 +102,55 @@ func (m *InterfaceManager) buildConfinementOptions(st *state.State, task *state.
 		kernelSnap = deviceCtx.Kernel()
 	}
 
+	auditedPlugs, err := m.auditedPlugs(st, snapInfo.InstanceName())
+	if err != nil {
+		return interfaces.ConfinementOptions{}, err
+	}
+
 	return interfaces.ConfinementOptions{
-		DevMode:           flags.DevMode,
-		JailMode:          flags.JailMode,
-		Classic:           flags.Classic,
-		ExtraLayouts:      extraLayouts,
-		AppArmorPrompting: m.useAppArmorPrompting,
-		KernelSnap:        kernelSnap,
+		DevMode:               flags.DevMode,
+		JailMode:              flags.JailMode,
+		Classic:               flags.Classic,
+		ExtraLayouts:          extraLayouts,
+		AppArmorPrompting:     m.useAppArmorPrompting,
+		KernelSnap:            kernelSnap,
+		AuditedPlugs:          auditedPlugs,
+		LogPrivilegedSyscalls: m.logPrivilegedSyscalls,
 	}, nil
 }
 
+// auditedPlugs returns the sorted names of the plugs of the given snap which
+// are connected in audit mode. Only connections present in the repository
+// are considered, so that a connection being removed no longer affects the
+// confinement of the snap.
+func (m *InterfaceManager) auditedPlugs(st *state.State, instanceName string) ([]string, error) {
+	conns, err := getConns(st)
+	if err != nil {
+		return nil, err
+	}
+	var plugs []string
+	for id, cstate := range conns {
+		if !cstate.Audit || cstate.Undesired || cstate.HotplugGone {
+			continue
+		}
+		connRef, err := interfaces.ParseConnRef(id)
+		if err != nil {
+			return nil, err
+		}
+		if connRef.PlugRef.Snap != instanceName {
+			continue
+		}
+		if _, err := m.repo.Connection(connRef); err != nil {
+			continue
+		}
+		if !strutil.ListContains(plugs, connRef.PlugRef.Name) {
+			plugs = append(plugs, connRef.PlugRef.Name)
+		}
+	}
+	sort.Strings(plugs)
+	return plugs, nil
+}
+
 func (m *InterfaceManager) setupAffectedSnaps(task *state.Task, affectingSnap string, affectedSnaps []string, tm timings.Measurer) error {
 	st := task.State()
 
@@ -598,6 +642,10 @@ func (m *InterfaceManager) doConnect(task *state.Task, _ *tomb.Tomb) (err error)
 	if err := task.Get("by-gadget", &byGadget); err != nil && !errors.Is(err, state.ErrNoState) {
 		return err
 	}
+	var audit bool
+	if err := task.Get("audit", &audit); err != nil && !errors.Is(err, state.ErrNoState) {
+		return err
+	}
 	var delayedSetupProfiles bool
 	if err := task.Get("delayed-setup-profiles", &delayedSetupProfiles); err != nil && !errors.Is(err, state.ErrNoState) {
 		return err
@@ -655,6 +703,18 @@ func (m *InterfaceManager) doConnect(task *state.Task, _ *tomb.Tomb) (err error)
 		return fmt.Errorf("building app set for snap %q: %v", slot.Snap.InstanceName(), err)
 	}
 
+	// connections which are being re-created, e.g. of hotplug slots, were
+	// established already and are not subject to the seeding restriction
+	if conn, ok := conns[connRef.ID()]; !ok || conn.Undesired {
+		refused, err := seedOnlyAfterSeeding(st, m.repo, plug.Interface)
+		if err != nil {
+			return err
+		}
+		if refused {
+			return fmt.Errorf("cannot connect %q interface: it can only be connected while the system is being seeded", plug.Interface)
+		}
+	}
+
 	// attributes are always present, even if there are no hooks (they're initialized by Connect).
 	plugDynamicAttrs, slotDynamicAttrs, err := getDynamicHookAttributes(task)
 	if err != nil {
@@ -717,6 +777,11 @@ func (m *InterfaceManager) doConnect(task *state.Task, _ *tomb.Tomb) (err error)
 		if err != nil {
 			return err
 		}
+		// the connection is recorded in the state only once the
+		// profiles are set up
+		if audit && !strutil.ListContains(plugOpts.AuditedPlugs, plugRef.Name) {
+			plugOpts.AuditedPlugs = append(plugOpts.AuditedPlugs, plugRef.Name)
+		}
 		if err := m.setupSnapSecurity(task, plugAppSet, plugOpts, perfTimings); err != nil {
 			return err
 		}
@@ -742,6 +807,7 @@ func (m *InterfaceManager) doConnect(task *state.Task, _ *tomb.Tomb) (err error)
 		Auto:             autoConnect,
 		ByGadget:         byGadget,
 		HotplugKey:       slot.HotplugKey,
+		Audit:            audit,
 	}
 	setConns(st, conns)
 
@@ -1086,6 +1152,185 @@ var contentLinkRetryTimeout = 30 * time.Second
 // timeout for retrying hotplug-related tasks
 var hotplugRetryTimeout = 300 * time.Millisecond
 
+// updatedDynamicAttrs returns a copy of the dynamic attributes of a side of a
+// connection with the given attributes set. Static attributes cannot be
+// changed.
+func updatedDynamicAttrs(side, name, snapName string, staticAttrs, dynamicAttrs, attrs map[string]any) (map[string]any, error) {
+	updated := utils.CopyAttributes(dynamicAttrs)
+	if updated == nil {
+		updated = make(map[string]any, len(attrs))
+	}
+	for key, value := range attrs {
+		if _, ok := staticAttrs[key]; ok {
+			return nil, fmt.Errorf("cannot change attribute %q of %s %q of snap %q as it was statically specified in the snap details", key, side, name, snapName)
+		}
+		updated[key] = utils.NormalizeInterfaceAttributes(value)
+	}
+	return updated, nil
+}
+
+// getTaskAttrs returns the attributes stored under the given key of the task,
+// decoding numbers such that they can be normalized like the attributes from
+// the snap details.
+func getTaskAttrs(task *state.Task, key string) (map[string]any, error) {
+	var raw *json.RawMessage
+	if err := task.Get(key, &raw); err != nil && !errors.Is(err, state.ErrNoState) {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+	var attrs map[string]any
+	if err := jsonutil.DecodeWithNumber(bytes.NewReader(*raw), &attrs); err != nil {
+		return nil, fmt.Errorf("cannot decode %s of task %q: %v", key, task.ID(), err)
+	}
+	return attrs, nil
+}
+
+// setupConnectedSnapsSecurity regenerates and reloads the security profiles
+// of both snaps of a connection.
+func (m *InterfaceManager) setupConnectedSnapsSecurity(task *state.Task, connRef *interfaces.ConnRef, tm timings.Measurer) error {
+	st := task.State()
+	for _, instanceName := range []string{connRef.SlotRef.Snap, connRef.PlugRef.Snap} {
+		var snapst snapstate.SnapState
+		if err := snapstate.Get(st, instanceName, &snapst); err != nil {
+			return err
+		}
+		snapInfo, err := snapst.CurrentInfo()
+		if err != nil {
+			return err
+		}
+		appSet, err := appSetForSnapRevision(st, snapInfo)
+		if err != nil {
+			return fmt.Errorf("building app set for snap %q: %v", snapInfo.InstanceName(), err)
+		}
+		opts, err := m.buildConfinementOptions(st, task, snapInfo, snapst.Flags)
+		if err != nil {
+			return err
+		}
+		if err := m.setupSnapSecurity(task, appSet, opts, tm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *InterfaceManager) doUpdateConnectionAttrs(task *state.Task, _ *tomb.Tomb) error {
+	st := task.State()
+	st.Lock()
+	defer st.Unlock()
+
+	perfTimings := state.TimingsForTask(task)
+	defer perfTimings.Save(st)
+
+	plugRef, slotRef, err := getPlugAndSlotRefs(task)
+	if err != nil {
+		return err
+	}
+	connRef := &interfaces.ConnRef{PlugRef: plugRef, SlotRef: slotRef}
+
+	plugAttrs, err := getTaskAttrs(task, "plug-attrs")
+	if err != nil {
+		return err
+	}
+	slotAttrs, err := getTaskAttrs(task, "slot-attrs")
+	if err != nil {
+		return err
+	}
+
+	conns, err := getConns(st)
+	if err != nil {
+		return err
+	}
+	cstate, ok := conns[connRef.ID()]
+	if !ok || cstate.Undesired || cstate.HotplugGone {
+		return fmt.Errorf("cannot update attributes of connection %q: not connected", connRef.ID())
+	}
+	conn, err := m.repo.Connection(connRef)
+	if err != nil {
+		return err
+	}
+
+	plugDynamicAttrs, err := updatedDynamicAttrs("plug", plugRef.Name, plugRef.Snap, conn.Plug.StaticAttrs(), conn.Plug.DynamicAttrs(), plugAttrs)
+	if err != nil {
+		return err
+	}
+	slotDynamicAttrs, err := updatedDynamicAttrs("slot", slotRef.Name, slotRef.Snap, conn.Slot.StaticAttrs(), conn.Slot.DynamicAttrs(), slotAttrs)
+	if err != nil {
+		return err
+	}
+
+	deviceCtx, err := snapstate.DeviceCtx(st, task, nil)
+	if err != nil {
+		return err
+	}
+	// updating attributes is a manual operation, it obeys the policy
+	// "connection" rules
+	policyCheck, err := newConnectChecker(st, deviceCtx)
+	if err != nil {
+		return err
+	}
+	oldPlugDynamicAttrs, oldSlotDynamicAttrs := conn.Plug.DynamicAttrs(), conn.Slot.DynamicAttrs()
+	conn, err = m.repo.UpdateConnectionAttrs(connRef, plugDynamicAttrs, slotDynamicAttrs, policyCheck.check)
+	if err != nil {
+		return err
+	}
+
+	// for undo
+	task.Set("old-conn", cstate)
+
+	if err := m.setupConnectedSnapsSecurity(task, connRef, perfTimings); err != nil {
+		if _, err := m.repo.UpdateConnectionAttrs(connRef, oldPlugDynamicAttrs, oldSlotDynamicAttrs, nil); err != nil {
+			logger.Noticef("cannot restore attributes of connection %q: %v", connRef.ID(), err)
+		}
+		return err
+	}
+
+	newState := *cstate
+	newState.DynamicPlugAttrs = conn.Plug.DynamicAttrs()
+	newState.DynamicSlotAttrs = conn.Slot.DynamicAttrs()
+	conns[connRef.ID()] = &newState
+	setConns(st, conns)
+	return nil
+}
+
+func (m *InterfaceManager) undoUpdateConnectionAttrs(task *state.Task, _ *tomb.Tomb) error {
+	st := task.State()
+	st.Lock()
+	defer st.Unlock()
+
+	perfTimings := state.TimingsForTask(task)
+	defer perfTimings.Save(st)
+
+	var old schema.ConnState
+	err := task.Get("old-conn", &old)
+	if errors.Is(err, state.ErrNoState) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	plugRef, slotRef, err := getPlugAndSlotRefs(task)
+	if err != nil {
+		return err
+	}
+	connRef := &interfaces.ConnRef{PlugRef: plugRef, SlotRef: slotRef}
+
+	if _, err := m.repo.UpdateConnectionAttrs(connRef, old.DynamicPlugAttrs, old.DynamicSlotAttrs, nil); err != nil {
+		return err
+	}
+
+	conns, err := getConns(st)
+	if err != nil {
+		return err
+	}
+	conns[connRef.ID()] = &old
+	setConns(st, conns)
+
+	return m.setupConnectedSnapsSecurity(task, connRef, perfTimings)
+}
+
 func obsoleteCorePhase2SetupProfiles(kind string, task *state.Task) (bool, error) {
 	if kind != "setup-profiles" {
 		return false, nil
@@ -1532,7 +1777,7 @@ func (m *InterfaceManager) doAutoConnect(task *state.Task, _ *tomb.Tomb) error {
 	plugs := m.repo.Plugs(snapName)
 	slots := m.repo.Slots(snapName)
 	newconns := make(map[string]*interfaces.ConnRef, len(plugs)+len(slots))
-	var connOpts map[string]*connectOpts
+	connOpts := make(map[string]*connectOpts)
 
 	conflictError := func(retry *state.Retry, err error) error {
 		if retry != nil {
@@ -1545,16 +1790,9 @@ func (m *InterfaceManager) doAutoConnect(task *state.Task, _ *tomb.Tomb) error {
 	// Consider gadget connections, we want to remember them in
 	// any case with "by-gadget" set, so they should be processed
 	// before the auto-connection ones.
-	if err := gadgectConnect.addGadgetConnections(newconns, conns, conflictError); err != nil {
+	if err := gadgectConnect.addGadgetConnections(newconns, connOpts, conns, conflictError); err != nil {
 		return err
 	}
-	if len(newconns) > 0 {
-		connOpts = make(map[string]*connectOpts, len(newconns))
-		byGadgetOpts := &connectOpts{AutoConnect: true, ByGadget: true}
-		for key := range newconns {
-			connOpts[key] = byGadgetOpts
-		}
-	}
 
 	// Auto-connect all the plugs unless specifically disallowed
 	checkAutoConnectAllowed := func(css []*snap.SlotInfo) []*snap.SlotInfo {
@@ -1674,6 +1912,8 @@ func (m *InterfaceManager) doAutoDisconnect(task *state.Task, _ *tomb.Tomb) erro
 	}
 
 	hookTasks := state.NewTaskSet()
+	var ifaceNames []string
+	var disconnectTaskSets []*state.TaskSet
 	for _, connRef := range connections {
 		conn, err := m.repo.Connection(connRef)
 		if err != nil {
@@ -1687,6 +1927,18 @@ func (m *InterfaceManager) doAutoDisconnect(task *state.Task, _ *tomb.Tomb) erro
 			return err
 		}
 		hookTasks.AddAll(ts)
+		ifaceNames = append(ifaceNames, conn.Interface())
+		disconnectTaskSets = append(disconnectTaskSets, ts)
+	}
+
+	// some interfaces must be disconnected before others, eg. when
+	// they use resources provided through the latter
+	for i, ts := range disconnectTaskSets {
+		for j, other := range disconnectTaskSets {
+			if i != j && m.repo.DisconnectsBefore(ifaceNames[j], ifaceNames[i]) {
+				ts.WaitAll(other)
+			}
+		}
 	}
 
 	snapstate.InjectTasks(task, hookTasks)
@@ -190,7 +190,16 @@ var profilesNeedRegenerationImpl = func(m *InterfaceManager) bool {
 		logger.Noticef("error trying to compare the snap system key: %v", err)
 		return true
 	}
-	return mismatch
+	if mismatch {
+		return true
+	}
+	// the option is not part of the system key as snap run cannot know
+	// about it, the profiles record whether it was set instead
+	var logged bool
+	if err := m.state.Get("seccomp-log-privileged-syscalls", &logged); err != nil && !errors.Is(err, state.ErrNoState) {
+		return true
+	}
+	return logged != m.logPrivilegedSyscalls
 }
 
 // Checks whether AppArmor Prompting should be used. Caller must lock m.state.
@@ -204,6 +213,25 @@ func (m *InterfaceManager) assessAppArmorPrompting() bool {
 	return false
 }
 
+// logPrivilegedSyscallsEnabled returns whether the syscalls granted by
+// privileged interfaces are logged, as set with the
+// debug.seccomp.log-privileged-syscalls system option. Caller must lock the
+// state.
+func logPrivilegedSyscallsEnabled(st *state.State) (bool, error) {
+	tr := config.NewTransaction(st)
+	var value any
+	if err := tr.Get("core", "debug.seccomp.log-privileged-syscalls", &value); err != nil && !config.IsNoOption(err) {
+		return false, err
+	}
+	switch value := value.(type) {
+	case bool:
+		return value, nil
+	case string:
+		return value == "true", nil
+	}
+	return false, nil
+}
+
 // snapdAppArmorServiceIsDisabledImpl returns true if the snapd.apparmor
 // service unit exists but is disabled
 func snapdAppArmorServiceIsDisabledImpl() bool {
@@ -212,7 +240,10 @@ func snapdAppArmorServiceIsDisabledImpl() bool {
 	return err == nil && !isEnabled
 }
 
-// regenerateAllSecurityProfiles will regenerate all security profiles. This
+// regenerateAllSecurityProfiles will regenerate all security profiles. The
+// profiles of a snap are skipped for a given security backend when they were
+// last regenerated from the same inputs, see profileDigestsForSnap, and are
+// still present on disk, see profilesPresent. This
 // function is expected to be called with the state locked, though in some
 // scenarios one may want to temporarily unlock the state for the duration of
 // security backends executing their setup.
@@ -274,6 +305,20 @@ func (m *InterfaceManager) regenerateAllSecurityProfiles(tm timings.Measurer, un
 		return precompOpts[instanceName]
 	}
 
+	extraData := interfaces.SystemKeyExtraData{
+		AppArmorPrompting: m.useAppArmorPrompting,
+	}
+
+	// Profiles of snaps which were generated from the very same inputs
+	// as the ones at hand are still current and are not regenerated.
+	digests := m.computeProfileDigests(securityBackends, appSets, precompOpts, extraData)
+	recordedDigests, err := getProfileDigests(m.state)
+	if err != nil {
+		logger.Noticef("cannot get recorded security profile digests: %v", err)
+		recordedDigests = nil
+	}
+	failedBackends := make(map[interfaces.SecuritySystem]bool)
+
 	func() {
 		if unlockState {
 			m.state.Unlock()
@@ -285,20 +330,54 @@ func (m *InterfaceManager) regenerateAllSecurityProfiles(tm timings.Measurer, un
 			if backend.Name() == "" {
 				continue // Test backends have no name, skip them to simplify testing.
 			}
-			if errors := interfaces.SetupMany(m.repo, backend, appSets, precomputedConfinementOpts, tm); len(errors) > 0 {
+			var outdated []*interfaces.SnapAppSet
+			for _, set := range appSets {
+				instanceName := set.InstanceName()
+				digest := digests[instanceName][backend.Name()]
+				if digest == "" || recordedDigests[instanceName][backend.Name()] != digest ||
+					!profilesPresent(m.repo, backend, set, precomputedConfinementOpts(instanceName)) {
+					outdated = append(outdated, set)
+				}
+			}
+			if len(outdated) == 0 {
+				logger.Debugf("%s profiles of all snaps are up to date", backend.Name())
+				continue
+			}
+			if errors := interfaces.SetupMany(m.repo, backend, outdated, precomputedConfinementOpts, tm); len(errors) > 0 {
 				logger.Noticef("cannot regenerate %s profiles", backend.Name())
 				for _, err := range errors {
 					logger.Notice(err.Error())
 				}
 				shouldWriteSystemKey = false
+				failedBackends[backend.Name()] = true
 			}
 		}
 	}()
 
-	if shouldWriteSystemKey {
-		extraData := interfaces.SystemKeyExtraData{
-			AppArmorPrompting: m.useAppArmorPrompting,
+	// Record the digests for the backends which succeeded, this also drops
+	// the digests of snaps which are no longer around.
+	newDigests := make(profileDigests, len(digests))
+	for instanceName, snapDigests := range digests {
+		for backend, digest := range snapDigests {
+			if failedBackends[backend] {
+				continue
+			}
+			if newDigests[instanceName] == nil {
+				newDigests[instanceName] = make(map[interfaces.SecuritySystem]string)
+			}
+			newDigests[instanceName][backend] = digest
+		}
+	}
+	setProfileDigests(m.state, newDigests)
+	if !failedBackends[interfaces.SecuritySecComp] {
+		if m.logPrivilegedSyscalls {
+			m.state.Set("seccomp-log-privileged-syscalls", true)
+		} else {
+			m.state.Set("seccomp-log-privileged-syscalls", nil)
 		}
+	}
+
+	if shouldWriteSystemKey {
 		if err := writeSystemKey(extraData); err != nil {
 			logger.Noticef("cannot write system key: %v", err)
 		}
@@ -306,6 +385,40 @@ func (m *InterfaceManager) regenerateAllSecurityProfiles(tm timings.Measurer, un
 	return nil
 }
 
+// computeProfileDigests computes the digests of the inputs of the security
+// profiles of the given snaps, for each of the given security backends. Snaps
+// for which the digests cannot be computed are left out.
+func (m *InterfaceManager) computeProfileDigests(backends []interfaces.SecurityBackend, appSets []*interfaces.SnapAppSet, opts map[string]interfaces.ConfinementOptions, extraData interfaces.SystemKeyExtraData) profileDigests {
+	names := make([]interfaces.SecuritySystem, 0, len(backends))
+	for _, backend := range backends {
+		if backend.Name() != "" {
+			names = append(names, backend.Name())
+		}
+	}
+	backendKeys, err := interfaces.BackendSystemKeys(names, extraData)
+	if err != nil {
+		logger.Noticef("cannot compute system key of security backends: %v", err)
+		return nil
+	}
+
+	digests := make(profileDigests, len(appSets))
+	for _, set := range appSets {
+		instanceName := set.InstanceName()
+		snapOpts, ok := opts[instanceName]
+		if !ok {
+			// confinement options are unknown, always regenerate
+			continue
+		}
+		snapDigests, err := profileDigestsForSnap(m.repo, set, snapOpts, backendKeys)
+		if err != nil {
+			logger.Noticef("cannot compute security profile digests of snap %q: %v", instanceName, err)
+			continue
+		}
+		digests[instanceName] = snapDigests
+	}
+	return digests
+}
+
 // renameCorePlugConnection renames one connection from "core-support" plug to
 // slot so that the plug name is "core-support-plug" while the slot is
 // unchanged. This matches a change introduced in 2.24, where the core snap no
@@ -584,11 +697,14 @@ func (m *InterfaceManager) setupSecurityByBackend(task *state.Task, appSets []*i
 		return fmt.Errorf("internal error: setupSecurityByBackend received an unexpected number of snaps (expected: %d, got %d)", len(opts), len(appSets))
 	}
 	confOpts := make(map[string]interfaces.ConfinementOptions, len(appSets))
+	instanceNames := make([]string, 0, len(appSets))
 	for i, set := range appSets {
 		confOpts[set.InstanceName()] = opts[i]
+		instanceNames = append(instanceNames, set.InstanceName())
 	}
 
 	st := task.State()
+	forgetProfileDigests(st, instanceNames...)
 	st.Unlock()
 	defer st.Lock()
 
@@ -613,6 +729,7 @@ func (m *InterfaceManager) setupSnapSecurity(task *state.Task, appSet *interface
 
 func (m *InterfaceManager) removeSnapSecurity(task *state.Task, instanceName string) error {
 	st := task.State()
+	forgetProfileDigests(st, instanceName)
 	for _, backend := range m.repo.Backends() {
 		st.Unlock()
 		err := backend.Remove(instanceName)
@@ -672,9 +789,10 @@ func newGadgetConnect(s *state.State, task *state.Task, repo *interfaces.Reposit
 }
 
 // addGadgetConnections adds to newconns any applicable connections
-// from the gadget connections stanza.
+// from the gadget connections stanza, along with their options carrying
+// the attributes from the gadget to connOpts.
 // conflictError is called to handle checkAutoconnectConflicts errors.
-func (gc *gadgetConnect) addGadgetConnections(newconns map[string]*interfaces.ConnRef, conns map[string]*schema.ConnState, conflictError func(*state.Retry, error) error) error {
+func (gc *gadgetConnect) addGadgetConnections(newconns map[string]*interfaces.ConnRef, connOpts map[string]*connectOpts, conns map[string]*schema.ConnState, conflictError func(*state.Retry, error) error) error {
 	var seeded bool
 	err := gc.st.Get("seeded", &seeded)
 	if err != nil && !errors.Is(err, state.ErrNoState) {
@@ -753,14 +871,78 @@ func (gc *gadgetConnect) addGadgetConnections(newconns map[string]*interfaces.Co
 			continue
 		}
 
+		if err := sanitizeGadgetConnectionAttrs(gc.repo, plug, slot, gconn.PlugAttrs, gconn.SlotAttrs); err != nil {
+			task.Logf("gadget connections: ignoring connection of plug %s:%s to slot %s:%s: %v", gconn.Plug.SnapID, gconn.Plug.Plug, gconn.Slot.SnapID, gconn.Slot.Slot, err)
+			continue
+		}
+
 		if err := addNewConnection(gc.st, task, newconns, conns, plug, slot, conflictError); err != nil {
 			return err
 		}
+		key := interfaces.NewConnRef(plug, slot).ID()
+		if _, ok := newconns[key]; ok && connOpts[key] == nil {
+			connOpts[key] = &connectOpts{
+				AutoConnect: true,
+				ByGadget:    true,
+				PlugAttrs:   gconn.PlugAttrs,
+				SlotAttrs:   gconn.SlotAttrs,
+			}
+		}
 	}
 
 	return nil
 }
 
+// sanitizeGadgetConnectionAttrs checks the attributes which a gadget
+// connection sets on the given plug and slot. The attributes cannot replace
+// the ones declared by the snaps, the plug and slot with the attributes added
+// must still pass the sanitization of the interface.
+func sanitizeGadgetConnectionAttrs(repo *interfaces.Repository, plug *snap.PlugInfo, slot *snap.SlotInfo, plugAttrs, slotAttrs map[string]any) error {
+	if len(plugAttrs) == 0 && len(slotAttrs) == 0 {
+		return nil
+	}
+	iface := repo.Interface(plug.Interface)
+	if iface == nil {
+		return fmt.Errorf("unknown interface %q", plug.Interface)
+	}
+	mergeAttrs := func(static, attrs map[string]any) (map[string]any, error) {
+		merged := make(map[string]any, len(static)+len(attrs))
+		for k, v := range static {
+			merged[k] = v
+		}
+		for k, v := range attrs {
+			if _, ok := static[k]; ok {
+				return nil, fmt.Errorf("cannot change attribute %q as it was statically specified in the snap details", k)
+			}
+			merged[k] = v
+		}
+		return merged, nil
+	}
+	if len(plugAttrs) > 0 {
+		attrs, err := mergeAttrs(plug.Attrs, plugAttrs)
+		if err != nil {
+			return err
+		}
+		sanitized := *plug
+		sanitized.Attrs = attrs
+		if err := interfaces.BeforePreparePlug(iface, &sanitized); err != nil {
+			return err
+		}
+	}
+	if len(slotAttrs) > 0 {
+		attrs, err := mergeAttrs(slot.Attrs, slotAttrs)
+		if err != nil {
+			return err
+		}
+		sanitized := *slot
+		sanitized.Attrs = attrs
+		if err := interfaces.BeforePrepareSlot(iface, &sanitized); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func addNewConnection(st *state.State, task *state.Task, newconns map[string]*interfaces.ConnRef, conns map[string]*schema.ConnState, plug *snap.PlugInfo, slot *snap.SlotInfo, conflictError func(*state.Retry, error) error) error {
 	connRef := interfaces.NewConnRef(plug, slot)
 	key := connRef.ID()
@@ -822,6 +1004,9 @@ type autoConnectChecker struct {
 	cache                map[string]*asserts.SnapDeclaration
 	baseDecl             *asserts.BaseDeclaration
 	contentCompatEnabled bool
+	// refusedSeedOnly holds the seed-only interfaces which cannot be
+	// auto-connected anymore as the system is seeded
+	refusedSeedOnly map[string]bool
 }
 
 func newAutoConnectChecker(s *state.State, repo *interfaces.Repository, deviceCtx snapstate.DeviceContext) (*autoConnectChecker, error) {
@@ -829,6 +1014,20 @@ func newAutoConnectChecker(s *state.State, repo *interfaces.Repository, deviceCt
 	if err != nil {
 		return nil, fmt.Errorf("internal error: cannot find base declaration: %v", err)
 	}
+	// the checks are done while holding the lock of the repository,
+	// figure out upfront which interfaces cannot be auto-connected
+	var seeded bool
+	if err := s.Get("seeded", &seeded); err != nil && !errors.Is(err, state.ErrNoState) {
+		return nil, err
+	}
+	refusedSeedOnly := make(map[string]bool)
+	if seeded {
+		for _, iface := range repo.AllInterfaces() {
+			if interfaces.StaticInfoOf(iface).SeedOnly {
+				refusedSeedOnly[iface.Name()] = true
+			}
+		}
+	}
 	return &autoConnectChecker{
 		st:                   s,
 		repo:                 repo,
@@ -836,6 +1035,7 @@ func newAutoConnectChecker(s *state.State, repo *interfaces.Repository, deviceCt
 		cache:                make(map[string]*asserts.SnapDeclaration),
 		baseDecl:             baseDecl,
 		contentCompatEnabled: isContentCompatLabelEnabled(s),
+		refusedSeedOnly:      refusedSeedOnly,
 	}, nil
 }
 
@@ -853,6 +1053,10 @@ func (c *autoConnectChecker) snapDeclaration(snapID string) (*asserts.SnapDeclar
 }
 
 func (c *autoConnectChecker) check(plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) (bool, interfaces.SideArity, error) {
+	if c.refusedSeedOnly[plug.Interface()] {
+		return false, nil, nil
+	}
+
 	modelAs := c.deviceCtx.Model()
 
 	var storeAs *asserts.Store
@@ -951,6 +1155,9 @@ func (c *autoConnectChecker) addAutoConnections(task *state.Task, newconns map[s
 		candSlots, arities := c.repo.AutoConnectCandidateSlots(plug.Snap.InstanceName(), plug.Name, c.check)
 
 		if len(candSlots) == 0 {
+			if err := c.suggestDefaultProvider(task, plug); err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -996,11 +1203,65 @@ func (c *autoConnectChecker) addAutoConnections(task *state.Task, newconns map[s
 	return nil
 }
 
+// defaultProviderSuggestion records that installing the default provider snap
+// of an interface would provide a slot for a plug which has none.
+type defaultProviderSuggestion struct {
+	Plug      string `json:"plug"`
+	Interface string `json:"interface"`
+	Provider  string `json:"provider"`
+}
+
+// suggestDefaultProvider records on the task, under
+// "default-provider-suggestions", that the default provider of the interface
+// of the given plug should be installed, if the interface declares one which
+// is not installed. It is called when the plug has no candidate slot.
+func (c *autoConnectChecker) suggestDefaultProvider(task *state.Task, plug *snap.PlugInfo) error {
+	iface := c.repo.Interface(plug.Interface)
+	if iface == nil {
+		return nil
+	}
+	provider := interfaces.StaticInfoOf(iface).DefaultProvider
+	if provider == "" {
+		return nil
+	}
+	var snapst snapstate.SnapState
+	err := snapstate.Get(c.st, provider, &snapst)
+	if err == nil {
+		// the provider is installed but its slots are not candidates
+		return nil
+	}
+	if !errors.Is(err, state.ErrNoState) {
+		return err
+	}
+
+	var suggestions []defaultProviderSuggestion
+	if err := task.Get("default-provider-suggestions", &suggestions); err != nil && !errors.Is(err, state.ErrNoState) {
+		return err
+	}
+	suggestion := defaultProviderSuggestion{
+		Plug:      plug.String(),
+		Interface: plug.Interface,
+		Provider:  provider,
+	}
+	for _, s := range suggestions {
+		if s == suggestion {
+			return nil
+		}
+	}
+	suggestions = append(suggestions, suggestion)
+	task.Set("default-provider-suggestions", suggestions)
+	task.Logf("No slot available for plug %s, install the default provider %q of interface %q to connect it", plug, provider, plug.Interface)
+	return nil
+}
+
 type connectChecker struct {
 	st                   *state.State
 	deviceCtx            snapstate.DeviceContext
 	baseDecl             *asserts.BaseDeclaration
 	contentCompatEnabled bool
+	// refusedSeedOnly holds the seed-only interfaces which cannot be
+	// auto-connected anymore as the system is seeded
+	refusedSeedOnly map[string]bool
 }
 
 func newConnectChecker(s *state.State, deviceCtx snapstate.DeviceContext) (*connectChecker, error) {
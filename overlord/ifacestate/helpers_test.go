@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -37,6 +38,7 @@ import (
 	"github.com/snapcore/snapd/logger"
 	"github.com/snapcore/snapd/osutil"
 	"github.com/snapcore/snapd/overlord"
+	"github.com/snapcore/snapd/overlord/configstate/config"
 	"github.com/snapcore/snapd/overlord/ifacestate"
 	"github.com/snapcore/snapd/overlord/snapstate"
 	"github.com/snapcore/snapd/overlord/snapstate/snapstatetest"
@@ -417,6 +419,8 @@ apps:
 	// function that writes the new system key with one always panics.
 	restore = ifacestate.MockProfilesNeedRegeneration(func(m *ifacestate.InterfaceManager) bool { return true })
 	defer restore()
+	restore = interfaces.MockSystemKey(`{"build-id": "abcde"}`)
+	defer restore()
 	restore = ifacestate.MockWriteSystemKey(func(extraData interfaces.SystemKeyExtraData) error { panic("should not attempt to write system key") })
 	defer restore()
 	// Put a fake system key in place, we just want to see that file being removed.
@@ -492,6 +496,8 @@ func (s *helpersSuite) TestProfileRegenerationSetupMany(c *C) {
 	// Pretend that security profiles are out of date.
 	restore = ifacestate.MockProfilesNeedRegeneration(func(m *ifacestate.InterfaceManager) bool { return true })
 	defer restore()
+	restore = interfaces.MockSystemKey(`{"build-id": "abcde"}`)
+	defer restore()
 	restore = ifacestate.MockWriteSystemKey(func(extraData interfaces.SystemKeyExtraData) error {
 		writeKey = true
 		return nil
@@ -540,6 +546,8 @@ func (s *helpersSuite) TestProfileRegenerationSetupManyFailsSystemKeyNotWritten(
 	// Pretend that security profiles are out of date.
 	restore = ifacestate.MockProfilesNeedRegeneration(func(m *ifacestate.InterfaceManager) bool { return true })
 	defer restore()
+	restore = interfaces.MockSystemKey(`{"build-id": "abcde"}`)
+	defer restore()
 	restore = ifacestate.MockWriteSystemKey(func(extraData interfaces.SystemKeyExtraData) error {
 		writeKey = true
 		return nil
@@ -811,3 +819,271 @@ func (s *helpersSuite) TestHasActiveConnection(c *C) {
 	c.Assert(err, IsNil)
 	c.Check(active, Equals, true)
 }
+
+func (s *helpersSuite) TestProfileRegenerationSkipsUpToDateSnaps(c *C) {
+	dirs.SetRootDir(c.MkDir())
+	defer dirs.SetRootDir("")
+
+	backend := &ifacetest.TestSecurityBackend{BackendName: "fake"}
+	restore := ifacestate.MockSecurityBackends([]interfaces.SecurityBackend{backend})
+	defer restore()
+
+	// Create a mock overlord, mainly to have state.
+	ovld := overlord.Mock()
+	st := ovld.State()
+
+	mockSnaps(c, st)
+
+	// Pretend that security profiles are out of date.
+	restore = ifacestate.MockProfilesNeedRegeneration(func(m *ifacestate.InterfaceManager) bool { return true })
+	defer restore()
+	restore = ifacestate.MockWriteSystemKey(func(extraData interfaces.SystemKeyExtraData) error { return nil })
+	defer restore()
+	restore = interfaces.MockSystemKey(`{"build-id": "abcde"}`)
+	defer restore()
+
+	startUp := func() []string {
+		backend.SetupCalls = nil
+		mgr, err := ifacestate.Manager(st, nil, ovld.TaskRunner(), nil, nil)
+		c.Assert(err, IsNil)
+		c.Assert(mgr.StartUp(), IsNil)
+		var setUp []string
+		for _, call := range backend.SetupCalls {
+			setUp = append(setUp, call.AppSet.InstanceName())
+		}
+		sort.Strings(setUp)
+		return setUp
+	}
+
+	// nothing is known about the profiles on disk
+	c.Check(startUp(), DeepEquals, []string{"bar", "foo"})
+
+	// profiles are regenerated from the very same inputs
+	c.Check(startUp(), IsNil)
+
+	// a new revision of one of the snaps
+	si := &snap.SideInfo{Revision: snap.R(2), RealName: "foo"}
+	snaptest.MockSnap(c, "name: foo\nversion: 2\n", si)
+	st.Lock()
+	var snapst snapstate.SnapState
+	c.Assert(snapstate.Get(st, "foo", &snapst), IsNil)
+	snapst.Sequence = snapstatetest.NewSequenceFromSnapSideInfos([]*snap.SideInfo{si})
+	snapst.Current = snap.R(2)
+	snapstate.Set(st, "foo", &snapst)
+	st.Unlock()
+	c.Check(startUp(), DeepEquals, []string{"foo"})
+
+	// the system changed
+	restore = interfaces.MockSystemKey(`{"build-id": "fghij"}`)
+	defer restore()
+	c.Check(startUp(), DeepEquals, []string{"bar", "foo"})
+
+	// the profiles of a snap were set up in the meantime
+	st.Lock()
+	ifacestate.ForgetProfileDigests(st, "bar")
+	st.Unlock()
+	c.Check(startUp(), DeepEquals, []string{"bar"})
+}
+
+func (s *helpersSuite) TestProfileRegenerationLogPrivilegedSyscalls(c *C) {
+	dirs.SetRootDir(c.MkDir())
+	defer dirs.SetRootDir("")
+
+	backend := &ifacetest.TestSecurityBackend{BackendName: "fake"}
+	restore := ifacestate.MockSecurityBackends([]interfaces.SecurityBackend{backend})
+	defer restore()
+
+	ovld := overlord.Mock()
+	st := ovld.State()
+
+	mockSnaps(c, st)
+
+	// the system key on disk is current
+	restore = interfaces.MockSystemKey(`{"build-id": "abcde"}`)
+	defer restore()
+	c.Assert(interfaces.WriteSystemKey(interfaces.SystemKeyExtraData{}), IsNil)
+	restore = ifacestate.MockWriteSystemKey(func(extraData interfaces.SystemKeyExtraData) error { return nil })
+	defer restore()
+
+	startUp := func() (setUp []string, logged []bool) {
+		backend.SetupCalls = nil
+		mgr, err := ifacestate.Manager(st, nil, ovld.TaskRunner(), nil, nil)
+		c.Assert(err, IsNil)
+		c.Assert(mgr.StartUp(), IsNil)
+		for _, call := range backend.SetupCalls {
+			setUp = append(setUp, call.AppSet.InstanceName())
+			logged = append(logged, call.Options.LogPrivilegedSyscalls)
+		}
+		sort.Strings(setUp)
+		return setUp, logged
+	}
+	setOption := func(value any) {
+		st.Lock()
+		defer st.Unlock()
+		tr := config.NewTransaction(st)
+		c.Assert(tr.Set("core", "debug.seccomp.log-privileged-syscalls", value), IsNil)
+		tr.Commit()
+	}
+
+	setUp, _ := startUp()
+	c.Check(setUp, HasLen, 0)
+
+	// toggling the option regenerates the profiles
+	setOption("true")
+	setUp, logged := startUp()
+	c.Check(setUp, DeepEquals, []string{"bar", "foo"})
+	c.Check(logged, DeepEquals, []bool{true, true})
+	setUp, _ = startUp()
+	c.Check(setUp, HasLen, 0)
+
+	setOption(false)
+	setUp, logged = startUp()
+	c.Check(setUp, DeepEquals, []string{"bar", "foo"})
+	c.Check(logged, DeepEquals, []bool{false, false})
+	setUp, _ = startUp()
+	c.Check(setUp, HasLen, 0)
+}
+
+func (s *helpersSuite) TestProfileRegenerationFailedBackendNotRecorded(c *C) {
+	dirs.SetRootDir(c.MkDir())
+	defer dirs.SetRootDir("")
+
+	fail := true
+	backend := &ifacetest.TestSecurityBackend{
+		BackendName: "fake",
+		SetupCallback: func(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, repo *interfaces.Repository) error {
+			if fail && appSet.InstanceName() == "foo" {
+				return errors.New("FAILED")
+			}
+			return nil
+		},
+	}
+	restore := ifacestate.MockSecurityBackends([]interfaces.SecurityBackend{backend})
+	defer restore()
+
+	ovld := overlord.Mock()
+	st := ovld.State()
+
+	mockSnaps(c, st)
+
+	restore = ifacestate.MockProfilesNeedRegeneration(func(m *ifacestate.InterfaceManager) bool { return true })
+	defer restore()
+	restore = ifacestate.MockWriteSystemKey(func(extraData interfaces.SystemKeyExtraData) error { return nil })
+	defer restore()
+	restore = interfaces.MockSystemKey(`{"build-id": "abcde"}`)
+	defer restore()
+
+	for i := 0; i < 2; i++ {
+		backend.SetupCalls = nil
+		mgr, err := ifacestate.Manager(st, nil, ovld.TaskRunner(), nil, nil)
+		c.Assert(err, IsNil)
+		c.Assert(mgr.StartUp(), IsNil)
+		// all snaps are set up again after a failure
+		c.Check(backend.SetupCalls, HasLen, 2)
+		fail = false
+	}
+}
+
+type devicePresenceInterface struct {
+	ifacetest.TestInterface
+}
+
+func (iface *devicePresenceInterface) PlugDevicePatterns() []string {
+	return []string{"/dev/tee[0-9]*"}
+}
+
+func (s *helpersSuite) TestProfileDigestsDevicePresence(c *C) {
+	dirs.SetRootDir(c.MkDir())
+	defer dirs.SetRootDir("")
+
+	repo := interfaces.NewRepository()
+	c.Assert(repo.AddInterface(&devicePresenceInterface{ifacetest.TestInterface{InterfaceName: "test"}}), IsNil)
+	c.Assert(repo.AddInterface(&ifacetest.TestInterface{InterfaceName: "test2"}), IsNil)
+	c.Assert(repo.AddAppSet(ifacetest.MockInfoAndAppSet(c, producerYaml, nil, nil)), IsNil)
+	appSet := ifacetest.MockInfoAndAppSet(c, consumerYaml, nil, nil)
+	c.Assert(repo.AddAppSet(appSet), IsNil)
+	_, err := repo.Connect(&interfaces.ConnRef{
+		PlugRef: interfaces.PlugRef{Snap: "consumer", Name: "plug"},
+		SlotRef: interfaces.SlotRef{Snap: "producer", Name: "slot"},
+	}, nil, nil, nil, nil, nil)
+	c.Assert(err, IsNil)
+
+	backendKeys := map[interfaces.SecuritySystem]string{
+		interfaces.SecurityUDev:     "key",
+		interfaces.SecurityAppArmor: "key",
+	}
+	before, err := ifacestate.ProfileDigestsForSnap(repo, appSet, interfaces.ConfinementOptions{}, backendKeys)
+	c.Assert(err, IsNil)
+
+	// a TEE device shows up after the profiles were generated
+	c.Assert(os.MkdirAll(filepath.Join(dirs.GlobalRootDir, "/dev"), 0755), IsNil)
+	c.Assert(os.WriteFile(filepath.Join(dirs.GlobalRootDir, "/dev/tee0"), nil, 0644), IsNil)
+
+	after, err := ifacestate.ProfileDigestsForSnap(repo, appSet, interfaces.ConfinementOptions{}, backendKeys)
+	c.Assert(err, IsNil)
+	c.Check(after[interfaces.SecurityUDev], Not(Equals), before[interfaces.SecurityUDev])
+	c.Check(after[interfaces.SecurityAppArmor], Equals, before[interfaces.SecurityAppArmor])
+}
+
+// profilesSecurityBackend is a test security backend writing one file per
+// snap, which it can tell through Profiles.
+type profilesSecurityBackend struct {
+	ifacetest.TestSecurityBackend
+}
+
+func (b *profilesSecurityBackend) Profiles(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, repo *interfaces.Repository) (map[string][]byte, error) {
+	path := filepath.Join(dirs.GlobalRootDir, "profiles", appSet.InstanceName())
+	return map[string][]byte{path: []byte("profile")}, nil
+}
+
+func (s *helpersSuite) TestProfileRegenerationMissingProfiles(c *C) {
+	dirs.SetRootDir(c.MkDir())
+	defer dirs.SetRootDir("")
+
+	backend := &profilesSecurityBackend{}
+	backend.BackendName = "fake"
+	backend.SetupCallback = func(appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, repo *interfaces.Repository) error {
+		profiles, err := backend.Profiles(appSet, opts, repo)
+		c.Assert(err, IsNil)
+		for path, content := range profiles {
+			c.Assert(os.MkdirAll(filepath.Dir(path), 0755), IsNil)
+			c.Assert(os.WriteFile(path, content, 0644), IsNil)
+		}
+		return nil
+	}
+	restore := ifacestate.MockSecurityBackends([]interfaces.SecurityBackend{backend})
+	defer restore()
+
+	ovld := overlord.Mock()
+	st := ovld.State()
+
+	mockSnaps(c, st)
+
+	restore = ifacestate.MockProfilesNeedRegeneration(func(m *ifacestate.InterfaceManager) bool { return true })
+	defer restore()
+	restore = ifacestate.MockWriteSystemKey(func(extraData interfaces.SystemKeyExtraData) error { return nil })
+	defer restore()
+	restore = interfaces.MockSystemKey(`{"build-id": "abcde"}`)
+	defer restore()
+
+	startUp := func() []string {
+		backend.SetupCalls = nil
+		mgr, err := ifacestate.Manager(st, nil, ovld.TaskRunner(), nil, nil)
+		c.Assert(err, IsNil)
+		c.Assert(mgr.StartUp(), IsNil)
+		var setUp []string
+		for _, call := range backend.SetupCalls {
+			setUp = append(setUp, call.AppSet.InstanceName())
+		}
+		sort.Strings(setUp)
+		return setUp
+	}
+
+	c.Check(startUp(), DeepEquals, []string{"bar", "foo"})
+	c.Check(startUp(), IsNil)
+
+	// the profile of a snap was removed behind the back of snapd
+	c.Assert(os.Remove(filepath.Join(dirs.GlobalRootDir, "profiles", "bar")), IsNil)
+	c.Check(startUp(), DeepEquals, []string{"bar"})
+	c.Check(startUp(), IsNil)
+}
@@ -70,6 +70,13 @@ type InterfaceManager struct {
 	interfacesRequestsManagerMu sync.Mutex
 	interfacesRequestsManager   *apparmorprompting.InterfacesRequestsManager
 
+	// whether the syscalls granted by privileged interfaces are logged,
+	// see logPrivilegedSyscallsEnabled
+	logPrivilegedSyscalls bool
+
+	// time at which denials are collected next, see ensureDenialStats
+	denialStatsNextCollect time.Time
+
 	preseed bool
 }
 
@@ -104,6 +111,7 @@ func Manager(s *state.State, hookManager *hookstate.HookManager, runner *state.T
 
 	addHandler("connect", m.doConnect, m.undoConnect)
 	addHandler("disconnect", m.doDisconnect, m.undoDisconnect)
+	addHandler("update-connection-attrs", m.doUpdateConnectionAttrs, m.undoUpdateConnectionAttrs)
 	addHandler("setup-profiles", m.doSetupProfiles, m.undoSetupProfiles)
 	addHandler("remove-profiles", m.doRemoveProfiles, m.doSetupProfiles)
 	addHandler("discard-conns", m.doDiscardConns, m.undoDiscardConns)
@@ -177,6 +185,13 @@ func (m *InterfaceManager) StartUp() error {
 	if assessAppArmorPrompting(m) {
 		m.useAppArmorPrompting = true
 	}
+	// Likewise, toggling the logging of privileged syscalls imposes a
+	// restart of snapd.
+	logPrivilegedSyscalls, err := logPrivilegedSyscallsEnabled(s)
+	if err != nil {
+		logger.Noticef("cannot get the debug.seccomp.log-privileged-syscalls option: %v", err)
+	}
+	m.logPrivilegedSyscalls = logPrivilegedSyscalls
 
 	appSets, err := snapsWithSecurityProfiles(m.state)
 	if err != nil {
@@ -279,6 +294,8 @@ func (m *InterfaceManager) Ensure() error {
 		return nil
 	}
 
+	m.ensureDenialStats()
+
 	if m.udevMonitorDisabled {
 		return nil
 	}
@@ -307,6 +324,48 @@ func (m *InterfaceManager) Ensure() error {
 	return nil
 }
 
+// ensureDenialStats periodically collects the denials logged by the kernel
+// when the denial-stats feature is enabled. The collected statistics are
+// dropped as soon as the feature is disabled.
+func (m *InterfaceManager) ensureDenialStats() {
+	logger.Trace("ensure", "manager", "InterfaceManager", "func", "ensureDenialStats")
+	now := time.Now()
+	if now.Before(m.denialStatsNextCollect) {
+		return
+	}
+	m.denialStatsNextCollect = now.Add(denialStatsCollectInterval)
+
+	st := m.state
+	st.Lock()
+	defer st.Unlock()
+
+	enabled, err := denialStatsEnabled(st)
+	if err != nil {
+		logger.Noticef("cannot check denial statistics feature: %v", err)
+		return
+	}
+	if !enabled {
+		forgetDenialStats(st)
+		return
+	}
+
+	cursor, err := denialStatsCursor(st)
+	if err != nil {
+		logger.Noticef("cannot get denial statistics cursor: %v", err)
+		return
+	}
+	st.Unlock()
+	log, err := denialStatsKernelLog(cursor)
+	st.Lock()
+	if err != nil {
+		logger.Noticef("cannot read kernel log: %v", err)
+		return
+	}
+	if err := addDenialStats(st, log); err != nil {
+		logger.Noticef("cannot collect denial statistics: %v", err)
+	}
+}
+
 // Stop implements StateStopper. It stops the udev monitor and prompting,
 // if running.
 func (m *InterfaceManager) Stop() {
@@ -382,6 +441,9 @@ type ConnectionState struct {
 	StaticSlotAttrs  map[string]any
 	DynamicSlotAttrs map[string]any
 	HotplugGone      bool
+	// Audit indicates whether the rules of the plug side of the connection
+	// log the accesses and syscalls they allow
+	Audit bool
 }
 
 // Active returns true if connection is not undesired and not removed by
@@ -413,6 +475,7 @@ func ConnectionStates(st *state.State) (connStateByRef map[string]ConnectionStat
 			StaticSlotAttrs:  cstate.StaticSlotAttrs,
 			DynamicSlotAttrs: cstate.DynamicSlotAttrs,
 			HotplugGone:      cstate.HotplugGone,
+			Audit:            cstate.Audit,
 		}
 	}
 	return connStateByRef, nil
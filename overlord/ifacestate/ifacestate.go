@@ -89,15 +89,40 @@ type connectOpts struct {
 	AutoConnect bool
 
 	DelayedSetupProfiles bool
+
+	Audit bool
+
+	// PlugAttrs and SlotAttrs are the initial dynamic attributes of the
+	// plug and slot, as set by gadget connections.
+	PlugAttrs map[string]any
+	SlotAttrs map[string]any
+}
+
+// ConnectOptions carries options for connecting an interface.
+type ConnectOptions struct {
+	// Audit requests the rules of the plug to log the accesses and syscalls
+	// they allow while the connection is established, the rules are still
+	// enforced.
+	Audit bool
 }
 
 // Connect returns a set of tasks for connecting an interface.
 func Connect(st *state.State, plugSnap, plugName, slotSnap, slotName string) (*state.TaskSet, error) {
+	return ConnectWithOptions(st, plugSnap, plugName, slotSnap, slotName, nil)
+}
+
+// ConnectWithOptions returns a set of tasks for connecting an interface with
+// the given options.
+func ConnectWithOptions(st *state.State, plugSnap, plugName, slotSnap, slotName string, opts *ConnectOptions) (*state.TaskSet, error) {
 	if err := snapstate.CheckChangeConflictMany(st, []string{plugSnap, slotSnap}, ""); err != nil {
 		return nil, err
 	}
 
-	return connect(st, plugSnap, plugName, slotSnap, slotName, connectOpts{})
+	var flags connectOpts
+	if opts != nil {
+		flags.Audit = opts.Audit
+	}
+	return connect(st, plugSnap, plugName, slotSnap, slotName, flags)
 }
 
 func connect(st *state.State, plugSnap, plugName, slotSnap, slotName string, flags connectOpts) (*state.TaskSet, error) {
@@ -212,14 +237,24 @@ func connect(st *state.State, plugSnap, plugName, slotSnap, slotName string, fla
 	if flags.DelayedSetupProfiles {
 		connectInterface.Set("delayed-setup-profiles", true)
 	}
+	if flags.Audit {
+		connectInterface.Set("audit", true)
+	}
 
 	// Expose a copy of all plug and slot attributes coming from yaml to interface hooks. The hooks will be able
 	// to modify them but all attributes will be checked against assertions after the hooks are run.
-	emptyDynamicAttrs := map[string]any{}
+	// Gadget connections can provide initial dynamic attributes.
+	plugDynamic, slotDynamic := flags.PlugAttrs, flags.SlotAttrs
+	if plugDynamic == nil {
+		plugDynamic = map[string]any{}
+	}
+	if slotDynamic == nil {
+		slotDynamic = map[string]any{}
+	}
 	connectInterface.Set("plug-static", plugStatic)
 	connectInterface.Set("slot-static", slotStatic)
-	connectInterface.Set("plug-dynamic", emptyDynamicAttrs)
-	connectInterface.Set("slot-dynamic", emptyDynamicAttrs)
+	connectInterface.Set("plug-dynamic", plugDynamic)
+	connectInterface.Set("slot-dynamic", slotDynamic)
 
 	// The main 'connect' task should wait on prepare-slot- hook or on prepare-plug- hook (whichever is present),
 	// but not on both. While there would be no harm in waiting for both, it's not needed as prepare-slot- will
@@ -318,6 +353,21 @@ func initialConnectAttributes(st *state.State, plugSnapInfo *snap.Info, plugSnap
 	return plug.Attrs, slot.Attrs, nil
 }
 
+// seedOnlyAfterSeeding returns whether the given interface can only be
+// connected while seeding and the system is seeded already.
+func seedOnlyAfterSeeding(st *state.State, repo *interfaces.Repository, ifaceName string) (bool, error) {
+	iface := repo.Interface(ifaceName)
+	if iface == nil || !interfaces.StaticInfoOf(iface).SeedOnly {
+		return false, nil
+	}
+	var seeded bool
+	err := st.Get("seeded", &seeded)
+	if err != nil && !errors.Is(err, state.ErrNoState) {
+		return false, err
+	}
+	return seeded, nil
+}
+
 // Disconnect returns a set of tasks for disconnecting an interface.
 func Disconnect(st *state.State, conn *interfaces.Connection) (*state.TaskSet, error) {
 	plugSnap := conn.Plug.Snap().InstanceName()
@@ -329,6 +379,43 @@ func Disconnect(st *state.State, conn *interfaces.Connection) (*state.TaskSet, e
 	return disconnectTasks(st, conn, disconnectOpts{})
 }
 
+// UpdateConnectionAttrs returns a set of tasks for setting dynamic attributes
+// of both sides of an established connection. The security profiles of the
+// snaps are regenerated and reloaded in place, the connection is not
+// disconnected and apps and services keep running. Attributes statically
+// specified in the snap details cannot be changed.
+func UpdateConnectionAttrs(st *state.State, conn *interfaces.Connection, plugAttrs, slotAttrs map[string]any) (*state.TaskSet, error) {
+	plugSnap := conn.Plug.Snap().InstanceName()
+	slotSnap := conn.Slot.Snap().InstanceName()
+	plugName := conn.Plug.Name()
+	slotName := conn.Slot.Name()
+	if len(plugAttrs) == 0 && len(slotAttrs) == 0 {
+		return nil, fmt.Errorf("cannot update attributes of connection %s:%s %s:%s: no attributes given", plugSnap, plugName, slotSnap, slotName)
+	}
+	if _, err := updatedDynamicAttrs("plug", plugName, plugSnap, conn.Plug.StaticAttrs(), nil, plugAttrs); err != nil {
+		return nil, err
+	}
+	if _, err := updatedDynamicAttrs("slot", slotName, slotSnap, conn.Slot.StaticAttrs(), nil, slotAttrs); err != nil {
+		return nil, err
+	}
+	if err := snapstate.CheckChangeConflictMany(st, []string{plugSnap, slotSnap}, ""); err != nil {
+		return nil, err
+	}
+
+	summary := fmt.Sprintf(i18n.G("Update attributes of connection %s:%s to %s:%s"),
+		plugSnap, plugName, slotSnap, slotName)
+	task := st.NewTask("update-connection-attrs", summary)
+	task.Set("slot", interfaces.SlotRef{Snap: slotSnap, Name: slotName})
+	task.Set("plug", interfaces.PlugRef{Snap: plugSnap, Name: plugName})
+	if len(plugAttrs) > 0 {
+		task.Set("plug-attrs", plugAttrs)
+	}
+	if len(slotAttrs) > 0 {
+		task.Set("slot-attrs", slotAttrs)
+	}
+	return state.NewTaskSet(task), nil
+}
+
 // Forget returs a set of tasks for disconnecting and forgetting an interface.
 // If the interface is already disconnected, it will be removed from the state
 // (forgotten).
@@ -543,6 +630,7 @@ func delayedCrossMgrInit() {
 		// hook into conflict checks mechanisms
 		snapstate.RegisterAffectedSnapsByKind("connect", connectDisconnectAffectedSnaps)
 		snapstate.RegisterAffectedSnapsByKind("disconnect", connectDisconnectAffectedSnaps)
+		snapstate.RegisterAffectedSnapsByKind("update-connection-attrs", connectDisconnectAffectedSnaps)
 
 		// hook into snap linking/unlinking and activation state changes
 		snapstate.AddLinkSnapParticipant(snapstate.LinkSnapParticipantFunc(OnSnapLinkageChanged))
@@ -39,6 +39,7 @@ import (
 	"github.com/snapcore/snapd/features"
 	"github.com/snapcore/snapd/interfaces"
 	"github.com/snapcore/snapd/interfaces/builtin"
+	"github.com/snapcore/snapd/interfaces/dbus"
 	"github.com/snapcore/snapd/interfaces/hotplug"
 	"github.com/snapcore/snapd/interfaces/ifacetest"
 	"github.com/snapcore/snapd/logger"
@@ -1550,6 +1551,54 @@ slots:
 	check(change)
 }
 
+func (s *interfaceManagerSuite) testConnectSeedOnly(c *C, seeded bool, check func(*state.Change)) {
+	s.MockModel(c, nil)
+	s.mockIfaces(&ifacetest.TestInterface{
+		InterfaceName:       "test",
+		InterfaceStaticInfo: interfaces.StaticInfo{SeedOnly: true},
+	}, &ifacetest.TestInterface{InterfaceName: "test2"})
+	s.MockSnapDecl(c, "consumer", "one-publisher", nil)
+	s.mockSnap(c, consumerYaml)
+	s.MockSnapDecl(c, "producer", "one-publisher", nil)
+	s.mockSnap(c, producerYaml)
+	_ = s.manager(c)
+
+	s.state.Lock()
+	s.state.Set("seeded", seeded)
+	change := s.state.NewChange("kind", "summary")
+	ts, err := ifacestate.Connect(s.state, "consumer", "plug", "producer", "slot")
+	c.Assert(err, IsNil)
+	change.AddAll(ts)
+	s.state.Unlock()
+
+	s.settle(c)
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	check(change)
+}
+
+func (s *interfaceManagerSuite) TestConnectSeedOnlyWhileSeeding(c *C) {
+	s.testConnectSeedOnly(c, false, func(change *state.Change) {
+		c.Assert(change.Err(), IsNil)
+		c.Check(change.Status(), Equals, state.DoneStatus)
+
+		repo := s.manager(c).Repository()
+		c.Check(repo.Interfaces().Connections, HasLen, 1)
+	})
+}
+
+func (s *interfaceManagerSuite) TestConnectSeedOnlyAfterSeeding(c *C) {
+	s.testConnectSeedOnly(c, true, func(change *state.Change) {
+		c.Check(change.Err(), ErrorMatches, `(?s).*cannot connect "test" interface: it can only be connected while the system is being seeded.*`)
+		c.Check(change.Status(), Equals, state.ErrorStatus)
+
+		repo := s.manager(c).Repository()
+		c.Check(repo.Interfaces().Connections, HasLen, 0)
+	})
+}
+
 func (s *interfaceManagerSuite) TestConnectTaskCheckDeviceScopeNoStore(c *C) {
 	s.MockModel(c, nil)
 
@@ -3035,6 +3084,69 @@ func (s *interfaceManagerSuite) TestDoSetupSnapSecurityAutoConnectsPlugs(c *C) {
 	c.Assert(ifaces.Connections, HasLen, 1) //FIXME add deep eq
 }
 
+func (s *interfaceManagerSuite) testAutoConnectDefaultProvider(c *C, providerInstalled bool) *state.Task {
+	s.MockModel(c, nil)
+	s.mockIfaces(&ifacetest.TestInterface{
+		InterfaceName:       "test",
+		InterfaceStaticInfo: interfaces.StaticInfo{DefaultProvider: "producer"},
+	}, &ifacetest.TestInterface{InterfaceName: "test2"})
+	s.mockSnap(c, ubuntuCoreSnapYaml)
+	if providerInstalled {
+		// the slots of the producer are not auto-connected, as the
+		// interface has no base declaration
+		s.mockSnap(c, producerYaml)
+	}
+	_ = s.manager(c)
+
+	snapInfo := s.mockSnap(c, consumerYaml)
+	change := s.addSetupSnapSecurityChange(c, &snapstate.SnapSetup{
+		SideInfo: &snap.SideInfo{
+			RealName: snapInfo.SnapName(),
+			Revision: snapInfo.Revision,
+		},
+	})
+	s.settle(c)
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	c.Assert(change.Status(), Equals, state.DoneStatus)
+	for _, t := range change.Tasks() {
+		if t.Kind() == "auto-connect" {
+			return t
+		}
+	}
+	c.Fatalf("cannot find the auto-connect task")
+	return nil
+}
+
+func (s *interfaceManagerSuite) TestAutoConnectSuggestsDefaultProvider(c *C) {
+	task := s.testAutoConnectDefaultProvider(c, false)
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	var suggestions []map[string]any
+	c.Assert(task.Get("default-provider-suggestions", &suggestions), IsNil)
+	c.Check(suggestions, DeepEquals, []map[string]any{{
+		"plug":      "consumer:plug",
+		"interface": "test",
+		"provider":  "producer",
+	}})
+	c.Check(strings.Join(task.Log(), "\n"), Matches, `(?s).*No slot available for plug consumer:plug, install the default provider "producer" of interface "test" to connect it.*`)
+}
+
+func (s *interfaceManagerSuite) TestAutoConnectNoSuggestionWhenDefaultProviderInstalled(c *C) {
+	task := s.testAutoConnectDefaultProvider(c, true)
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	var suggestions []map[string]any
+	c.Check(task.Get("default-provider-suggestions", &suggestions), testutil.ErrorIs, state.ErrNoState)
+	c.Check(strings.Join(task.Log(), "\n"), Not(testutil.Contains), "default provider")
+}
+
 // The auto-connect task will auto-connect slots with viable candidates.
 func (s *interfaceManagerSuite) TestDoSetupSnapSecurityAutoConnectsSlots(c *C) {
 	s.MockModel(c, nil)
@@ -5340,6 +5452,53 @@ func (s *interfaceManagerSuite) TestConnectSetsUpSecurity(c *C) {
 	c.Check(s.secBackend.SetupCalls[1].Options, DeepEquals, interfaces.ConfinementOptions{KernelSnap: "krnl"})
 }
 
+func (s *interfaceManagerSuite) TestConnectAuditSetsUpSecurity(c *C) {
+	s.MockModel(c, nil)
+
+	s.mockIfaces(&ifacetest.TestInterface{InterfaceName: "test"}, &ifacetest.TestInterface{InterfaceName: "test2"})
+
+	s.mockSnap(c, consumerYaml)
+	s.mockSnap(c, producerYaml)
+	_ = s.manager(c)
+
+	s.state.Lock()
+	ts, err := ifacestate.ConnectWithOptions(s.state, "consumer", "plug", "producer", "slot", &ifacestate.ConnectOptions{Audit: true})
+	c.Assert(err, IsNil)
+	ts.Tasks()[0].Set("snap-setup", &snapstate.SnapSetup{
+		SideInfo: &snap.SideInfo{
+			RealName: "consumer",
+		},
+	})
+	var audit bool
+	c.Assert(ts.Tasks()[2].Kind(), Equals, "connect")
+	c.Assert(ts.Tasks()[2].Get("audit", &audit), IsNil)
+	c.Check(audit, Equals, true)
+
+	change := s.state.NewChange("connect", "")
+	change.AddAll(ts)
+	s.state.Unlock()
+
+	s.settle(c)
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	c.Assert(change.Err(), IsNil)
+	c.Check(change.Status(), Equals, state.DoneStatus)
+
+	c.Assert(s.secBackend.SetupCalls, HasLen, 2)
+	c.Check(s.secBackend.SetupCalls[0].AppSet.InstanceName(), Equals, "producer")
+	c.Check(s.secBackend.SetupCalls[1].AppSet.InstanceName(), Equals, "consumer")
+
+	// only the plug side is audited
+	c.Check(s.secBackend.SetupCalls[0].Options, DeepEquals, interfaces.ConfinementOptions{KernelSnap: "krnl"})
+	c.Check(s.secBackend.SetupCalls[1].Options, DeepEquals, interfaces.ConfinementOptions{KernelSnap: "krnl", AuditedPlugs: []string{"plug"}})
+
+	connStates, err := ifacestate.ConnectionStates(s.state)
+	c.Assert(err, IsNil)
+	c.Check(connStates["consumer:plug producer:slot"].Audit, Equals, true)
+}
+
 func (s *interfaceManagerSuite) TestConnectWithComponentsSetsUpSecurity(c *C) {
 	s.MockModel(c, nil)
 
@@ -5479,6 +5638,190 @@ func (s *interfaceManagerSuite) TestDisconnectSetsUpSecurity(c *C) {
 	c.Check(s.secBackend.SetupCalls[1].Options, DeepEquals, interfaces.ConfinementOptions{KernelSnap: "krnl"})
 }
 
+func (s *interfaceManagerSuite) TestDisconnectAuditedSetsUpSecurity(c *C) {
+	s.mockIfaces(&ifacetest.TestInterface{InterfaceName: "test"}, &ifacetest.TestInterface{InterfaceName: "test2"})
+	s.mockSnap(c, consumerYaml)
+	s.mockSnap(c, producerYaml)
+
+	s.state.Lock()
+	s.state.Set("conns", map[string]any{
+		"consumer:plug producer:slot": map[string]any{"interface": "test", "audit": true},
+	})
+	s.state.Unlock()
+
+	s.manager(c)
+	conn := s.getConnection(c, "consumer", "plug", "producer", "slot")
+
+	s.state.Lock()
+	ts, err := ifacestate.Disconnect(s.state, conn)
+	c.Assert(err, IsNil)
+	ts.Tasks()[0].Set("snap-setup", &snapstate.SnapSetup{
+		SideInfo: &snap.SideInfo{
+			RealName: "consumer",
+		},
+	})
+
+	change := s.state.NewChange("disconnect", "")
+	change.AddAll(ts)
+	s.state.Unlock()
+
+	s.settle(c)
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	c.Assert(change.Err(), IsNil)
+	c.Check(change.Status(), Equals, state.DoneStatus)
+
+	// the connection is gone so the plug is no longer audited
+	c.Assert(s.secBackend.SetupCalls, HasLen, 2)
+	c.Check(s.secBackend.SetupCalls[0].AppSet.InstanceName(), Equals, "consumer")
+	c.Check(s.secBackend.SetupCalls[0].Options, DeepEquals, interfaces.ConfinementOptions{KernelSnap: "krnl"})
+}
+
+func (s *interfaceManagerSuite) TestUpdateConnectionAttrsTask(c *C) {
+	s.mockIfaces(&ifacetest.TestInterface{InterfaceName: "test"}, &ifacetest.TestInterface{InterfaceName: "test2"})
+	s.mockSnap(c, consumerYaml)
+	s.mockSnap(c, producerYaml)
+
+	s.state.Lock()
+	s.state.Set("conns", map[string]any{
+		"consumer:plug producer:slot": map[string]any{"interface": "test"},
+	})
+	s.state.Unlock()
+
+	conn := s.getConnection(c, "consumer", "plug", "producer", "slot")
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	ts, err := ifacestate.UpdateConnectionAttrs(s.state, conn, map[string]any{"path": "/foo"}, nil)
+	c.Assert(err, IsNil)
+	c.Assert(ts.Tasks(), HasLen, 1)
+	task := ts.Tasks()[0]
+	c.Check(task.Kind(), Equals, "update-connection-attrs")
+	c.Check(task.Summary(), Equals, "Update attributes of connection consumer:plug to producer:slot")
+	var plugAttrs map[string]any
+	c.Assert(task.Get("plug-attrs", &plugAttrs), IsNil)
+	c.Check(plugAttrs, DeepEquals, map[string]any{"path": "/foo"})
+	c.Check(task.Get("slot-attrs", &plugAttrs), testutil.ErrorIs, state.ErrNoState)
+
+	_, err = ifacestate.UpdateConnectionAttrs(s.state, conn, nil, nil)
+	c.Check(err, ErrorMatches, `cannot update attributes of connection consumer:plug producer:slot: no attributes given`)
+	_, err = ifacestate.UpdateConnectionAttrs(s.state, conn, map[string]any{"attr1": "other"}, nil)
+	c.Check(err, ErrorMatches, `cannot change attribute "attr1" of plug "plug" of snap "consumer" as it was statically specified in the snap details`)
+	_, err = ifacestate.UpdateConnectionAttrs(s.state, conn, nil, map[string]any{"attr2": "other"})
+	c.Check(err, ErrorMatches, `cannot change attribute "attr2" of slot "slot" of snap "producer" as it was statically specified in the snap details`)
+}
+
+func (s *interfaceManagerSuite) TestUpdateConnectionAttrsSetsUpSecurity(c *C) {
+	s.MockModel(c, nil)
+
+	s.mockIfaces(&ifacetest.TestInterface{InterfaceName: "test"}, &ifacetest.TestInterface{InterfaceName: "test2"})
+	s.mockSnap(c, consumerYaml)
+	s.mockSnap(c, producerYaml)
+
+	s.state.Lock()
+	s.state.Set("conns", map[string]any{
+		"consumer:plug producer:slot": map[string]any{
+			"interface":    "test",
+			"auto":         true,
+			"plug-static":  map[string]any{"attr1": "value1"},
+			"plug-dynamic": map[string]any{"other": "value"},
+			"slot-static":  map[string]any{"attr2": "value2"},
+		},
+	})
+	s.state.Unlock()
+
+	conn := s.getConnection(c, "consumer", "plug", "producer", "slot")
+
+	s.state.Lock()
+	ts, err := ifacestate.UpdateConnectionAttrs(s.state, conn, map[string]any{"path": "/foo"}, map[string]any{"number": 1})
+	c.Assert(err, IsNil)
+	change := s.state.NewChange("update-connection", "")
+	change.AddAll(ts)
+	s.state.Unlock()
+
+	s.settle(c)
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	c.Assert(change.Err(), IsNil)
+	c.Check(change.Status(), Equals, state.DoneStatus)
+
+	// the profiles of both snaps are regenerated, nothing is removed
+	c.Assert(s.secBackend.SetupCalls, HasLen, 2)
+	c.Assert(s.secBackend.RemoveCalls, HasLen, 0)
+	c.Check(s.secBackend.SetupCalls[0].AppSet.InstanceName(), Equals, "producer")
+	c.Check(s.secBackend.SetupCalls[1].AppSet.InstanceName(), Equals, "consumer")
+
+	var conns map[string]any
+	c.Assert(s.state.Get("conns", &conns), IsNil)
+	c.Check(conns, DeepEquals, map[string]any{
+		"consumer:plug producer:slot": map[string]any{
+			"interface":    "test",
+			"auto":         true,
+			"plug-static":  map[string]any{"attr1": "value1"},
+			"plug-dynamic": map[string]any{"other": "value", "path": "/foo"},
+			"slot-static":  map[string]any{"attr2": "value2"},
+			"slot-dynamic": map[string]any{"number": float64(1)},
+		},
+	})
+
+	conn = s.getConnection(c, "consumer", "plug", "producer", "slot")
+	c.Check(conn.Plug.DynamicAttrs(), DeepEquals, map[string]any{"other": "value", "path": "/foo"})
+	c.Check(conn.Slot.DynamicAttrs(), DeepEquals, map[string]any{"number": int64(1)})
+}
+
+func (s *interfaceManagerSuite) TestUpdateConnectionAttrsUndo(c *C) {
+	s.MockModel(c, nil)
+
+	s.mockIfaces(&ifacetest.TestInterface{InterfaceName: "test"}, &ifacetest.TestInterface{InterfaceName: "test2"})
+	s.mockSnap(c, consumerYaml)
+	s.mockSnap(c, producerYaml)
+
+	connState := map[string]any{
+		"interface":    "test",
+		"plug-static":  map[string]any{"attr1": "value1"},
+		"plug-dynamic": map[string]any{"other": "value"},
+		"slot-static":  map[string]any{"attr2": "value2"},
+	}
+	s.state.Lock()
+	s.state.Set("conns", map[string]any{"consumer:plug producer:slot": connState})
+	s.state.Unlock()
+
+	conn := s.getConnection(c, "consumer", "plug", "producer", "slot")
+
+	s.state.Lock()
+	ts, err := ifacestate.UpdateConnectionAttrs(s.state, conn, map[string]any{"other": "changed"}, nil)
+	c.Assert(err, IsNil)
+	change := s.state.NewChange("update-connection", "")
+	change.AddAll(ts)
+	terr := s.state.NewTask("error-trigger", "provoking undo")
+	terr.WaitAll(ts)
+	change.AddTask(terr)
+	s.state.Unlock()
+
+	s.settle(c)
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	c.Assert(change.Status(), Equals, state.ErrorStatus)
+	c.Check(ts.Tasks()[0].Status(), Equals, state.UndoneStatus)
+
+	var conns map[string]any
+	c.Assert(s.state.Get("conns", &conns), IsNil)
+	c.Check(conns, DeepEquals, map[string]any{"consumer:plug producer:slot": connState})
+
+	conn = s.getConnection(c, "consumer", "plug", "producer", "slot")
+	c.Check(conn.Plug.DynamicAttrs(), DeepEquals, map[string]any{"other": "value"})
+
+	// profiles were set up again with the original attributes
+	c.Check(s.secBackend.SetupCalls, HasLen, 4)
+}
+
 func (s *interfaceManagerSuite) TestDisconnectTracksConnectionsInState(c *C) {
 	s.mockIfaces(&ifacetest.TestInterface{InterfaceName: "test"}, &ifacetest.TestInterface{InterfaceName: "test2"})
 	s.mockSnap(c, consumerYaml)
@@ -7793,6 +8136,96 @@ func (s *interfaceManagerSuite) TestDisconnectInterfaces(c *C) {
 	}
 }
 
+func (s *interfaceManagerSuite) TestDisconnectInterfacesOrdered(c *C) {
+	// connections of "test2" are removed before the ones of "test"
+	s.mockIfaces(
+		&ifacetest.TestInterface{InterfaceName: "test"},
+		&ifacetest.TestDisconnectOrderInterface{
+			TestInterface:             ifacetest.TestInterface{InterfaceName: "test2"},
+			DisconnectAfterInterfaces: []string{"test"},
+		},
+	)
+	_ = s.manager(c)
+
+	const producerWithOtherSlotYaml = `
+name: producer
+version: 1
+slots:
+ slot:
+  interface: test
+ otherslot:
+  interface: test2
+`
+	consumerInfo := s.mockSnap(c, consumerYaml)
+	producerInfo := s.mockSnap(c, producerWithOtherSlotYaml)
+
+	consumerAppSet, err := interfaces.NewSnapAppSet(consumerInfo, nil)
+	c.Assert(err, IsNil)
+	producerAppSet, err := interfaces.NewSnapAppSet(producerInfo, nil)
+	c.Assert(err, IsNil)
+
+	s.state.Lock()
+
+	repo := s.manager(c).Repository()
+	c.Assert(repo.AddAppSet(consumerAppSet), IsNil)
+	c.Assert(repo.AddAppSet(producerAppSet), IsNil)
+	for _, connRef := range []*interfaces.ConnRef{
+		{PlugRef: interfaces.PlugRef{Snap: "consumer", Name: "plug"}, SlotRef: interfaces.SlotRef{Snap: "producer", Name: "slot"}},
+		{PlugRef: interfaces.PlugRef{Snap: "consumer", Name: "otherplug"}, SlotRef: interfaces.SlotRef{Snap: "producer", Name: "otherslot"}},
+	} {
+		_, err := repo.Connect(connRef, nil, nil, nil, nil, nil)
+		c.Assert(err, IsNil)
+	}
+
+	chg := s.state.NewChange("remove", "")
+	t := s.state.NewTask("auto-disconnect", "")
+	t.Set("snap-setup", &snapstate.SnapSetup{SideInfo: &snap.SideInfo{RealName: "consumer"}})
+	chg.AddTask(t)
+
+	s.state.Unlock()
+
+	s.se.Ensure()
+	s.se.Wait()
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	disconnects := make(map[string]*state.Task)
+	for _, ht := range t.HaltTasks() {
+		if ht.Kind() == "disconnect" {
+			var plugRef interfaces.PlugRef
+			c.Assert(ht.Get("plug", &plugRef), IsNil)
+			disconnects[plugRef.Name] = ht
+		}
+	}
+	c.Assert(disconnects, HasLen, 2)
+
+	// all the tasks disconnecting "test" wait for the ones of "test2", but
+	// not the other way around
+	var waited int
+	for _, ht := range t.HaltTasks() {
+		var plugRef interfaces.PlugRef
+		if ht.Kind() == "disconnect" {
+			c.Assert(ht.Get("plug", &plugRef), IsNil)
+		} else {
+			var hsup hookstate.HookSetup
+			c.Assert(ht.Get("hook-setup", &hsup), IsNil)
+			if strings.HasSuffix(hsup.Hook, "-otherplug") || strings.HasSuffix(hsup.Hook, "-otherslot") {
+				plugRef.Name = "otherplug"
+			} else {
+				plugRef.Name = "plug"
+			}
+		}
+		if plugRef.Name == "plug" {
+			c.Check(ht.WaitTasks(), testutil.Contains, disconnects["otherplug"], Commentf("%s", ht.Summary()))
+			waited++
+		} else {
+			c.Check(ht.WaitTasks(), Not(testutil.Contains), disconnects["plug"], Commentf("%s", ht.Summary()))
+		}
+	}
+	c.Check(waited > 0, Equals, true)
+}
+
 func (s *interfaceManagerSuite) testDisconnectInterfacesRetry(c *C, conflictingKind string) {
 	s.mockIfaces(&ifacetest.TestInterface{InterfaceName: "test"})
 	_ = s.manager(c)
@@ -8298,6 +8731,161 @@ volumes:
 	})
 }
 
+func (s *interfaceManagerSuite) setupAutoConnectGadgetAttributes(c *C, gadgetConns string) {
+	r := assertstest.MockBuiltinBaseDeclaration([]byte(`
+type: base-declaration
+authority-id: canonical
+series: 16
+slots:
+  test:
+    deny-auto-connection: true
+`))
+	s.AddCleanup(r)
+
+	s.mockIfaces(&ifacetest.TestInterface{
+		InterfaceName: "test",
+		BeforePreparePlugCallback: func(plug *snap.PlugInfo) error {
+			if rate, ok := plug.Attrs["rate"]; ok && rate != int64(9600) {
+				return fmt.Errorf("unsupported rate %v", rate)
+			}
+			return nil
+		},
+	})
+	s.MockModel(c, nil)
+
+	s.MockSnapDecl(c, "foo", "publisher1", nil)
+	s.mockSnap(c, `name: foo
+version: 1.0
+plugs:
+  serial:
+    interface: test
+    mode: raw
+`)
+	s.MockSnapDecl(c, "bar", "publisher2", nil)
+	s.mockSnap(c, `name: bar
+version: 1.0
+slots:
+  serial:
+    interface: test
+`)
+
+	s.manager(c)
+
+	gadgetInfo := s.mockSnap(c, `name: gadget
+type: gadget
+`)
+
+	gadgetYaml := []byte("connections:\n" + gadgetConns + `
+volumes:
+    volume-id:
+        bootloader: grub
+`)
+	err := os.WriteFile(filepath.Join(gadgetInfo.MountDir(), "meta", "gadget.yaml"), gadgetYaml, 0644)
+	c.Assert(err, IsNil)
+}
+
+func (s *interfaceManagerSuite) TestAutoConnectGadgetAttributes(c *C) {
+	r1 := release.MockOnClassic(false)
+	defer r1()
+
+	s.setupAutoConnectGadgetAttributes(c, `
+   - plug: fooididididididididididididididi:serial
+     slot: barididididididididididididididi:serial
+     plug-attributes:
+       rate: 9600
+     slot-attributes:
+       path: /dev/ttyS1
+`)
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	chg := s.state.NewChange("setting-up", "...")
+	t := s.state.NewTask("auto-connect", "gadget connections")
+	t.Set("snap-setup", &snapstate.SnapSetup{
+		SideInfo: &snap.SideInfo{
+			RealName: "foo", Revision: snap.R(1)},
+	})
+	chg.AddTask(t)
+
+	s.state.Unlock()
+	s.settle(c)
+	s.state.Lock()
+
+	c.Assert(chg.Err(), IsNil)
+	tasks := chg.Tasks()
+	c.Assert(tasks, HasLen, 3)
+	c.Assert(tasks[1].Kind(), Equals, "connect")
+
+	// the attributes are persisted with the connection
+	var conns map[string]any
+	err := s.state.Get("conns", &conns)
+	c.Assert(err, IsNil)
+	c.Check(conns, DeepEquals, map[string]any{
+		"foo:serial bar:serial": map[string]any{
+			"interface":    "test",
+			"auto":         true,
+			"by-gadget":    true,
+			"plug-static":  map[string]any{"mode": "raw"},
+			"plug-dynamic": map[string]any{"rate": float64(9600)},
+			"slot-dynamic": map[string]any{"path": "/dev/ttyS1"},
+		},
+	})
+
+	repo := s.manager(c).Repository()
+	conn, err := repo.Connection(&interfaces.ConnRef{
+		PlugRef: interfaces.PlugRef{Snap: "foo", Name: "serial"},
+		SlotRef: interfaces.SlotRef{Snap: "bar", Name: "serial"},
+	})
+	c.Assert(err, IsNil)
+	var rate int64
+	c.Check(conn.Plug.Attr("rate", &rate), IsNil)
+	c.Check(rate, Equals, int64(9600))
+	var path string
+	c.Check(conn.Slot.Attr("path", &path), IsNil)
+	c.Check(path, Equals, "/dev/ttyS1")
+}
+
+func (s *interfaceManagerSuite) TestAutoConnectGadgetAttributesInvalid(c *C) {
+	r1 := release.MockOnClassic(false)
+	defer r1()
+
+	s.setupAutoConnectGadgetAttributes(c, `
+   - plug: fooididididididididididididididi:serial
+     slot: barididididididididididididididi:serial
+     plug-attributes:
+       rate: 115200
+   - plug: fooididididididididididididididi:serial
+     slot: barididididididididididididididi:serial
+     plug-attributes:
+       mode: cooked
+`)
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	chg := s.state.NewChange("setting-up", "...")
+	t := s.state.NewTask("auto-connect", "gadget connections")
+	t.Set("snap-setup", &snapstate.SnapSetup{
+		SideInfo: &snap.SideInfo{
+			RealName: "foo", Revision: snap.R(1)},
+	})
+	chg.AddTask(t)
+
+	s.state.Unlock()
+	s.se.Ensure()
+	s.se.Wait()
+	s.state.Lock()
+
+	c.Assert(chg.Err(), IsNil)
+	c.Assert(chg.Tasks(), HasLen, 1)
+
+	logs := t.Log()
+	c.Assert(logs, HasLen, 2)
+	c.Check(logs[0], Matches, `.* ignoring connection of plug fooididididididididididididididi:serial to slot barididididididididididididididi:serial: unsupported rate 115200`)
+	c.Check(logs[1], Matches, `.* ignoring connection of plug fooididididididididididididididi:serial to slot barididididididididididididididi:serial: cannot change attribute "mode" as it was statically specified in the snap details`)
+}
+
 func (s *interfaceManagerSuite) testChangeConflict(c *C, kind string) {
 	s.state.Lock()
 	defer s.state.Unlock()
@@ -11396,6 +11984,9 @@ func (s *interfaceManagerSuite) TestDoRegenerateSecurityProfilesHappy(c *C) {
 	})
 	s.state.Unlock()
 
+	// the system changed since the profiles were generated at startup
+	s.AddCleanup(interfaces.MockSystemKey(`{"build-id": "other"}`))
+
 	// Setup profiles for refreshed snap v2
 	s.state.Lock()
 	change := s.state.NewChange("regenerate-security-profiles", "")
@@ -11487,6 +12078,9 @@ func (s *interfaceManagerSuite) testDoRegenerateSecurityProfilesError(c *C, tc r
 	})
 	s.state.Unlock()
 
+	// the system changed since the profiles were generated at startup
+	s.AddCleanup(interfaces.MockSystemKey(`{"build-id": "other"}`))
+
 	// Setup profiles for refreshed snap v2
 	s.state.Lock()
 	change := s.state.NewChange("regenerate-security-profiles", "")
@@ -11632,7 +12226,7 @@ func (s *interfaceManagerSuite) TestSystemKeyMismatchCompat(c *C) {
 }
 
 func (s *interfaceManagerSuite) TestEnsureLoopLogging(c *C) {
-	testutil.CheckEnsureLoopLogging("ifacemgr.go", c, false)
+	testutil.CheckEnsureLoopLogging("ifacemgr.go", c, true)
 }
 
 func (s *interfaceManagerSuite) setCompatEnabledFeature(c *C) {
@@ -11982,3 +12576,233 @@ func (s *interfaceManagerSuite) TestAutoConnectSupportsConfigurableAutoConnectSe
 	})
 	c.Assert(logs, HasLen, 0)
 }
+
+func (s *interfaceManagerSuite) TestSnapPolicy(c *C) {
+	s.extraBackends = []interfaces.SecurityBackend{&dbus.Backend{}}
+	s.mockIfaces(&ifacetest.TestInterface{
+		InterfaceName: "test",
+		DBusPermanentSlotCallback: func(spec *dbus.Specification, slot *snap.SlotInfo) error {
+			spec.AddSnippet("<policy>permanent</policy>")
+			return nil
+		},
+		DBusConnectedSlotCallback: func(spec *dbus.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddSnippet("<policy>connected</policy>")
+			return nil
+		},
+	}, &ifacetest.TestInterface{InterfaceName: "test2"})
+	mgr := s.manager(c)
+	repo := mgr.Repository()
+
+	siP := s.mockAppSet(c, producerYaml+`apps:
+  app:
+`)
+	siC := s.mockAppSet(c, consumerYaml)
+	c.Assert(repo.AddAppSet(siC), IsNil)
+	c.Assert(repo.AddAppSet(siP), IsNil)
+	connRef := &interfaces.ConnRef{
+		PlugRef: interfaces.PlugRef{Snap: "consumer", Name: "plug"},
+		SlotRef: interfaces.SlotRef{Snap: "producer", Name: "slot"},
+	}
+	_, err := repo.Connect(connRef, nil, nil, nil, nil, nil)
+	c.Assert(err, IsNil)
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	// the test backend cannot describe its policy and is skipped
+	policies, err := mgr.SnapPolicy("producer", nil, false)
+	c.Assert(err, IsNil)
+	c.Assert(policies, HasLen, 1)
+	c.Check(policies[0], DeepEquals, &ifacestate.SnapPolicy{
+		Backend: "dbus",
+		Parts: []*ifacestate.PolicyPart{{
+			Interface: "test",
+			Slot:      &interfaces.SlotRef{Snap: "producer", Name: "slot"},
+			Snippets: map[string][]string{
+				"snap.producer.app":                       {"<policy>permanent</policy>"},
+				"snap.producer.hook.connect-slot-slot":    {"<policy>permanent</policy>"},
+				"snap.producer.hook.disconnect-slot-slot": {"<policy>permanent</policy>"},
+				"snap.producer.hook.prepare-slot-slot":    {"<policy>permanent</policy>"},
+				"snap.producer.hook.unprepare-slot-slot":  {"<policy>permanent</policy>"},
+			},
+		}, {
+			Interface: "test",
+			Plug:      &interfaces.PlugRef{Snap: "consumer", Name: "plug"},
+			Slot:      &interfaces.SlotRef{Snap: "producer", Name: "slot"},
+			Snippets: map[string][]string{
+				"snap.producer.app":                       {"<policy>connected</policy>"},
+				"snap.producer.hook.connect-slot-slot":    {"<policy>connected</policy>"},
+				"snap.producer.hook.disconnect-slot-slot": {"<policy>connected</policy>"},
+				"snap.producer.hook.prepare-slot-slot":    {"<policy>connected</policy>"},
+				"snap.producer.hook.unprepare-slot-slot":  {"<policy>connected</policy>"},
+			},
+		}},
+	})
+
+	// the expected profiles are compared with the ones on disk
+	policies, err = mgr.SnapPolicy("producer", []string{"dbus"}, true)
+	c.Assert(err, IsNil)
+	c.Assert(policies, HasLen, 1)
+	profiles := policies[0].Profiles
+	c.Assert(profiles, HasLen, 5)
+	appProfile := profiles[0]
+	c.Check(appProfile.Path, Equals, filepath.Join(dirs.SnapDBusSystemPolicyDir, "snap.producer.app.conf"))
+	c.Check(appProfile.Status, Equals, ifacestate.PolicyProfileMissing)
+	c.Check(appProfile.Content, testutil.Contains, "<policy>permanent</policy>\n<policy>connected</policy>")
+
+	c.Assert(os.MkdirAll(dirs.SnapDBusSystemPolicyDir, 0755), IsNil)
+	c.Assert(os.WriteFile(appProfile.Path, []byte(appProfile.Content), 0644), IsNil)
+	c.Assert(os.WriteFile(profiles[1].Path, []byte("stale"), 0644), IsNil)
+	policies, err = mgr.SnapPolicy("producer", []string{"dbus"}, true)
+	c.Assert(err, IsNil)
+	profiles = policies[0].Profiles
+	c.Check(profiles[0].Status, Equals, ifacestate.PolicyProfileSame)
+	c.Check(profiles[0].OnDisk, Equals, "")
+	c.Check(profiles[1].Status, Equals, ifacestate.PolicyProfileDiffers)
+	c.Check(profiles[1].OnDisk, Equals, "stale")
+	c.Check(profiles[2].Status, Equals, ifacestate.PolicyProfileMissing)
+
+	_, err = mgr.SnapPolicy("producer", []string{"foo"}, false)
+	c.Check(err, ErrorMatches, `unknown security backend "foo"`)
+	_, err = mgr.SnapPolicy("producer", []string{string(s.secBackend.Name())}, false)
+	c.Check(err, ErrorMatches, `cannot describe the policy of security backend ""`)
+	_, err = mgr.SnapPolicy("unknown", nil, false)
+	c.Check(err, testutil.ErrorIs, state.ErrNoState)
+}
+
+func (s *interfaceManagerSuite) TestConnectionPolicy(c *C) {
+	s.extraBackends = []interfaces.SecurityBackend{&dbus.Backend{}}
+	s.mockIfaces(&ifacetest.TestInterface{
+		InterfaceName: "test",
+		DBusConnectedPlugCallback: func(spec *dbus.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddSnippet("<policy>plug</policy>")
+			return nil
+		},
+		DBusConnectedSlotCallback: func(spec *dbus.Specification, plug *interfaces.ConnectedPlug, slot *interfaces.ConnectedSlot) error {
+			spec.AddSnippet("<policy>slot</policy>")
+			return nil
+		},
+	}, &ifacetest.TestInterface{InterfaceName: "test2"})
+	mgr := s.manager(c)
+	repo := mgr.Repository()
+
+	siP := s.mockAppSet(c, producerYaml+`apps:
+  app:
+`)
+	siC := s.mockAppSet(c, consumerYaml)
+	c.Assert(repo.AddAppSet(siC), IsNil)
+	c.Assert(repo.AddAppSet(siP), IsNil)
+	connRef := &interfaces.ConnRef{
+		PlugRef: interfaces.PlugRef{Snap: "consumer", Name: "plug"},
+		SlotRef: interfaces.SlotRef{Snap: "producer", Name: "slot"},
+	}
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	// the test backend cannot describe its policy and is skipped
+	connPolicy, err := mgr.ConnectionPolicy(connRef)
+	c.Assert(err, IsNil)
+	c.Check(connPolicy, DeepEquals, &ifacestate.ConnectionPolicy{
+		Interface: "test",
+		Plug:      connRef.PlugRef,
+		Slot:      connRef.SlotRef,
+		Policies: []*ifacestate.SnapPolicy{{
+			Backend: "dbus",
+			Parts: []*ifacestate.PolicyPart{{
+				Interface: "test",
+				Plug:      &connRef.PlugRef,
+				Slot:      &connRef.SlotRef,
+				Snippets: map[string][]string{
+					"snap.consumer.hook.connect-plug-plug":         {"<policy>plug</policy>"},
+					"snap.consumer.hook.disconnect-plug-plug":      {"<policy>plug</policy>"},
+					"snap.consumer.hook.prepare-plug-plug":         {"<policy>plug</policy>"},
+					"snap.consumer.hook.unprepare-plug-plug":       {"<policy>plug</policy>"},
+					"snap.consumer.hook.connect-plug-otherplug":    {"<policy>plug</policy>"},
+					"snap.consumer.hook.disconnect-plug-otherplug": {"<policy>plug</policy>"},
+					"snap.consumer.hook.prepare-plug-otherplug":    {"<policy>plug</policy>"},
+					"snap.consumer.hook.unprepare-plug-otherplug":  {"<policy>plug</policy>"},
+					"snap.producer.app":                            {"<policy>slot</policy>"},
+					"snap.producer.hook.connect-slot-slot":         {"<policy>slot</policy>"},
+					"snap.producer.hook.disconnect-slot-slot":      {"<policy>slot</policy>"},
+					"snap.producer.hook.prepare-slot-slot":         {"<policy>slot</policy>"},
+					"snap.producer.hook.unprepare-slot-slot":       {"<policy>slot</policy>"},
+				},
+			}},
+		}},
+	})
+	// nothing was connected
+	_, err = repo.Connection(connRef)
+	c.Check(err, FitsTypeOf, &interfaces.NotConnectedError{})
+
+	_, err = repo.Connect(connRef, nil, nil, nil, nil, nil)
+	c.Assert(err, IsNil)
+	connPolicy, err = mgr.ConnectionPolicy(connRef)
+	c.Assert(err, IsNil)
+	c.Check(connPolicy.Connected, Equals, true)
+	c.Check(connPolicy.Policies, HasLen, 1)
+
+	connRef.SlotRef.Name = "missing"
+	_, err = mgr.ConnectionPolicy(connRef)
+	c.Check(err, ErrorMatches, `cannot connect slot "missing" from snap "producer": no such slot`)
+}
+
+func (s *interfaceManagerSuite) TestConnectionPolicyNotAllowed(c *C) {
+	restore := assertstest.MockBuiltinBaseDeclaration([]byte(`
+type: base-declaration
+authority-id: canonical
+series: 16
+slots:
+  test:
+    allow-connection:
+      plug-publisher-id:
+        - $SLOT_PUBLISHER_ID
+`))
+	defer restore()
+	s.MockModel(c, nil)
+	s.mockIfaces(&ifacetest.TestInterface{InterfaceName: "test"}, &ifacetest.TestInterface{InterfaceName: "test2"})
+	s.MockSnapDecl(c, "consumer", "consumer-publisher", nil)
+	s.mockSnap(c, consumerYaml)
+	s.MockSnapDecl(c, "producer", "producer-publisher", nil)
+	s.mockSnap(c, producerYaml)
+	mgr := s.manager(c)
+
+	connRef := &interfaces.ConnRef{
+		PlugRef: interfaces.PlugRef{Snap: "consumer", Name: "plug"},
+		SlotRef: interfaces.SlotRef{Snap: "producer", Name: "slot"},
+	}
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	// refused like a manual connection would be
+	_, err := mgr.ConnectionPolicy(connRef)
+	c.Check(err, ErrorMatches, `connection not allowed by slot rule of interface "test"`)
+}
+
+func (s *interfaceManagerSuite) TestConnectionPolicySeedOnlyAfterSeeding(c *C) {
+	s.MockModel(c, nil)
+	s.mockIfaces(&ifacetest.TestInterface{
+		InterfaceName:       "test",
+		InterfaceStaticInfo: interfaces.StaticInfo{SeedOnly: true},
+	}, &ifacetest.TestInterface{InterfaceName: "test2"})
+	s.mockSnap(c, consumerYaml)
+	s.mockSnap(c, producerYaml)
+	mgr := s.manager(c)
+
+	connRef := &interfaces.ConnRef{
+		PlugRef: interfaces.PlugRef{Snap: "consumer", Name: "plug"},
+		SlotRef: interfaces.SlotRef{Snap: "producer", Name: "slot"},
+	}
+
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	s.state.Set("seeded", false)
+	_, err := mgr.ConnectionPolicy(connRef)
+	c.Check(err, IsNil)
+
+	s.state.Set("seeded", true)
+	_, err = mgr.ConnectionPolicy(connRef)
+	c.Check(err, ErrorMatches, `cannot connect "test" interface: it can only be connected while the system is being seeded`)
+}
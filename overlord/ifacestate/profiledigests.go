@@ -0,0 +1,216 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package ifacestate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/interfaces/udev"
+	"github.com/snapcore/snapd/osutil"
+	"github.com/snapcore/snapd/overlord/state"
+	"github.com/snapcore/snapd/snap"
+)
+
+// profileDigests maps the instance names of snaps to the digests of the
+// inputs their security profiles were last generated from, by security
+// backend.
+type profileDigests map[string]map[interfaces.SecuritySystem]string
+
+func getProfileDigests(st *state.State) (profileDigests, error) {
+	var digests profileDigests
+	if err := st.Get("security-profile-digests", &digests); err != nil && !errors.Is(err, state.ErrNoState) {
+		return nil, err
+	}
+	if digests == nil {
+		digests = make(profileDigests)
+	}
+	return digests, nil
+}
+
+func setProfileDigests(st *state.State, digests profileDigests) {
+	if len(digests) == 0 {
+		st.Set("security-profile-digests", nil)
+		return
+	}
+	st.Set("security-profile-digests", digests)
+}
+
+// forgetProfileDigests drops the recorded profile digests of the given snaps,
+// so that their profiles are generated again the next time all the security
+// profiles are regenerated. It is used whenever the profiles of a snap are set
+// up or removed outside of regenerateAllSecurityProfiles.
+func forgetProfileDigests(st *state.State, instanceNames ...string) {
+	digests, err := getProfileDigests(st)
+	if err != nil {
+		// cannot trust any of them
+		setProfileDigests(st, nil)
+		return
+	}
+	if len(digests) == 0 {
+		return
+	}
+	for _, instanceName := range instanceNames {
+		delete(digests, instanceName)
+	}
+	setProfileDigests(st, digests)
+}
+
+type profileInputsConnection struct {
+	ID               string         `json:"id"`
+	PlugRevision     snap.Revision  `json:"plug-revision"`
+	SlotRevision     snap.Revision  `json:"slot-revision"`
+	PlugStaticAttrs  map[string]any `json:"plug-static,omitempty"`
+	PlugDynamicAttrs map[string]any `json:"plug-dynamic,omitempty"`
+	SlotStaticAttrs  map[string]any `json:"slot-static,omitempty"`
+	SlotDynamicAttrs map[string]any `json:"slot-dynamic,omitempty"`
+	// PresentDevices are the device patterns of the plug interface which
+	// match device nodes present on the system, they are only part of the
+	// inputs of the udev profiles, see interfaces.DevicePresenceUser.
+	PresentDevices []string `json:"present-devices,omitempty"`
+
+	presentDevices []string
+}
+
+// profileInputs holds everything the security profiles of a snap are
+// generated from, other than snapd itself and the host system which are
+// captured by the system key of each backend and by the presence of the
+// devices some interfaces only grant access to when present.
+type profileInputs struct {
+	SystemKey             string                    `json:"system-key"`
+	Revision              snap.Revision             `json:"revision"`
+	Components            []string                  `json:"components,omitempty"`
+	DevMode               bool                      `json:"devmode,omitempty"`
+	JailMode              bool                      `json:"jailmode,omitempty"`
+	Classic               bool                      `json:"classic,omitempty"`
+	ExtraLayouts          []string                  `json:"extra-layouts,omitempty"`
+	AppArmorPrompting     bool                      `json:"apparmor-prompting,omitempty"`
+	KernelSnap            string                    `json:"kernel-snap,omitempty"`
+	AuditedPlugs          []string                  `json:"audited-plugs,omitempty"`
+	LogPrivilegedSyscalls bool                      `json:"log-privileged-syscalls,omitempty"`
+	Connections           []profileInputsConnection `json:"connections,omitempty"`
+}
+
+// profileDigestsForSnap computes, for each of the security backends with a
+// key in backendKeys, a digest of the inputs of the security profiles of the
+// given snap.
+func profileDigestsForSnap(repo *interfaces.Repository, appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, backendKeys map[interfaces.SecuritySystem]string) (map[interfaces.SecuritySystem]string, error) {
+	info := appSet.Info()
+	inputs := profileInputs{
+		Revision:              info.Revision,
+		DevMode:               opts.DevMode,
+		JailMode:              opts.JailMode,
+		Classic:               opts.Classic,
+		AppArmorPrompting:     opts.AppArmorPrompting,
+		KernelSnap:            opts.KernelSnap,
+		AuditedPlugs:          opts.AuditedPlugs,
+		LogPrivilegedSyscalls: opts.LogPrivilegedSyscalls,
+	}
+	for _, comp := range appSet.Components() {
+		inputs.Components = append(inputs.Components, comp.FullName()+"="+comp.Revision.String())
+	}
+	sort.Strings(inputs.Components)
+	for i := range opts.ExtraLayouts {
+		inputs.ExtraLayouts = append(inputs.ExtraLayouts, opts.ExtraLayouts[i].String())
+	}
+
+	connRefs, err := repo.Connections(appSet.InstanceName())
+	if err != nil {
+		return nil, err
+	}
+	for _, connRef := range connRefs {
+		conn, err := repo.Connection(connRef)
+		if err != nil {
+			return nil, err
+		}
+		inputs.Connections = append(inputs.Connections, profileInputsConnection{
+			ID:               connRef.ID(),
+			PlugRevision:     conn.Plug.Snap().Revision,
+			SlotRevision:     conn.Slot.Snap().Revision,
+			PlugStaticAttrs:  conn.Plug.StaticAttrs(),
+			PlugDynamicAttrs: conn.Plug.DynamicAttrs(),
+			SlotStaticAttrs:  conn.Slot.StaticAttrs(),
+			SlotDynamicAttrs: conn.Slot.DynamicAttrs(),
+			presentDevices:   plugPresentDevices(repo, conn),
+		})
+	}
+	sort.Slice(inputs.Connections, func(i, j int) bool {
+		return inputs.Connections[i].ID < inputs.Connections[j].ID
+	})
+
+	digests := make(map[interfaces.SecuritySystem]string, len(backendKeys))
+	for backend, key := range backendKeys {
+		inputs.SystemKey = key
+		for i := range inputs.Connections {
+			inputs.Connections[i].PresentDevices = nil
+			if backend == interfaces.SecurityUDev {
+				inputs.Connections[i].PresentDevices = inputs.Connections[i].presentDevices
+			}
+		}
+		data, err := json.Marshal(&inputs)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		digests[backend] = hex.EncodeToString(sum[:])
+	}
+	return digests, nil
+}
+
+// plugPresentDevices returns the device patterns of the plug interface of the
+// given connection which match device nodes present on the system.
+func plugPresentDevices(repo *interfaces.Repository, conn *interfaces.Connection) []string {
+	presenceUser, ok := repo.Interface(conn.Plug.Interface()).(interfaces.DevicePresenceUser)
+	if !ok {
+		return nil
+	}
+	var present []string
+	for _, pattern := range presenceUser.PlugDevicePatterns() {
+		if udev.DevicePresent(pattern) {
+			present = append(present, pattern)
+		}
+	}
+	return present
+}
+
+// profilesPresent returns whether all the security files that the given
+// backend writes for the snap exist, such that the profiles of a snap are
+// regenerated when some were removed behind the back of snapd. Backends which
+// cannot tell their security files are assumed to have them.
+func profilesPresent(repo *interfaces.Repository, backend interfaces.SecurityBackend, appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions) bool {
+	profilesBackend, ok := backend.(interfaces.SecurityBackendProfiles)
+	if !ok {
+		return true
+	}
+	profiles, err := profilesBackend.Profiles(appSet, opts, repo)
+	if err != nil {
+		return false
+	}
+	for path := range profiles {
+		if !osutil.FileExists(path) {
+			return false
+		}
+	}
+	return true
+}
@@ -41,4 +41,7 @@ type ConnState struct {
 	// slots.
 	HotplugGone bool            `json:"hotplug-gone,omitempty" yaml:"hotplug-gone,omitempty"`
 	HotplugKey  snap.HotplugKey `json:"hotplug-key,omitempty" yaml:"hotplug-key,omitempty"`
+	// Audit indicates a connection whose plug side rules log the accesses
+	// and syscalls they allow.
+	Audit bool `json:"audit,omitempty" yaml:"audit,omitempty"`
 }
@@ -0,0 +1,338 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+
+/*
+ * Copyright (C) 2026 Canonical Ltd
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License version 3 as
+ * published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ *
+ */
+
+package ifacestate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+
+	"github.com/snapcore/snapd/interfaces"
+	"github.com/snapcore/snapd/osutil"
+	"github.com/snapcore/snapd/overlord/snapstate"
+)
+
+// PolicyPart is the security policy contributed to a snap by a single plug
+// or slot, or by a single connection when both Plug and Slot are set.
+type PolicyPart struct {
+	Interface string              `json:"interface"`
+	Plug      *interfaces.PlugRef `json:"plug,omitempty"`
+	Slot      *interfaces.SlotRef `json:"slot,omitempty"`
+	// Snippets are keyed by the security tag of the app or hook they apply
+	// to. Snippets of backends which are not specific to an app or hook
+	// are keyed by the instance name of the snap.
+	Snippets map[string][]string `json:"snippets"`
+}
+
+// Possible states of a PolicyProfile.
+const (
+	PolicyProfileSame    = "same"
+	PolicyProfileDiffers = "differs"
+	PolicyProfileMissing = "missing"
+)
+
+// PolicyProfile is a security file of a snap as expected by a backend,
+// compared with the one present on disk.
+type PolicyProfile struct {
+	Path    string `json:"path"`
+	Status  string `json:"status"`
+	Content string `json:"content"`
+	OnDisk  string `json:"on-disk,omitempty"`
+}
+
+// SnapPolicy is the security policy which a backend generates for a snap.
+type SnapPolicy struct {
+	Backend  interfaces.SecuritySystem `json:"backend"`
+	Parts    []*PolicyPart             `json:"parts"`
+	Profiles []*PolicyProfile          `json:"profiles,omitempty"`
+}
+
+// policySnippets returns the snippets held by a specification, keyed by
+// security tag, and whether the specification could be described at all.
+func policySnippets(instanceName string, spec interfaces.Specification) (map[string][]string, bool) {
+	switch spec := spec.(type) {
+	case interface{ Snippets() map[string][]string }:
+		return spec.Snippets(), true
+	case interface{ Snippets() []string }:
+		snippets := spec.Snippets()
+		if len(snippets) == 0 {
+			return nil, true
+		}
+		return map[string][]string{instanceName: snippets}, true
+	case interface {
+		MountEntries() []osutil.MountEntry
+		UserMountEntries() []osutil.MountEntry
+	}:
+		var entries []string
+		for _, entry := range append(spec.MountEntries(), spec.UserMountEntries()...) {
+			entries = append(entries, entry.String())
+		}
+		if len(entries) == 0 {
+			return nil, true
+		}
+		return map[string][]string{instanceName: entries}, true
+	}
+	return nil, false
+}
+
+// SnapPolicy returns the security policy which the given security backends,
+// or all the backends able to describe it if none is given, generate for
+// the given snap, broken down by contributing plug, slot and connection.
+// With withProfiles the security files expected by the backends are
+// compared with the ones on disk as well. The state must be locked by the
+// caller.
+func (m *InterfaceManager) SnapPolicy(instanceName string, backendNames []string, withProfiles bool) ([]*SnapPolicy, error) {
+	var snapst snapstate.SnapState
+	if err := snapstate.Get(m.state, instanceName, &snapst); err != nil {
+		return nil, err
+	}
+	snapInfo, err := snapst.CurrentInfo()
+	if err != nil {
+		return nil, err
+	}
+	appSet, err := appSetForSnapRevision(m.state, snapInfo)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := m.buildConfinementOptions(m.state, nil, snapInfo, snapst.Flags)
+	if err != nil {
+		return nil, err
+	}
+
+	backends := m.repo.Backends()
+	if len(backendNames) > 0 {
+		byName := make(map[string]interfaces.SecurityBackend, len(backends))
+		for _, backend := range backends {
+			byName[string(backend.Name())] = backend
+		}
+		backends = backends[:0:0]
+		for _, name := range backendNames {
+			backend, ok := byName[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown security backend %q", name)
+			}
+			backends = append(backends, backend)
+		}
+	}
+
+	var policies []*SnapPolicy
+	for _, backend := range backends {
+		parts, err := m.repo.SnapSpecificationParts(backend.Name(), appSet, opts)
+		if err != nil {
+			return nil, err
+		}
+		policy := &SnapPolicy{Backend: backend.Name(), Parts: []*PolicyPart{}}
+		described := true
+		for _, part := range parts {
+			snippets, ok := policySnippets(instanceName, part.Spec)
+			if !ok {
+				described = false
+				break
+			}
+			if len(snippets) == 0 {
+				continue
+			}
+			policy.Parts = append(policy.Parts, &PolicyPart{
+				Interface: part.Interface,
+				Plug:      part.Plug,
+				Slot:      part.Slot,
+				Snippets:  snippets,
+			})
+		}
+		if !described {
+			if len(backendNames) > 0 {
+				return nil, fmt.Errorf("cannot describe the policy of security backend %q", backend.Name())
+			}
+			continue
+		}
+		if withProfiles {
+			if policy.Profiles, err = policyProfiles(backend, appSet, opts, m.repo); err != nil {
+				return nil, err
+			}
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// ConnectionPolicy is the security policy which connecting a plug to a slot
+// would add to the snaps of the plug and of the slot.
+type ConnectionPolicy struct {
+	Interface string             `json:"interface"`
+	Plug      interfaces.PlugRef `json:"plug"`
+	Slot      interfaces.SlotRef `json:"slot"`
+	// Connected is set when the plug is already connected to the slot, the
+	// policy is then already in effect.
+	Connected bool `json:"connected,omitempty"`
+	// Policies hold a single part with the snippets of both snaps, for
+	// each backend the connection contributes to.
+	Policies []*SnapPolicy `json:"policies"`
+}
+
+// ConnectionPolicy returns the security policy which connecting the given
+// plug and slot would add to their snaps, as generated by the security
+// backends able to describe it, without connecting them. The connection is
+// checked by the interface and, unless established already, against the
+// policy like a manual connection, an error is returned when it would be
+// refused. As no interface hooks are run, it has no dynamic attributes. The
+// state must be locked by the caller.
+func (m *InterfaceManager) ConnectionPolicy(connRef *interfaces.ConnRef) (*ConnectionPolicy, error) {
+	conn, err := m.repo.PreviewConnect(connRef, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	_, err = m.repo.Connection(connRef)
+	connected := err == nil
+	if !connected {
+		if err := m.checkConnectPolicy(conn); err != nil {
+			return nil, err
+		}
+	}
+
+	iface := m.repo.Interface(conn.Plug.Interface())
+	plugOpts, err := m.snapConfinementOptions(conn.Plug.Snap().InstanceName())
+	if err != nil {
+		return nil, err
+	}
+	slotOpts, err := m.snapConfinementOptions(conn.Slot.Snap().InstanceName())
+	if err != nil {
+		return nil, err
+	}
+
+	connPolicy := &ConnectionPolicy{
+		Interface: iface.Name(),
+		Plug:      connRef.PlugRef,
+		Slot:      connRef.SlotRef,
+		Connected: connected,
+		Policies:  []*SnapPolicy{},
+	}
+	for _, backend := range m.repo.Backends() {
+		plugSpec := backend.NewSpecification(conn.Plug.AppSet(), plugOpts)
+		if err := plugSpec.AddConnectedPlug(iface, conn.Plug, conn.Slot); err != nil {
+			return nil, err
+		}
+		slotSpec := backend.NewSpecification(conn.Slot.AppSet(), slotOpts)
+		if err := slotSpec.AddConnectedSlot(iface, conn.Plug, conn.Slot); err != nil {
+			return nil, err
+		}
+		plugSnippets, ok := policySnippets(connRef.PlugRef.Snap, plugSpec)
+		if !ok {
+			continue
+		}
+		slotSnippets, ok := policySnippets(connRef.SlotRef.Snap, slotSpec)
+		if !ok {
+			continue
+		}
+		if len(plugSnippets) == 0 && len(slotSnippets) == 0 {
+			continue
+		}
+		// both snaps are the same when a snap connects to itself
+		snippets := make(map[string][]string, len(plugSnippets)+len(slotSnippets))
+		for key, values := range slotSnippets {
+			snippets[key] = append(snippets[key], values...)
+		}
+		for key, values := range plugSnippets {
+			snippets[key] = append(snippets[key], values...)
+		}
+		plugRef, slotRef := connRef.PlugRef, connRef.SlotRef
+		connPolicy.Policies = append(connPolicy.Policies, &SnapPolicy{
+			Backend: backend.Name(),
+			Parts: []*PolicyPart{{
+				Interface: iface.Name(),
+				Plug:      &plugRef,
+				Slot:      &slotRef,
+				Snippets:  snippets,
+			}},
+		})
+	}
+	return connPolicy, nil
+}
+
+// checkConnectPolicy checks the given connection against the policy in the
+// same way as a manual connection, see doConnect.
+func (m *InterfaceManager) checkConnectPolicy(conn *interfaces.Connection) error {
+	refused, err := seedOnlyAfterSeeding(m.state, m.repo, conn.Plug.Interface())
+	if err != nil {
+		return err
+	}
+	if refused {
+		return fmt.Errorf("cannot connect %q interface: it can only be connected while the system is being seeded", conn.Plug.Interface())
+	}
+	deviceCtx, err := snapstate.DeviceCtx(m.state, nil, nil)
+	if err != nil {
+		return err
+	}
+	policyCheck, err := newConnectChecker(m.state, deviceCtx)
+	if err != nil {
+		return err
+	}
+	_, err = policyCheck.check(conn.Plug, conn.Slot)
+	return err
+}
+
+// snapConfinementOptions returns the confinement options of the current
+// revision of the given snap.
+func (m *InterfaceManager) snapConfinementOptions(instanceName string) (interfaces.ConfinementOptions, error) {
+	var snapst snapstate.SnapState
+	if err := snapstate.Get(m.state, instanceName, &snapst); err != nil {
+		return interfaces.ConfinementOptions{}, err
+	}
+	snapInfo, err := snapst.CurrentInfo()
+	if err != nil {
+		return interfaces.ConfinementOptions{}, err
+	}
+	return m.buildConfinementOptions(m.state, nil, snapInfo, snapst.Flags)
+}
+
+// policyProfiles compares the security files which the given backend expects
+// for a snap with the ones on disk.
+func policyProfiles(backend interfaces.SecurityBackend, appSet *interfaces.SnapAppSet, opts interfaces.ConfinementOptions, repo *interfaces.Repository) ([]*PolicyProfile, error) {
+	profilesBackend, ok := backend.(interfaces.SecurityBackendProfiles)
+	if !ok {
+		return nil, nil
+	}
+	expected, err := profilesBackend.Profiles(appSet, opts, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]*PolicyProfile, 0, len(expected))
+	for path, content := range expected {
+		profile := &PolicyProfile{Path: path, Content: string(content)}
+		onDisk, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			profile.Status = PolicyProfileMissing
+		case err != nil:
+			return nil, err
+		case bytes.Equal(onDisk, content):
+			profile.Status = PolicyProfileSame
+		default:
+			profile.Status = PolicyProfileDiffers
+			profile.OnDisk = string(onDisk)
+		}
+		profiles = append(profiles, profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Path < profiles[j].Path })
+	return profiles, nil
+}
@@ -195,7 +195,55 @@ func SystemctlSupportsUserUnits() bool {
 	// Ubuntu 14.04's systemctl does not support the arguments
 	// needed to enable user session units. Further more, it does
 	// not ship with a systemd user instance.
-	return !(ReleaseInfo.ID == "ubuntu" && ReleaseInfo.VersionID == "14.04")
+	return !ReleaseInfo.Is("ubuntu", "14.04")
+}
+
+// compareVersionID compares two os-release VERSION_IDs as dotted version
+// numbers, so that for instance "9" is older than "10". It returns -1, 0 or
+// +1, like strings.Compare which is used for versions VersionCompare does not
+// understand.
+func compareVersionID(a, b string) int {
+	if res, err := strutil.VersionCompare(a, b); err == nil {
+		return res
+	}
+	return strings.Compare(a, b)
+}
+
+// Is returns whether the release is the given version of the distribution
+// with the given ID.
+func (rel OS) Is(id, version string) bool {
+	if rel.ID != id {
+		return false
+	}
+	if rel.VersionID == "" || version == "" {
+		return rel.VersionID == version
+	}
+	return compareVersionID(rel.VersionID, version) == 0
+}
+
+// AtLeast returns whether the release is the given version, or a more recent
+// one, of the distribution with the given ID. Rolling releases, which have no
+// VERSION_ID, are more recent than any version.
+func (rel OS) AtLeast(id, version string) bool {
+	if rel.ID != id {
+		return false
+	}
+	if rel.VersionID == "" || version == "" {
+		return true
+	}
+	return compareVersionID(rel.VersionID, version) >= 0
+}
+
+// Is returns whether the running system is the given version of the
+// distribution with the given ID.
+func Is(id, version string) bool {
+	return ReleaseInfo.Is(id, version)
+}
+
+// AtLeast returns whether the running system is the given version, or a more
+// recent one, of the distribution with the given ID.
+func AtLeast(id, version string) bool {
+	return ReleaseInfo.AtLeast(id, version)
 }
 
 // OnClassic states whether the process is running inside a
@@ -337,3 +337,53 @@ func (s *ReleaseTestSuite) TestSystemctlSupportsUserUnits(c *C) {
 		c.Check(release.SystemctlSupportsUserUnits(), Equals, t.supported)
 	}
 }
+
+func (s *ReleaseTestSuite) TestIs(c *C) {
+	for _, t := range []struct {
+		rel         release.OS
+		id, version string
+		is          bool
+	}{
+		{release.OS{ID: "ubuntu", VersionID: "14.04"}, "ubuntu", "14.04", true},
+		{release.OS{ID: "ubuntu", VersionID: "16.04"}, "ubuntu", "14.04", false},
+		{release.OS{ID: "ubuntu", VersionID: "14.04"}, "ubuntu", "14.4", true},
+		{release.OS{ID: "fedora", VersionID: "14.04"}, "ubuntu", "14.04", false},
+		{release.OS{ID: "debian", VersionID: "10"}, "debian", "10", true},
+		// rolling releases have no version
+		{release.OS{ID: "arch"}, "arch", "", true},
+		{release.OS{ID: "arch"}, "arch", "2024.01", false},
+		{release.OS{ID: "ubuntu", VersionID: "24.04"}, "ubuntu", "", false},
+	} {
+		c.Check(t.rel.Is(t.id, t.version), Equals, t.is, Commentf("%v is %s %s", t.rel, t.id, t.version))
+	}
+}
+
+func (s *ReleaseTestSuite) TestAtLeast(c *C) {
+	for _, t := range []struct {
+		rel         release.OS
+		id, version string
+		atLeast     bool
+	}{
+		{release.OS{ID: "ubuntu", VersionID: "14.04"}, "ubuntu", "16.04", false},
+		{release.OS{ID: "ubuntu", VersionID: "16.04"}, "ubuntu", "16.04", true},
+		{release.OS{ID: "ubuntu", VersionID: "24.04"}, "ubuntu", "16.04", true},
+		{release.OS{ID: "ubuntu", VersionID: "16.10"}, "ubuntu", "16.04", true},
+		// versions are compared as numbers, not lexically
+		{release.OS{ID: "debian", VersionID: "9"}, "debian", "10", false},
+		{release.OS{ID: "debian", VersionID: "10"}, "debian", "9", true},
+		{release.OS{ID: "fedora", VersionID: "42"}, "ubuntu", "16.04", false},
+		// rolling releases are more recent than any version
+		{release.OS{ID: "arch"}, "arch", "2024.01", true},
+		{release.OS{ID: "ubuntu", VersionID: "14.04"}, "ubuntu", "", true},
+	} {
+		c.Check(t.rel.AtLeast(t.id, t.version), Equals, t.atLeast, Commentf("%v at least %s %s", t.rel, t.id, t.version))
+	}
+}
+
+func (s *ReleaseTestSuite) TestIsAndAtLeastRunningSystem(c *C) {
+	defer release.MockReleaseInfo(&release.OS{ID: "ubuntu", VersionID: "16.04"})()
+	c.Check(release.Is("ubuntu", "16.04"), Equals, true)
+	c.Check(release.Is("ubuntu", "14.04"), Equals, false)
+	c.Check(release.AtLeast("ubuntu", "14.04"), Equals, true)
+	c.Check(release.AtLeast("ubuntu", "18.04"), Equals, false)
+}
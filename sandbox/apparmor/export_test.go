@@ -22,6 +22,7 @@ package apparmor
 import (
 	"io"
 	"os"
+	"time"
 
 	"github.com/snapcore/snapd/osutil"
 	"github.com/snapcore/snapd/testutil"
@@ -41,6 +42,20 @@ func MockRuntimeNumCPU(new func() int) (restore func()) {
 	}
 }
 
+func MockLoadProfilesRetry(attempts int, delay time.Duration, sleep func(time.Duration)) (restore func()) {
+	r1 := testutil.Backup(&loadProfilesAttempts)
+	r2 := testutil.Backup(&loadProfilesRetryDelay)
+	r3 := testutil.Backup(&timeSleep)
+	loadProfilesAttempts = attempts
+	loadProfilesRetryDelay = delay
+	timeSleep = sleep
+	return func() {
+		r3()
+		r2()
+		r1()
+	}
+}
+
 func MockMkdirAll(f func(string, os.FileMode) error) func() {
 	r := testutil.Backup(&osMkdirAll)
 	osMkdirAll = f
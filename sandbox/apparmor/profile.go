@@ -23,10 +23,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/snapcore/snapd/dirs"
 	"github.com/snapcore/snapd/logger"
@@ -59,6 +62,15 @@ var (
 
 	osutilIsHomeUsingRemoteFS   = osutil.IsHomeUsingRemoteFS
 	osutilIsRootWritableOverlay = osutil.IsRootWritableOverlay
+
+	timeSleep = time.Sleep
+
+	// loadProfilesAttempts is the number of times apparmor_parser is run
+	// when loading profiles fails for a transient reason.
+	loadProfilesAttempts = 3
+	// loadProfilesRetryDelay is the delay before the first retry, it is
+	// doubled after each failed attempt.
+	loadProfilesRetryDelay = 100 * time.Millisecond
 )
 
 // RemoteFSSnippet contains extra permissions necessary for snaps and snap-confine
@@ -134,22 +146,63 @@ var LoadProfiles = func(fnames []string, cacheDir string, flags AaParserFlags) e
 		args = append(args, "--quiet")
 	}
 
-	cmd, _, err := AppArmorParser()
-	if err != nil {
-		return err
-	}
+	delay := loadProfilesRetryDelay
+	for attempt := 1; ; attempt++ {
+		// a command cannot be run more than once, build a fresh one
+		// for every attempt
+		cmd, _, err := AppArmorParser()
+		if err != nil {
+			return err
+		}
 
-	cmd.Args = append(cmd.Args, args...)
-	cmd.Args = append(cmd.Args, fnames...)
-	output, err := cmd.CombinedOutput()
-	if err != nil || strings.Contains(string(output), "parser error") {
+		cmd.Args = append(cmd.Args, args...)
+		cmd.Args = append(cmd.Args, fnames...)
+		output, err := cmd.CombinedOutput()
+		if err == nil && !strings.Contains(string(output), "parser error") {
+			return nil
+		}
 		if err == nil {
 			// ensure we have an error to report
 			err = fmt.Errorf("exit status 0 with parser error")
 		}
+		if attempt < loadProfilesAttempts && isTransientLoadFailure(err, output) {
+			logger.Noticef("cannot load apparmor profiles (attempt %d/%d), retrying in %v: %v", attempt, loadProfilesAttempts, delay, err)
+			timeSleep(delay)
+			delay *= 2
+			continue
+		}
 		return fmt.Errorf("cannot load apparmor profiles: %s\napparmor_parser output:\n%s", err, string(output))
 	}
-	return nil
+}
+
+// transientLoadFailureMessages are the error messages reported by
+// apparmor_parser when the kernel refused to load a policy for a reason that
+// is likely to go away on its own.
+var transientLoadFailureMessages = []string{
+	"Resource temporarily unavailable",
+	"Device or resource busy",
+	"Cannot allocate memory",
+}
+
+// isTransientLoadFailure returns true if the failure of apparmor_parser is
+// worth retrying, that is, the parser was killed by a signal or the kernel
+// reported a temporary condition. Errors in the profiles themselves are never
+// retried.
+func isTransientLoadFailure(err error, output []byte) bool {
+	if strings.Contains(string(output), "parser error") {
+		return false
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			return true
+		}
+	}
+	for _, msg := range transientLoadFailureMessages {
+		if strings.Contains(string(output), msg) {
+			return true
+		}
+	}
+	return false
 }
 
 // Remove any of the AppArmor profiles in names from the AppArmor cache in
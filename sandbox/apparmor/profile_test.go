@@ -26,6 +26,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	. "gopkg.in/check.v1"
 
@@ -125,6 +126,70 @@ parser error
 	})
 }
 
+func (s *appArmorSuite) TestLoadProfilesRetriesTransientErrors(c *C) {
+	dirs.SetRootDir(c.MkDir())
+	defer dirs.SetRootDir("")
+	var delays []time.Duration
+	restore := apparmor.MockLoadProfilesRetry(3, time.Second, func(d time.Duration) {
+		delays = append(delays, d)
+	})
+	defer restore()
+	stamp := filepath.Join(c.MkDir(), "stamp")
+	// fail the first time only
+	cmd := testutil.MockCommand(c, "apparmor_parser", fmt.Sprintf(`
+if [ ! -e %[1]s ]; then
+	touch %[1]s
+	echo "Cache read/write disabled: Device or resource busy"
+	exit 1
+fi
+`, stamp))
+	defer cmd.Restore()
+	restore = apparmor.MockParserSearchPath(cmd.BinDir())
+	defer restore()
+	err := apparmor.LoadProfiles([]string{"/path/to/snap.samba.smbd"}, apparmor.CacheDir, 0)
+	c.Assert(err, IsNil)
+	call := []string{"apparmor_parser", "--replace", "--write-cache", fmt.Sprintf("--cache-loc=%s/var/cache/apparmor", dirs.GlobalRootDir), "--quiet", "/path/to/snap.samba.smbd"}
+	c.Assert(cmd.Calls(), DeepEquals, [][]string{call, call})
+	c.Check(delays, DeepEquals, []time.Duration{time.Second})
+}
+
+func (s *appArmorSuite) TestLoadProfilesRetriesTransientErrorsGivesUp(c *C) {
+	dirs.SetRootDir(c.MkDir())
+	defer dirs.SetRootDir("")
+	var delays []time.Duration
+	restore := apparmor.MockLoadProfilesRetry(3, time.Second, func(d time.Duration) {
+		delays = append(delays, d)
+	})
+	defer restore()
+	cmd := testutil.MockCommand(c, "apparmor_parser", `echo "Resource temporarily unavailable"; exit 1`)
+	defer cmd.Restore()
+	restore = apparmor.MockParserSearchPath(cmd.BinDir())
+	defer restore()
+	err := apparmor.LoadProfiles([]string{"/path/to/snap.samba.smbd"}, apparmor.CacheDir, 0)
+	c.Assert(err, ErrorMatches, `cannot load apparmor profiles: exit status 1
+apparmor_parser output:
+Resource temporarily unavailable
+`)
+	c.Assert(cmd.Calls(), HasLen, 3)
+	c.Check(delays, DeepEquals, []time.Duration{time.Second, 2 * time.Second})
+}
+
+func (s *appArmorSuite) TestLoadProfilesDoesNotRetryParserErrors(c *C) {
+	dirs.SetRootDir(c.MkDir())
+	defer dirs.SetRootDir("")
+	restore := apparmor.MockLoadProfilesRetry(3, time.Second, func(d time.Duration) {
+		c.Fatalf("unexpected retry")
+	})
+	defer restore()
+	cmd := testutil.MockCommand(c, "apparmor_parser", `echo "parser error: Resource temporarily unavailable"; exit 1`)
+	defer cmd.Restore()
+	restore = apparmor.MockParserSearchPath(cmd.BinDir())
+	defer restore()
+	err := apparmor.LoadProfiles([]string{"/path/to/snap.samba.smbd"}, apparmor.CacheDir, 0)
+	c.Assert(err, ErrorMatches, `(?s)cannot load apparmor profiles: exit status 1\n.*`)
+	c.Assert(cmd.Calls(), HasLen, 1)
+}
+
 func (s *appArmorSuite) TestLoadProfilesRunsAppArmorParserReplaceWithSnapdDebug(c *C) {
 	os.Setenv("SNAPD_DEBUG", "1")
 	defer os.Unsetenv("SNAPD_DEBUG")
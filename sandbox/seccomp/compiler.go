@@ -234,3 +234,14 @@ func (c *Compiler) Compile(in, out string) error {
 	}
 	return nil
 }
+
+// CompileForArch compiles given source profile for the given dpkg
+// architecture only and saves the result to the out location. The
+// architecture must be either the native one or its compat one.
+func (c *Compiler) CompileForArch(in, out, arch string) error {
+	cmd := exec.Command(c.snapSeccomp, "compile", in, out, arch)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return osutil.OutputErr(output, err)
+	}
+	return nil
+}
@@ -161,6 +161,26 @@ exit 1
 	})
 }
 
+func (s *compilerSuite) TestCompileForArch(c *C) {
+	cmd := testutil.MockCommand(c, "snap-seccomp", `
+if [ "$1" = "compile" ] && [ "$4" = "i386" ]; then exit 0; fi
+echo "error: cannot compile for architecture \"$4\" on this host"
+exit 1
+`)
+	defer cmd.Restore()
+	compiler, err := seccomp.NewCompiler(fromCmd(c, cmd))
+	c.Assert(err, IsNil)
+
+	err = compiler.CompileForArch("foo.src", "foo.bin", "i386")
+	c.Assert(err, IsNil)
+	err = compiler.CompileForArch("foo.src", "foo.bin", "s390x")
+	c.Assert(err, ErrorMatches, `error: cannot compile for architecture "s390x" on this host`)
+	c.Check(cmd.Calls(), DeepEquals, [][]string{
+		{"snap-seccomp", "compile", "foo.src", "foo.bin", "i386"},
+		{"snap-seccomp", "compile", "foo.src", "foo.bin", "s390x"},
+	})
+}
+
 func (s *compilerSuite) TestCompileUnhappy(c *C) {
 	cmd := testutil.MockCommand(c, "snap-seccomp", `
 if [ "$1" = "compile" ]; then echo "i will not"; exit 1; fi